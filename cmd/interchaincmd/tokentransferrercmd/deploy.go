@@ -9,6 +9,7 @@ import (
 	cmdflags "github.com/ava-labs/avalanche-cli/cmd/flags"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
 	"github.com/ava-labs/avalanche-cli/pkg/ictt"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
@@ -20,6 +21,20 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// tooling setup and contract calls made by deploy, replaced in tests
+var (
+	foundryIsInstalled       = ictt.FoundryIsInstalled
+	installFoundry           = ictt.InstallFoundry
+	downloadRepo             = ictt.DownloadRepo
+	buildContracts           = ictt.BuildContracts
+	getSubnetParams          = teleporter.GetSubnetParams
+	deployWrappedNativeToken = ictt.DeployWrappedNativeToken
+	getTokenParams           = ictt.GetTokenParams
+	deployNativeHome         = ictt.DeployNativeHome
+	deployERC20Remote        = ictt.DeployERC20Remote
+	registerERC20Remote      = ictt.RegisterERC20Remote
+)
+
 type HomeFlags struct {
 	chainFlags   contract.ChainFlags
 	homeAddress  string
@@ -79,8 +94,8 @@ func deploy(_ *cobra.Command, args []string) error {
 }
 
 func CallDeploy(_ []string, flags DeployFlags) error {
-	if !ictt.FoundryIsInstalled() {
-		if err := ictt.InstallFoundry(); err != nil {
+	if !foundryIsInstalled() {
+		if err := installFoundry(); err != nil {
 			return err
 		}
 	}
@@ -305,11 +320,11 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 
 	// Setup Contracts
 	ux.Logger.PrintToUser("Downloading Avalanche InterChain Token Transfer Contracts")
-	if err := ictt.DownloadRepo(app, flags.version); err != nil {
+	if err := downloadRepo(app, flags.version); err != nil {
 		return err
 	}
 	ux.Logger.PrintToUser("Compiling Avalanche InterChain Token Transfer")
-	if err := ictt.BuildContracts(app); err != nil {
+	if err := buildContracts(app); err != nil {
 		return err
 	}
 	ux.Logger.PrintToUser("")
@@ -327,7 +342,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		tokenAddress  common.Address
 	)
 	// TODO: need registry address, manager address, private key for the home chain (academy for fuji)
-	homeEndpoint, _, homeBlockchainID, _, homeRegistryAddress, homeKey, err := teleporter.GetSubnetParams(
+	homeEndpoint, _, homeBlockchainID, _, homeRegistryAddress, homeKey, err := getSubnetParams(
 		app,
 		network,
 		flags.homeFlags.chainFlags.SubnetName,
@@ -336,6 +351,10 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 	if err != nil {
 		return err
 	}
+	homeSigner, err := evm.NewSignerFromPrivateKey(homeKey.PrivKeyHex())
+	if err != nil {
+		return err
+	}
 	if flags.homeFlags.homeAddress != "" {
 		homeAddress = common.HexToAddress(flags.homeFlags.homeAddress)
 		endpointKind, err := ictt.GetEndpointKind(homeEndpoint, homeAddress)
@@ -356,7 +375,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		default:
 			return fmt.Errorf("unsupported ictt endpoint kind %d", endpointKind)
 		}
-		tokenSymbol, tokenName, tokenDecimals, err = ictt.GetTokenParams(
+		tokenSymbol, tokenName, tokenDecimals, err = getTokenParams(
 			homeEndpoint,
 			tokenAddress.Hex(),
 		)
@@ -366,7 +385,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 	}
 	if flags.homeFlags.erc20Address != "" {
 		tokenAddress = common.HexToAddress(flags.homeFlags.erc20Address)
-		tokenSymbol, tokenName, tokenDecimals, err = ictt.GetTokenParams(
+		tokenSymbol, tokenName, tokenDecimals, err = getTokenParams(
 			homeEndpoint,
 			tokenAddress.Hex(),
 		)
@@ -376,7 +395,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		homeAddress, err = ictt.DeployERC20Home(
 			icttSrcDir,
 			homeEndpoint,
-			homeKey.PrivKeyHex(),
+			homeSigner,
 			common.HexToAddress(homeRegistryAddress),
 			common.HexToAddress(homeKey.C()),
 			tokenAddress,
@@ -397,43 +416,21 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		if err != nil {
 			return err
 		}
-		wrappedNativeTokenAddress, err := ictt.DeployWrappedNativeToken(
+		homeAddress, tokenSymbol, tokenName, tokenDecimals, err = deployNativeTokenHome(
 			icttSrcDir,
 			homeEndpoint,
-			homeKey.PrivKeyHex(),
-			nativeTokenSymbol,
-		)
-		if err != nil {
-			return err
-		}
-		tokenSymbol, tokenName, tokenDecimals, err = ictt.GetTokenParams(
-			homeEndpoint,
-			wrappedNativeTokenAddress.Hex(),
-		)
-		if err != nil {
-			return err
-		}
-		ux.Logger.PrintToUser("Wrapped Native Token Deployed to %s", homeEndpoint)
-		ux.Logger.PrintToUser("%s Address: %s", tokenSymbol, wrappedNativeTokenAddress)
-		ux.Logger.PrintToUser("")
-		homeAddress, err = ictt.DeployNativeHome(
-			icttSrcDir,
-			homeEndpoint,
-			homeKey.PrivKeyHex(),
+			homeSigner,
 			common.HexToAddress(homeRegistryAddress),
 			common.HexToAddress(homeKey.C()),
-			wrappedNativeTokenAddress,
+			nativeTokenSymbol,
 		)
 		if err != nil {
 			return err
 		}
-		ux.Logger.PrintToUser("Home Deployed to %s", homeEndpoint)
-		ux.Logger.PrintToUser("Home Address: %s", homeAddress)
-		ux.Logger.PrintToUser("")
 	}
 
 	// Remote Deploy
-	remoteEndpoint, _, _, _, remoteRegistryAddress, remoteKey, err := teleporter.GetSubnetParams(
+	remoteEndpoint, _, _, _, remoteRegistryAddress, remoteKey, err := getSubnetParams(
 		app,
 		network,
 		flags.remoteFlags.SubnetName,
@@ -442,11 +439,15 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 	if err != nil {
 		return err
 	}
+	remoteSigner, err := evm.NewSignerFromPrivateKey(remoteKey.PrivKeyHex())
+	if err != nil {
+		return err
+	}
 
-	remoteAddress, err := ictt.DeployERC20Remote(
+	remoteAddress, err := deployERC20Remote(
 		icttSrcDir,
 		remoteEndpoint,
-		remoteKey.PrivKeyHex(),
+		remoteSigner,
 		common.HexToAddress(remoteRegistryAddress),
 		common.HexToAddress(remoteKey.C()),
 		homeBlockchainID,
@@ -459,9 +460,9 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		return err
 	}
 
-	if err := ictt.RegisterERC20Remote(
+	if err := registerERC20Remote(
 		remoteEndpoint,
-		remoteKey.PrivKeyHex(),
+		remoteSigner,
 		remoteAddress,
 	); err != nil {
 		return err
@@ -472,3 +473,50 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 
 	return nil
 }
+
+// deployNativeTokenHome deploys a wrapped native token and its home endpoint, and returns
+// the home address together with the symbol, name and decimals of the wrapped token read
+// on chain, to be used by the remote endpoint
+func deployNativeTokenHome(
+	icttSrcDir string,
+	homeEndpoint string,
+	homeSigner *evm.Signer,
+	homeRegistryAddress common.Address,
+	homeManagerAddress common.Address,
+	nativeTokenSymbol string,
+) (common.Address, string, string, uint8, error) {
+	wrappedNativeTokenAddress, err := deployWrappedNativeToken(
+		icttSrcDir,
+		homeEndpoint,
+		homeSigner,
+		nativeTokenSymbol,
+	)
+	if err != nil {
+		return common.Address{}, "", "", 0, err
+	}
+	tokenSymbol, tokenName, tokenDecimals, err := getTokenParams(
+		homeEndpoint,
+		wrappedNativeTokenAddress.Hex(),
+	)
+	if err != nil {
+		return common.Address{}, "", "", 0, err
+	}
+	ux.Logger.PrintToUser("Wrapped Native Token Deployed to %s", homeEndpoint)
+	ux.Logger.PrintToUser("%s Address: %s", tokenSymbol, wrappedNativeTokenAddress)
+	ux.Logger.PrintToUser("")
+	homeAddress, err := deployNativeHome(
+		icttSrcDir,
+		homeEndpoint,
+		homeSigner,
+		homeRegistryAddress,
+		homeManagerAddress,
+		wrappedNativeTokenAddress,
+	)
+	if err != nil {
+		return common.Address{}, "", "", 0, err
+	}
+	ux.Logger.PrintToUser("Home Deployed to %s", homeEndpoint)
+	ux.Logger.PrintToUser("Home Address: %s", homeAddress)
+	ux.Logger.PrintToUser("")
+	return homeAddress, tokenSymbol, tokenName, tokenDecimals, nil
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package tokentransferrercmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/ictt"
+	"github.com/ava-labs/avalanche-cli/pkg/key"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployNativeHomeUsesOnChainDecimals(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	defer func() {
+		app = nil
+		foundryIsInstalled = ictt.FoundryIsInstalled
+		installFoundry = ictt.InstallFoundry
+		downloadRepo = ictt.DownloadRepo
+		buildContracts = ictt.BuildContracts
+		getSubnetParams = teleporter.GetSubnetParams
+		deployWrappedNativeToken = ictt.DeployWrappedNativeToken
+		getTokenParams = ictt.GetTokenParams
+		deployNativeHome = ictt.DeployNativeHome
+		deployERC20Remote = ictt.DeployERC20Remote
+		registerERC20Remote = ictt.RegisterERC20Remote
+	}()
+
+	network := models.NewLocalNetwork()
+	homeBlockchainID := ids.GenerateTestID()
+	for _, sc := range []*models.Sidecar{
+		{Name: "home", TokenName: "TEST Token", TokenSymbol: "TEST", Networks: map[string]models.NetworkData{network.Name(): {BlockchainID: homeBlockchainID}}},
+		{Name: "remote", TokenName: "REM Token", TokenSymbol: "REM", Networks: map[string]models.NetworkData{network.Name(): {BlockchainID: ids.GenerateTestID()}}},
+	} {
+		require.NoError(app.CreateSidecar(sc))
+	}
+	k, err := key.NewSoft(network.ID)
+	require.NoError(err)
+
+	foundryIsInstalled = func() bool { return true }
+	downloadRepo = func(*application.Avalanche, string) error { return nil }
+	buildContracts = func(*application.Avalanche) error { return nil }
+	getSubnetParams = func(_ *application.Avalanche, _ models.Network, subnetName string, _ bool) (string, ids.ID, ids.ID, string, string, *key.SoftKey, error) {
+		blockchainID := ids.GenerateTestID()
+		if subnetName == "home" {
+			blockchainID = homeBlockchainID
+		}
+		return "http://" + subnetName, ids.Empty, blockchainID, "", common.Address{}.Hex(), k, nil
+	}
+	wrappedAddress := common.HexToAddress("0x1")
+	homeAddress := common.HexToAddress("0x2")
+	remoteAddress := common.HexToAddress("0x3")
+	deployWrappedNativeToken = func(_, rpcURL string, signer *evm.Signer, tokenSymbol string) (common.Address, error) {
+		require.Equal("http://home", rpcURL)
+		require.Equal(common.HexToAddress(k.C()), signer.Address())
+		require.Equal("TEST", tokenSymbol)
+		return wrappedAddress, nil
+	}
+	getTokenParams = func(_, tokenAddress string) (string, string, uint8, error) {
+		require.Equal(wrappedAddress.Hex(), tokenAddress)
+		return "WTEST", "Wrapped TEST", 18, nil
+	}
+	deployNativeHome = func(_, _ string, _ *evm.Signer, _, _, wrapped common.Address) (common.Address, error) {
+		require.Equal(wrappedAddress, wrapped)
+		return homeAddress, nil
+	}
+	var (
+		remoteHome     common.Address
+		remoteDecimals uint8
+		remoteSymbol   string
+	)
+	deployERC20Remote = func(
+		_, rpcURL string,
+		signer *evm.Signer,
+		_, _ common.Address,
+		blockchainID [32]byte,
+		home common.Address,
+		_, tokenSymbol string,
+		tokenDecimals uint8,
+	) (common.Address, error) {
+		require.Equal("http://remote", rpcURL)
+		require.Equal(common.HexToAddress(k.C()), signer.Address())
+		require.Equal([32]byte(homeBlockchainID), blockchainID)
+		remoteHome = home
+		remoteSymbol = tokenSymbol
+		remoteDecimals = tokenDecimals
+		return remoteAddress, nil
+	}
+	registered := false
+	registerERC20Remote = func(_ string, _ *evm.Signer, address common.Address) error {
+		require.Equal(remoteAddress, address)
+		registered = true
+		return nil
+	}
+
+	require.NoError(CallDeploy(nil, DeployFlags{
+		Network: networkoptions.NetworkFlags{UseLocal: true},
+		homeFlags: HomeFlags{
+			chainFlags: contract.ChainFlags{SubnetName: "home"},
+			native:     true,
+		},
+		remoteFlags: contract.ChainFlags{SubnetName: "remote"},
+	}))
+	// the remote represents the wrapped native token deployed on the home chain
+	require.Equal(homeAddress, remoteHome)
+	require.Equal("WTEST", remoteSymbol)
+	require.Equal(uint8(18), remoteDecimals)
+	require.True(registered)
+}
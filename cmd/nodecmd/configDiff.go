@@ -0,0 +1,130 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/remoteconfig"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configDiffNodes []string
+	configDiffFix   bool
+)
+
+func newConfigDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config-diff [clusterName]",
+		Short: "(ALPHA Warning) Show the drift between the expected and the actual AvalancheGo node configs of a cluster",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node config-diff command renders the AvalancheGo node config that the CLI
+expects for each node of a cluster, including the node configs of the
+subnets it validates, downloads the node config actually present
+on the node, and prints the keys that differ, for example after a manual edit.
+
+Bootstrap settings and log level are taken from the node config, as the CLI
+preserves them. With --fix, the expected node config is uploaded to the nodes
+that drifted, and they are restarted.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: configDiff,
+	}
+	cmd.Flags().StringSliceVar(&configDiffNodes, "node", []string{}, "compare config only of given comma separated list of nodes (node IDs, cloud IDs or IPs). defaults to all cluster nodes")
+	cmd.Flags().BoolVar(&configDiffFix, "fix", false, "upload the expected node config to the nodes that drifted, and restart them")
+	return cmd
+}
+
+func configDiff(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	if err := checkCluster(clusterName); err != nil {
+		return err
+	}
+	clusterConfig, err := app.GetClusterConfig(clusterName)
+	if err != nil {
+		return err
+	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	if len(configDiffNodes) != 0 {
+		hosts, err = filterHosts(hosts, configDiffNodes)
+		if err != nil {
+			return err
+		}
+	}
+	defer disconnectHosts(hosts)
+
+	spinSession := ux.NewUserSpinner()
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, "Compare Node Config"))
+			diffs, err := ssh.RunSSHDiffAvalancheNodeConfig(app, host, clusterConfig.Network, clusterConfig.Subnets)
+			if err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				ux.SpinFailWithError(spinner, "", err)
+				return
+			}
+			if len(diffs) > 0 && configDiffFix {
+				if err := ssh.RunSSHRenderAvalancheNodeConfig(app, host, clusterConfig.Network, clusterConfig.Subnets); err != nil {
+					nodeResults.AddResult(host.NodeID, nil, err)
+					ux.SpinFailWithError(spinner, "", err)
+					return
+				}
+				if err := ssh.RunSSHRestartNode(host); err != nil {
+					nodeResults.AddResult(host.NodeID, nil, err)
+					ux.SpinFailWithError(spinner, "", err)
+					return
+				}
+			}
+			nodeResults.AddResult(host.NodeID, diffs, nil)
+			ux.SpinComplete(spinner)
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	spinSession.Stop()
+	if wgResults.HasErrors() {
+		return fmt.Errorf("failed to compare node config of node(s) %s", wgResults.GetErrorHostMap())
+	}
+	driftedNodes := printConfigDiffs(hosts, wgResults.GetResultMap())
+	switch {
+	case driftedNodes == 0:
+		ux.Logger.GreenCheckmarkToUser("Node configs of cluster %s match the expected ones", clusterName)
+	case configDiffFix:
+		ux.Logger.GreenCheckmarkToUser("Expected node config uploaded to %d node(s) of cluster %s", driftedNodes, clusterName)
+	default:
+		ux.Logger.PrintToUser(logging.Yellow.Wrap("%d node(s) of cluster %s drifted from the expected node config. Use --fix to restore it"), driftedNodes, clusterName)
+	}
+	return nil
+}
+
+// printConfigDiffs prints the config diffs of each host in [diffsMap], and returns the
+// number of hosts whose config differs
+func printConfigDiffs(hosts []*models.Host, diffsMap map[string]interface{}) int {
+	driftedNodes := 0
+	for _, host := range hosts {
+		diffs, ok := diffsMap[host.NodeID].([]remoteconfig.ConfigDiff)
+		if !ok || len(diffs) == 0 {
+			continue
+		}
+		driftedNodes++
+		ux.Logger.PrintToUser("Node %s (%s): %d key(s) differ", host.GetCloudID(), host.IP, len(diffs))
+		for _, diff := range diffs {
+			ux.Logger.PrintToUser("  %s", diff)
+		}
+	}
+	return driftedNodes
+}
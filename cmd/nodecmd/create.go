@@ -3,8 +3,11 @@
 package nodecmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"net"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -15,6 +18,7 @@ import (
 	"time"
 
 	awsAPI "github.com/ava-labs/avalanche-cli/pkg/cloud/aws"
+	gcpAPI "github.com/ava-labs/avalanche-cli/pkg/cloud/gcp"
 	"github.com/ava-labs/avalanche-cli/pkg/docker"
 
 	"github.com/ava-labs/avalanche-cli/pkg/metrics"
@@ -26,11 +30,15 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/monitoring"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/remoteconfig"
 	"github.com/ava-labs/avalanche-cli/pkg/ssh"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/avalanche-cli/pkg/wireguard"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -38,10 +46,17 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
+	"golang.org/x/mod/semver"
 )
 
 const (
 	enableMonitoringFlag = "enable-monitoring"
+	cpusFlag             = "cpus"
+	memoryGBFlag         = "memory-gb"
+	pruningEnabledFlag   = "pruning-enabled"
+	stateSyncEnabledFlag = "state-sync-enabled"
+	// latestNodeVersion stands for the latest AvalancheGo release in --node-version
+	latestNodeVersion = "latest"
 )
 
 var (
@@ -64,6 +79,7 @@ var (
 	cmdLineAlternativeKeyPairName         string
 	addMonitoring                         bool
 	useSSHAgent                           bool
+	noSSHAgent                            bool
 	sshIdentity                           string
 	numAPINodes                           []int
 	throughput                            int
@@ -73,8 +89,57 @@ var (
 	versionComments                       = map[string]string{
 		"v1.11.0-fuji": " (recommended for fuji durango)",
 	}
-	grafanaPkg string
-	wizSubnet  string
+	grafanaPkg         string
+	wizSubnet          string
+	waitHealthy        bool
+	waitHealthyTimeout time.Duration
+	waitHealthyPoll    time.Duration
+	httpPort           uint = constants.AvalanchegoAPIPort
+	stakingPort        uint = constants.AvalanchegoP2PPort
+	sshCIDRs           []string
+	allowPublicSSH     bool
+	logLevel           string
+	serviceEnvEntries  []string
+	customComposeFile  string
+	setupParallelism   int
+	userIPVersion      string
+	serviceEnv         map[string]map[string]string
+	amiEntries         []string
+	amiOverrides       map[string]string
+	tagEntries         []string
+	instanceTags       map[string]string
+	nodeVersionEntries []string
+	nodeVersions       map[int]string
+	awsVPCID           string
+	parallelRegions    bool
+	partialOK          bool
+	sshUser            string
+	sshKeyAlgorithm    string
+	awsSubnetID        string
+	provisionTimeout   time.Duration
+	skipChecksum       bool
+	skipRegionCheck    bool
+	reuseEIPs          bool
+	customMachineType  bool
+	customCPUs         int
+	customMemoryGB     float64
+	pruningEnabled     bool
+	stateSyncEnabled   bool
+	cChainDBConfig     remoteconfig.CChainDBConfig
+	customBootstrapIDs []string
+	customBootstrapIPs []string
+	bootstrapPeers     remoteconfig.BootstrapPeers
+	bootstrapGenesis   string
+	joinGenesis        []byte
+	joinNetworkID      uint32
+	meshMode           string
+	dnsZone            string
+	dnsPrefix          string
+	remoteWriteURL     string
+	remoteWriteUser    string
+	remoteWritePass    string
+	remoteWriteToken   string
+	remoteWrite        *monitoring.RemoteWriteConfig
 )
 
 func newCreateCmd() *cobra.Command {
@@ -101,9 +166,10 @@ will apply to all nodes in the cluster`,
 	}
 	networkoptions.AddNetworkFlagsToCmd(cmd, &globalNetworkFlags, false, createSupportedNetworkOptions)
 	cmd.Flags().BoolVar(&useStaticIP, "use-static-ip", true, "attach static Public IP on cloud servers")
+	cmd.Flags().BoolVar(&reuseEIPs, "reuse-eips", false, "on AWS, attach elastic IPs previously allocated by avalanche-cli that are not in use before allocating new ones. reused IPs are released when the nodes are destroyed")
 	cmd.Flags().BoolVar(&useAWS, "aws", false, "create node/s in AWS cloud")
 	cmd.Flags().BoolVar(&useGCP, "gcp", false, "create node/s in GCP cloud")
-	cmd.Flags().StringSliceVar(&cmdLineRegion, "region", []string{}, "create node(s) in given region(s). Use comma to separate multiple regions")
+	cmd.Flags().StringSliceVar(&cmdLineRegion, "region", []string{}, "create node(s) in given region(s). For GCP, zones (e.g. us-east1-b) are also accepted. Use comma to separate multiple regions")
 	cmd.Flags().BoolVar(&authorizeAccess, "authorize-access", false, "authorize CLI to create cloud resources")
 	cmd.Flags().IntSliceVar(&numValidatorsNodes, "num-validators", []int{}, "number of nodes to create per region(s). Use comma to separate multiple numbers for each region in the same order as --region flag")
 	cmd.Flags().StringVar(&nodeType, "node-type", "", "cloud instance type. Use 'default' to use recommended default instance type")
@@ -116,6 +182,7 @@ will apply to all nodes in the cluster`,
 	cmd.Flags().StringVar(&cmdLineAlternativeKeyPairName, "alternative-key-pair-name", "", "key pair name to use if default one generates conflicts")
 	cmd.Flags().StringVar(&awsProfile, "aws-profile", constants.AWSDefaultCredential, "aws profile to use")
 	cmd.Flags().BoolVar(&useSSHAgent, "use-ssh-agent", false, "use ssh agent(ex: Yubikey) for ssh auth")
+	cmd.Flags().BoolVar(&noSSHAgent, "no-ssh-agent", false, "do not add downloaded key pair files to ssh agent, use them directly for ssh auth")
 	cmd.Flags().StringVar(&sshIdentity, "ssh-agent-identity", "", "use given ssh identity(only for ssh agent). If not set, default will be used")
 	cmd.Flags().BoolVar(&addMonitoring, enableMonitoringFlag, false, "set up Prometheus monitoring for created nodes. This option creates a separate monitoring cloud instance and incures additional cost")
 	cmd.Flags().StringVar(&grafanaPkg, "grafana-pkg", "", "use grafana pkg instead of apt repo(by default), for example https://dl.grafana.com/oss/release/grafana_10.4.1_amd64.deb")
@@ -126,13 +193,99 @@ will apply to all nodes in the cluster`,
 	cmd.Flags().StringVar(&volumeType, "aws-volume-type", "gp3", "AWS volume type")
 	cmd.Flags().IntVar(&volumeSize, "aws-volume-size", constants.CloudServerStorageSize, "AWS volume size in GB")
 	cmd.Flags().BoolVar(&replaceKeyPair, "auto-replace-keypair", false, "automatically replaces key pair to access node if previous key pair is not found")
+	cmd.Flags().UintVar(&httpPort, "http-port", constants.AvalanchegoAPIPort, "avalanchego HTTP port to use on created node(s)")
+	cmd.Flags().UintVar(&stakingPort, "staking-port", constants.AvalanchegoP2PPort, "avalanchego staking (P2P) port to use on created node(s)")
+	cmd.Flags().StringSliceVar(&sshCIDRs, "ssh-cidr", []string{}, "CIDR(s) allowed to access node(s) via ssh and http, instead of current IP. Use comma to separate multiple CIDRs")
+	cmd.Flags().StringVar(&userIPVersion, "ip-version", utils.IPVersionAuto, "IP version [4, 6] of the user IP address to grant access to created node(s). defaults to IPv4, or IPv6 if there is no IPv4 connectivity")
+	cmd.Flags().BoolVar(&allowPublicSSH, "allow-public-ssh", false, "allow 0.0.0.0/0 to be used in --ssh-cidr")
+	cmd.Flags().IntVar(&setupParallelism, "parallelism", 0, "maximum number of nodes to set up concurrently (default min(nodes, 2*CPUs))")
+	cmd.Flags().StringSliceVar(&amiEntries, "ami", []string{}, "use the given AWS AMIs instead of the default Ubuntu image, as [region=]ami-id (without region, applies to all regions). the image must be Ubuntu based")
+	cmd.Flags().StringArrayVar(&nodeVersionEntries, "node-version", []string{}, "run the given AvalancheGo version on a node instead of the cluster one, as nodeIndex=version, e.g. for canary testing. nodes are indexed from 0 in creation order, by region in alphabetical order. version is a semantic version or latest. can be repeated")
+	cmd.Flags().StringArrayVar(&tagEntries, "tags", []string{}, "add the given tag (AWS) or label (GCP) to created cloud server(s), as key=value. can be repeated")
+	cmd.Flags().StringVar(&sshKeyAlgorithm, "ssh-key-algorithm", awsAPI.SSHKeyAlgorithmRSA, "algorithm of the AWS key pair created to access node(s) [rsa, ecdsa, ed25519]")
+	cmd.Flags().BoolVar(&parallelRegions, "parallel-regions", false, "create the AWS instances of different regions concurrently, instead of one region after the other")
+	cmd.Flags().StringVar(&sshUser, "ssh-user", constants.AnsibleSSHUser, "remote user to connect as over SSH, for AWS AMIs that don't use the ubuntu user")
+	cmd.Flags().BoolVar(&partialOK, "partial-ok", false, "if the AWS instances of some regions can't be created, keep and set up the nodes of the other regions instead of destroying all of them")
+	cmd.Flags().StringVar(&awsVPCID, "aws-vpc-id", "", "create node(s) in the given AWS VPC instead of the default one (requires --aws-subnet-id and a single region)")
+	cmd.Flags().StringVar(&awsSubnetID, "aws-subnet-id", "", "create node(s) in the given AWS VPC subnet (requires --aws-vpc-id). the subnet must be reachable from the internet")
+	cmd.Flags().StringVar(&customComposeFile, "compose-file", "", "(advanced, unsupported) use the given docker compose file for the node(s) instead of the generated one. it must define the avalanchego service")
+	cmd.Flags().StringArrayVar(&serviceEnvEntries, "env", []string{}, "set environment variable on a node docker service, as [service:]KEY=VALUE (service defaults to avalanchego). can be repeated")
+	cmd.Flags().BoolVar(&pruningEnabled, pruningEnabledFlag, false, "enable C-Chain state pruning on created node(s). disable it explicitly for archival nodes")
+	cmd.Flags().BoolVar(&stateSyncEnabled, stateSyncEnabledFlag, true, "enable C-Chain state sync on created node(s)")
+	cmd.Flags().StringVar(&logLevel, "log-level", "", "avalanchego log level to use on created node(s) [off, fatal, error, warn, info, trace, debug, verbo]")
+	cmd.Flags().BoolVar(&waitHealthy, "wait-healthy", false, "wait for created node(s) to be bootstrapped and healthy before finishing")
+	cmd.Flags().BoolVar(&customMachineType, "custom-machine-type", false, "use a GCP custom machine type built from --cpus and --memory-gb instead of --node-type")
+	cmd.Flags().IntVar(&customCPUs, cpusFlag, 0, "number of vCPUs of the GCP custom machine type (1 or an even number)")
+	cmd.Flags().Float64Var(&customMemoryGB, memoryGBFlag, 0, "memory in GB of the GCP custom machine type (multiple of 256MB, between 0.9GB and 6.5GB per vCPU)")
+	cmd.Flags().StringSliceVar(&customBootstrapIDs, "bootstrap-ids", []string{}, "join an existing devnet by bootstrapping from the given comma separated NodeIDs (requires --bootstrap-ips, in the same order)")
+	cmd.Flags().StringVar(&bootstrapGenesis, "bootstrap-genesis", "", "genesis file of the existing devnet to join (requires --bootstrap-ids and --bootstrap-ips)")
+	cmd.Flags().StringSliceVar(&customBootstrapIPs, "bootstrap-ips", []string{}, "join an existing devnet by bootstrapping from the given comma separated ip:port staking addresses (requires --bootstrap-ids)")
+	cmd.Flags().StringVar(&dnsZone, "dns-zone", "", "create a DNS A record for each node pointing at its static IP, in the given Route53 hosted zone ID (AWS) or Cloud DNS managed zone (GCP)")
+	cmd.Flags().StringVar(&dnsPrefix, "dns-prefix", "", "prefix of the node DNS names, as <prefix>-<instance id>.<zone domain> (requires --dns-zone). defaults to the cluster name")
+	cmd.Flags().StringVar(&remoteWriteURL, "prometheus-remote-write-url", "", "make the new monitoring instance prometheus also remote write metrics to the given http(s) URL, e.g. a central Grafana Cloud or Mimir endpoint (requires --enable-monitoring)")
+	cmd.Flags().StringVar(&remoteWriteUser, "prometheus-remote-write-username", "", "basic auth username of the prometheus remote write URL (requires --prometheus-remote-write-password)")
+	cmd.Flags().StringVar(&remoteWritePass, "prometheus-remote-write-password", "", "basic auth password of the prometheus remote write URL")
+	cmd.Flags().StringVar(&remoteWriteToken, "prometheus-remote-write-token", "", "bearer token of the prometheus remote write URL (instead of basic auth)")
+	cmd.Flags().StringVar(&meshMode, "mesh", "", "connect the Devnet node(s) through a private mesh network, and use it for node to node traffic [wireguard]")
+	cmd.Flags().BoolVar(&skipRegionCheck, "skip-region-check", false, "do not check AWS regions and GCP zones against the list of known ones, e.g. for recently launched regions")
+	cmd.Flags().BoolVar(&skipChecksum, "skip-checksum", false, "do not verify the AvalancheGo docker image against its published checksum, e.g. when using a registry mirror")
+	cmd.Flags().DurationVar(&provisionTimeout, "provision-timeout", constants.SSHServerStartTimeout, "maximum time to wait for created cloud server(s) to accept SSH connections")
+	cmd.Flags().DurationVar(&waitHealthyTimeout, "wait-healthy-timeout", constants.NodeWaitHealthyTimeout, "maximum time to wait for node(s) to become healthy (only with --wait-healthy)")
+	cmd.Flags().DurationVar(&waitHealthyPoll, "wait-healthy-interval", constants.NodeWaitHealthyPollInterval, "interval between node health checks (only with --wait-healthy)")
 	return cmd
 }
 
+// validateAvalancheGoPorts checks that the given avalanchego ports are valid and don't
+// collide with each other or with other ports used on the cloud server
+func validateAvalancheGoPorts(httpPort, stakingPort uint) error {
+	reservedPorts := []uint{
+		constants.SSHTCPPort,
+		constants.AvalanchegoMonitoringPort,
+		constants.AvalanchegoGrafanaPort,
+		constants.AvalanchegoLokiPort,
+		constants.AvalanchegoMachineMetricsPort,
+	}
+	for _, port := range []uint{httpPort, stakingPort} {
+		if port == 0 || port > math.MaxUint16 {
+			return fmt.Errorf("invalid port %d: must be between 1 and %d", port, math.MaxUint16)
+		}
+		if slices.Contains(reservedPorts, port) {
+			return fmt.Errorf("port %d is reserved for other services on the node", port)
+		}
+	}
+	if httpPort == stakingPort {
+		return fmt.Errorf("http port and staking port must be different")
+	}
+	return nil
+}
+
+// validateSSHCIDRs checks that all [cidrs] are valid CIDRs. 0.0.0.0/0 is only
+// accepted if [allowPublic] is set
+func validateSSHCIDRs(cidrs []string, allowPublic bool) error {
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid ssh CIDR %s: %w", cidr, err)
+		}
+		if ones, _ := ipNet.Mask.Size(); ones == 0 && !allowPublic {
+			return fmt.Errorf("ssh CIDR %s opens ssh access to everyone, use --allow-public-ssh if this is intended", cidr)
+		}
+	}
+	return nil
+}
+
+// customPort returns [port] if it differs from [defaultPort], and 0 otherwise
+func customPort(port uint, defaultPort uint) uint {
+	if port == defaultPort {
+		return 0
+	}
+	return port
+}
+
 // override postrun function from root.go, so that we don't double send metrics for the same command
 func handlePostRun(_ *cobra.Command, _ []string) {}
 
-func preCreateChecks(clusterName string) error {
+func preCreateChecks(cmd *cobra.Command, clusterName string) error {
 	if !flags.EnsureMutuallyExclusive([]bool{useLatestAvalanchegoReleaseVersion, useLatestAvalanchegoPreReleaseVersion, useAvalanchegoVersionFromSubnet != "", useCustomAvalanchegoVersion != ""}) {
 		return fmt.Errorf("latest avalanchego released version, latest avalanchego pre-released version, custom avalanchego version and avalanchego version based on given subnet, are mutually exclusive options")
 	}
@@ -142,6 +295,45 @@ func preCreateChecks(clusterName string) error {
 	if !useAWS && awsProfile != constants.AWSDefaultCredential {
 		return fmt.Errorf("could not use AWS profile for non AWS cloud option")
 	}
+	if !useAWS && len(amiEntries) > 0 {
+		return fmt.Errorf("could not use AMI for non AWS cloud option")
+	}
+	if !useAWS && parallelRegions {
+		return fmt.Errorf("could not use parallel regions for non AWS cloud option")
+	}
+	if !useAWS && sshUser != constants.AnsibleSSHUser {
+		return fmt.Errorf("could not use --ssh-user for non AWS cloud option")
+	}
+	if sshUser == "" || strings.ContainsAny(sshUser, " /:@") {
+		return fmt.Errorf("invalid ssh user %q", sshUser)
+	}
+	if !useAWS && partialOK {
+		return fmt.Errorf("could not use --partial-ok for non AWS cloud option")
+	}
+	if partialOK && len(nodeVersionEntries) > 0 {
+		return fmt.Errorf("could not use --node-version with --partial-ok, as node indexes depend on all regions being created")
+	}
+	if err := awsAPI.ValidateSSHKeyAlgorithm(sshKeyAlgorithm); err != nil {
+		return err
+	}
+	if !useAWS && sshKeyAlgorithm != awsAPI.SSHKeyAlgorithmRSA {
+		return fmt.Errorf("could not use ssh key algorithm %s for non AWS cloud option", sshKeyAlgorithm)
+	}
+	if useSSHAgent && cmd.Flags().Changed("ssh-key-algorithm") {
+		return fmt.Errorf("could not use --ssh-key-algorithm with ssh agent, the ssh agent identity is used instead")
+	}
+	if !useAWS && (awsVPCID != "" || awsSubnetID != "") {
+		return fmt.Errorf("could not use AWS VPC for non AWS cloud option")
+	}
+	if reuseEIPs && (!useAWS || !useStaticIP) {
+		return fmt.Errorf("--reuse-eips can only be used for AWS nodes with static IPs")
+	}
+	if customMachineType && !useGCP {
+		return fmt.Errorf("could not use custom machine type for non GCP cloud option")
+	}
+	if !customMachineType && (cmd.Flags().Changed(cpusFlag) || cmd.Flags().Changed(memoryGBFlag)) {
+		return fmt.Errorf("--%s and --%s can only be used with --custom-machine-type", cpusFlag, memoryGBFlag)
+	}
 	if len(utils.Unique(cmdLineRegion)) != len(numValidatorsNodes) {
 		return fmt.Errorf("regions provided is not consistent with number of nodes provided. Please make sure list of regions is unique")
 	}
@@ -193,6 +385,142 @@ func preCreateChecks(clusterName string) error {
 	if grafanaPkg != "" && !addMonitoring {
 		return fmt.Errorf("grafana package can only be used with monitoring setup")
 	}
+	if err := validateAvalancheGoPorts(httpPort, stakingPort); err != nil {
+		return err
+	}
+	if logLevel != "" {
+		if err := remoteconfig.ValidateLogLevel(logLevel); err != nil {
+			return err
+		}
+	}
+	if !cmd.Flags().Changed("provision-timeout") {
+		// the default may have been overridden in the config file after flags were set up
+		provisionTimeout = constants.SSHServerStartTimeout
+	}
+	if provisionTimeout <= 0 {
+		return fmt.Errorf("provision timeout must be greater than 0")
+	}
+	if setupParallelism < 0 {
+		return fmt.Errorf("parallelism must not be negative")
+	}
+	var err error
+	if serviceEnv, err = docker.ParseServiceEnv(serviceEnvEntries); err != nil {
+		return err
+	}
+	if amiOverrides, err = awsAPI.ParseAMIOverrides(amiEntries); err != nil {
+		return err
+	}
+	if instanceTags, err = utils.ParseKeyValues(tagEntries); err != nil {
+		return fmt.Errorf("invalid --tags: %w", err)
+	}
+	if nodeVersions, err = parseNodeVersions(nodeVersionEntries); err != nil {
+		return fmt.Errorf("invalid --node-version: %w", err)
+	}
+	if len(numValidatorsNodes) > 0 {
+		numNodes := 0
+		for _, num := range append(slices.Clone(numValidatorsNodes), numAPINodes...) {
+			numNodes += num
+		}
+		if err := checkNodeVersionIndexes(nodeVersions, numNodes); err != nil {
+			return err
+		}
+	}
+	if err := awsAPI.ValidateVPCPlacement(awsVPCID, awsSubnetID); err != nil {
+		return err
+	}
+	if customMachineType {
+		if nodeType != "" {
+			return fmt.Errorf("could not use both --node-type and --custom-machine-type")
+		}
+		if nodeType, err = gcpAPI.BuildCustomMachineType(customCPUs, customMemoryGB); err != nil {
+			return err
+		}
+	}
+	var requestedPruning, requestedStateSync *bool
+	if cmd.Flags().Changed(pruningEnabledFlag) {
+		requestedPruning = &pruningEnabled
+	}
+	if cmd.Flags().Changed(stateSyncEnabledFlag) {
+		requestedStateSync = &stateSyncEnabled
+	}
+	if cChainDBConfig, err = remoteconfig.NewCChainDBConfig(requestedPruning, requestedStateSync); err != nil {
+		return err
+	}
+	if len(customBootstrapIDs) > 0 || len(customBootstrapIPs) > 0 {
+		if !globalNetworkFlags.UseDevnet {
+			return fmt.Errorf("bootstrap IDs and IPs can only be set in Devnet")
+		}
+		if bootstrapPeers, err = remoteconfig.NewBootstrapPeers(customBootstrapIDs, customBootstrapIPs); err != nil {
+			return err
+		}
+		if bootstrapGenesis == "" {
+			return fmt.Errorf("--bootstrap-genesis is required to join an existing devnet, as nodes must share its genesis and network ID")
+		}
+		if joinGenesis, err = os.ReadFile(bootstrapGenesis); err != nil {
+			return fmt.Errorf("could not read bootstrap genesis: %w", err)
+		}
+		if joinNetworkID, err = remoteconfig.ParseGenesisNetworkID(joinGenesis); err != nil {
+			return err
+		}
+	} else if bootstrapGenesis != "" {
+		return fmt.Errorf("--bootstrap-genesis requires --bootstrap-ids and --bootstrap-ips")
+	}
+	remoteWrite = nil
+	if remoteWriteURL != "" {
+		remoteWrite = &monitoring.RemoteWriteConfig{
+			URL:         remoteWriteURL,
+			Username:    remoteWriteUser,
+			Password:    remoteWritePass,
+			BearerToken: remoteWriteToken,
+		}
+		if err := remoteWrite.Validate(); err != nil {
+			return err
+		}
+	} else if remoteWriteUser != "" || remoteWritePass != "" || remoteWriteToken != "" {
+		return fmt.Errorf("prometheus remote write credentials require --prometheus-remote-write-url")
+	}
+	if meshMode != "" {
+		if meshMode != wireguard.MeshMode {
+			return fmt.Errorf("invalid mesh mode %q, supported modes are [%s]", meshMode, wireguard.MeshMode)
+		}
+		if !globalNetworkFlags.UseDevnet {
+			return fmt.Errorf("mesh can only be set up in Devnet")
+		}
+		if !bootstrapPeers.IsEmpty() {
+			return fmt.Errorf("could not use both --mesh and bootstrap IDs and IPs, as the bootstrap peers are not part of the mesh")
+		}
+	}
+	if !addMonitoring {
+		for service := range serviceEnv {
+			if service != docker.ServiceEnvDefaultService {
+				return fmt.Errorf("env for service %s can only be used with monitoring setup", service)
+			}
+		}
+	}
+	if customComposeFile != "" {
+		if len(serviceEnv) > 0 {
+			return fmt.Errorf("could not use both --env and --compose-file. set the env in the compose file instead")
+		}
+		if err := docker.ValidateCustomComposeFile(customComposeFile); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"WARNING: using custom compose file %s. This is an advanced, unsupported setup: "+
+				"node commands only manage its avalanchego service, and node upgrade merges the generated compose file into it",
+			customComposeFile,
+		)))
+	}
+	if err := validateSSHCIDRs(sshCIDRs, allowPublicSSH); err != nil {
+		return err
+	}
+	if err := utils.ValidateIPVersion(userIPVersion); err != nil {
+		return err
+	}
+	if waitHealthy {
+		if err := validateWaitHealthyFlags(); err != nil {
+			return err
+		}
+	}
 	// check external cluster
 	if err := failForExternal(clusterName); err != nil {
 		return err
@@ -239,7 +567,7 @@ func stringToAWSVolumeType(input string) types.VolumeType {
 
 func createNodes(cmd *cobra.Command, args []string) error {
 	clusterName := args[0]
-	if err := preCreateChecks(clusterName); err != nil {
+	if err := preCreateChecks(cmd, clusterName); err != nil {
 		return err
 	}
 	network, err := networkoptions.GetNetworkFromCmdLineFlags(
@@ -254,6 +582,10 @@ func createNodes(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if !bootstrapPeers.IsEmpty() {
+		// nodes joining an existing devnet must use its network ID
+		network = models.NewDevnetNetwork(network.Endpoint, joinNetworkID)
+	}
 	network = models.NewNetworkFromCluster(network, clusterName)
 
 	globalNetworkFlags.UseDevnet = network.Kind == models.Devnet // set globalNetworkFlags.UseDevnet to true if network is devnet for further use
@@ -261,10 +593,29 @@ func createNodes(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := resolveLatestNodeVersions(nodeVersions); err != nil {
+		return err
+	}
+	// checksums are obtained before creating any cloud server, so it fails fast
+	avalancheGoImageDigests := map[string]string{}
+	if !skipChecksum && !utils.IsE2E() {
+		for _, version := range utils.Unique(append([]string{avalancheGoVersion}, maps.Values(nodeVersions)...)) {
+			avalancheGoImageDigests[version], err = docker.GetPublishedImageDigest(app.Downloader, constants.AvalancheGoDockerImage, version)
+			if err != nil {
+				return fmt.Errorf("%w. Use --skip-checksum to skip AvalancheGo image verification", err)
+			}
+		}
+	}
 	cloudService, err := setCloudService()
 	if err != nil {
 		return err
 	}
+	if err := validateInstanceTags(cloudService, instanceTags); err != nil {
+		return err
+	}
+	if err := validateDNSFlags(cloudService); err != nil {
+		return err
+	}
 	nodeType, err = setCloudInstanceType(cloudService)
 	if err != nil {
 		return err
@@ -292,6 +643,9 @@ func createNodes(cmd *cobra.Command, args []string) error {
 	}
 	monitoringHostRegion := ""
 	monitoringNodeConfig := models.RegionConfig{}
+	// regions that could not be created with --partial-ok, and the nodes requested for them
+	failedRegions := map[string]error{}
+	failedRegionsNumNodes := map[string]NumNodes{}
 	existingMonitoringInstance, err = getExistingMonitoringInstance(clusterName)
 	if err != nil {
 		return err
@@ -301,6 +655,14 @@ func createNodes(cmd *cobra.Command, args []string) error {
 			return err
 		}
 	}
+	if remoteWrite != nil {
+		if existingMonitoringInstance != "" {
+			return fmt.Errorf("prometheus remote write can only be set when the monitoring instance of the cluster is created")
+		}
+		if !addMonitoring {
+			return fmt.Errorf("prometheus remote write requires --%s", enableMonitoringFlag)
+		}
+	}
 	if utils.IsE2E() {
 		usr, err := user.Current()
 		if err != nil {
@@ -400,13 +762,22 @@ func createNodes(cmd *cobra.Command, args []string) error {
 			}
 			numNodesMetricsMap = numNodesMap
 			regions := maps.Keys(ec2SvcMap)
-			if existingMonitoringInstance == "" {
-				monitoringHostRegion = regions[0]
-			}
 			cloudConfigMap, err = createAWSInstances(ec2SvcMap, nodeType, numNodesMap, regions, ami, false)
-			if err != nil {
+			var partialErr *partialRegionsError
+			if errors.As(err, &partialErr) {
+				failedRegions = partialErr.failed
+				numNodesMap = maps.Clone(numNodesMap)
+				for region := range failedRegions {
+					failedRegionsNumNodes[region] = numNodesMap[region]
+					delete(numNodesMap, region)
+				}
+				regions = maps.Keys(numNodesMap)
+			} else if err != nil {
 				return err
 			}
+			if existingMonitoringInstance == "" {
+				monitoringHostRegion = regions[0]
+			}
 			monitoringEc2SvcMap := make(map[string]*awsAPI.AwsCloud)
 			if addMonitoring && existingMonitoringInstance == "" {
 				monitoringEc2SvcMap[monitoringHostRegion] = ec2SvcMap[monitoringHostRegion]
@@ -414,7 +785,7 @@ func createNodes(cmd *cobra.Command, args []string) error {
 				if err != nil {
 					return err
 				}
-				monitoringNodeConfig = monitoringCloudConfig[regions[0]]
+				monitoringNodeConfig = monitoringCloudConfig[monitoringHostRegion]
 			}
 			if existingMonitoringInstance != "" {
 				addMonitoring = true
@@ -457,7 +828,7 @@ func createNodes(cmd *cobra.Command, args []string) error {
 				}
 				cloudConfigMap[region] = currentRegionConfig
 				if addMonitoring {
-					if err = AddMonitoringSecurityGroupRule(ec2SvcMap, monitoringNodeConfig.PublicIPs[0], currentRegionConfig.SecurityGroup, region); err != nil {
+					if err = AddMonitoringSecurityGroupRule(ec2SvcMap, monitoringNodeConfig.PublicIPs[0], currentRegionConfig.SecurityGroup, region, httpPort); err != nil {
 						return err
 					}
 				}
@@ -530,7 +901,7 @@ func createNodes(cmd *cobra.Command, args []string) error {
 					networkName := fmt.Sprintf("%s-network", prefix)
 					firewallName := fmt.Sprintf("%s-%s-monitoring", networkName, strings.ReplaceAll(monitoringNodeConfig.PublicIPs[0], ".", ""))
 					ports := []string{
-						strconv.Itoa(constants.AvalanchegoMachineMetricsPort), strconv.Itoa(constants.AvalanchegoAPIPort),
+						strconv.Itoa(constants.AvalanchegoMachineMetricsPort), strconv.Itoa(int(httpPort)),
 						strconv.Itoa(constants.AvalanchegoMonitoringPort), strconv.Itoa(constants.AvalanchegoGrafanaPort),
 						strconv.Itoa(constants.AvalanchegoLokiPort),
 					}
@@ -550,6 +921,20 @@ func createNodes(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if dnsZone != "" {
+		// nodes are still set up if DNS records can't be created, as they are reachable by IP
+		if err := createNodeDNSRecords(cloudService, clusterName, gcpProjectName, gcpCredentialFilepath, cloudConfigMap, publicIPMap); err != nil {
+			ux.Logger.RedXToUser("Failed to create DNS records for the nodes in zone %s: %s", dnsZone, err)
+		}
+	}
+	for region, cloudConfig := range cloudConfigMap {
+		cloudConfig.HTTPPort = customPort(httpPort, constants.AvalanchegoAPIPort)
+		cloudConfig.StakingPort = customPort(stakingPort, constants.AvalanchegoP2PPort)
+		if sshUser != constants.AnsibleSSHUser {
+			cloudConfig.SSHUser = sshUser
+		}
+		cloudConfigMap[region] = cloudConfig
+	}
 	if err = CreateClusterNodeConfig(
 		network,
 		cloudConfigMap,
@@ -590,15 +975,19 @@ func createNodes(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	hosts := utils.Filter(allHosts, func(h *models.Host) bool { return slices.Contains(cloudConfigMap.GetAllInstanceIDs(), h.GetCloudID()) })
+	hostVersions, err := getHostAvalancheGoVersions(getOrderedInstanceIDs(cloudConfigMap), avalancheGoVersion, nodeVersions)
+	if err != nil {
+		return err
+	}
 	// waiting for all nodes to become accessible
 	checkHosts := hosts
 	if addMonitoring && len(monitoringHosts) > 0 {
 		checkHosts = append(checkHosts, monitoringHosts[0])
 	}
-	failedHosts := waitForHosts(checkHosts)
+	failedHosts := waitForHosts(checkHosts, provisionTimeout)
 	if failedHosts.Len() > 0 {
 		for _, result := range failedHosts.GetResults() {
-			ux.Logger.PrintToUser("Instance %s failed to provision with error %s. Please check instance logs for more information", result.NodeID, result.Err)
+			ux.Logger.PrintToUser("Instance %s failed to provision with error %s. Please check instance logs for more information", result.NodeID, describeNodeError(result.Err))
 		}
 		return fmt.Errorf("failed to provision node(s) %s", failedHosts.GetNodeList())
 	}
@@ -611,6 +1000,10 @@ func createNodes(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	subnetMetrics, err := getSubnetMetricsTargets(clusterName)
+	if err != nil {
+		return err
+	}
 	startTime := time.Now()
 	if addMonitoring {
 		if len(monitoringHosts) != 1 {
@@ -650,7 +1043,14 @@ func createNodes(cmd *cobra.Command, args []string) error {
 					return
 				}
 				ux.Logger.Info("RunSSHCopyMonitoringDashboards completed")
-				if err := ssh.RunSSHSetupPrometheusConfig(monitoringHost, avalancheGoPorts, machinePorts, ltPorts); err != nil {
+				if remoteWrite != nil {
+					if err := saveRemoteWriteConfig(monitoringHost.GetCloudID(), remoteWrite); err != nil {
+						nodeResults.AddResult(monitoringHost.NodeID, nil, err)
+						ux.SpinFailWithError(spinner, "", err)
+						return
+					}
+				}
+				if err := ssh.RunSSHSetupPrometheusConfig(monitoringHost, avalancheGoPorts, machinePorts, ltPorts, subnetMetrics, remoteWrite); err != nil {
 					nodeResults.AddResult(monitoringHost.NodeID, nil, err)
 					ux.SpinFailWithError(spinner, "", err)
 					return
@@ -672,10 +1072,18 @@ func createNodes(cmd *cobra.Command, args []string) error {
 			}(&wgResults, monitoringHost)
 		}
 	}
+	parallelism := setupParallelism
+	if parallelism == 0 {
+		parallelism = utils.DefaultParallelism(len(hosts))
+	}
+	ux.Logger.Info("Setting up %d node(s) with parallelism %d", len(hosts), parallelism)
+	setupSem := utils.NewSemaphore(parallelism)
 	for _, host := range hosts {
 		wg.Add(1)
 		go func(nodeResults *models.NodeResults, host *models.Host) {
 			defer wg.Done()
+			setupSem.Acquire()
+			defer setupSem.Release()
 			if err := host.Connect(0); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
 				return
@@ -712,7 +1120,8 @@ func createNodes(cmd *cobra.Command, args []string) error {
 				ux.SpinComplete(spinner)
 			}
 			spinner = spinSession.SpinToUser(utils.ScriptLog(host.NodeID, "Setup AvalancheGo"))
-			if err := docker.ComposeSSHSetupNode(host, network, avalancheGoVersion, addMonitoring); err != nil {
+			hostVersion := hostVersions[host.GetCloudID()]
+			if err := docker.ComposeSSHSetupNode(host, network, hostVersion, logLevel, cChainDBConfig, addMonitoring, serviceEnv, avalancheGoImageDigests[hostVersion], customComposeFile); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
 				ux.SpinFailWithError(spinner, "", err)
 				return
@@ -724,13 +1133,13 @@ func createNodes(cmd *cobra.Command, args []string) error {
 	ux.Logger.Info("Create and setup nodes time took: %s", time.Since(startTime))
 	spinSession.Stop()
 	if network.Kind == models.Devnet {
-		if err := setupDevnet(clusterName, hosts, apiNodeIPMap); err != nil {
+		if err := setupDevnet(clusterName, hosts, apiNodeIPMap, bootstrapPeers); err != nil {
 			return err
 		}
 	}
 	for _, node := range hosts {
 		if wgResults.HasNodeIDWithError(node.NodeID) {
-			ux.Logger.RedXToUser("Node %s is ERROR with error: %s", node.NodeID, wgResults.GetErrorHostMap()[node.NodeID])
+			ux.Logger.RedXToUser("Node %s is ERROR with error: %s", node.NodeID, describeNodeError(wgResults.GetErrorHostMap()[node.NodeID]))
 		}
 	}
 
@@ -742,12 +1151,51 @@ func createNodes(cmd *cobra.Command, args []string) error {
 			monitoringPublicIP = monitoringNodeConfig.PublicIPs[0]
 		}
 		printResults(cloudConfigMap, publicIPMap, monitoringPublicIP)
-		ux.Logger.PrintToUser(logging.Green.Wrap("AvalancheGo and Avalanche-CLI installed and node(s) are bootstrapping!"))
+		if waitHealthy {
+			if err := waitForHealthyHosts(hosts, waitHealthyTimeout, waitHealthyPoll); err != nil {
+				return err
+			}
+			ux.Logger.PrintToUser(logging.Green.Wrap("AvalancheGo and Avalanche-CLI installed and node(s) are healthy!"))
+		} else {
+			ux.Logger.PrintToUser(logging.Green.Wrap("AvalancheGo and Avalanche-CLI installed and node(s) are bootstrapping!"))
+		}
+	}
+	if len(failedRegions) > 0 {
+		printFailedRegions(clusterName, failedRegions, failedRegionsNumNodes)
 	}
 	sendNodeCreateMetrics(cmd, cloudService, network.Name(), numNodesMetricsMap)
 	return nil
 }
 
+// printFailedRegions reports the regions that could not be created with --partial-ok,
+// and how to retry them on the same cluster
+func printFailedRegions(clusterName string, failedRegions map[string]error, failedRegionsNumNodes map[string]NumNodes) {
+	regions := maps.Keys(failedRegions)
+	slices.Sort(regions)
+	ux.Logger.PrintToUser(logging.Yellow.Wrap("Node(s) could not be created in the following region(s), and were not added to the cluster:"))
+	for _, region := range regions {
+		ux.Logger.RedXToUser("AWS[%s]: %s", region, failedRegions[region])
+	}
+	ux.Logger.PrintToUser("To retry them, run: %s", getRetryRegionsCommand(clusterName, failedRegionsNumNodes))
+}
+
+// getRetryRegionsCommand returns the node create command that creates the nodes of [numNodes]
+// in cluster [clusterName]
+func getRetryRegionsCommand(clusterName string, numNodes map[string]NumNodes) string {
+	regions := maps.Keys(numNodes)
+	slices.Sort(regions)
+	command := fmt.Sprintf(
+		"avalanche node create %s --aws --region %s --num-validators %s",
+		clusterName,
+		strings.Join(regions, ","),
+		strings.Join(utils.Map(regions, func(region string) string { return strconv.Itoa(numNodes[region].numValidators) }), ","),
+	)
+	if slices.ContainsFunc(regions, func(region string) bool { return numNodes[region].numAPI > 0 }) {
+		command += " --num-apis " + strings.Join(utils.Map(regions, func(region string) string { return strconv.Itoa(numNodes[region].numAPI) }), ",")
+	}
+	return command
+}
+
 func promptSetUpMonitoring() (bool, error) {
 	monitoringInstance, err := app.Prompt.CaptureYesNo("Do you want to set up monitoring? (This requires additional cloud instance and may incur additional cost)")
 	if err != nil {
@@ -784,6 +1232,14 @@ func CreateClusterNodeConfig(
 				CloudService:  cloudService,
 				UseStaticIP:   useStaticIP,
 				IsMonitor:     false,
+				HTTPPort:      cloudConfig.HTTPPort,
+				StakingPort:   cloudConfig.StakingPort,
+				SSHUser:       cloudConfig.SSHUser,
+				Tags:          instanceTags,
+			}
+			if len(cloudConfig.DNSNames) > 0 {
+				nodeConfig.DNSZone = cloudConfig.DNSZone
+				nodeConfig.DNSName = cloudConfig.DNSNames[i]
 			}
 			if err := app.CreateNodeCloudConfigFile(cloudConfig.InstanceIDs[i], &nodeConfig); err != nil {
 				return err
@@ -819,6 +1275,7 @@ func saveExternalHostConfig(externalHostConfig models.RegionConfig, hostRegion,
 		UseStaticIP:   useStaticIP,
 		IsMonitor:     isMonitoring,
 		IsLoadTest:    isLoadTest,
+		Tags:          instanceTags,
 	}
 	if err := app.CreateNodeCloudConfigFile(externalHostConfig.InstanceIDs[0], &nodeConfig); err != nil {
 		return err
@@ -983,6 +1440,86 @@ func provideStakingCertAndKey(host *models.Host) error {
 	return ssh.RunSSHUploadStakingFiles(host, keyPath)
 }
 
+// parseNodeVersions parses the nodeIndex=version [entries] of --node-version. Versions are
+// either semantic versions or latest
+func parseNodeVersions(entries []string) (map[int]string, error) {
+	keyValues, err := utils.ParseKeyValues(entries)
+	if err != nil {
+		return nil, err
+	}
+	versions := map[int]string{}
+	for key, version := range keyValues {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("invalid node index %q: expected a number starting from 0", key)
+		}
+		if version != latestNodeVersion && !semver.IsValid(version) {
+			return nil, fmt.Errorf("invalid version %q for node %d: expected a semantic version as v1.11.8, or %s", version, index, latestNodeVersion)
+		}
+		versions[index] = version
+	}
+	return versions, nil
+}
+
+// checkNodeVersionIndexes checks that the node indexes of [versions] are those of the [numNodes] created nodes
+func checkNodeVersionIndexes(versions map[int]string, numNodes int) error {
+	for index := range versions {
+		if index >= numNodes {
+			return fmt.Errorf("invalid --node-version: node index %d is out of range, %d node(s) are created", index, numNodes)
+		}
+	}
+	return nil
+}
+
+// resolveLatestNodeVersions replaces latest in [versions] by the latest AvalancheGo release
+func resolveLatestNodeVersions(versions map[int]string) error {
+	if !slices.Contains(maps.Values(versions), latestNodeVersion) {
+		return nil
+	}
+	latestReleaseVersion, err := app.Downloader.GetLatestReleaseVersion(binutils.GetGithubLatestReleaseURL(
+		constants.AvaLabsOrg,
+		constants.AvalancheGoRepoName,
+	))
+	if err != nil {
+		return err
+	}
+	for index, version := range versions {
+		if version == latestNodeVersion {
+			versions[index] = latestReleaseVersion
+		}
+	}
+	return nil
+}
+
+// getOrderedInstanceIDs returns the instance IDs of [cloudConfigMap] in creation order, by
+// region in alphabetical order. Indexes of --node-version refer to this order
+func getOrderedInstanceIDs(cloudConfigMap models.CloudConfig) []string {
+	regions := maps.Keys(cloudConfigMap)
+	slices.Sort(regions)
+	instanceIDs := []string{}
+	for _, region := range regions {
+		instanceIDs = append(instanceIDs, cloudConfigMap[region].InstanceIDs...)
+	}
+	return instanceIDs
+}
+
+// getHostAvalancheGoVersions returns the AvalancheGo version to run on each of [instanceIDs],
+// by instance ID: the version given in [nodeVersions] for its index, or else [defaultVersion]
+func getHostAvalancheGoVersions(instanceIDs []string, defaultVersion string, nodeVersions map[int]string) (map[string]string, error) {
+	if err := checkNodeVersionIndexes(nodeVersions, len(instanceIDs)); err != nil {
+		return nil, err
+	}
+	hostVersions := map[string]string{}
+	for index, instanceID := range instanceIDs {
+		hostVersions[instanceID] = defaultVersion
+		if version, ok := nodeVersions[index]; ok {
+			hostVersions[instanceID] = version
+			ux.Logger.PrintToUser("Node %d (%s) will run AvalancheGo %s", index, instanceID, version)
+		}
+	}
+	return hostVersions, nil
+}
+
 // getAvalancheGoVersion asks users whether they want to install the newest Avalanche Go version
 // or if they want to use the newest Avalanche Go Version that is still compatible with Subnet EVM
 // version of their choice
@@ -1088,6 +1625,20 @@ func promptAvalancheGoVersionChoice(latestReleaseVersion string, latestPreReleas
 	return nil
 }
 
+// validateInstanceTags checks that [tags] can be set on the servers of [cloudService]
+func validateInstanceTags(cloudService string, tags map[string]string) error {
+	switch {
+	case len(tags) == 0:
+		return nil
+	case cloudService == constants.AWSCloudService:
+		return awsAPI.ValidateTags(tags)
+	case cloudService == constants.GCPCloudService:
+		return gcpAPI.ValidateLabels(tags)
+	default:
+		return fmt.Errorf("--tags is only supported on AWS and GCP")
+	}
+}
+
 func setCloudService() (string, error) {
 	if utils.IsE2E() {
 		if !utils.E2EDocker() {
@@ -1176,14 +1727,14 @@ func printResults(cloudConfigMap models.CloudConfig, publicIPMap map[string]stri
 			ux.Logger.PrintLineSeparator()
 			ux.Logger.PrintToUser("API Endpoint(s) for region [%s]: ", logging.LightBlue.Wrap(region))
 			for _, apiNode := range cloudConfig.APIInstanceIDs {
-				ux.Logger.PrintToUser(logging.Green.Wrap(fmt.Sprintf("    http://%s:9650", publicIPMap[apiNode])))
+				ux.Logger.PrintToUser(logging.Green.Wrap(fmt.Sprintf("    http://%s:%d", publicIPMap[apiNode], cloudConfig.GetHTTPPort())))
 			}
 			ux.Logger.PrintLineSeparator()
 			ux.Logger.PrintToUser("")
 		}
 		ux.Logger.PrintToUser("Don't delete or replace your ssh private key file at %s as you won't be able to access your cloud server without it", cloudConfig.CertFilePath)
 		ux.Logger.PrintLineSeparator()
-		for _, instanceID := range cloudConfig.InstanceIDs {
+		for i, instanceID := range cloudConfig.InstanceIDs {
 			nodeID, _ := getNodeID(app.GetNodeInstanceDirPath(instanceID))
 			publicIP := ""
 			publicIP = publicIPMap[instanceID]
@@ -1192,6 +1743,9 @@ func printResults(cloudConfigMap models.CloudConfig, publicIPMap map[string]stri
 			} else {
 				ux.Logger.PrintToUser("%s Cloud Instance ID: %s | Public IP: %s | %s ", logging.Green.Wrap(">"), instanceID, publicIP, logging.Green.Wrap(nodeID.String()))
 			}
+			if len(cloudConfig.DNSNames) > 0 {
+				ux.Logger.PrintToUser("  DNS Name: %s", cloudConfig.DNSNames[i])
+			}
 			ux.Logger.PrintToUser("staker.crt, staker.key and signer.key are stored at %s. Please keep them safe, as these files can be used to fully recreate your node.", app.GetNodeInstanceDirPath(instanceID))
 			ux.Logger.PrintLineSeparator()
 		}
@@ -1201,7 +1755,7 @@ func printResults(cloudConfigMap models.CloudConfig, publicIPMap map[string]stri
 			IP: monitoringHostIP,
 		}
 		if err := waitForMonitoringEndpoint(&monitoringHost); err != nil {
-			ux.Logger.RedXToUser("Failed to wait for monitoring endpoint to be available with error: %w", err)
+			ux.Logger.RedXToUser("Failed to wait for monitoring endpoint to be available with error: %s", err)
 		} else {
 			getMonitoringHint(monitoringHostIP)
 		}
@@ -1231,27 +1785,26 @@ func waitForMonitoringEndpoint(monitoringHost *models.Host) error {
 	return nil
 }
 
-// waitForHosts waits for all hosts to become available via SSH.
-func waitForHosts(hosts []*models.Host) *models.NodeResults {
-	hostErrors := models.NodeResults{}
-	createdWaitGroup := sync.WaitGroup{}
+// waitForHosts waits for all hosts to become available via SSH within [timeout].
+func waitForHosts(hosts []*models.Host, timeout time.Duration) *models.NodeResults {
 	spinSession := ux.NewUserSpinner()
-	for _, host := range hosts {
-		createdWaitGroup.Add(1)
-		go func(nodeResults *models.NodeResults, host *models.Host) {
-			defer createdWaitGroup.Done()
+	defer spinSession.Stop()
+	return node.WaitForHosts(
+		hosts,
+		func(host *models.Host) error {
 			spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, "Waiting for instance response"))
-			if err := host.WaitForSSHShell(constants.SSHServerStartTimeout); err != nil {
-				nodeResults.AddResult(host.NodeID, nil, err)
+			if err := host.WaitForSSHShell(timeout); err != nil {
 				ux.SpinFailWithError(spinner, "", err)
-				return
+				return err
 			}
 			ux.SpinComplete(spinner)
-		}(&hostErrors, host)
-	}
-	createdWaitGroup.Wait()
-	spinSession.Stop()
-	return &hostErrors
+			return nil
+		},
+		constants.SSHServerStartLogInterval,
+		func(pending int, total int) {
+			ux.Logger.PrintToUser("Still waiting for %d/%d instance(s) to respond...", pending, total)
+		},
+	)
 }
 
 // requestCloudAuth makes sure user agree to
@@ -1291,8 +1844,8 @@ func getSeparateHostNodeParam(cloudName string) (
 			locationsListURL: "https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/using-regions-availability-zones.html",
 		},
 		constants.GCPCloudService: {
-			defaultLocations: []string{"us-east1", "us-central1", "us-west1"},
-			locationName:     "Google Region",
+			defaultLocations: gcpDefaultZones,
+			locationName:     "Google Zone",
 			locationsListURL: "https://cloud.google.com/compute/docs/regions-zones/",
 		},
 	}
@@ -1310,7 +1863,11 @@ func getSeparateHostNodeParam(cloudName string) (
 		return "", err
 	}
 	if userRegion == awsCustomRegion {
-		userRegion, err = app.Prompt.CaptureString(fmt.Sprintf("Which %s do you want to set up your node in?", supportedClouds[cloudName].locationName))
+		if cloudName == constants.GCPCloudService {
+			userRegion, err = app.Prompt.CaptureValidatedString(fmt.Sprintf("Which %s do you want to set up your node in?", supportedClouds[cloudName].locationName), validateGCPZone)
+		} else {
+			userRegion, err = app.Prompt.CaptureValidatedString(fmt.Sprintf("Which %s do you want to set up your node in?", supportedClouds[cloudName].locationName), validateAWSRegion)
+		}
 		if err != nil {
 			return "", err
 		}
@@ -1335,8 +1892,8 @@ func getRegionsNodeNum(cloudName string) (
 			locationsListURL: "https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/using-regions-availability-zones.html",
 		},
 		constants.GCPCloudService: {
-			defaultLocations: []string{"us-east1", "us-central1", "us-west1"},
-			locationName:     "Google Region",
+			defaultLocations: gcpDefaultZones,
+			locationName:     "Google Zone",
 			locationsListURL: "https://cloud.google.com/compute/docs/regions-zones/",
 		},
 	}
@@ -1356,27 +1913,39 @@ func getRegionsNodeNum(cloudName string) (
 		if err != nil {
 			return nil, err
 		}
+		userRegions := []string{userRegion}
 		if userRegion == awsCustomRegion {
-			userRegion, err = app.Prompt.CaptureString(fmt.Sprintf("Which %s do you want to set up your node in?", supportedClouds[cloudName].locationName))
-			if err != nil {
-				return nil, err
+			if cloudName == constants.GCPCloudService {
+				userRegion, err = app.Prompt.CaptureValidatedString(fmt.Sprintf("Which %ss do you want to set up your node(s) in? Use comma to separate multiple %ss", supportedClouds[cloudName].locationName, supportedClouds[cloudName].locationName), validateGCPZones)
+				if err != nil {
+					return nil, err
+				}
+				userRegions = splitGCPZones(userRegion)
+			} else {
+				userRegion, err = app.Prompt.CaptureValidatedString(fmt.Sprintf("Which %s do you want to set up your node in?", supportedClouds[cloudName].locationName), validateAWSRegion)
+				if err != nil {
+					return nil, err
+				}
+				userRegions = []string{userRegion}
 			}
 		}
-		numAPINodes := uint32(0)
-		numNodes, err := app.Prompt.CaptureUint32(fmt.Sprintf("How many nodes do you want to set up in %s %s?", userRegion, supportedClouds[cloudName].locationName))
-		if err != nil {
-			return nil, err
-		}
-		if globalNetworkFlags.UseDevnet {
-			numAPINodes, err = app.Prompt.CaptureUint32(fmt.Sprintf("How many API nodes (nodes without stake) do you want to set up in %s %s?", userRegion, supportedClouds[cloudName].locationName))
+		for _, userRegion := range userRegions {
+			numAPINodes := uint32(0)
+			numNodes, err := app.Prompt.CaptureUint32(fmt.Sprintf("How many nodes do you want to set up in %s %s?", userRegion, supportedClouds[cloudName].locationName))
 			if err != nil {
 				return nil, err
 			}
+			if globalNetworkFlags.UseDevnet {
+				numAPINodes, err = app.Prompt.CaptureUint32(fmt.Sprintf("How many API nodes (nodes without stake) do you want to set up in %s %s?", userRegion, supportedClouds[cloudName].locationName))
+				if err != nil {
+					return nil, err
+				}
+			}
+			if numNodes > uint32(math.MaxInt32) || numAPINodes > uint32(math.MaxInt32) {
+				return nil, fmt.Errorf("number of nodes exceeds the range of a signed 32-bit integer")
+			}
+			nodes[userRegion] = NumNodes{int(numNodes), int(numAPINodes)}
 		}
-		if numNodes > uint32(math.MaxInt32) || numAPINodes > uint32(math.MaxInt32) {
-			return nil, fmt.Errorf("number of nodes exceeds the range of a signed 32-bit integer")
-		}
-		nodes[userRegion] = NumNodes{int(numNodes), int(numAPINodes)}
 		var currentInput []string
 		if globalNetworkFlags.UseDevnet {
 			currentInput = utils.Map(maps.Keys(nodes), func(region string) string {
@@ -1471,7 +2040,7 @@ func getPrometheusTargets(clusterName string) ([]string, []string, []string, err
 		return avalancheGoPorts, machinePorts, ltPorts, err
 	}
 	for _, host := range inventoryHosts {
-		avalancheGoPorts = append(avalancheGoPorts, fmt.Sprintf("'%s:%s'", host.IP, strconv.Itoa(constants.AvalanchegoAPIPort)))
+		avalancheGoPorts = append(avalancheGoPorts, fmt.Sprintf("'%s:%d'", host.IP, host.GetHTTPPort()))
 		machinePorts = append(machinePorts, fmt.Sprintf("'%s:%s'", host.IP, strconv.Itoa(constants.AvalanchegoMachineMetricsPort)))
 	}
 	// no need to check error here as it's ok to have no load test instances
@@ -1481,3 +2050,102 @@ func getPrometheusTargets(clusterName string) ([]string, []string, []string, err
 	}
 	return avalancheGoPorts, machinePorts, ltPorts, nil
 }
+
+// getSubnetMetricsTargets returns the VM metrics scrape targets of the Subnet-EVM subnets
+// tracked by the cluster, scraped from all the cluster nodes
+func getSubnetMetricsTargets(clusterName string) ([]monitoring.SubnetMetricsTarget, error) {
+	clusterConfig, err := app.GetClusterConfig(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	inventoryHosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
+	if err != nil {
+		return nil, err
+	}
+	targets := utils.Map(inventoryHosts, func(host *models.Host) string {
+		return fmt.Sprintf("%s:%d", host.IP, host.GetHTTPPort())
+	})
+	subnetMetrics := []monitoring.SubnetMetricsTarget{}
+	if len(targets) == 0 {
+		return subnetMetrics, nil
+	}
+	for _, subnetName := range clusterConfig.Subnets {
+		sc, err := app.LoadSidecar(subnetName)
+		if err != nil {
+			return nil, err
+		}
+		blockchainID := sc.Networks[clusterConfig.Network.Name()].BlockchainID
+		if sc.VM != models.SubnetEvm || blockchainID == ids.Empty {
+			continue
+		}
+		subnetMetrics = append(subnetMetrics, monitoring.SubnetMetricsTarget{
+			BlockchainID: blockchainID.String(),
+			Targets:      targets,
+		})
+	}
+	return subnetMetrics, nil
+}
+
+// updatePrometheusConfig regenerates the prometheus config of the cluster monitoring host, if
+// any, so that it scrapes the current cluster nodes, load test hosts and subnets
+func updatePrometheusConfig(clusterName string) error {
+	monitoringInventoryPath := app.GetMonitoringInventoryDir(clusterName)
+	if !utils.DirectoryExists(monitoringInventoryPath) {
+		return nil
+	}
+	monitoringHosts, err := ansible.GetInventoryFromAnsibleInventoryFile(monitoringInventoryPath)
+	if err != nil {
+		return err
+	}
+	if len(monitoringHosts) == 0 {
+		return nil
+	}
+	avalancheGoPorts, machinePorts, ltPorts, err := getPrometheusTargets(clusterName)
+	if err != nil {
+		return err
+	}
+	subnetMetrics, err := getSubnetMetricsTargets(clusterName)
+	if err != nil {
+		return err
+	}
+	remoteWrite, err := loadRemoteWriteConfig(monitoringHosts[0].GetCloudID())
+	if err != nil {
+		return err
+	}
+	if err := ssh.RunSSHSetupPrometheusConfig(monitoringHosts[0], avalancheGoPorts, machinePorts, ltPorts, subnetMetrics, remoteWrite); err != nil {
+		return err
+	}
+	return docker.RestartDockerComposeService(monitoringHosts[0], utils.GetRemoteComposeFile(), "prometheus", constants.SSHLongRunningScriptTimeout)
+}
+
+// saveRemoteWriteConfig saves the prometheus remote write target of monitoring instance [cloudID] at
+// its node dir, so it is kept when the prometheus config is regenerated. Only readable by the user,
+// as it contains credentials
+func saveRemoteWriteConfig(cloudID string, remoteWrite *monitoring.RemoteWriteConfig) error {
+	remoteWriteBytes, err := json.MarshalIndent(remoteWrite, "", "    ")
+	if err != nil {
+		return err
+	}
+	nodeDir := app.GetNodeInstanceDirPath(cloudID)
+	if err := os.MkdirAll(nodeDir, constants.DefaultPerms755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(nodeDir, constants.RemoteWriteConfigFileName), remoteWriteBytes, constants.WriteReadUserOnlyPerms)
+}
+
+// loadRemoteWriteConfig returns the prometheus remote write target of monitoring instance [cloudID],
+// or nil if it has none
+func loadRemoteWriteConfig(cloudID string) (*monitoring.RemoteWriteConfig, error) {
+	remoteWriteBytes, err := os.ReadFile(filepath.Join(app.GetNodeInstanceDirPath(cloudID), constants.RemoteWriteConfigFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	remoteWrite := &monitoring.RemoteWriteConfig{}
+	if err := json.Unmarshal(remoteWriteBytes, remoteWrite); err != nil {
+		return nil, fmt.Errorf("invalid prometheus remote write config of monitoring instance %s: %w", cloudID, err)
+	}
+	return remoteWrite, nil
+}
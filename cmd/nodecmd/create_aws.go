@@ -3,10 +3,12 @@
 package nodecmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 
 	"golang.org/x/exp/maps"
 
@@ -18,6 +20,8 @@ import (
 
 	awsAPI "github.com/ava-labs/avalanche-cli/pkg/cloud/aws"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/wireguard"
+	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
 func getNewKeyPairName(ec2Svc *awsAPI.AwsCloud) (string, error) {
@@ -138,12 +142,26 @@ func getAWSCloudConfig(awsProfile string, singleNode bool, clusterSgRegions []st
 	ec2SvcMap := map[string]*awsAPI.AwsCloud{}
 	amiMap := map[string]string{}
 	numNodesMap := map[string]NumNodes{}
+	// catch region typos before calling AWS
+	for region := range finalRegions {
+		if err := validateAWSRegion(region); err != nil {
+			return nil, nil, nil, err
+		}
+	}
 	// verify regions are valid
 	if invalidRegions, err := checkRegions(maps.Keys(finalRegions)); err != nil {
 		return nil, nil, nil, err
 	} else if len(invalidRegions) > 0 {
 		return nil, nil, nil, fmt.Errorf("invalid regions %s provided for %s", invalidRegions, constants.AWSCloudService)
 	}
+	for region := range amiOverrides {
+		if _, ok := finalRegions[region]; region != awsAPI.AllRegionsAMIKey && !ok {
+			return nil, nil, nil, fmt.Errorf("AMI given for region %s, but no nodes are created there", region)
+		}
+	}
+	if awsVPCID != "" && len(finalRegions) != 1 {
+		return nil, nil, nil, fmt.Errorf("AWS VPC %s can only be used when creating nodes in a single region", awsVPCID)
+	}
 	for region := range finalRegions {
 		var err error
 		if singleNode {
@@ -169,13 +187,22 @@ func getAWSCloudConfig(awsProfile string, singleNode bool, clusterSgRegions []st
 		if err != nil {
 			return nil, nil, nil, err
 		}
-		amiMap[region], err = ec2SvcMap[region].GetUbuntuAMIID(arch, constants.UbuntuVersionLTS)
-		if err != nil {
+		if amiID, ok := awsAPI.GetAMIOverride(amiOverrides, region); ok {
+			if err := ec2SvcMap[region].CheckAMI(amiID, arch); err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid AMI for region %s: %w", region, err)
+			}
+			amiMap[region] = amiID
+		} else if amiMap[region], err = ec2SvcMap[region].GetUbuntuAMIID(arch, constants.UbuntuVersionLTS); err != nil {
 			if isExpiredCredentialError(err) {
 				printExpiredCredentialsOutput(awsProfile)
 			}
 			return nil, nil, nil, err
 		}
+		if awsVPCID != "" {
+			if err := ec2SvcMap[region].CheckSubnetInVPC(awsSubnetID, awsVPCID); err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid AWS VPC placement for region %s: %w", region, err)
+			}
+		}
 		isSupported, err := ec2SvcMap[region].IsInstanceTypeSupported(instanceType)
 		if err != nil {
 			return nil, nil, nil, err
@@ -188,6 +215,18 @@ func getAWSCloudConfig(awsProfile string, singleNode bool, clusterSgRegions []st
 	return ec2SvcMap, amiMap, numNodesMap, nil
 }
 
+// validateAWSRegion checks that [region] is a known AWS region, unless --skip-region-check
+// is given, as the list of known regions may miss recently launched ones
+func validateAWSRegion(region string) error {
+	if skipRegionCheck {
+		return nil
+	}
+	if err := awsAPI.ValidateRegion(region); err != nil {
+		return fmt.Errorf("%w. Use --skip-region-check if it is a recently launched region", err)
+	}
+	return nil
+}
+
 // createEC2Instances creates  ec2 instances
 func createEC2Instances(ec2Svc map[string]*awsAPI.AwsCloud,
 	regions []string,
@@ -199,7 +238,7 @@ func createEC2Instances(ec2Svc map[string]*awsAPI.AwsCloud,
 	} else {
 		ux.Logger.PrintToUser("Creating separate monitoring EC2 instance(s) on AWS...")
 	}
-	userIPAddress, err := utils.GetUserIPAddress()
+	userIPAddress, err := utils.GetUserIPAddressForVersion(userIPVersion)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -207,6 +246,16 @@ func createEC2Instances(ec2Svc map[string]*awsAPI.AwsCloud,
 	instanceIDs := map[string][]string{}
 	elasticIPs := map[string][]string{}
 	sshCertPath := map[string]string{}
+	sgIDs := map[string]string{}
+	// the key pairs and security groups created here are deleted again for the regions whose
+	// instances can't be created, so that a retry starts from the same state
+	created := map[string]createdRegionAccess{}
+	setupDone := false
+	defer func() {
+		if !setupDone {
+			deleteCreatedRegionAccess(ec2Svc, created, nil)
+		}
+	}()
 	for _, region := range regions {
 		keyPairExists, err := ec2Svc[region].CheckKeyPairExists(regionConf[region].Prefix)
 		if err != nil {
@@ -237,7 +286,7 @@ func createEC2Instances(ec2Svc map[string]*awsAPI.AwsCloud,
 			if err = os.RemoveAll(privKey); err != nil {
 				return instanceIDs, elasticIPs, sshCertPath, keyPairName, fmt.Errorf("unable to delete existing key pair file %s in .ssh dir due to %w", privKey, err)
 			}
-			if err := ec2Svc[region].CreateAndDownloadKeyPair(regionConf[region].Prefix, privKey); err != nil {
+			if err := ec2Svc[region].CreateAndDownloadKeyPair(regionConf[region].Prefix, privKey, sshKeyAlgorithm); err != nil {
 				return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 			}
 		} else {
@@ -248,6 +297,7 @@ func createEC2Instances(ec2Svc map[string]*awsAPI.AwsCloud,
 					if err := ec2Svc[region].UploadSSHIdentityKeyPair(regionConf[region].Prefix, sshIdentity); err != nil {
 						return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 					}
+					created[region] = createdRegionAccess{keyPair: regionConf[region].Prefix}
 				case !useSSHAgent && certInSSHDir:
 					ux.Logger.PrintToUser("Default Key Pair named %s already exists on your .ssh directory but not on AWS", regionConf[region].Prefix)
 					ux.Logger.PrintToUser("We need to create a new Key Pair in AWS as we can't find Key Pair named %s in AWS[%s]", regionConf[region].Prefix, region)
@@ -255,14 +305,16 @@ func createEC2Instances(ec2Svc map[string]*awsAPI.AwsCloud,
 					if err != nil {
 						return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 					}
-					if err := ec2Svc[region].CreateAndDownloadKeyPair(regionConf[region].Prefix, privKey); err != nil {
+					if err := ec2Svc[region].CreateAndDownloadKeyPair(regionConf[region].Prefix, privKey, sshKeyAlgorithm); err != nil {
 						return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 					}
+					created[region] = createdRegionAccess{keyPair: regionConf[region].Prefix, keyPairCertPath: privKey}
 				case !useSSHAgent && !certInSSHDir:
 					ux.Logger.PrintToUser(fmt.Sprintf("Creating new key pair %s in AWS[%s]", keyPairName, region))
-					if err := ec2Svc[region].CreateAndDownloadKeyPair(regionConf[region].Prefix, privKey); err != nil {
+					if err := ec2Svc[region].CreateAndDownloadKeyPair(regionConf[region].Prefix, privKey, sshKeyAlgorithm); err != nil {
 						return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 					}
+					created[region] = createdRegionAccess{keyPair: regionConf[region].Prefix, keyPairCertPath: privKey}
 				}
 			} else {
 				// keypair exists
@@ -282,39 +334,53 @@ func createEC2Instances(ec2Svc map[string]*awsAPI.AwsCloud,
 					if err != nil {
 						return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 					}
-					if err := ec2Svc[region].CreateAndDownloadKeyPair(keyPairName[region], privKey); err != nil {
+					if err := ec2Svc[region].CreateAndDownloadKeyPair(keyPairName[region], privKey, sshKeyAlgorithm); err != nil {
 						return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 					}
+					created[region] = createdRegionAccess{keyPair: keyPairName[region], keyPairCertPath: privKey}
 				}
 			}
 		}
-		securityGroupExists, sg, err := ec2Svc[region].CheckSecurityGroupExists(regionConf[region].SecurityGroupName)
+		securityGroupExists, sg, err := ec2Svc[region].CheckSecurityGroupExists(regionConf[region].SecurityGroupName, awsVPCID)
 		if err != nil {
 			return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 		}
 		if !securityGroupExists {
 			ux.Logger.PrintToUser(fmt.Sprintf("Creating new security group %s in AWS[%s]", securityGroupName, region))
-			if newSGID, err := ec2Svc[region].SetupSecurityGroup(userIPAddress, regionConf[region].SecurityGroupName); err != nil {
+			if newSGID, err := ec2Svc[region].SetupSecurityGroup(userIPAddress, sshCIDRs, regionConf[region].SecurityGroupName, awsVPCID, int32(httpPort), int32(stakingPort)); err != nil {
 				return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 			} else {
 				sgID = newSGID
+				regionAccess := created[region]
+				regionAccess.securityGroupID = sgID
+				created[region] = regionAccess
 			}
 		} else {
 			sgID = *sg.GroupId
 			ux.Logger.PrintToUser(fmt.Sprintf("Using existing security group %s in AWS[%s]", securityGroupName, region))
-			ipInTCP := awsAPI.CheckIPInSg(&sg, userIPAddress, constants.SSHTCPPort)
-			ipInHTTP := awsAPI.CheckIPInSg(&sg, userIPAddress, constants.AvalanchegoAPIPort)
+			accessIPs := []string{userIPAddress}
+			if len(sshCIDRs) > 0 {
+				accessIPs = sshCIDRs
+			}
+			ipInStaking := awsAPI.CheckIPInSg(&sg, "0.0.0.0/0", int32(stakingPort))
 			ipInMonitoring := awsAPI.CheckIPInSg(&sg, userIPAddress, constants.AvalanchegoMonitoringPort)
 			ipInGrafana := awsAPI.CheckIPInSg(&sg, userIPAddress, constants.AvalanchegoGrafanaPort)
 			ipInLoki := awsAPI.CheckIPInSg(&sg, "0.0.0.0/0", constants.AvalanchegoLokiPort)
 
-			if !ipInTCP {
-				if err := ec2Svc[region].AddSecurityGroupRule(sgID, "ingress", "tcp", userIPAddress, constants.SSHTCPPort); err != nil {
-					return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
+			for _, accessIP := range accessIPs {
+				if !awsAPI.CheckIPInSg(&sg, accessIP, constants.SSHTCPPort) {
+					if err := ec2Svc[region].AddSecurityGroupRule(sgID, "ingress", "tcp", accessIP, constants.SSHTCPPort); err != nil {
+						return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
+					}
+				}
+				if !awsAPI.CheckIPInSg(&sg, accessIP, int32(httpPort)) {
+					if err := ec2Svc[region].AddSecurityGroupRule(sgID, "ingress", "tcp", accessIP, int32(httpPort)); err != nil {
+						return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
+					}
 				}
 			}
-			if !ipInHTTP {
-				if err := ec2Svc[region].AddSecurityGroupRule(sgID, "ingress", "tcp", userIPAddress, constants.AvalanchegoAPIPort); err != nil {
+			if !ipInStaking {
+				if err := ec2Svc[region].AddSecurityGroupRule(sgID, "ingress", "tcp", "0.0.0.0/0", int32(stakingPort)); err != nil {
 					return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 				}
 			}
@@ -334,80 +400,69 @@ func createEC2Instances(ec2Svc map[string]*awsAPI.AwsCloud,
 				}
 			}
 		}
-		sshCertPath[region] = privKey
-		if instanceIDs[region], err = ec2Svc[region].CreateEC2Instances(
-			regionConf[region].Prefix,
-			regionConf[region].NumNodes,
-			regionConf[region].ImageID,
-			regionConf[region].InstanceType,
-			keyPairName[region],
-			sgID,
-			forMonitoring,
-			iops,
-			throughput,
-			stringToAWSVolumeType(volumeType),
-			volumeSize,
-		); err != nil {
-			return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
-		}
-		spinSession := ux.NewUserSpinner()
-		spinner := spinSession.SpinToUser("Waiting for EC2 instance(s) in AWS[%s] to be provisioned...", region)
-		if err := ec2Svc[region].WaitForEC2Instances(instanceIDs[region], types.InstanceStateNameRunning); err != nil {
-			ux.SpinFailWithError(spinner, "", err)
-			return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
-		}
-		ux.SpinComplete(spinner)
-		spinSession.Stop()
-		if useStaticIP {
-			publicIPs := []string{}
-			for count := 0; count < regionConf[region].NumNodes; count++ {
-				allocationID, publicIP, err := ec2Svc[region].CreateEIP(regionConf[region].Prefix)
-				if err != nil {
-					return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
-				}
-				if err := ec2Svc[region].AssociateEIP(instanceIDs[region][count], allocationID); err != nil {
-					return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
-				}
-				publicIPs = append(publicIPs, publicIP)
-			}
-			elasticIPs[region] = publicIPs
-		} else {
-			instanceEIPMap, err := ec2Svc[region].GetInstancePublicIPs(instanceIDs[region])
-			if err != nil {
+		// wireguard peers reach each other at their public IPs
+		if meshMode == wireguard.MeshMode && !(securityGroupExists && awsAPI.CheckIPInSg(&sg, "0.0.0.0/0", wireguard.ListenPort)) {
+			if err := ec2Svc[region].AddSecurityGroupRule(sgID, "ingress", "udp", "0.0.0.0/0", wireguard.ListenPort); err != nil {
 				return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
 			}
-			regionElasticIPs := []string{}
-			for _, instanceID := range instanceIDs[region] {
-				regionElasticIPs = append(regionElasticIPs, instanceEIPMap[instanceID])
-			}
-			elasticIPs[region] = regionElasticIPs
 		}
+		sshCertPath[region] = privKey
+		sgIDs[region] = sgID
+	}
+	setupDone = true
+	regions, instanceIDs, elasticIPs, provisionErr := provisionRegions(
+		regions,
+		func(region string) ([]string, []string, error) {
+			return createRegionEC2Instances(ec2Svc[region], region, regionConf[region], keyPairName[region], sgIDs[region], forMonitoring)
+		},
+		func(failedRegions []string, instanceIDs map[string][]string) {
+			failedCreated := map[string]createdRegionAccess{}
+			for _, region := range failedRegions {
+				if regionAccess, ok := created[region]; ok {
+					failedCreated[region] = regionAccess
+				}
+			}
+			deleteCreatedRegionAccess(ec2Svc, failedCreated, instanceIDs)
+		},
+	)
+	var partialErr *partialRegionsError
+	if provisionErr != nil && !errors.As(provisionErr, &partialErr) {
+		return instanceIDs, elasticIPs, sshCertPath, keyPairName, provisionErr
 	}
 	ux.Logger.GreenCheckmarkToUser("New EC2 instance(s) successfully created in AWS!")
 	for _, region := range regions {
-		if useSSHAgent {
-			// takes the cert file downloaded from AWS and moves it to .ssh directory
-			err = addCertToSSH(regionConf[region].CertName)
-			if err != nil {
-				return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
+		certFilePath, err := app.GetSSHCertFilePath(regionConf[region].CertName)
+		if err != nil {
+			return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
+		}
+		agentAvailable := utils.IsSSHAgentAvailable()
+		switch utils.SelectSSHKeyAuth(useSSHAgent, noSSHAgent, agentAvailable, utils.FileExists(certFilePath)) {
+		case utils.SSHKeyAuthAddToAgent:
+			// adds the cert file downloaded from AWS to the ssh-agent
+			if err := addCertToSSH(certFilePath); err != nil {
+				ux.Logger.PrintToUser("Could not add %s to ssh-agent: %s. Using it directly for ssh instead", certFilePath, err)
+				sshCertPath[region] = certFilePath
+			} else {
+				sshCertPath[region] = ""
 			}
+		case utils.SSHKeyAuthAgent:
 			sshCertPath[region] = ""
-		} else {
+		default:
+			if useSSHAgent && !agentAvailable {
+				ux.Logger.PrintToUser("ssh-agent is not available. Using %s directly for ssh", certFilePath)
+			}
 			// don't overwrite existing sshCertPath for a particular region
 			if _, ok := sshCertPath[region]; !ok {
-				sshCertPath[region], err = app.GetSSHCertFilePath(regionConf[region].CertName)
-				if err != nil {
-					return instanceIDs, elasticIPs, sshCertPath, keyPairName, err
-				}
+				sshCertPath[region] = certFilePath
 			}
 		}
 	}
 	// instanceIDs, elasticIPs, certFilePath, keyPairName, err
-	return instanceIDs, elasticIPs, sshCertPath, keyPairName, nil
+	return instanceIDs, elasticIPs, sshCertPath, keyPairName, provisionErr
 }
 
-func AddMonitoringSecurityGroupRule(ec2Svc map[string]*awsAPI.AwsCloud, monitoringHostPublicIP, securityGroupName, region string) error {
-	securityGroupExists, sg, err := ec2Svc[region].CheckSecurityGroupExists(securityGroupName)
+func AddMonitoringSecurityGroupRule(ec2Svc map[string]*awsAPI.AwsCloud, monitoringHostPublicIP, securityGroupName, region string, httpPort uint) error {
+	securityGroupExists, sg, err := ec2Svc[region].CheckSecurityGroupExists(securityGroupName, "")
 	if err != nil {
 		return err
 	}
@@ -415,22 +470,22 @@ func AddMonitoringSecurityGroupRule(ec2Svc map[string]*awsAPI.AwsCloud, monitori
 		return fmt.Errorf("security group %s doesn't exist in region %s", securityGroupName, region)
 	}
 	metricsPortInSG := awsAPI.CheckIPInSg(&sg, monitoringHostPublicIP, constants.AvalanchegoMachineMetricsPort)
-	apiPortInSG := awsAPI.CheckIPInSg(&sg, monitoringHostPublicIP, constants.AvalanchegoAPIPort)
+	apiPortInSG := awsAPI.CheckIPInSg(&sg, monitoringHostPublicIP, int32(httpPort))
 	if !metricsPortInSG {
 		if err = ec2Svc[region].AddSecurityGroupRule(*sg.GroupId, "ingress", "tcp", monitoringHostPublicIP+constants.IPAddressSuffix, constants.AvalanchegoMachineMetricsPort); err != nil {
 			return err
 		}
 	}
 	if !apiPortInSG {
-		if err = ec2Svc[region].AddSecurityGroupRule(*sg.GroupId, "ingress", "tcp", monitoringHostPublicIP+constants.IPAddressSuffix, constants.AvalanchegoAPIPort); err != nil {
+		if err = ec2Svc[region].AddSecurityGroupRule(*sg.GroupId, "ingress", "tcp", monitoringHostPublicIP+constants.IPAddressSuffix, int32(httpPort)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func deleteHostSecurityGroupRule(ec2Svc *awsAPI.AwsCloud, hostPublicIP, securityGroupName string) error {
-	securityGroupExists, sg, err := ec2Svc.CheckSecurityGroupExists(securityGroupName)
+func deleteHostSecurityGroupRule(ec2Svc *awsAPI.AwsCloud, hostPublicIP, securityGroupName string, httpPort uint) error {
+	securityGroupExists, sg, err := ec2Svc.CheckSecurityGroupExists(securityGroupName, "")
 	if err != nil {
 		return err
 	}
@@ -439,22 +494,22 @@ func deleteHostSecurityGroupRule(ec2Svc *awsAPI.AwsCloud, hostPublicIP, security
 		return nil
 	}
 	metricsPortInSG := awsAPI.CheckIPInSg(&sg, hostPublicIP, constants.AvalanchegoMachineMetricsPort)
-	apiPortInSG := awsAPI.CheckIPInSg(&sg, hostPublicIP, constants.AvalanchegoAPIPort)
+	apiPortInSG := awsAPI.CheckIPInSg(&sg, hostPublicIP, int32(httpPort))
 	if metricsPortInSG {
 		if err = ec2Svc.DeleteSecurityGroupRule(*sg.GroupId, "ingress", "tcp", hostPublicIP+constants.IPAddressSuffix, constants.AvalanchegoMachineMetricsPort); err != nil {
 			return err
 		}
 	}
 	if apiPortInSG {
-		if err = ec2Svc.DeleteSecurityGroupRule(*sg.GroupId, "ingress", "tcp", hostPublicIP+constants.IPAddressSuffix, constants.AvalanchegoAPIPort); err != nil {
+		if err = ec2Svc.DeleteSecurityGroupRule(*sg.GroupId, "ingress", "tcp", hostPublicIP+constants.IPAddressSuffix, int32(httpPort)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func grantAccessToPublicIPViaSecurityGroup(ec2Svc *awsAPI.AwsCloud, publicIP, securityGroupName, region string) error {
-	securityGroupExists, sg, err := ec2Svc.CheckSecurityGroupExists(securityGroupName)
+func grantAccessToPublicIPViaSecurityGroup(ec2Svc *awsAPI.AwsCloud, publicIP, securityGroupName, region string, httpPort uint) error {
+	securityGroupExists, sg, err := ec2Svc.CheckSecurityGroupExists(securityGroupName, "")
 	if err != nil {
 		return err
 	}
@@ -462,20 +517,50 @@ func grantAccessToPublicIPViaSecurityGroup(ec2Svc *awsAPI.AwsCloud, publicIP, se
 		return fmt.Errorf("security group %s doesn't exist in region %s", securityGroupName, region)
 	}
 	metricsPortInSG := awsAPI.CheckIPInSg(&sg, publicIP, constants.AvalanchegoMachineMetricsPort)
-	apiPortInSG := awsAPI.CheckIPInSg(&sg, publicIP, constants.AvalanchegoAPIPort)
+	apiPortInSG := awsAPI.CheckIPInSg(&sg, publicIP, int32(httpPort))
 	if !metricsPortInSG {
 		if err = ec2Svc.AddSecurityGroupRule(*sg.GroupId, "ingress", "tcp", publicIP+constants.IPAddressSuffix, constants.AvalanchegoMachineMetricsPort); err != nil {
 			return err
 		}
 	}
 	if !apiPortInSG {
-		if err = ec2Svc.AddSecurityGroupRule(*sg.GroupId, "ingress", "tcp", publicIP+constants.IPAddressSuffix, constants.AvalanchegoAPIPort); err != nil {
+		if err = ec2Svc.AddSecurityGroupRule(*sg.GroupId, "ingress", "tcp", publicIP+constants.IPAddressSuffix, int32(httpPort)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// destroyCreatedAWSInstances terminates instances created by a failed createEC2Instances call,
+// releasing the elastic IPs allocated for them
+func destroyCreatedAWSInstances(ec2Svc map[string]*awsAPI.AwsCloud, instanceIDs map[string][]string, elasticIPs map[string][]string) error {
+	ux.Logger.PrintToUser("Destroying all created AWS instances due to error to prevent charge for unused AWS instances...")
+	failedNodes := map[string]error{}
+	for region, regionInstanceID := range instanceIDs {
+		for i, instanceID := range regionInstanceID {
+			publicIP := ""
+			if useStaticIP && i < len(elasticIPs[region]) {
+				publicIP = elasticIPs[region][i]
+			}
+			ux.Logger.PrintToUser(fmt.Sprintf("Destroying AWS cloud server %s...", instanceID))
+			if destroyErr := ec2Svc[region].DestroyInstance(instanceID, publicIP, publicIP != ""); destroyErr != nil {
+				failedNodes[instanceID] = destroyErr
+				continue
+			}
+			ux.Logger.PrintToUser(fmt.Sprintf("AWS cloud server instance %s destroyed", instanceID))
+		}
+	}
+	if len(failedNodes) > 0 {
+		ux.Logger.PrintToUser("Failed nodes: ")
+		for node, err := range failedNodes {
+			ux.Logger.PrintToUser(fmt.Sprintf("Failed to destroy node %s due to %s", node, err))
+		}
+		ux.Logger.PrintToUser("Destroy the above instance(s) on AWS console to prevent charges")
+		return fmt.Errorf("failed to destroy node(s) %s", failedNodes)
+	}
+	return nil
+}
+
 func createAWSInstances(
 	ec2Svc map[string]*awsAPI.AwsCloud,
 	nodeType string,
@@ -491,42 +576,56 @@ func createAWSInstances(
 		if err != nil {
 			return models.CloudConfig{}, err
 		}
+		// security group names are only unique per VPC, so groups in a custom VPC get its ID
+		securityGroupName := prefix + "-" + region + constants.AWSSecurityGroupSuffix
+		if awsVPCID != "" {
+			securityGroupName = prefix + "-" + region + "-" + awsVPCID + constants.AWSSecurityGroupSuffix
+		}
 		regionConf[region] = models.RegionConfig{
 			Prefix:            prefix,
 			ImageID:           ami[region],
 			CertName:          prefix + "-" + region + constants.CertSuffix,
-			SecurityGroupName: prefix + "-" + region + constants.AWSSecurityGroupSuffix,
+			SecurityGroupName: securityGroupName,
 			NumNodes:          numNodes[region].All(),
 			InstanceType:      nodeType,
 		}
 	}
 	// Create new EC2 instances
 	instanceIDs, elasticIPs, certFilePath, keyPairName, err := createEC2Instances(ec2Svc, regions, regionConf, forMonitoring)
-	if err != nil {
-		if err.Error() == constants.EIPLimitErr {
-			ux.Logger.PrintToUser("Failed to create AWS cloud server(s), please try creating again in a different region")
-		} else {
-			ux.Logger.PrintToUser("Failed to create AWS cloud server(s) with error: %s", err.Error())
-		}
+	var partialErr *partialRegionsError
+	if errors.As(err, &partialErr) {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(partialErr.Error()))
+		// only what was created in the failed regions is destroyed, so that the other regions are kept
+		failedInstanceIDs, failedElasticIPs := map[string][]string{}, map[string][]string{}
+		for region := range partialErr.failed {
+			failedInstanceIDs[region], failedElasticIPs[region] = instanceIDs[region], elasticIPs[region]
+		}
+		// instances that can't be destroyed are listed to the user, and don't affect the created regions
+		_ = destroyCreatedAWSInstances(ec2Svc, failedInstanceIDs, failedElasticIPs)
+		regions = utils.Filter(regions, func(region string) bool {
+			_, failed := partialErr.failed[region]
+			return !failed
+		})
+	} else if err != nil {
+		ux.Logger.PrintToUser("Failed to create AWS cloud server(s) with error: %s", err.Error())
 		// we destroy created instances so that user doesn't pay for unused EC2 instances
-		ux.Logger.PrintToUser("Destroying all created AWS instances due to error to prevent charge for unused AWS instances...")
-		failedNodes := map[string]error{}
-		for region, regionInstanceID := range instanceIDs {
-			for _, instanceID := range regionInstanceID {
-				ux.Logger.PrintToUser(fmt.Sprintf("Destroying AWS cloud server %s...", instanceID))
-				if destroyErr := ec2Svc[region].DestroyInstance(instanceID, "", true); destroyErr != nil {
-					failedNodes[instanceID] = destroyErr
-				}
-				ux.Logger.PrintToUser(fmt.Sprintf("AWS cloud server instance %s destroyed", instanceID))
+		if destroyErr := destroyCreatedAWSInstances(ec2Svc, instanceIDs, elasticIPs); destroyErr != nil {
+			return models.CloudConfig{}, destroyErr
+		}
+		if useStaticIP && errors.Is(err, awsAPI.ErrEIPQuotaExceeded) {
+			ux.Logger.PrintToUser("Elastic IP quota is exceeded. Cloud servers can be created without static IPs, in which case their IPs may change on restart")
+			retry, promptErr := app.Prompt.CaptureYesNo("Do you want to retry creating the cloud server(s) without static IPs?")
+			if promptErr != nil {
+				return models.CloudConfig{}, promptErr
 			}
-		}
-		if len(failedNodes) > 0 {
-			ux.Logger.PrintToUser("Failed nodes: ")
-			for node, err := range failedNodes {
-				ux.Logger.PrintToUser(fmt.Sprintf("Failed to destroy node %s due to %s", node, err))
+			if retry {
+				useStaticIP = false
+				return createAWSInstances(ec2Svc, nodeType, numNodes, regions, ami, forMonitoring)
+			}
+			ux.Logger.PrintToUser("Please try creating again in a different region, or request an elastic IP quota increase in AWS console")
+			if !reuseEIPs {
+				ux.Logger.PrintToUser("Elastic IPs previously allocated by avalanche-cli but not in use can be reused with --reuse-eips")
 			}
-			ux.Logger.PrintToUser("Destroy the above instance(s) on AWS console to prevent charges")
-			return models.CloudConfig{}, fmt.Errorf("failed to destroy node(s) %s", failedNodes)
 		}
 		return models.CloudConfig{}, err
 	}
@@ -541,15 +640,290 @@ func createAWSInstances(
 			ImageID:       ami[region],
 		}
 	}
+	if partialErr != nil {
+		return awsCloudConfig, partialErr
+	}
 	return awsCloudConfig, nil
 }
 
-// addCertToSSH takes the cert file downloaded from AWS and moves it to .ssh directory
-func addCertToSSH(certName string) error {
-	certFilePath, err := app.GetSSHCertFilePath(certName)
-	if err != nil {
-		return err
+// provisionRegions runs [provision] for [regions] as set by --parallel-regions and --partial-ok, and
+// returns the instance IDs and public IPs created by region. When regions fail, [cleanup] is called
+// with the ones that won't be kept, together with the instance IDs created in them. If only some of
+// the regions failed with --partial-ok, the created ones are returned with a partialRegionsError
+func provisionRegions(
+	regions []string,
+	provision func(region string) ([]string, []string, error),
+	cleanup func(failedRegions []string, instanceIDs map[string][]string),
+) ([]string, map[string][]string, map[string][]string, error) {
+	instanceIDs, publicIPs, err := forEachRegion(regions, parallelRegions, partialOK, provision)
+	if err == nil {
+		return regions, instanceIDs, publicIPs, nil
 	}
+	failedRegions := getFailedRegions(err)
+	if !partialOK || len(failedRegions) == len(regions) {
+		// the instances of all regions are destroyed by the caller
+		cleanup(regions, instanceIDs)
+		return regions, instanceIDs, publicIPs, err
+	}
+	// the regions that were created are kept, and the failed ones reported to the caller
+	cleanup(utils.Filter(regions, func(region string) bool {
+		_, failed := failedRegions[region]
+		return failed
+	}), instanceIDs)
+	return utils.Filter(regions, func(region string) bool {
+		_, failed := failedRegions[region]
+		return !failed
+	}), instanceIDs, publicIPs, &partialRegionsError{failed: failedRegions}
+}
+
+// createdRegionAccess is the key pair and security group created by createEC2Instances in a region
+type createdRegionAccess struct {
+	keyPair         string
+	keyPairCertPath string
+	securityGroupID string
+}
+
+// deleteCreatedRegionAccess deletes the key pairs and security groups in [created], as well as the
+// key pair files downloaded for them. Security groups used by [instanceIDs] can't be deleted until
+// the instances are terminated, so they are listed to the user instead
+func deleteCreatedRegionAccess(
+	ec2Svc map[string]*awsAPI.AwsCloud,
+	created map[string]createdRegionAccess,
+	instanceIDs map[string][]string,
+) {
+	regions := maps.Keys(created)
+	slices.Sort(regions)
+	for _, region := range regions {
+		regionAccess := created[region]
+		if regionAccess.keyPair != "" {
+			if err := ec2Svc[region].DeleteKeyPair(regionAccess.keyPair); err != nil {
+				ux.Logger.RedXToUser("Could not delete key pair %s in AWS[%s]: %s. Delete it on AWS console", regionAccess.keyPair, region, err)
+			} else {
+				ux.Logger.PrintToUser("Deleted key pair %s in AWS[%s]", regionAccess.keyPair, region)
+			}
+			if regionAccess.keyPairCertPath != "" {
+				if err := os.RemoveAll(regionAccess.keyPairCertPath); err != nil {
+					ux.Logger.RedXToUser("Could not delete key pair file %s: %s", regionAccess.keyPairCertPath, err)
+				}
+			}
+		}
+		if regionAccess.securityGroupID == "" {
+			continue
+		}
+		if len(instanceIDs[region]) > 0 {
+			ux.Logger.PrintToUser("Security group %s in AWS[%s] is still used by the instances being destroyed. Delete it on AWS console once they are terminated", regionAccess.securityGroupID, region)
+			continue
+		}
+		if err := ec2Svc[region].DeleteSecurityGroup(regionAccess.securityGroupID); err != nil {
+			ux.Logger.RedXToUser("Could not delete security group %s in AWS[%s]: %s. Delete it on AWS console", regionAccess.securityGroupID, region, err)
+		} else {
+			ux.Logger.PrintToUser("Deleted security group %s in AWS[%s]", regionAccess.securityGroupID, region)
+		}
+	}
+}
+
+// regionError is the error of provisioning a region
+type regionError struct {
+	region string
+	err    error
+}
+
+func (e *regionError) Error() string {
+	return fmt.Sprintf("AWS[%s]: %s", e.region, e.err)
+}
+
+func (e *regionError) Unwrap() error {
+	return e.err
+}
+
+// getFailedRegions returns the errors by region contained in [err], as returned by forEachRegion
+func getFailedRegions(err error) map[string]error {
+	failed := map[string]error{}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, err := range errs {
+		var regionErr *regionError
+		if errors.As(err, &regionErr) {
+			failed[regionErr.region] = regionErr.err
+		}
+	}
+	return failed
+}
+
+// partialRegionsError is returned, together with the cloud config of the regions that were
+// created, when some regions failed with --partial-ok
+type partialRegionsError struct {
+	failed map[string]error
+}
+
+func (e *partialRegionsError) Error() string {
+	regions := maps.Keys(e.failed)
+	slices.Sort(regions)
+	msgs := utils.Map(regions, func(region string) string {
+		return fmt.Sprintf("%s: %s", region, e.failed[region])
+	})
+	return fmt.Sprintf("failed to create node(s) in %d region(s): %s", len(regions), strings.Join(msgs, "; "))
+}
+
+// forEachRegion runs [provision] for each of [regions], one after the other or, if [parallel],
+// concurrently, and aggregates the instance IDs and public IPs it returns by region. The outputs
+// of failed regions are also aggregated, so that what they created can be destroyed. Sequential
+// runs stop at the first failed region unless [continueOnError], while parallel runs return the
+// errors of all of them. Errors are returned as regionError, joined if more than one
+func forEachRegion(
+	regions []string,
+	parallel bool,
+	continueOnError bool,
+	provision func(region string) ([]string, []string, error),
+) (map[string][]string, map[string][]string, error) {
+	instanceIDs := map[string][]string{}
+	publicIPs := map[string][]string{}
+	if !parallel {
+		regionErrs := []error{}
+		for _, region := range regions {
+			var err error
+			instanceIDs[region], publicIPs[region], err = provision(region)
+			if err != nil {
+				regionErrs = append(regionErrs, &regionError{region: region, err: err})
+				if !continueOnError {
+					break
+				}
+			}
+		}
+		return instanceIDs, publicIPs, errors.Join(regionErrs...)
+	}
+	regionInstanceIDs := make([][]string, len(regions))
+	regionPublicIPs := make([][]string, len(regions))
+	regionErrs := make([]error, len(regions))
+	wg := sync.WaitGroup{}
+	for i, region := range regions {
+		wg.Add(1)
+		go func(i int, region string) {
+			defer wg.Done()
+			regionInstanceIDs[i], regionPublicIPs[i], regionErrs[i] = provision(region)
+		}(i, region)
+	}
+	wg.Wait()
+	for i, region := range regions {
+		instanceIDs[region] = regionInstanceIDs[i]
+		publicIPs[region] = regionPublicIPs[i]
+		if regionErrs[i] != nil {
+			regionErrs[i] = &regionError{region: region, err: regionErrs[i]}
+		}
+	}
+	return instanceIDs, publicIPs, errors.Join(regionErrs...)
+}
+
+// createRegionEC2Instances creates the EC2 instances of [region] with key pair [keyPairName] and
+// security group [sgID], waits for them to run, and gets their public IPs. Instances and elastic
+// IPs created before a failure are also returned, so that they can be destroyed
+func createRegionEC2Instances(
+	ec2Svc *awsAPI.AwsCloud,
+	region string,
+	regionConf models.RegionConfig,
+	keyPairName string,
+	sgID string,
+	forMonitoring bool,
+) ([]string, []string, error) {
+	var (
+		instanceIDs []string
+		publicIPs   []string
+		err         error
+	)
+	if instanceIDs, err = ec2Svc.CreateEC2Instances(
+		regionConf.Prefix,
+		regionConf.NumNodes,
+		regionConf.ImageID,
+		regionConf.InstanceType,
+		keyPairName,
+		sgID,
+		awsSubnetID,
+		forMonitoring,
+		iops,
+		throughput,
+		stringToAWSVolumeType(volumeType),
+		volumeSize,
+		instanceTags,
+	); err != nil {
+		return instanceIDs, publicIPs, err
+	}
+	if parallelRegions {
+		// spinners of concurrent regions would overwrite each other
+		ux.Logger.PrintToUser("Waiting for EC2 instance(s) in AWS[%s] to be provisioned...", region)
+		if err := ec2Svc.WaitForEC2Instances(instanceIDs, types.InstanceStateNameRunning); err != nil {
+			return instanceIDs, publicIPs, err
+		}
+		ux.Logger.GreenCheckmarkToUser("EC2 instance(s) in AWS[%s] provisioned", region)
+	} else {
+		spinSession := ux.NewUserSpinner()
+		spinner := spinSession.SpinToUser("Waiting for EC2 instance(s) in AWS[%s] to be provisioned...", region)
+		if err := ec2Svc.WaitForEC2Instances(instanceIDs, types.InstanceStateNameRunning); err != nil {
+			ux.SpinFailWithError(spinner, "", err)
+			return instanceIDs, publicIPs, err
+		}
+		ux.SpinComplete(spinner)
+		spinSession.Stop()
+	}
+	if useStaticIP {
+		publicIPs = []string{}
+		reusedEIPs := []awsAPI.ElasticIP{}
+		if reuseEIPs {
+			availableEIPs, err := ec2Svc.GetUnassociatedEIPs()
+			if err != nil {
+				return instanceIDs, publicIPs, err
+			}
+			reusedEIPs, _ = awsAPI.SelectEIPsToReuse(availableEIPs, regionConf.NumNodes)
+			if len(reusedEIPs) > 0 {
+				ux.Logger.PrintToUser("Reusing %d existing elastic IP(s) in AWS[%s]", len(reusedEIPs), region)
+			}
+		}
+		for count := 0; count < regionConf.NumNodes; count++ {
+			var allocationID, publicIP string
+			reused := count < len(reusedEIPs)
+			if reused {
+				allocationID, publicIP = reusedEIPs[count].AllocationID, reusedEIPs[count].PublicIP
+			} else {
+				allocationID, publicIP, err = ec2Svc.CreateEIP(regionConf.Prefix)
+				if err != nil {
+					return instanceIDs, publicIPs, &awsAPI.EIPAllocationError{
+						Region:    region,
+						Allocated: count,
+						Requested: regionConf.NumNodes,
+						Err:       err,
+					}
+				}
+				// kept before association so that it is released on cleanup if association fails
+				publicIPs = append(publicIPs, publicIP)
+			}
+			if err := ec2Svc.AssociateEIP(instanceIDs[count], allocationID); err != nil {
+				return instanceIDs, publicIPs, &awsAPI.EIPAllocationError{
+					Region:    region,
+					Allocated: count,
+					Requested: regionConf.NumNodes,
+					Err:       err,
+				}
+			}
+			if reused {
+				// a reused address is only kept once associated, so that it is not released on cleanup before
+				publicIPs = append(publicIPs, publicIP)
+			}
+		}
+	} else {
+		instanceEIPMap, err := ec2Svc.GetInstancePublicIPs(instanceIDs)
+		if err != nil {
+			return instanceIDs, publicIPs, err
+		}
+		for _, instanceID := range instanceIDs {
+			publicIPs = append(publicIPs, instanceEIPMap[instanceID])
+		}
+	}
+	return instanceIDs, publicIPs, nil
+}
+
+// addCertToSSH adds the cert file downloaded from AWS to the ssh-agent
+func addCertToSSH(certFilePath string) error {
 	cmd := exec.Command("ssh-add", certFilePath)
 	utils.SetupRealtimeCLIOutput(cmd, true, true)
 	return cmd.Run()
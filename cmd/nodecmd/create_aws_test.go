@@ -0,0 +1,173 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachRegion(t *testing.T) {
+	regions := []string{"us-east-1", "eu-west-1", "ap-south-1"}
+	errQuota := errors.New("quota exceeded")
+	newProvision := func(failingRegion string) (func(string) ([]string, []string, error), *[]string) {
+		mu := sync.Mutex{}
+		provisioned := []string{}
+		return func(region string) ([]string, []string, error) {
+			mu.Lock()
+			provisioned = append(provisioned, region)
+			mu.Unlock()
+			if region == failingRegion {
+				// an instance was created before failing
+				return []string{region + "-i0"}, nil, errQuota
+			}
+			return []string{region + "-i0", region + "-i1"}, []string{region + "-ip0", region + "-ip1"}, nil
+		}, &provisioned
+	}
+	expectedInstanceIDs := map[string][]string{
+		"us-east-1":  {"us-east-1-i0", "us-east-1-i1"},
+		"eu-west-1":  {"eu-west-1-i0", "eu-west-1-i1"},
+		"ap-south-1": {"ap-south-1-i0", "ap-south-1-i1"},
+	}
+	expectedPublicIPs := map[string][]string{
+		"us-east-1":  {"us-east-1-ip0", "us-east-1-ip1"},
+		"eu-west-1":  {"eu-west-1-ip0", "eu-west-1-ip1"},
+		"ap-south-1": {"ap-south-1-ip0", "ap-south-1-ip1"},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		provision, provisioned := newProvision("")
+		instanceIDs, publicIPs, err := forEachRegion(regions, parallel, false, provision)
+		require.NoError(t, err)
+		require.Equal(t, expectedInstanceIDs, instanceIDs)
+		require.Equal(t, expectedPublicIPs, publicIPs)
+		require.ElementsMatch(t, regions, *provisioned)
+	}
+
+	// sequential runs stop at the failed region, keeping what it created
+	provision, provisioned := newProvision("eu-west-1")
+	instanceIDs, publicIPs, err := forEachRegion(regions, false, false, provision)
+	require.ErrorIs(t, err, errQuota)
+	require.Equal(t, []string{"us-east-1", "eu-west-1"}, *provisioned)
+	require.Equal(t, map[string][]string{
+		"us-east-1": {"us-east-1-i0", "us-east-1-i1"},
+		"eu-west-1": {"eu-west-1-i0"},
+	}, instanceIDs)
+	require.Equal(t, map[string][]string{
+		"us-east-1": {"us-east-1-ip0", "us-east-1-ip1"},
+		"eu-west-1": nil,
+	}, publicIPs)
+
+	// parallel runs provision every region, and the error names the failed one
+	provision, provisioned = newProvision("eu-west-1")
+	instanceIDs, publicIPs, err = forEachRegion(regions, true, false, provision)
+	require.ErrorIs(t, err, errQuota)
+	require.ErrorContains(t, err, "AWS[eu-west-1]: quota exceeded")
+	require.ElementsMatch(t, regions, *provisioned)
+	require.Equal(t, []string{"eu-west-1-i0"}, instanceIDs["eu-west-1"])
+	require.Equal(t, expectedInstanceIDs["ap-south-1"], instanceIDs["ap-south-1"])
+	require.Equal(t, expectedPublicIPs["us-east-1"], publicIPs["us-east-1"])
+	require.Nil(t, publicIPs["eu-west-1"])
+}
+
+func TestForEachRegionPartialFailure(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	defer func() {
+		app = nil
+		partialOK = false
+		parallelRegions = false
+	}()
+	regions := []string{"us-east-1", "eu-west-1", "ap-south-1"}
+	errQuota := errors.New("quota exceeded")
+	provision := func(region string) ([]string, []string, error) {
+		if region == "eu-west-1" {
+			return []string{region + "-i0"}, nil, errQuota
+		}
+		return []string{region + "-i0", region + "-i1"}, []string{region + "-ip0", region + "-ip1"}, nil
+	}
+	cleanedUp := [][]string{}
+	cleanup := func(failedRegions []string, instanceIDs map[string][]string) {
+		cleanedUp = append(cleanedUp, failedRegions)
+		require.Equal([]string{"eu-west-1-i0"}, instanceIDs["eu-west-1"])
+	}
+
+	// without --partial-ok, every region is cleaned up
+	partialOK = false
+	createdRegions, _, _, err := provisionRegions(regions, provision, cleanup)
+	require.ErrorIs(err, errQuota)
+	var partialErr *partialRegionsError
+	require.False(errors.As(err, &partialErr))
+	require.Equal(regions, createdRegions)
+	require.Equal([][]string{regions}, cleanedUp)
+
+	// sequential runs provision the regions after the failed one when continuing on errors
+	partialOK = true
+	for _, parallel := range []bool{false, true} {
+		parallelRegions = parallel
+		cleanedUp = [][]string{}
+		createdRegions, instanceIDs, publicIPs, err := provisionRegions(regions, provision, cleanup)
+		require.ErrorAs(err, &partialErr)
+		require.EqualError(err, "failed to create node(s) in 1 region(s): eu-west-1: quota exceeded")
+		require.Len(instanceIDs, 3)
+		require.Equal([]string{"us-east-1", "ap-south-1"}, createdRegions)
+		// only the failed region is cleaned up
+		require.Equal([][]string{{"eu-west-1"}}, cleanedUp)
+
+		// the regions that were created are recorded in the clusters config
+		cloudConfig := models.CloudConfig{}
+		for _, region := range createdRegions {
+			cloudConfig[region] = models.RegionConfig{InstanceIDs: instanceIDs[region], PublicIPs: publicIPs[region]}
+		}
+		clusterName := "partial"
+		if parallel {
+			clusterName = "partialParallel"
+		}
+		require.NoError(CreateClusterNodeConfig(
+			models.NewFujiNetwork(),
+			cloudConfig,
+			models.RegionConfig{},
+			"",
+			clusterName,
+			constants.AWSCloudService,
+			false,
+		))
+		clustersConfig, err := app.LoadClustersConfig()
+		require.NoError(err)
+		require.ElementsMatch(
+			[]string{"us-east-1-i0", "us-east-1-i1", "ap-south-1-i0", "ap-south-1-i1"},
+			clustersConfig.Clusters[clusterName].Nodes,
+		)
+		nodeConfig, err := app.LoadClusterNodeConfig("ap-south-1-i1")
+		require.NoError(err)
+		require.Equal("ap-south-1", nodeConfig.Region)
+		require.Equal("ap-south-1-ip1", nodeConfig.ElasticIP)
+		_, err = app.LoadClusterNodeConfig("eu-west-1-i0")
+		require.Error(err)
+	}
+}
+
+func TestGetRetryRegionsCommand(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(
+		"avalanche node create myCluster --aws --region eu-west-1,us-east-1 --num-validators 3,2",
+		getRetryRegionsCommand("myCluster", map[string]NumNodes{"us-east-1": {2, 0}, "eu-west-1": {3, 0}}),
+	)
+	require.Equal(
+		"avalanche node create myCluster --aws --region eu-west-1,us-east-1 --num-validators 3,2 --num-apis 0,1",
+		getRetryRegionsCommand("myCluster", map[string]NumNodes{"us-east-1": {2, 1}, "eu-west-1": {3, 0}}),
+	)
+}
@@ -18,9 +18,11 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/key"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/remoteconfig"
 	"github.com/ava-labs/avalanche-cli/pkg/ssh"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/wireguard"
 	"github.com/ava-labs/avalanchego/config"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -148,7 +150,9 @@ func generateCustomGenesis(
 	return json.MarshalIndent(genesisMap, "", " ")
 }
 
-func setupDevnet(clusterName string, hosts []*models.Host, apiNodeIPMap map[string]string) error {
+// setupDevnet generates the devnet genesis and node configs of [hosts], and restarts them on the devnet.
+// If [bootstrapPeers] is not empty, nodes bootstrap from it instead of from the cluster validators
+func setupDevnet(clusterName string, hosts []*models.Host, apiNodeIPMap map[string]string, bootstrapPeers remoteconfig.BootstrapPeers) error {
 	if err := checkCluster(clusterName); err != nil {
 		return err
 	}
@@ -172,24 +176,10 @@ func setupDevnet(clusterName string, hosts []*models.Host, apiNodeIPMap map[stri
 	} else {
 		endpointIP = ansibleHosts[ansibleHostIDs[0]].IP
 	}
-	endpoint := fmt.Sprintf("http://%s:%d", endpointIP, constants.AvalanchegoAPIPort)
-	network := models.NewDevnetNetwork(endpoint, 0)
+	endpoint := fmt.Sprintf("http://%s:%d", endpointIP, ansibleHosts[ansibleHostIDs[0]].GetHTTPPort())
+	network := models.NewDevnetNetwork(endpoint, joinNetworkID)
 	network = models.NewNetworkFromCluster(network, clusterName)
 
-	// get random staking key for devnet genesis
-	k, err := key.NewSoft(network.ID)
-	if err != nil {
-		return err
-	}
-	stakingAddrStr := k.X()[0]
-
-	// get ewoq key as funded key for devnet genesis
-	k, err = key.LoadEwoq(network.ID)
-	if err != nil {
-		return err
-	}
-	walletAddrStr := k.X()[0]
-
 	// exclude API nodes from genesis file generation as they will have no stake
 	hostsAPI := utils.Filter(hosts, func(h *models.Host) bool {
 		return slices.Contains(maps.Keys(apiNodeIPMap), h.GetCloudID())
@@ -199,16 +189,50 @@ func setupDevnet(clusterName string, hosts []*models.Host, apiNodeIPMap map[stri
 	})
 	hostsWithoutAPIIDs := utils.Map(hostsWithoutAPI, func(h *models.Host) string { return h.NodeID })
 
-	// create genesis file at each node dir
-	genesisBytes, err := generateCustomGenesis(network.ID, walletAddrStr, stakingAddrStr, hostsWithoutAPI)
-	if err != nil {
-		return err
+	// create genesis file at each node dir, reusing the genesis of the devnet being joined if any
+	genesisBytes := joinGenesis
+	if bootstrapPeers.IsEmpty() {
+		// get random staking key for devnet genesis
+		k, err := key.NewSoft(network.ID)
+		if err != nil {
+			return err
+		}
+		stakingAddrStr := k.X()[0]
+
+		// get ewoq key as funded key for devnet genesis
+		k, err = key.LoadEwoq(network.ID)
+		if err != nil {
+			return err
+		}
+		walletAddrStr := k.X()[0]
+
+		if genesisBytes, err = generateCustomGenesis(network.ID, walletAddrStr, stakingAddrStr, hostsWithoutAPI); err != nil {
+			return err
+		}
 	}
 	// make sure that custom genesis is saved to the subnet dir
 	if err := os.WriteFile(app.GetGenesisPath(subnetName), genesisBytes, constants.WriteReadReadPerms); err != nil {
 		return err
 	}
 
+	// node to node traffic goes through the mesh if there is one. Nodes added to a cluster
+	// with a mesh join it
+	clustersConfig, err := app.LoadClustersConfig()
+	if err != nil {
+		return err
+	}
+	meshIPs := clustersConfig.Clusters[clusterName].MeshIPs
+	nodeIPs := map[string]string{}
+	for _, host := range hosts {
+		nodeIPs[host.GetCloudID()] = host.IP
+	}
+	if meshMode == wireguard.MeshMode || len(meshIPs) > 0 {
+		if meshIPs, err = setupWireguardMesh(clusterName, hosts, meshIPs); err != nil {
+			return err
+		}
+		nodeIPs = meshIPs
+	}
+
 	// create avalanchego conf node.json at each node dir
 	bootstrapIPs := []string{}
 	bootstrapIDs := []string{}
@@ -216,11 +240,20 @@ func setupDevnet(clusterName string, hosts []*models.Host, apiNodeIPMap map[stri
 	for _, host := range append(hostsWithoutAPI, hostsAPI...) {
 		confMap := map[string]interface{}{}
 		confMap[config.HTTPHostKey] = ""
-		confMap[config.PublicIPKey] = host.IP
+		confMap[config.PublicIPKey] = nodeIPs[host.GetCloudID()]
 		confMap[config.NetworkNameKey] = fmt.Sprintf("network-%d", network.ID)
 		confMap[config.BootstrapIDsKey] = strings.Join(bootstrapIDs, ",")
 		confMap[config.BootstrapIPsKey] = strings.Join(bootstrapIPs, ",")
 		confMap[config.GenesisFileKey] = filepath.Join(constants.DockerNodeConfigPath, "genesis.json")
+		if host.HTTPPort != 0 {
+			confMap[config.HTTPPortKey] = host.HTTPPort
+		}
+		if host.StakingPort != 0 {
+			confMap[config.StakingPortKey] = host.StakingPort
+		}
+		if logLevel != "" {
+			confMap[config.LogLevelKey] = logLevel
+		}
 		confBytes, err := json.MarshalIndent(confMap, "", " ")
 		if err != nil {
 			return err
@@ -237,7 +270,7 @@ func setupDevnet(clusterName string, hosts []*models.Host, apiNodeIPMap map[stri
 				return err
 			}
 			bootstrapIDs = append(bootstrapIDs, nodeID.String())
-			bootstrapIPs = append(bootstrapIPs, fmt.Sprintf("%s:9651", host.IP))
+			bootstrapIPs = append(bootstrapIPs, fmt.Sprintf("%s:%d", nodeIPs[host.GetCloudID()], host.GetStakingPort()))
 		}
 	}
 	// update node/s genesis + conf and start
@@ -249,7 +282,7 @@ func setupDevnet(clusterName string, hosts []*models.Host, apiNodeIPMap map[stri
 			defer wg.Done()
 
 			keyPath := filepath.Join(app.GetNodesDir(), host.GetCloudID())
-			if err := ssh.RunSSHSetupDevNet(host, keyPath); err != nil {
+			if err := ssh.RunSSHSetupDevNet(host, keyPath, bootstrapPeers); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
 				ux.Logger.RedXToUser(utils.ScriptLog(host.NodeID, "Setup devnet err: %v", err))
 				return
@@ -278,13 +311,99 @@ func setupDevnet(clusterName string, hosts []*models.Host, apiNodeIPMap map[stri
 	ux.Logger.PrintToUser("Devnet Network Id: %s", logging.Green.Wrap(strconv.FormatUint(uint64(network.ID), 10)))
 	ux.Logger.PrintToUser("Devnet Endpoint: %s", logging.Green.Wrap(network.Endpoint))
 	ux.Logger.PrintLineSeparator()
-	// update cluster config with network information
-	clustersConfig, err := app.LoadClustersConfig()
+	// update cluster config with network and mesh information
+	clustersConfig, err = app.LoadClustersConfig()
 	if err != nil {
 		return err
 	}
 	clusterConfig := clustersConfig.Clusters[clusterName]
 	clusterConfig.Network = network
+	clusterConfig.MeshIPs = meshIPs
 	clustersConfig.Clusters[clusterName] = clusterConfig
 	return app.WriteClustersConfigFile(&clustersConfig)
 }
+
+// setupWireguardMesh connects [hosts] through a Wireguard mesh. If [clusterMeshIPs] is not empty, [hosts]
+// join the mesh of cluster [clusterName] with those mesh IPs, by cloud ID, and its members get the new peers.
+// Keys are generated locally, and each node config is saved at its node dir before being uploaded.
+// Returns the mesh IP of each node, by cloud ID
+func setupWireguardMesh(clusterName string, hosts []*models.Host, clusterMeshIPs map[string]string) (map[string]string, error) {
+	cloudIDs := utils.Map(hosts, func(h *models.Host) string { return h.GetCloudID() })
+	existingMembers := []wireguard.Member{}
+	existingHosts := []*models.Host{}
+	if len(clusterMeshIPs) > 0 {
+		inventoryHosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
+		if err != nil {
+			return nil, err
+		}
+		for _, host := range inventoryHosts {
+			meshIP, ok := clusterMeshIPs[host.GetCloudID()]
+			if !ok || slices.Contains(cloudIDs, host.GetCloudID()) {
+				continue
+			}
+			// keys of the members are kept in their config at their node dir
+			config, err := os.ReadFile(filepath.Join(app.GetNodeInstanceDirPath(host.GetCloudID()), wireguard.InterfaceName+".conf"))
+			if err != nil {
+				return nil, fmt.Errorf("could not read wireguard config of mesh node %s: %w", host.GetCloudID(), err)
+			}
+			privateKey, err := wireguard.GetConfigPrivateKey(config)
+			if err != nil {
+				return nil, fmt.Errorf("mesh node %s: %w", host.GetCloudID(), err)
+			}
+			publicKey, err := wireguard.GetPublicKey(privateKey)
+			if err != nil {
+				return nil, fmt.Errorf("mesh node %s: %w", host.GetCloudID(), err)
+			}
+			existingMembers = append(existingMembers, wireguard.Member{
+				Name:       host.GetCloudID(),
+				PublicIP:   host.IP,
+				MeshIP:     meshIP,
+				PrivateKey: privateKey,
+				PublicKey:  publicKey,
+			})
+			existingHosts = append(existingHosts, host)
+		}
+	}
+	publicIPs := map[string]string{}
+	for _, host := range hosts {
+		publicIPs[host.GetCloudID()] = host.IP
+	}
+	members, err := wireguard.ExtendMesh(existingMembers, cloudIDs, publicIPs)
+	if err != nil {
+		return nil, err
+	}
+	// all members get the updated config, so the existing ones peer with the new ones
+	hosts = append(existingHosts, hosts...)
+	meshIPs := map[string]string{}
+	for _, member := range members {
+		config, err := wireguard.RenderConfig(member, members)
+		if err != nil {
+			return nil, err
+		}
+		configPath := filepath.Join(app.GetNodeInstanceDirPath(member.Name), wireguard.InterfaceName+".conf")
+		if err := os.WriteFile(configPath, config, constants.WriteReadUserOnlyPerms); err != nil {
+			return nil, err
+		}
+		meshIPs[member.Name] = member.MeshIP
+	}
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			configPath := filepath.Join(app.GetNodeInstanceDirPath(host.GetCloudID()), wireguard.InterfaceName+".conf")
+			if err := ssh.RunSSHSetupWireguard(host, configPath); err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				ux.Logger.RedXToUser(utils.ScriptLog(host.NodeID, "Setup wireguard err: %v", err))
+				return
+			}
+			ux.Logger.GreenCheckmarkToUser(utils.ScriptLog(host.NodeID, "Setup wireguard with mesh IP %s", meshIPs[host.GetCloudID()]))
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	if wgResults.HasErrors() {
+		return nil, fmt.Errorf("failed to setup wireguard mesh on node(s) %s", wgResults.GetErrorHostMap())
+	}
+	return meshIPs, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -23,8 +24,67 @@ import (
 
 	gcpAPI "github.com/ava-labs/avalanche-cli/pkg/cloud/gcp"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/wireguard"
 )
 
+// gcpDefaultZones is the curated list of zones offered when prompting for GCP locations
+var gcpDefaultZones = []string{"us-east1-b", "us-central1-a", "us-west1-a", "europe-west1-b", "asia-southeast1-b"}
+
+var gcpZoneRegexp = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+-[a-z]$`)
+
+// isGCPZone returns true if [location] is a GCP zone (e.g. us-east1-b)
+// rather than a GCP region (e.g. us-east1)
+func isGCPZone(location string) bool {
+	return gcpZoneRegexp.MatchString(location)
+}
+
+// gcpZoneToRegion returns the region a GCP zone belongs to
+func gcpZoneToRegion(zone string) string {
+	return zone[:strings.LastIndex(zone, "-")]
+}
+
+func validateGCPZone(input string) error {
+	if !isGCPZone(input) {
+		return fmt.Errorf("invalid GCP zone %q, expected format is <region>-<zone>, e.g. us-east1-b", input)
+	}
+	return validateGCPLocation(input)
+}
+
+// validateGCPLocation checks that [location] is in a known GCP region, unless --skip-region-check
+// is given, as the list of known regions may miss recently launched ones
+func validateGCPLocation(location string) error {
+	if skipRegionCheck {
+		return nil
+	}
+	if err := gcpAPI.ValidateLocation(location); err != nil {
+		return fmt.Errorf("%w. Use --skip-region-check if it is in a recently launched region", err)
+	}
+	return nil
+}
+
+func splitGCPZones(input string) []string {
+	return utils.Filter(utils.Map(strings.Split(input, ","), strings.TrimSpace), func(s string) bool {
+		return s != ""
+	})
+}
+
+// validateGCPZones validates a comma separated list of unique GCP zones
+func validateGCPZones(input string) error {
+	zones := splitGCPZones(input)
+	if len(zones) == 0 {
+		return errors.New("at least one GCP zone must be provided")
+	}
+	for _, zone := range zones {
+		if err := validateGCPZone(zone); err != nil {
+			return err
+		}
+	}
+	if len(utils.Unique(zones)) != len(zones) {
+		return errors.New("GCP zones must be unique")
+	}
+	return nil
+}
+
 func getServiceAccountKeyFilepath() (string, error) {
 	if cmdLineGCPCredentialsPath != "" {
 		return cmdLineGCPCredentialsPath, nil
@@ -123,6 +183,12 @@ func getGCPConfig(singleNode bool) (*gcpAPI.GcpCloud, map[string]NumNodes, strin
 			}
 		}
 	}
+	// catch location typos before calling GCP
+	for location := range finalRegions {
+		if err := validateGCPLocation(location); err != nil {
+			return nil, nil, "", "", "", err
+		}
+	}
 	gcpClient, projectName, gcpCredentialFilePath, err := getGCPCloudCredentials()
 	if err != nil {
 		return nil, nil, "", "", "", err
@@ -132,17 +198,32 @@ func getGCPConfig(singleNode bool) (*gcpAPI.GcpCloud, map[string]NumNodes, strin
 		return nil, nil, "", "", "", err
 	}
 	finalZones := map[string]NumNodes{}
-	// verify regions are valid and place in random zones per region
-	for region, numNodes := range finalRegions {
-		if !slices.Contains(gcpCloud.ListRegions(), region) {
-			return nil, nil, "", "", "", fmt.Errorf("invalid region %s", region)
-		} else {
-			finalZone, err := gcpCloud.GetRandomZone(region)
+	regions := gcpCloud.ListRegions()
+	// verify locations are valid. zones are used as given, regions get a random zone
+	for location, numNodes := range finalRegions {
+		if isGCPZone(location) {
+			region := gcpZoneToRegion(location)
+			if !slices.Contains(regions, region) {
+				return nil, nil, "", "", "", fmt.Errorf("invalid region %s for zone %s", region, location)
+			}
+			zones, err := gcpCloud.ListZonesInRegion(region)
 			if err != nil {
 				return nil, nil, "", "", "", err
 			}
-			finalZones[finalZone] = numNodes
+			if !slices.Contains(zones, location) {
+				return nil, nil, "", "", "", fmt.Errorf("invalid zone %s", location)
+			}
+			finalZones[location] = numNodes
+			continue
+		}
+		if !slices.Contains(regions, location) {
+			return nil, nil, "", "", "", fmt.Errorf("invalid region %s", location)
+		}
+		finalZone, err := gcpCloud.GetRandomZone(location)
+		if err != nil {
+			return nil, nil, "", "", "", err
 		}
+		finalZones[finalZone] = numNodes
 	}
 	imageID, err := gcpCloud.GetUbuntuImageID()
 	if err != nil {
@@ -187,13 +268,13 @@ func createGCEInstances(gcpClient *gcpAPI.GcpCloud,
 	if err != nil {
 		return nil, nil, "", "", err
 	}
-	userIPAddress, err := utils.GetUserIPAddress()
+	userIPAddress, err := utils.GetUserIPAddressForVersion(userIPVersion)
 	if err != nil {
 		return nil, nil, "", "", err
 	}
 	if !networkExists {
 		ux.Logger.PrintToUser("Creating new network %s in GCP", networkName)
-		if _, err := gcpClient.SetupNetwork(userIPAddress, networkName); err != nil {
+		if _, err := gcpClient.SetupNetwork(userIPAddress, sshCIDRs, networkName, httpPort, stakingPort); err != nil {
 			return nil, nil, "", "", err
 		}
 	} else {
@@ -204,16 +285,18 @@ func createGCEInstances(gcpClient *gcpAPI.GcpCloud,
 			return nil, nil, "", "", err
 		}
 		if !firewallExists {
+			ports := []string{
+				strconv.Itoa(constants.AvalanchegoMonitoringPort),
+				strconv.Itoa(constants.AvalanchegoGrafanaPort),
+			}
+			if len(sshCIDRs) == 0 {
+				ports = append([]string{strconv.Itoa(constants.SSHTCPPort), strconv.Itoa(int(httpPort))}, ports...)
+			}
 			_, err := gcpClient.SetFirewallRule(
 				userIPAddress,
 				firewallName,
 				networkName,
-				[]string{
-					strconv.Itoa(constants.SSHTCPPort),
-					strconv.Itoa(constants.AvalanchegoAPIPort),
-					strconv.Itoa(constants.AvalanchegoMonitoringPort),
-					strconv.Itoa(constants.AvalanchegoGrafanaPort),
-				},
+				ports,
 			)
 			if err != nil {
 				return nil, nil, "", "", err
@@ -242,6 +325,55 @@ func createGCEInstances(gcpClient *gcpAPI.GcpCloud,
 					return nil, nil, "", "", err
 				}
 			}
+			if httpPort != constants.AvalanchegoAPIPort && len(sshCIDRs) == 0 {
+				firewallHTTPName := fmt.Sprintf("%s-http-%d", firewallName, httpPort)
+				firewallExists, err = gcpClient.CheckFirewallExists(firewallHTTPName, false)
+				if err != nil {
+					return nil, nil, "", "", err
+				}
+				if !firewallExists {
+					if _, err := gcpClient.SetFirewallRule(userIPAddress, firewallHTTPName, networkName, []string{strconv.Itoa(int(httpPort))}); err != nil {
+						return nil, nil, "", "", err
+					}
+				}
+			}
+		}
+		if len(sshCIDRs) > 0 {
+			firewallAccessName := gcpAPI.AccessFirewallName(networkName, sshCIDRs)
+			firewallExists, err := gcpClient.CheckFirewallExists(firewallAccessName, false)
+			if err != nil {
+				return nil, nil, "", "", err
+			}
+			if !firewallExists {
+				if _, err := gcpClient.SetFirewallRuleForSourceRanges(sshCIDRs, firewallAccessName, networkName, []string{strconv.Itoa(constants.SSHTCPPort), strconv.Itoa(int(httpPort))}); err != nil {
+					return nil, nil, "", "", err
+				}
+			}
+		}
+		if stakingPort != constants.AvalanchegoP2PPort {
+			firewallStakingName := fmt.Sprintf("%s-staking-%d", networkName, stakingPort)
+			firewallExists, err := gcpClient.CheckFirewallExists(firewallStakingName, false)
+			if err != nil {
+				return nil, nil, "", "", err
+			}
+			if !firewallExists {
+				if _, err := gcpClient.SetFirewallRule("0.0.0.0/0", firewallStakingName, networkName, []string{strconv.Itoa(int(stakingPort))}); err != nil {
+					return nil, nil, "", "", err
+				}
+			}
+		}
+		// wireguard peers reach each other at their public IPs
+		if meshMode == wireguard.MeshMode {
+			firewallWireguardName := fmt.Sprintf("%s-wireguard", networkName)
+			firewallExists, err := gcpClient.CheckFirewallExists(firewallWireguardName, false)
+			if err != nil {
+				return nil, nil, "", "", err
+			}
+			if !firewallExists {
+				if _, err := gcpClient.SetFirewallRuleForProtocol([]string{"0.0.0.0/0"}, firewallWireguardName, networkName, "udp", []string{strconv.Itoa(wireguard.ListenPort)}); err != nil {
+					return nil, nil, "", "", err
+				}
+			}
 		}
 	}
 	nodeName := map[string]string{}
@@ -283,7 +415,8 @@ func createGCEInstances(gcpClient *gcpAPI.GcpCloud,
 			instanceType,
 			publicIP[zone],
 			numNodes.All(),
-			forMonitoring)
+			forMonitoring,
+			instanceTags)
 		if err != nil {
 			ux.SpinFailWithError(spinner, "", err)
 			return nil, nil, "", "", err
@@ -321,12 +454,19 @@ func createGCPInstance(
 	if err != nil {
 		return models.CloudConfig{}, err
 	}
-	for zoneToCheck := range numNodesMap {
-		isSupported, err := gcpClient.IsInstanceTypeSupported(instanceType, zoneToCheck)
-		if err != nil {
+	if gcpAPI.IsCustomMachineType(instanceType) {
+		// custom machine types are not listed by GCP, so they are only checked to be valid
+		if err := gcpAPI.ValidateCustomMachineType(instanceType); err != nil {
 			return models.CloudConfig{}, err
-		} else if !isSupported {
-			return models.CloudConfig{}, fmt.Errorf("instance type %s is not supported in %s zone", instanceType, zoneToCheck)
+		}
+	} else {
+		for zoneToCheck := range numNodesMap {
+			isSupported, err := gcpClient.IsInstanceTypeSupported(instanceType, zoneToCheck)
+			if err != nil {
+				return models.CloudConfig{}, err
+			} else if !isSupported {
+				return models.CloudConfig{}, fmt.Errorf("instance type %s is not supported in %s zone", instanceType, zoneToCheck)
+			}
 		}
 	}
 	instanceIDs, elasticIPs, certFilePath, keyPairName, err := createGCEInstances(
@@ -397,7 +537,7 @@ func updateClustersConfigGCPKeyFilepath(projectName, serviceAccountKeyFilepath s
 	return app.WriteClustersConfigFile(&clustersConfig)
 }
 
-func grantAccessToPublicIPViaFirewall(gcpClient *gcpAPI.GcpCloud, projectName string, publicIP string, label string) error {
+func grantAccessToPublicIPViaFirewall(gcpClient *gcpAPI.GcpCloud, projectName string, publicIP string, label string, httpPort uint) error {
 	prefix, err := defaultAvalancheCLIPrefix("")
 	if err != nil {
 		return err
@@ -405,7 +545,7 @@ func grantAccessToPublicIPViaFirewall(gcpClient *gcpAPI.GcpCloud, projectName st
 	networkName := fmt.Sprintf("%s-network", prefix)
 	firewallName := fmt.Sprintf("%s-%s-%s", networkName, strings.ReplaceAll(publicIP, ".", ""), label)
 	ports := []string{
-		strconv.Itoa(constants.AvalanchegoMachineMetricsPort), strconv.Itoa(constants.AvalanchegoAPIPort),
+		strconv.Itoa(constants.AvalanchegoMachineMetricsPort), strconv.Itoa(int(httpPort)),
 		strconv.Itoa(constants.AvalanchegoMonitoringPort), strconv.Itoa(constants.AvalanchegoGrafanaPort),
 		strconv.Itoa(constants.AvalanchegoLokiPort),
 	}
@@ -421,7 +561,7 @@ func grantAccessToPublicIPViaFirewall(gcpClient *gcpAPI.GcpCloud, projectName st
 	return nil
 }
 
-func setGCPAWMRelayerSecurityGroupRule(awmRelayerHost *models.Host) error {
+func setGCPAWMRelayerSecurityGroupRule(awmRelayerHost *models.Host, httpPort uint) error {
 	gcpClient, _, _, _, projectName, err := getGCPConfig(true)
 	if err != nil {
 		return err
@@ -433,7 +573,7 @@ func setGCPAWMRelayerSecurityGroupRule(awmRelayerHost *models.Host) error {
 	networkName := fmt.Sprintf("%s-network", prefix)
 	firewallName := fmt.Sprintf("%s-%s-relayer", networkName, strings.ReplaceAll(awmRelayerHost.IP, ".", ""))
 	ports := []string{
-		strconv.Itoa(constants.AvalanchegoAPIPort),
+		strconv.Itoa(int(httpPort)),
 	}
 	return gcpClient.AddFirewall(
 		awmRelayerHost.IP,
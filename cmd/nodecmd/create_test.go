@@ -0,0 +1,112 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/monitoring"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeVersions(t *testing.T) {
+	require := require.New(t)
+	versions, err := parseNodeVersions([]string{"0=v1.11.8", "2=latest"})
+	require.NoError(err)
+	require.Equal(map[int]string{0: "v1.11.8", 2: latestNodeVersion}, versions)
+
+	versions, err = parseNodeVersions(nil)
+	require.NoError(err)
+	require.Empty(versions)
+
+	_, err = parseNodeVersions([]string{"first=v1.11.8"})
+	require.ErrorContains(err, "invalid node index")
+	_, err = parseNodeVersions([]string{"-1=v1.11.8"})
+	require.ErrorContains(err, "invalid node index")
+	_, err = parseNodeVersions([]string{"0=1.11.8"})
+	require.ErrorContains(err, "invalid version")
+	_, err = parseNodeVersions([]string{"0=v1.11.8", "0=v1.11.9"})
+	require.ErrorContains(err, "more than once")
+}
+
+func TestGetHostAvalancheGoVersions(t *testing.T) {
+	require := require.New(t)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	cloudConfigMap := models.CloudConfig{
+		"us-west-2": {InstanceIDs: []string{"i-west0", "i-west1"}},
+		"us-east-1": {InstanceIDs: []string{"i-east0", "i-east1"}},
+	}
+	instanceIDs := getOrderedInstanceIDs(cloudConfigMap)
+	require.Equal([]string{"i-east0", "i-east1", "i-west0", "i-west1"}, instanceIDs)
+
+	hostVersions, err := getHostAvalancheGoVersions(instanceIDs, "v1.11.8", map[int]string{1: "v1.11.9", 3: "v1.11.10"})
+	require.NoError(err)
+	require.Equal(map[string]string{
+		"i-east0": "v1.11.8",
+		"i-east1": "v1.11.9",
+		"i-west0": "v1.11.8",
+		"i-west1": "v1.11.10",
+	}, hostVersions)
+
+	hostVersions, err = getHostAvalancheGoVersions(instanceIDs, "v1.11.8", nil)
+	require.NoError(err)
+	for _, version := range hostVersions {
+		require.Equal("v1.11.8", version)
+	}
+
+	_, err = getHostAvalancheGoVersions(instanceIDs, "v1.11.8", map[int]string{4: "v1.11.9"})
+	require.ErrorContains(err, "node index 4 is out of range, 4 node(s) are created")
+}
+
+func TestValidateRegionsSkipCheck(t *testing.T) {
+	require := require.New(t)
+	defer func() {
+		skipRegionCheck = false
+	}()
+	require.NoError(validateAWSRegion("us-east-1"))
+	require.ErrorContains(validateAWSRegion("us-east-9"), "Use --skip-region-check")
+	require.NoError(validateGCPLocation("us-east1-b"))
+	require.ErrorContains(validateGCPLocation("us-east9-b"), "Use --skip-region-check")
+
+	// regions launched after the known lists were updated are accepted
+	skipRegionCheck = true
+	require.NoError(validateAWSRegion("us-east-9"))
+	require.NoError(validateGCPLocation("us-east9-b"))
+	// the zone format is still checked
+	require.ErrorContains(validateGCPZone("us-east9"), "expected format is <region>-<zone>")
+}
+
+func TestRemoteWriteConfig(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	defer func() {
+		app = nil
+	}()
+
+	// monitoring instances created without remote write have none
+	remoteWrite, err := loadRemoteWriteConfig("i-monitoring")
+	require.NoError(err)
+	require.Nil(remoteWrite)
+
+	expected := &monitoring.RemoteWriteConfig{
+		URL:         "https://mimir.example.com/api/v1/push",
+		BearerToken: "token",
+	}
+	require.NoError(saveRemoteWriteConfig("i-monitoring", expected))
+	info, err := os.Stat(filepath.Join(app.GetNodeInstanceDirPath("i-monitoring"), constants.RemoteWriteConfigFileName))
+	require.NoError(err)
+	require.Equal(os.FileMode(constants.WriteReadUserOnlyPerms), info.Mode().Perm())
+	remoteWrite, err = loadRemoteWriteConfig("i-monitoring")
+	require.NoError(err)
+	require.Equal(expected, remoteWrite)
+}
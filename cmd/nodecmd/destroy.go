@@ -42,6 +42,7 @@ If there is a static IP address attached, it will be released.`,
 	cmd.Flags().BoolVar(&authorizeRemove, "authorize-remove", false, "authorize CLI to remove all local files related to cloud nodes")
 	cmd.Flags().BoolVarP(&authorizeAll, "authorize-all", "y", false, "authorize all CLI requests")
 	cmd.Flags().StringVar(&awsProfile, "aws-profile", constants.AWSDefaultCredential, "aws profile to use")
+	addConfirmMainnetFlag(cmd)
 
 	return cmd
 }
@@ -102,6 +103,9 @@ func destroyNodes(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkMainnetConfirmation(clusterName, "destroying its nodes"); err != nil {
+		return err
+	}
 	if authorizeAll {
 		authorizeAccess = true
 		authorizeRemove = true
@@ -147,6 +151,8 @@ func destroyNodes(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("no endpoint found in the  %s", nodeToStopConfig.CloudService)
 	}
 	var gcpCloud *gcpAPI.GcpCloud
+	gcpProjectName := ""
+	gcpCredentialsPath := ""
 	ec2SvcMap := make(map[string]*awsAPI.AwsCloud)
 	// TODO: need implementation for GCP
 	if nodeToStopConfig.CloudService == constants.AWSCloudService {
@@ -183,7 +189,7 @@ func destroyNodes(_ *cobra.Command, args []string) error {
 					ux.Logger.PrintToUser("node %s is already destroyed", nodeConfig.NodeID)
 				}
 				for _, sg := range filteredSGList {
-					if err = deleteHostSecurityGroupRule(ec2SvcMap[sg.region], nodeConfig.ElasticIP, sg.securityGroup); err != nil {
+					if err = deleteHostSecurityGroupRule(ec2SvcMap[sg.region], nodeConfig.ElasticIP, sg.securityGroup, sg.httpPort); err != nil {
 						ux.Logger.RedXToUser("unable to delete IP address %s from security group %s in region %s due to %s, please delete it manually",
 							nodeConfig.ElasticIP, sg.securityGroup, sg.region, err.Error())
 					}
@@ -193,7 +199,7 @@ func destroyNodes(_ *cobra.Command, args []string) error {
 					return fmt.Errorf("cloud access is required")
 				}
 				if gcpCloud == nil {
-					gcpClient, projectName, _, err := getGCPCloudCredentials()
+					gcpClient, projectName, credentialsPath, err := getGCPCloudCredentials()
 					if err != nil {
 						return err
 					}
@@ -201,6 +207,8 @@ func destroyNodes(_ *cobra.Command, args []string) error {
 					if err != nil {
 						return err
 					}
+					gcpProjectName = projectName
+					gcpCredentialsPath = credentialsPath
 				}
 				if err = gcpCloud.DestroyGCPNode(nodeConfig, clusterName); err != nil {
 					if !errors.Is(err, gcpAPI.ErrNodeNotFoundToBeRunning) {
@@ -210,6 +218,10 @@ func destroyNodes(_ *cobra.Command, args []string) error {
 					ux.Logger.GreenCheckmarkToUser("node %s is already destroyed", nodeConfig.NodeID)
 				}
 			}
+			if err := deleteNodeDNSRecord(nodeConfig, gcpProjectName, gcpCredentialsPath); err != nil {
+				ux.Logger.RedXToUser("unable to delete DNS record %s of node %s due to %s, please delete it manually",
+					nodeConfig.DNSName, nodeConfig.NodeID, err.Error())
+			}
 			ux.Logger.GreenCheckmarkToUser("Node instance %s in cluster %s successfully destroyed!", nodeConfig.NodeID, clusterName)
 		}
 		if err := removeDeletedNodeDirectory(node); err != nil {
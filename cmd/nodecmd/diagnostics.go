@@ -0,0 +1,116 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diagnosticsNode    string
+	diagnosticsOutput  string
+	diagnosticsLogTail int
+)
+
+func newDiagnosticsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diagnostics [clusterName]",
+		Short: "(ALPHA Warning) Collect a support bundle from a node",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node diagnostics command gathers what is needed to troubleshoot a node into a
+local tar.gz bundle:
+  - the parsed health and version of AvalancheGo
+  - the node, C-Chain and subnet configs, with private keys redacted
+  - the last lines of the AvalancheGo logs
+  - the docker compose ps and logs output
+  - the disk and memory usage of the host
+
+The bundle manifest lists every collected file, and the ones that could not be
+collected together with the reason.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: nodeDiagnostics,
+	}
+	cmd.Flags().StringVar(&diagnosticsNode, "node", "", "node to collect diagnostics from, by cloud ID, IP or NodeID. required if the cluster has more than one node")
+	cmd.Flags().StringVar(&diagnosticsOutput, "output", "", "path of the bundle to write. defaults to <clusterName>-<cloudID>-diagnostics-<timestamp>.tar.gz")
+	cmd.Flags().IntVar(&diagnosticsLogTail, "log-lines", node.DefaultDiagnosticsLogTail, "number of lines to include from the end of each log")
+	return cmd
+}
+
+// sshDiagnosticsHost collects the diagnostics of a cloud node over ssh
+type sshDiagnosticsHost struct {
+	host *models.Host
+}
+
+func (h sshDiagnosticsHost) Command(script string) ([]byte, error) {
+	return h.host.Command(script, nil, constants.SSHScriptTimeout)
+}
+
+func (h sshDiagnosticsHost) DownloadFile(remoteFile string, localFile string) error {
+	return ssh.RunSSHDownloadFile(h.host, remoteFile, localFile)
+}
+
+func (h sshDiagnosticsHost) CheckHealthy() ([]byte, error) {
+	return ssh.RunSSHCheckHealthy(h.host)
+}
+
+func (h sshDiagnosticsHost) GetVersion() ([]byte, error) {
+	return ssh.RunSSHCheckAvalancheGoVersion(h.host)
+}
+
+func (h sshDiagnosticsHost) ExpandHome(path string) string {
+	return h.host.ExpandHome(path)
+}
+
+func nodeDiagnostics(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	if diagnosticsLogTail < 1 {
+		return fmt.Errorf("log lines must be at least 1")
+	}
+	host, err := getClusterHost(clusterName, diagnosticsNode)
+	if err != nil {
+		return err
+	}
+	cloudID := host.GetCloudID()
+	defer disconnectHosts([]*models.Host{host})
+
+	bundlePath := diagnosticsOutput
+	if bundlePath == "" {
+		bundlePath = fmt.Sprintf("%s-%s-diagnostics-%s.tar.gz", clusterName, cloudID, time.Now().UTC().Format("20060102T150405Z"))
+	}
+	workDir, err := os.MkdirTemp("", "node-diagnostics-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+
+	ux.Logger.PrintToUser("Collecting diagnostics from node %s...", cloudID)
+	nodeID := cloudID
+	if avalancheNodeID, err := getNodeID(app.GetNodeInstanceDirPath(cloudID)); err == nil {
+		nodeID = avalancheNodeID.String()
+	}
+	manifest := node.CollectDiagnostics(sshDiagnosticsHost{host: host}, nodeID, workDir, diagnosticsLogTail)
+	for _, entry := range manifest.Failed() {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf("could not collect %s: %s", entry.File, entry.Error)))
+	}
+	if err := node.WriteDiagnosticsBundle(workDir, manifest, bundlePath); err != nil {
+		return err
+	}
+	if absPath, err := filepath.Abs(bundlePath); err == nil {
+		bundlePath = absPath
+	}
+	ux.Logger.GreenCheckmarkToUser("Diagnostics of node %s written to %s (%d of %d files collected)", cloudID, bundlePath, len(manifest.Entries)-len(manifest.Failed()), len(manifest.Entries))
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	awsAPI "github.com/ava-labs/avalanche-cli/pkg/cloud/aws"
+	gcpAPI "github.com/ava-labs/avalanche-cli/pkg/cloud/gcp"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+)
+
+var dnsLabelRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// dnsZoneClient creates and deletes the A records of the nodes in a DNS zone
+type dnsZoneClient interface {
+	GetZoneDomain() (string, error)
+	CreateARecords(records map[string]string) error
+	DeleteARecords(records map[string]string) error
+}
+
+// validateDNSFlags checks the --dns-zone and --dns-prefix flags for [cloudService]
+func validateDNSFlags(cloudService string) error {
+	if dnsZone == "" {
+		if dnsPrefix != "" {
+			return fmt.Errorf("--dns-prefix requires --dns-zone")
+		}
+		return nil
+	}
+	if cloudService != constants.AWSCloudService && cloudService != constants.GCPCloudService {
+		return fmt.Errorf("--dns-zone is only supported on AWS and GCP")
+	}
+	if !useStaticIP {
+		return fmt.Errorf("--dns-zone requires static IPs, as DNS records would point to stale IPs otherwise")
+	}
+	if dnsPrefix != "" && !dnsLabelRegex.MatchString(dnsPrefix) {
+		return fmt.Errorf("invalid --dns-prefix %q: it must contain only lowercase letters, digits and hyphens", dnsPrefix)
+	}
+	return nil
+}
+
+// nodeDNSName returns the DNS name of the node [instanceID], as <prefix>-<instanceID>.<domain>
+func nodeDNSName(prefix, instanceID, domain string) string {
+	return strings.ToLower(fmt.Sprintf("%s-%s.%s", prefix, instanceID, domain))
+}
+
+// getDNSZoneClient returns the client of [zone], a Route53 hosted zone ID on AWS or a
+// Cloud DNS managed zone of [gcpProjectName] on GCP, accessed with [gcpCredentialsPath]
+func getDNSZoneClient(cloudService, zone, gcpProjectName, gcpCredentialsPath string) (dnsZoneClient, error) {
+	if cloudService == constants.GCPCloudService {
+		return gcpAPI.NewCloudDNS(gcpProjectName, zone, gcpCredentialsPath, context.Background())
+	}
+	return awsAPI.NewRoute53(awsProfile, zone)
+}
+
+// createNodeDNSRecords creates an A record in --dns-zone pointing at the public IP of
+// each node of [cloudConfigMap], and sets the DNS names on the region configs
+func createNodeDNSRecords(
+	cloudService string,
+	clusterName string,
+	gcpProjectName string,
+	gcpCredentialsPath string,
+	cloudConfigMap models.CloudConfig,
+	publicIPMap map[string]string,
+) error {
+	zoneClient, err := getDNSZoneClient(cloudService, dnsZone, gcpProjectName, gcpCredentialsPath)
+	if err != nil {
+		return err
+	}
+	domain, err := zoneClient.GetZoneDomain()
+	if err != nil {
+		return err
+	}
+	prefix := dnsPrefix
+	if prefix == "" {
+		prefix = clusterName
+	}
+	records := map[string]string{}
+	regionDNSNames := map[string][]string{}
+	for region, cloudConfig := range cloudConfigMap {
+		for _, instanceID := range cloudConfig.InstanceIDs {
+			name := nodeDNSName(prefix, instanceID, domain)
+			records[name] = publicIPMap[instanceID]
+			regionDNSNames[region] = append(regionDNSNames[region], name)
+		}
+	}
+	if err := zoneClient.CreateARecords(records); err != nil {
+		return err
+	}
+	for region, cloudConfig := range cloudConfigMap {
+		cloudConfig.DNSZone = dnsZone
+		cloudConfig.DNSNames = regionDNSNames[region]
+		cloudConfigMap[region] = cloudConfig
+	}
+	return nil
+}
+
+// deleteNodeDNSRecord deletes the DNS record created for the node of [nodeConfig], if any
+func deleteNodeDNSRecord(nodeConfig models.NodeConfig, gcpProjectName, gcpCredentialsPath string) error {
+	if nodeConfig.DNSName == "" {
+		return nil
+	}
+	zoneClient, err := getDNSZoneClient(nodeConfig.CloudService, nodeConfig.DNSZone, gcpProjectName, gcpCredentialsPath)
+	if err != nil {
+		return err
+	}
+	return zoneClient.DeleteARecords(map[string]string{nodeConfig.DNSName: nodeConfig.ElasticIP})
+}
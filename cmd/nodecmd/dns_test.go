@@ -0,0 +1,80 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDNSFlags(t *testing.T) {
+	require := require.New(t)
+	defer func() {
+		dnsZone, dnsPrefix, useStaticIP = "", "", true
+	}()
+	dnsZone, dnsPrefix, useStaticIP = "", "", true
+	require.NoError(validateDNSFlags(constants.E2EDocker))
+	dnsPrefix = "validators"
+	require.ErrorContains(validateDNSFlags(constants.AWSCloudService), "requires --dns-zone")
+	dnsZone = "Z0123456789"
+	require.NoError(validateDNSFlags(constants.AWSCloudService))
+	require.NoError(validateDNSFlags(constants.GCPCloudService))
+	require.ErrorContains(validateDNSFlags(constants.E2EDocker), "only supported on AWS and GCP")
+	dnsPrefix = "Validators_1"
+	require.ErrorContains(validateDNSFlags(constants.AWSCloudService), "invalid --dns-prefix")
+	dnsPrefix = ""
+	useStaticIP = false
+	require.ErrorContains(validateDNSFlags(constants.AWSCloudService), "requires static IPs")
+}
+
+func TestNodeDNSName(t *testing.T) {
+	require.Equal(t, "mycluster-i-0abc.nodes.example.com", nodeDNSName("myCluster", "i-0abc", "nodes.example.com"))
+}
+
+func TestCreateClusterNodeConfigDNSNames(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	defer func() {
+		app = nil
+	}()
+	require.NoError(CreateClusterNodeConfig(
+		models.NewFujiNetwork(),
+		models.CloudConfig{
+			"us-east-1": {
+				InstanceIDs: []string{"i-0a", "i-0b"},
+				PublicIPs:   []string{"1.2.3.4", "3.4.5.6"},
+				DNSZone:     "Z0123456789",
+				DNSNames:    []string{"mycluster-i-0a.nodes.example.com", "mycluster-i-0b.nodes.example.com"},
+			},
+			"eu-west-1": {
+				InstanceIDs: []string{"i-0c"},
+				PublicIPs:   []string{"5.6.7.8"},
+			},
+		},
+		models.RegionConfig{},
+		"",
+		"mycluster",
+		constants.AWSCloudService,
+		false,
+	))
+	nodeConfig, err := app.LoadClusterNodeConfig("i-0b")
+	require.NoError(err)
+	require.Equal("Z0123456789", nodeConfig.DNSZone)
+	require.Equal("mycluster-i-0b.nodes.example.com", nodeConfig.DNSName)
+	require.Equal("3.4.5.6", nodeConfig.ElasticIP)
+	// nodes created without --dns-zone have no DNS record
+	nodeConfig, err = app.LoadClusterNodeConfig("i-0c")
+	require.NoError(err)
+	require.Empty(nodeConfig.DNSZone)
+	require.Empty(nodeConfig.DNSName)
+}
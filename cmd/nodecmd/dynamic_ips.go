@@ -30,6 +30,20 @@ func getNodesWithDynamicIP(clusterNodes []string) ([]models.NodeConfig, error) {
 	return nodesWithDynamicIP, nil
 }
 
+// getNodesToRefreshIP returns the configs of all cluster nodes, including
+// those with static IPs that may have been re-associated
+func getNodesToRefreshIP(clusterNodes []string) ([]models.NodeConfig, error) {
+	nodes := []models.NodeConfig{}
+	for _, node := range clusterNodes {
+		nodeConfig, err := app.LoadClusterNodeConfig(node)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, nodeConfig)
+	}
+	return nodes, nil
+}
+
 func getPublicIPsForNodesWithDynamicIP(nodesWithDynamicIP []models.NodeConfig) (map[string]string, error) {
 	publicIPMap := make(map[string]string)
 	var (
@@ -86,46 +100,61 @@ func getPublicIPsForNodesWithDynamicIP(nodesWithDynamicIP []models.NodeConfig) (
 // update public IPs
 // - in ansible inventory file
 // - in host config file
-func updatePublicIPs(clusterName string) error {
+// nodes with static IPs are also refreshed if [includeStaticIPs] is set.
+// nodes whose instance has no public IP (eg. stopped) keep their current IP
+func updatePublicIPs(clusterName string, includeStaticIPs bool) error {
 	clusterNodes, err := getClusterNodes(clusterName)
 	if err != nil {
 		return err
 	}
-	nodesWithDynamicIP, err := getNodesWithDynamicIP(clusterNodes)
+	nodesToRefresh, err := getNodesWithDynamicIP(clusterNodes)
 	if err != nil {
 		return err
 	}
-	if len(nodesWithDynamicIP) > 0 {
-		nodeIDs := utils.Map(nodesWithDynamicIP, func(c models.NodeConfig) string { return c.NodeID })
-		ux.Logger.PrintToUser("Nodes with dynamic IPs in cluster: %s", nodeIDs)
-		publicIPMap, err := getPublicIPsForNodesWithDynamicIP(nodesWithDynamicIP)
+	if includeStaticIPs {
+		nodesToRefresh, err = getNodesToRefreshIP(clusterNodes)
 		if err != nil {
 			return err
 		}
-		changed := 0
-		for _, node := range nodesWithDynamicIP {
-			if node.ElasticIP != publicIPMap[node.NodeID] {
-				ux.Logger.PrintToUser("Updating IP information from %s to %s for node %s",
-					node.ElasticIP,
-					publicIPMap[node.NodeID],
-					node.NodeID,
-				)
-				changed++
-			}
-			node.ElasticIP = publicIPMap[node.NodeID]
-			if err := app.CreateNodeCloudConfigFile(node.NodeID, &node); err != nil { //nolint:gosec
-				return err
-			}
+	}
+	if len(nodesToRefresh) == 0 {
+		ux.Logger.PrintToUser("No nodes with dynamic IPs in cluster")
+		return nil
+	}
+	nodeIDs := utils.Map(nodesToRefresh, func(c models.NodeConfig) string { return c.NodeID })
+	if includeStaticIPs {
+		ux.Logger.PrintToUser("Nodes in cluster: %s", nodeIDs)
+	} else {
+		ux.Logger.PrintToUser("Nodes with dynamic IPs in cluster: %s", nodeIDs)
+	}
+	publicIPMap, err := getPublicIPsForNodesWithDynamicIP(nodesToRefresh)
+	if err != nil {
+		return err
+	}
+	changedIPMap := map[string]string{}
+	for _, node := range nodesToRefresh {
+		publicIP := publicIPMap[node.NodeID]
+		if publicIP == "" {
+			ux.Logger.PrintToUser("Node %s has no public IP (instance may be stopped), keeping IP %s", node.NodeID, node.ElasticIP)
+			continue
 		}
-		if changed == 0 {
-			ux.Logger.PrintToUser("No changes to IPs detected")
-			return nil
+		if node.ElasticIP == publicIP {
+			continue
 		}
-		if err = ansible.UpdateInventoryHostPublicIP(app.GetAnsibleInventoryDirPath(clusterName), publicIPMap); err != nil {
+		ux.Logger.PrintToUser("Updating IP information from %s to %s for node %s",
+			node.ElasticIP,
+			publicIP,
+			node.NodeID,
+		)
+		node.ElasticIP = publicIP
+		if err := app.CreateNodeCloudConfigFile(node.NodeID, &node); err != nil { //nolint:gosec
 			return err
 		}
-	} else {
-		ux.Logger.PrintToUser("No nodes with dynamic IPs in cluster")
+		changedIPMap[node.NodeID] = publicIP
+	}
+	if len(changedIPMap) == 0 {
+		ux.Logger.PrintToUser("No changes to IPs detected")
+		return nil
 	}
-	return nil
+	return ansible.UpdateInventoryHostPublicIP(app.GetAnsibleInventoryDirPath(clusterName), changedIPMap)
 }
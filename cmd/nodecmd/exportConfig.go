@@ -0,0 +1,165 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/remoteconfig"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+const nodeConfigSummaryFileName = "summary.json"
+
+// nodeConfigSummaryKeys are the avalanchego config keys included in the export summary
+var nodeConfigSummaryKeys = []string{
+	"network-id",
+	"public-ip",
+	"http-port",
+	"staking-port",
+	"track-subnets",
+	"bootstrap-ids",
+	"bootstrap-ips",
+	"genesis-file",
+	"log-level",
+}
+
+// cChainConfigSummaryKeys are the C-Chain config keys included in the export summary
+var cChainConfigSummaryKeys = []string{
+	"pruning-enabled",
+	"state-sync-enabled",
+}
+
+var (
+	exportConfigNodes  []string
+	exportConfigOutDir string
+)
+
+// nodeConfigSummary describes a node config export
+type nodeConfigSummary struct {
+	ClusterName   string                 `json:"clusterName"`
+	CloudID       string                 `json:"cloudID"`
+	NodeID        string                 `json:"nodeID"`
+	IP            string                 `json:"ip"`
+	Config        map[string]interface{} `json:"config"`
+	CChainConfig  map[string]interface{} `json:"cChainConfig,omitempty"`
+	ExportedFiles []string               `json:"exportedFiles"`
+}
+
+func newExportConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-config [clusterName]",
+		Short: "(ALPHA Warning) Export the AvalancheGo configs of nodes in a cluster",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node export-config command downloads the AvalancheGo node config, genesis,
+and subnet and chain configs of nodes in a cluster into a local folder, one
+subfolder per node. A summary.json file with the main node settings is also
+written for each node.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: exportNodeConfigs,
+	}
+	cmd.Flags().StringSliceVar(&exportConfigNodes, "node", []string{}, "export config only of given comma separated list of nodes (node IDs, cloud IDs or IPs). defaults to all cluster nodes")
+	cmd.Flags().StringVar(&exportConfigOutDir, "out", "", "directory to export the configs to. defaults to <clusterName>-config")
+	return cmd
+}
+
+func exportNodeConfigs(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	if err := checkCluster(clusterName); err != nil {
+		return err
+	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	if len(exportConfigNodes) != 0 {
+		hosts, err = filterHosts(hosts, exportConfigNodes)
+		if err != nil {
+			return err
+		}
+	}
+	defer disconnectHosts(hosts)
+	outDir := exportConfigOutDir
+	if outDir == "" {
+		outDir = clusterName + "-config"
+	}
+
+	spinSession := ux.NewUserSpinner()
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, "Export Config"))
+			if err := exportNodeConfig(clusterName, host, filepath.Join(outDir, host.GetCloudID())); err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				ux.SpinFailWithError(spinner, "", err)
+				return
+			}
+			ux.SpinComplete(spinner)
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	spinSession.Stop()
+	if wgResults.HasErrors() {
+		return fmt.Errorf("failed to export config for node(s) %s", wgResults.GetErrorHostMap())
+	}
+	ux.Logger.GreenCheckmarkToUser("Configs of node(s) in cluster %s exported to %s", clusterName, outDir)
+	return nil
+}
+
+// exportNodeConfig downloads the configs of [host] into [nodeOutDir] and writes its summary
+func exportNodeConfig(clusterName string, host *models.Host, nodeOutDir string) error {
+	avagoConfig, exportedFiles, err := ssh.RunSSHExportNodeConfig(host, nodeOutDir)
+	if err != nil {
+		return err
+	}
+	summary := nodeConfigSummary{
+		ClusterName:   clusterName,
+		CloudID:       host.GetCloudID(),
+		IP:            host.IP,
+		Config:        map[string]interface{}{},
+		ExportedFiles: exportedFiles,
+	}
+	if nodeID, err := getNodeID(app.GetNodeInstanceDirPath(host.GetCloudID())); err == nil {
+		summary.NodeID = nodeID.String()
+	}
+	for _, key := range nodeConfigSummaryKeys {
+		if value, ok := avagoConfig[key]; ok {
+			summary.Config[key] = value
+		}
+	}
+	cChainConfigPath, err := filepath.Rel(constants.CloudNodeConfigPath, remoteconfig.GetRemoteAvalancheCChainConfig())
+	if err != nil {
+		return err
+	}
+	if cChainConfigBytes, err := os.ReadFile(filepath.Join(nodeOutDir, cChainConfigPath)); err == nil {
+		var cChainConfig map[string]interface{}
+		if err := json.Unmarshal(cChainConfigBytes, &cChainConfig); err != nil {
+			return fmt.Errorf("failed to parse C-Chain config: %w", err)
+		}
+		summary.CChainConfig = map[string]interface{}{}
+		for _, key := range cChainConfigSummaryKeys {
+			if value, ok := cChainConfig[key]; ok {
+				summary.CChainConfig[key] = value
+			}
+		}
+	}
+	summaryBytes, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(nodeOutDir, nodeConfigSummaryFileName), summaryBytes, constants.WriteReadReadPerms)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var healthNode string
+
+func newHealthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "health [clusterName]",
+		Short: "(ALPHA Warning) Check if the nodes of a cluster are healthy",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node health command checks the health of all nodes in a cluster, or of the node given
+by --node, and prints one line per node. It is meant for scripts and monitoring, and exits with:
+  0 if all the nodes are healthy
+  1 if the nodes could not be checked, e.g. the cluster doesn't exist
+  2 if a node is unhealthy
+  3 if a node is unreachable`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: healthNodes,
+	}
+	cmd.Flags().StringVar(&healthNode, "node", "", "only check the given node, by cloud ID, IP or NodeID")
+	return cmd
+}
+
+func healthNodes(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	var hosts []*models.Host
+	if healthNode != "" {
+		host, err := getClusterHost(clusterName, healthNode)
+		if err != nil {
+			return err
+		}
+		hosts = []*models.Host{host}
+	} else {
+		if err := checkCluster(clusterName); err != nil {
+			return err
+		}
+		var err error
+		hosts, err = getClusterHosts(clusterName)
+		if err != nil {
+			return err
+		}
+	}
+	defer disconnectHosts(hosts)
+
+	hostsHealth := node.CheckHostsHealth(hosts, ssh.RunSSHCheckHealthy)
+	notHealthy := 0
+	for _, hostHealth := range hostsHealth {
+		line := fmt.Sprintf("%s %s %s", hostHealth.Host.GetCloudID(), hostHealth.Host.IP, hostHealth.Status)
+		if hostHealth.Status != node.HealthStatusHealthy {
+			notHealthy++
+			if hostHealth.Err != nil {
+				line += ": " + describeNodeError(hostHealth.Err)
+			}
+		}
+		ux.Logger.PrintToUser("%s", line)
+	}
+	if exitCode := node.HealthExitCode(hostsHealth); exitCode != node.HealthExitCodeHealthy {
+		return cobrautils.NewExitCodeError(exitCode, fmt.Errorf("%d of %d node(s) in cluster %s are not healthy", notHealthy, len(hostsHealth), clusterName))
+	}
+	return nil
+}
@@ -7,15 +7,116 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/remoteconfig"
 	"github.com/ava-labs/avalanche-cli/pkg/ssh"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/cobra"
 )
 
+// hostFilter restricts cluster wide operations to some of the cluster nodes
+var hostFilter models.HostFilter
+
+// addHostFilterFlags adds --only-node and --exclude-node to a cluster wide command
+func addHostFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&hostFilter.Only, "only-node", []string{}, "only apply to given node(s), by cloud ID or ansible ID. can be repeated")
+	cmd.Flags().StringSliceVar(&hostFilter.Exclude, "exclude-node", []string{}, "do not apply to given node(s), by cloud ID or ansible ID, e.g. nodes under maintenance. can be repeated")
+}
+
+// confirmMainnet skips the typed confirmation asked by destructive commands on Mainnet clusters
+var confirmMainnet bool
+
+// addConfirmMainnetFlag adds --confirm-mainnet to a destructive cluster command
+func addConfirmMainnetFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&confirmMainnet, "confirm-mainnet", false, "confirm the operation on a Mainnet cluster without typing the cluster name")
+}
+
+// checkMainnetConfirmation makes sure that the destructive [operation] is wanted, if [clusterName]
+// is a Mainnet cluster: either --confirm-mainnet is given or the user types the cluster name
+func checkMainnetConfirmation(clusterName string, operation string) error {
+	network, err := app.GetClusterNetwork(clusterName)
+	if err != nil {
+		return err
+	}
+	if network.Kind != models.Mainnet || confirmMainnet {
+		return nil
+	}
+	ux.Logger.PrintToUser(logging.Red.Wrap(fmt.Sprintf(
+		"WARNING: cluster %s is on Mainnet, and %s can't be undone. Mainnet validators may be affected",
+		clusterName,
+		operation,
+	)))
+	typedName, err := app.Prompt.CaptureString(fmt.Sprintf("Type the cluster name %s to confirm", clusterName))
+	if err != nil {
+		return err
+	}
+	if typedName != clusterName {
+		return fmt.Errorf("typed name %q is not the cluster name, aborting %s. Use --confirm-mainnet to skip this confirmation", typedName, operation)
+	}
+	return nil
+}
+
+// chainAlias is the alias set for the subnet blockchain on the nodes by node sync and node update subnet
+var chainAlias string
+
+// addChainAliasFlag adds --alias to a command that syncs subnet data to the nodes
+func addChainAliasFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&chainAlias, "alias", "", "alias of the subnet blockchain on the node(s), to use in RPC URLs instead of the blockchain ID. defaults to the subnet name")
+}
+
+// getChainAlias returns the alias to set for the blockchain of [subnetName]: --alias if given,
+// or else the subnet name, if it is a valid alias
+func getChainAlias(subnetName string) (string, error) {
+	if chainAlias != "" {
+		return chainAlias, remoteconfig.ValidateChainAlias(chainAlias)
+	}
+	if err := remoteconfig.ValidateChainAlias(subnetName); err != nil {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf("Subnet name %q can't be used as chain alias, use --alias to set one", subnetName)))
+		return "", nil
+	}
+	return subnetName, nil
+}
+
+// filterClusterHosts applies --only-node and --exclude-node to the cluster [hosts]
+func filterClusterHosts(hosts []*models.Host) ([]*models.Host, error) {
+	selectedHosts, err := hostFilter.Apply(hosts)
+	if err != nil {
+		return nil, err
+	}
+	if skipped := len(hosts) - len(selectedHosts); skipped > 0 {
+		ux.Logger.PrintToUser("Skipping %d node(s) due to node filters", skipped)
+	}
+	return selectedHosts, nil
+}
+
+// getClusterHost returns the host of cluster [clusterName] given by [node], as cloud ID, IP or NodeID.
+// [node] can be empty if the cluster has a single host
+func getClusterHost(clusterName string, node string) (*models.Host, error) {
+	if err := checkCluster(clusterName); err != nil {
+		return nil, err
+	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case node != "":
+		if hosts, err = filterHosts(hosts, []string{node}); err != nil {
+			return nil, err
+		}
+	case len(hosts) != 1:
+		return nil, fmt.Errorf("cluster %s has %d nodes, please select one with --node", clusterName, len(hosts))
+	}
+	return hosts[0], nil
+}
+
 // NumNodes is a struct to hold number of nodes with and without stake
 type NumNodes struct {
 	numValidators int // with stake
@@ -27,46 +128,21 @@ func (n NumNodes) All() int {
 }
 
 func getUnhealthyNodes(hosts []*models.Host) ([]string, error) {
-	wg := sync.WaitGroup{}
-	wgResults := models.NodeResults{}
-	for _, host := range hosts {
-		wg.Add(1)
-		go func(nodeResults *models.NodeResults, host *models.Host) {
-			defer wg.Done()
-			if resp, err := ssh.RunSSHCheckHealthy(host); err != nil {
-				nodeResults.AddResult(host.GetCloudID(), nil, err)
-				return
-			} else {
-				if isHealthy, err := parseHealthyOutput(resp); err != nil {
-					nodeResults.AddResult(host.GetCloudID(), nil, err)
-				} else {
-					nodeResults.AddResult(host.GetCloudID(), isHealthy, err)
-				}
-			}
-		}(&wgResults, host)
-	}
-	wg.Wait()
-	if wgResults.HasErrors() {
-		return nil, fmt.Errorf("failed to get health status for node(s) %s", wgResults.GetErrorHostMap())
-	}
-	return utils.Filter(wgResults.GetNodeList(), func(nodeID string) bool {
-		return !wgResults.GetResultMap()[nodeID].(bool)
-	}), nil
-}
-
-func parseHealthyOutput(byteValue []byte) (bool, error) {
-	var result map[string]interface{}
-	if err := json.Unmarshal(byteValue, &result); err != nil {
-		return false, err
-	}
-	isHealthyInterface, ok := result["result"].(map[string]interface{})
-	if ok {
-		isHealthy, ok := isHealthyInterface["healthy"].(bool)
-		if ok {
-			return isHealthy, nil
+	hostsHealth := node.CheckHostsHealth(hosts, ssh.RunSSHCheckHealthy)
+	hostErrors := map[string]error{}
+	unhealthyNodes := []string{}
+	for _, hostHealth := range hostsHealth {
+		switch {
+		case hostHealth.Err != nil:
+			hostErrors[hostHealth.Host.GetCloudID()] = hostHealth.Err
+		case hostHealth.Status != node.HealthStatusHealthy:
+			unhealthyNodes = append(unhealthyNodes, hostHealth.Host.GetCloudID())
 		}
 	}
-	return false, fmt.Errorf("unable to parse node healthy status")
+	if len(hostErrors) > 0 {
+		return nil, fmt.Errorf("failed to get health status for node(s) %s", hostErrors)
+	}
+	return unhealthyNodes, nil
 }
 
 func getNotBootstrappedNodes(hosts []*models.Host) ([]string, error) {
@@ -112,6 +188,21 @@ func parseBootstrappedOutput(byteValue []byte) (bool, error) {
 	return false, errors.New("unable to parse node bootstrap status")
 }
 
+func parseNodeIDOutput(byteValue []byte) (string, error) {
+	var result map[string]interface{}
+	if err := json.Unmarshal(byteValue, &result); err != nil {
+		return "", err
+	}
+	nodeIDInterface, ok := result["result"].(map[string]interface{})
+	if ok {
+		nodeID, ok := nodeIDInterface["nodeID"].(string)
+		if ok {
+			return nodeID, nil
+		}
+	}
+	return "", errors.New("unable to parse node ID")
+}
+
 func getRPCIncompatibleNodes(hosts []*models.Host, subnetName string) ([]string, error) {
 	ux.Logger.PrintToUser("Checking compatibility of node(s) avalanche go RPC protocol version with Subnet EVM RPC of subnet %s ...", subnetName)
 	sc, err := app.LoadSidecar(subnetName)
@@ -227,3 +318,100 @@ func checkHostsAreBootstrapped(hosts []*models.Host) error {
 	}
 	return nil
 }
+
+// isHostHealthy checks that the node at [host] is bootstrapped and healthy
+func isHostHealthy(host *models.Host) (bool, error) {
+	resp, err := ssh.RunSSHCheckBootstrapped(host)
+	if err != nil {
+		return false, err
+	}
+	if isBootstrapped, err := parseBootstrappedOutput(resp); err != nil || !isBootstrapped {
+		return false, err
+	}
+	resp, err = ssh.RunSSHCheckHealthy(host)
+	if err != nil {
+		return false, err
+	}
+	return node.ParseHealthyOutput(resp)
+}
+
+// validateWaitHealthyFlags checks the --wait-healthy-timeout and --wait-healthy-interval
+// values shared by the commands that wait for nodes to become healthy again
+func validateWaitHealthyFlags() error {
+	if waitHealthyTimeout <= 0 || waitHealthyPoll <= 0 {
+		return fmt.Errorf("wait healthy timeout and interval must be greater than 0")
+	}
+	return nil
+}
+
+// waitForHostHealthy polls every [pollInterval] until the node at [host] is bootstrapped
+// and healthy, or until [timeout] expires
+func waitForHostHealthy(host *models.Host, timeout time.Duration, pollInterval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		isHealthy, err := isHostHealthy(host)
+		if isHealthy {
+			return nil
+		}
+		if time.Now().Add(pollInterval).After(deadline) {
+			if err == nil {
+				err = fmt.Errorf("not healthy after %s", timeout)
+			}
+			return err
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// waitForHealthyHosts polls every [pollInterval] until all [hosts] are bootstrapped
+// and healthy, or until [timeout] expires. Prints a per node health summary
+func waitForHealthyHosts(hosts []*models.Host, timeout time.Duration, pollInterval time.Duration) error {
+	ux.Logger.PrintToUser("Waiting up to %s for node(s) to be healthy...", timeout)
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			if err := waitForHostHealthy(host, timeout, pollInterval); err != nil {
+				nodeResults.AddResult(host.NodeID, false, err)
+				return
+			}
+			nodeResults.AddResult(host.NodeID, true, nil)
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	for _, node := range hosts {
+		if wgResults.HasNodeIDWithError(node.NodeID) {
+			ux.Logger.RedXToUser("Node %s is UNHEALTHY: %s", node.NodeID, wgResults.GetErrorHostMap()[node.NodeID])
+		} else {
+			ux.Logger.GreenCheckmarkToUser("Node %s is HEALTHY", node.NodeID)
+		}
+	}
+	if wgResults.HasErrors() {
+		return fmt.Errorf("node(s) %s failed to become healthy", wgResults.GetErrorHosts())
+	}
+	return nil
+}
+
+// describeNodeError presents a node failure according to the kind of remote command
+// or connection error, if any
+func describeNodeError(err error) string {
+	var cmdErr *models.CommandError
+	if !errors.As(err, &cmdErr) {
+		if kind := models.ClassifyConnectionError(err); kind != models.ConnectionErrorUnknown {
+			return fmt.Sprintf("%s: %s", kind, err)
+		}
+		return err.Error()
+	}
+	switch {
+	case cmdErr.IsTimeout():
+		return fmt.Sprintf("timeout running remote command, node may be overloaded or unresponsive: %s", err)
+	case cmdErr.IsConnectionLost():
+		return fmt.Sprintf("lost SSH connection to node, please check that it is running and reachable: %s", err)
+	case cmdErr.IsCommandNotFound():
+		return fmt.Sprintf("a required tool is missing on node: %s", err)
+	default:
+		return fmt.Sprintf("remote command failed with exit code %d: %s", cmdErr.ExitCode, err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckMainnetConfirmation(t *testing.T) {
+	require := require.New(t)
+	prompter := prompts.NewMockPrompter()
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompter, nil)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	defer func() {
+		app = nil
+		confirmMainnet = false
+	}()
+	require.NoError(app.WriteClustersConfigFile(&models.ClustersConfig{
+		Clusters: map[string]models.ClusterConfig{
+			"mainnet-cluster": {Network: models.NewMainnetNetwork()},
+			"fuji-cluster":    {Network: models.NewFujiNetwork()},
+		},
+	}))
+
+	// no confirmation is asked for other networks, as nothing is queued on the prompter
+	require.NoError(checkMainnetConfirmation("fuji-cluster", "destroying its nodes"))
+
+	// the typed name must match the cluster name
+	prompter.QueueString("fuji-cluster")
+	require.ErrorContains(checkMainnetConfirmation("mainnet-cluster", "destroying its nodes"), "aborting destroying its nodes")
+	prompter.QueueString("mainnet-cluster")
+	require.NoError(checkMainnetConfirmation("mainnet-cluster", "destroying its nodes"))
+
+	// the flag skips the prompt
+	confirmMainnet = true
+	require.NoError(checkMainnetConfirmation("mainnet-cluster", "destroying its nodes"))
+}
+
+func TestValidateWaitHealthyFlags(t *testing.T) {
+	require := require.New(t)
+	defer func() {
+		waitHealthyTimeout = 0
+		waitHealthyPoll = 0
+	}()
+	waitHealthyTimeout = time.Minute
+	waitHealthyPoll = time.Second
+	require.NoError(validateWaitHealthyFlags())
+	waitHealthyPoll = 0
+	require.ErrorContains(validateWaitHealthyFlags(), "must be greater than 0")
+	waitHealthyPoll = time.Second
+	waitHealthyTimeout = -time.Minute
+	require.ErrorContains(validateWaitHealthyFlags(), "must be greater than 0")
+}
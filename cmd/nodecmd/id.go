@@ -0,0 +1,168 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var nodeIDJSONOutput bool
+
+// nodeIDEntry maps a cloud server instance to the node ID of its avalanchego
+type nodeIDEntry struct {
+	InstanceID string `json:"instanceID"`
+	NodeID     string `json:"nodeID,omitempty"`
+	PublicIP   string `json:"publicIP"`
+	// Pending is set when the node could not be reached, e.g. because avalanchego is not up yet
+	Pending bool `json:"pending,omitempty"`
+}
+
+func newIDCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "id [clusterName]",
+		Short: "(ALPHA Warning) Print the node ID of each node of a cluster",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node id command prints the instance ID, the avalanchego node ID and the public IP of
+each node of the cluster, as needed to register the nodes as validators.
+
+Node IDs are asked to the nodes over ssh the first time, and are then kept in the node
+configs. Nodes that can't be reached yet, e.g. because avalanchego is still starting, are
+reported as pending.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: printNodeIDs,
+	}
+	cmd.Flags().BoolVar(&nodeIDJSONOutput, "json", false, "print the node IDs as JSON")
+	return cmd
+}
+
+func printNodeIDs(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	if err := checkCluster(clusterName); err != nil {
+		return err
+	}
+	clusterConfig, err := app.GetClusterConfig(clusterName)
+	if err != nil {
+		return err
+	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	hosts = utils.Filter(hosts, func(h *models.Host) bool { return clusterConfig.IsAvalancheGoHost(h.GetCloudID()) })
+	defer disconnectHosts(hosts)
+	entries, fetchErrors, err := getClusterNodeIDEntries(hosts, getNodeIDOverSSH)
+	if err != nil {
+		return err
+	}
+	if nodeIDJSONOutput {
+		entriesBytes, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser(string(entriesBytes))
+		return nil
+	}
+	writeNodeIDTable(os.Stdout, entries)
+	for _, entry := range entries {
+		if entry.Pending {
+			ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf("Node %s is pending: %s", entry.InstanceID, fetchErrors[entry.InstanceID])))
+		}
+	}
+	return nil
+}
+
+// getNodeIDOverSSH asks the avalanchego of [host] for its node ID
+func getNodeIDOverSSH(host *models.Host) (string, error) {
+	resp, err := ssh.RunSSHGetNodeID(host)
+	if err != nil {
+		return "", err
+	}
+	return parseNodeIDOutput(resp)
+}
+
+// getClusterNodeIDEntries returns the node ID entries of [hosts], in the same order. Node IDs
+// cached in the node configs are used as is. The others are obtained with [fetchNodeID],
+// concurrently, and cached. Hosts whose node ID can't be obtained are marked as pending, and
+// their errors returned by cloud ID
+func getClusterNodeIDEntries(
+	hosts []*models.Host,
+	fetchNodeID func(*models.Host) (string, error),
+) ([]nodeIDEntry, map[string]error, error) {
+	nodeConfigs := map[string]models.NodeConfig{}
+	hostsToFetch := []*models.Host{}
+	for _, host := range hosts {
+		cloudID := host.GetCloudID()
+		nodeConfig, err := app.LoadClusterNodeConfig(cloudID)
+		if err != nil {
+			return nil, nil, err
+		}
+		nodeConfigs[cloudID] = nodeConfig
+		if nodeConfig.AvalancheGoNodeID == "" {
+			hostsToFetch = append(hostsToFetch, host)
+		}
+	}
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	for _, host := range hostsToFetch {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			nodeID, err := fetchNodeID(host)
+			nodeResults.AddResult(host.GetCloudID(), nodeID, err)
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	fetchErrors := wgResults.GetErrorHostMap()
+	for cloudID, nodeIDI := range wgResults.GetResultMap() {
+		if _, ok := fetchErrors[cloudID]; ok {
+			continue
+		}
+		nodeConfig := nodeConfigs[cloudID]
+		nodeConfig.AvalancheGoNodeID = nodeIDI.(string)
+		if err := app.CreateNodeCloudConfigFile(cloudID, &nodeConfig); err != nil {
+			return nil, nil, err
+		}
+		nodeConfigs[cloudID] = nodeConfig
+	}
+	entries := []nodeIDEntry{}
+	for _, host := range hosts {
+		cloudID := host.GetCloudID()
+		_, pending := fetchErrors[cloudID]
+		entries = append(entries, nodeIDEntry{
+			InstanceID: cloudID,
+			NodeID:     nodeConfigs[cloudID].AvalancheGoNodeID,
+			PublicIP:   host.IP,
+			Pending:    pending,
+		})
+	}
+	return entries, fetchErrors, nil
+}
+
+// writeNodeIDTable writes [entries] as a table to [w]
+func writeNodeIDTable(w io.Writer, entries []nodeIDEntry) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Instance ID", "Node ID", "IP"})
+	table.SetRowLine(true)
+	for _, entry := range entries {
+		nodeID := entry.NodeID
+		if entry.Pending {
+			nodeID = "PENDING"
+		}
+		table.Append([]string{entry.InstanceID, nodeID, entry.PublicIP})
+	}
+	table.Render()
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClusterNodeIDEntries(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	defer func() {
+		app = nil
+	}()
+	hosts := []*models.Host{}
+	for i, cloudID := range []string{"node0", "node1", "node2"} {
+		nodeConfig := models.NodeConfig{
+			NodeID:       cloudID,
+			ElasticIP:    []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}[i],
+			CloudService: constants.AWSCloudService,
+		}
+		if cloudID == "node1" {
+			nodeConfig.AvalancheGoNodeID = "NodeID-cached"
+		}
+		require.NoError(app.CreateNodeCloudConfigFile(cloudID, &nodeConfig))
+		hosts = append(hosts, &models.Host{NodeID: "aws_node_" + cloudID, IP: nodeConfig.ElasticIP})
+	}
+	lock := sync.Mutex{}
+	fetched := []string{}
+	nodeUp := map[string]bool{"node0": true}
+	fetchNodeID := func(host *models.Host) (string, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		fetched = append(fetched, host.GetCloudID())
+		if !nodeUp[host.GetCloudID()] {
+			return "", errors.New("connection refused")
+		}
+		return "NodeID-" + host.GetCloudID(), nil
+	}
+
+	// cached node IDs are not fetched, and nodes that are not up are pending
+	entries, fetchErrors, err := getClusterNodeIDEntries(hosts, fetchNodeID)
+	require.NoError(err)
+	require.ElementsMatch([]string{"node0", "node2"}, fetched)
+	require.Equal([]nodeIDEntry{
+		{InstanceID: "node0", NodeID: "NodeID-node0", PublicIP: "1.1.1.1"},
+		{InstanceID: "node1", NodeID: "NodeID-cached", PublicIP: "2.2.2.2"},
+		{InstanceID: "node2", PublicIP: "3.3.3.3", Pending: true},
+	}, entries)
+	require.Len(fetchErrors, 1)
+	require.ErrorContains(fetchErrors["node2"], "connection refused")
+	nodeConfig, err := app.LoadClusterNodeConfig("node0")
+	require.NoError(err)
+	require.Equal("NodeID-node0", nodeConfig.AvalancheGoNodeID)
+
+	// fetched node IDs are cached, and pending nodes are asked again
+	fetched = []string{}
+	nodeUp["node2"] = true
+	entries, fetchErrors, err = getClusterNodeIDEntries(hosts, fetchNodeID)
+	require.NoError(err)
+	require.Equal([]string{"node2"}, fetched)
+	require.Empty(fetchErrors)
+	require.Equal("NodeID-node2", entries[2].NodeID)
+	require.False(entries[2].Pending)
+
+	buf := &bytes.Buffer{}
+	writeNodeIDTable(buf, []nodeIDEntry{
+		{InstanceID: "node0", NodeID: "NodeID-node0", PublicIP: "1.1.1.1"},
+		{InstanceID: "node2", PublicIP: "3.3.3.3", Pending: true},
+	})
+	table := buf.String()
+	require.Contains(table, "INSTANCE ID")
+	require.Regexp(`node0\s+\|\s+NodeID-node0\s+\|\s+1\.1\.1\.1`, table)
+	require.Regexp(`node2\s+\|\s+PENDING\s+\|\s+3\.3\.3\.3`, table)
+}
+
+func TestParseNodeIDOutput(t *testing.T) {
+	require := require.New(t)
+	nodeID, err := parseNodeIDOutput([]byte(`{"jsonrpc":"2.0","result":{"nodeID":"NodeID-abc","nodePOP":{}},"id":1}`))
+	require.NoError(err)
+	require.Equal("NodeID-abc", nodeID)
+	_, err = parseNodeIDOutput([]byte(`{"jsonrpc":"2.0","error":{"code":-32000},"id":1}`))
+	require.ErrorContains(err, "unable to parse node ID")
+}
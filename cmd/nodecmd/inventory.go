@@ -0,0 +1,112 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanche-cli/pkg/ansible"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errEmptyInventory = errors.New("inventory has no nodes")
+
+// getClusterHosts returns the hosts of the ansible inventory of cluster [clusterName].
+// If the inventory is missing or empty, it is regenerated from the cluster config, with the
+// node IPs refreshed from the cloud API, before failing with remediation guidance
+func getClusterHosts(clusterName string) ([]*models.Host, error) {
+	inventoryPath := app.GetAnsibleInventoryDirPath(clusterName)
+	hosts, err := ansible.GetInventoryFromAnsibleInventoryFile(inventoryPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if len(hosts) > 0 {
+		return hosts, nil
+	}
+	ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+		"Inventory of cluster %s has no nodes, regenerating it from the cluster config...", clusterName,
+	)))
+	if err := regenerateClusterInventory(clusterName); err != nil {
+		return nil, emptyInventoryError(clusterName, inventoryPath, err)
+	}
+	hosts, err = ansible.GetInventoryFromAnsibleInventoryFile(inventoryPath)
+	if err != nil {
+		return nil, emptyInventoryError(clusterName, inventoryPath, err)
+	}
+	if len(hosts) == 0 {
+		return nil, emptyInventoryError(clusterName, inventoryPath, errors.New("no nodes written"))
+	}
+	ux.Logger.GreenCheckmarkToUser("Inventory of cluster %s regenerated with %d node(s)", clusterName, len(hosts))
+	return hosts, nil
+}
+
+// regenerateClusterInventory writes the ansible inventory of cluster [clusterName] from the
+// configs of its nodes. IPs of cloud nodes are refreshed first, as they may be outdated
+func regenerateClusterInventory(clusterName string) error {
+	clusterConfig, err := app.GetClusterConfig(clusterName)
+	if err != nil {
+		return err
+	}
+	if len(clusterConfig.Nodes) == 0 {
+		return fmt.Errorf("cluster config has no nodes")
+	}
+	nodeConfigs := []models.NodeConfig{}
+	for _, cloudID := range clusterConfig.Nodes {
+		nodeConfig, err := app.LoadClusterNodeConfig(cloudID)
+		if err != nil {
+			return fmt.Errorf("failed to load config of node %s: %w", cloudID, err)
+		}
+		nodeConfigs = append(nodeConfigs, nodeConfig)
+	}
+	if !clusterConfig.External {
+		publicIPMap, err := getPublicIPsForNodesWithDynamicIP(nodeConfigs)
+		if err != nil {
+			return err
+		}
+		for i := range nodeConfigs {
+			publicIP := publicIPMap[nodeConfigs[i].NodeID]
+			if publicIP == "" || publicIP == nodeConfigs[i].ElasticIP {
+				continue
+			}
+			nodeConfigs[i].ElasticIP = publicIP
+			if err := app.CreateNodeCloudConfigFile(nodeConfigs[i].NodeID, &nodeConfigs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, nodeConfig := range nodeConfigs {
+		if nodeConfig.ElasticIP == "" {
+			return fmt.Errorf("node %s has no known IP", nodeConfig.NodeID)
+		}
+	}
+	inventoryPath := app.GetAnsibleInventoryDirPath(clusterName)
+	// inventory entries are appended, so start from an empty file
+	if err := os.RemoveAll(filepath.Join(inventoryPath, constants.AnsibleHostInventoryFileName)); err != nil {
+		return err
+	}
+	return ansible.WriteNodeConfigsToAnsibleInventory(inventoryPath, nodeConfigs)
+}
+
+// emptyInventoryError explains why the inventory of [clusterName] at [inventoryPath] is unusable,
+// and how to fix it. As the inventory is regenerated from the cluster config on every command,
+// the fix is to the cluster config itself
+func emptyInventoryError(clusterName string, inventoryPath string, cause error) error {
+	return fmt.Errorf(
+		"%w: cluster %s inventory at %s is missing or empty, and could not be regenerated (%s). "+
+			"Check that cluster %s lists its nodes in %s, and that each of them has a config with its IP in %s, "+
+			"or destroy and recreate the cluster if its nodes no longer exist",
+		errEmptyInventory,
+		clusterName,
+		filepath.Join(inventoryPath, constants.AnsibleHostInventoryFileName),
+		cause,
+		clusterName,
+		app.GetClustersConfigPath(),
+		filepath.Join(app.GetNodesDir(), "<node>", constants.NodeCloudConfigFileName),
+	)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClusterHosts(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	defer func() {
+		app = nil
+	}()
+	require.NoError(app.WriteClustersConfigFile(&models.ClustersConfig{
+		Clusters: map[string]models.ClusterConfig{
+			"empty":    {},
+			"external": {Nodes: []string{"node0", "node1"}, External: true},
+		},
+	}))
+	for i, ip := range []string{"1.1.1.1", "2.2.2.2"} {
+		cloudID := []string{"node0", "node1"}[i]
+		require.NoError(app.CreateNodeCloudConfigFile(cloudID, &models.NodeConfig{
+			NodeID:       cloudID,
+			ElasticIP:    ip,
+			CloudService: constants.AWSCloudService,
+			CertPath:     "/cert.pem",
+		}))
+	}
+
+	// a cluster with no nodes can't be regenerated, and the error points at the inventory and the fixes
+	_, err := getClusterHosts("empty")
+	require.ErrorIs(err, errEmptyInventory)
+	require.ErrorContains(err, filepath.Join(app.GetAnsibleInventoryDirPath("empty"), constants.AnsibleHostInventoryFileName))
+	require.ErrorContains(err, "cluster config has no nodes")
+	require.ErrorContains(err, "Check that cluster empty lists its nodes in "+app.GetClustersConfigPath())
+	require.NotContains(err.Error(), "refresh-ips")
+	require.ErrorContains(err, "recreate the cluster")
+
+	// a missing inventory is regenerated from the node configs
+	hosts, err := getClusterHosts("external")
+	require.NoError(err)
+	require.Len(hosts, 2)
+	require.Equal("aws_node_node0", hosts[0].NodeID)
+	require.Equal("1.1.1.1", hosts[0].IP)
+	require.Equal("aws_node_node1", hosts[1].NodeID)
+	require.Equal("2.2.2.2", hosts[1].IP)
+
+	// as is an empty one
+	inventoryFile := filepath.Join(app.GetAnsibleInventoryDirPath("external"), constants.AnsibleHostInventoryFileName)
+	require.NoError(os.WriteFile(inventoryFile, nil, constants.WriteReadReadPerms))
+	hosts, err = getClusterHosts("external")
+	require.NoError(err)
+	require.Len(hosts, 2)
+
+	// nodes without a known IP can't be written
+	require.NoError(os.WriteFile(inventoryFile, nil, constants.WriteReadReadPerms))
+	require.NoError(app.CreateNodeCloudConfigFile("node1", &models.NodeConfig{NodeID: "node1", CloudService: constants.AWSCloudService}))
+	_, err = getClusterHosts("external")
+	require.ErrorIs(err, errEmptyInventory)
+	require.ErrorContains(err, "node node1 has no known IP")
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/ansible"
 	"github.com/ava-labs/avalanche-cli/pkg/application"
@@ -35,8 +37,13 @@ var (
 	repoDirName        string
 	loadTestHostRegion string
 	loadTestBranch     string
+	loadTestRunEntries []string
+	loadTestRuns       []ssh.LoadTestRun
+	loadTestParallel   bool
 )
 
+var loadTestRunNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
 type clusterInfo struct {
 	API       []nodeInfo `yaml:"API,omitempty"`
 	Validator []nodeInfo `yaml:"VALIDATOR,omitempty"`
@@ -62,7 +69,12 @@ The node loadtest command starts load testing for an existing devnet cluster. If
 not have an existing load test host, the command creates a separate cloud server and builds the load 
 test binary based on the provided load test Git Repo URL and load test binary build command. 
 
-The command will then run the load test binary based on the provided load test run command.`,
+The command will then run the load test binary based on the provided load test run command.
+
+To run several load tests at once, for example against different chains, give each one with
+--load-test-run name=command instead of --load-test-cmd. Runs are executed one after the other,
+or all at once with --parallel-runs, and each one writes its output to its own result file,
+downloaded by node loadtest stop.`,
 
 		Args: cobrautils.ExactArgs(3),
 		RunE: startLoadTest,
@@ -79,6 +91,8 @@ The command will then run the load test binary based on the provided load test r
 	cmd.Flags().StringVar(&loadTestCmd, "load-test-cmd", "", "command to run load test")
 	cmd.Flags().StringVar(&loadTestHostRegion, "region", "", "create load test node in a given region")
 	cmd.Flags().StringVar(&loadTestBranch, "load-test-branch", "", "load test branch or commit")
+	cmd.Flags().StringArrayVar(&loadTestRunEntries, "load-test-run", []string{}, "run the given named load test command, as name=command, instead of --load-test-cmd. can be repeated")
+	cmd.Flags().BoolVar(&loadTestParallel, "parallel-runs", false, "run the --load-test-run commands at the same time instead of one after the other")
 	return cmd
 }
 
@@ -86,6 +100,16 @@ func preLoadTestChecks(clusterName string) error {
 	if err := checkCluster(clusterName); err != nil {
 		return err
 	}
+	if len(loadTestRunEntries) > 0 && loadTestCmd != "" {
+		return fmt.Errorf("could not use both --load-test-cmd and --load-test-run")
+	}
+	if loadTestParallel && len(loadTestRunEntries) == 0 {
+		return fmt.Errorf("--parallel-runs can only be used with --load-test-run")
+	}
+	var err error
+	if loadTestRuns, err = parseLoadTestRuns(loadTestRunEntries); err != nil {
+		return err
+	}
 	if useAWS && useGCP {
 		return fmt.Errorf("could not use both AWS and GCP cloud options")
 	}
@@ -219,7 +243,7 @@ func startLoadTest(_ *cobra.Command, args []string) error {
 		}
 		if existingSeparateInstance == "" {
 			for _, sg := range filteredSGList {
-				if err = grantAccessToPublicIPViaSecurityGroup(ec2SvcMap[sg.region], loadTestNodeConfig.PublicIPs[0], sg.securityGroup, sg.region); err != nil {
+				if err = grantAccessToPublicIPViaSecurityGroup(ec2SvcMap[sg.region], loadTestNodeConfig.PublicIPs[0], sg.securityGroup, sg.region, sg.httpPort); err != nil {
 					return err
 				}
 			}
@@ -260,7 +284,7 @@ func startLoadTest(_ *cobra.Command, args []string) error {
 			loadTestNodeConfig.PublicIPs = []string{loadTestPublicIPMap[loadTestNodeConfig.InstanceIDs[0]]}
 		}
 		if existingSeparateInstance == "" {
-			if err = grantAccessToPublicIPViaFirewall(gcpClient, projectName, loadTestNodeConfig.PublicIPs[0], "loadtest"); err != nil {
+			if err = grantAccessToPublicIPViaFirewall(gcpClient, projectName, loadTestNodeConfig.PublicIPs[0], "loadtest", filteredSGList[0].httpPort); err != nil {
 				return err
 			}
 		}
@@ -298,10 +322,10 @@ func startLoadTest(_ *cobra.Command, args []string) error {
 
 	// waiting for all nodes to become accessible
 	if existingSeparateInstance == "" {
-		failedHosts := waitForHosts(currentLoadTestHost)
+		failedHosts := waitForHosts(currentLoadTestHost, constants.SSHServerStartTimeout)
 		if failedHosts.Len() > 0 {
 			for _, result := range failedHosts.GetResults() {
-				ux.Logger.PrintToUser("Instance %s failed to provision with error %s. Please check instance logs for more information", result.NodeID, result.Err)
+				ux.Logger.PrintToUser("Instance %s failed to provision with error %s. Please check instance logs for more information", result.NodeID, describeNodeError(result.Err))
 			}
 			return fmt.Errorf("failed to provision node(s) %s", failedHosts.GetNodeList())
 		}
@@ -326,14 +350,7 @@ func startLoadTest(_ *cobra.Command, args []string) error {
 		if err := docker.ComposeSSHSetupLoadTest(currentLoadTestHost[0]); err != nil {
 			return err
 		}
-		avalancheGoPorts, machinePorts, ltPorts, err := getPrometheusTargets(clusterName)
-		if err != nil {
-			return err
-		}
-		if err := ssh.RunSSHSetupPrometheusConfig(monitoringHosts[0], avalancheGoPorts, machinePorts, ltPorts); err != nil {
-			return err
-		}
-		if err := docker.RestartDockerComposeService(monitoringHosts[0], utils.GetRemoteComposeFile(), "prometheus", constants.SSHLongRunningScriptTimeout); err != nil {
+		if err := updatePrometheusConfig(clusterName); err != nil {
 			return err
 		}
 	}
@@ -362,13 +379,41 @@ func startLoadTest(_ *cobra.Command, args []string) error {
 	}
 
 	ux.Logger.PrintToUser("%s Running load test", logging.Green.Wrap(">"))
-	if err := ssh.RunSSHRunLoadTest(currentLoadTestHost[0], loadTestCmd, loadTestName); err != nil {
+	if len(loadTestRuns) > 0 {
+		if err := ssh.RunSSHRunLoadTests(currentLoadTestHost[0], loadTestName, loadTestRuns, loadTestParallel); err != nil {
+			return err
+		}
+		for _, run := range loadTestRuns {
+			ux.Logger.PrintToUser("Load test run %s writes to %s", run.Name, ssh.LoadTestResultFileName(loadTestName, run.Name))
+		}
+	} else if err := ssh.RunSSHRunLoadTest(currentLoadTestHost[0], loadTestCmd, loadTestName); err != nil {
 		return err
 	}
 	ux.Logger.PrintToUser("Load test successfully run!")
 	return nil
 }
 
+// parseLoadTestRuns parses the name=command [entries] of --load-test-run, keeping their order
+func parseLoadTestRuns(entries []string) ([]ssh.LoadTestRun, error) {
+	commands, err := utils.ParseKeyValues(entries)
+	if err != nil {
+		return nil, err
+	}
+	runs := []ssh.LoadTestRun{}
+	for _, entry := range entries {
+		name, _, _ := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !loadTestRunNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("invalid load test run name %q: only letters, numbers, '_' and '-' are allowed", name)
+		}
+		if commands[name] == "" {
+			return nil, fmt.Errorf("load test run %s has no command", name)
+		}
+		runs = append(runs, ssh.LoadTestRun{Name: name, Command: commands[name]})
+	}
+	return runs, nil
+}
+
 func getDeployedSubnetInfo(clusterName string, subnetName string) (string, string, error) {
 	sc, err := app.LoadSidecar(subnetName)
 	if err != nil {
@@ -512,7 +557,7 @@ func GetLoadTestScript(app *application.Avalanche) error {
 			return err
 		}
 	}
-	if loadTestCmd == "" {
+	if loadTestCmd == "" && len(loadTestRuns) == 0 {
 		loadTestCmd, err = app.Prompt.CaptureString("What is the load test command?")
 		if err != nil {
 			return err
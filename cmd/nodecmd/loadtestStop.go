@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/ansible"
 	awsAPI "github.com/ava-labs/avalanche-cli/pkg/cloud/aws"
@@ -141,10 +143,12 @@ func stopLoadTest(_ *cobra.Command, args []string) error {
 			return fmt.Errorf("host %s is not found in hosts inventory file", nodeConfig.NodeID)
 		}
 		host := hosts[0]
-		loadTestResultFileName := fmt.Sprintf("loadtest_%s.txt", loadTestName)
-		// Download the load test result from remote cloud server to local machine
-		if err = ssh.RunSSHDownloadFile(host, fmt.Sprintf("/home/ubuntu/%s", loadTestResultFileName), filepath.Join(app.GetAnsibleInventoryDirPath(clusterName), loadTestResultFileName)); err != nil {
-			ux.Logger.RedXToUser("Unable to download load test result %s to local machine due to %s", loadTestResultFileName, err.Error())
+		// Download the load test results of all runs from remote cloud server to local machine
+		resultsDir := app.GetAnsibleInventoryDirPath(clusterName)
+		if resultFiles, err := ssh.RunSSHDownloadFiles(host, ssh.GetRemoteLoadTestResultFiles(loadTestName), resultsDir); err != nil {
+			ux.Logger.RedXToUser("Unable to download load test results of %s to local machine due to %s", loadTestName, err.Error())
+		} else {
+			printLoadTestSummary(loadTestName, resultsDir, resultFiles)
 		}
 		switch nodeConfig.CloudService {
 		case constants.AWSCloudService:
@@ -160,7 +164,7 @@ func stopLoadTest(_ *cobra.Command, args []string) error {
 				return err
 			}
 			for _, sg := range filteredSGList {
-				if err = deleteHostSecurityGroupRule(ec2SvcMap[sg.region], loadTestNodeConfig.PublicIPs[0], sg.securityGroup); err != nil {
+				if err = deleteHostSecurityGroupRule(ec2SvcMap[sg.region], loadTestNodeConfig.PublicIPs[0], sg.securityGroup, sg.httpPort); err != nil {
 					ux.Logger.RedXToUser("unable to delete IP address %s from security group %s in region %s due to %s, please delete it manually",
 						loadTestNodeConfig.PublicIPs[0], sg.securityGroup, sg.region, err.Error())
 				}
@@ -277,3 +281,36 @@ func removeLoadTestNodeFromClustersConfig(clusterName, loadTestName string) erro
 func removeLoadTestInventoryDir(clusterName string) error {
 	return os.RemoveAll(app.GetLoadTestInventoryDir(clusterName))
 }
+
+// loadTestRunName returns the run name of the load test [loadTestName] that wrote [resultFile],
+// empty for the single run of a load test
+func loadTestRunName(loadTestName string, resultFile string) string {
+	runName := strings.TrimPrefix(filepath.Base(resultFile), strings.TrimSuffix(ssh.LoadTestResultFileName(loadTestName, ""), ".txt"))
+	return strings.Trim(strings.TrimSuffix(runName, ".txt"), ".")
+}
+
+// lastOutputLine returns the last non empty line of [output], where load tests usually
+// print their final stats
+func lastOutputLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// printLoadTestSummary prints, for each result file of [loadTestName] downloaded to [resultsDir],
+// the run that wrote it and its last output line
+func printLoadTestSummary(loadTestName string, resultsDir string, resultFiles []string) {
+	sort.Strings(resultFiles)
+	ux.Logger.PrintToUser("Load test %s results downloaded to %s:", loadTestName, resultsDir)
+	for _, resultFile := range resultFiles {
+		runName := loadTestRunName(loadTestName, resultFile)
+		if runName == "" {
+			runName = loadTestName
+		}
+		output, err := os.ReadFile(filepath.Join(resultsDir, resultFile))
+		if err != nil {
+			ux.Logger.PrintToUser("  %s (%s): %s", runName, resultFile, err)
+			continue
+		}
+		ux.Logger.PrintToUser("  %s (%s): %s", runName, resultFile, lastOutputLine(string(output)))
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLoadTestRuns(t *testing.T) {
+	require := require.New(t)
+
+	runs, err := parseLoadTestRuns([]string{"mysubnet=./simulator --chain=mysubnet", "cchain=./simulator --chain=C"})
+	require.NoError(err)
+	require.Equal([]ssh.LoadTestRun{
+		{Name: "mysubnet", Command: "./simulator --chain=mysubnet"},
+		{Name: "cchain", Command: "./simulator --chain=C"},
+	}, runs)
+
+	for _, entries := range [][]string{
+		{"./simulator"},
+		{"a=./simulator", "a=./simulator"},
+		{"a.b=./simulator"},
+		{"a="},
+	} {
+		_, err := parseLoadTestRuns(entries)
+		require.Error(err, entries)
+	}
+}
+
+func TestLoadTestRunName(t *testing.T) {
+	require := require.New(t)
+
+	for _, runName := range []string{"", "cchain", "my_subnet"} {
+		require.Equal(runName, loadTestRunName("lt1", ssh.LoadTestResultFileName("lt1", runName)))
+	}
+	require.Equal("cchain", loadTestRunName("lt1", "results/loadtest_lt1.cchain.txt"))
+	require.Equal("avg tps: 1500", lastOutputLine("starting\navg tps: 1500\n\n"))
+}
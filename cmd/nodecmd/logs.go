@@ -0,0 +1,80 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsNode   string
+	logsFile   string
+	logsLines  int
+	logsSince  time.Duration
+	logsFollow bool
+)
+
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [clusterName]",
+		Short: "(ALPHA Warning) Show the AvalancheGo logs of a node",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node logs command prints the last lines of the AvalancheGo logs of a node. By default
+the docker compose logs of the avalanchego service are shown. Use --file to read a log file
+of the AvalancheGo logs dir instead, as main.log or C.log.
+
+With --follow, new lines are streamed until Ctrl-C is pressed.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: nodeLogs,
+	}
+	cmd.Flags().StringVar(&logsNode, "node", "", "node to get logs from, by cloud ID, IP or NodeID. required if the cluster has more than one node")
+	cmd.Flags().StringVar(&logsFile, "file", "", "AvalancheGo log file to read, as main.log, instead of the docker compose logs")
+	cmd.Flags().IntVar(&logsLines, "lines", node.DefaultLogsLines, "number of lines to show from the end of the log")
+	cmd.Flags().DurationVar(&logsSince, "since", 0, "only show lines newer than the given duration, as 30m. docker compose logs only")
+	cmd.Flags().BoolVar(&logsFollow, "follow", false, "keep streaming new log lines")
+	return cmd
+}
+
+func nodeLogs(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	host, err := getClusterHost(clusterName, logsNode)
+	if err != nil {
+		return err
+	}
+	defer disconnectHosts([]*models.Host{host})
+	logsCmd, err := node.LogsCommand(node.LogsOptions{
+		File:    logsFile,
+		LogsDir: host.ExpandHome(constants.CloudNodeLogsPath),
+		Lines:   logsLines,
+		Since:   logsSince,
+		Follow:  logsFollow,
+	})
+	if err != nil {
+		return err
+	}
+	if !logsFollow {
+		output, err := host.Command(logsCmd, nil, constants.SSHLongRunningScriptTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to get logs of node %s: %w: %s", host.GetCloudID(), err, string(output))
+		}
+		fmt.Print(string(output))
+		return nil
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	ux.Logger.PrintToUser("Following logs of node %s. Press Ctrl-C to stop", host.GetCloudID())
+	return host.StreamSSHCommandContext(ctx, logsCmd, nil)
+}
@@ -30,18 +30,39 @@ rest of the commands to maintain your node and make your node a Subnet Validator
 	cmd.AddCommand(newSyncCmd())
 	// node destroy
 	cmd.AddCommand(newDestroyCmd())
+	// node stop
+	cmd.AddCommand(newStopCmd())
+	// node restart
+	cmd.AddCommand(newRestartCmd())
+	// node diagnostics
+	cmd.AddCommand(newDiagnosticsCmd())
+	// node logs
+	cmd.AddCommand(newLogsCmd())
+	// node health
+	cmd.AddCommand(newHealthCmd())
+	// node rotate-keys
+	cmd.AddCommand(newRotateKeysCmd())
+	// node set-log-level
+	cmd.AddCommand(newSetLogLevelCmd())
 	// node status cluster
 	cmd.AddCommand(newStatusCmd())
 	// node list
 	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newIDCmd())
 	// node update
 	cmd.AddCommand(newUpdateCmd())
 	// node devnet
 	cmd.AddCommand(newDevnetCmd())
 	// node upgrade
 	cmd.AddCommand(newUpgradeCmd())
+	// node upgrade-subnet-evm
+	cmd.AddCommand(newUpgradeSubnetEVMCmd())
+	// node restore-snapshot
+	cmd.AddCommand(newRestoreSnapshotCmd())
 	// node ssh
 	cmd.AddCommand(newSSHCmd())
+	// node ssh-config
+	cmd.AddCommand(newSSHConfigCmd())
 	// node scp
 	cmd.AddCommand(newSCPCmd())
 	// node whitelist
@@ -56,6 +77,10 @@ rest of the commands to maintain your node and make your node a Subnet Validator
 	cmd.AddCommand(newAddDashboardCmd())
 	// node export
 	cmd.AddCommand(newExportCmd())
+	// node export-config
+	cmd.AddCommand(newExportConfigCmd())
+	// node config-diff
+	cmd.AddCommand(newConfigDiffCmd())
 	// node import
 	cmd.AddCommand(newImportCmd())
 	return cmd
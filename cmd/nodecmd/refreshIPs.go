@@ -3,25 +3,37 @@
 package nodecmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
+	"github.com/ava-labs/avalanche-cli/pkg/ansible"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/spf13/cobra"
 )
 
+var refreshStaticIPs bool
+
 func newRefreshIPsCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "refresh-ips [clusterName]",
-		Short: "(ALPHA Warning) Refresh IPs for nodes with dynamic IPs in the cluster",
+		Use:     "refresh-ips [clusterName]",
+		Aliases: []string{"refresh-ip"},
+		Short:   "(ALPHA Warning) Refresh IPs for nodes with dynamic IPs in the cluster",
 		Long: `(ALPHA Warning) This command is currently in experimental mode.
 
 The node refresh-ips command obtains the current IP for all nodes with dynamic IPs in the cluster,
-and updates the local node information used by CLI commands.`,
+and updates the local node information used by CLI commands.
+
+Use --static-ips to also refresh nodes with static IPs, eg. after an elastic IP
+was re-associated. Nodes with no public IP, such as stopped instances, keep their
+current IP.`,
 		Args: cobrautils.ExactArgs(1),
 		RunE: refreshIPs,
 	}
 
+	cmd.Flags().BoolVar(&refreshStaticIPs, "static-ips", false, "also refresh IPs of nodes with static IPs")
 	cmd.Flags().StringVar(&awsProfile, "aws-profile", constants.AWSDefaultCredential, "aws profile to use")
 
 	return cmd
@@ -35,7 +47,16 @@ func refreshIPs(_ *cobra.Command, args []string) error {
 	if err := failForExternal(clusterName); err != nil {
 		return err
 	}
-	return updatePublicIPs(clusterName)
+	// an empty inventory has no IPs to update, so it is rebuilt instead
+	hosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(hosts) == 0 {
+		ux.Logger.PrintToUser("Inventory of cluster %s has no nodes, regenerating it from the cluster config", clusterName)
+		return regenerateClusterInventory(clusterName)
+	}
+	return updatePublicIPs(clusterName, refreshStaticIPs)
 }
 
 func failForExternal(clusterName string) error {
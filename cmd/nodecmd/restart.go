@@ -0,0 +1,131 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var (
+	maxUnavailable           int
+	continueOnRestartFailure bool
+	restartUnhealthyOnly     bool
+)
+
+func newRestartCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restart [clusterName]",
+		Short: "(ALPHA Warning) Restart AvalancheGo on all nodes in a cluster, one batch at a time",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node restart command restarts AvalancheGo on all nodes in a cluster without
+taking the whole cluster down. Nodes are restarted in batches of at most
+--max-unavailable nodes, and the next batch is restarted only after every node
+of the previous one is bootstrapped and healthy again.
+
+If a node fails to restart or to become healthy within --wait-healthy-timeout, the
+remaining nodes are not restarted, unless --continue-on-failure is given.
+
+With --unhealthy-only, the health of every node is checked first, and only the
+nodes reporting unhealthy or that can't be reached are restarted. Healthy nodes
+are left untouched.
+
+Nodes stopped with avalanche node stop are started again, and no longer reported
+as STOPPED by avalanche node status.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: restartNodes,
+	}
+	cmd.Flags().IntVar(&maxUnavailable, "max-unavailable", 1, "maximum number of nodes restarted at the same time")
+	cmd.Flags().BoolVar(&continueOnRestartFailure, "continue-on-failure", false, "keep restarting the remaining nodes if a node fails to become healthy")
+	cmd.Flags().BoolVar(&restartUnhealthyOnly, "unhealthy-only", false, "only restart nodes that are unhealthy or unreachable")
+	cmd.Flags().DurationVar(&waitHealthyTimeout, "wait-healthy-timeout", constants.NodeWaitHealthyTimeout, "maximum time to wait for each restarted node to become healthy")
+	cmd.Flags().DurationVar(&waitHealthyPoll, "wait-healthy-interval", constants.NodeWaitHealthyPollInterval, "interval between node health checks")
+	addHostFilterFlags(cmd)
+	return cmd
+}
+
+func restartNodes(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	if maxUnavailable < 1 {
+		return fmt.Errorf("max unavailable nodes must be at least 1")
+	}
+	if err := validateWaitHealthyFlags(); err != nil {
+		return err
+	}
+	if err := checkCluster(clusterName); err != nil {
+		return err
+	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	if hosts, err = filterClusterHosts(hosts); err != nil {
+		return err
+	}
+	defer disconnectHosts(hosts)
+
+	if restartUnhealthyOnly {
+		ux.Logger.PrintToUser("Checking health of %d node(s) in cluster %s...", len(hosts), clusterName)
+		hostsHealth := node.CheckHostsHealth(hosts, ssh.RunSSHCheckHealthy)
+		for _, hostHealth := range hostsHealth {
+			switch hostHealth.Status {
+			case node.HealthStatusHealthy:
+				ux.Logger.PrintToUser("Node %s is healthy, skipping it", hostHealth.Host.NodeID)
+			case node.HealthStatusUnreachable:
+				ux.Logger.PrintToUser("Node %s is unreachable: %s", hostHealth.Host.NodeID, describeNodeError(hostHealth.Err))
+			default:
+				ux.Logger.PrintToUser("Node %s is unhealthy", hostHealth.Host.NodeID)
+			}
+		}
+		hosts = node.FilterHostsByHealth(hostsHealth, node.HealthStatusUnhealthy, node.HealthStatusUnreachable)
+		if len(hosts) == 0 {
+			ux.Logger.GreenCheckmarkToUser("All node(s) in cluster %s are healthy, nothing to restart", clusterName)
+			return nil
+		}
+	}
+
+	ux.Logger.PrintToUser("Restarting %d node(s) in cluster %s, at most %d at a time...", len(hosts), clusterName, maxUnavailable)
+	results := node.RollingRestart(
+		hosts,
+		maxUnavailable,
+		func(host *models.Host) error {
+			if err := ssh.RunSSHRestartNode(host); err != nil {
+				return err
+			}
+			// nodes stopped with avalanche node stop are running again
+			return markNodeStarted(host.GetCloudID())
+		},
+		func(host *models.Host) error {
+			return waitForHostHealthy(host, waitHealthyTimeout, waitHealthyPoll)
+		},
+		continueOnRestartFailure,
+		func(host *models.Host, err error) {
+			switch {
+			case err == nil:
+				ux.Logger.GreenCheckmarkToUser("Node %s restarted and healthy", host.NodeID)
+			case errors.Is(err, node.ErrRestartSkipped):
+				ux.Logger.PrintToUser("Node %s skipped: %s", host.NodeID, err)
+			default:
+				ux.Logger.RedXToUser("Node %s failed to restart: %s", host.NodeID, describeNodeError(err))
+			}
+		},
+	)
+	if results.HasErrors() {
+		return fmt.Errorf("failed to restart node(s) %s", results.GetErrorHosts())
+	}
+	if restartUnhealthyOnly {
+		ux.Logger.GreenCheckmarkToUser("All unhealthy node(s) in cluster %s restarted", clusterName)
+		return nil
+	}
+	ux.Logger.GreenCheckmarkToUser("All node(s) in cluster %s restarted", clusterName)
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/cobra"
+)
+
+var rotateKeysNode string
+
+func newRotateKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-keys [clusterName]",
+		Short: "(ALPHA Warning) Replace the staking keys of a node",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node rotate-keys command generates a new staking certificate, staking key and
+BLS key for a node, uploads them and restarts the node, for example when its keys
+may have been compromised.
+
+The NodeID is derived from the staking certificate, so it changes. Validations
+of the previous NodeID, on the Primary Network and on Subnets, are not moved to
+the new one: the node must be registered again as a validator.
+
+The previous keys are kept locally in a backup directory.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: rotateKeys,
+	}
+	cmd.Flags().StringVar(&rotateKeysNode, "node", "", "node to rotate keys of, by cloud ID, IP or NodeID. required if the cluster has more than one node")
+	addConfirmMainnetFlag(cmd)
+	return cmd
+}
+
+func rotateKeys(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	host, err := getClusterHost(clusterName, rotateKeysNode)
+	if err != nil {
+		return err
+	}
+	defer disconnectHosts([]*models.Host{host})
+	if err := checkMainnetConfirmation(clusterName, "rotating the staking keys of a node"); err != nil {
+		return err
+	}
+	cloudID := host.GetCloudID()
+	keyDir := app.GetNodeInstanceDirPath(cloudID)
+	previousNodeID, err := getNodeID(keyDir)
+	if err != nil {
+		return err
+	}
+
+	ux.Logger.PrintToUser(logging.Red.Wrap(fmt.Sprintf(
+		"WARNING: rotating the staking keys of node %s changes its NodeID %s. "+
+			"The node will stop validating the Primary Network and any Subnet, and must be registered as a validator again",
+		cloudID,
+		previousNodeID,
+	)))
+	yes, err := app.Prompt.CaptureYesNo("Do you want to rotate the staking keys?")
+	if err != nil {
+		return err
+	}
+	if !yes {
+		return errors.New("abort avalanche node rotate-keys command")
+	}
+
+	nodeID, backupDir, err := node.RotateStakingKeys(
+		keyDir,
+		generateNodeCertAndKeys,
+		func(keyDir string) error {
+			return ssh.RunSSHUploadStakingFiles(host, keyDir)
+		},
+		func() error {
+			return ssh.RunSSHRestartNode(host)
+		},
+	)
+	if backupDir != "" {
+		ux.Logger.PrintToUser("Previous staking keys of node %s saved at %s", cloudID, backupDir)
+	}
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := app.LoadClusterNodeConfig(cloudID)
+	if err != nil {
+		return err
+	}
+	nodeConfig.AvalancheGoNodeID = ""
+	if err := app.CreateNodeCloudConfigFile(cloudID, &nodeConfig); err != nil {
+		return err
+	}
+	ux.Logger.GreenCheckmarkToUser("Staking keys of node %s rotated. NodeID changed from %s to %s", cloudID, previousNodeID, nodeID)
+	ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf("Remember to register %s as a validator again, for example with avalanche node validate primary %s", nodeID, clusterName)))
+	return nil
+}
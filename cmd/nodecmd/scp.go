@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/spf13/cobra"
@@ -43,6 +44,8 @@ For example:
 $ avalanche node scp [cluster1|node1]:/tmp/file.txt /tmp/file.txt
 $ avalanche node scp /tmp/file.txt [cluster1|NodeID-XXXX]:/tmp/file.txt
 $ avalanche node scp node1:/tmp/file.txt NodeID-XXXX:/tmp/file.txt
+
+To download files from all nodes of a cluster into per node folders, use node scp download.
 `,
 		Args: cobrautils.MinimumNArgs(2),
 		RunE: scpNode,
@@ -51,9 +54,65 @@ $ avalanche node scp node1:/tmp/file.txt NodeID-XXXX:/tmp/file.txt
 	cmd.Flags().BoolVar(&withCompression, "compress", false, "use compression for ssh")
 	cmd.Flags().BoolVar(&includeMonitor, "with-monitor", false, "include monitoring node for scp cluster operations")
 	cmd.Flags().BoolVar(&includeLoadTest, "with-loadtest", false, "include loadtest node for scp cluster operations")
+	cmd.AddCommand(newSCPDownloadCmd())
 	return cmd
 }
 
+func newSCPDownloadCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "download [clusterName] [remotePath] [localDir]",
+		Short: "(ALPHA Warning) Download files from all nodes of a cluster",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node scp download command downloads the files matching remotePath from all
+nodes of a cluster into localDir, one subfolder per node. remotePath can contain
+globs, like ~/.avalanchego/logs/loadtest_*.txt, that are resolved on
+each node. Downloads are parallelized.`,
+		Args: cobrautils.ExactArgs(3),
+		RunE: scpDownload,
+	}
+	cmd.Flags().BoolVar(&includeMonitor, "with-monitor", false, "include monitoring node")
+	cmd.Flags().BoolVar(&includeLoadTest, "with-loadtest", false, "include loadtest node")
+	return cmd
+}
+
+func scpDownload(_ *cobra.Command, args []string) error {
+	clusterName, remotePath, localDir := args[0], args[1], args[2]
+	if err := utils.ValidateRemoteGlob(remotePath); err != nil {
+		return err
+	}
+	hosts, err := GetAllClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	defer disconnectHosts(hosts)
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	spinSession := ux.NewUserSpinner()
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, "Download "+remotePath))
+			files, err := ssh.RunSSHDownloadFiles(host, remotePath, filepath.Join(localDir, host.GetCloudID()))
+			if err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				ux.SpinFailWithError(spinner, "", err)
+				return
+			}
+			nodeResults.AddResult(host.NodeID, files, nil)
+			ux.SpinComplete(spinner)
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	spinSession.Stop()
+	if wgResults.HasErrors() {
+		return fmt.Errorf("failed to download files from node(s) %s", wgResults.GetErrorHostMap())
+	}
+	ux.Logger.GreenCheckmarkToUser("Files matching %s downloaded from cluster %s into %s", remotePath, clusterName, localDir)
+	return nil
+}
+
 func scpNode(_ *cobra.Command, args []string) error {
 	var err error
 	clustersConfig := models.ClustersConfig{}
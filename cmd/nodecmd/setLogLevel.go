@@ -0,0 +1,92 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/remoteconfig"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+func newSetLogLevelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-log-level [clusterName] [logLevel]",
+		Short: "(ALPHA Warning) Set AvalancheGo log level of all nodes in a cluster",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node set-log-level command sets the AvalancheGo log level of all nodes in a cluster,
+and restarts them so that it takes effect.
+
+Accepted log levels are: off, fatal, error, warn, info, trace, debug, verbo`,
+		Args: cobrautils.ExactArgs(2),
+		RunE: setLogLevel,
+	}
+	cmd.Flags().StringSliceVar(&validators, "validators", []string{}, "set log level only on given comma separated list of validators. defaults to all cluster nodes")
+	addHostFilterFlags(cmd)
+	return cmd
+}
+
+func setLogLevel(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	logLevel := args[1]
+	if err := remoteconfig.ValidateLogLevel(logLevel); err != nil {
+		return err
+	}
+	if err := checkCluster(clusterName); err != nil {
+		return err
+	}
+	clusterConfig, err := app.GetClusterConfig(clusterName)
+	if err != nil {
+		return err
+	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	if hosts, err = filterClusterHosts(hosts); err != nil {
+		return err
+	}
+	if len(validators) != 0 {
+		hosts, err = filterHosts(hosts, validators)
+		if err != nil {
+			return err
+		}
+	}
+	defer disconnectHosts(hosts)
+
+	spinSession := ux.NewUserSpinner()
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, "Set log level to "+logLevel))
+			if err := ssh.RunSSHRenderAvalancheNodeConfigWithLogLevel(app, host, clusterConfig.Network, clusterConfig.Subnets, logLevel); err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				ux.SpinFailWithError(spinner, "", err)
+				return
+			}
+			if err := ssh.RunSSHRestartNode(host); err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				ux.SpinFailWithError(spinner, "", err)
+				return
+			}
+			ux.SpinComplete(spinner)
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	spinSession.Stop()
+	if wgResults.HasErrors() {
+		return fmt.Errorf("failed to set log level for node(s) %s", wgResults.GetErrorHostMap())
+	}
+	ux.Logger.GreenCheckmarkToUser("Log level of node(s) in cluster %s set to %s", clusterName, logLevel)
+	return nil
+}
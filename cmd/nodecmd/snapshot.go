@@ -0,0 +1,173 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreSnapshotName           string
+	restoreSnapshotMaxUnavailable int
+)
+
+func newRestoreSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore-snapshot [clusterName]",
+		Short: "(ALPHA Warning) Restore the avalanchego DB of the nodes of a cluster from a snapshot",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node restore-snapshot command stops the nodes of the cluster, replaces their avalanchego
+DB with a snapshot taken by node upgrade --snapshot, and starts them again.
+
+By default, the most recent snapshot stored on each node is restored. Use --snapshot to give
+the name of a snapshot stored on the nodes, or the path of a snapshot downloaded with
+node upgrade --snapshot-download, which is uploaded to the nodes first.
+
+Nodes are restored in batches of at most --max-unavailable nodes, and the next batch is
+restored only after every node of the previous one is healthy again. If a node fails, the
+remaining nodes are not restored. Each node is checked to have enough free disk space to
+extract the snapshot before it is stopped.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: restoreSnapshot,
+	}
+	cmd.Flags().StringVar(&restoreSnapshotName, "snapshot", "", "name of the snapshot on the nodes, or path of a local snapshot, to restore. defaults to the latest one on each node")
+	cmd.Flags().IntVar(&restoreSnapshotMaxUnavailable, "max-unavailable", 1, "maximum number of nodes restored at the same time")
+	cmd.Flags().DurationVar(&waitHealthyTimeout, "wait-healthy-timeout", constants.NodeWaitHealthyTimeout, "maximum time to wait for each restored node to become healthy")
+	cmd.Flags().DurationVar(&waitHealthyPoll, "wait-healthy-interval", constants.NodeWaitHealthyPollInterval, "interval between node health checks")
+	addHostFilterFlags(cmd)
+	addConfirmMainnetFlag(cmd)
+	return cmd
+}
+
+func restoreSnapshot(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	if restoreSnapshotMaxUnavailable < 1 {
+		return fmt.Errorf("max unavailable nodes must be at least 1")
+	}
+	if err := validateWaitHealthyFlags(); err != nil {
+		return err
+	}
+	if err := checkCluster(clusterName); err != nil {
+		return err
+	}
+	if err := checkMainnetConfirmation(clusterName, "replacing the DB of its nodes"); err != nil {
+		return err
+	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	if hosts, err = filterClusterHosts(hosts); err != nil {
+		return err
+	}
+	defer disconnectHosts(hosts)
+	snapshotPaths := sync.Map{}
+	ux.Logger.PrintToUser("Restoring snapshot on %d node(s) in cluster %s, at most %d at a time...", len(hosts), clusterName, restoreSnapshotMaxUnavailable)
+	results := node.RollingRestart(
+		hosts,
+		restoreSnapshotMaxUnavailable,
+		func(host *models.Host) error {
+			snapshotPath, err := restoreNodeDBSnapshot(host, restoreSnapshotName)
+			snapshotPaths.Store(host.NodeID, snapshotPath)
+			return err
+		},
+		func(host *models.Host) error {
+			return waitForHostHealthy(host, waitHealthyTimeout, waitHealthyPoll)
+		},
+		false,
+		func(host *models.Host, err error) {
+			switch {
+			case err == nil:
+				snapshotPath, _ := snapshotPaths.Load(host.NodeID)
+				ux.Logger.GreenCheckmarkToUser("Node %s restored from %s", host.GetCloudID(), snapshotPath)
+			case errors.Is(err, node.ErrRestartSkipped):
+				ux.Logger.PrintToUser("Node %s skipped: %s", host.GetCloudID(), err)
+			default:
+				ux.Logger.RedXToUser("Node %s failed to restore snapshot: %s", host.GetCloudID(), describeNodeError(err))
+			}
+		},
+	)
+	if results.HasErrors() {
+		return fmt.Errorf("failed to restore snapshot on node(s) %s", results.GetErrorHosts())
+	}
+	return nil
+}
+
+// restoreNodeDBSnapshot stops [host], restores its DB from [snapshot] and starts it again.
+// [snapshot] can be the name of a snapshot on the host, the path of a local snapshot, or empty
+// for the latest snapshot on the host. Returns the path of the restored snapshot on the host
+func restoreNodeDBSnapshot(host *models.Host, snapshot string) (string, error) {
+	var snapshotPath string
+	switch {
+	case snapshot == "":
+		snapshots, err := ssh.RunSSHListDBSnapshots(host)
+		if err != nil {
+			return "", err
+		}
+		latest := node.LatestDBSnapshot(snapshots)
+		if latest == "" {
+			return "", fmt.Errorf("no db snapshots found at %s", host.ExpandHome(constants.CloudNodeDBSnapshotsPath))
+		}
+		snapshotPath = host.ExpandHome(filepath.Join(constants.CloudNodeDBSnapshotsPath, latest))
+	case utils.FileExists(snapshot):
+		ux.Logger.PrintToUser("Uploading %s to node %s...", snapshot, host.GetCloudID())
+		var err error
+		if snapshotPath, err = ssh.RunSSHUploadDBSnapshot(host, snapshot); err != nil {
+			return "", err
+		}
+	default:
+		snapshotPath = host.ExpandHome(filepath.Join(constants.CloudNodeDBSnapshotsPath, filepath.Base(snapshot)))
+	}
+	// checked before stopping the node, so that it keeps running if the snapshot can't be extracted
+	if err := ssh.RunSSHCheckDBSnapshotDiskSpace(host, snapshotPath); err != nil {
+		return "", err
+	}
+	if err := ssh.RunSSHStopNode(host); err != nil {
+		return "", err
+	}
+	if err := ssh.RunSSHRestoreDBSnapshot(host, snapshotPath); err != nil {
+		// the DB is only replaced once the snapshot is extracted, so the node can run again
+		if startErr := ssh.RunSSHStartNode(host); startErr != nil {
+			return "", fmt.Errorf("%w, and node failed to start again: %w", err, startErr)
+		}
+		return "", err
+	}
+	return snapshotPath, ssh.RunSSHStartNode(host)
+}
+
+// snapshotNodeDB archives the DB of the stopped [host] to a timestamped snapshot on the host.
+// If [download] is set, the snapshot is downloaded to the node local dir and removed from the host
+func snapshotNodeDB(host *models.Host, download bool) error {
+	snapshotName := node.DBSnapshotName(time.Now())
+	snapshotPath, err := ssh.RunSSHSnapshotDB(host, snapshotName)
+	if err != nil {
+		return err
+	}
+	if !download {
+		ux.Logger.PrintToUser("Node %s db snapshot saved on the node at %s", host.GetCloudID(), snapshotPath)
+		return nil
+	}
+	localPath := filepath.Join(app.GetNodeInstanceDirPath(host.GetCloudID()), constants.DBSnapshotsDir, snapshotName)
+	if err := ssh.RunSSHDownloadDBSnapshot(host, snapshotPath, localPath); err != nil {
+		return err
+	}
+	if err := host.Remove(snapshotPath, false); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Node %s db snapshot downloaded to %s", host.GetCloudID(), localPath)
+	return nil
+}
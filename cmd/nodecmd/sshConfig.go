@@ -0,0 +1,118 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+var writeSSHConfig bool
+
+func newSSHConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ssh-config [clusterName]",
+		Short: "(ALPHA Warning) Print the ssh connection strings of the nodes of a cluster",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node ssh-config command prints the ssh command to connect to each node of the cluster,
+including its monitoring and load test nodes, using the IPs and cert paths of the node configs.
+
+With --write-ssh-config, a Host entry per node is written to ~/.ssh/config instead, so that
+nodes can be reached with ssh <nodeID>. The entries of the cluster are kept together, and
+are replaced when the command is run again, e.g. after the node IPs change.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: sshConfig,
+	}
+	cmd.Flags().BoolVar(&writeSSHConfig, "write-ssh-config", false, "write a Host entry per node to ~/.ssh/config")
+	return cmd
+}
+
+func sshConfig(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	if err := checkCluster(clusterName); err != nil {
+		return err
+	}
+	nodeConfigs, err := getClusterSSHNodeConfigs(clusterName)
+	if err != nil {
+		return err
+	}
+	if !writeSSHConfig {
+		for _, nodeConfig := range nodeConfigs {
+			ux.Logger.PrintToUser(utils.GetSSHConnectionString(nodeConfig.ElasticIP, nodeConfig.CertPath))
+		}
+		return nil
+	}
+	blocks := []string{}
+	for _, nodeConfig := range nodeConfigs {
+		blocks = append(blocks, utils.GetSSHConfigBlock(nodeConfig.NodeID, nodeConfig.ElasticIP, nodeConfig.CertPath))
+	}
+	sshConfigPath := utils.UserHomePath(".ssh", "config")
+	if err := writeClusterSSHConfig(sshConfigPath, clusterName, blocks); err != nil {
+		return err
+	}
+	ux.Logger.GreenCheckmarkToUser("Wrote ssh config for %d node(s) of cluster %s to %s", len(blocks), clusterName, sshConfigPath)
+	for _, nodeConfig := range nodeConfigs {
+		ux.Logger.PrintToUser("  ssh %s", nodeConfig.NodeID)
+	}
+	return nil
+}
+
+// getClusterSSHNodeConfigs returns the configs of the nodes of [clusterName], including its
+// monitoring and load test nodes, failing if any of them has no known IP
+func getClusterSSHNodeConfigs(clusterName string) ([]models.NodeConfig, error) {
+	clusterConfig, err := app.GetClusterConfig(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	cloudIDs := slices.Clone(clusterConfig.Nodes)
+	if clusterConfig.MonitoringInstance != "" {
+		cloudIDs = append(cloudIDs, clusterConfig.MonitoringInstance)
+	}
+	loadTestNames := maps.Keys(clusterConfig.LoadTestInstance)
+	slices.Sort(loadTestNames)
+	for _, loadTestName := range loadTestNames {
+		cloudIDs = append(cloudIDs, clusterConfig.LoadTestInstance[loadTestName])
+	}
+	nodeConfigs := []models.NodeConfig{}
+	for _, cloudID := range cloudIDs {
+		nodeConfig, err := app.LoadClusterNodeConfig(cloudID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config of node %s: %w", cloudID, err)
+		}
+		if nodeConfig.ElasticIP == "" {
+			return nil, fmt.Errorf("node %s has no known IP, run avalanche node refresh-ips %s first", cloudID, clusterName)
+		}
+		nodeConfigs = append(nodeConfigs, nodeConfig)
+	}
+	return nodeConfigs, nil
+}
+
+// writeClusterSSHConfig sets the ssh config section of [clusterName] in the file at
+// [sshConfigPath] to [blocks], creating the file if needed
+func writeClusterSSHConfig(sshConfigPath string, clusterName string, blocks []string) error {
+	config, err := os.ReadFile(sshConfigPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(sshConfigPath), 0o700); err != nil {
+		return err
+	}
+	sectionName := fmt.Sprintf("avalanche-cli cluster %s", clusterName)
+	return os.WriteFile(
+		sshConfigPath,
+		[]byte(utils.UpdateSSHConfigSection(string(config), sectionName, blocks)),
+		constants.WriteReadUserOnlyPerms,
+	)
+}
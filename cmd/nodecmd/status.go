@@ -6,12 +6,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"sync"
 
 	"github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
-	"github.com/ava-labs/avalanche-cli/pkg/ansible"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
 	"github.com/ava-labs/avalanche-cli/pkg/ssh"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
@@ -40,6 +39,7 @@ To get the bootstrap status of a node with a Subnet, use --subnet flag`,
 		RunE: statusNode,
 	}
 	cmd.Flags().StringVar(&subnetName, "subnet", "", "specify the subnet the node is syncing with")
+	addHostFilterFlags(cmd)
 
 	return cmd
 }
@@ -67,7 +67,16 @@ func statusNode(_ *cobra.Command, args []string) error {
 			return ErrNoBlockchainID
 		}
 	}
-	hostIDs := utils.Filter(clusterConf.GetCloudIDs(), clusterConf.IsAvalancheGoHost)
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	if hosts, err = filterClusterHosts(hosts); err != nil {
+		return err
+	}
+	hostIDs := utils.Filter(clusterConf.GetCloudIDs(), func(hostID string) bool {
+		return clusterConf.IsAvalancheGoHost(hostID) && slices.ContainsFunc(hosts, func(h *models.Host) bool { return h.GetCloudID() == hostID })
+	})
 	nodeIDs, err := utils.MapWithError(hostIDs, func(s string) (string, error) {
 		n, err := getNodeID(app.GetNodeInstanceDirPath(s))
 		return n.String(), err
@@ -82,109 +91,86 @@ func statusNode(_ *cobra.Command, args []string) error {
 		}
 	}
 
-	hosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
-	if err != nil {
-		return err
-	}
-	defer disconnectHosts(hosts)
-
-	spinSession := ux.NewUserSpinner()
-	spinner := spinSession.SpinToUser("Checking node(s) status...")
-	notBootstrappedNodes, err := getNotBootstrappedNodes(hosts)
-	if err != nil {
-		ux.SpinFailWithError(spinner, "", err)
-		return err
-	}
-	ux.SpinComplete(spinner)
+	// nodes stopped with avalanche node stop can't be queried
+	stoppedNodes := utils.Filter(hostIDs, func(hostID string) bool {
+		nodeConfig, err := app.LoadClusterNodeConfig(hostID)
+		return err == nil && nodeConfig.AvalancheGoStopped
+	})
 
-	spinner = spinSession.SpinToUser("Checking if node(s) are healthy...")
-	unhealthyNodes, err := getUnhealthyNodes(hosts)
-	if err != nil {
-		ux.SpinFailWithError(spinner, "", err)
-		return err
-	}
-	ux.SpinComplete(spinner)
+	hosts = utils.Filter(hosts, func(h *models.Host) bool { return !slices.Contains(stoppedNodes, h.GetCloudID()) })
+	defer disconnectHosts(hosts)
 
-	spinner = spinSession.SpinToUser("Getting avalanchego version of node(s)...")
-	wg := sync.WaitGroup{}
-	wgResults := models.NodeResults{}
-	for _, host := range hosts {
-		wg.Add(1)
-		go func(nodeResults *models.NodeResults, host *models.Host) {
-			defer wg.Done()
-			if resp, err := ssh.RunSSHCheckAvalancheGoVersion(host); err != nil {
-				nodeResults.AddResult(host.GetCloudID(), nil, err)
-				return
-			} else {
-				if avalancheGoVersion, _, err := parseAvalancheGoOutput(resp); err != nil {
-					nodeResults.AddResult(host.GetCloudID(), nil, err)
-				} else {
-					nodeResults.AddResult(host.GetCloudID(), avalancheGoVersion, err)
-				}
+	checks := node.HostStatusChecks{
+		Bootstrapped: func(host *models.Host) (bool, error) {
+			resp, err := ssh.RunSSHCheckBootstrapped(host)
+			if err != nil {
+				return false, err
+			}
+			return parseBootstrappedOutput(resp)
+		},
+		Healthy: func(host *models.Host) (bool, error) {
+			resp, err := ssh.RunSSHCheckHealthy(host)
+			if err != nil {
+				return false, err
 			}
-		}(&wgResults, host)
+			return node.ParseHealthyOutput(resp)
+		},
+		AvalancheGoVersion: func(host *models.Host) (string, error) {
+			resp, err := ssh.RunSSHCheckAvalancheGoVersion(host)
+			if err != nil {
+				return "", err
+			}
+			avalancheGoVersion, _, err := parseAvalancheGoOutput(resp)
+			return avalancheGoVersion, err
+		},
 	}
-	wg.Wait()
-
-	if wgResults.HasErrors() {
-		e := fmt.Errorf("failed to get avalanchego version for node(s) %s", wgResults.GetErrorHostMap())
-		ux.SpinFailWithError(spinner, "", e)
-		return e
+	if subnetName != "" {
+		checks.SubnetSyncStatus = func(host *models.Host) (string, error) {
+			resp, err := ssh.RunSSHSubnetSyncStatus(host, blockchainID.String())
+			if err != nil {
+				return "", err
+			}
+			return parseSubnetSyncOutput(resp)
+		}
 	}
+	spinSession := ux.NewUserSpinner()
+	spinner := spinSession.SpinToUser("Checking node(s) status...")
+	_, hostStatuses := node.GetHostsStatus(hosts, checks, utils.DefaultParallelism(len(hosts)))
 	ux.SpinComplete(spinner)
 	spinSession.Stop()
-	avagoVersions := map[string]string{}
-	for nodeID, avalanchegoVersion := range wgResults.GetResultMap() {
-		avagoVersions[nodeID] = fmt.Sprintf("%v", avalanchegoVersion)
-	}
 
+	errorNodes := map[string]error{}
+	notBootstrappedNodes := []string{}
+	unhealthyNodes := []string{}
+	avagoVersions := map[string]string{}
 	notSyncedNodes := []string{}
 	subnetSyncedNodes := []string{}
 	subnetValidatingNodes := []string{}
-	if subnetName != "" {
-		hostsToCheckSyncStatus := []string{}
-		for _, hostID := range hostIDs {
-			if slices.Contains(notBootstrappedNodes, hostID) {
-				notSyncedNodes = append(notSyncedNodes, hostID)
-			} else {
-				hostsToCheckSyncStatus = append(hostsToCheckSyncStatus, hostID)
-			}
+	for _, hostID := range hostIDs {
+		hostStatus, ok := hostStatuses[hostID]
+		if !ok {
+			// stopped nodes are not queried
+			continue
 		}
-		if len(hostsToCheckSyncStatus) != 0 {
-			ux.Logger.PrintToUser("Getting subnet sync status of node(s)")
-			hostsToCheck := utils.Filter(hosts, func(h *models.Host) bool { return slices.Contains(hostsToCheckSyncStatus, h.GetCloudID()) })
-			wg := sync.WaitGroup{}
-			wgResults := models.NodeResults{}
-			for _, host := range hostsToCheck {
-				wg.Add(1)
-				go func(nodeResults *models.NodeResults, host *models.Host) {
-					defer wg.Done()
-					if syncstatus, err := ssh.RunSSHSubnetSyncStatus(host, blockchainID.String()); err != nil {
-						nodeResults.AddResult(host.GetCloudID(), nil, err)
-						return
-					} else {
-						if subnetSyncStatus, err := parseSubnetSyncOutput(syncstatus); err != nil {
-							nodeResults.AddResult(host.GetCloudID(), nil, err)
-							return
-						} else {
-							nodeResults.AddResult(host.GetCloudID(), subnetSyncStatus, err)
-						}
-					}
-				}(&wgResults, host)
-			}
-			wg.Wait()
-			if wgResults.HasErrors() {
-				return fmt.Errorf("failed to check sync status for node(s) %s", wgResults.GetErrorHostMap())
-			}
-			for nodeID, subnetSyncStatus := range wgResults.GetResultMap() {
-				switch subnetSyncStatus {
-				case status.Syncing.String():
-					subnetSyncedNodes = append(subnetSyncedNodes, nodeID)
-				case status.Validating.String():
-					subnetValidatingNodes = append(subnetValidatingNodes, nodeID)
-				default:
-					notSyncedNodes = append(notSyncedNodes, nodeID)
-				}
+		if hostStatus.Err != nil {
+			errorNodes[hostID] = hostStatus.Err
+			continue
+		}
+		avagoVersions[hostID] = hostStatus.AvalancheGoVersion
+		if !hostStatus.Bootstrapped {
+			notBootstrappedNodes = append(notBootstrappedNodes, hostID)
+		}
+		if !hostStatus.Healthy {
+			unhealthyNodes = append(unhealthyNodes, hostID)
+		}
+		if subnetName != "" {
+			switch hostStatus.SubnetSyncStatus {
+			case status.Syncing.String():
+				subnetSyncedNodes = append(subnetSyncedNodes, hostID)
+			case status.Validating.String():
+				subnetValidatingNodes = append(subnetValidatingNodes, hostID)
+			default:
+				notSyncedNodes = append(notSyncedNodes, hostID)
 			}
 		}
 	}
@@ -207,6 +193,8 @@ func statusNode(_ *cobra.Command, args []string) error {
 		avagoVersions,
 		unhealthyNodes,
 		notBootstrappedNodes,
+		stoppedNodes,
+		errorNodes,
 		notSyncedNodes,
 		subnetSyncedNodes,
 		subnetValidatingNodes,
@@ -214,6 +202,13 @@ func statusNode(_ *cobra.Command, args []string) error {
 		subnetName,
 		nodeConfigs,
 	)
+	if len(errorNodes) > 0 {
+		errorHostIDs := utils.Filter(hostIDs, func(hostID string) bool { return errorNodes[hostID] != nil })
+		for _, hostID := range errorHostIDs {
+			ux.Logger.RedXToUser("Node %s: %s", hostID, describeNodeError(errorNodes[hostID]))
+		}
+		return fmt.Errorf("failed to get status of node(s) %s", strings.Join(errorHostIDs, ", "))
+	}
 	return nil
 }
 
@@ -224,6 +219,8 @@ func printOutput(
 	avagoVersions map[string]string,
 	unhealthyHosts []string,
 	notBootstrappedHosts []string,
+	stoppedHosts []string,
+	errorHosts map[string]error,
 	notSyncedHosts []string,
 	subnetSyncedHosts []string,
 	subnetValidatingHosts []string,
@@ -272,6 +269,14 @@ func printOutput(
 			if slices.Contains(unhealthyHosts, cloudID) {
 				healthyStatus = logging.Red.Wrap("UNHEALTHY")
 			}
+			if slices.Contains(stoppedHosts, cloudID) {
+				boostrappedStatus = logging.Yellow.Wrap("STOPPED")
+				healthyStatus = logging.Yellow.Wrap("STOPPED")
+			}
+			if _, ok := errorHosts[cloudID]; ok {
+				boostrappedStatus = logging.Red.Wrap("ERR")
+				healthyStatus = logging.Red.Wrap("ERR")
+			}
 			nodeIDStr = nodeIDs[i]
 			avagoVersion = avagoVersions[cloudID]
 		}
@@ -287,7 +292,12 @@ func printOutput(
 		}
 		if subnetName != "" {
 			syncedStatus := ""
-			if clusterConf.MonitoringInstance != cloudID {
+			switch {
+			case slices.Contains(stoppedHosts, cloudID):
+				syncedStatus = logging.Yellow.Wrap("STOPPED")
+			case errorHosts[cloudID] != nil:
+				syncedStatus = logging.Red.Wrap("ERR")
+			case clusterConf.MonitoringInstance != cloudID:
 				syncedStatus = logging.Red.Wrap("NOT_BOOTSTRAPPED")
 				if slices.Contains(subnetSyncedHosts, cloudID) {
 					syncedStatus = logging.Green.Wrap("SYNCED")
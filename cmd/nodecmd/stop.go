@@ -0,0 +1,237 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	awsAPI "github.com/ava-labs/avalanche-cli/pkg/cloud/aws"
+	gcpAPI "github.com/ava-labs/avalanche-cli/pkg/cloud/gcp"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var stopCloudInstances bool
+
+func newStopCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stop [clusterName]",
+		Short: "(ALPHA Warning) Stop all nodes in a cluster",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node stop command stops AvalancheGo on all nodes in a cluster, preserving
+node data (staking keys, configs and database).
+
+Use --cloud to also stop the cloud server instances to save cost. Storage disks
+are kept, but instances without a static IP may get a new public IP once started.
+
+Stopped nodes are reported as STOPPED by avalanche node status until AvalancheGo
+is started again with avalanche node restart.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: stopNodes,
+	}
+	cmd.Flags().BoolVar(&stopCloudInstances, "cloud", false, "also stop the cloud server instances")
+	cmd.Flags().BoolVar(&authorizeAccess, "authorize-access", false, "authorize CLI to stop cloud resources")
+	cmd.Flags().BoolVarP(&authorizeAll, "authorize-all", "y", false, "authorize all CLI requests")
+	cmd.Flags().StringVar(&awsProfile, "aws-profile", constants.AWSDefaultCredential, "aws profile to use")
+	return cmd
+}
+
+func stopNodes(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	if err := checkCluster(clusterName); err != nil {
+		return err
+	}
+	clusterConfig, err := app.GetClusterConfig(clusterName)
+	if err != nil {
+		return err
+	}
+	if clusterConfig.External && stopCloudInstances {
+		return fmt.Errorf("cloud instances of EXTERNAL cluster %s can't be stopped", clusterName)
+	}
+	if authorizeAll {
+		authorizeAccess = true
+	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	defer disconnectHosts(hosts)
+	if err := getStopConfirmation(clusterConfig, hosts); err != nil {
+		return err
+	}
+
+	spinSession := ux.NewUserSpinner()
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, "Stop AvalancheGo"))
+			if err := ssh.RunSSHStopNode(host); err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				ux.SpinFailWithError(spinner, "", err)
+				return
+			}
+			if err := markNodeStopped(host.GetCloudID(), false); err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				ux.SpinFailWithError(spinner, "", err)
+				return
+			}
+			ux.SpinComplete(spinner)
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	spinSession.Stop()
+	if stopCloudInstances {
+		// instances are only stopped once avalanchego was stopped cleanly on them
+		stoppedHosts := utils.Filter(hosts, func(h *models.Host) bool {
+			return !wgResults.HasNodeIDWithError(h.NodeID)
+		})
+		if err := stopCloudInstancesForHosts(clusterName, stoppedHosts, &wgResults); err != nil {
+			return err
+		}
+	}
+	if wgResults.HasErrors() {
+		for _, result := range wgResults.GetResults() {
+			if result.Err != nil {
+				ux.Logger.RedXToUser("Failed to stop node %s due to %s", result.NodeID, result.Err)
+			}
+		}
+		return fmt.Errorf("failed to stop node(s) %s", wgResults.GetErrorHostMap())
+	}
+	ux.Logger.GreenCheckmarkToUser("All nodes in cluster %s are successfully stopped!", clusterName)
+	return nil
+}
+
+// getStopConfirmation asks the user to confirm stopping Mainnet validators,
+// unless authorized by flags
+func getStopConfirmation(clusterConfig models.ClusterConfig, hosts []*models.Host) error {
+	if authorizeAll {
+		return nil
+	}
+	if clusterConfig.Network.Kind == models.Mainnet {
+		validators := []string{}
+		for _, host := range clusterConfig.GetValidatorHosts(hosts) {
+			nodeID, err := getNodeID(app.GetNodeInstanceDirPath(host.GetCloudID()))
+			if err != nil {
+				return err
+			}
+			isValidator, err := checkNodeIsPrimaryNetworkValidator(nodeID, clusterConfig.Network)
+			if err != nil {
+				return err
+			}
+			if isValidator {
+				validators = append(validators, nodeID.String())
+			}
+		}
+		if len(validators) > 0 {
+			ux.Logger.PrintToUser("Node(s) %s are Mainnet validators. Stopping them lowers their uptime and may forfeit staking rewards", validators)
+			yes, err := app.Prompt.CaptureNoYes("Do you want to proceed?")
+			if err != nil {
+				return err
+			}
+			if !yes {
+				return errors.New("abort avalanche node stop command")
+			}
+		}
+	}
+	return nil
+}
+
+// stopCloudInstancesForHosts stops the cloud server instances of the given hosts,
+// adding any failure to [nodeResults]
+func stopCloudInstancesForHosts(clusterName string, hosts []*models.Host, nodeResults *models.NodeResults) error {
+	var gcpCloud *gcpAPI.GcpCloud
+	ec2SvcMap := make(map[string]*awsAPI.AwsCloud)
+	for _, host := range hosts {
+		cloudID := host.GetCloudID()
+		nodeConfig, err := app.LoadClusterNodeConfig(cloudID)
+		if err != nil {
+			nodeResults.AddResult(host.NodeID, nil, err)
+			continue
+		}
+		ux.Logger.PrintToUser("Stopping node instance %s in cluster %s...", cloudID, clusterName)
+		if nodeConfig.CloudService == "" || nodeConfig.CloudService == constants.AWSCloudService {
+			if !(authorizeAccess || authorizedAccessFromSettings()) && (requestCloudAuth(constants.AWSCloudService) != nil) {
+				return fmt.Errorf("cloud access is required")
+			}
+			if _, ok := ec2SvcMap[nodeConfig.Region]; !ok {
+				ec2Svc, err := awsAPI.NewAwsCloud(awsProfile, nodeConfig.Region)
+				if err != nil {
+					return err
+				}
+				ec2SvcMap[nodeConfig.Region] = ec2Svc
+			}
+			if err := ec2SvcMap[nodeConfig.Region].StopInstance(cloudID); err != nil {
+				if isExpiredCredentialError(err) {
+					ux.Logger.PrintToUser("")
+					printExpiredCredentialsOutput(awsProfile)
+					return err
+				}
+				nodeResults.AddResult(host.NodeID, nil, err)
+				continue
+			}
+		} else {
+			if !(authorizeAccess || authorizedAccessFromSettings()) && (requestCloudAuth(constants.GCPCloudService) != nil) {
+				return fmt.Errorf("cloud access is required")
+			}
+			if gcpCloud == nil {
+				gcpClient, projectName, _, err := getGCPCloudCredentials()
+				if err != nil {
+					return err
+				}
+				gcpCloud, err = gcpAPI.NewGcpCloud(gcpClient, projectName, context.Background())
+				if err != nil {
+					return err
+				}
+			}
+			if err := gcpCloud.StopInstance(cloudID, nodeConfig.Region); err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				continue
+			}
+		}
+		if err := markNodeStopped(cloudID, true); err != nil {
+			nodeResults.AddResult(host.NodeID, nil, err)
+			continue
+		}
+		ux.Logger.GreenCheckmarkToUser("Node instance %s in cluster %s successfully stopped!", cloudID, clusterName)
+	}
+	return nil
+}
+
+// markNodeStopped records in the node config that avalanchego, and optionally
+// the cloud instance, are stopped
+func markNodeStopped(cloudID string, instanceStopped bool) error {
+	nodeConfig, err := app.LoadClusterNodeConfig(cloudID)
+	if err != nil {
+		return err
+	}
+	nodeConfig.AvalancheGoStopped = true
+	nodeConfig.InstanceStopped = nodeConfig.InstanceStopped || instanceStopped
+	return app.CreateNodeCloudConfigFile(cloudID, &nodeConfig)
+}
+
+// markNodeStarted clears the stopped state recorded by node stop in the node config,
+// once avalanchego is running again
+func markNodeStarted(cloudID string) error {
+	nodeConfig, err := app.LoadClusterNodeConfig(cloudID)
+	if err != nil {
+		return err
+	}
+	if !nodeConfig.AvalancheGoStopped && !nodeConfig.InstanceStopped {
+		return nil
+	}
+	nodeConfig.AvalancheGoStopped = false
+	nodeConfig.InstanceStopped = false
+	return app.CreateNodeCloudConfigFile(cloudID, &nodeConfig)
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkNodeStoppedAndStarted(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	defer func() {
+		app = nil
+	}()
+	require.NoError(app.CreateNodeCloudConfigFile("i-a", &models.NodeConfig{NodeID: "i-a", Region: "us-east-1"}))
+
+	require.NoError(markNodeStopped("i-a", false))
+	nodeConfig, err := app.LoadClusterNodeConfig("i-a")
+	require.NoError(err)
+	require.True(nodeConfig.AvalancheGoStopped)
+	require.False(nodeConfig.InstanceStopped)
+
+	// the instance stop is kept when avalanchego is stopped again
+	require.NoError(markNodeStopped("i-a", true))
+	require.NoError(markNodeStopped("i-a", false))
+	nodeConfig, err = app.LoadClusterNodeConfig("i-a")
+	require.NoError(err)
+	require.True(nodeConfig.AvalancheGoStopped)
+	require.True(nodeConfig.InstanceStopped)
+
+	require.NoError(markNodeStarted("i-a"))
+	nodeConfig, err = app.LoadClusterNodeConfig("i-a")
+	require.NoError(err)
+	require.False(nodeConfig.AvalancheGoStopped)
+	require.False(nodeConfig.InstanceStopped)
+	require.Equal("us-east-1", nodeConfig.Region)
+}
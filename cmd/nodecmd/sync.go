@@ -7,36 +7,52 @@ import (
 	"sync"
 
 	"github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
-	"github.com/ava-labs/avalanche-cli/pkg/ansible"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
 	"github.com/ava-labs/avalanche-cli/pkg/ssh"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	buildInContainer   bool
+	customVMBuildImage string
 )
 
 func newSyncCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "sync [clusterName] [subnetName]",
-		Short: "(ALPHA Warning) Sync nodes in a cluster with a subnet",
+		Use:     "sync [clusterName] [subnetName]",
+		Aliases: []string{"sync-subnet"},
+		Short:   "(ALPHA Warning) Sync nodes in a cluster with a subnet",
 		Long: `(ALPHA Warning) This command is currently in experimental mode.
 
 The node sync command enables all nodes in a cluster to be bootstrapped to a Subnet. 
+If no subnetName is given, the user is prompted to select any number of the Subnets
+deployed on the cluster network to sync with.
+Nodes get the Subnet VM, genesis, and subnet, chain and upgrade configs, start tracking the
+Subnet and are restarted. Nodes already tracking the Subnet are skipped.
 You can check the subnet bootstrap status by calling avalanche node status <clusterName> --subnet <subnetName>`,
-		Args: cobrautils.ExactArgs(2),
+		Args: cobrautils.RangeArgs(1, 2),
 		RunE: syncSubnet,
 	}
 
 	cmd.Flags().StringSliceVar(&validators, "validators", []string{}, "sync subnet into given comma separated list of validators. defaults to all cluster nodes")
 	cmd.Flags().BoolVar(&avoidChecks, "no-checks", false, "do not check for bootstrapped/healthy status or rpc compatibility of nodes against subnet")
+	cmd.Flags().BoolVar(&buildInContainer, "build-in-container", false, "build custom VMs inside a docker container for reproducible builds")
+	cmd.Flags().StringVar(&customVMBuildImage, "build-image", constants.CustomVMBuildImage, "docker image used to build custom VMs with --build-in-container")
 
+	addHostFilterFlags(cmd)
+	addChainAliasFlag(cmd)
 	return cmd
 }
 
 func syncSubnet(_ *cobra.Command, args []string) error {
 	clusterName := args[0]
-	subnetName := args[1]
 	if err := checkCluster(clusterName); err != nil {
 		return err
 	}
@@ -44,13 +60,59 @@ func syncSubnet(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	subnetNames := args[1:]
+	if len(subnetNames) == 0 {
+		subnetNames, err = promptSubnetsToSync(clusterConfig)
+		if err != nil {
+			return err
+		}
+		if len(subnetNames) == 0 {
+			ux.Logger.PrintToUser("No Subnet selected to sync with")
+			return nil
+		}
+	}
+	if chainAlias != "" && len(subnetNames) > 1 {
+		return fmt.Errorf("--alias can only be used when syncing a single subnet")
+	}
+	for _, subnetName := range subnetNames {
+		if err := syncSubnetOnCluster(clusterName, clusterConfig, subnetName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// promptSubnetsToSync asks the user which of the subnets deployed on the cluster network,
+// and not yet tracked by the cluster, should be synced
+func promptSubnetsToSync(clusterConfig models.ClusterConfig) ([]string, error) {
+	subnetNames, err := app.GetSubnetNamesOnNetwork(clusterConfig.Network)
+	if err != nil {
+		return nil, err
+	}
+	subnetNames = utils.Filter(subnetNames, func(subnetName string) bool {
+		return !slices.Contains(clusterConfig.Subnets, subnetName)
+	})
+	if len(subnetNames) == 0 {
+		return nil, fmt.Errorf("no untracked subnets deployed on %s found", clusterConfig.Network.Name())
+	}
+	return app.Prompt.CaptureListMultiple("Which Subnets do you want the cluster to sync with?", subnetNames)
+}
+
+func syncSubnetOnCluster(clusterName string, clusterConfig models.ClusterConfig, subnetName string) error {
 	if _, err := subnetcmd.ValidateSubnetNameAndGetChains([]string{subnetName}); err != nil {
 		return err
 	}
-	hosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
+	alias, err := getChainAlias(subnetName)
+	if err != nil {
+		return err
+	}
+	hosts, err := getClusterHosts(clusterName)
 	if err != nil {
 		return err
 	}
+	if hosts, err = filterClusterHosts(hosts); err != nil {
+		return err
+	}
 	if len(validators) != 0 {
 		hosts, err = filterHosts(hosts, validators)
 		if err != nil {
@@ -58,6 +120,27 @@ func syncSubnet(_ *cobra.Command, args []string) error {
 		}
 	}
 	defer disconnectHosts(hosts)
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	subnetID := sc.Networks[clusterConfig.Network.Name()].SubnetID
+	if subnetID == ids.Empty {
+		return fmt.Errorf("subnet %s is not deployed on %s", subnetName, clusterConfig.Network.Name())
+	}
+	hosts, trackingHosts, failedHosts := node.SplitHostsBySubnetTracking(hosts, func(host *models.Host) (bool, error) {
+		return ssh.RunSSHIsTrackingSubnet(host, subnetID.String())
+	})
+	if len(failedHosts) > 0 {
+		return fmt.Errorf("failed to check subnet tracking of node(s) %s", failedHosts)
+	}
+	for _, host := range trackingHosts {
+		ux.Logger.PrintToUser("Node %s already tracks Subnet %s, skipping it", host.GetCloudID(), subnetName)
+	}
+	if len(hosts) == 0 {
+		ux.Logger.GreenCheckmarkToUser("All node(s) already track Subnet %s", subnetName)
+		return addSubnetToClusterConfig(clusterName, subnetName)
+	}
 	if !avoidChecks {
 		if err := checkHostsAreBootstrapped(hosts); err != nil {
 			return err
@@ -69,23 +152,35 @@ func syncSubnet(_ *cobra.Command, args []string) error {
 			return err
 		}
 	}
-	if err := prepareSubnetPlugin(hosts, subnetName); err != nil {
+	buildImage := ""
+	if buildInContainer {
+		buildImage = customVMBuildImage
+	}
+	if err := prepareSubnetPlugin(hosts, subnetName, buildImage); err != nil {
 		return err
 	}
-	untrackedNodes, err := trackSubnet(hosts, clusterName, clusterConfig.Network, subnetName)
+	untrackedNodes, err := trackSubnet(hosts, clusterName, clusterConfig.Network, subnetName, alias)
 	if err != nil {
 		return err
 	}
 	if len(untrackedNodes) > 0 {
 		return fmt.Errorf("node(s) %s failed to sync with subnet %s", untrackedNodes, subnetName)
 	}
+	if err := addSubnetToClusterConfig(clusterName, subnetName); err != nil {
+		return err
+	}
+	// scrape the subnet VM metrics too
+	if err := updatePrometheusConfig(clusterName); err != nil {
+		ux.Logger.RedXToUser("Unable to update the monitoring config with Subnet %s metrics: %s", subnetName, err)
+	}
 	ux.Logger.PrintToUser("Node(s) successfully started syncing with Subnet!")
 	ux.Logger.PrintToUser(fmt.Sprintf("Check node subnet syncing status with avalanche node status %s --subnet %s", clusterName, subnetName))
 	return nil
 }
 
-// prepareSubnetPlugin creates subnet plugin to all nodes in the cluster
-func prepareSubnetPlugin(hosts []*models.Host, subnetName string) error {
+// prepareSubnetPlugin creates subnet plugin to all nodes in the cluster.
+// Custom VMs are built inside a container of [customVMBuildImage], if given
+func prepareSubnetPlugin(hosts []*models.Host, subnetName string, customVMBuildImage string) error {
 	sc, err := app.LoadSidecar(subnetName)
 	if err != nil {
 		return err
@@ -96,7 +191,7 @@ func prepareSubnetPlugin(hosts []*models.Host, subnetName string) error {
 		wg.Add(1)
 		go func(nodeResults *models.NodeResults, host *models.Host) {
 			defer wg.Done()
-			if err := ssh.RunSSHCreatePlugin(host, sc); err != nil {
+			if err := ssh.RunSSHCreatePlugin(host, sc, customVMBuildImage); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
 			}
 		}(&wgResults, host)
@@ -115,6 +210,7 @@ func trackSubnet(
 	clusterName string,
 	network models.Network,
 	subnetName string,
+	alias string,
 ) ([]string, error) {
 	// load cluster config
 	clusterConf, err := app.GetClusterConfig(clusterName)
@@ -132,22 +228,44 @@ func trackSubnet(
 			defer wg.Done()
 			if err := ssh.RunSSHStopNode(host); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
+				return
 			}
 			if err := ssh.RunSSHRenderAvalancheNodeConfig(app, host, network, allSubnets); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
+				return
 			}
-			if err := ssh.RunSSHSyncSubnetData(app, host, network, subnetName); err != nil {
+			if err := ssh.RunSSHSyncSubnetData(app, host, network, subnetName, alias); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
+				return
 			}
 			if err := ssh.RunSSHStartNode(host); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
 				return
 			}
+			nodeResults.AddResult(host.NodeID, nil, nil)
 		}(&wgResults, host)
 	}
 	wg.Wait()
-	if wgResults.HasErrors() {
-		return nil, fmt.Errorf("failed to track subnet for node(s) %s", wgResults.GetErrorHostMap())
+	for _, host := range hosts {
+		if err := wgResults.GetErrorHostMap()[host.NodeID]; err != nil {
+			ux.Logger.RedXToUser("Node %s failed to track Subnet %s: %s", host.GetCloudID(), subnetName, err)
+		} else {
+			ux.Logger.GreenCheckmarkToUser("Node %s is tracking Subnet %s", host.GetCloudID(), subnetName)
+		}
 	}
 	return wgResults.GetErrorHosts(), nil
 }
+
+// addSubnetToClusterConfig records that the nodes of [clusterName] track [subnetName], so that
+// later node config renders keep tracking it
+func addSubnetToClusterConfig(clusterName string, subnetName string) error {
+	clusterConfig, err := app.GetClusterConfig(clusterName)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(clusterConfig.Subnets, subnetName) {
+		return nil
+	}
+	clusterConfig.Subnets = append(clusterConfig.Subnets, subnetName)
+	return app.SetClusterConfig(clusterName, clusterConfig)
+}
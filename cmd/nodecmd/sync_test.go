@@ -0,0 +1,66 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptSubnetsToSync(t *testing.T) {
+	require := require.New(t)
+	mockPrompt := &mocks.Prompter{}
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, mockPrompt, nil)
+	defer func() {
+		app = nil
+	}()
+	network := models.NewFujiNetwork()
+	deployed := models.NetworkData{SubnetID: ids.GenerateTestID(), BlockchainID: ids.GenerateTestID()}
+	for _, sc := range []models.Sidecar{
+		{Name: "subnetA", Subnet: "subnetA", Networks: map[string]models.NetworkData{network.Name(): deployed}},
+		{Name: "subnetB", Subnet: "subnetB", Networks: map[string]models.NetworkData{network.Name(): deployed}},
+		{Name: "subnetC", Subnet: "subnetC", Networks: map[string]models.NetworkData{network.Name(): deployed}},
+		{Name: "subnetD", Subnet: "subnetD", Networks: map[string]models.NetworkData{network.Name(): deployed}},
+		// not deployed on the cluster network
+		{Name: "subnetE", Subnet: "subnetE"},
+	} {
+		sc := sc
+		require.NoError(app.CreateSidecar(&sc))
+	}
+	clusterConfig := models.ClusterConfig{Network: network, Subnets: []string{"subnetB"}}
+	prompt := "Which Subnets do you want the cluster to sync with?"
+	// only the deployed subnets not yet tracked by the cluster are offered
+	options := []string{"subnetA", "subnetC", "subnetD"}
+
+	// subset, preserving input order
+	mockPrompt.On("CaptureListMultiple", prompt, options).Return([]string{"subnetA", "subnetD"}, nil).Once()
+	selected, err := promptSubnetsToSync(clusterConfig)
+	require.NoError(err)
+	require.Equal([]string{"subnetA", "subnetD"}, selected)
+
+	// none selected
+	mockPrompt.On("CaptureListMultiple", prompt, options).Return([]string{}, nil).Once()
+	selected, err = promptSubnetsToSync(clusterConfig)
+	require.NoError(err)
+	require.Empty(selected)
+
+	// prompt error
+	mockPrompt.On("CaptureListMultiple", prompt, options).Return(nil, errors.New("fake error")).Once()
+	_, err = promptSubnetsToSync(clusterConfig)
+	require.ErrorContains(err, "fake error")
+
+	// every deployed subnet is already tracked
+	clusterConfig.Subnets = []string{"subnetA", "subnetB", "subnetC", "subnetD"}
+	_, err = promptSubnetsToSync(clusterConfig)
+	require.ErrorContains(err, "no untracked subnets deployed on Fuji found")
+
+	mockPrompt.AssertExpectations(t)
+}
@@ -7,7 +7,6 @@ import (
 	"sync"
 
 	"github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
-	"github.com/ava-labs/avalanche-cli/pkg/ansible"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/ssh"
@@ -28,6 +27,8 @@ You can check the updated subnet bootstrap status by calling avalanche node stat
 		RunE: updateSubnet,
 	}
 
+	addHostFilterFlags(cmd)
+	addChainAliasFlag(cmd)
 	return cmd
 }
 
@@ -44,10 +45,17 @@ func updateSubnet(_ *cobra.Command, args []string) error {
 	if _, err := subnetcmd.ValidateSubnetNameAndGetChains([]string{subnetName}); err != nil {
 		return err
 	}
-	hosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
+	alias, err := getChainAlias(subnetName)
 	if err != nil {
 		return err
 	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	if hosts, err = filterClusterHosts(hosts); err != nil {
+		return err
+	}
 	defer disconnectHosts(hosts)
 	if err := checkHostsAreBootstrapped(hosts); err != nil {
 		return err
@@ -58,7 +66,7 @@ func updateSubnet(_ *cobra.Command, args []string) error {
 	if err := checkHostsAreRPCCompatible(hosts, subnetName); err != nil {
 		return err
 	}
-	nonUpdatedNodes, err := doUpdateSubnet(hosts, clusterName, clusterConfig.Network, subnetName)
+	nonUpdatedNodes, err := doUpdateSubnet(hosts, clusterName, clusterConfig.Network, subnetName, alias)
 	if err != nil {
 		return err
 	}
@@ -77,6 +85,7 @@ func doUpdateSubnet(
 	clusterName string,
 	network models.Network,
 	subnetName string,
+	alias string,
 ) ([]string, error) {
 	// load cluster config
 	clusterConf, err := app.GetClusterConfig(clusterName)
@@ -98,7 +107,7 @@ func doUpdateSubnet(
 			if err := ssh.RunSSHRenderAvalancheNodeConfig(app, host, network, allSubnets); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
 			}
-			if err := ssh.RunSSHSyncSubnetData(app, host, network, subnetName); err != nil {
+			if err := ssh.RunSSHSyncSubnetData(app, host, network, subnetName, alias); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
 			}
 			if err := ssh.RunSSHStartNode(host); err != nil {
@@ -8,11 +8,12 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/ava-labs/avalanche-cli/pkg/ansible"
 	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/docker"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
 	"github.com/ava-labs/avalanche-cli/pkg/ssh"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
@@ -20,12 +21,21 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
+	"golang.org/x/mod/semver"
+)
+
+var (
+	upgradeAvalancheGoVersion string
+	upgradeMaxUnavailable     int
+	upgradeSnapshot           bool
+	upgradeSnapshotDownload   bool
 )
 
 type nodeUpgradeInfo struct {
-	AvalancheGoVersion    string   // avalanche go version to update to on cloud server
-	SubnetEVMVersion      string   // subnet EVM version to update to on cloud server
-	SubnetEVMIDsToUpgrade []string // list of ID of Subnet EVM to be upgraded to subnet EVM version to update to
+	CurrentAvalancheGoVersion string   // avalanche go version currently running on cloud server
+	AvalancheGoVersion        string   // avalanche go version to update to on cloud server
+	SubnetEVMVersion          string   // subnet EVM version to update to on cloud server
+	SubnetEVMIDsToUpgrade     []string // list of ID of Subnet EVM to be upgraded to subnet EVM version to update to
 }
 
 func newUpgradeCmd() *cobra.Command {
@@ -37,16 +47,46 @@ func newUpgradeCmd() *cobra.Command {
 The node update command suite provides a collection of commands for nodes to update
 their avalanchego or VM version.
 
+By default, avalanchego and Subnet-EVM are upgraded to their latest compatible
+versions. Use --avalanchego-version to upgrade only avalanchego to a given version.
+Nodes are upgraded in batches of at most --max-unavailable nodes, and the next batch is
+upgraded only after every node of the previous one is bootstrapped and healthy again, so
+that the whole cluster is not taken down at once. If a node fails, the remaining nodes are
+not upgraded.
+
+The new AvalancheGo docker image is verified against the checksum published for its
+version, unless --skip-checksum is given.
+
+Use --snapshot to archive the avalanchego DB of each node, after stopping it, before it is
+upgraded, so that it can be rolled back with avalanche node restore-snapshot. Snapshots are
+kept on the node, unless --snapshot-download is given.
+
 You can check the status after upgrade by calling avalanche node status`,
 		Args: cobrautils.ExactArgs(1),
 		RunE: upgrade,
 	}
-
+	cmd.Flags().StringVar(&upgradeAvalancheGoVersion, "avalanchego-version", "", "upgrade avalanchego to given version (Subnet-EVM is not upgraded)")
+	cmd.Flags().IntVar(&upgradeMaxUnavailable, "max-unavailable", 1, "maximum number of nodes to upgrade at the same time")
+	cmd.Flags().DurationVar(&waitHealthyTimeout, "wait-healthy-timeout", constants.NodeWaitHealthyTimeout, "maximum time to wait for each upgraded node to become healthy")
+	cmd.Flags().DurationVar(&waitHealthyPoll, "wait-healthy-interval", constants.NodeWaitHealthyPollInterval, "interval between node health checks")
+	cmd.Flags().BoolVar(&skipChecksum, "skip-checksum", false, "do not verify the AvalancheGo docker image against its published checksum, e.g. when using a registry mirror")
+	cmd.Flags().BoolVar(&upgradeSnapshot, "snapshot", false, "snapshot the avalanchego DB of each node before upgrading it")
+	cmd.Flags().BoolVar(&upgradeSnapshotDownload, "snapshot-download", false, "download the DB snapshots locally, removing them from the nodes (implies --snapshot)")
+	addHostFilterFlags(cmd)
 	return cmd
 }
 
 func upgrade(_ *cobra.Command, args []string) error {
 	clusterName := args[0]
+	if upgradeMaxUnavailable < 1 {
+		return fmt.Errorf("max unavailable must be at least 1")
+	}
+	if err := validateWaitHealthyFlags(); err != nil {
+		return err
+	}
+	if upgradeAvalancheGoVersion != "" && !semver.IsValid(upgradeAvalancheGoVersion) {
+		return fmt.Errorf("invalid avalanchego version %s, expected format is vX.Y.Z", upgradeAvalancheGoVersion)
+	}
 	if err := checkCluster(clusterName); err != nil {
 		return err
 	}
@@ -55,54 +95,175 @@ func upgrade(_ *cobra.Command, args []string) error {
 		return err
 	}
 	network := clusterConfig.Network
-	hosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
+	hosts, err := getClusterHosts(clusterName)
 	if err != nil {
 		return err
 	}
+	if hosts, err = filterClusterHosts(hosts); err != nil {
+		return err
+	}
 	defer disconnectHosts(hosts)
-	toUpgradeNodesMap, err := getNodesUpgradeInfo(hosts)
+	var toUpgradeNodesMap map[*models.Host]nodeUpgradeInfo
+	if upgradeAvalancheGoVersion != "" {
+		toUpgradeNodesMap, err = getNodesAvalancheGoUpgradeInfo(hosts, upgradeAvalancheGoVersion)
+	} else {
+		toUpgradeNodesMap, err = getNodesUpgradeInfo(hosts)
+	}
 	if err != nil {
 		return err
 	}
-	spinSession := ux.NewUserSpinner()
-	for host, upgradeInfo := range toUpgradeNodesMap {
-		if upgradeInfo.AvalancheGoVersion != "" {
-			spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, fmt.Sprintf("Upgrading avalanchego to version %s...", upgradeInfo.AvalancheGoVersion)))
-			if err := upgradeAvalancheGo(host, network, upgradeInfo.AvalancheGoVersion); err != nil {
-				ux.SpinFailWithError(spinner, "", err)
-				return err
+	hostsToUpgrade := utils.Filter(hosts, func(h *models.Host) bool {
+		upgradeInfo, ok := toUpgradeNodesMap[h]
+		return ok && (upgradeInfo.AvalancheGoVersion != "" || upgradeInfo.SubnetEVMVersion != "")
+	})
+	if len(hostsToUpgrade) == 0 {
+		ux.Logger.PrintToUser("All nodes are already up to date")
+		return nil
+	}
+	// checksums are obtained before taking any node down, so it fails fast
+	avalancheGoImageDigests := map[string]string{}
+	if !skipChecksum && !utils.IsE2E() {
+		for _, host := range hostsToUpgrade {
+			version := toUpgradeNodesMap[host].AvalancheGoVersion
+			if _, ok := avalancheGoImageDigests[version]; ok || version == "" {
+				continue
+			}
+			avalancheGoImageDigests[version], err = docker.GetPublishedImageDigest(app.Downloader, constants.AvalancheGoDockerImage, version)
+			if err != nil {
+				return fmt.Errorf("%w. Use --skip-checksum to skip AvalancheGo image verification", err)
 			}
-			ux.SpinComplete(spinner)
 		}
-		if upgradeInfo.SubnetEVMVersion != "" {
-			subnetEVMVersionToUpgradeToWoPrefix := strings.TrimPrefix(upgradeInfo.SubnetEVMVersion, "v")
-			subnetEVMArchive := fmt.Sprintf(constants.SubnetEVMArchive, subnetEVMVersionToUpgradeToWoPrefix)
-			subnetEVMReleaseURL := fmt.Sprintf(constants.SubnetEVMReleaseURL, upgradeInfo.SubnetEVMVersion, subnetEVMArchive)
-			spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, fmt.Sprintf("Upgrading SubnetEVM to version %s...", upgradeInfo.SubnetEVMVersion)))
-			if err := getNewSubnetEVMRelease(host, subnetEVMReleaseURL, subnetEVMArchive); err != nil {
+	}
+	spinSession := ux.NewUserSpinner()
+	upgradeFunc := func(host *models.Host) error {
+		upgradeInfo := toUpgradeNodesMap[host]
+		return upgradeNode(spinSession, host, network, upgradeInfo, avalancheGoImageDigests[upgradeInfo.AvalancheGoVersion])
+	}
+	if upgradeSnapshot || upgradeSnapshotDownload {
+		upgradeOnlyFunc := upgradeFunc
+		upgradeFunc = func(host *models.Host) error {
+			return node.SnapshotThenUpgrade(
+				host,
+				ssh.RunSSHStopNode,
+				func(host *models.Host) error { return snapshotNodeDB(host, upgradeSnapshotDownload) },
+				ssh.RunSSHStartNode,
+				upgradeOnlyFunc,
+			)
+		}
+	}
+	// rolling upgrade: only upgradeMaxUnavailable nodes are down at the same time, and no more
+	// nodes are taken down once one fails
+	results := node.RollingRestart(
+		hostsToUpgrade,
+		upgradeMaxUnavailable,
+		upgradeFunc,
+		func(host *models.Host) error {
+			return waitForHostHealthy(host, waitHealthyTimeout, waitHealthyPoll)
+		},
+		false,
+		nil,
+	)
+	spinSession.Stop()
+	if results.HasErrors() {
+		return fmt.Errorf("failed to upgrade node(s) %s", results.GetErrorHostMap())
+	}
+	printUpgradeResults(hostsToUpgrade, toUpgradeNodesMap)
+	return nil
+}
+
+// upgradeNode upgrades avalanchego and/or Subnet-EVM on [host] as described by [upgradeInfo].
+// If [avalancheGoImageDigest] is not empty, the new AvalancheGo image is verified against it
+func upgradeNode(spinSession *ux.UserSpinner, host *models.Host, network models.Network, upgradeInfo nodeUpgradeInfo, avalancheGoImageDigest string) error {
+	if upgradeInfo.AvalancheGoVersion != "" {
+		spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, fmt.Sprintf("Upgrading avalanchego to version %s...", upgradeInfo.AvalancheGoVersion)))
+		if err := upgradeAvalancheGo(host, network, upgradeInfo.AvalancheGoVersion, avalancheGoImageDigest); err != nil {
+			ux.SpinFailWithError(spinner, "", err)
+			return err
+		}
+		ux.SpinComplete(spinner)
+	}
+	if upgradeInfo.SubnetEVMVersion != "" {
+		subnetEVMVersionToUpgradeToWoPrefix := strings.TrimPrefix(upgradeInfo.SubnetEVMVersion, "v")
+		subnetEVMArchive := fmt.Sprintf(constants.SubnetEVMArchive, subnetEVMVersionToUpgradeToWoPrefix)
+		subnetEVMReleaseURL := binutils.GetGithubReleaseAssetURL(constants.AvaLabsOrg, constants.SubnetEVMRepoName, upgradeInfo.SubnetEVMVersion, subnetEVMArchive)
+		spinner := spinSession.SpinToUser(utils.ScriptLog(host.NodeID, fmt.Sprintf("Upgrading SubnetEVM to version %s...", upgradeInfo.SubnetEVMVersion)))
+		if err := getNewSubnetEVMRelease(host, subnetEVMReleaseURL, subnetEVMArchive); err != nil {
+			ux.SpinFailWithError(spinner, "", err)
+			return err
+		}
+		if err := ssh.RunSSHStopNode(host); err != nil {
+			ux.SpinFailWithError(spinner, "", err)
+			return err
+		}
+		for _, vmID := range upgradeInfo.SubnetEVMIDsToUpgrade {
+			subnetEVMBinaryPath := fmt.Sprintf(constants.CloudNodeSubnetEvmBinaryPath, vmID)
+			if err := upgradeSubnetEVM(host, subnetEVMBinaryPath); err != nil {
 				ux.SpinFailWithError(spinner, "", err)
 				return err
 			}
-			if err := ssh.RunSSHStopNode(host); err != nil {
-				ux.SpinFailWithError(spinner, "", err)
-				return err
+		}
+		if err := ssh.RunSSHStartNode(host); err != nil {
+			ux.SpinFailWithError(spinner, "", err)
+			return err
+		}
+		ux.SpinComplete(spinner)
+	}
+	return nil
+}
+
+// printUpgradeResults prints avalanchego version before and after the upgrade for each upgraded host
+func printUpgradeResults(hosts []*models.Host, upgradeInfoMap map[*models.Host]nodeUpgradeInfo) {
+	for _, host := range hosts {
+		afterVersion := "unknown"
+		if resp, err := ssh.RunSSHCheckAvalancheGoVersion(host); err == nil {
+			if version, _, err := parseAvalancheGoOutput(resp); err == nil {
+				afterVersion = version
 			}
-			for _, vmID := range upgradeInfo.SubnetEVMIDsToUpgrade {
-				subnetEVMBinaryPath := fmt.Sprintf(constants.CloudNodeSubnetEvmBinaryPath, vmID)
-				if err := upgradeSubnetEVM(host, subnetEVMBinaryPath); err != nil {
-					ux.SpinFailWithError(spinner, "", err)
-					return err
+		}
+		ux.Logger.GreenCheckmarkToUser("Node %s avalanchego version: %s -> %s", host.NodeID, upgradeInfoMap[host].CurrentAvalancheGoVersion, afterVersion)
+	}
+}
+
+// getNodesAvalancheGoUpgradeInfo gets the avalanchego version of all given nodes, and returns
+// upgrade info to [avalancheGoVersion] for the nodes that are not already running it
+func getNodesAvalancheGoUpgradeInfo(hosts []*models.Host, avalancheGoVersion string) (map[*models.Host]nodeUpgradeInfo, error) {
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			if resp, err := ssh.RunSSHCheckAvalancheGoVersion(host); err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				return
+			} else {
+				if currentVersion, _, err := parseAvalancheGoOutput(resp); err != nil {
+					nodeResults.AddResult(host.NodeID, nil, err)
+				} else {
+					nodeResults.AddResult(host.NodeID, currentVersion, err)
 				}
 			}
-			if err := ssh.RunSSHStartNode(host); err != nil {
-				ux.SpinFailWithError(spinner, "", err)
-				return err
-			}
-			ux.SpinComplete(spinner)
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	if wgResults.HasErrors() {
+		return nil, fmt.Errorf("failed to get avalanchego version for node(s) %s", wgResults.GetErrorHostMap())
+	}
+	currentVersions := wgResults.GetResultMap()
+	nodesToUpgrade := make(map[*models.Host]nodeUpgradeInfo)
+	for _, host := range hosts {
+		currentVersion := currentVersions[host.NodeID].(string)
+		if currentVersion == avalancheGoVersion {
+			ux.Logger.PrintToUser("Node %s is already running avalanchego version %s. Skipping...", host.NodeID, avalancheGoVersion)
+			continue
+		}
+		ux.Logger.PrintToUser("Upgrading Avalanche Go version for node %s from version %s to version %s", host.NodeID, currentVersion, avalancheGoVersion)
+		nodesToUpgrade[host] = nodeUpgradeInfo{
+			CurrentAvalancheGoVersion: currentVersion,
+			AvalancheGoVersion:        avalancheGoVersion,
 		}
 	}
-	spinSession.Stop()
-	return nil
+	return nodesToUpgrade, nil
 }
 
 // getNodesUpgradeInfo gets the node versions of all given nodes and checks which
@@ -167,6 +328,7 @@ func getNodesUpgradeInfo(hosts []*models.Host) (map[*models.Host]nodeUpgradeInfo
 		currentAvalancheGoVersion := vmVersions[constants.PlatformKeyName]
 		avalancheGoVersionToUpdateTo := latestAvagoVersion
 		nodeUpgradeInfo := nodeUpgradeInfo{}
+		nodeUpgradeInfo.CurrentAvalancheGoVersion, _ = currentAvalancheGoVersion.(string)
 		nodeUpgradeInfo.SubnetEVMIDsToUpgrade = []string{}
 		for vmName, vmVersion := range vmVersions {
 			// when calling info.getNodeVersion, this is what we get
@@ -216,8 +378,9 @@ func upgradeAvalancheGo(
 	host *models.Host,
 	network models.Network,
 	avaGoVersionToUpdateTo string,
+	avalancheGoImageDigest string,
 ) error {
-	if err := ssh.RunSSHUpgradeAvalanchego(host, network, avaGoVersionToUpdateTo); err != nil {
+	if err := ssh.RunSSHUpgradeAvalanchego(host, network, avaGoVersionToUpdateTo, avalancheGoImageDigest); err != nil {
 		return err
 	}
 	return nil
@@ -227,7 +390,7 @@ func upgradeSubnetEVM(
 	host *models.Host,
 	subnetEVMBinaryPath string,
 ) error {
-	if _, err := host.Command(fmt.Sprintf("cp -f subnet-evm %s", subnetEVMBinaryPath), nil, constants.SSHFileOpsTimeout); err != nil {
+	if _, err := host.Command(fmt.Sprintf("cp -f subnet-evm %s", host.ExpandHome(subnetEVMBinaryPath)), nil, constants.SSHFileOpsTimeout); err != nil {
 		return err
 	}
 	return nil
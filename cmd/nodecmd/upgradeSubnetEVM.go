@@ -0,0 +1,235 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+)
+
+var upgradeSubnetEVMVersion string
+
+func newUpgradeSubnetEVMCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade-subnet-evm [clusterName] [subnetName]",
+		Short: "(ALPHA Warning) Upgrade the Subnet-EVM of a Subnet on all nodes in a cluster",
+		Long: `(ALPHA Warning) This command is currently in experimental mode.
+
+The node upgrade-subnet-evm command upgrades the Subnet-EVM plugin of the given Subnet to
+--version on all nodes of the cluster that track the Subnet.
+
+Before anything is changed, the RPC protocol version of the new Subnet-EVM version is checked
+against the one of the AvalancheGo version each node runs, and the upgrade is aborted if any
+node would not be able to run it.
+
+Nodes are upgraded in batches of at most --max-unavailable nodes, and the next batch is
+upgraded only after every node of the previous one is bootstrapped and healthy again. If a
+node fails, the remaining nodes are not upgraded, unless --continue-on-failure is given.`,
+		Args: cobrautils.ExactArgs(2),
+		RunE: upgradeClusterSubnetEVM,
+	}
+	cmd.Flags().StringVar(&upgradeSubnetEVMVersion, "version", "", "Subnet-EVM version to upgrade to, as vX.Y.Z")
+	cmd.Flags().IntVar(&maxUnavailable, "max-unavailable", 1, "maximum number of nodes upgraded at the same time")
+	cmd.Flags().BoolVar(&continueOnRestartFailure, "continue-on-failure", false, "keep upgrading the remaining nodes if a node fails to become healthy")
+	cmd.Flags().DurationVar(&waitHealthyTimeout, "wait-healthy-timeout", constants.NodeWaitHealthyTimeout, "maximum time to wait for each upgraded node to become healthy")
+	cmd.Flags().DurationVar(&waitHealthyPoll, "wait-healthy-interval", constants.NodeWaitHealthyPollInterval, "interval between node health checks")
+	addHostFilterFlags(cmd)
+	return cmd
+}
+
+func upgradeClusterSubnetEVM(_ *cobra.Command, args []string) error {
+	clusterName := args[0]
+	subnetName := args[1]
+	if !semver.IsValid(upgradeSubnetEVMVersion) {
+		return fmt.Errorf("invalid Subnet-EVM version %q, expected format is vX.Y.Z", upgradeSubnetEVMVersion)
+	}
+	if maxUnavailable < 1 {
+		return fmt.Errorf("max unavailable nodes must be at least 1")
+	}
+	if err := validateWaitHealthyFlags(); err != nil {
+		return err
+	}
+	if err := checkCluster(clusterName); err != nil {
+		return err
+	}
+	clusterConfig, err := app.GetClusterConfig(clusterName)
+	if err != nil {
+		return err
+	}
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	if sc.VM != models.SubnetEvm {
+		return fmt.Errorf("subnet %s does not use Subnet-EVM", subnetName)
+	}
+	subnetID := sc.Networks[clusterConfig.Network.Name()].SubnetID
+	if subnetID == ids.Empty {
+		return fmt.Errorf("subnet %s is not deployed on %s", subnetName, clusterConfig.Network.Name())
+	}
+	vmID, err := sc.GetVMID()
+	if err != nil {
+		return err
+	}
+	hosts, err := getClusterHosts(clusterName)
+	if err != nil {
+		return err
+	}
+	if hosts, err = filterClusterHosts(hosts); err != nil {
+		return err
+	}
+	defer disconnectHosts(hosts)
+
+	untrackingHosts, hosts, failedHosts := node.SplitHostsBySubnetTracking(hosts, func(host *models.Host) (bool, error) {
+		return ssh.RunSSHIsTrackingSubnet(host, subnetID.String())
+	})
+	if len(failedHosts) > 0 {
+		return fmt.Errorf("failed to check subnet tracking of node(s) %s", failedHosts)
+	}
+	for _, host := range untrackingHosts {
+		ux.Logger.PrintToUser("Node %s does not track Subnet %s, skipping it", host.GetCloudID(), subnetName)
+	}
+	if len(hosts) == 0 {
+		return fmt.Errorf("no node of cluster %s tracks Subnet %s", clusterName, subnetName)
+	}
+
+	rpcVersion, err := vm.GetRPCProtocolVersion(app, models.SubnetEvm, upgradeSubnetEVMVersion)
+	if err != nil {
+		return err
+	}
+	hostsRPCVersion, hostsVMVersion, err := getNodesVMVersions(hosts, vmID)
+	if err != nil {
+		return err
+	}
+	if err := node.CheckVMRPCCompatibility(hostsRPCVersion, upgradeSubnetEVMVersion, rpcVersion); err != nil {
+		return err
+	}
+	hosts = utils.Filter(hosts, func(host *models.Host) bool {
+		if hostsVMVersion[host.NodeID] == upgradeSubnetEVMVersion {
+			ux.Logger.PrintToUser("Node %s already runs Subnet-EVM %s, skipping it", host.GetCloudID(), upgradeSubnetEVMVersion)
+			return false
+		}
+		return true
+	})
+	if len(hosts) == 0 {
+		ux.Logger.GreenCheckmarkToUser("All node(s) tracking Subnet %s already run Subnet-EVM %s", subnetName, upgradeSubnetEVMVersion)
+		return updateSidecarVMVersion(&sc, upgradeSubnetEVMVersion, rpcVersion)
+	}
+
+	subnetEVMArchive := fmt.Sprintf(constants.SubnetEVMArchive, strings.TrimPrefix(upgradeSubnetEVMVersion, "v"))
+	subnetEVMReleaseURL := binutils.GetGithubReleaseAssetURL(constants.AvaLabsOrg, constants.SubnetEVMRepoName, upgradeSubnetEVMVersion, subnetEVMArchive)
+	subnetEVMBinaryPath := fmt.Sprintf(constants.CloudNodeSubnetEvmBinaryPath, vmID)
+	ux.Logger.PrintToUser("Upgrading Subnet-EVM of Subnet %s to %s on %d node(s), at most %d at a time...", subnetName, upgradeSubnetEVMVersion, len(hosts), maxUnavailable)
+	results := node.RollingRestart(
+		hosts,
+		maxUnavailable,
+		func(host *models.Host) error {
+			// the release is downloaded before stopping the node, to keep it down as little as possible
+			if err := getNewSubnetEVMRelease(host, subnetEVMReleaseURL, subnetEVMArchive); err != nil {
+				return err
+			}
+			if err := ssh.RunSSHStopNode(host); err != nil {
+				return err
+			}
+			if err := upgradeSubnetEVM(host, subnetEVMBinaryPath); err != nil {
+				return err
+			}
+			return ssh.RunSSHStartNode(host)
+		},
+		func(host *models.Host) error {
+			return waitForHostHealthy(host, waitHealthyTimeout, waitHealthyPoll)
+		},
+		continueOnRestartFailure,
+		func(host *models.Host, err error) {
+			switch {
+			case err == nil:
+				ux.Logger.GreenCheckmarkToUser("Node %s Subnet-EVM version: %s -> %s", host.GetCloudID(), hostsVMVersion[host.NodeID], upgradeSubnetEVMVersion)
+			case errors.Is(err, node.ErrRestartSkipped):
+				ux.Logger.PrintToUser("Node %s skipped: %s", host.GetCloudID(), err)
+			default:
+				ux.Logger.RedXToUser("Node %s failed to upgrade: %s", host.GetCloudID(), describeNodeError(err))
+			}
+		},
+	)
+	if results.HasErrors() {
+		return fmt.Errorf("failed to upgrade Subnet-EVM on node(s) %s", results.GetErrorHosts())
+	}
+	ux.Logger.GreenCheckmarkToUser("Subnet-EVM of Subnet %s upgraded to %s on all node(s) of cluster %s", subnetName, upgradeSubnetEVMVersion, clusterName)
+	return updateSidecarVMVersion(&sc, upgradeSubnetEVMVersion, rpcVersion)
+}
+
+// updateSidecarVMVersion records in [sc] that its nodes run the VM [vmVersion], with RPC protocol
+// version [rpcVersion], so that later deploys and node syncs use it
+func updateSidecarVMVersion(sc *models.Sidecar, vmVersion string, rpcVersion int) error {
+	if sc.VMVersion == vmVersion && sc.RPCVersion == rpcVersion {
+		return nil
+	}
+	sc.VMVersion = vmVersion
+	sc.RPCVersion = rpcVersion
+	if err := app.UpdateSidecar(sc); err != nil {
+		return fmt.Errorf("failed to update the subnet configuration after upgrading Subnet-EVM: %w", err)
+	}
+	return nil
+}
+
+// getNodesVMVersions gets in parallel, by node, the AvalancheGo RPC protocol version of [hosts], and
+// the version of the VM [vmID] they run
+func getNodesVMVersions(hosts []*models.Host, vmID string) (map[string]uint32, map[string]string, error) {
+	wg := sync.WaitGroup{}
+	wgResults := models.NodeResults{}
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			resp, err := ssh.RunSSHCheckAvalancheGoVersion(host)
+			if err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				return
+			}
+			_, rpcVersion, err := parseAvalancheGoOutput(resp)
+			if err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				return
+			}
+			vmVersions, err := parseNodeVersionOutput(resp)
+			if err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+				return
+			}
+			vmVersion, _ := vmVersions[vmID].(string)
+			nodeResults.AddResult(host.NodeID, nodeVMVersion{rpcVersion: rpcVersion, vmVersion: vmVersion}, nil)
+		}(&wgResults, host)
+	}
+	wg.Wait()
+	if wgResults.HasErrors() {
+		return nil, nil, fmt.Errorf("failed to get avalanchego version for node(s) %s", wgResults.GetErrorHostMap())
+	}
+	hostsRPCVersion := map[string]uint32{}
+	hostsVMVersion := map[string]string{}
+	for hostID, result := range wgResults.GetResultMap() {
+		version := result.(nodeVMVersion)
+		hostsRPCVersion[hostID] = version.rpcVersion
+		hostsVMVersion[hostID] = version.vmVersion
+	}
+	return hostsRPCVersion, hostsVMVersion, nil
+}
+
+type nodeVMVersion struct {
+	rpcVersion uint32
+	vmVersion  string
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSidecarVMVersion(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	defer func() {
+		app = nil
+	}()
+	sc := models.Sidecar{Name: "testSubnet", Subnet: "testSubnet", VM: models.SubnetEvm, VMVersion: "v0.6.0", RPCVersion: 33}
+	require.NoError(app.CreateSidecar(&sc))
+
+	require.NoError(updateSidecarVMVersion(&sc, "v0.6.5", 35))
+	savedSC, err := app.LoadSidecar("testSubnet")
+	require.NoError(err)
+	require.Equal("v0.6.5", savedSC.VMVersion)
+	require.Equal(35, savedSC.RPCVersion)
+}
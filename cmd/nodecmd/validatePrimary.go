@@ -10,7 +10,6 @@ import (
 	"time"
 
 	subnetcmd "github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
-	"github.com/ava-labs/avalanche-cli/pkg/ansible"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/keychain"
@@ -297,7 +296,7 @@ func validatePrimaryNetwork(_ *cobra.Command, args []string) error {
 	}
 	network := clusterConfig.Network
 
-	allHosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
+	allHosts, err := getClusterHosts(clusterName)
 	if err != nil {
 		return err
 	}
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	subnetcmd "github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
-	"github.com/ava-labs/avalanche-cli/pkg/ansible"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/keychain"
@@ -23,7 +22,10 @@ import (
 	"golang.org/x/exp/maps"
 )
 
-var avoidSubnetValidationChecks bool
+var (
+	avoidSubnetValidationChecks bool
+	skipExistingValidators      bool
+)
 
 func newValidateSubnetCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -37,11 +39,13 @@ make the nodes Primary Network validators before making them Subnet validators.
 If The command is run before the nodes are bootstrapped on the Primary Network, the command will fail. 
 You can check the bootstrap status by calling avalanche node status <clusterName>
 If The command is run before the nodes are synced to the subnet, the command will fail.
-You can check the subnet sync status by calling avalanche node status <clusterName> --subnet <subnetName>`,
+You can check the subnet sync status by calling avalanche node status <clusterName> --subnet <subnetName>
+With --skip-existing-validators, nodes already validating the Subnet are skipped instead of failing,
+so the command can be rerun on a cluster until all its nodes validate the Subnet.
+The result of each node is reported at the end.`,
 		Args: cobrautils.ExactArgs(2),
 		RunE: validateSubnet,
 	}
-
 	cmd.Flags().StringVarP(&keyName, "key", "k", "", "select the key to use [fuji/devnet only]")
 	cmd.Flags().BoolVarP(&useLedger, "ledger", "g", false, "use ledger instead of key (always true on mainnet, defaults to false on fuji/devnet)")
 	cmd.Flags().BoolVarP(&useEwoq, "ewoq", "e", false, "use ewoq key [fuji/devnet only]")
@@ -56,6 +60,7 @@ You can check the subnet sync status by calling avalanche node status <clusterNa
 
 	cmd.Flags().BoolVar(&avoidSubnetValidationChecks, "no-validation-checks", true, "do not check if subnet is already synced or validated")
 	cmd.Flags().BoolVar(&avoidChecks, "no-checks", false, "do not check for bootstrapped status or healthy status")
+	cmd.Flags().BoolVar(&skipExistingValidators, "skip-existing-validators", false, "skip the nodes already validating the subnet instead of failing them")
 
 	return cmd
 }
@@ -169,6 +174,42 @@ func waitForNodeToBePrimaryNetworkValidator(network models.Network, nodeID ids.N
 	return waitForSubnetValidator(network, ids.Empty, nodeID.String())
 }
 
+var errAlreadySubnetValidator = errors.New("node is already a subnet validator")
+
+// needsSubnetValidator tells if the node [nodeID] of [host] has to be added as a validator
+// of [subnetID]. It is false if the node already validates the subnet, and an error is
+// returned if the node can't be added yet. With [checkSync], the node must be syncing
+// [blockchainID], as reported by [getSyncStatus]
+func needsSubnetValidator(
+	host *models.Host,
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	blockchainID ids.ID,
+	checkSync bool,
+	getSyncStatus func(host *models.Host, blockchainID string) (string, error),
+	isSubnetValidator func(subnetID ids.ID, nodeID ids.NodeID) (bool, error),
+) (bool, error) {
+	if checkSync {
+		// we have to check if node is synced to subnet before adding the node as a validator
+		subnetSyncStatus, err := getSyncStatus(host, blockchainID.String())
+		if err != nil {
+			return false, fmt.Errorf("failed to get subnet sync status: %w", err)
+		}
+		switch subnetSyncStatus {
+		case status.Syncing.String():
+		case status.Validating.String():
+			return false, nil
+		default:
+			return false, errors.New("node is not synced to subnet yet, please try again later")
+		}
+	}
+	isValidator, err := isSubnetValidator(subnetID, nodeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get validator status: %w", err)
+	}
+	return !isValidator, nil
+}
+
 func validateSubnet(_ *cobra.Command, args []string) error {
 	clusterName := args[0]
 	subnetName := args[1]
@@ -186,7 +227,7 @@ func validateSubnet(_ *cobra.Command, args []string) error {
 	}
 	network := clusterConfig.Network
 
-	allHosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
+	allHosts, err := getClusterHosts(clusterName)
 	if err != nil {
 		return err
 	}
@@ -252,6 +293,8 @@ func validateSubnet(_ *cobra.Command, args []string) error {
 		}
 	}
 	nodeErrors := map[string]error{}
+	// results of the nodes that did not fail, by host node ID
+	nodeResults := map[string]string{}
 	// set node errors for node ID conversions
 	for _, host := range hosts {
 		if _, b := nodeIDMap[host.NodeID]; !b {
@@ -304,47 +347,73 @@ func validateSubnet(_ *cobra.Command, args []string) error {
 		if !b {
 			return fmt.Errorf("nodeID should be defined on add subnet validators loop")
 		}
-		if !avoidSubnetValidationChecks {
-			// we have to check if node is synced to subnet before adding the node as a validator
-			subnetSyncStatus, err := getNodeSubnetSyncStatus(host, blockchainID.String())
-			if err != nil {
-				ux.Logger.PrintToUser("Failed to get subnet sync status for node %s", host.NodeID)
-				nodeErrors[host.NodeID] = err
-				continue
-			}
-			if subnetSyncStatus != status.Syncing.String() {
-				if subnetSyncStatus == status.Validating.String() {
-					ux.Logger.PrintToUser("Failed to add node %s as subnet validator as node is already a subnet validator", host.NodeID)
-					nodeErrors[host.NodeID] = errors.New("node is already a subnet validator")
-				} else {
-					ux.Logger.PrintToUser("Failed to add node %s as subnet validator as node is not synced to subnet yet", host.NodeID)
-					nodeErrors[host.NodeID] = errors.New("node is not synced to subnet yet, please try again later")
-				}
-				continue
-			}
-		}
-		if isValidator, err := subnet.IsSubnetValidator(subnetID, nodeID, network); err != nil {
-			ux.Logger.PrintToUser("Failed to get validator status for node %s", host.NodeID)
+		addValidator, err := needsSubnetValidator(
+			host,
+			nodeID,
+			subnetID,
+			blockchainID,
+			!avoidSubnetValidationChecks,
+			getNodeSubnetSyncStatus,
+			func(subnetID ids.ID, nodeID ids.NodeID) (bool, error) {
+				return subnet.IsSubnetValidator(subnetID, nodeID, network)
+			},
+		)
+		if err != nil {
+			ux.Logger.PrintToUser("Failed to add node %s as subnet validator due to %s", host.NodeID, err)
 			nodeErrors[host.NodeID] = err
 			continue
-		} else if isValidator {
+		}
+		if !addValidator {
+			if skipExistingValidators {
+				ux.Logger.PrintToUser("Node %s is already a subnet validator, skipping it", host.NodeID)
+				nodeResults[host.NodeID] = "already a subnet validator, skipped"
+				continue
+			}
 			ux.Logger.PrintToUser("Failed to add node %s as subnet validator as node is already a subnet validator", host.NodeID)
-			nodeErrors[host.NodeID] = errors.New("node is already a subnet validator")
+			nodeErrors[host.NodeID] = errAlreadySubnetValidator
 			continue
 		}
 		if err := addNodeAsSubnetValidator(deployer, network, subnetID, kc, useLedger, nodeID.String(), subnetName, i, len(hosts)); err != nil {
 			ux.Logger.PrintToUser("Failed to add node %s as subnet validator due to %s", host.NodeID, err.Error())
 			nodeErrors[host.NodeID] = err
+			continue
 		}
+		nodeResults[host.NodeID] = "added as subnet validator"
 	}
+	printValidateSubnetResults(hosts, nodeIDMap, nodeResults, nodeErrors)
 	if len(nodeErrors) > 0 {
 		ux.Logger.PrintToUser("Failed nodes: ")
 		for node, err := range nodeErrors {
 			ux.Logger.PrintToUser("node %s failed due to %s", node, err)
 		}
 		return fmt.Errorf("node(s) %s failed to validate subnet %s", maps.Keys(nodeErrors), subnetName)
+	} else if skipExistingValidators {
+		ux.Logger.PrintToUser("All nodes in cluster %s are validating subnet %s!", clusterName, subnetName)
 	} else {
 		ux.Logger.PrintToUser("All nodes in cluster %s are successfully added as Subnet validators!", clusterName)
 	}
 	return nil
 }
+
+// printValidateSubnetResults prints the result of validating the subnet for each host
+func printValidateSubnetResults(
+	hosts []*models.Host,
+	nodeIDMap map[string]ids.NodeID,
+	nodeResults map[string]string,
+	nodeErrors map[string]error,
+) {
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Results:")
+	for _, host := range hosts {
+		nodeID := "unknown node ID"
+		if id, ok := nodeIDMap[host.NodeID]; ok {
+			nodeID = id.String()
+		}
+		if err, ok := nodeErrors[host.NodeID]; ok {
+			ux.Logger.RedXToUser("%s (%s): failed: %s", host.GetCloudID(), nodeID, err)
+		} else {
+			ux.Logger.GreenCheckmarkToUser("%s (%s): %s", host.GetCloudID(), nodeID, nodeResults[host.NodeID])
+		}
+	}
+	ux.Logger.PrintLineSeparator()
+}
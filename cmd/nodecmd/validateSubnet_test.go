@@ -0,0 +1,88 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedsSubnetValidator(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	blockchainID := ids.GenerateTestID()
+	validatingNode := ids.GenerateTestNodeID()
+	syncingNode := ids.GenerateTestNodeID()
+	lateNode := ids.GenerateTestNodeID()
+	unknownNode := ids.GenerateTestNodeID()
+	errAPI := errors.New("API unavailable")
+	// stubbed subnet validator set, and node sync status by host
+	validatorSet := map[ids.ID][]ids.NodeID{subnetID: {validatingNode}}
+	syncStatus := map[string]string{
+		"validating": status.Validating.String(),
+		"syncing":    status.Syncing.String(),
+		"late":       status.Unknown.String(),
+	}
+	getSyncStatus := func(host *models.Host, chainID string) (string, error) {
+		require.Equal(t, blockchainID.String(), chainID)
+		if syncStatus, ok := syncStatus[host.NodeID]; ok {
+			return syncStatus, nil
+		}
+		return "", errAPI
+	}
+	isSubnetValidator := func(subnetID ids.ID, nodeID ids.NodeID) (bool, error) {
+		if nodeID == unknownNode {
+			return false, errAPI
+		}
+		for _, validator := range validatorSet[subnetID] {
+			if validator == nodeID {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	for _, tc := range []struct {
+		name        string
+		host        string
+		nodeID      ids.NodeID
+		checkSync   bool
+		expectedAdd bool
+		expectedErr error
+		errContains string
+	}{
+		{name: "validating node is skipped", host: "validating", nodeID: validatingNode, checkSync: true},
+		{name: "validating node is skipped without sync check", host: "validating", nodeID: validatingNode},
+		{name: "synced node is added", host: "syncing", nodeID: syncingNode, checkSync: true, expectedAdd: true},
+		{name: "node is added without sync check", host: "late", nodeID: lateNode, expectedAdd: true},
+		{name: "not synced node fails", host: "late", nodeID: lateNode, checkSync: true, errContains: "not synced to subnet yet"},
+		{name: "sync status error", host: "unreachable", nodeID: syncingNode, checkSync: true, expectedErr: errAPI},
+		{name: "validator set error", host: "syncing", nodeID: unknownNode, checkSync: true, expectedErr: errAPI},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			add, err := needsSubnetValidator(
+				&models.Host{NodeID: tc.host},
+				tc.nodeID,
+				subnetID,
+				blockchainID,
+				tc.checkSync,
+				getSyncStatus,
+				isSubnetValidator,
+			)
+			switch {
+			case tc.expectedErr != nil:
+				require.ErrorIs(err, tc.expectedErr)
+			case tc.errContains != "":
+				require.ErrorContains(err, tc.errContains)
+			default:
+				require.NoError(err)
+			}
+			require.Equal(tc.expectedAdd, add)
+		})
+	}
+}
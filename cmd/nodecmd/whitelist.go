@@ -4,6 +4,7 @@ package nodecmd
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,23 +30,27 @@ var (
 	app           *application.Avalanche
 	userIPAddress string
 	userPubKey    string
+	userKeyFiles  []string
 	discoverIP    bool
 )
 
 func newWhitelistCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "whitelist <clusterName> [--ip <IP>] [--ssh \"<sshPubKey>\"]",
+		Use:   "whitelist <clusterName> [--ip <IP>] [--ssh \"<sshPubKey>\"] [--key <sshPubKeyFile>]",
 		Short: "(ALPHA Warning) Grant access to the cluster ",
 		Long: `(ALPHA Warning) The whitelist command suite provides a collection of tools for granting access to the cluster.
 
 	Command adds IP if --ip params provided to cloud security access rules allowing it to access all nodes in the cluster via ssh or http.
 	It also command adds SSH public key to all nodes in the cluster if --ssh params is there.
+	SSH public keys can also be read from files with --key, which can be repeated to whitelist
+	multiple keys at once. Keys already present on a node are skipped.
 	If no params provided it detects current user IP automaticaly and whitelists it`,
 		Args: cobrautils.MinimumNArgs(1),
 		RunE: whitelist,
 	}
 	cmd.Flags().StringVar(&userIPAddress, "ip", "", "ip address to whitelist")
 	cmd.Flags().StringVar(&userPubKey, "ssh", "", "ssh public key to whitelist")
+	cmd.Flags().StringSliceVar(&userKeyFiles, "key", []string{}, "file containing ssh public key(s) to whitelist. Can be repeated for multiple files")
 	cmd.Flags().BoolVarP(&discoverIP, "current-ip", "y", false, "whitelist current host ip")
 	return cmd
 }
@@ -56,6 +61,7 @@ type regionSecurityGroup struct {
 	cloud         string
 	region        string
 	securityGroup string
+	httpPort      uint // avalanchego http port of the nodes behind the security group
 }
 
 func whitelist(_ *cobra.Command, args []string) error {
@@ -75,12 +81,26 @@ func whitelist(_ *cobra.Command, args []string) error {
 		}
 		ux.Logger.PrintToUser("Detected your IP address as: %s", logging.LightBlue.Wrap(userIPAddress))
 	}
-	if userIPAddress == "" && userPubKey == "" {
+	sshPubKeys := []string{}
+	if userPubKey != "" {
+		sshPubKeys = append(sshPubKeys, userPubKey)
+	}
+	for _, keyFile := range userKeyFiles {
+		fileKeys, err := readSSHPubKeysFile(keyFile)
+		if err != nil {
+			return err
+		}
+		sshPubKeys = append(sshPubKeys, fileKeys...)
+	}
+	if userIPAddress == "" && len(sshPubKeys) == 0 {
 		// prompt for ssh key
 		userPubKey, err = utils.ReadLongString("Enter SSH public key to whitelist (leave empty to skip):\n")
 		if err != nil {
 			return err
 		}
+		if userPubKey != "" {
+			sshPubKeys = append(sshPubKeys, userPubKey)
+		}
 		// prompt for IP
 		detectedIPAddress, err := utils.GetUserIPAddress()
 		if err != nil {
@@ -98,11 +118,13 @@ func whitelist(_ *cobra.Command, args []string) error {
 			userIPAddress = ""
 		}
 	}
-	if userPubKey != "" {
-		if !utils.IsSSHPubKey(userPubKey) {
-			return fmt.Errorf("invalid SSH public key: %s", userPubKey)
+	if len(sshPubKeys) > 0 {
+		for _, sshPubKey := range sshPubKeys {
+			if !utils.IsSSHPubKey(sshPubKey) {
+				return fmt.Errorf("invalid SSH public key: %s", sshPubKey)
+			}
 		}
-		if err := whitelistSSHPubKey(clusterName, userPubKey); err != nil {
+		if err := whitelistSSHPubKeys(clusterName, sshPubKeys); err != nil {
 			return err
 		}
 		if userIPAddress == "" {
@@ -176,7 +198,7 @@ func GrantAccessToIPinAWS(awsProfile string, region string, sgName string, userI
 	if err != nil {
 		return fmt.Errorf("failed to establish connection to %s cloud region %s with err: %w", constants.AWSCloudService, region, err)
 	}
-	securityGroupExists, sg, err := ec2Svc.CheckSecurityGroupExists(sgName)
+	securityGroupExists, sg, err := ec2Svc.CheckSecurityGroupExists(sgName, "")
 	if err != nil || !securityGroupExists {
 		return fmt.Errorf("can't find security group %s in %s cloud region %s with err: %w", sgName, constants.AWSCloudService, region, err)
 	}
@@ -232,8 +254,30 @@ func GrantAccessToIPinGCP(userIPAddress string) error {
 	return nil
 }
 
-func whitelistSSHPubKey(clusterName string, pubkey string) error {
-	sshPubKey := strings.Trim(pubkey, "\"'")
+// readSSHPubKeysFile returns the ssh public keys contained in [keyFile], one per line
+func readSSHPubKeysFile(keyFile string) ([]string, error) {
+	content, err := os.ReadFile(utils.ExpandHome(keyFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH public key file %s: %w", keyFile, err)
+	}
+	sshPubKeys := []string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sshPubKeys = append(sshPubKeys, line)
+	}
+	if len(sshPubKeys) == 0 {
+		return nil, fmt.Errorf("no SSH public key found in file %s", keyFile)
+	}
+	return sshPubKeys, nil
+}
+
+func whitelistSSHPubKeys(clusterName string, pubkeys []string) error {
+	sshPubKeys := utils.Map(pubkeys, func(pubkey string) string {
+		return strings.Trim(pubkey, "\"'")
+	})
 	if err := checkCluster(clusterName); err != nil {
 		return err
 	}
@@ -242,7 +286,7 @@ func whitelistSSHPubKey(clusterName string, pubkey string) error {
 		return err
 	}
 	clusterConfig := clustersConfig.Clusters[clusterName]
-	hosts, err := ansible.GetInventoryFromAnsibleInventoryFile(app.GetAnsibleInventoryDirPath(clusterName))
+	hosts, err := getClusterHosts(clusterName)
 	if err != nil {
 		return err
 	}
@@ -260,18 +304,19 @@ func whitelistSSHPubKey(clusterName string, pubkey string) error {
 		}
 		hosts = append(hosts, loadTestHost...)
 	}
-	ux.Logger.PrintToUser("Whitelisting SSH public key on all nodes in cluster: %s", logging.LightBlue.Wrap(clusterName))
+	ux.Logger.PrintToUser("Whitelisting %d SSH public key(s) on all nodes in cluster: %s", len(sshPubKeys), logging.LightBlue.Wrap(clusterName))
 	wg := sync.WaitGroup{}
 	wgResults := models.NodeResults{}
 	for _, host := range hosts {
 		wg.Add(1)
 		go func(nodeResults *models.NodeResults, host *models.Host) {
 			defer wg.Done()
-			if err := ssh.RunSSHWhitelistPubKey(host, sshPubKey); err != nil {
+			if err := ssh.RunSSHWhitelistPubKeys(host, sshPubKeys); err != nil {
 				nodeResults.AddResult(host.NodeID, nil, err)
 				return
 			}
-			ux.Logger.GreenCheckmarkToUser(utils.ScriptLog(host.NodeID, "Whitelisted SSH public key"))
+			nodeResults.AddResult(host.NodeID, nil, nil)
+			ux.Logger.GreenCheckmarkToUser(utils.ScriptLog(host.NodeID, "Whitelisted SSH public key(s)"))
 		}(&wgResults, host)
 	}
 	wg.Wait()
@@ -295,6 +340,7 @@ func getCloudSecurityGroupList(clusterNodes []string) ([]regionSecurityGroup, er
 			cloud:         nodeConfig.CloudService,
 			region:        nodeConfig.Region,
 			securityGroup: nodeConfig.SecurityGroup,
+			httpPort:      nodeConfig.GetHTTPPort(),
 		}) {
 			continue
 		}
@@ -302,6 +348,7 @@ func getCloudSecurityGroupList(clusterNodes []string) ([]regionSecurityGroup, er
 			cloud:         nodeConfig.CloudService,
 			region:        nodeConfig.Region,
 			securityGroup: nodeConfig.SecurityGroup,
+			httpPort:      nodeConfig.GetHTTPPort(),
 		})
 	}
 	return cloudSecurityGroupList, nil
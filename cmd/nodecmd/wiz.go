@@ -57,6 +57,7 @@ var (
 	useLatestEvmPreReleasedVersion         bool
 	customVMRepoURL                        string
 	customVMBranch                         string
+	customVMTag                            string
 	customVMBuildScript                    string
 	nodeConf                               string
 	subnetConf                             string
@@ -65,6 +66,7 @@ var (
 	customGrafanaDashboardPath             string
 	teleporterReady                        bool
 	runRelayer                             bool
+	relayerLogLevel                        string
 	teleporterVersion                      string
 	teleporterMessengerContractAddressPath string
 	teleporterMessengerDeployerAddressPath string
@@ -103,6 +105,7 @@ The node wiz command creates a devnet and deploys, sync and validate a subnet in
 	cmd.Flags().StringVar(&subnetGenesisFile, "subnet-genesis", "", "file path of the subnet genesis")
 	cmd.Flags().BoolVar(&teleporterReady, "teleporter", false, "generate a teleporter-ready vm")
 	cmd.Flags().BoolVar(&runRelayer, "relayer", false, "run AWM relayer when deploying the vm")
+	cmd.Flags().StringVar(&relayerLogLevel, "relayer-log-level", "", "log level of the AWM relayer (off, fatal, error, warn, info, trace, debug, verbo). Defaults to info for a new config, and keeps the current level otherwise")
 	cmd.Flags().BoolVar(&useEvmSubnet, "evm-subnet", false, "use Subnet-EVM as the subnet virtual machine")
 	cmd.Flags().BoolVar(&useCustomSubnet, "custom-subnet", false, "use a custom VM as the subnet virtual machine")
 	cmd.Flags().StringVar(&evmVersion, "evm-version", "", "version of Subnet-EVM to use")
@@ -113,12 +116,14 @@ The node wiz command creates a devnet and deploys, sync and validate a subnet in
 	cmd.Flags().BoolVar(&useLatestEvmPreReleasedVersion, "latest-pre-released-evm-version", false, "use latest Subnet-EVM pre-released version")
 	cmd.Flags().StringVar(&customVMRepoURL, "custom-vm-repo-url", "", "custom vm repository url")
 	cmd.Flags().StringVar(&customVMBranch, "custom-vm-branch", "", "custom vm branch or commit")
+	cmd.Flags().StringVar(&customVMTag, "custom-vm-tag", "", "custom vm git tag to build from, checked to exist in the repository")
 	cmd.Flags().StringVar(&customVMBuildScript, "custom-vm-build-script", "", "custom vm build-script")
 	cmd.Flags().StringVar(&customGrafanaDashboardPath, "add-grafana-dashboard", "", "path to additional grafana dashboard json file")
 	cmd.Flags().StringVar(&nodeConf, "node-config", "", "path to avalanchego node configuration for subnet")
 	cmd.Flags().StringVar(&subnetConf, "subnet-config", "", "path to the subnet configuration for subnet")
 	cmd.Flags().StringVar(&chainConf, "chain-config", "", "path to the chain configuration for subnet")
 	cmd.Flags().BoolVar(&useSSHAgent, "use-ssh-agent", false, "use ssh agent for ssh")
+	cmd.Flags().BoolVar(&noSSHAgent, "no-ssh-agent", false, "do not add downloaded key pair files to ssh agent, use them directly for ssh auth")
 	cmd.Flags().StringVar(&sshIdentity, "ssh-agent-identity", "", "use given ssh identity(only for ssh agent). If not set, default will be used.")
 	cmd.Flags().BoolVar(&useLatestAvalanchegoReleaseVersion, "latest-avalanchego-version", false, "install latest avalanchego release version on node/s")
 	cmd.Flags().BoolVar(&useLatestAvalanchegoPreReleaseVersion, "latest-avalanchego-pre-release-version", false, "install latest avalanchego pre-release version on node/s")
@@ -148,6 +153,11 @@ func wiz(cmd *cobra.Command, args []string) error {
 	if len(args) > 1 {
 		subnetName = args[1]
 	}
+	if relayerLogLevel != "" {
+		if err := teleporter.ValidateRelayerLogLevel(relayerLogLevel); err != nil {
+			return err
+		}
+	}
 	clusterAlreadyExists, err := app.ClusterExists(clusterName)
 	if err != nil {
 		return err
@@ -179,6 +189,7 @@ func wiz(cmd *cobra.Command, args []string) error {
 			useLatestEvmPreReleasedVersion,
 			customVMRepoURL,
 			customVMBranch,
+			customVMTag,
 			customVMBuildScript,
 		); err != nil {
 			return err
@@ -494,6 +505,7 @@ func updateAWMRelayerHostConfig(host *models.Host, subnetName string, clusterNam
 			ClusterName: clusterName,
 		},
 		CloudNodeID: host.GetCloudID(),
+		LogLevel:    relayerLogLevel,
 	}
 	if err := relayercmd.CallAddSubnetToService(subnetName, flags); err != nil {
 		return err
@@ -585,7 +597,7 @@ func deployClusterYAMLFile(clusterName, subnetName string) error {
 		if err = ssh.RunSSHCopyYAMLFile(separateHosts[0], app.GetClusterYAMLFilePath(clusterName)); err != nil {
 			return err
 		}
-		ux.Logger.GreenCheckmarkToUser("Cluster information YAML file can be found at /home/ubuntu/%s at external host", constants.ClusterYAMLFileName)
+		ux.Logger.GreenCheckmarkToUser("Cluster information YAML file can be found at %s at external host", separateHosts[0].ExpandHome("~/"+constants.ClusterYAMLFileName))
 	}
 	return nil
 }
@@ -855,6 +867,7 @@ func setAWMRelayerSecurityGroupRule(clusterName string, awmRelayerHost *models.H
 		return err
 	}
 	hasGCPNodes := false
+	gcpHTTPPort := uint(constants.AvalanchegoAPIPort)
 	lastRegion := ""
 	var ec2Svc *awsAPI.AwsCloud
 	for _, cloudID := range clusterConfig.GetCloudIDs() {
@@ -871,32 +884,34 @@ func setAWMRelayerSecurityGroupRule(clusterName string, awmRelayerHost *models.H
 				}
 				lastRegion = nodeConfig.Region
 			}
-			securityGroupExists, sg, err := ec2Svc.CheckSecurityGroupExists(nodeConfig.SecurityGroup)
+			securityGroupExists, sg, err := ec2Svc.CheckSecurityGroupExists(nodeConfig.SecurityGroup, "")
 			if err != nil {
 				return err
 			}
 			if !securityGroupExists {
 				return fmt.Errorf("security group %s doesn't exist in region %s", nodeConfig.SecurityGroup, nodeConfig.Region)
 			}
-			if inSG := awsAPI.CheckIPInSg(&sg, awmRelayerHost.IP, constants.AvalanchegoAPIPort); !inSG {
+			httpPort := int32(nodeConfig.GetHTTPPort())
+			if inSG := awsAPI.CheckIPInSg(&sg, awmRelayerHost.IP, httpPort); !inSG {
 				if err = ec2Svc.AddSecurityGroupRule(
 					*sg.GroupId,
 					"ingress",
 					"tcp",
 					awmRelayerHost.IP+constants.IPAddressSuffix,
-					constants.AvalanchegoAPIPort,
+					httpPort,
 				); err != nil {
 					return err
 				}
 			}
 		case nodeConfig.CloudService == constants.GCPCloudService:
 			hasGCPNodes = true
+			gcpHTTPPort = nodeConfig.GetHTTPPort()
 		default:
 			return fmt.Errorf("cloud %s is not supported", nodeConfig.CloudService)
 		}
 	}
 	if hasGCPNodes {
-		if err := setGCPAWMRelayerSecurityGroupRule(awmRelayerHost); err != nil {
+		if err := setGCPAWMRelayerSecurityGroupRule(awmRelayerHost, gcpHTTPPort); err != nil {
 			return err
 		}
 	}
@@ -919,6 +934,7 @@ func populateSubnetVMMetrics(flags map[string]string, subnetName string) {
 			flags[constants.MetricsSubnetVM] = "Custom-VM"
 			flags[constants.MetricsCustomVMRepoURL] = sc.CustomVMRepoURL
 			flags[constants.MetricsCustomVMBranch] = sc.CustomVMBranch
+			flags[constants.MetricsCustomVMTag] = sc.CustomVMTag
 			flags[constants.MetricsCustomVMBuildScript] = sc.CustomVMBuildScript
 		}
 	}
@@ -3,6 +3,7 @@
 package primarycmd
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/spf13/cobra"
 )
 
@@ -71,41 +73,46 @@ in the Primary Network`,
 }
 
 func promptProofOfPossession() (jsonProofOfPossession, error) {
+	var (
+		publicKeyBytes []byte
+		popBytes       []byte
+		err            error
+	)
 	if publicKey != "" {
-		err := prompts.ValidateHexa(publicKey)
+		publicKeyBytes, err = prompts.ParseHexBytes(publicKey, bls.PublicKeyLen)
 		if err != nil {
 			ux.Logger.PrintToUser("Format error in given public key: %s", err)
-			publicKey = ""
 		}
 	}
 	if pop != "" {
-		err := prompts.ValidateHexa(pop)
+		popBytes, err = prompts.ParseHexBytes(pop, bls.SignatureLen)
 		if err != nil {
 			ux.Logger.PrintToUser("Format error in given proof of possession: %s", err)
-			pop = ""
 		}
 	}
-	if publicKey == "" || pop == "" {
+	if publicKeyBytes == nil || popBytes == nil {
 		ux.Logger.PrintToUser("Next, we need the public key and proof of possession of the node's BLS")
 		ux.Logger.PrintToUser("SSH into the node and call info.getNodeID API to get the node's BLS info")
 		ux.Logger.PrintToUser("Check https://docs.avax.network/apis/avalanchego/apis/info#infogetnodeid for instructions on calling info.getNodeID API")
 	}
-	var err error
-	if publicKey == "" {
+	if publicKeyBytes == nil {
 		txt := "What is the public key of the node's BLS?"
-		publicKey, err = app.Prompt.CaptureValidatedString(txt, prompts.ValidateHexa)
+		publicKeyBytes, err = app.Prompt.CaptureHexBytes(txt, bls.PublicKeyLen)
 		if err != nil {
 			return jsonProofOfPossession{}, err
 		}
 	}
-	if pop == "" {
+	if popBytes == nil {
 		txt := "What is the proof of possession of the node's BLS?"
-		pop, err = app.Prompt.CaptureValidatedString(txt, prompts.ValidateHexa)
+		popBytes, err = app.Prompt.CaptureHexBytes(txt, bls.SignatureLen)
 		if err != nil {
 			return jsonProofOfPossession{}, err
 		}
 	}
-	return jsonProofOfPossession{PublicKey: publicKey, ProofOfPossession: pop}, nil
+	return jsonProofOfPossession{
+		PublicKey:         "0x" + hex.EncodeToString(publicKeyBytes),
+		ProofOfPossession: "0x" + hex.EncodeToString(popBytes),
+	}, nil
 }
 
 func addValidator(_ *cobra.Command, _ []string) error {
@@ -25,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/cmd/updatecmd"
 	"github.com/ava-labs/avalanche-cli/internal/migrations"
 	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/config"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
@@ -45,6 +46,13 @@ var (
 	Version   = ""
 	cfgFile   string
 	skipCheck bool
+	// releaseMirror is an optional base URL or local dir releases are resolved against instead of github
+	releaseMirror string
+	// assumeYes auto answers confirmation prompts, see prompts.AutoConfirmPrompter
+	assumeYes bool
+	noMetrics bool
+	// logJSON prints the output to the user as JSON lines, see ux.UserLog.SetJSONOutput
+	logJSON bool
 )
 
 func NewRootCmd() *cobra.Command {
@@ -73,6 +81,14 @@ in with avalanche subnet create myNewSubnet.`,
 		StringVar(&logLevel, "log-level", "ERROR", "log level for the application")
 	rootCmd.PersistentFlags().
 		BoolVar(&skipCheck, constants.SkipUpdateFlag, false, "skip check for new versions")
+	rootCmd.PersistentFlags().
+		StringVar(&releaseMirror, "release-mirror", "", "base URL or local directory to download releases from, instead of github")
+	rootCmd.PersistentFlags().
+		BoolVar(&assumeYes, "yes", false, "answer yes to all confirmation prompts, and fail on prompts that require a value")
+	rootCmd.PersistentFlags().
+		BoolVar(&noMetrics, constants.ConfigNoMetricsKey, false, "do not send usage metrics, even if enabled. can also be set in the config file")
+	rootCmd.PersistentFlags().
+		BoolVar(&logJSON, "log-json", false, "print output as JSON lines with level, msg and fields keys, for automation. prompts are still shown on the terminal")
 
 	// add sub commands
 	rootCmd.AddCommand(subnetcmd.NewCmd(app))
@@ -121,9 +137,25 @@ func createApp(cmd *cobra.Command, _ []string) error {
 	log.Info("-----------")
 	log.Info(fmt.Sprintf("cmd: %s", strings.Join(os.Args[1:], " ")))
 	cf := config.New()
-	app.Setup(baseDir, log, cf, prompts.NewPrompter(), application.NewDownloader())
+	downloader := application.NewDownloader()
+	if releaseMirror != "" {
+		releaseSource, err := application.NewMirrorReleaseSource(releaseMirror)
+		if err != nil {
+			return err
+		}
+		binutils.SetReleaseSource(releaseSource)
+		downloader = application.NewDownloaderWithSource(releaseSource)
+	}
+	prompter := prompts.NewPrompter()
+	if assumeYes {
+		prompter = prompts.NewAutoConfirmPrompter(prompter)
+	}
+	app.Setup(baseDir, log, cf, prompter, downloader)
 
 	initConfig()
+	if err := app.Conf.BindFlag(constants.ConfigNoMetricsKey, cmd.Root().PersistentFlags().Lookup(constants.ConfigNoMetricsKey)); err != nil {
+		return err
+	}
 
 	if err := migrations.RunMigrations(app); err != nil {
 		return err
@@ -303,6 +335,7 @@ func setupLogging(baseDir string) (logging.Logger, error) {
 	}
 	// create the user facing logger as a global var
 	ux.NewUserLog(log, os.Stdout)
+	ux.Logger.SetJSONOutput(logJSON)
 	return log, nil
 }
 
@@ -319,6 +352,7 @@ func initConfig() {
 			app.Conf.MergeConfig(app.Log, oldMetricsConfig)
 		}
 	}
+	app.LoadTimeoutOverrides()
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
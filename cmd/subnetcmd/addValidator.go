@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanchego/ids"
 	avagoconstants "github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/spf13/cobra"
 )
@@ -204,16 +205,38 @@ func CallAddValidator(
 	}
 	ux.Logger.PrintToUser("Your subnet auth keys for add validator tx creation: %s", subnetAuthKeys)
 
+	validators, err := subnet.GetPublicSubnetValidators(subnetID, network)
+	if err != nil {
+		return err
+	}
 	if nodeIDStr == "" {
-		nodeID, err = PromptNodeID()
-		if err != nil {
-			return err
+		for {
+			nodeID, err = PromptNodeID()
+			if err != nil {
+				return err
+			}
+			err = subnet.CheckNotSubnetValidator(validators, subnetID, nodeID)
+			if err == nil {
+				break
+			}
+			ux.Logger.RedXToUser("%s. Please enter another NodeID", err)
 		}
 	} else {
 		nodeID, err = ids.NodeIDFromString(nodeIDStr)
 		if err != nil {
 			return err
 		}
+		if err := subnet.CheckNotSubnetValidator(validators, subnetID, nodeID); err != nil {
+			return err
+		}
+	}
+	if isPrimaryValidator, err := subnet.IsSubnetValidator(ids.Empty, nodeID, network); err != nil {
+		return err
+	} else if !isPrimaryValidator {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"WARNING: node %s is not a Primary Network validator. The transaction may be rejected, and the node must be bootstrapped and synced to the subnet before the validation starts",
+			nodeID,
+		)))
 	}
 
 	selectedWeight, err := getWeight()
@@ -6,13 +6,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
 )
 
 var (
@@ -20,6 +24,7 @@ var (
 	subnetConf       string
 	chainConf        string
 	perNodeChainConf string
+	upgradeConf      string
 )
 
 // avalanche subnet configure
@@ -29,8 +34,12 @@ func newConfigureCmd() *cobra.Command {
 		Short: "Adds additional config files for the avalanchego nodes",
 		Long: `AvalancheGo nodes support several different configuration files. Subnets have their own
 Subnet config which applies to all chains/VMs in the Subnet. Each chain within the Subnet
-can have its own chain config. A chain can also have special requirements for the AvalancheGo node 
-configuration itself. This command allows you to set all those files.`,
+can have its own chain config, and its own upgrade.json. A chain can also have special requirements
+for the AvalancheGo node configuration itself. This command allows you to set all those files.
+
+JSON files are validated before being stored. The Subnet config is checked against the AvalancheGo
+Subnet config schema, and for Subnet-EVM chains, the upgrade.json is checked against the Subnet-EVM
+upgrade schema. The stored files are used on deploy, and when syncing cluster nodes with the Subnet.`,
 		RunE: configure,
 		Args: cobrautils.ExactArgs(1),
 	}
@@ -39,6 +48,7 @@ configuration itself. This command allows you to set all those files.`,
 	cmd.Flags().StringVar(&subnetConf, "subnet-config", "", "path to the subnet configuration")
 	cmd.Flags().StringVar(&chainConf, "chain-config", "", "path to the chain configuration")
 	cmd.Flags().StringVar(&perNodeChainConf, "per-node-chain-config", "", "path to per node chain configuration for local network")
+	cmd.Flags().StringVar(&upgradeConf, "upgrade-config", "", "path to the chain upgrade.json")
 	return cmd
 }
 
@@ -67,6 +77,7 @@ func configure(_ *cobra.Command, args []string) error {
 		perNodeChainLabel = constants.PerNodeChainConfigFileName
 		subnetLabel       = constants.SubnetConfigFileName
 		nodeLabel         = constants.NodeConfigFileName
+		upgradeLabel      = constants.UpgradeBytesFileName
 	)
 	configsToLoad := map[string]string{}
 
@@ -82,10 +93,13 @@ func configure(_ *cobra.Command, args []string) error {
 	if perNodeChainConf != "" {
 		configsToLoad[perNodeChainLabel] = perNodeChainConf
 	}
+	if upgradeConf != "" {
+		configsToLoad[upgradeLabel] = upgradeConf
+	}
 
 	// no flags provided
 	if len(configsToLoad) == 0 {
-		options := []string{nodeLabel, chainLabel, subnetLabel, perNodeChainLabel}
+		options := []string{nodeLabel, chainLabel, subnetLabel, perNodeChainLabel, upgradeLabel}
 		selected, err := app.Prompt.CaptureList("Which configuration file would you like to provide?", options)
 		if err != nil {
 			return err
@@ -95,7 +109,7 @@ func configure(_ *cobra.Command, args []string) error {
 			return err
 		}
 		var other string
-		if selected == chainLabel || selected == perNodeChainLabel {
+		if selected == chainLabel || selected == perNodeChainLabel || selected == upgradeLabel {
 			other = subnetLabel
 		} else {
 			other = chainLabel
@@ -112,9 +126,21 @@ func configure(_ *cobra.Command, args []string) error {
 		}
 	}
 
-	// load each provided file
-	for filename, configPath := range configsToLoad {
-		if err = updateConf(subnetName, configPath, filename); err != nil {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	filenames := maps.Keys(configsToLoad)
+	sort.Strings(filenames)
+	// validate all the provided files before storing any of them
+	configsBytes := map[string][]byte{}
+	for _, filename := range filenames {
+		if configsBytes[filename], err = loadConf(sc, configsToLoad[filename], filename); err != nil {
+			return err
+		}
+	}
+	for _, filename := range filenames {
+		if err = updateConf(subnetName, configsBytes[filename], filename); err != nil {
 			return err
 		}
 	}
@@ -122,23 +148,30 @@ func configure(_ *cobra.Command, args []string) error {
 	return nil
 }
 
-func updateConf(subnet, path, filename string) error {
-	var (
-		fileBytes []byte
-		err       error
-	)
-	if strings.ToLower(filepath.Ext(filename)) == "json" {
-		fileBytes, err = utils.ValidateJSON(path)
-		if err != nil {
-			return err
-		}
-	} else {
-		fileBytes, err = os.ReadFile(path)
-		if err != nil {
-			return err
-		}
+// loadConf reads the config file at [path], to be stored as [filename], validating it when
+// its schema is known
+func loadConf(sc models.Sidecar, path, filename string) ([]byte, error) {
+	if strings.ToLower(filepath.Ext(filename)) != ".json" {
+		return os.ReadFile(path)
+	}
+	fileBytes, err := utils.ValidateJSON(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
 	}
-	subnetDir := filepath.Join(app.GetSubnetDir(), subnet)
+	switch {
+	case filename == constants.SubnetConfigFileName:
+		err = subnet.ValidateSubnetConfig(fileBytes)
+	case filename == constants.UpgradeBytesFileName && sc.VM == models.SubnetEvm:
+		err = subnet.ValidateSubnetEVMUpgradeConfig(fileBytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return fileBytes, nil
+}
+
+func updateConf(subnetName string, fileBytes []byte, filename string) error {
+	subnetDir := filepath.Join(app.GetSubnetDir(), subnetName)
 	if err := os.MkdirAll(subnetDir, constants.DefaultPerms755); err != nil {
 		return err
 	}
@@ -0,0 +1,107 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/avalanche-cli/tests/e2e/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureSubnet(t *testing.T) {
+	testDir := t.TempDir()
+	require := require.New(t)
+	testSubnet := "testSubnet"
+
+	app = application.New()
+	mockAppDownloader := mocks.Downloader{}
+	mockAppDownloader.On("Download", mock.Anything).Return([]byte("{\"rpcChainVMProtocolVersion\": {\"v0.9.99\": 18}}"), nil)
+	app.Setup(testDir, logging.NoLog{}, nil, prompts.NewPrompter(), &mockAppDownloader)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	genBytes, sc, err := vm.CreateEvmSubnetConfig(
+		app,
+		testSubnet,
+		"../../"+utils.SubnetEvmGenesisPath,
+		"v0.9.99",
+		false,
+		0,
+		"",
+		nil,
+		false,
+		false,
+		nil,
+		vm.AllowListFiles{},
+		vm.AirdropFlags{},
+		nil,
+	)
+	require.NoError(err)
+	require.NoError(app.WriteGenesisFile(testSubnet, genBytes))
+	require.NoError(app.CreateSidecar(sc))
+	defer func() {
+		subnetConf = ""
+		chainConf = ""
+		upgradeConf = ""
+		app = nil
+	}()
+
+	writeConf := func(name string, content string) string {
+		path := filepath.Join(testDir, name)
+		require.NoError(os.WriteFile(path, []byte(content), constants.WriteReadReadPerms))
+		return path
+	}
+	validSubnetConf := `{"validatorOnly": true, "proposerMinBlockDelay": 0}`
+	validChainConf := `{"log-level": "debug"}`
+	validUpgradeConf := `{"precompileUpgrades": [{"feeManagerConfig": {"blockTimestamp": 1700000000, "adminAddresses": ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]}}]}`
+
+	// invalid files are rejected, and nothing is stored
+	subnetConf = writeConf("invalid-subnet.json", `{"validatorOnly": true`)
+	chainConf = writeConf("chain.json", validChainConf)
+	require.ErrorContains(configure(nil, []string{testSubnet}), "invalid JSON")
+	require.False(app.AvagoSubnetConfigExists(testSubnet))
+	require.False(app.ChainConfigExists(testSubnet))
+	subnetConf = writeConf("invalid-subnet-schema.json", `{"consensusParameters": {"k": 1}}`)
+	require.ErrorContains(configure(nil, []string{testSubnet}), "invalid subnet config")
+	require.False(app.AvagoSubnetConfigExists(testSubnet))
+	subnetConf = ""
+	chainConf = ""
+	upgradeConf = writeConf("invalid-upgrade.json", `{"precompileUpgrades": [{"notAPrecompile": {}}]}`)
+	require.ErrorContains(configure(nil, []string{testSubnet}), "invalid upgrade config")
+	require.False(app.NetworkUpgradeExists(testSubnet))
+
+	// valid files are stored, and read back as provided
+	subnetConf = writeConf("subnet.json", validSubnetConf)
+	chainConf = writeConf("chain.json", validChainConf)
+	upgradeConf = writeConf("upgrade.json", validUpgradeConf)
+	require.NoError(configure(nil, []string{testSubnet}))
+	rawSubnetConf, err := app.LoadRawAvagoSubnetConfig(testSubnet)
+	require.NoError(err)
+	require.Equal(validSubnetConf, string(rawSubnetConf))
+	rawChainConf, err := app.LoadRawChainConfig(testSubnet)
+	require.NoError(err)
+	require.Equal(validChainConf, string(rawChainConf))
+	rawUpgradeConf, err := app.LoadRawNetworkUpgrades(testSubnet)
+	require.NoError(err)
+	require.Equal(validUpgradeConf, string(rawUpgradeConf))
+
+	// an existing config is replaced
+	updatedSubnetConf := `{"validatorOnly": false}`
+	subnetConf = writeConf("subnet.json", updatedSubnetConf)
+	chainConf = ""
+	upgradeConf = ""
+	require.NoError(configure(nil, []string{testSubnet}))
+	rawSubnetConf, err = app.LoadRawAvagoSubnetConfig(testSubnet)
+	require.NoError(err)
+	require.Equal(updatedSubnetConf, string(rawSubnetConf))
+}
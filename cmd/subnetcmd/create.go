@@ -3,12 +3,18 @@
 package subnetcmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/ava-labs/avalanche-cli/cmd/flags"
@@ -21,25 +27,32 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/spf13/cobra"
 	"golang.org/x/mod/semver"
 )
 
 const (
-	forceFlag  = "force"
-	latest     = "latest"
-	preRelease = "pre-release"
+	forceFlag            = "force"
+	latest               = "latest"
+	preRelease           = "pre-release"
+	genesisTimestampFlag = "genesis-timestamp"
+	dumpGenesisFlag      = "dump-genesis"
 )
 
 var (
 	forceCreate                    bool
 	useSubnetEvm                   bool
 	genesisFile                    string
+	genesisStdin                   bool
+	genesisValidateScript          string
 	vmFile                         string
 	useCustom                      bool
 	evmVersion                     string
+	evmVersionConstraint           string
 	evmChainID                     uint64
 	evmToken                       string
+	evmGenesisTimestamp            string
 	evmDefaults                    bool
 	useLatestReleasedEvmVersion    bool
 	useLatestPreReleasedEvmVersion bool
@@ -47,11 +60,36 @@ var (
 	teleporterReady                bool
 	runRelayer                     bool
 	useWarp                        bool
+	txAllowListFile                string
+	deployerAllowListFile          string
+	dumpGenesis                    bool
+	evmAirdropAddress              string
+	evmAirdropAmount               string
+	airdropCSVFile                 string
+	airdropMaxSupply               string
+	evmTemplateFile                string
 
 	errIllegalNameCharacter = errors.New(
 		"illegal name character: only letters, no special characters allowed")
-	errMutuallyExlusiveVersionOptions = errors.New("version flags --latest,--pre-release,vm-version are mutually exclusive")
-	errMutuallyVMConfigOptions        = errors.New("specifying --genesis flag disables SubnetEVM config flags --evm-chain-id,--evm-token,--evm-defaults")
+	errEmptyName                      = errors.New("name cannot be empty")
+	errNameTooLong                    = fmt.Errorf("name is too long: at most %d characters allowed", txs.MaxNameLen)
+	errNameEdgeSpace                  = errors.New("name cannot start or end with a space")
+	errReservedName                   = errors.New("reserved name: it could be confused with a Primary Network chain")
+	errMutuallyExlusiveVersionOptions = errors.New("version flags --latest,--pre-release,--vm-version,--vm-version-constraint are mutually exclusive")
+	errMutuallyVMConfigOptions        = errors.New("specifying --genesis flag disables SubnetEVM config flags --evm-chain-id,--evm-token,--genesis-timestamp,--evm-defaults")
+	errMutuallyAllowListFileOptions   = errors.New("specifying --genesis flag disables SubnetEVM allow list flags --tx-allow-list-file,--deployer-allow-list-file")
+	errAllowListFileOnCustomVM        = errors.New("allow list flags --tx-allow-list-file,--deployer-allow-list-file are only supported on Subnet-EVM")
+	errMutuallyGenesisOptions         = errors.New("--genesis and --genesis-stdin are mutually exclusive")
+	errEmptyGenesisStdin              = errors.New("--genesis-stdin was given but no genesis was read from stdin")
+	errTeleporterWithoutWarp          = errors.New("warp should be enabled for teleporter to work")
+	errFromGithubRepoOnSubnetEVM      = errors.New("--from-github-repo is only supported on custom VMs")
+	errGenesisTimestampOnCustomVM     = errors.New("--genesis-timestamp is only supported on Subnet-EVM")
+	errMutuallyCustomVMRefOptions     = errors.New("--custom-vm-branch and --custom-vm-tag are mutually exclusive")
+	errMutuallyAirdropOptions         = errors.New("specifying --genesis flag disables SubnetEVM airdrop flags --evm-airdrop-address,--evm-airdrop-amount,--airdrop-csv,--airdrop-max-supply")
+	errAirdropOnCustomVM              = errors.New("airdrop flags --evm-airdrop-address,--evm-airdrop-amount,--airdrop-csv,--airdrop-max-supply are only supported on Subnet-EVM")
+	errMutuallyTemplateOptions        = errors.New("specifying --genesis flag disables SubnetEVM template flag --evm-template")
+	errTemplateOnCustomVM             = errors.New("--evm-template is only supported on Subnet-EVM")
+	errDumpGenesisOnCustomVM          = errors.New("--dump-genesis is only supported on Subnet-EVM, as custom VMs are built or copied on creation")
 )
 
 // avalanche subnet create
@@ -66,6 +104,19 @@ all the steps you need to create your first Subnet.
 The tool supports deploying Subnet-EVM, and custom VMs. You
 can create a custom, user-generated genesis with a custom VM by providing
 the path to your genesis and VM binaries with the --genesis and --vm flags.
+The genesis can also be piped into the command with the --genesis-stdin flag.
+
+To enforce custom checks on the genesis before it is written, pass an executable
+with the --validate-script flag. It receives the genesis JSON on stdin, and the
+subnet is not created if it exits with non-zero status.
+
+To reuse the same fee config, precompiles and airdrop across subnets, pass
+a Subnet-EVM template file with the --evm-template flag. Settings given by
+other flags, as the airdrop and allow list ones, take precedence over it.
+
+To preview the resulting genesis without creating the subnet, pass the
+--dump-genesis flag. The genesis is printed to stdout and nothing is saved.
+It is only supported on Subnet-EVM.
 
 By default, running the command with a subnetName that already exists
 causes the command to fail. If you’d like to overwrite an existing
@@ -75,11 +126,21 @@ configuration, pass the -f flag.`,
 		PersistentPostRun: handlePostRun,
 	}
 	cmd.Flags().StringVar(&genesisFile, "genesis", "", "file path of genesis to use")
+	cmd.Flags().BoolVar(&genesisStdin, "genesis-stdin", false, "read genesis to use from stdin")
+	cmd.Flags().StringVar(&genesisValidateScript, "validate-script", "", "executable that gets the genesis on stdin, and aborts creation if it exits with non-zero status")
+	cmd.Flags().BoolVar(&dumpGenesis, dumpGenesisFlag, false, "print the resulting genesis to stdout and exit, without saving the subnet configuration")
 	cmd.Flags().BoolVar(&useSubnetEvm, "evm", false, "use the Subnet-EVM as the base template")
 	cmd.Flags().StringVar(&evmVersion, "vm-version", "", "version of Subnet-EVM template to use")
+	cmd.Flags().StringVar(&evmVersionConstraint, "vm-version-constraint", "", "use the newest Subnet-EVM release matching a semver constraint, e.g. v0.6.x, ~v0.6.2 or >=v0.6.0,<v0.7.0")
 	cmd.Flags().Uint64Var(&evmChainID, "evm-chain-id", 0, "chain ID to use with Subnet-EVM")
 	cmd.Flags().StringVar(&evmToken, "evm-token", "", "token name to use with Subnet-EVM")
+	cmd.Flags().StringVar(&evmGenesisTimestamp, genesisTimestampFlag, "", "genesis timestamp to use with Subnet-EVM, as unix seconds or RFC3339, instead of the current time. makes genesis reproducible")
 	cmd.Flags().BoolVar(&evmDefaults, "evm-defaults", false, "use default settings for fees/airdrop/precompiles/teleporter with Subnet-EVM")
+	cmd.Flags().StringVar(&evmAirdropAddress, "evm-airdrop-address", "", "address to airdrop tokens to in the Subnet-EVM genesis, instead of prompting. defaults to a new stored key")
+	cmd.Flags().StringVar(&evmAirdropAmount, "evm-airdrop-amount", "", "amount of tokens to airdrop in the Subnet-EVM genesis, in token units, instead of prompting. defaults to 1 million")
+	cmd.Flags().StringVar(&airdropCSVFile, "airdrop-csv", "", "CSV file with the addresses to airdrop tokens to in the Subnet-EVM genesis, one address,balance per line with balances in token units")
+	cmd.Flags().StringVar(&airdropMaxSupply, "airdrop-max-supply", "", "fail if the genesis allocation from --airdrop-csv, including the teleporter key prefunding, exceeds the given amount of tokens")
+	cmd.Flags().StringVar(&evmTemplateFile, "evm-template", "", "JSON file with the fee config, precompiles and airdrop defaults to use with Subnet-EVM, instead of prompting")
 	cmd.Flags().BoolVar(&useCustom, "custom", false, "use a custom VM template")
 	cmd.Flags().BoolVar(&useLatestPreReleasedEvmVersion, preRelease, false, "use latest Subnet-EVM pre-released version, takes precedence over --vm-version")
 	cmd.Flags().BoolVar(&useLatestReleasedEvmVersion, latest, false, "use latest Subnet-EVM released version, takes precedence over --vm-version")
@@ -88,11 +149,14 @@ configuration, pass the -f flag.`,
 	cmd.Flags().StringVar(&vmFile, "custom-vm-path", "", "file path of custom vm to use")
 	cmd.Flags().StringVar(&customVMRepoURL, "custom-vm-repo-url", "", "custom vm repository url")
 	cmd.Flags().StringVar(&customVMBranch, "custom-vm-branch", "", "custom vm branch or commit")
+	cmd.Flags().StringVar(&customVMTag, "custom-vm-tag", "", "custom vm git tag to build from, checked to exist in the repository")
 	cmd.Flags().StringVar(&customVMBuildScript, "custom-vm-build-script", "", "custom vm build-script")
 	cmd.Flags().BoolVar(&useRepo, "from-github-repo", false, "generate custom VM binary from github repository")
 	cmd.Flags().BoolVar(&useWarp, "warp", true, "generate a vm with warp support (needed for teleporter)")
 	cmd.Flags().BoolVar(&teleporterReady, "teleporter", false, "generate a teleporter-ready vm")
 	cmd.Flags().BoolVar(&runRelayer, "relayer", false, "run AWM relayer when deploying the vm")
+	cmd.Flags().StringVar(&txAllowListFile, "tx-allow-list-file", "", "JSON/CSV file with the admin/manager/enabled addresses of the transaction allow list precompile")
+	cmd.Flags().StringVar(&deployerAllowListFile, "deployer-allow-list-file", "", "JSON/CSV file with the admin/manager/enabled addresses of the contract deployment allow list precompile")
 	return cmd
 }
 
@@ -111,6 +175,7 @@ func CallCreate(
 	useLatestPreReleasedEvmVersionParam bool,
 	customVMRepoURLParam string,
 	customVMBranchParam string,
+	customVMTagParam string,
 	customVMBuildScriptParam string,
 ) error {
 	forceCreate = forceCreateParam
@@ -125,13 +190,41 @@ func CallCreate(
 	useCustom = useCustomParam
 	customVMRepoURL = customVMRepoURLParam
 	customVMBranch = customVMBranchParam
+	customVMTag = customVMTagParam
 	customVMBuildScript = customVMBuildScriptParam
 	return createSubnetConfig(cmd, []string{subnetName})
 }
 
+// validateCreateFlags checks the consistency of the flags given to subnet create, so
+// that invalid combinations are rejected before any prompting
+func validateCreateFlags() error {
+	if moreThanOneVMSelected() {
+		return errors.New("too many VMs selected. Provide at most one VM selection flag")
+	}
+	if !flags.EnsureMutuallyExclusive([]bool{useLatestReleasedEvmVersion, useLatestPreReleasedEvmVersion, evmVersion != "", evmVersionConstraint != ""}) {
+		return errMutuallyExlusiveVersionOptions
+	}
+	if evmVersionConstraint != "" {
+		if _, err := vm.ParseVersionConstraint(evmVersionConstraint); err != nil {
+			return err
+		}
+	}
+	// --evm-defaults enables teleporter for Subnet-EVM
+	if (teleporterReady || evmDefaults) && !useWarp && !useCustom {
+		return errTeleporterWithoutWarp
+	}
+	if useRepo && useSubnetEvm {
+		return errFromGithubRepoOnSubnetEVM
+	}
+	if customVMBranch != "" && customVMTag != "" {
+		return errMutuallyCustomVMRefOptions
+	}
+	return nil
+}
+
 func detectVMTypeFromFlags() {
 	// assumes custom
-	if customVMRepoURL != "" || customVMBranch != "" || customVMBuildScript != "" {
+	if customVMRepoURL != "" || customVMBranch != "" || customVMTag != "" || customVMBuildScript != "" {
 		useCustom = true
 	}
 }
@@ -159,12 +252,37 @@ func getVMFromFlag() models.VMType {
 	return ""
 }
 
+// writeGenesisFromReader reads a genesis from [r] and writes it to a temporary file,
+// returning its path, so it can be used the same way as a --genesis file
+func writeGenesisFromReader(r io.Reader) (string, error) {
+	genesisBytes, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	if len(bytes.TrimSpace(genesisBytes)) == 0 {
+		return "", errEmptyGenesisStdin
+	}
+	if !json.Valid(genesisBytes) {
+		return "", errors.New("genesis read from stdin is not valid JSON")
+	}
+	genesisFile, err := os.CreateTemp("", "genesis-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer genesisFile.Close()
+	if _, err := genesisFile.Write(genesisBytes); err != nil {
+		_ = os.Remove(genesisFile.Name())
+		return "", err
+	}
+	return genesisFile.Name(), nil
+}
+
 // override postrun function from root.go, so that we don't double send metrics for the same command
 func handlePostRun(_ *cobra.Command, _ []string) {}
 
 func createSubnetConfig(cmd *cobra.Command, args []string) error {
 	subnetName := args[0]
-	if app.GenesisExists(subnetName) && !forceCreate {
+	if app.GenesisExists(subnetName) && !forceCreate && !dumpGenesis {
 		return errors.New("configuration already exists. Use --" + forceFlag + " parameter to overwrite")
 	}
 
@@ -172,20 +290,81 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("subnet name %q is invalid: %w", subnetName, err)
 	}
 
+	if genesisValidateScript != "" && !utils.IsExecutable(genesisValidateScript) {
+		return fmt.Errorf("genesis validation script %s not found or not executable", genesisValidateScript)
+	}
+
 	detectVMTypeFromFlags()
 
-	if moreThanOneVMSelected() {
-		return errors.New("too many VMs selected. Provide at most one VM selection flag")
+	if err := validateCreateFlags(); err != nil {
+		return err
 	}
 
-	if !flags.EnsureMutuallyExclusive([]bool{useLatestReleasedEvmVersion, useLatestPreReleasedEvmVersion, evmVersion != ""}) {
-		return errMutuallyExlusiveVersionOptions
+	if genesisStdin {
+		if genesisFile != "" {
+			return errMutuallyGenesisOptions
+		}
+		genesisPath, err := writeGenesisFromReader(os.Stdin)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = os.Remove(genesisPath)
+			genesisFile = ""
+		}()
+		genesisFile = genesisPath
 	}
 
-	if genesisFile != "" && (evmChainID != 0 || evmToken != "" || evmDefaults) {
+	var subnetEVMGenesisTimestamp *time.Time
+	if evmGenesisTimestamp != "" {
+		timestamp, err := vm.ParseGenesisTimestamp(evmGenesisTimestamp)
+		if err != nil {
+			return err
+		}
+		if err := vm.ValidateGenesisTimestamp(timestamp, time.Now()); err != nil {
+			return err
+		}
+		subnetEVMGenesisTimestamp = &timestamp
+	}
+
+	if genesisFile != "" && (evmChainID != 0 || evmToken != "" || subnetEVMGenesisTimestamp != nil || evmDefaults) {
 		return errMutuallyVMConfigOptions
 	}
 
+	allowListFiles := vm.AllowListFiles{
+		TxAllowListFile:       txAllowListFile,
+		DeployerAllowListFile: deployerAllowListFile,
+	}
+	usingAllowListFiles := allowListFiles.TxAllowListFile != "" || allowListFiles.DeployerAllowListFile != ""
+	if genesisFile != "" && usingAllowListFiles {
+		return errMutuallyAllowListFileOptions
+	}
+
+	airdrop := vm.AirdropFlags{
+		Address:   evmAirdropAddress,
+		Amount:    evmAirdropAmount,
+		CSVFile:   airdropCSVFile,
+		MaxSupply: airdropMaxSupply,
+	}
+	if err := airdrop.Validate(); err != nil {
+		return err
+	}
+	if genesisFile != "" && airdrop.IsSet() {
+		return errMutuallyAirdropOptions
+	}
+
+	var evmTemplate *vm.EvmTemplate
+	if evmTemplateFile != "" {
+		if genesisFile != "" {
+			return errMutuallyTemplateOptions
+		}
+		template, err := vm.LoadEvmTemplate(evmTemplateFile)
+		if err != nil {
+			return err
+		}
+		evmTemplate = template
+	}
+
 	subnetType := getVMFromFlag()
 
 	if subnetType == "" {
@@ -199,6 +378,30 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 		subnetType = models.VMTypeFromString(subnetTypeStr)
 	}
 
+	if subnetType != models.SubnetEvm && usingAllowListFiles {
+		return errAllowListFileOnCustomVM
+	}
+
+	if subnetType != models.SubnetEvm && airdrop.IsSet() {
+		return errAirdropOnCustomVM
+	}
+
+	if subnetType != models.SubnetEvm && evmTemplate != nil {
+		return errTemplateOnCustomVM
+	}
+
+	if subnetType != models.CustomVM && useRepo {
+		return errFromGithubRepoOnSubnetEVM
+	}
+
+	if subnetType != models.SubnetEvm && subnetEVMGenesisTimestamp != nil {
+		return errGenesisTimestampOnCustomVM
+	}
+
+	if subnetType != models.SubnetEvm && dumpGenesis {
+		return errDumpGenesisOnCustomVM
+	}
+
 	var (
 		genesisBytes []byte
 		sc           *models.Sidecar
@@ -218,7 +421,7 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 	}
 
 	if subnetType == models.SubnetEvm {
-		evmVersion, err = vm.GetVMVersion(app, "Subnet-EVM", constants.SubnetEVMRepoName, evmVersion)
+		evmVersion, err = vm.GetVMVersion(app, "Subnet-EVM", constants.SubnetEVMRepoName, evmVersion, evmVersionConstraint)
 		if err != nil {
 			return err
 		}
@@ -252,7 +455,7 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		if teleporterReady && !useWarp {
-			return fmt.Errorf("warp should be enabled for teleporter to work")
+			return errTeleporterWithoutWarp
 		}
 		if teleporterReady {
 			runRelayer, err = prompts.CaptureBoolFlag(
@@ -283,12 +486,17 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 			subnetName,
 			genesisFile,
 			evmVersion,
-			true,
+			// a genesis preview doesn't need the Subnet-EVM binary to be installed
+			!dumpGenesis,
 			evmChainID,
 			evmToken,
+			subnetEVMGenesisTimestamp,
 			evmDefaults,
 			useWarp,
 			teleporterInfo,
+			allowListFiles,
+			airdrop,
+			evmTemplate,
 		)
 		if err != nil {
 			return err
@@ -301,6 +509,7 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 			useRepo,
 			customVMRepoURL,
 			customVMBranch,
+			customVMTag,
 			customVMBuildScript,
 			vmFile,
 		)
@@ -325,6 +534,16 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if genesisValidateScript != "" {
+		if err := vm.RunGenesisValidationScript(genesisValidateScript, genesisBytes); err != nil {
+			return err
+		}
+	}
+
+	if dumpGenesis {
+		return writeGenesisPreview(cmd.OutOrStdout(), genesisBytes)
+	}
+
 	if err = app.WriteGenesisFile(subnetName, genesisBytes); err != nil {
 		return err
 	}
@@ -343,6 +562,17 @@ func createSubnetConfig(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// writeGenesisPreview writes the pretty printed [genesisBytes] JSON to [w]
+func writeGenesisPreview(w io.Writer, genesisBytes []byte) error {
+	var prettyJSON bytes.Buffer
+	if err := json.Indent(&prettyJSON, genesisBytes, "", "    "); err != nil {
+		return fmt.Errorf("invalid genesis JSON: %w", err)
+	}
+	prettyJSON.WriteString("\n")
+	_, err := prettyJSON.WriteTo(w)
+	return err
+}
+
 func addSubnetEVMGenesisPrefundedAddress(genesisBytes []byte, address string, balance string) ([]byte, error) {
 	var genesisMap map[string]interface{}
 	if err := json.Unmarshal(genesisBytes, &genesisMap); err != nil {
@@ -371,36 +601,52 @@ func sendMetrics(cmd *cobra.Command, repoName, subnetName string) error {
 	if err != nil {
 		return err
 	}
-	conf := genesis.Config.GenesisPrecompiles
-	precompiles := make([]string, 6)
-	for precompileName := range conf {
+	genesisStats := vm.GetGenesisStats(genesis)
+	maps.Copy(flags, getPrecompileMetricFlags(genesisStats))
+	flags[constants.NumberOfAirdrops] = strconv.Itoa(genesisStats.NumAllocations)
+	metrics.HandleTracking(cmd, constants.MetricsSubnetCreateCommand, app, flags)
+	return nil
+}
+
+// getPrecompileMetricFlags returns a precompile tag for each precompile of [genesisStats], and
+// for the custom airdrop if any, together with their sorted, deduplicated and comma separated list
+func getPrecompileMetricFlags(genesisStats vm.GenesisStats) map[string]string {
+	flags := map[string]string{}
+	precompiles := utils.Filter(genesisStats.Precompiles, func(precompileName string) bool { return precompileName != "" })
+	if genesisStats.CustomAirdrop {
+		precompiles = append(precompiles, constants.CustomAirdrop)
+	}
+	precompiles = utils.Unique(precompiles)
+	for _, precompileName := range precompiles {
 		precompileTag := "precompile-" + precompileName
 		flags[precompileTag] = precompileName
-		precompiles = append(precompiles, precompileName)
-	}
-	numAirdropAddresses := len(genesis.Alloc)
-	for address := range genesis.Alloc {
-		if address.String() != vm.PrefundedEwoqAddress.String() {
-			precompileTag := "precompile-" + constants.CustomAirdrop
-			flags[precompileTag] = constants.CustomAirdrop
-			precompiles = append(precompiles, constants.CustomAirdrop)
-			break
-		}
 	}
 	sort.Strings(precompiles)
-	precompilesJoined := strings.Join(precompiles, ",")
-	flags[constants.PrecompileType] = precompilesJoined
-	flags[constants.NumberOfAirdrops] = strconv.Itoa(numAirdropAddresses)
-	metrics.HandleTracking(cmd, constants.MetricsSubnetCreateCommand, app, flags)
-	return nil
+	flags[constants.PrecompileType] = strings.Join(precompiles, ",")
+	return flags
 }
 
+// reservedSubnetNames are names, lowercased and without spaces, that could be taken for the
+// Primary Network chains
+var reservedSubnetNames = []string{"p", "x", "c", "pchain", "xchain", "cchain", "primary", "primarynetwork"}
+
 func checkInvalidSubnetNames(name string) error {
+	switch {
+	case name == "":
+		return errEmptyName
+	case strings.TrimSpace(name) != name:
+		return errNameEdgeSpace
+	case len(name) > txs.MaxNameLen:
+		return errNameTooLong
+	}
 	// this is currently exactly the same code as in avalanchego/vms/platformvm/create_chain_tx.go
 	for _, r := range name {
 		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ') {
 			return errIllegalNameCharacter
 		}
 	}
+	if slices.Contains(reservedSubnetNames, strings.ToLower(strings.ReplaceAll(name, " ", ""))) {
+		return errReservedName
+	}
 	return nil
 }
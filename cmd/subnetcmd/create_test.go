@@ -3,8 +3,24 @@
 package subnetcmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,3 +71,252 @@ func Test_moreThanOneVMSelected(t *testing.T) {
 		})
 	}
 }
+
+func Test_writeGenesisFromReader(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, err := os.ReadFile(filepath.Join("..", "..", "tests", "e2e", "assets", "test_subnet_evm_genesis.json"))
+	require.NoError(err)
+	genesisPath, err := writeGenesisFromReader(bytes.NewReader(genesisBytes))
+	require.NoError(err)
+	defer os.Remove(genesisPath)
+	writtenBytes, err := os.ReadFile(genesisPath)
+	require.NoError(err)
+	require.Equal(genesisBytes, writtenBytes)
+	isEVM, err := utils.PathIsSubnetEVMGenesis(genesisPath)
+	require.NoError(err)
+	require.True(isEVM)
+
+	_, err = writeGenesisFromReader(strings.NewReader(""))
+	require.ErrorIs(err, errEmptyGenesisStdin)
+	_, err = writeGenesisFromReader(strings.NewReader(" \n"))
+	require.ErrorIs(err, errEmptyGenesisStdin)
+	_, err = writeGenesisFromReader(strings.NewReader("{not json"))
+	require.Error(err)
+}
+
+func Test_validateCreateFlags(t *testing.T) {
+	type test struct {
+		name            string
+		useSubnetEvm    bool
+		useCustom       bool
+		teleporterReady bool
+		evmDefaults     bool
+		useWarp         bool
+		useRepo         bool
+		customVMBranch  string
+		customVMTag     string
+		evmVersion      string
+		evmConstraint   string
+		expectedErr     error
+	}
+	tests := []test{
+		{name: "teleporter with warp", useSubnetEvm: true, teleporterReady: true, useWarp: true},
+		{name: "teleporter without warp", useSubnetEvm: true, teleporterReady: true, expectedErr: errTeleporterWithoutWarp},
+		{name: "evm defaults without warp", useSubnetEvm: true, evmDefaults: true, expectedErr: errTeleporterWithoutWarp},
+		{name: "teleporter without warp and no vm selected", teleporterReady: true, expectedErr: errTeleporterWithoutWarp},
+		{name: "no teleporter without warp", useSubnetEvm: true},
+		{name: "custom vm without warp", useCustom: true, evmDefaults: true},
+		{name: "github repo on custom vm", useCustom: true, useRepo: true, useWarp: true},
+		{name: "github repo without vm selected", useRepo: true, useWarp: true},
+		{name: "github repo on subnet evm", useSubnetEvm: true, useRepo: true, useWarp: true, expectedErr: errFromGithubRepoOnSubnetEVM},
+		{name: "custom vm tag", useCustom: true, useWarp: true, customVMTag: "v1.0.0"},
+		{name: "custom vm branch and tag", useCustom: true, useWarp: true, customVMBranch: "main", customVMTag: "v1.0.0", expectedErr: errMutuallyCustomVMRefOptions},
+		{name: "version constraint", useSubnetEvm: true, useWarp: true, evmConstraint: "~v0.6.2"},
+		{name: "version constraint and version", useSubnetEvm: true, useWarp: true, evmVersion: "v0.6.2", evmConstraint: "v0.6.x", expectedErr: errMutuallyExlusiveVersionOptions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			useSubnetEvm = tt.useSubnetEvm
+			useCustom = tt.useCustom
+			teleporterReady = tt.teleporterReady
+			evmDefaults = tt.evmDefaults
+			useWarp = tt.useWarp
+			useRepo = tt.useRepo
+			customVMBranch = tt.customVMBranch
+			customVMTag = tt.customVMTag
+			evmVersion = tt.evmVersion
+			evmVersionConstraint = tt.evmConstraint
+			defer func() {
+				useSubnetEvm, useCustom, teleporterReady, evmDefaults, useWarp, useRepo = false, false, false, false, true, false
+				customVMBranch, customVMTag, evmVersion, evmVersionConstraint = "", "", "", ""
+			}()
+			err := validateCreateFlags()
+			if tt.expectedErr == nil {
+				require.NoError(err)
+			} else {
+				require.ErrorIs(err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func Test_checkInvalidSubnetNames(t *testing.T) {
+	type test struct {
+		name        string
+		subnetName  string
+		expectedErr error
+	}
+	tests := []test{
+		{name: "valid", subnetName: "mySubnet"},
+		{name: "valid with numbers and inner spaces", subnetName: "my Subnet 2"},
+		{name: "empty", subnetName: "", expectedErr: errEmptyName},
+		{name: "leading space", subnetName: " mySubnet", expectedErr: errNameEdgeSpace},
+		{name: "trailing space", subnetName: "mySubnet ", expectedErr: errNameEdgeSpace},
+		{name: "only spaces", subnetName: "   ", expectedErr: errNameEdgeSpace},
+		{name: "max length", subnetName: strings.Repeat("a", 128)},
+		{name: "too long", subnetName: strings.Repeat("a", 129), expectedErr: errNameTooLong},
+		{name: "special character", subnetName: "my-subnet", expectedErr: errIllegalNameCharacter},
+		{name: "non ascii letter", subnetName: "subnét", expectedErr: errIllegalNameCharacter},
+		{name: "reserved chain alias", subnetName: "C", expectedErr: errReservedName},
+		{name: "reserved chain name", subnetName: "P Chain", expectedErr: errReservedName},
+		{name: "reserved primary network", subnetName: "PrimaryNetwork", expectedErr: errReservedName},
+		{name: "reserved name as prefix", subnetName: "cchainFork"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			err := checkInvalidSubnetNames(tt.subnetName)
+			if tt.expectedErr == nil {
+				require.NoError(err)
+			} else {
+				require.ErrorIs(err, tt.expectedErr)
+			}
+		})
+	}
+	require.ErrorContains(t, checkInvalidSubnetNames(strings.Repeat("a", 129)), "at most 128 characters")
+}
+
+func Test_createSubnetConfigRejectsFlagsBeforePrompting(t *testing.T) {
+	require := require.New(t)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	prompter := prompts.NewMockPrompter()
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompter, nil)
+	defer func() {
+		app = nil
+		teleporterReady, useWarp = false, true
+	}()
+
+	// flags are set to their defaults on command creation
+	cmd := newCreateCmd()
+	teleporterReady = true
+	useWarp = false
+	err := createSubnetConfig(cmd, []string{"testSubnet"})
+	require.ErrorIs(err, errTeleporterWithoutWarp)
+	require.Empty(prompter.Calls())
+}
+
+func Test_createSubnetConfigDumpGenesis(t *testing.T) {
+	require := require.New(t)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	mockDownloader := &mocks.Downloader{}
+	mockDownloader.On("Download", mock.Anything).Return([]byte(`{"rpcChainVMProtocolVersion": {"v0.9.99": 18}}`), nil)
+	mockDownloader.On("GetLatestReleaseVersion", mock.Anything).Return("v1.0.0", nil)
+	prompter := prompts.NewMockPrompter()
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompter, mockDownloader)
+	require.NoError(os.MkdirAll(app.GetKeyDir(), constants.DefaultPerms755))
+	// evm defaults enable teleporter, whose release assets are used from the local cache
+	teleporterAssetsDir := filepath.Join(app.GetTeleporterBinDir(), "v1.0.0")
+	require.NoError(os.MkdirAll(teleporterAssetsDir, constants.DefaultPerms755))
+	for _, asset := range []string{
+		"TeleporterMessenger_Contract_Address_v1.0.0.txt",
+		"TeleporterMessenger_Deployer_Address_v1.0.0.txt",
+		"TeleporterMessenger_Deployment_Transaction_v1.0.0.txt",
+		"TeleporterRegistry_Bytecode_v1.0.0.txt",
+	} {
+		require.NoError(os.WriteFile(filepath.Join(teleporterAssetsDir, asset), []byte("0x618FEdD9A45a8C456812ecAAE70C671c6249DfaC"), constants.WriteReadReadPerms))
+	}
+	defer func() {
+		app = nil
+		useSubnetEvm, evmVersion, evmChainID, evmToken, evmDefaults, dumpGenesis = false, "", 0, "", false, false
+		teleporterReady, runRelayer, evmGenesisTimestamp = false, false, ""
+	}()
+
+	cmd := newCreateCmd()
+	require.NoError(cmd.ParseFlags([]string{
+		"--evm",
+		"--vm-version", "v0.9.99",
+		"--evm-chain-id", "1234",
+		"--evm-token", "TEST",
+		"--evm-defaults",
+		"--genesis-timestamp", "1700000000",
+		"--" + dumpGenesisFlag,
+	}))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(createSubnetConfig(cmd, []string{"testSubnet"}))
+
+	genesis := core.Genesis{}
+	require.NoError(json.Unmarshal(out.Bytes(), &genesis))
+	require.Equal(uint64(1234), genesis.Config.ChainID.Uint64())
+	require.Equal(uint64(1700000000), genesis.Timestamp)
+	require.True(strings.HasPrefix(out.String(), "{\n    \""))
+	require.Empty(prompter.Calls())
+	// nothing is saved
+	require.False(app.GenesisExists("testSubnet"))
+	require.False(app.SidecarExists("testSubnet"))
+}
+
+func Test_createSubnetConfigDumpGenesisOnCustomVM(t *testing.T) {
+	require := require.New(t)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	prompter := prompts.NewMockPrompter()
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompter, nil)
+	defer func() {
+		app = nil
+		useCustom, dumpGenesis = false, false
+	}()
+
+	cmd := newCreateCmd()
+	require.NoError(cmd.ParseFlags([]string{"--custom", "--" + dumpGenesisFlag}))
+	err := createSubnetConfig(cmd, []string{"testSubnet"})
+	require.ErrorIs(err, errDumpGenesisOnCustomVM)
+	require.Empty(prompter.Calls())
+	require.NoDirExists(app.GetCustomVMDir())
+}
+
+func Test_writeGenesisPreview(t *testing.T) {
+	require := require.New(t)
+	var out bytes.Buffer
+	require.NoError(writeGenesisPreview(&out, []byte(`{"config":{"chainId":1},"alloc":{}}`)))
+	require.Equal("{\n    \"config\": {\n        \"chainId\": 1\n    },\n    \"alloc\": {}\n}\n", out.String())
+	require.Error(writeGenesisPreview(&out, []byte("{not json")))
+}
+
+func Test_getPrecompileMetricFlags(t *testing.T) {
+	require := require.New(t)
+	var genesis core.Genesis
+	require.NoError(json.Unmarshal([]byte(`{
+  "config": {
+    "chainId": 99999,
+    "warpConfig": {"blockTimestamp": 0, "quorumNumerator": 67},
+    "txAllowListConfig": {"blockTimestamp": 0, "adminAddresses": ["0x8db97c7cece249c2b98bdc0226cc4c2a57bf52fc"]}
+  },
+  "alloc": {
+    "8db97c7cece249c2b98bdc0226cc4c2a57bf52fc": {"balance": "0x1"},
+    "0x0000000000000000000000000000000000000001": {"balance": "0x1"}
+  },
+  "gasLimit": "0x7a1200",
+  "difficulty": "0x0"
+}`), &genesis))
+	flags := getPrecompileMetricFlags(vm.GetGenesisStats(genesis))
+	require.Equal(map[string]string{
+		"precompile-" + constants.CustomAirdrop: constants.CustomAirdrop,
+		"precompile-txAllowListConfig":          "txAllowListConfig",
+		"precompile-warpConfig":                 "warpConfig",
+		constants.PrecompileType:                constants.CustomAirdrop + ",txAllowListConfig,warpConfig",
+	}, flags)
+
+	// empty and duplicated entries are not reported
+	flags = getPrecompileMetricFlags(vm.GenesisStats{Precompiles: []string{"", "warpConfig", "warpConfig"}})
+	require.Equal(map[string]string{
+		"precompile-warpConfig":  "warpConfig",
+		constants.PrecompileType: "warpConfig",
+	}, flags)
+	flags = getPrecompileMetricFlags(vm.GenesisStats{})
+	require.Equal(map[string]string{constants.PrecompileType: ""}, flags)
+}
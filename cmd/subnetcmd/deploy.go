@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
 	"github.com/ava-labs/avalanche-cli/pkg/txutils"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
@@ -101,6 +102,7 @@ so you can take your locally tested Subnet and deploy it on Fuji or Mainnet.`,
 	cmd.Flags().StringVar(&teleporterEsp.MessengerDeployerAddressPath, "teleporter-messenger-deployer-address-path", "", "path to a teleporter messenger deployer address file")
 	cmd.Flags().StringVar(&teleporterEsp.MessengerDeployerTxPath, "teleporter-messenger-deployer-tx-path", "", "path to a teleporter messenger deployer tx file")
 	cmd.Flags().StringVar(&teleporterEsp.RegistryBydecodePath, "teleporter-registry-bytecode-path", "", "path to a teleporter registry bytecode file")
+	cmd.Flags().StringVar(&teleporterEsp.RelayerLogLevel, "relayer-log-level", "", "log level of the local AWM relayer (off, fatal, error, warn, info, trace, debug, verbo). Defaults to info for a new config, and keeps the current level otherwise")
 	return cmd
 }
 
@@ -274,6 +276,11 @@ func deploySubnet(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("if setting any teleporter asset path, you must set all teleporter asset paths")
 		}
 	}
+	if teleporterEsp.RelayerLogLevel != "" {
+		if err := teleporter.ValidateRelayerLogLevel(teleporterEsp.RelayerLogLevel); err != nil {
+			return err
+		}
+	}
 
 	chain := chains[0]
 
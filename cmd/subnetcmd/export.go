@@ -3,9 +3,9 @@
 package subnetcmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
@@ -19,7 +19,10 @@ var (
 	exportOutput        string
 	customVMRepoURL     string
 	customVMBranch      string
+	customVMTag         string
 	customVMBuildScript string
+	exportExcludeKeys   bool
+	exportFormat        string
 )
 
 // avalanche subnet list
@@ -30,7 +33,13 @@ func newExportCmd() *cobra.Command {
 		Long: `The subnet export command write the details of an existing Subnet deploy to a file.
 
 The command prompts for an output path. You can also provide one with
-the --output flag.`,
+the --output flag.
+
+Use --exclude-keys to strip key material (teleporter key, private keys in
+node, chain and subnet configs) from the export before sharing it.
+
+The export is written as JSON by default. Use --export-format to write it as
+YAML or TOML instead. All formats can be imported back with avalanche subnet import file.`,
 		RunE: exportSubnet,
 		Args: cobrautils.ExactArgs(1),
 	}
@@ -45,6 +54,8 @@ the --output flag.`,
 	cmd.Flags().StringVar(&customVMRepoURL, "custom-vm-repo-url", "", "custom vm repository url")
 	cmd.Flags().StringVar(&customVMBranch, "custom-vm-branch", "", "custom vm branch")
 	cmd.Flags().StringVar(&customVMBuildScript, "custom-vm-build-script", "", "custom vm build-script")
+	cmd.Flags().BoolVar(&exportExcludeKeys, "exclude-keys", false, "remove key material from the exported data")
+	cmd.Flags().StringVar(&exportFormat, "export-format", models.ExportFormatJSON, fmt.Sprintf("format of the export data [%s]", strings.Join(models.ExportFormats, ", ")))
 	return cmd
 }
 
@@ -54,7 +65,13 @@ func CallExportSubnet(subnetName, exportPath string) error {
 }
 
 func exportSubnet(_ *cobra.Command, args []string) error {
-	var err error
+	if exportFormat == "" {
+		exportFormat = models.ExportFormatJSON
+	}
+	serializer, err := models.GetExportSerializer(exportFormat)
+	if err != nil {
+		return err
+	}
 	if exportOutput == "" {
 		pathPrompt := "Enter file path to write export data to"
 		exportOutput, err = app.Prompt.CaptureString(pathPrompt)
@@ -165,8 +182,14 @@ func exportSubnet(_ *cobra.Command, args []string) error {
 		SubnetConfig:    subnetConfig,
 		NetworkUpgrades: networkUpgrades,
 	}
+	if exportExcludeKeys {
+		exportData, err = exportData.WithoutKeys()
+		if err != nil {
+			return err
+		}
+	}
 
-	exportBytes, err := json.Marshal(exportData)
+	exportBytes, err := serializer.Marshal(exportData)
 	if err != nil {
 		return err
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/internal/mocks"
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanche-cli/pkg/vm"
@@ -43,9 +44,13 @@ func TestExportImportSubnet(t *testing.T) {
 		false,
 		0,
 		"",
+		nil,
 		false,
 		false,
 		nil,
+		vm.AllowListFiles{},
+		vm.AirdropFlags{},
+		nil,
 	)
 	require.NoError(err)
 	err = app.WriteGenesisFile(testSubnet, genBytes)
@@ -97,3 +102,71 @@ func TestExportImportSubnet(t *testing.T) {
 	err = importSubnet(nil, []string{exportOutput})
 	require.NoError(err)
 }
+
+func TestExportImportSubnetFormats(t *testing.T) {
+	testDir := t.TempDir()
+	require := require.New(t)
+	testSubnet := "testSubnet"
+	vmVersion := "v0.9.99"
+	testSubnetEVMCompat := []byte("{\"rpcChainVMProtocolVersion\": {\"v0.9.99\": 18}}")
+
+	app = application.New()
+
+	mockAppDownloader := mocks.Downloader{}
+	mockAppDownloader.On("Download", mock.Anything).Return(testSubnetEVMCompat, nil)
+
+	app.Setup(testDir, logging.NoLog{}, nil, prompts.NewPrompter(), &mockAppDownloader)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	genBytes, sc, err := vm.CreateEvmSubnetConfig(
+		app,
+		testSubnet,
+		"../../"+utils.SubnetEvmGenesisPath,
+		vmVersion,
+		false,
+		0,
+		"",
+		nil,
+		false,
+		false,
+		nil,
+		vm.AllowListFiles{},
+		vm.AirdropFlags{},
+		nil,
+	)
+	require.NoError(err)
+	require.NoError(app.WriteGenesisFile(testSubnet, genBytes))
+	require.NoError(app.CreateSidecar(sc))
+	sidecarFile := filepath.Join(app.GetBaseDir(), constants.SubnetDir, testSubnet, constants.SidecarFileName)
+	genFile := filepath.Join(app.GetBaseDir(), constants.SubnetDir, testSubnet, constants.GenesisFileName)
+	origSidecar, err := app.LoadSidecar(testSubnet)
+	require.NoError(err)
+
+	defer func() {
+		exportOutput = ""
+		exportFormat = ""
+		app = nil
+	}()
+	for _, format := range models.ExportFormats {
+		// no extension, so the import detects the format from the content
+		for _, fileName := range []string{testSubnet + "." + format, testSubnet + "-" + format} {
+			exportOutput = filepath.Join(testDir, fileName)
+			exportFormat = format
+			require.NoError(exportSubnet(nil, []string{testSubnet}), fileName)
+			require.FileExists(exportOutput)
+
+			require.NoError(os.Remove(sidecarFile))
+			require.NoError(os.Remove(genFile))
+			require.NoError(importSubnet(nil, []string{exportOutput}), fileName)
+
+			importedSidecar, err := app.LoadSidecar(testSubnet)
+			require.NoError(err)
+			require.Equal(origSidecar, importedSidecar, fileName)
+			importedGenesis, err := app.LoadRawGenesis(testSubnet)
+			require.NoError(err)
+			require.Equal(genBytes, importedGenesis, fileName)
+		}
+	}
+
+	exportFormat = "xml"
+	require.ErrorContains(exportSubnet(nil, []string{testSubnet}), "invalid export format")
+}
@@ -3,7 +3,6 @@
 package subnetcmd
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -110,8 +109,9 @@ func importFromFile(importPath string) error {
 		return err
 	}
 
-	importable := models.Exportable{}
-	err = json.Unmarshal(importFileBytes, &importable)
+	// the format is given by the file extension, or detected from the content
+	importFormat, _ := models.ExportFormatFromPath(importPath)
+	importable, err := models.UnmarshalExportable(importFileBytes, importFormat)
 	if err != nil {
 		return err
 	}
@@ -129,8 +129,8 @@ func importFromFile(importPath string) error {
 		if importable.Sidecar.CustomVMRepoURL == "" {
 			return fmt.Errorf("repository url must be defined for custom vm import")
 		}
-		if importable.Sidecar.CustomVMBranch == "" {
-			return fmt.Errorf("repository branch must be defined for custom vm import")
+		if importable.Sidecar.CustomVMBranch == "" && importable.Sidecar.CustomVMTag == "" {
+			return fmt.Errorf("repository branch or tag must be defined for custom vm import")
 		}
 		if importable.Sidecar.CustomVMBuildScript == "" {
 			return fmt.Errorf("build script must be defined for custom vm import")
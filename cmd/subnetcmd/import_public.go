@@ -4,10 +4,13 @@ package subnetcmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
@@ -15,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/vm"
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/coreth/core"
 	"github.com/spf13/cobra"
@@ -37,7 +41,11 @@ func newImportPublicCmd() *cobra.Command {
 
 The genesis file should be available from the disk for this to work. By default, an imported Subnet
 doesn't overwrite an existing Subnet with the same name. To allow overwrites, provide the --force
-flag.`,
+flag.
+
+If the URL of a node tracking the Subnet is given with --node-url, the blockchain and its VM version
+are taken from the node, and Subnet-EVM blockchains are detected by their genesis and their eth RPC
+unless --evm or --custom is given. Any other blockchain is imported as a custom VM.`,
 	}
 
 	networkoptions.AddNetworkFlagsToCmd(cmd, &globalNetworkFlags, false, importPublicSupportedNetworkOptions)
@@ -88,14 +96,24 @@ func importPublic(*cobra.Command, []string) error {
 			if err != nil {
 				return err
 			}
-			ctx, cancel := utils.GetAPIContext()
-			defer cancel()
-			infoAPI := info.NewClient(nodeURL)
-			options := []rpc.Option{}
-			reply, err = infoAPI.GetNodeVersion(ctx, options...)
-			if err != nil {
-				return fmt.Errorf("failed to query node - is it running and reachable? %w", err)
-			}
+		}
+	}
+	nodeURL = strings.TrimSuffix(nodeURL, "/")
+	if nodeURL != "" {
+		ctx, cancel := utils.GetAPIContext()
+		defer cancel()
+		infoAPI := info.NewClient(nodeURL)
+		options := []rpc.Option{}
+		reply, err = infoAPI.GetNodeVersion(ctx, options...)
+		if err != nil {
+			return fmt.Errorf("failed to query node - is it running and reachable? %w", err)
+		}
+		nodeNetworkID, err := infoAPI.GetNetworkID(ctx, options...)
+		if err != nil {
+			return fmt.Errorf("failed to query node network: %w", err)
+		}
+		if nodeNetworkID != network.ID {
+			return fmt.Errorf("node %s is on network ID %d, not on %s", nodeURL, nodeNetworkID, network.Name())
 		}
 	}
 
@@ -112,9 +130,14 @@ func importPublic(*cobra.Command, []string) error {
 		}
 	}
 
+	// a node tracking the subnet is queried instead of the public API, if given
+	endpoint := network.Endpoint
+	if nodeURL != "" {
+		endpoint = nodeURL
+	}
 	ux.Logger.PrintToUser("Getting information from the %s network...", network.Name())
 
-	createChainTx, err := utils.GetBlockchainTx(network.Endpoint, blockchainID)
+	createChainTx, err := utils.GetBlockchainTx(endpoint, blockchainID)
 	if err != nil {
 		return err
 	}
@@ -134,6 +157,18 @@ func importPublic(*cobra.Command, []string) error {
 	// In this case, an import could clash because the tool supports unique names only
 
 	vmType := getVMFromFlag()
+	var evmChainID string
+	if vmType == "" && nodeURL != "" {
+		evmChainID, err = getSubnetEVMChainID(fmt.Sprintf("%s/ext/bc/%s/rpc", nodeURL, blockchainID), genBytes)
+		if err == nil {
+			vmType = models.SubnetEvm
+		} else {
+			ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+				"Blockchain %s is not a Subnet-EVM (%s), importing it as a custom VM", blockchainID, err,
+			)))
+			vmType = models.CustomVM
+		}
+	}
 	if vmType == "" {
 		subnetTypeStr, err := app.Prompt.CaptureList(
 			"What's this VM's type?",
@@ -167,12 +202,7 @@ func importPublic(*cobra.Command, []string) error {
 
 	if reply != nil {
 		// a node was queried
-		for _, v := range reply.VMVersions {
-			if v == vmIDstr {
-				sc.VMVersion = v
-				break
-			}
-		}
+		sc.VMVersion = reply.VMVersions[vmIDstr]
 		sc.RPCVersion = int(reply.RPCProtocolVersion)
 	} else {
 		// no node was queried, ask the user
@@ -196,7 +226,10 @@ func importPublic(*cobra.Command, []string) error {
 			return fmt.Errorf("failed getting RPCVersion for VM type %s with version %s", vmType, sc.VMVersion)
 		}
 	}
-	if vmType == models.SubnetEvm {
+	switch {
+	case evmChainID != "":
+		sc.ChainID = evmChainID
+	case vmType == models.SubnetEvm:
 		var genesis core.Genesis
 		if err := json.Unmarshal(genBytes, &genesis); err != nil {
 			return err
@@ -213,6 +246,36 @@ func importPublic(*cobra.Command, []string) error {
 	}
 
 	ux.Logger.PrintToUser("Subnet %q imported successfully", sc.Name)
+	if vmType == models.CustomVM {
+		ux.Logger.PrintToUser("To deploy it, copy the binary of VM %s to %s", vmIDstr, app.GetCustomVMPath(subnetName))
+	}
 
 	return nil
 }
+
+// getSubnetEVMChainID returns the EVM chain ID of the blockchain served at [rpcURL], if both
+// [genesisData] is a Subnet-EVM genesis and the blockchain answers to eth RPC with its chain ID
+func getSubnetEVMChainID(rpcURL string, genesisData []byte) (string, error) {
+	genesis, err := utils.ByteSliceToSubnetEvmGenesis(genesisData)
+	if err != nil {
+		return "", fmt.Errorf("genesis is not a Subnet-EVM genesis: %w", err)
+	}
+	if genesis.Config == nil || genesis.Config.ChainID == nil {
+		return "", errors.New("genesis has no EVM chain ID")
+	}
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	ctx, cancel := utils.GetAPIContext()
+	defer cancel()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return "", fmt.Errorf("no eth RPC at %s: %w", rpcURL, err)
+	}
+	if chainID.Cmp(genesis.Config.ChainID) != 0 {
+		return "", fmt.Errorf("eth RPC chain ID %s differs from genesis chain ID %s", chainID, genesis.Config.ChainID)
+	}
+	return chainID.String(), nil
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnetcmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	avagoconstants "github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+// newStubNodeServer returns a server answering the node API calls used by subnet import public,
+// for a network [networkID] with the blockchains of [createChainTxs]. Blockchains in
+// [evmChainIDs] answer eth_chainId with the given hex chain ID
+func newStubNodeServer(
+	t *testing.T,
+	networkID uint32,
+	createChainTxs map[ids.ID]*txs.CreateChainTx,
+	evmChainIDs map[ids.ID]string,
+) *httptest.Server {
+	require := require.New(t)
+	txsHex := map[string]string{}
+	vmVersions := map[string]string{}
+	for blockchainID, createChainTx := range createChainTxs {
+		tx := txs.Tx{Unsigned: createChainTx}
+		txBytes, err := txs.Codec.Marshal(txs.CodecVersion, &tx)
+		require.NoError(err)
+		txsHex[blockchainID.String()], err = formatting.Encode(formatting.Hex, txBytes)
+		require.NoError(err)
+		vmVersions[createChainTx.VMID.String()] = "v0.6.5"
+	}
+	results := map[string]func(params json.RawMessage) interface{}{
+		"info.getNetworkID": func(json.RawMessage) interface{} {
+			return map[string]interface{}{"networkID": strconv.FormatUint(uint64(networkID), 10)}
+		},
+		"info.getNodeVersion": func(json.RawMessage) interface{} {
+			return map[string]interface{}{"version": "avalanchego/1.11.8", "rpcProtocolVersion": "35", "vmVersions": vmVersions}
+		},
+		"platform.getTx": func(params json.RawMessage) interface{} {
+			var args struct {
+				TxID string `json:"txID"`
+			}
+			require.NoError(json.Unmarshal(params, &args))
+			return map[string]interface{}{"tx": txsHex[args.TxID], "encoding": "hex"}
+		},
+	}
+	mux := http.NewServeMux()
+	serveJSONRPC := func(w http.ResponseWriter, r *http.Request, result func(method string, params json.RawMessage) (interface{}, bool)) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		require.NoError(json.NewDecoder(r.Body).Decode(&request))
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+		if reply, ok := result(request.Method, request.Params); ok {
+			response["result"] = reply
+		} else {
+			response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(json.NewEncoder(w).Encode(response))
+	}
+	nodeAPI := func(w http.ResponseWriter, r *http.Request) {
+		serveJSONRPC(w, r, func(method string, params json.RawMessage) (interface{}, bool) {
+			if result, ok := results[method]; ok {
+				return result(params), true
+			}
+			return nil, false
+		})
+	}
+	mux.HandleFunc("/ext/info", nodeAPI)
+	mux.HandleFunc("/ext/P", nodeAPI)
+	for blockchainID, evmChainID := range evmChainIDs {
+		evmChainID := evmChainID
+		mux.HandleFunc("/ext/bc/"+blockchainID.String()+"/rpc", func(w http.ResponseWriter, r *http.Request) {
+			serveJSONRPC(w, r, func(method string, _ json.RawMessage) (interface{}, bool) {
+				return evmChainID, method == "eth_chainId"
+			})
+		})
+	}
+	return httptest.NewServer(mux)
+}
+
+func newCreateChainTx(name string, vmID ids.ID, genesis []byte) *txs.CreateChainTx {
+	return &txs.CreateChainTx{
+		BaseTx:      txs.BaseTx{BaseTx: avax.BaseTx{NetworkID: 12345}},
+		SubnetID:    ids.GenerateTestID(),
+		ChainName:   name,
+		VMID:        vmID,
+		GenesisData: genesis,
+		SubnetAuth:  &secp256k1fx.Input{},
+	}
+}
+
+func Test_importPublicFromNode(t *testing.T) {
+	require := require.New(t)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	prompter := prompts.NewMockPrompter()
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompter, nil)
+	defer func() {
+		app = nil
+		overwriteImport, useCustom = false, false
+		nodeURL, blockchainIDstr = "", ""
+		globalNetworkFlags = networkoptions.NetworkFlags{}
+	}()
+
+	evmBlockchainID, customBlockchainID := ids.GenerateTestID(), ids.GenerateTestID()
+	evmVMID, customVMID := ids.GenerateTestID(), ids.GenerateTestID()
+	evmGenesis := []byte(`{"config":{"chainId":12345},"alloc":{},"gasLimit":"0x7a1200","difficulty":"0x0"}`)
+	createChainTxs := map[ids.ID]*txs.CreateChainTx{
+		evmBlockchainID:    newCreateChainTx("evmSubnet", evmVMID, evmGenesis),
+		customBlockchainID: newCreateChainTx("customSubnet", customVMID, []byte("custom genesis")),
+	}
+	server := newStubNodeServer(t, avagoconstants.FujiID, createChainTxs, map[ids.ID]string{evmBlockchainID: "0x3039"})
+	defer server.Close()
+	globalNetworkFlags = networkoptions.NetworkFlags{UseFuji: true}
+	networkName := models.NewFujiNetwork().Name()
+
+	// a Subnet-EVM is detected from the genesis and the eth RPC of the node
+	nodeURL, blockchainIDstr = server.URL, evmBlockchainID.String()
+	require.NoError(importPublic(nil, nil))
+	sc, err := app.LoadSidecar("evmSubnet")
+	require.NoError(err)
+	require.Equal(models.VMType(models.SubnetEvm), sc.VM)
+	require.Equal("12345", sc.ChainID)
+	require.Equal("v0.6.5", sc.VMVersion)
+	require.Equal(35, sc.RPCVersion)
+	require.Equal(evmVMID.String(), sc.ImportedVMID)
+	require.Equal(models.NetworkData{
+		SubnetID:     createChainTxs[evmBlockchainID].SubnetID,
+		BlockchainID: evmBlockchainID,
+	}, sc.Networks[networkName])
+	genesis, err := app.LoadRawGenesis("evmSubnet")
+	require.NoError(err)
+	require.Equal(evmGenesis, genesis)
+
+	// a blockchain without eth RPC is imported as a custom VM
+	blockchainIDstr = customBlockchainID.String()
+	require.NoError(importPublic(nil, nil))
+	sc, err = app.LoadSidecar("customSubnet")
+	require.NoError(err)
+	require.Equal(models.VMType(models.CustomVM), sc.VM)
+	require.Empty(sc.ChainID)
+	require.Equal("v0.6.5", sc.VMVersion)
+	require.Equal(customVMID.String(), sc.ImportedVMID)
+	genesis, err = app.LoadRawGenesis("customSubnet")
+	require.NoError(err)
+	require.Equal([]byte("custom genesis"), genesis)
+
+	// the VM type flags skip the detection
+	useCustom = true
+	blockchainIDstr = evmBlockchainID.String()
+	require.NoError(importPublic(nil, nil))
+	sc, err = app.LoadSidecar("evmSubnet")
+	require.NoError(err)
+	require.Equal(models.VMType(models.CustomVM), sc.VM)
+	require.Empty(prompter.Calls())
+
+	// nodes on another network are rejected
+	globalNetworkFlags = networkoptions.NetworkFlags{UseMainnet: true}
+	require.ErrorContains(importPublic(nil, nil), "not on Mainnet")
+}
@@ -6,8 +6,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
@@ -15,29 +17,45 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/subnet-evm/params"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
-var statsSupportedNetworkOptions = []networkoptions.NetworkOption{networkoptions.Fuji, networkoptions.Mainnet}
+var (
+	statsSupportedNetworkOptions = []networkoptions.NetworkOption{networkoptions.Fuji, networkoptions.Mainnet}
+	statsGenesis                 bool
+)
 
 // avalanche subnet stats
 func newStatsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "stats [subnetName]",
 		Short: "Show validator statistics for the given subnet",
-		Long:  `The subnet stats command prints validator statistics for the given Subnet.`,
-		Args:  cobrautils.ExactArgs(1),
-		RunE:  stats,
+		Long: `The subnet stats command prints validator statistics for the given Subnet.
+
+With --genesis, it instead prints a summary of the Subnet genesis: chain ID,
+prefunded supply, allocations, enabled precompiles and fee config.`,
+		Args: cobrautils.ExactArgs(1),
+		RunE: stats,
 	}
 	networkoptions.AddNetworkFlagsToCmd(cmd, &globalNetworkFlags, false, statsSupportedNetworkOptions)
+	cmd.Flags().BoolVar(&statsGenesis, "genesis", false, "show genesis statistics instead of validator statistics")
 	return cmd
 }
 
 func stats(_ *cobra.Command, args []string) error {
+	if statsGenesis {
+		chains, err := ValidateSubnetNameAndGetChains(args)
+		if err != nil {
+			return err
+		}
+		return printGenesisStats(chains[0])
+	}
 	network, err := networkoptions.GetNetworkFromCmdLineFlags(
 		app,
 		"",
@@ -85,6 +103,66 @@ func stats(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+func printGenesisStats(subnetName string) error {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	if sc.VM != models.SubnetEvm {
+		ux.Logger.PrintToUser("Subnet %s uses a non-EVM VM (%s), genesis stats unavailable", subnetName, sc.VM)
+		return nil
+	}
+	genesis, err := app.LoadEvmGenesis(subnetName)
+	if err != nil {
+		return err
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"genesis stat", "value"})
+	table.SetAutoWrapText(false)
+	table.AppendBulk(buildGenesisStatsRows(vm.GetGenesisStats(genesis), sc.TokenSymbol))
+	table.Render()
+	return nil
+}
+
+func buildGenesisStatsRows(genesisStats vm.GenesisStats, tokenSymbol string) [][]string {
+	chainID := ""
+	if genesisStats.ChainID != nil {
+		chainID = genesisStats.ChainID.String()
+	}
+	precompiles := "none"
+	if len(genesisStats.Precompiles) > 0 {
+		precompiles = strings.Join(genesisStats.Precompiles, ", ")
+	}
+	feeConfig := genesisStats.FeeConfig
+	return [][]string{
+		{"chain ID", chainID},
+		{"allocations", strconv.Itoa(genesisStats.NumAllocations)},
+		{"prefunded supply", fmt.Sprintf("%s %s", formatWei(genesisStats.TotalSupply), tokenSymbol)},
+		{"precompiles", precompiles},
+		{"gas limit", bigIntString(feeConfig.GasLimit)},
+		{"target block rate", strconv.FormatUint(feeConfig.TargetBlockRate, 10)},
+		{"min base fee", bigIntString(feeConfig.MinBaseFee)},
+		{"target gas", bigIntString(feeConfig.TargetGas)},
+		{"base fee change denominator", bigIntString(feeConfig.BaseFeeChangeDenominator)},
+		{"min block gas cost", bigIntString(feeConfig.MinBlockGasCost)},
+		{"max block gas cost", bigIntString(feeConfig.MaxBlockGasCost)},
+		{"block gas cost step", bigIntString(feeConfig.BlockGasCostStep)},
+	}
+}
+
+// formatWei formats [amount] of wei in units of 10^18
+func formatWei(amount *big.Int) string {
+	formatted := new(big.Rat).SetFrac(amount, big.NewInt(params.Ether)).FloatString(18)
+	return strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+}
+
+func bigIntString(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+	return n.String()
+}
+
 func buildCurrentValidatorStats(pClient platformvm.Client, infoClient info.Client, table *tablewriter.Table, subnetID ids.ID) ([][]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -4,15 +4,18 @@ package subnetcmd
 
 import (
 	"io"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ava-labs/avalanche-cli/internal/mocks"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/olekukonko/tablewriter"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -71,3 +74,31 @@ func TestStats(t *testing.T) {
 	require.Equal(remaining, rows[0][3])
 	require.Equal(expectedVerStr, rows[0][4])
 }
+
+func TestBuildGenesisStatsRows(t *testing.T) {
+	require := require.New(t)
+
+	totalSupply, ok := new(big.Int).SetString("1000000500000000000000000", 10)
+	require.True(ok)
+	rows := buildGenesisStatsRows(vm.GenesisStats{
+		ChainID:        big.NewInt(99999),
+		NumAllocations: 2,
+		TotalSupply:    totalSupply,
+		Precompiles:    []string{"txAllowListConfig", "warpConfig"},
+		FeeConfig:      commontype.FeeConfig{GasLimit: big.NewInt(8000000)},
+	}, "TEST")
+	rowsMap := map[string]string{}
+	for _, row := range rows {
+		rowsMap[row[0]] = row[1]
+	}
+	require.Equal("99999", rowsMap["chain ID"])
+	require.Equal("2", rowsMap["allocations"])
+	require.Equal("1000000.5 TEST", rowsMap["prefunded supply"])
+	require.Equal("txAllowListConfig, warpConfig", rowsMap["precompiles"])
+	require.Equal("8000000", rowsMap["gas limit"])
+	require.Equal("", rowsMap["min base fee"])
+
+	rows = buildGenesisStatsRows(vm.GenesisStats{TotalSupply: big.NewInt(0), Precompiles: []string{}}, "TEST")
+	require.Contains(rows, []string{"precompiles", "none"})
+	require.Contains(rows, []string{"prefunded supply", "0 TEST"})
+}
@@ -4,16 +4,23 @@ package teleportercmd
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	cmdflags "github.com/ava-labs/avalanche-cli/cmd/flags"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
 	"github.com/ava-labs/avalanche-cli/pkg/localnet"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
 	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/olekukonko/tablewriter"
 
 	"github.com/spf13/cobra"
 )
@@ -21,6 +28,7 @@ import (
 type DeployFlags struct {
 	Network                      networkoptions.NetworkFlags
 	SubnetName                   string
+	SubnetNames                  []string
 	BlockchainID                 string
 	CChain                       bool
 	KeyName                      string
@@ -34,6 +42,8 @@ type DeployFlags struct {
 	MessengerDeployerTxPath      string
 	RegistryBydecodePath         string
 	PrivateKeyFlags              contract.PrivateKeyFlags
+	UseLedger                    bool
+	LedgerIndex                  uint32
 }
 
 const (
@@ -46,6 +56,7 @@ var (
 		networkoptions.Local,
 		networkoptions.Devnet,
 		networkoptions.Fuji,
+		networkoptions.Mainnet,
 	}
 	deployFlags DeployFlags
 )
@@ -60,8 +71,11 @@ func newDeployCmd() *cobra.Command {
 		Args:  cobrautils.ExactArgs(0),
 	}
 	networkoptions.AddNetworkFlagsToCmd(cmd, &deployFlags.Network, true, deploySupportedNetworkOptions)
-	contract.AddPrivateKeyFlagsToCmd(cmd, &msgFlags.PrivateKeyFlags, "to fund teleporter deploy")
+	contract.AddPrivateKeyFlagsToCmd(cmd, &deployFlags.PrivateKeyFlags, "to fund teleporter deploy")
+	cmd.Flags().BoolVar(&deployFlags.UseLedger, "ledger", false, "use ledger to fund teleporter deploy (always true on mainnet)")
+	cmd.Flags().Uint32Var(&deployFlags.LedgerIndex, "ledger-index", 0, "ledger address index to use when funding with ledger")
 	cmd.Flags().StringVar(&deployFlags.SubnetName, "subnet", "", "deploy teleporter into the given CLI subnet")
+	cmd.Flags().StringSliceVar(&deployFlags.SubnetNames, "subnets", []string{}, "deploy teleporter into each of the given comma separated CLI subnets. subnets not deployed to the network are skipped")
 	cmd.Flags().StringVar(&deployFlags.BlockchainID, "blockchain-id", "", "deploy teleporter into the given blockchain ID/Alias")
 	cmd.Flags().BoolVar(&deployFlags.CChain, "c-chain", false, "deploy teleporter into C-Chain")
 	cmd.Flags().BoolVar(&deployFlags.DeployMessenger, "deploy-messenger", true, "deploy Teleporter Messenger")
@@ -92,12 +106,15 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 	if err != nil {
 		return err
 	}
-	if !cmdflags.EnsureMutuallyExclusive([]bool{flags.SubnetName != "", flags.BlockchainID != "", flags.CChain}) {
-		return fmt.Errorf("--subnet, --blockchain-id and --cchain are mutually exclusive flags")
+	if !cmdflags.EnsureMutuallyExclusive([]bool{flags.SubnetName != "", len(flags.SubnetNames) > 0, flags.BlockchainID != "", flags.CChain}) {
+		return fmt.Errorf("--subnet, --subnets, --blockchain-id and --cchain are mutually exclusive flags")
 	}
 	if !flags.DeployMessenger && !flags.DeployRegistry {
 		return fmt.Errorf("you should set at least one of --deploy-messenger/--deploy-registry to true")
 	}
+	if len(flags.SubnetNames) > 0 {
+		return deployToSubnets(network, flags)
+	}
 	if flags.SubnetName == "" && flags.BlockchainID == "" && !flags.CChain {
 		// fill flags based on user prompts
 		blockchainIDOptions := []string{
@@ -132,6 +149,24 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		}
 	}
 
+	td, _, err := deployToBlockchain(network, flags)
+	if err != nil {
+		return err
+	}
+	return deployToLocalCChain(network, flags, td)
+}
+
+// teleporterDeployResult holds the teleporter contracts of a blockchain
+type teleporterDeployResult struct {
+	alreadyDeployed  bool
+	messengerAddress string
+	registryAddress  string
+}
+
+// deployToBlockchain deploys teleporter into the blockchain selected by [flags], and
+// updates the subnet sidecar if it is a CLI subnet. Returns the deployer with the
+// teleporter assets that were used
+func deployToBlockchain(network models.Network, flags DeployFlags) (*teleporter.Deployer, teleporterDeployResult, error) {
 	var (
 		blockchainID         string
 		teleporterSubnetDesc string
@@ -143,24 +178,24 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		teleporterSubnetDesc = flags.SubnetName
 		sc, err := app.LoadSidecar(flags.SubnetName)
 		if err != nil {
-			return fmt.Errorf("failed to load sidecar: %w", err)
+			return nil, teleporterDeployResult{}, fmt.Errorf("failed to load sidecar: %w", err)
 		}
 		if b, _, err := app.HasSubnetEVMGenesis(flags.SubnetName); err != nil {
-			return err
+			return nil, teleporterDeployResult{}, err
 		} else if !b {
-			return fmt.Errorf("only Subnet-EVM based vms can be used for teleporter")
+			return nil, teleporterDeployResult{}, fmt.Errorf("only Subnet-EVM based vms can be used for teleporter")
 		}
 		if sc.Networks[network.Name()].BlockchainID == ids.Empty {
-			return fmt.Errorf("subnet has not been deployed to %s", network.Name())
+			return nil, teleporterDeployResult{}, fmt.Errorf("subnet has not been deployed to %s", network.Name())
 		}
 		blockchainID = sc.Networks[network.Name()].BlockchainID.String()
 		if sc.TeleporterVersion != "" {
 			teleporterVersion = sc.TeleporterVersion
 		}
-		if sc.TeleporterKey != "" {
+		if sc.TeleporterKey != "" && !flags.UseLedger && network.Kind != models.Mainnet {
 			k, err := app.GetKey(sc.TeleporterKey, network, true)
 			if err != nil {
-				return err
+				return nil, teleporterDeployResult{}, err
 			}
 			privateKey = k.PrivKeyHex()
 		}
@@ -171,44 +206,61 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		teleporterSubnetDesc = cChainName
 		blockchainID = cChainAlias
 	}
-	genesisAddress, genesisPrivateKey, err := contract.GetEVMSubnetPrefundedKey(
-		app,
-		network,
-		flags.SubnetName,
-		flags.CChain,
-		flags.BlockchainID,
-	)
+	useLedger, err := deployUsesLedger(network, flags)
 	if err != nil {
-		return err
+		return nil, teleporterDeployResult{}, err
 	}
-	if privateKey == "" {
-		privateKey, err = contract.GetPrivateKeyFromFlags(
+	var signer *evm.Signer
+	if useLedger {
+		signer, err = evm.NewLedgerSigner(flags.LedgerIndex)
+		if err != nil {
+			return nil, teleporterDeployResult{}, fmt.Errorf("failure connecting to ledger: %w", err)
+		}
+		ux.Logger.PrintToUser("Using ledger address %s to fund teleporter deploy", signer.Address().Hex())
+	} else {
+		genesisAddress, genesisPrivateKey, err := contract.GetEVMSubnetPrefundedKey(
 			app,
-			deployFlags.PrivateKeyFlags,
-			genesisPrivateKey,
+			network,
+			flags.SubnetName,
+			flags.CChain,
+			flags.BlockchainID,
 		)
 		if err != nil {
-			return err
+			return nil, teleporterDeployResult{}, err
 		}
 		if privateKey == "" {
-			privateKey, err = prompts.PromptPrivateKey(
-				app.Prompt,
-				"deploy teleporter",
-				app.GetKeyDir(),
-				app.GetKey,
-				genesisAddress,
+			privateKey, err = contract.GetPrivateKeyFromFlags(
+				app,
+				flags.PrivateKeyFlags,
 				genesisPrivateKey,
 			)
 			if err != nil {
-				return err
+				return nil, teleporterDeployResult{}, err
+			}
+			if privateKey == "" {
+				privateKey, err = prompts.PromptPrivateKey(
+					app.Prompt,
+					"deploy teleporter",
+					app.GetKeyDir(),
+					app.GetKey,
+					genesisAddress,
+					genesisPrivateKey,
+				)
+				if err != nil {
+					return nil, teleporterDeployResult{}, err
+				}
 			}
 		}
+		signer, err = evm.NewSignerFromPrivateKey(privateKey)
+		if err != nil {
+			return nil, teleporterDeployResult{}, err
+		}
 	}
 	switch {
 	case flags.MessengerContractAddressPath != "" || flags.MessengerDeployerAddressPath != "" || flags.MessengerDeployerTxPath != "" || flags.RegistryBydecodePath != "":
 		teleporterVersion = ""
 		if flags.MessengerContractAddressPath == "" || flags.MessengerDeployerAddressPath == "" || flags.MessengerDeployerTxPath == "" || flags.RegistryBydecodePath == "" {
-			return fmt.Errorf("if setting any teleporter asset path, you must set all teleporter asset paths")
+			return nil, teleporterDeployResult{}, fmt.Errorf("if setting any teleporter asset path, you must set all teleporter asset paths")
 		}
 	case flags.Version != "" && flags.Version != "latest":
 		teleporterVersion = flags.Version
@@ -216,7 +268,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 	default:
 		teleporterInfo, err := teleporter.GetInfo(app)
 		if err != nil {
-			return err
+			return nil, teleporterDeployResult{}, err
 		}
 		teleporterVersion = teleporterInfo.Version
 	}
@@ -233,31 +285,31 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 			flags.MessengerDeployerTxPath,
 			flags.RegistryBydecodePath,
 		); err != nil {
-			return err
+			return nil, teleporterDeployResult{}, err
 		}
 	} else {
 		if err := td.DownloadAssets(
 			app.GetTeleporterBinDir(),
 			teleporterVersion,
 		); err != nil {
-			return err
+			return nil, teleporterDeployResult{}, err
 		}
 	}
 	alreadyDeployed, teleporterMessengerAddress, teleporterRegistryAddress, err := td.Deploy(
 		teleporterSubnetDesc,
 		rpcURL,
-		privateKey,
+		signer,
 		flags.DeployMessenger,
 		flags.DeployRegistry,
 	)
 	if err != nil {
-		return err
+		return nil, teleporterDeployResult{}, err
 	}
 	if flags.SubnetName != "" && !alreadyDeployed {
 		// update sidecar
 		sc, err := app.LoadSidecar(flags.SubnetName)
 		if err != nil {
-			return fmt.Errorf("failed to load sidecar: %w", err)
+			return nil, teleporterDeployResult{}, fmt.Errorf("failed to load sidecar: %w", err)
 		}
 		sc.TeleporterReady = true
 		sc.TeleporterVersion = teleporterVersion
@@ -270,19 +322,33 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		}
 		sc.Networks[network.Name()] = networkInfo
 		if err := app.UpdateSidecar(&sc); err != nil {
-			return err
+			return nil, teleporterDeployResult{}, err
 		}
 	}
+	return &td, teleporterDeployResult{
+		alreadyDeployed:  alreadyDeployed,
+		messengerAddress: teleporterMessengerAddress,
+		registryAddress:  teleporterRegistryAddress,
+	}, nil
+}
+
+// deployToLocalCChain deploys teleporter into C-Chain for local and devnet networks,
+// using the assets of [td], unless C-Chain was the target of the deploy
+func deployToLocalCChain(network models.Network, flags DeployFlags, td *teleporter.Deployer) error {
 	// automatic deploy to cchain for local/devnet
 	if !flags.CChain && (network.Kind == models.Local || network.Kind == models.Devnet) {
 		ewoq, err := app.GetKey("ewoq", network, false)
 		if err != nil {
 			return err
 		}
+		ewoqSigner, err := evm.NewSignerFromPrivateKey(ewoq.PrivKeyHex())
+		if err != nil {
+			return err
+		}
 		alreadyDeployed, teleporterMessengerAddress, teleporterRegistryAddress, err := td.Deploy(
 			cChainName,
 			network.BlockchainEndpoint(cChainAlias),
-			ewoq.PrivKeyHex(),
+			ewoqSigner,
 			flags.DeployMessenger,
 			flags.DeployRegistry,
 		)
@@ -314,3 +380,152 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 	}
 	return nil
 }
+
+// deployUsesLedger decides if the teleporter deploy is going to be funded by a
+// ledger device. Mainnet always requires ledger usage
+func deployUsesLedger(network models.Network, flags DeployFlags) (bool, error) {
+	privateKeyFlagsSet := flags.PrivateKeyFlags.PrivateKey != "" ||
+		flags.PrivateKeyFlags.KeyName != "" ||
+		flags.PrivateKeyFlags.GenesisKey
+	if flags.UseLedger && privateKeyFlagsSet {
+		return false, fmt.Errorf("--ledger is mutually exclusive with --private-key, --key and --genesis-key")
+	}
+	if network.Kind == models.Mainnet {
+		if privateKeyFlagsSet {
+			return false, fmt.Errorf("mainnet teleporter deploy requires ledger usage")
+		}
+		return true, nil
+	}
+	return flags.UseLedger, nil
+}
+
+// subnetDeployResult is the outcome of deploying teleporter into a CLI subnet
+type subnetDeployResult struct {
+	subnetName string
+	skipErr    error // reason the subnet was skipped, if it was
+	err        error
+	teleporterDeployResult
+}
+
+func (r subnetDeployResult) status() string {
+	switch {
+	case r.skipErr != nil:
+		return "skipped: " + r.skipErr.Error()
+	case r.err != nil:
+		return "failed: " + r.err.Error()
+	case r.alreadyDeployed:
+		return "already deployed"
+	default:
+		return "deployed"
+	}
+}
+
+// deployToSubnetList deploys teleporter into each subnet of [subnetNames] using [deployFunc].
+// Subnets rejected by [checkDeployable] are skipped with a warning, and a failure on
+// a subnet doesn't prevent the deploy into the next ones
+func deployToSubnetList(
+	subnetNames []string,
+	checkDeployable func(subnetName string) error,
+	deployFunc func(subnetName string) (teleporterDeployResult, error),
+) []subnetDeployResult {
+	results := []subnetDeployResult{}
+	for _, subnetName := range subnetNames {
+		if err := checkDeployable(subnetName); err != nil {
+			ux.Logger.PrintToUser(logging.Yellow.Wrap("Warning: skipping subnet %s: %s"), subnetName, err)
+			results = append(results, subnetDeployResult{subnetName: subnetName, skipErr: err})
+			continue
+		}
+		deployResult, err := deployFunc(subnetName)
+		if err != nil {
+			ux.Logger.RedXToUser("Teleporter deploy into subnet %s failed: %s", subnetName, err)
+		}
+		results = append(results, subnetDeployResult{
+			subnetName:             subnetName,
+			err:                    err,
+			teleporterDeployResult: deployResult,
+		})
+	}
+	return results
+}
+
+// printSubnetDeploySummary prints a table with the teleporter contracts of each subnet
+func printSubnetDeploySummary(w io.Writer, results []subnetDeployResult) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"subnet", "status", "messenger address", "registry address"})
+	table.SetRowLine(true)
+	for _, result := range results {
+		table.Append([]string{result.subnetName, result.status(), result.messengerAddress, result.registryAddress})
+	}
+	table.Render()
+}
+
+// checkSubnetTeleporterDeployable checks that [subnetName] is a Subnet-EVM subnet deployed to [network]
+func checkSubnetTeleporterDeployable(network models.Network, subnetName string) error {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return fmt.Errorf("failed to load sidecar: %w", err)
+	}
+	if sc.Networks[network.Name()].BlockchainID == ids.Empty {
+		return fmt.Errorf("not deployed to %s", network.Name())
+	}
+	if isEVM, _, err := app.HasSubnetEVMGenesis(subnetName); err != nil {
+		return err
+	} else if !isEVM {
+		return fmt.Errorf("only Subnet-EVM based vms can be used for teleporter")
+	}
+	return nil
+}
+
+// deployToSubnets deploys teleporter into every subnet given with --subnets, and then
+// into C-Chain for local and devnet networks
+func deployToSubnets(network models.Network, flags DeployFlags) error {
+	var td *teleporter.Deployer
+	results := deployToSubnetList(
+		flags.SubnetNames,
+		func(subnetName string) error {
+			return checkSubnetTeleporterDeployable(network, subnetName)
+		},
+		func(subnetName string) (teleporterDeployResult, error) {
+			ux.Logger.PrintToUser("Deploying teleporter into subnet %s", subnetName)
+			subnetFlags := flags
+			subnetFlags.SubnetNames = nil
+			subnetFlags.SubnetName = subnetName
+			subnetTd, deployResult, err := deployToBlockchain(network, subnetFlags)
+			if err != nil {
+				return teleporterDeployResult{}, err
+			}
+			td = subnetTd
+			if deployResult.alreadyDeployed {
+				// addresses of previous deploys are kept on the sidecar
+				sc, err := app.LoadSidecar(subnetName)
+				if err != nil {
+					return teleporterDeployResult{}, fmt.Errorf("failed to load sidecar: %w", err)
+				}
+				if deployResult.messengerAddress == "" {
+					deployResult.messengerAddress = sc.Networks[network.Name()].TeleporterMessengerAddress
+				}
+				if deployResult.registryAddress == "" {
+					deployResult.registryAddress = sc.Networks[network.Name()].TeleporterRegistryAddress
+				}
+			}
+			return deployResult, nil
+		},
+	)
+	ux.Logger.PrintToUser("")
+	printSubnetDeploySummary(os.Stdout, results)
+	if td != nil {
+		if err := deployToLocalCChain(network, flags, td); err != nil {
+			return err
+		}
+	}
+	failedSubnets := []string{}
+	for _, result := range results {
+		if result.err != nil {
+			failedSubnets = append(failedSubnets, result.subnetName)
+		}
+	}
+	if len(failedSubnets) > 0 {
+		return fmt.Errorf("failed to deploy teleporter into subnet(s) %s", strings.Join(failedSubnets, ", "))
+	}
+	return nil
+}
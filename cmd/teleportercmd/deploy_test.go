@@ -0,0 +1,146 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleportercmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployUsesLedger(t *testing.T) {
+	require := require.New(t)
+	type test struct {
+		name        string
+		network     models.Network
+		flags       DeployFlags
+		useLedger   bool
+		expectError bool
+	}
+
+	tests := []test{
+		{
+			name:      "fuji without key flags",
+			network:   models.NewFujiNetwork(),
+			flags:     DeployFlags{},
+			useLedger: false,
+		},
+		{
+			name:      "fuji with ledger",
+			network:   models.NewFujiNetwork(),
+			flags:     DeployFlags{UseLedger: true},
+			useLedger: true,
+		},
+		{
+			name:    "fuji with stored key",
+			network: models.NewFujiNetwork(),
+			flags: DeployFlags{
+				PrivateKeyFlags: contract.PrivateKeyFlags{KeyName: "key"},
+			},
+			useLedger: false,
+		},
+		{
+			name:    "ledger and private key",
+			network: models.NewFujiNetwork(),
+			flags: DeployFlags{
+				UseLedger:       true,
+				PrivateKeyFlags: contract.PrivateKeyFlags{PrivateKey: "key"},
+			},
+			expectError: true,
+		},
+		{
+			name:      "mainnet defaults to ledger",
+			network:   models.NewMainnetNetwork(),
+			flags:     DeployFlags{},
+			useLedger: true,
+		},
+		{
+			name:    "mainnet with genesis key",
+			network: models.NewMainnetNetwork(),
+			flags: DeployFlags{
+				PrivateKeyFlags: contract.PrivateKeyFlags{GenesisKey: true},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		useLedger, err := deployUsesLedger(tt.network, tt.flags)
+		if tt.expectError {
+			require.Error(err, tt.name)
+		} else {
+			require.NoError(err, tt.name)
+			require.Equal(tt.useLedger, useLedger, tt.name)
+		}
+	}
+}
+
+func TestDeployToSubnetList(t *testing.T) {
+	require := require.New(t)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+
+	errNotDeployed := errors.New("not deployed to Local Network")
+	deployedSubnets := map[string]teleporterDeployResult{
+		"subnetA": {messengerAddress: "0xMessengerA", registryAddress: "0xRegistryA"},
+		"subnetC": {alreadyDeployed: true, messengerAddress: "0xMessengerC", registryAddress: "0xRegistryC"},
+	}
+	deployCalls := []string{}
+	results := deployToSubnetList(
+		[]string{"subnetA", "subnetB", "subnetC"},
+		func(subnetName string) error {
+			if _, ok := deployedSubnets[subnetName]; !ok {
+				return errNotDeployed
+			}
+			return nil
+		},
+		func(subnetName string) (teleporterDeployResult, error) {
+			deployCalls = append(deployCalls, subnetName)
+			return deployedSubnets[subnetName], nil
+		},
+	)
+	// the skipped subnet doesn't abort the deploy into the next ones
+	require.Equal([]string{"subnetA", "subnetC"}, deployCalls)
+	require.Equal([]subnetDeployResult{
+		{subnetName: "subnetA", teleporterDeployResult: deployedSubnets["subnetA"]},
+		{subnetName: "subnetB", skipErr: errNotDeployed},
+		{subnetName: "subnetC", teleporterDeployResult: deployedSubnets["subnetC"]},
+	}, results)
+	require.Equal("deployed", results[0].status())
+	require.Equal("skipped: not deployed to Local Network", results[1].status())
+	require.Equal("already deployed", results[2].status())
+
+	var summary bytes.Buffer
+	printSubnetDeploySummary(&summary, results)
+	for _, expected := range []string{"subnetA", "0xMessengerA", "0xRegistryA", "subnetB", "skipped", "subnetC", "0xMessengerC", "0xRegistryC"} {
+		require.Contains(summary.String(), expected)
+	}
+}
+
+func TestDeployToSubnetListFailure(t *testing.T) {
+	require := require.New(t)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+
+	deployErr := errors.New("rpc unreachable")
+	results := deployToSubnetList(
+		[]string{"subnetA", "subnetB"},
+		func(string) error { return nil },
+		func(subnetName string) (teleporterDeployResult, error) {
+			if subnetName == "subnetA" {
+				return teleporterDeployResult{}, deployErr
+			}
+			return teleporterDeployResult{messengerAddress: "0xMessengerB"}, nil
+		},
+	)
+	require.Len(results, 2)
+	require.ErrorIs(results[0].err, deployErr)
+	require.Equal("failed: rpc unreachable", results[0].status())
+	require.NoError(results[1].err)
+	require.Equal("0xMessengerB", results[1].messengerAddress)
+}
@@ -18,6 +18,8 @@ import (
 type AddSubnetToServiceFlags struct {
 	Network     networkoptions.NetworkFlags
 	CloudNodeID string
+	LogLevel    string
+	LogFormat   string
 }
 
 var (
@@ -36,6 +38,8 @@ func newAddSubnetToServiceCmd() *cobra.Command {
 	}
 	networkoptions.AddNetworkFlagsToCmd(cmd, &addSubnetToServiceFlags.Network, true, addSubnetToServiceSupportedNetworkOptions)
 	cmd.Flags().StringVar(&addSubnetToServiceFlags.CloudNodeID, "cloud-node-id", "", "generate a config to be used on given cloud node")
+	cmd.Flags().StringVar(&addSubnetToServiceFlags.LogLevel, "relayer-log-level", "", "log level of the relayer (off, fatal, error, warn, info, trace, debug, verbo). Defaults to info for a new config, and keeps the current level otherwise")
+	cmd.Flags().StringVar(&addSubnetToServiceFlags.LogFormat, "log-format", teleporter.RelayerLogFormatJSON, "log format of the relayer (auto, plain, colors, json). The relayer only writes json logs")
 	return cmd
 }
 
@@ -44,6 +48,16 @@ func addSubnetToService(_ *cobra.Command, args []string) error {
 }
 
 func CallAddSubnetToService(subnetName string, flags AddSubnetToServiceFlags) error {
+	if flags.LogLevel != "" {
+		if err := teleporter.ValidateRelayerLogLevel(flags.LogLevel); err != nil {
+			return err
+		}
+	}
+	if flags.LogFormat != "" {
+		if err := teleporter.ValidateRelayerLogFormat(flags.LogFormat); err != nil {
+			return err
+		}
+	}
 	network, err := networkoptions.GetNetworkFromCmdLineFlags(
 		app,
 		"",
@@ -83,6 +97,7 @@ func CallAddSubnetToService(subnetName string, flags AddSubnetToServiceFlags) er
 	if err = teleporter.UpdateRelayerConfig(
 		configPath,
 		app.GetAWMRelayerServiceStorageDir(storageBasePath),
+		flags.LogLevel,
 		relayerAddress,
 		relayerPrivateKey,
 		network,
@@ -102,6 +117,7 @@ func CallAddSubnetToService(subnetName string, flags AddSubnetToServiceFlags) er
 	if err = teleporter.UpdateRelayerConfig(
 		configPath,
 		app.GetAWMRelayerServiceStorageDir(storageBasePath),
+		flags.LogLevel,
 		relayerAddress,
 		relayerPrivateKey,
 		network,
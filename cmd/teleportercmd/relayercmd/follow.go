@@ -0,0 +1,152 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package relayercmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+)
+
+const (
+	localLogsFollowInterval  = 500 * time.Millisecond
+	remoteLogsFollowInterval = 5 * time.Second
+)
+
+// relayerLogTail splits data appended to a log into complete lines,
+// keeping a trailing partial line until its newline arrives
+type relayerLogTail struct {
+	// offset is the position in the log up to which data was consumed
+	offset  int64
+	partial string
+}
+
+// feed consumes [data] appended at the current offset and returns the new complete lines
+func (t *relayerLogTail) feed(data []byte) []string {
+	t.offset += int64(len(data))
+	content := t.partial + string(data)
+	lines := strings.Split(content, "\n")
+	t.partial = lines[len(lines)-1]
+	return lines[:len(lines)-1]
+}
+
+// reset restarts the tail at the beginning of the log, used when it was truncated
+func (t *relayerLogTail) reset() {
+	t.offset = 0
+	t.partial = ""
+}
+
+// readLogFrom returns the content of [logPath] after [offset]. It also
+// indicates if the log is now smaller than [offset], that is, it was truncated
+func readLogFrom(logPath string, offset int64) ([]byte, bool, error) {
+	f, err := os.Open(logPath)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+	if info.Size() < offset {
+		return nil, true, nil
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+	data, err := io.ReadAll(f)
+	return data, false, err
+}
+
+// followRelayerLogs polls the relayer log for appended lines and outputs them
+// until interrupted. Remote relayer logs are downloaded on each poll
+func followRelayerLogs(network models.Network, blockchainIDToSubnetName map[string]string) error {
+	var (
+		logsPath string
+		interval = localLogsFollowInterval
+		download = func() error { return nil }
+	)
+	switch {
+	case logFile != "":
+		logsPath = logFile
+	case network.Kind == models.Local:
+		logsPath = app.GetAWMRelayerLogPath()
+	case network.ClusterName != "":
+		host, err := node.GetAWMRelayerHost(app, network.ClusterName)
+		if err != nil {
+			return err
+		}
+		if host == nil {
+			return fmt.Errorf("no relayer host found on cluster %s", network.ClusterName)
+		}
+		defer host.Disconnect()
+		tmpFile, err := os.CreateTemp("", "avalanchecli-awm-relayer-*.log")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmpFile.Name())
+		if err := tmpFile.Close(); err != nil {
+			return err
+		}
+		logsPath = tmpFile.Name()
+		interval = remoteLogsFollowInterval
+		download = func() error {
+			return ssh.RunSSHDownloadAWMRelayerLogs(host, logsPath)
+		}
+	default:
+		return fmt.Errorf("relayer logs on %s require either --cluster or --log-file", network.Name())
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// start at the current end of the log, so only new lines are shown
+	tail := relayerLogTail{}
+	if err := download(); err != nil {
+		return err
+	}
+	data, _, err := readLogFrom(logsPath, 0)
+	if err != nil {
+		return err
+	}
+	tail.feed(data)
+	ux.Logger.PrintToUser("Following relayer logs. Press Ctrl-C to stop")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if err := download(); err != nil {
+			return err
+		}
+		data, truncated, err := readLogFrom(logsPath, tail.offset)
+		if err != nil {
+			return err
+		}
+		if truncated {
+			tail.reset()
+			continue
+		}
+		logLines := tail.feed(data)
+		if len(logLines) == 0 {
+			continue
+		}
+		if err := outputLogLines(logLines, blockchainIDToSubnetName); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package relayercmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayerLogTailFeed(t *testing.T) {
+	require := require.New(t)
+	tail := relayerLogTail{}
+
+	require.Empty(tail.feed([]byte(`{"msg":"first"}`)))
+	require.Equal([]string{`{"msg":"first"}`}, tail.feed([]byte("\n")))
+	require.Equal([]string{`{"msg":"second"}`}, tail.feed([]byte(`{"msg":"second"}`+"\n"+`{"msg":"thi`)))
+	require.Equal([]string{`{"msg":"third"}`, `{"msg":"fourth"}`}, tail.feed([]byte(`rd"}`+"\n"+`{"msg":"fourth"}`+"\n")))
+	require.Empty(tail.feed(nil))
+	require.Equal(int64(len(`{"msg":"first"}`+"\n"+`{"msg":"second"}`+"\n"+`{"msg":"third"}`+"\n"+`{"msg":"fourth"}`+"\n")), tail.offset)
+}
+
+func TestReadLogFromGrowingFile(t *testing.T) {
+	require := require.New(t)
+	logPath := filepath.Join(t.TempDir(), "relayer.log")
+	require.NoError(os.WriteFile(logPath, []byte("line1\nline"), constants.WriteReadReadPerms))
+
+	tail := relayerLogTail{}
+	data, truncated, err := readLogFrom(logPath, tail.offset)
+	require.NoError(err)
+	require.False(truncated)
+	require.Equal([]string{"line1"}, tail.feed(data))
+
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, constants.WriteReadReadPerms)
+	require.NoError(err)
+	_, err = f.WriteString("2\nline3\n")
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	data, truncated, err = readLogFrom(logPath, tail.offset)
+	require.NoError(err)
+	require.False(truncated)
+	require.Equal([]string{"line2", "line3"}, tail.feed(data))
+
+	require.NoError(os.WriteFile(logPath, []byte("new\n"), constants.WriteReadReadPerms))
+	_, truncated, err = readLogFrom(logPath, tail.offset)
+	require.NoError(err)
+	require.True(truncated)
+	tail.reset()
+	data, _, err = readLogFrom(logPath, tail.offset)
+	require.NoError(err)
+	require.Equal([]string{"new"}, tail.feed(data))
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
 	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -24,10 +26,17 @@ import (
 )
 
 var (
-	logsNetworkOptions = []networkoptions.NetworkOption{networkoptions.Local}
-	raw                bool
-	last               uint
-	first              uint
+	logsNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Cluster,
+		networkoptions.Fuji,
+		networkoptions.Mainnet,
+	}
+	raw     bool
+	last    uint
+	first   uint
+	logFile string
+	follow  bool
 )
 
 // avalanche teleporter relayer logs
@@ -35,53 +44,66 @@ func newLogsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "logs",
 		Short: "shows pretty formatted AWM relayer logs",
-		Long:  "Shows pretty formatted AWM relayer logs",
-		RunE:  logs,
-		Args:  cobrautils.ExactArgs(0),
+		Long: `Shows pretty formatted AWM relayer logs.
+
+For local networks, shows the logs of the relayer running on localhost.
+For clusters, fetches the logs of the relayer running on the cluster relayer node.
+Alternatively, --log-file can be used to pretty print any AWM relayer JSON log file.
+
+With --follow, new log lines are shown as they are appended, until Ctrl-C is pressed.`,
+		RunE: logs,
+		Args: cobrautils.ExactArgs(0),
 	}
 	networkoptions.AddNetworkFlagsToCmd(cmd, &globalNetworkFlags, true, logsNetworkOptions)
 	cmd.Flags().BoolVar(&raw, "raw", false, "raw logs output")
 	cmd.Flags().UintVar(&last, "last", 0, "output last N log lines")
 	cmd.Flags().UintVar(&first, "first", 0, "output first N log lines")
+	cmd.Flags().StringVar(&logFile, "log-file", "", "pretty print the given AWM relayer JSON log file")
+	cmd.Flags().BoolVar(&follow, "follow", false, "keep showing new log lines as they are appended")
 	return cmd
 }
 
 func logs(_ *cobra.Command, _ []string) error {
-	network, err := networkoptions.GetNetworkFromCmdLineFlags(
-		app,
-		"",
-		globalNetworkFlags,
-		false,
-		false,
-		logsNetworkOptions,
-		"",
-	)
-	if err != nil {
-		return err
+	if follow && (first != 0 || last != 0) {
+		return fmt.Errorf("--first and --last can't be used with --follow")
 	}
-	var logLines []string
-	switch {
-	case network.Kind == models.Local:
-		logsPath := app.GetAWMRelayerLogPath()
-		bs, err := os.ReadFile(logsPath)
+	network := models.UndefinedNetwork
+	if logFile == "" || networkFlagsSet(globalNetworkFlags) {
+		var err error
+		network, err = networkoptions.GetNetworkFromCmdLineFlags(
+			app,
+			"",
+			globalNetworkFlags,
+			false,
+			false,
+			logsNetworkOptions,
+			"",
+		)
 		if err != nil {
 			return err
 		}
-		logs := string(bs)
-		logLines = strings.Split(logs, "\n")
-	default:
-		return fmt.Errorf("unsupported network")
 	}
-	if first != 0 {
-		if len(logLines) > int(first) {
-			logLines = logLines[:first]
+	blockchainIDToSubnetName := map[string]string{}
+	if !raw && network != models.UndefinedNetwork {
+		var err error
+		blockchainIDToSubnetName, err = getBlockchainIDToSubnetNameMap(network)
+		if err != nil {
+			return err
 		}
 	}
-	if last != 0 {
-		if len(logLines) > int(last) {
-			logLines = logLines[len(logLines)-1-int(last):]
-		}
+	if follow {
+		return followRelayerLogs(network, blockchainIDToSubnetName)
+	}
+	logLines, err := getRelayerLogLines(network)
+	if err != nil {
+		return err
 	}
+	logLines = filterLogLines(logLines, first, last)
+	return outputLogLines(logLines, blockchainIDToSubnetName)
+}
+
+// outputLogLines prints [logLines] either raw or pretty formatted
+func outputLogLines(logLines []string, blockchainIDToSubnetName map[string]string) error {
 	if raw {
 		for _, logLine := range logLines {
 			logLine = strings.TrimSpace(logLine)
@@ -91,10 +113,73 @@ func logs(_ *cobra.Command, _ []string) error {
 		}
 		return nil
 	}
-	blockchainIDToSubnetName, err := getBlockchainIDToSubnetNameMap(network)
+	return printLogLines(logLines, blockchainIDToSubnetName)
+}
+
+func networkFlagsSet(networkFlags networkoptions.NetworkFlags) bool {
+	return networkFlags.UseLocal ||
+		networkFlags.UseDevnet ||
+		networkFlags.UseFuji ||
+		networkFlags.UseMainnet ||
+		networkFlags.ClusterName != ""
+}
+
+// getRelayerLogLines obtains the relayer log lines either from --log-file,
+// from the local relayer, or from the relayer host of a cluster
+func getRelayerLogLines(network models.Network) ([]string, error) {
+	var logsPath string
+	switch {
+	case logFile != "":
+		logsPath = logFile
+	case network.Kind == models.Local:
+		logsPath = app.GetAWMRelayerLogPath()
+	case network.ClusterName != "":
+		host, err := node.GetAWMRelayerHost(app, network.ClusterName)
+		if err != nil {
+			return nil, err
+		}
+		if host == nil {
+			return nil, fmt.Errorf("no relayer host found on cluster %s", network.ClusterName)
+		}
+		defer host.Disconnect()
+		tmpFile, err := os.CreateTemp("", "avalanchecli-awm-relayer-*.log")
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(tmpFile.Name())
+		if err := tmpFile.Close(); err != nil {
+			return nil, err
+		}
+		if err := ssh.RunSSHDownloadAWMRelayerLogs(host, tmpFile.Name()); err != nil {
+			return nil, err
+		}
+		logsPath = tmpFile.Name()
+	default:
+		return nil, fmt.Errorf("relayer logs on %s require either --cluster or --log-file", network.Name())
+	}
+	bs, err := os.ReadFile(logsPath)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return strings.Split(string(bs), "\n"), nil
+}
+
+func filterLogLines(logLines []string, first uint, last uint) []string {
+	if first != 0 {
+		if len(logLines) > int(first) {
+			logLines = logLines[:first]
+		}
 	}
+	if last != 0 {
+		if len(logLines) > int(last) {
+			logLines = logLines[len(logLines)-1-int(last):]
+		}
+	}
+	return logLines
+}
+
+// printLogLines pretty prints a set of AWM relayer JSON log lines as a table
+func printLogLines(logLines []string, blockchainIDToSubnetName map[string]string) error {
 	t := table.NewWriter()
 	t.AppendHeader(table.Row{"", "Time", "Chain", "Log"})
 	for _, logLine := range logLines {
@@ -103,7 +188,9 @@ func logs(_ *cobra.Command, _ []string) error {
 			logMap := map[string]interface{}{}
 			err := json.Unmarshal([]byte(logLine), &logMap)
 			if err != nil {
-				return err
+				// not a relayer JSON log entry, as a panic trace. show it as is
+				t.AppendRow(table.Row{"", "", "", wordwrap.WrapString(logLine, 80)})
+				continue
 			}
 			levelEmoji := ""
 			levelStr, b := logMap["level"].(string)
@@ -148,7 +235,6 @@ func logs(_ *cobra.Command, _ []string) error {
 		}
 	}
 	fmt.Println(t.Render())
-
 	return nil
 }
 
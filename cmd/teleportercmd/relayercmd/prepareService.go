@@ -4,6 +4,7 @@ package relayercmd
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"os"
 	"os/user"
@@ -18,7 +19,9 @@ import (
 //go:embed awm-relayer.service
 var awmRelayerServiceTemplate []byte
 
-// avalanche teleporter msg
+var forcePrepareService bool
+
+// avalanche teleporter relayer prepareService
 func newPrepareServiceCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "prepareService",
@@ -27,10 +30,23 @@ func newPrepareServiceCmd() *cobra.Command {
 		RunE:  prepareService,
 		Args:  cobrautils.ExactArgs(0),
 	}
+	cmd.Flags().BoolVar(&forcePrepareService, "force", false, "overwrite an existing AWM relayer service configuration")
 	return cmd
 }
 
 func prepareService(_ *cobra.Command, _ []string) error {
+	return callPrepareService(forcePrepareService)
+}
+
+func callPrepareService(force bool) error {
+	awmRelayerConfigPath := app.GetAWMRelayerServiceConfigPath("")
+	if !force {
+		if fileInfo, err := os.Stat(awmRelayerConfigPath); err == nil && fileInfo.Size() > 0 {
+			return fmt.Errorf("AWM relayer service config %s already exists. use --force to overwrite it", awmRelayerConfigPath)
+		} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
 	relayerBin, err := teleporter.InstallRelayer(app.GetAWMRelayerBinDir())
 	if err != nil {
 		return err
@@ -43,9 +59,9 @@ func prepareService(_ *cobra.Command, _ []string) error {
 		return err
 	}
 	awmRelayerServicePath := filepath.Join(app.GetAWMRelayerServiceDir(""), "awm-relayer.service")
-	awmRelayerServiceConf := fmt.Sprintf(string(awmRelayerServiceTemplate), usr.Username, usr.HomeDir, relayerBin, app.GetAWMRelayerServiceConfigPath(""))
+	awmRelayerServiceConf := fmt.Sprintf(string(awmRelayerServiceTemplate), usr.Username, usr.HomeDir, relayerBin, awmRelayerConfigPath)
 	if err := os.WriteFile(awmRelayerServicePath, []byte(awmRelayerServiceConf), constants.WriteReadReadPerms); err != nil {
 		return err
 	}
-	return os.RemoveAll(app.GetAWMRelayerServiceConfigPath(""))
+	return os.RemoveAll(awmRelayerConfigPath)
 }
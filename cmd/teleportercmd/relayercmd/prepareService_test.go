@@ -0,0 +1,30 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package relayercmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareServicePreservesExistingConfig(t *testing.T) {
+	require := require.New(t)
+	app = application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, nil, nil)
+
+	configPath := app.GetAWMRelayerServiceConfigPath("")
+	configContent := []byte(`{"log-level": "info"}`)
+	require.NoError(os.MkdirAll(app.GetAWMRelayerServiceDir(""), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(configPath, configContent, constants.WriteReadReadPerms))
+
+	err := callPrepareService(false)
+	require.ErrorContains(err, "--force")
+	bs, err := os.ReadFile(configPath)
+	require.NoError(err)
+	require.Equal(configContent, bs)
+}
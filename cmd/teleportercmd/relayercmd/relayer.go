@@ -22,8 +22,10 @@ and configuring an AWM relayer on localhost.`,
 	app = injectedApp
 	cmd.AddCommand(newPrepareServiceCmd())
 	cmd.AddCommand(newAddSubnetToServiceCmd())
+	cmd.AddCommand(newRemoveSubnetFromServiceCmd())
 	cmd.AddCommand(newStopCmd())
 	cmd.AddCommand(newStartCmd())
 	cmd.AddCommand(newLogsCmd())
+	cmd.AddCommand(newValidateCmd())
 	return cmd
 }
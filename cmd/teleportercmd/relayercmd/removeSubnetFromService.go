@@ -0,0 +1,146 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package relayercmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/spf13/cobra"
+)
+
+type RemoveSubnetFromServiceFlags struct {
+	Network     networkoptions.NetworkFlags
+	CloudNodeID string
+}
+
+var removeSubnetFromServiceFlags RemoveSubnetFromServiceFlags
+
+// avalanche teleporter relayer removeSubnetFromService
+func newRemoveSubnetFromServiceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "removeSubnetFromService [subnetName]",
+		Short: "Removes a subnet from the AWM relayer service configuration",
+		Long: `Removes a subnet from the AWM relayer service configuration, so that the relayer
+no longer relays messages from or to it. If the relayer is running, it is restarted
+to apply the change.`,
+		RunE: removeSubnetFromService,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &removeSubnetFromServiceFlags.Network, true, addSubnetToServiceSupportedNetworkOptions)
+	cmd.Flags().StringVar(&removeSubnetFromServiceFlags.CloudNodeID, "cloud-node-id", "", "update the config used on given cloud node")
+	return cmd
+}
+
+func removeSubnetFromService(_ *cobra.Command, args []string) error {
+	return CallRemoveSubnetFromService(args[0], removeSubnetFromServiceFlags)
+}
+
+func CallRemoveSubnetFromService(subnetName string, flags RemoveSubnetFromServiceFlags) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		flags.Network,
+		true,
+		false,
+		addSubnetToServiceSupportedNetworkOptions,
+		subnetName,
+	)
+	if err != nil {
+		return err
+	}
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return fmt.Errorf("failed to load sidecar: %w", err)
+	}
+	blockchainID := sc.Networks[network.Name()].BlockchainID
+	if blockchainID == ids.Empty {
+		return fmt.Errorf("subnet %s is not deployed to %s", subnetName, network.Name())
+	}
+
+	configBasePath := ""
+	if flags.CloudNodeID != "" {
+		configBasePath = app.GetNodeInstanceDirPath(flags.CloudNodeID)
+	}
+	configPath := app.GetAWMRelayerServiceConfigPath(configBasePath)
+	if !utils.FileExists(configPath) {
+		return fmt.Errorf("there is no AWM relayer service configuration at %s", configPath)
+	}
+	ux.Logger.PrintToUser("updating configuration file %s", configPath)
+	removed, err := teleporter.RemoveChainFromRelayerConfig(configPath, blockchainID.String())
+	if err != nil {
+		return err
+	}
+	if !removed {
+		ux.Logger.PrintToUser("Subnet %s (blockchain %s) is not in the relayer configuration. Nothing to do", subnetName, blockchainID)
+		return nil
+	}
+	ux.Logger.GreenCheckmarkToUser("Subnet %s removed from the relayer configuration", subnetName)
+
+	switch {
+	case flags.CloudNodeID != "" && network.ClusterName != "":
+		host, err := node.GetHostWithCloudID(app, network.ClusterName, flags.CloudNodeID)
+		if err != nil {
+			return err
+		}
+		if err := ssh.RunSSHUploadNodeAWMRelayerConfig(host, configBasePath); err != nil {
+			return err
+		}
+		if err := ssh.RunSSHRestartAWMRelayerService(host); err != nil {
+			return err
+		}
+		ux.Logger.GreenCheckmarkToUser("Remote AWM Relayer on %s updated", flags.CloudNodeID)
+	case flags.CloudNodeID == "" && network.Kind == models.Local:
+		return restartLocalRelayer(blockchainID)
+	}
+	return nil
+}
+
+// restartLocalRelayer removes [blockchainID] from the config of the local relayer, restarting
+// it so that it stops relaying the blockchain. Nothing is done if the local relayer is not running
+func restartLocalRelayer(blockchainID ids.ID) error {
+	relayerIsUp, _, _, err := teleporter.RelayerIsUp(app.GetAWMRelayerRunPath())
+	if err != nil {
+		return err
+	}
+	if !relayerIsUp {
+		return nil
+	}
+	b, relayerConfigPath, err := subnet.GetAWMRelayerConfigPath()
+	if err != nil {
+		return err
+	}
+	if !b {
+		return nil
+	}
+	if _, err := teleporter.RemoveChainFromRelayerConfig(relayerConfigPath, blockchainID.String()); err != nil {
+		return err
+	}
+	if err := teleporter.RelayerCleanup(
+		app.GetAWMRelayerRunPath(),
+		app.GetAWMRelayerStorageDir(),
+	); err != nil {
+		return err
+	}
+	if err := teleporter.DeployRelayer(
+		app.GetAWMRelayerBinDir(),
+		relayerConfigPath,
+		app.GetAWMRelayerLogPath(),
+		app.GetAWMRelayerRunPath(),
+		app.GetAWMRelayerStorageDir(),
+	); err != nil {
+		return err
+	}
+	ux.Logger.GreenCheckmarkToUser("Local AWM Relayer restarted")
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package relayercmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+
+	"github.com/spf13/cobra"
+)
+
+const relayerEndpointCheckTimeout = 5 * time.Second
+
+var (
+	validateNetworkOptions = []networkoptions.NetworkOption{networkoptions.Local, networkoptions.Cluster}
+	relayerConfigPath      string
+	skipEndpointsCheck     bool
+)
+
+// avalanche teleporter relayer validate
+func newValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "validates AWM relayer config",
+		Long: `Validates an AWM relayer config, reporting every problem found together
+with the config field it relates to.
+
+Validates the config given by --config, or the config of the relayer of the
+specified network (Currently only for local network, cluster). For clusters,
+the config is fetched from the relayer node, and its endpoints are not checked
+as they are only reachable from the node.`,
+		RunE: validate,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &globalNetworkFlags, true, validateNetworkOptions)
+	cmd.Flags().StringVar(&relayerConfigPath, "config", "", "validate the given AWM relayer config file")
+	cmd.Flags().BoolVar(&skipEndpointsCheck, "skip-endpoints-check", false, "do not check that the config API and RPC endpoints are reachable")
+	return cmd
+}
+
+func validate(_ *cobra.Command, _ []string) error {
+	configPath := relayerConfigPath
+	checkEndpoints := !skipEndpointsCheck
+	if configPath == "" || networkFlagsSet(globalNetworkFlags) {
+		if configPath != "" {
+			return fmt.Errorf("--config can't be used together with network flags")
+		}
+		network, err := networkoptions.GetNetworkFromCmdLineFlags(
+			app,
+			"",
+			globalNetworkFlags,
+			false,
+			false,
+			validateNetworkOptions,
+			"",
+		)
+		if err != nil {
+			return err
+		}
+		switch {
+		case network.Kind == models.Local:
+			configExists, localConfigPath, err := subnet.GetAWMRelayerConfigPath()
+			if err != nil {
+				return err
+			}
+			if !configExists {
+				return fmt.Errorf("there is no local AWM relayer config at %s", localConfigPath)
+			}
+			configPath = localConfigPath
+		case network.ClusterName != "":
+			host, err := node.GetAWMRelayerHost(app, network.ClusterName)
+			if err != nil {
+				return err
+			}
+			if host == nil {
+				return fmt.Errorf("no relayer host found on cluster %s", network.ClusterName)
+			}
+			defer host.Disconnect()
+			tmpFile, err := os.CreateTemp("", "avalanchecli-awm-relayer-config-*.json")
+			if err != nil {
+				return err
+			}
+			defer os.Remove(tmpFile.Name())
+			if err := tmpFile.Close(); err != nil {
+				return err
+			}
+			if err := ssh.RunSSHDownloadAWMRelayerConfig(host, tmpFile.Name()); err != nil {
+				return err
+			}
+			configPath = tmpFile.Name()
+			checkEndpoints = false
+		}
+	}
+	configBytes, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	problems, err := teleporter.GetRelayerConfigProblems(configBytes, checkEndpoints, relayerEndpointCheckTimeout)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		ux.Logger.GreenCheckmarkToUser("AWM relayer config is valid")
+		return nil
+	}
+	for _, problem := range problems {
+		ux.Logger.RedXToUser("%s", problem)
+	}
+	return fmt.Errorf("AWM relayer config has %d problem(s)", len(problems))
+}
@@ -280,6 +280,36 @@ func (_m *Prompter) CaptureGitURL(promptStr string) (*url.URL, error) {
 	return r0, r1
 }
 
+// CaptureHexBytes provides a mock function with given fields: promptStr, expectedLen
+func (_m *Prompter) CaptureHexBytes(promptStr string, expectedLen int) ([]byte, error) {
+	ret := _m.Called(promptStr, expectedLen)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CaptureHexBytes")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]byte, error)); ok {
+		return rf(promptStr, expectedLen)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []byte); ok {
+		r0 = rf(promptStr, expectedLen)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(promptStr, expectedLen)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CaptureID provides a mock function with given fields: promptStr
 func (_m *Prompter) CaptureID(promptStr string) (ids.ID, error) {
 	ret := _m.Called(promptStr)
@@ -394,6 +424,36 @@ func (_m *Prompter) CaptureList(promptStr string, options []string) (string, err
 	return r0, r1
 }
 
+// CaptureListMultiple provides a mock function with given fields: promptStr, options
+func (_m *Prompter) CaptureListMultiple(promptStr string, options []string) ([]string, error) {
+	ret := _m.Called(promptStr, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CaptureListMultiple")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string) ([]string, error)); ok {
+		return rf(promptStr, options)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string) []string); ok {
+		r0 = rf(promptStr, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(promptStr, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CaptureListWithSize provides a mock function with given fields: promptStr, options, size
 func (_m *Prompter) CaptureListWithSize(promptStr string, options []string, size int) (string, error) {
 	ret := _m.Called(promptStr, options, size)
@@ -678,6 +738,34 @@ func (_m *Prompter) CaptureRepoFile(promptStr string, repo string, branch string
 	return r0, r1
 }
 
+// CaptureSignedInt provides a mock function with given fields: promptStr, comparators
+func (_m *Prompter) CaptureSignedInt(promptStr string, comparators []prompts.SignedComparator) (int, error) {
+	ret := _m.Called(promptStr, comparators)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CaptureSignedInt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []prompts.SignedComparator) (int, error)); ok {
+		return rf(promptStr, comparators)
+	}
+	if rf, ok := ret.Get(0).(func(string, []prompts.SignedComparator) int); ok {
+		r0 = rf(promptStr, comparators)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []prompts.SignedComparator) error); ok {
+		r1 = rf(promptStr, comparators)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CaptureString provides a mock function with given fields: promptStr
 func (_m *Prompter) CaptureString(promptStr string) (string, error) {
 	ret := _m.Called(promptStr)
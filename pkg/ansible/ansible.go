@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
@@ -16,6 +17,11 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 )
 
+const (
+	avalanchegoHTTPPortVar    = "avalanchego_http_port"
+	avalanchegoStakingPortVar = "avalanchego_staking_port"
+)
+
 // CreateAnsibleHostInventory creates inventory file for ansible
 // specifies the ip address of the cloud server and the corresponding ssh cert path for the cloud server
 func CreateAnsibleHostInventory(inventoryDirPath, certFilePath, cloudService string, publicIPMap map[string]string, cloudConfigMap models.CloudConfig) error {
@@ -35,7 +41,7 @@ func CreateAnsibleHostInventory(inventoryDirPath, certFilePath, cloudService str
 				if err != nil {
 					return err
 				}
-				if err = writeToInventoryFile(inventoryFile, ansibleInstanceID, publicIPMap[instanceID], cloudConfig.CertFilePath); err != nil {
+				if err = writeToInventoryFile(inventoryFile, ansibleInstanceID, publicIPMap[instanceID], cloudConfig.CertFilePath, cloudConfig.SSHUser, cloudConfig.HTTPPort, cloudConfig.StakingPort); err != nil {
 					return err
 				}
 			}
@@ -46,7 +52,7 @@ func CreateAnsibleHostInventory(inventoryDirPath, certFilePath, cloudService str
 			if err != nil {
 				return err
 			}
-			if err = writeToInventoryFile(inventoryFile, ansibleInstanceID, publicIPMap[instanceID], certFilePath); err != nil {
+			if err = writeToInventoryFile(inventoryFile, ansibleInstanceID, publicIPMap[instanceID], certFilePath, "", 0, 0); err != nil {
 				return err
 			}
 		}
@@ -54,13 +60,22 @@ func CreateAnsibleHostInventory(inventoryDirPath, certFilePath, cloudService str
 	return nil
 }
 
-func writeToInventoryFile(inventoryFile *os.File, ansibleInstanceID, publicIP, certFilePath string) error {
+func writeToInventoryFile(inventoryFile *os.File, ansibleInstanceID, publicIP, certFilePath, sshUser string, httpPort, stakingPort uint) error {
+	if sshUser == "" {
+		sshUser = constants.AnsibleSSHUser
+	}
 	inventoryContent := ansibleInstanceID
 	inventoryContent += " ansible_host="
 	inventoryContent += publicIP
-	inventoryContent += " ansible_user=ubuntu"
+	inventoryContent += fmt.Sprintf(" ansible_user=%s", sshUser)
 	inventoryContent += fmt.Sprintf(" ansible_ssh_private_key_file=%s", certFilePath)
 	inventoryContent += fmt.Sprintf(" ansible_ssh_common_args='%s'", constants.AnsibleSSHUseAgentParams)
+	if httpPort != 0 {
+		inventoryContent += fmt.Sprintf(" %s=%d", avalanchegoHTTPPortVar, httpPort)
+	}
+	if stakingPort != 0 {
+		inventoryContent += fmt.Sprintf(" %s=%d", avalanchegoStakingPortVar, stakingPort)
+	}
 	if _, err := inventoryFile.WriteString(inventoryContent + "\n"); err != nil {
 		return err
 	}
@@ -83,7 +98,7 @@ func WriteNodeConfigsToAnsibleInventory(inventoryDirPath string, nc []models.Nod
 		if err != nil {
 			return err
 		}
-		if err := writeToInventoryFile(inventoryFile, nodeID, nodeConfig.ElasticIP, nodeConfig.CertPath); err != nil {
+		if err := writeToInventoryFile(inventoryFile, nodeID, nodeConfig.ElasticIP, nodeConfig.CertPath, nodeConfig.SSHUser, nodeConfig.HTTPPort, nodeConfig.StakingPort); err != nil {
 			return err
 		}
 	}
@@ -125,6 +140,12 @@ func GetInventoryFromAnsibleInventoryFile(inventoryDirPath string) ([]*models.Ho
 			SSHPrivateKeyPath: parsedHost["ansible_ssh_private_key_file"],
 			SSHCommonArgs:     parsedHost["ansible_ssh_common_args"],
 		}
+		if host.HTTPPort, err = parseInventoryPort(parsedHost, avalanchegoHTTPPortVar); err != nil {
+			return nil, err
+		}
+		if host.StakingPort, err = parseInventoryPort(parsedHost, avalanchegoStakingPortVar); err != nil {
+			return nil, err
+		}
 		inventory = append(inventory, host)
 	}
 	if err := scanner.Err(); err != nil {
@@ -133,6 +154,19 @@ func GetInventoryFromAnsibleInventoryFile(inventoryDirPath string) ([]*models.Ho
 	return inventory, nil
 }
 
+// parseInventoryPort returns the port stored in inventory var [key], or 0 if not set
+func parseInventoryPort(parsedHost map[string]string, key string) (uint, error) {
+	portStr, ok := parsedHost[key]
+	if !ok {
+		return 0, nil
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q in inventory: %w", key, portStr, err)
+	}
+	return uint(port), nil
+}
+
 func GetHostByNodeID(nodeID string, inventoryDirPath string) (*models.Host, error) {
 	allHosts, err := GetInventoryFromAnsibleInventoryFile(inventoryDirPath)
 	if err != nil {
@@ -8,9 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 
-	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"golang.org/x/mod/semver"
 )
 
@@ -26,14 +24,25 @@ type Downloader interface {
 	GetAllReleasesForRepo(org, repo string) ([]string, error)
 }
 
-type downloader struct{}
+type downloader struct {
+	source ReleaseSource
+	client *http.Client
+}
 
 func NewDownloader() Downloader {
-	return &downloader{}
+	return NewDownloaderWithSource(NewGithubReleaseSource())
+}
+
+// NewDownloaderWithSource returns a downloader that resolves release metadata against [source]
+func NewDownloaderWithSource(source ReleaseSource) Downloader {
+	return &downloader{
+		source: source,
+		client: newHTTPClient(),
+	}
 }
 
-func (downloader) Download(url string) ([]byte, error) {
-	resp, err := http.Get(url)
+func (d downloader) Download(url string) ([]byte, error) {
+	resp, err := d.client.Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +54,7 @@ func (downloader) Download(url string) ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
-// GetLatestPreReleaseVersion returns the latest available pre release version from github
+// GetLatestPreReleaseVersion returns the latest available pre release version from the release source
 func (d downloader) GetLatestPreReleaseVersion(org, repo string) (string, error) {
 	releases, err := d.GetAllReleasesForRepo(org, repo)
 	if err != nil {
@@ -58,9 +67,8 @@ func (d downloader) GetLatestPreReleaseVersion(org, repo string) (string, error)
 }
 
 func (d downloader) GetAllReleasesForRepo(org, repo string) ([]string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", org, repo)
-	token := os.Getenv(constants.GithubAPITokenEnvVarName)
-	body, err := d.doAPIRequest(url, token)
+	url := d.source.ReleasesURL(org, repo)
+	body, err := d.doAPIRequest(url, d.source.APIToken())
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +96,7 @@ func (d downloader) GetAllReleasesForRepo(org, repo string) ([]string, error) {
 	return releases, nil
 }
 
-func (downloader) doAPIRequest(url, token string) (io.ReadCloser, error) {
+func (d downloader) doAPIRequest(url, token string) (io.ReadCloser, error) {
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
@@ -97,7 +105,7 @@ func (downloader) doAPIRequest(url, token string) (io.ReadCloser, error) {
 		// avoid rate limitation issues at CI
 		request.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
 	}
-	resp, err := http.DefaultClient.Do(request)
+	resp, err := d.client.Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed doing request to %s: %w", url, err)
 	}
@@ -107,12 +115,11 @@ func (downloader) doAPIRequest(url, token string) (io.ReadCloser, error) {
 	return resp.Body, nil
 }
 
-// GetLatestReleaseVersion returns the latest available release version from github
+// GetLatestReleaseVersion returns the latest available release version from [releaseURL]
 func (d downloader) GetLatestReleaseVersion(releaseURL string) (string, error) {
 	// TODO: Question if there is a less error prone (= simpler) way to install latest avalanchego
 	// Maybe the binary package manager should also allow the actual avalanchego binary for download
-	token := os.Getenv(constants.GithubAPITokenEnvVarName)
-	body, err := d.doAPIRequest(releaseURL, token)
+	body, err := d.doAPIRequest(releaseURL, d.source.APIToken())
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,114 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package application
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+)
+
+// ReleaseSource resolves where release metadata and release assets of a
+// github style org/repo are fetched from
+type ReleaseSource interface {
+	// LatestReleaseURL returns the URL of the latest release metadata,
+	// a json object with a tag_name field
+	LatestReleaseURL(org, repo string) string
+	// ReleasesURL returns the URL of the metadata of all releases,
+	// a json array of objects with a tag_name field
+	ReleasesURL(org, repo string) string
+	// AssetURL returns the URL of the release asset [asset] for [version]
+	AssetURL(org, repo, version, asset string) string
+	// APIToken returns the token to authorize metadata requests with, if any
+	APIToken() string
+}
+
+type (
+	githubReleaseSource struct{}
+	mirrorReleaseSource struct {
+		baseURL string
+	}
+)
+
+var (
+	_ ReleaseSource = (*githubReleaseSource)(nil)
+	_ ReleaseSource = (*mirrorReleaseSource)(nil)
+)
+
+// NewGithubReleaseSource returns a release source that resolves against github.com
+func NewGithubReleaseSource() ReleaseSource {
+	return &githubReleaseSource{}
+}
+
+func (githubReleaseSource) LatestReleaseURL(org, repo string) string {
+	return "https://api.github.com/repos/" + org + "/" + repo + "/releases/latest"
+}
+
+func (githubReleaseSource) ReleasesURL(org, repo string) string {
+	return fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", org, repo)
+}
+
+func (githubReleaseSource) AssetURL(org, repo, version, asset string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s", org, repo, version, asset)
+}
+
+func (githubReleaseSource) APIToken() string {
+	return os.Getenv(constants.GithubAPITokenEnvVarName)
+}
+
+// NewMirrorReleaseSource returns a release source that resolves against a mirror
+// of github releases, for air-gapped environments. [mirror] is either a http(s)
+// base URL or a local directory, with layout:
+//
+//	<mirror>/<org>/<repo>/latest.json
+//	<mirror>/<org>/<repo>/releases.json
+//	<mirror>/<org>/<repo>/<version>/<asset>
+func NewMirrorReleaseSource(mirror string) (ReleaseSource, error) {
+	baseURL, err := url.Parse(mirror)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release mirror %q: %w", mirror, err)
+	}
+	switch baseURL.Scheme {
+	case "http", "https", "file":
+	case "":
+		absPath, err := filepath.Abs(mirror)
+		if err != nil {
+			return nil, err
+		}
+		baseURL = &url.URL{Scheme: "file", Path: filepath.ToSlash(absPath)}
+	default:
+		return nil, fmt.Errorf("invalid release mirror %q: unsupported scheme %s", mirror, baseURL.Scheme)
+	}
+	return &mirrorReleaseSource{baseURL: strings.TrimSuffix(baseURL.String(), "/")}, nil
+}
+
+func (s mirrorReleaseSource) LatestReleaseURL(org, repo string) string {
+	return fmt.Sprintf("%s/%s/%s/latest.json", s.baseURL, org, repo)
+}
+
+func (s mirrorReleaseSource) ReleasesURL(org, repo string) string {
+	return fmt.Sprintf("%s/%s/%s/releases.json", s.baseURL, org, repo)
+}
+
+func (s mirrorReleaseSource) AssetURL(org, repo, version, asset string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", s.baseURL, org, repo, version, asset)
+}
+
+func (mirrorReleaseSource) APIToken() string {
+	// the github token is never sent to a mirror
+	return ""
+}
+
+// newHTTPClient returns a http client that also serves file:// URLs, so that
+// mirrors on local directories can be used
+func newHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package application
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOrg  = "ava-labs"
+	testRepo = "subnet-evm"
+)
+
+var testMirrorFiles = map[string]string{
+	"ava-labs/subnet-evm/latest.json":       `{"tag_name": "v0.6.5"}`,
+	"ava-labs/subnet-evm/releases.json":     `[{"tag_name": "v0.6.6-rc.0"}, {"tag_name": "v0.6.5"}, {"tag_name": "v0.6.4"}]`,
+	"ava-labs/subnet-evm/v0.6.5/evm.tar.gz": "binary",
+}
+
+func writeTestMirror(t *testing.T) string {
+	dir := t.TempDir()
+	for path, content := range testMirrorFiles {
+		filePath := filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func testReleaseSource(t *testing.T, source ReleaseSource) {
+	require := require.New(t)
+	d := NewDownloaderWithSource(source)
+
+	latest, err := d.GetLatestReleaseVersion(source.LatestReleaseURL(testOrg, testRepo))
+	require.NoError(err)
+	require.Equal("v0.6.5", latest)
+
+	releases, err := d.GetAllReleasesForRepo(testOrg, testRepo)
+	require.NoError(err)
+	require.Equal([]string{"v0.6.6-rc.0", "v0.6.5", "v0.6.4"}, releases)
+
+	preRelease, err := d.GetLatestPreReleaseVersion(testOrg, testRepo)
+	require.NoError(err)
+	require.Equal("v0.6.6-rc.0", preRelease)
+
+	binary, err := d.Download(source.AssetURL(testOrg, testRepo, "v0.6.5", "evm.tar.gz"))
+	require.NoError(err)
+	require.Equal([]byte("binary"), binary)
+
+	_, err = d.Download(source.AssetURL(testOrg, testRepo, "v0.6.5", "missing.tar.gz"))
+	require.Error(err)
+	_, err = d.GetAllReleasesForRepo(testOrg, "missing")
+	require.Error(err)
+}
+
+func TestMirrorReleaseSourceHTTP(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir(writeTestMirror(t))))
+	defer server.Close()
+	source, err := NewMirrorReleaseSource(server.URL + "/")
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/ava-labs/subnet-evm/v0.6.5/evm.tar.gz", source.AssetURL(testOrg, testRepo, "v0.6.5", "evm.tar.gz"))
+	require.Empty(t, source.APIToken())
+	testReleaseSource(t, source)
+}
+
+func TestMirrorReleaseSourceLocalDir(t *testing.T) {
+	source, err := NewMirrorReleaseSource(writeTestMirror(t))
+	require.NoError(t, err)
+	testReleaseSource(t, source)
+}
+
+func TestNewMirrorReleaseSourceInvalid(t *testing.T) {
+	_, err := NewMirrorReleaseSource("ftp://mirror.example.com")
+	require.Error(t, err)
+}
+
+func TestGithubReleaseSource(t *testing.T) {
+	require := require.New(t)
+	source := NewGithubReleaseSource()
+	require.Equal("https://api.github.com/repos/ava-labs/subnet-evm/releases/latest", source.LatestReleaseURL(testOrg, testRepo))
+	require.Equal("https://api.github.com/repos/ava-labs/subnet-evm/releases", source.ReleasesURL(testOrg, testRepo))
+	require.Equal("https://github.com/ava-labs/subnet-evm/releases/download/v0.6.5/evm.tar.gz", source.AssetURL(testOrg, testRepo, "v0.6.5", "evm.tar.gz"))
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+)
+
+// overridableTimeouts are the operational timeouts of constants that can be set in the
+// timeouts section of the config file, by key
+var overridableTimeouts = map[string]*time.Duration{
+	"cloud-operation-timeout":         &constants.CloudOperationTimeout,
+	"anr-request-timeout":             &constants.ANRRequestTimeout,
+	"api-request-timeout":             &constants.APIRequestTimeout,
+	"api-request-large-timeout":       &constants.APIRequestLargeTimeout,
+	"ssh-server-start-timeout":        &constants.SSHServerStartTimeout,
+	"ssh-script-timeout":              &constants.SSHScriptTimeout,
+	"ssh-long-running-script-timeout": &constants.SSHLongRunningScriptTimeout,
+	"ssh-dir-ops-timeout":             &constants.SSHDirOpsTimeout,
+	"ssh-file-ops-timeout":            &constants.SSHFileOpsTimeout,
+	"ssh-post-timeout":                &constants.SSHPOSTTimeout,
+	"ssh-db-snapshot-timeout":         &constants.SSHDBSnapshotTimeout,
+}
+
+// ApplyTimeoutOverrides sets the operational timeouts of constants to the durations of
+// [overrides], by key (e.g. {"ssh-script-timeout": "5m"}). Unknown keys are ignored, and
+// values that are not positive durations keep the default, both with a warning log.
+// Returns the applied overrides
+func ApplyTimeoutOverrides(log logging.Logger, overrides map[string]string) map[string]time.Duration {
+	applied := map[string]time.Duration{}
+	for key, value := range overrides {
+		timeout, ok := overridableTimeouts[key]
+		if !ok {
+			log.Warn("Ignoring unknown timeout in config file", zap.String("key", key))
+			continue
+		}
+		duration, err := parsePositiveDuration(value)
+		if err != nil {
+			log.Warn("Ignoring invalid timeout in config file, using default",
+				zap.String("key", key),
+				zap.Duration("default", *timeout),
+				zap.Error(err),
+			)
+			continue
+		}
+		*timeout = duration
+		applied[key] = duration
+	}
+	return applied
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration %s is not positive", value)
+	}
+	return duration, nil
+}
+
+// LoadTimeoutOverrides applies the timeouts section of the config file, if any
+func (app *Avalanche) LoadTimeoutOverrides() {
+	if app.Conf == nil {
+		return
+	}
+	applied := ApplyTimeoutOverrides(app.Log, app.Conf.GetConfigStringMapValue(constants.ConfigTimeoutsKey))
+	for key, duration := range applied {
+		app.Log.Info("Using timeout from config file", zap.String("key", key), zap.Duration("timeout", duration))
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/config"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreTimeouts restores the overridable timeouts to their current values at test cleanup
+func restoreTimeouts(t *testing.T) {
+	defaults := map[*time.Duration]time.Duration{}
+	for _, timeout := range overridableTimeouts {
+		defaults[timeout] = *timeout
+	}
+	t.Cleanup(func() {
+		for timeout, value := range defaults {
+			*timeout = value
+		}
+	})
+}
+
+func TestApplyTimeoutOverrides(t *testing.T) {
+	require := require.New(t)
+	restoreTimeouts(t)
+	defaultSSHFileOpsTimeout := constants.SSHFileOpsTimeout
+	defaultAPIRequestTimeout := constants.APIRequestTimeout
+	defaultCloudOperationTimeout := constants.CloudOperationTimeout
+
+	applied := ApplyTimeoutOverrides(logging.NoLog{}, map[string]string{
+		"ssh-script-timeout":         "5m",
+		"ssh-server-start-timeout":   "90s",
+		"ssh-file-ops-timeout":       "soon",
+		"api-request-timeout":        "-1s",
+		"cloud-operation-timeout":    "0s",
+		"unknown-timeout":            "1m",
+		"ssh-db-snapshot-timeout":    "12h",
+		"health-check-interval-typo": "1s",
+	})
+	require.Equal(map[string]time.Duration{
+		"ssh-script-timeout":       5 * time.Minute,
+		"ssh-server-start-timeout": 90 * time.Second,
+		"ssh-db-snapshot-timeout":  12 * time.Hour,
+	}, applied)
+	require.Equal(5*time.Minute, constants.SSHScriptTimeout)
+	require.Equal(90*time.Second, constants.SSHServerStartTimeout)
+	require.Equal(12*time.Hour, constants.SSHDBSnapshotTimeout)
+	// malformed and non positive values keep the defaults
+	require.Equal(defaultSSHFileOpsTimeout, constants.SSHFileOpsTimeout)
+	require.Equal(defaultAPIRequestTimeout, constants.APIRequestTimeout)
+	require.Equal(defaultCloudOperationTimeout, constants.CloudOperationTimeout)
+
+	require.Empty(ApplyTimeoutOverrides(logging.NoLog{}, nil))
+}
+
+func TestLoadTimeoutOverrides(t *testing.T) {
+	require := require.New(t)
+	restoreTimeouts(t)
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	defaultSSHPOSTTimeout := constants.SSHPOSTTimeout
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(os.WriteFile(configPath, []byte(`{
+		"MetricsEnabled": false,
+		"timeouts": {
+			"ssh-long-running-script-timeout": "30m",
+			"ssh-post-timeout": 10,
+			"unknown-timeout": "1m"
+		}
+	}`), constants.WriteReadReadPerms))
+	app := newTestApp(t)
+	app.Conf = config.New()
+	app.Conf.SetConfig(app.Log, configPath)
+	app.LoadTimeoutOverrides()
+	require.Equal(30*time.Minute, constants.SSHLongRunningScriptTimeout)
+	// durations need a unit
+	require.Equal(defaultSSHPOSTTimeout, constants.SSHPOSTTimeout)
+
+	// a config without timeouts changes nothing
+	viper.Reset()
+	require.NoError(os.WriteFile(configPath, []byte(`{"MetricsEnabled": false}`), constants.WriteReadReadPerms))
+	app.Conf.SetConfig(app.Log, configPath)
+	app.LoadTimeoutOverrides()
+	require.Equal(30*time.Minute, constants.SSHLongRunningScriptTimeout)
+}
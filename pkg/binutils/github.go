@@ -6,6 +6,7 @@ package binutils
 import (
 	"fmt"
 
+	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 )
 
@@ -32,8 +33,26 @@ var (
 	_ GithubDownloader = (*avalancheGoDownloader)(nil)
 )
 
+// releaseSource resolves release metadata and download URLs. github by default
+var releaseSource = application.NewGithubReleaseSource()
+
+// SetReleaseSource sets the source release metadata and binaries are resolved against
+func SetReleaseSource(source application.ReleaseSource) {
+	releaseSource = source
+}
+
+// GetReleaseSource returns the source release metadata and binaries are resolved against
+func GetReleaseSource() application.ReleaseSource {
+	return releaseSource
+}
+
 func GetGithubLatestReleaseURL(org, repo string) string {
-	return "https://api.github.com/repos/" + org + "/" + repo + "/releases/latest"
+	return releaseSource.LatestReleaseURL(org, repo)
+}
+
+// GetGithubReleaseAssetURL returns the URL of the release asset [asset] of [org]/[repo] for [version]
+func GetGithubReleaseAssetURL(org, repo, version, asset string) string {
+	return releaseSource.AssetURL(org, repo, version, asset)
 }
 
 func NewAvagoDownloader() GithubDownloader {
@@ -49,32 +68,28 @@ func (avalancheGoDownloader) GetDownloadURL(version string, installer Installer)
 
 	switch goos {
 	case linux:
-		avalanchegoURL = fmt.Sprintf(
-			"https://github.com/%s/%s/releases/download/%s/avalanchego-linux-%s-%s.tar.gz",
+		avalanchegoURL = releaseSource.AssetURL(
 			constants.AvaLabsOrg,
 			constants.AvalancheGoRepoName,
 			version,
-			goarch,
-			version,
+			fmt.Sprintf("avalanchego-linux-%s-%s.tar.gz", goarch, version),
 		)
 		ext = tarExtension
 	case darwin:
-		avalanchegoURL = fmt.Sprintf(
-			"https://github.com/%s/%s/releases/download/%s/avalanchego-macos-%s.zip",
+		avalanchegoURL = releaseSource.AssetURL(
 			constants.AvaLabsOrg,
 			constants.AvalancheGoRepoName,
 			version,
-			version,
+			fmt.Sprintf("avalanchego-macos-%s.zip", version),
 		)
 		ext = zipExtension
 		// EXPERIMENTAL WIN, no support
 	case windows:
-		avalanchegoURL = fmt.Sprintf(
-			"https://github.com/%s/%s/releases/download/%s/avalanchego-win-%s-experimental.zip",
+		avalanchegoURL = releaseSource.AssetURL(
 			constants.AvaLabsOrg,
 			constants.AvalancheGoRepoName,
 			version,
-			version,
+			fmt.Sprintf("avalanchego-win-%s-experimental.zip", version),
 		)
 		ext = zipExtension
 	default:
@@ -97,24 +112,19 @@ func (subnetEVMDownloader) GetDownloadURL(version string, installer Installer) (
 
 	switch goos {
 	case linux:
-		subnetEVMURL = fmt.Sprintf(
-			"https://github.com/%s/%s/releases/download/%s/%s_%s_linux_%s.tar.gz",
+		subnetEVMURL = releaseSource.AssetURL(
 			constants.AvaLabsOrg,
 			constants.SubnetEVMRepoName,
 			version,
-			constants.SubnetEVMRepoName,
-			version[1:], // WARN subnet-evm isn't consistent in its release naming, it's omitting the v in the file name...
-			goarch,
+			// WARN subnet-evm isn't consistent in its release naming, it's omitting the v in the file name...
+			fmt.Sprintf("%s_%s_linux_%s.tar.gz", constants.SubnetEVMRepoName, version[1:], goarch),
 		)
 	case darwin:
-		subnetEVMURL = fmt.Sprintf(
-			"https://github.com/%s/%s/releases/download/%s/%s_%s_darwin_%s.tar.gz",
+		subnetEVMURL = releaseSource.AssetURL(
 			constants.AvaLabsOrg,
 			constants.SubnetEVMRepoName,
 			version,
-			constants.SubnetEVMRepoName,
-			version[1:],
-			goarch,
+			fmt.Sprintf("%s_%s_darwin_%s.tar.gz", constants.SubnetEVMRepoName, version[1:], goarch),
 		)
 	default:
 		return "", "", fmt.Errorf("OS not supported: %s", goos)
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/stretchr/testify/require"
 )
@@ -119,3 +120,26 @@ func TestGetDownloadURL_SubnetEVM(t *testing.T) {
 		require.Equal(tt.expectedErr, err)
 	}
 }
+
+func TestGetDownloadURL_ReleaseMirror(t *testing.T) {
+	require := require.New(t)
+	mirrorSource, err := application.NewMirrorReleaseSource("https://mirror.example.com/releases")
+	require.NoError(err)
+	SetReleaseSource(mirrorSource)
+	defer SetReleaseSource(application.NewGithubReleaseSource())
+
+	require.Equal(
+		"https://mirror.example.com/releases/ava-labs/avalanchego/latest.json",
+		GetGithubLatestReleaseURL(constants.AvaLabsOrg, constants.AvalancheGoRepoName),
+	)
+
+	mockInstaller := &mocks.Installer{}
+	mockInstaller.On("GetArch").Return("amd64", "linux")
+	url, ext, err := NewAvagoDownloader().GetDownloadURL("v1.17.1", mockInstaller)
+	require.NoError(err)
+	require.Equal("https://mirror.example.com/releases/ava-labs/avalanchego/v1.17.1/avalanchego-linux-amd64-v1.17.1.tar.gz", url)
+	require.Equal(tarExtension, ext)
+	url, _, err = NewSubnetEVMDownloader().GetDownloadURL("v0.6.5", mockInstaller)
+	require.NoError(err)
+	require.Equal("https://mirror.example.com/releases/ava-labs/subnet-evm/v0.6.5/subnet-evm_0.6.5_linux_amd64.tar.gz", url)
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
@@ -32,22 +33,19 @@ func CheckReleaseVersion(
 
 	switch goos {
 	case "linux":
-		downloadURL = fmt.Sprintf(
-			"https://github.com/ava-labs/%s/releases/download/%s/%s_%s_linux_%s.tar.gz",
+		downloadURL = releaseSource.AssetURL(
+			constants.AvaLabsOrg,
 			repo,
 			version,
-			repo,
-			version[1:], // WARN subnet-evm isn't consistent in its release naming, it's omitting the v in the file name...
-			arch,
+			// WARN subnet-evm isn't consistent in its release naming, it's omitting the v in the file name...
+			fmt.Sprintf("%s_%s_linux_%s.tar.gz", repo, version[1:], arch),
 		)
 	case "darwin":
-		downloadURL = fmt.Sprintf(
-			"https://github.com/ava-labs/%s/releases/download/%s/%s_%s_darwin_%s.tar.gz",
+		downloadURL = releaseSource.AssetURL(
+			constants.AvaLabsOrg,
 			repo,
 			version,
-			repo,
-			version[1:],
-			arch,
+			fmt.Sprintf("%s_%s_darwin_%s.tar.gz", repo, version[1:], arch),
 		)
 	default:
 		return nil, fmt.Errorf("OS not supported: %s", goos)
@@ -5,14 +5,21 @@ package aws
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net"
 	"os"
+	"regexp"
 	"slices"
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
@@ -23,14 +30,43 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/exp/maps"
 )
 
+const (
+	SSHKeyAlgorithmRSA     = "rsa"
+	SSHKeyAlgorithmECDSA   = "ecdsa"
+	SSHKeyAlgorithmED25519 = "ed25519"
+)
+
+// SSHKeyAlgorithms are the algorithms of the key pairs created by CreateAndDownloadKeyPair
+var SSHKeyAlgorithms = []string{SSHKeyAlgorithmRSA, SSHKeyAlgorithmECDSA, SSHKeyAlgorithmED25519}
+
 var (
 	ErrNoInstanceState         = errors.New("unable to get instance state")
 	ErrNoAddressFound          = errors.New("unable to get public IP address info on AWS")
 	ErrNodeNotFoundToBeRunning = errors.New("node not found to be running")
+	ErrEIPQuotaExceeded        = errors.New("elastic IP quota exceeded")
 )
 
+// EIPAllocationError is returned when elastic IPs could not be set up for all
+// instances of a region. It keeps the underlying AWS error details
+type EIPAllocationError struct {
+	Region    string
+	Allocated int
+	Requested int
+	Err       error
+}
+
+func (e *EIPAllocationError) Error() string {
+	return fmt.Sprintf("failed to set up elastic IP %d of %d in AWS[%s]: %s", e.Allocated+1, e.Requested, e.Region, e.Err)
+}
+
+func (e *EIPAllocationError) Unwrap() error {
+	return e.Err
+}
+
 type AwsCloud struct {
 	ec2Client *ec2.Client
 	ctx       context.Context
@@ -38,25 +74,8 @@ type AwsCloud struct {
 
 // NewAwsCloud creates an AWS cloud
 func NewAwsCloud(awsProfile, region string) (*AwsCloud, error) {
-	var (
-		cfg aws.Config
-		err error
-	)
 	ctx := context.Background()
-	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
-		// Load session from env variables
-		cfg, err = config.LoadDefaultConfig(
-			ctx,
-			config.WithRegion(region),
-		)
-	} else {
-		// Load session from profile in config file
-		cfg, err = config.LoadDefaultConfig(
-			ctx,
-			config.WithRegion(region),
-			config.WithSharedConfigProfile(awsProfile),
-		)
-	}
+	cfg, err := loadAwsConfig(ctx, awsProfile, region)
 	if err != nil {
 		return nil, err
 	}
@@ -66,75 +85,120 @@ func NewAwsCloud(awsProfile, region string) (*AwsCloud, error) {
 	}, nil
 }
 
-// CreateSecurityGroup creates a security group
-func (c *AwsCloud) CreateSecurityGroup(groupName, description string) (string, error) {
-	createSGOutput, err := c.ec2Client.CreateSecurityGroup(c.ctx, &ec2.CreateSecurityGroupInput{
+// loadAwsConfig loads the AWS config from env variables if set, or else from
+// [awsProfile] in the shared config file
+func loadAwsConfig(ctx context.Context, awsProfile, region string) (aws.Config, error) {
+	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
+		// Load session from env variables
+		return config.LoadDefaultConfig(
+			ctx,
+			config.WithRegion(region),
+		)
+	}
+	// Load session from profile in config file
+	return config.LoadDefaultConfig(
+		ctx,
+		config.WithRegion(region),
+		config.WithSharedConfigProfile(awsProfile),
+	)
+}
+
+// createSecurityGroupInput returns the input to create a security group, in
+// the default VPC if [vpcID] is empty
+func createSecurityGroupInput(groupName, description, vpcID string) *ec2.CreateSecurityGroupInput {
+	sgInput := &ec2.CreateSecurityGroupInput{
 		GroupName:   aws.String(groupName),
 		Description: aws.String(description),
-	})
+	}
+	if vpcID != "" {
+		sgInput.VpcId = aws.String(vpcID)
+	}
+	return sgInput
+}
+
+// CreateSecurityGroup creates a security group in [vpcID], or in the default VPC if empty
+func (c *AwsCloud) CreateSecurityGroup(groupName, description, vpcID string) (string, error) {
+	createSGOutput, err := c.ec2Client.CreateSecurityGroup(c.ctx, createSecurityGroupInput(groupName, description, vpcID))
 	if err != nil {
 		return "", err
 	}
 	return *createSGOutput.GroupId, nil
 }
 
-// CheckSecurityGroupExists checks if the given security group exists
-func (c *AwsCloud) CheckSecurityGroupExists(sgName string) (bool, types.SecurityGroup, error) {
-	sgInput := &ec2.DescribeSecurityGroupsInput{
-		GroupNames: []string{
-			sgName,
-		},
-	}
+// DeleteSecurityGroup deletes the security group [groupID]. It fails while the group is still
+// used by an instance, including terminating ones
+func (c *AwsCloud) DeleteSecurityGroup(groupID string) error {
+	_, err := c.ec2Client.DeleteSecurityGroup(c.ctx, &ec2.DeleteSecurityGroupInput{
+		GroupId: aws.String(groupID),
+	})
+	return err
+}
 
-	sg, err := c.ec2Client.DescribeSecurityGroups(c.ctx, sgInput)
+// CheckSecurityGroupExists checks if the given security group exists.
+// The group is looked up by name filter, so groups outside the default VPC are also found
+func (c *AwsCloud) CheckSecurityGroupExists(sgName, vpcID string) (bool, types.SecurityGroup, error) {
+	sg, err := c.ec2Client.DescribeSecurityGroups(c.ctx, securityGroupsInput(sgName, vpcID))
 	if err != nil {
 		if strings.Contains(err.Error(), "InvalidGroup.NotFound") {
 			return false, types.SecurityGroup{}, nil
 		}
 		return false, types.SecurityGroup{}, err
 	}
+	if len(sg.SecurityGroups) == 0 {
+		return false, types.SecurityGroup{}, nil
+	}
 	return true, sg.SecurityGroups[0], nil
 }
 
+// securityGroupsInput returns the input to describe the security groups named [sgName],
+// restricted to the VPC [vpcID] if not empty, as group names are only unique per VPC
+func securityGroupsInput(sgName, vpcID string) *ec2.DescribeSecurityGroupsInput {
+	filters := []types.Filter{
+		{
+			Name:   aws.String("group-name"),
+			Values: []string{sgName},
+		},
+	}
+	if vpcID != "" {
+		filters = append(filters, types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcID},
+		})
+	}
+	return &ec2.DescribeSecurityGroupsInput{Filters: filters}
+}
+
+// ipPermission returns the permission for [protocol] on [port] for [ip], as an IPv6
+// range if [ip] is IPv6 and as an IPv4 range otherwise. A single host netmask is added if missing
+func ipPermission(protocol, ip string, port int32) types.IpPermission {
+	cidr := utils.IPToCIDR(ip)
+	permission := types.IpPermission{
+		IpProtocol: aws.String(protocol),
+		FromPort:   aws.Int32(port),
+		ToPort:     aws.Int32(port),
+	}
+	if utils.IsIPv6(cidr) {
+		permission.Ipv6Ranges = []types.Ipv6Range{{CidrIpv6: aws.String(cidr)}}
+	} else {
+		permission.IpRanges = []types.IpRange{{CidrIp: aws.String(cidr)}}
+	}
+	return permission
+}
+
 // AddSecurityGroupRule adds a rule to the given security group
 func (c *AwsCloud) AddSecurityGroupRule(groupID, direction, protocol, ip string, port int32) error {
-	if !strings.Contains(ip, "/") {
-		ip = fmt.Sprintf("%s/32", ip) // add netmask /32 if missing
-	}
 	switch direction {
 	case "ingress":
 		if _, err := c.ec2Client.AuthorizeSecurityGroupIngress(c.ctx, &ec2.AuthorizeSecurityGroupIngressInput{
-			GroupId: aws.String(groupID),
-			IpPermissions: []types.IpPermission{
-				{
-					IpProtocol: aws.String(protocol),
-					FromPort:   aws.Int32(port),
-					ToPort:     aws.Int32(port),
-					IpRanges: []types.IpRange{
-						{
-							CidrIp: aws.String(ip),
-						},
-					},
-				},
-			},
+			GroupId:       aws.String(groupID),
+			IpPermissions: []types.IpPermission{ipPermission(protocol, ip, port)},
 		}); err != nil {
 			return err
 		}
 	case "egress":
 		if _, err := c.ec2Client.AuthorizeSecurityGroupEgress(c.ctx, &ec2.AuthorizeSecurityGroupEgressInput{
-			GroupId: aws.String(groupID),
-			IpPermissions: []types.IpPermission{
-				{
-					IpProtocol: aws.String(protocol),
-					FromPort:   aws.Int32(port),
-					ToPort:     aws.Int32(port),
-					IpRanges: []types.IpRange{
-						{
-							CidrIp: aws.String(ip),
-						},
-					},
-				},
-			},
+			GroupId:       aws.String(groupID),
+			IpPermissions: []types.IpPermission{ipPermission(protocol, ip, port)},
 		}); err != nil {
 			return err
 		}
@@ -146,43 +210,18 @@ func (c *AwsCloud) AddSecurityGroupRule(groupID, direction, protocol, ip string,
 
 // DeleteSecurityGroupRule removes a rule from the given security group
 func (c *AwsCloud) DeleteSecurityGroupRule(groupID, direction, protocol, ip string, port int32) error {
-	if !strings.Contains(ip, "/") {
-		ip = fmt.Sprintf("%s/32", ip) // add netmask /32 if missing
-	}
 	switch direction {
 	case "ingress":
 		if _, err := c.ec2Client.RevokeSecurityGroupIngress(c.ctx, &ec2.RevokeSecurityGroupIngressInput{
-			GroupId: aws.String(groupID),
-			IpPermissions: []types.IpPermission{
-				{
-					IpProtocol: aws.String(protocol),
-					FromPort:   aws.Int32(port),
-					ToPort:     aws.Int32(port),
-					IpRanges: []types.IpRange{
-						{
-							CidrIp: aws.String(ip),
-						},
-					},
-				},
-			},
+			GroupId:       aws.String(groupID),
+			IpPermissions: []types.IpPermission{ipPermission(protocol, ip, port)},
 		}); err != nil {
 			return err
 		}
 	case "egress":
 		if _, err := c.ec2Client.RevokeSecurityGroupEgress(c.ctx, &ec2.RevokeSecurityGroupEgressInput{
-			GroupId: aws.String(groupID),
-			IpPermissions: []types.IpPermission{
-				{
-					IpProtocol: aws.String(protocol),
-					FromPort:   aws.Int32(port),
-					ToPort:     aws.Int32(port),
-					IpRanges: []types.IpRange{
-						{
-							CidrIp: aws.String(ip),
-						},
-					},
-				},
-			},
+			GroupId:       aws.String(groupID),
+			IpPermissions: []types.IpPermission{ipPermission(protocol, ip, port)},
 		}); err != nil {
 			return err
 		}
@@ -192,8 +231,9 @@ func (c *AwsCloud) DeleteSecurityGroupRule(groupID, direction, protocol, ip stri
 	return nil
 }
 
-// CreateEC2Instances creates EC2 instances
-func (c *AwsCloud) CreateEC2Instances(prefix string, count int, amiID, instanceType, keyName, securityGroupID string, forMonitoring bool, iops, throughput int, volumeType types.VolumeType, volumeSize int) ([]string, error) {
+// CreateEC2Instances creates EC2 instances. If [subnetID] is not empty, the instances
+// are launched in that VPC subnet instead of the default one. [tags] are added to the default ones
+func (c *AwsCloud) CreateEC2Instances(prefix string, count int, amiID, instanceType, keyName, securityGroupID, subnetID string, forMonitoring bool, iops, throughput int, volumeType types.VolumeType, volumeSize int, tags map[string]string) ([]string, error) {
 	var diskVolumeSize int32
 	if forMonitoring {
 		diskVolumeSize = constants.MonitoringCloudServerStorageSize
@@ -212,13 +252,12 @@ func (c *AwsCloud) CreateEC2Instances(prefix string, count int, amiID, instanceT
 		ebsValue.Iops = aws.Int32(int32(iops))
 	}
 
-	runResult, err := c.ec2Client.RunInstances(c.ctx, &ec2.RunInstancesInput{
-		ImageId:          aws.String(amiID),
-		InstanceType:     types.InstanceType(instanceType),
-		KeyName:          aws.String(keyName),
-		SecurityGroupIds: []string{securityGroupID},
-		MinCount:         aws.Int32(int32(count)),
-		MaxCount:         aws.Int32(int32(count)),
+	runInput := &ec2.RunInstancesInput{
+		ImageId:      aws.String(amiID),
+		InstanceType: types.InstanceType(instanceType),
+		KeyName:      aws.String(keyName),
+		MinCount:     aws.Int32(int32(count)),
+		MaxCount:     aws.Int32(int32(count)),
 		BlockDeviceMappings: []types.BlockDeviceMapping{
 			{
 				DeviceName: aws.String("/dev/sda1"), // ubuntu ami disk name
@@ -228,19 +267,12 @@ func (c *AwsCloud) CreateEC2Instances(prefix string, count int, amiID, instanceT
 		TagSpecifications: []types.TagSpecification{
 			{
 				ResourceType: types.ResourceTypeInstance,
-				Tags: []types.Tag{
-					{
-						Key:   aws.String("Name"),
-						Value: aws.String(prefix),
-					},
-					{
-						Key:   aws.String("Managed-By"),
-						Value: aws.String("avalanche-cli"),
-					},
-				},
+				Tags:         InstanceTags(prefix, tags),
 			},
 		},
-	})
+	}
+	setRunInstancesNetwork(runInput, securityGroupID, subnetID)
+	runResult, err := c.ec2Client.RunInstances(c.ctx, runInput)
 	if err != nil {
 		return nil, err
 	}
@@ -257,6 +289,25 @@ func (c *AwsCloud) CreateEC2Instances(prefix string, count int, amiID, instanceT
 	}
 }
 
+// setRunInstancesNetwork sets the security group [securityGroupID] of [runInput]. If [subnetID]
+// is not empty, the instances are launched in it through their primary network interface, with
+// a public IP address, as custom subnets usually don't assign one by default
+func setRunInstancesNetwork(runInput *ec2.RunInstancesInput, securityGroupID, subnetID string) {
+	if subnetID == "" {
+		runInput.SecurityGroupIds = []string{securityGroupID}
+		return
+	}
+	runInput.NetworkInterfaces = []types.InstanceNetworkInterfaceSpecification{
+		{
+			DeviceIndex:              aws.Int32(0),
+			SubnetId:                 aws.String(subnetID),
+			Groups:                   []string{securityGroupID},
+			AssociatePublicIpAddress: aws.Bool(true),
+			DeleteOnTermination:      aws.Bool(true),
+		},
+	}
+}
+
 // WaitForEC2Instances waits for the EC2 instances to be running
 func (c *AwsCloud) WaitForEC2Instances(nodeIDs []string, state types.InstanceStateName) error {
 	instanceInput := &ec2.DescribeInstancesInput{
@@ -395,6 +446,16 @@ func (c *AwsCloud) DestroyInstance(instanceID, publicIP string, releasePublicIP
 	return nil
 }
 
+// StopInstance stops an EC2 instance with the given ID, keeping its storage.
+func (c *AwsCloud) StopInstance(instanceID string) error {
+	if _, err := c.ec2Client.StopInstances(c.ctx, &ec2.StopInstancesInput{
+		InstanceIds: []string{instanceID},
+	}); err != nil {
+		return err
+	}
+	return c.WaitForEC2Instances([]string{instanceID}, types.InstanceStateNameStopped)
+}
+
 // CreateEIP creates an Elastic IP address.
 func (c *AwsCloud) CreateEIP(prefix string) (string, string, error) {
 	if addr, err := c.ec2Client.AllocateAddress(c.ctx, &ec2.AllocateAddressInput{
@@ -407,15 +468,15 @@ func (c *AwsCloud) CreateEIP(prefix string) (string, string, error) {
 						Value: aws.String(prefix),
 					},
 					{
-						Key:   aws.String("Managed-By"),
-						Value: aws.String("avalanche-cli"),
+						Key:   aws.String(managedByTagKey),
+						Value: aws.String(managedByTagValue),
 					},
 				},
 			},
 		},
 	}); err != nil {
 		if isEIPQuotaExceededError(err) {
-			return "", "", fmt.Errorf("elastic IP quota exceeded: %w", err)
+			return "", "", fmt.Errorf("%w: %w", ErrEIPQuotaExceeded, err)
 		}
 		return "", "", err
 	} else {
@@ -434,22 +495,129 @@ func (c *AwsCloud) AssociateEIP(instanceID, allocationID string) error {
 	return nil
 }
 
-// CreateAndDownloadKeyPair creates a new key pair and downloads the private key material to the specified file path.
-func (c *AwsCloud) CreateAndDownloadKeyPair(keyName string, privateKeyFilePath string) error {
-	createKeyPairOutput, err := c.ec2Client.CreateKeyPair(c.ctx, &ec2.CreateKeyPairInput{
-		KeyName: aws.String(keyName),
-	})
+// ElasticIP is an Elastic IP address allocated in the account
+type ElasticIP struct {
+	AllocationID string
+	PublicIP     string
+}
+
+// GetUnassociatedEIPs returns the Elastic IP addresses of the region allocated by avalanche-cli that
+// are not associated to any instance or network interface, so they can be reused instead of allocating
+// new ones. Addresses not allocated by avalanche-cli are never returned, as they would be released
+// when the nodes using them are destroyed
+func (c *AwsCloud) GetUnassociatedEIPs() ([]ElasticIP, error) {
+	addressOutput, err := c.ec2Client.DescribeAddresses(c.ctx, unassociatedEIPsInput())
 	if err != nil {
-		return err
+		return nil, err
 	}
-	privateKeyMaterial := *createKeyPairOutput.KeyMaterial
-	err = os.WriteFile(privateKeyFilePath, []byte(privateKeyMaterial), 0o600)
-	if err != nil {
+	eips := []ElasticIP{}
+	for _, address := range addressOutput.Addresses {
+		if address.AssociationId != nil || address.AllocationId == nil || address.PublicIp == nil {
+			continue
+		}
+		eips = append(eips, ElasticIP{
+			AllocationID: *address.AllocationId,
+			PublicIP:     *address.PublicIp,
+		})
+	}
+	return eips, nil
+}
+
+// unassociatedEIPsInput returns the input to describe the VPC Elastic IP addresses allocated by avalanche-cli
+func unassociatedEIPsInput() *ec2.DescribeAddressesInput {
+	return &ec2.DescribeAddressesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("domain"), Values: []string{"vpc"}},
+			{Name: aws.String("tag:" + managedByTagKey), Values: []string{managedByTagValue}},
+		},
+	}
+}
+
+// SelectEIPsToReuse picks up to [count] addresses of [available] to be reused, by public IP order.
+// Returns the picked addresses, and how many new ones still need to be allocated
+func SelectEIPsToReuse(available []ElasticIP, count int) ([]ElasticIP, int) {
+	candidates := append([]ElasticIP{}, available...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].PublicIP < candidates[j].PublicIP
+	})
+	reused := candidates[:min(count, len(candidates))]
+	return reused, count - len(reused)
+}
+
+// CreateAndDownloadKeyPair creates a new key pair and downloads the private key material to the specified file path.
+func (c *AwsCloud) CreateAndDownloadKeyPair(keyName string, privateKeyFilePath string, keyAlgorithm string) error {
+	var privateKeyMaterial []byte
+	if keyAlgorithm == SSHKeyAlgorithmECDSA {
+		// AWS can't generate ECDSA key pairs, but accepts importing them
+		var publicKeyMaterial []byte
+		var err error
+		privateKeyMaterial, publicKeyMaterial, err = newECDSAKeyPair()
+		if err != nil {
+			return err
+		}
+		if _, err := c.ec2Client.ImportKeyPair(c.ctx, &ec2.ImportKeyPairInput{
+			KeyName:           aws.String(keyName),
+			PublicKeyMaterial: publicKeyMaterial,
+		}); err != nil {
+			return err
+		}
+	} else {
+		createKeyPairOutput, err := c.ec2Client.CreateKeyPair(c.ctx, createKeyPairInput(keyName, keyAlgorithm))
+		if err != nil {
+			return err
+		}
+		privateKeyMaterial = []byte(*createKeyPairOutput.KeyMaterial)
+	}
+	if err := os.WriteFile(privateKeyFilePath, privateKeyMaterial, 0o600); err != nil {
+		// the key pair can't be used without its private key, so it is not left behind
+		if deleteErr := c.DeleteKeyPair(keyName); deleteErr != nil {
+			return fmt.Errorf("%w, and key pair %s could not be deleted: %w", err, keyName, deleteErr)
+		}
 		return err
 	}
 	return nil
 }
 
+// ValidateSSHKeyAlgorithm checks that key pairs can be created with [keyAlgorithm]
+func ValidateSSHKeyAlgorithm(keyAlgorithm string) error {
+	if !slices.Contains(SSHKeyAlgorithms, keyAlgorithm) {
+		return fmt.Errorf("invalid ssh key algorithm %q. Accepted algorithms are %s", keyAlgorithm, strings.Join(SSHKeyAlgorithms, ", "))
+	}
+	return nil
+}
+
+// createKeyPairInput returns the input to create the key pair [keyName] of type [keyAlgorithm]
+// in AWS. RSA keys, the default, are 2048 bits long. ed25519 keys are returned in OpenSSH format
+func createKeyPairInput(keyName string, keyAlgorithm string) *ec2.CreateKeyPairInput {
+	keyType := types.KeyTypeRsa
+	if keyAlgorithm == SSHKeyAlgorithmED25519 {
+		keyType = types.KeyTypeEd25519
+	}
+	return &ec2.CreateKeyPairInput{
+		KeyName:   aws.String(keyName),
+		KeyType:   keyType,
+		KeyFormat: types.KeyFormatPem,
+	}
+}
+
+// newECDSAKeyPair generates a P-256 ECDSA key pair, returning the PEM encoded private key
+// and the public key in authorized_keys format
+func newECDSAKeyPair() ([]byte, []byte, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyBytes}), ssh.MarshalAuthorizedKey(publicKey), nil
+}
+
 // DeleteKeyPair deletes an existing key pair in AWS console
 func (c *AwsCloud) DeleteKeyPair(keyName string) error {
 	_, err := c.ec2Client.DeleteKeyPair(c.ctx, &ec2.DeleteKeyPairInput{
@@ -478,64 +646,87 @@ func (c *AwsCloud) UploadSSHIdentityKeyPair(keyName string, identity string) err
 	return err
 }
 
-// SetupSecurityGroup sets up a security group for the AwsCloud instance.
-func (c *AwsCloud) SetupSecurityGroup(ipAddress, securityGroupName string) (string, error) {
-	sgID, err := c.CreateSecurityGroup(securityGroupName, "Allow SSH, AVAX HTTP outbound traffic")
-	if err != nil {
-		return "", err
-	}
-	if err := c.AddSecurityGroupRule(sgID, "ingress", "tcp", ipAddress, constants.SSHTCPPort); err != nil {
-		return "", err
-	}
-	if err := c.AddSecurityGroupRule(sgID, "ingress", "tcp", ipAddress, constants.AvalanchegoAPIPort); err != nil {
-		return "", err
-	}
-	if err := c.AddSecurityGroupRule(sgID, "ingress", "tcp", ipAddress, constants.AvalanchegoMonitoringPort); err != nil {
-		return "", err
-	}
-	if err := c.AddSecurityGroupRule(sgID, "ingress", "tcp", ipAddress, constants.AvalanchegoGrafanaPort); err != nil {
-		return "", err
+// securityGroupRule is an ingress rule of the security group created by SetupSecurityGroup
+type securityGroupRule struct {
+	ip   string
+	port int32
+}
+
+// securityGroupIngressRules returns the ingress rules for a new security group, allowing
+// [accessCIDRs] (or [ipAddress] if empty) to access ssh and avalanchego http on [httpPort],
+// [ipAddress] to access monitoring, and everyone to access avalanchego staking on [stakingPort]
+func securityGroupIngressRules(ipAddress string, accessCIDRs []string, httpPort, stakingPort int32) []securityGroupRule {
+	if len(accessCIDRs) == 0 {
+		accessCIDRs = []string{ipAddress}
+	}
+	rules := []securityGroupRule{}
+	for _, cidr := range accessCIDRs {
+		rules = append(rules,
+			securityGroupRule{ip: cidr, port: constants.SSHTCPPort},
+			securityGroupRule{ip: cidr, port: httpPort},
+		)
 	}
-	if err := c.AddSecurityGroupRule(sgID, "ingress", "tcp", "0.0.0.0/0", constants.AvalanchegoLokiPort); err != nil {
+	return append(rules, []securityGroupRule{
+		{ip: ipAddress, port: constants.AvalanchegoMonitoringPort},
+		{ip: ipAddress, port: constants.AvalanchegoGrafanaPort},
+		{ip: "0.0.0.0/0", port: constants.AvalanchegoLokiPort},
+		{ip: "0.0.0.0/0", port: stakingPort},
+	}...)
+}
+
+// SetupSecurityGroup sets up a security group for the AwsCloud instance, in [vpcID] if not empty.
+// If [accessCIDRs] is not empty, ssh and http access is granted to them instead of [ipAddress].
+func (c *AwsCloud) SetupSecurityGroup(ipAddress string, accessCIDRs []string, securityGroupName, vpcID string, httpPort, stakingPort int32) (string, error) {
+	sgID, err := c.CreateSecurityGroup(securityGroupName, "Allow SSH, AVAX HTTP outbound traffic", vpcID)
+	if err != nil {
 		return "", err
 	}
-	if err := c.AddSecurityGroupRule(sgID, "ingress", "tcp", "0.0.0.0/0", constants.AvalanchegoP2PPort); err != nil {
-		return "", err
+	for _, rule := range securityGroupIngressRules(ipAddress, accessCIDRs, httpPort, stakingPort) {
+		if err := c.AddSecurityGroupRule(sgID, "ingress", "tcp", rule.ip, rule.port); err != nil {
+			// a group missing rules would be reused as is by later runs, so it is not left behind
+			if deleteErr := c.DeleteSecurityGroup(sgID); deleteErr != nil {
+				return "", fmt.Errorf("%w, and security group %s could not be deleted: %w", err, sgID, deleteErr)
+			}
+			return "", err
+		}
 	}
 	return sgID, nil
 }
 
 // CheckIPInSg checks if the IP is present in the SecurityGroup.
+// Both IPv4 and IPv6 addresses and ranges are supported
 func CheckIPInSg(sg *types.SecurityGroup, currentIP string, port int32) bool {
-	if !strings.Contains(currentIP, "/") {
-		currentIP = fmt.Sprintf("%s/32", currentIP) // add netmask /32 if missing
-	}
+	currentIP = utils.IPToCIDR(currentIP)
 	for _, ipPermission := range sg.IpPermissions {
-		for _, ipRange := range ipPermission.IpRanges {
-			cidr := *ipRange.CidrIp
-			switch {
-			case cidr == "0.0.0.0/0" || cidr == currentIP:
-				if ipPermission.FromPort != nil && *ipPermission.FromPort == port {
-					return true
-				}
-			default:
-				_, ipNet, err := net.ParseCIDR(cidr)
-				if err != nil {
-					continue
-				}
-				ip := net.ParseIP(strings.Split(currentIP, "/")[0])
-				if ip == nil {
-					continue
-				}
-				if ipNet.Contains(ip) && ipPermission.FromPort != nil && *ipPermission.FromPort == port {
-					return true
-				}
+		if ipPermission.FromPort == nil || *ipPermission.FromPort != port {
+			continue
+		}
+		cidrs := utils.Map(ipPermission.IpRanges, func(r types.IpRange) string { return aws.ToString(r.CidrIp) })
+		cidrs = append(cidrs, utils.Map(ipPermission.Ipv6Ranges, func(r types.Ipv6Range) string { return aws.ToString(r.CidrIpv6) })...)
+		for _, cidr := range cidrs {
+			if cidr == currentIP || cidrContains(cidr, currentIP) {
+				return true
 			}
 		}
 	}
 	return false
 }
 
+// cidrContains tells if the range [cidr] contains the whole range [otherCIDR]
+func cidrContains(cidr, otherCIDR string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	otherIP, otherIPNet, err := net.ParseCIDR(otherCIDR)
+	if err != nil {
+		return false
+	}
+	ones, bits := ipNet.Mask.Size()
+	otherOnes, otherBits := otherIPNet.Mask.Size()
+	return bits == otherBits && ones <= otherOnes && ipNet.Contains(otherIP)
+}
+
 // CheckKeyPairExists checks if the specified key pair exists in the AWS Cloud.
 func (c *AwsCloud) CheckKeyPairExists(kpName string) (bool, error) {
 	keyPairInput := &ec2.DescribeKeyPairsInput{
@@ -581,6 +772,121 @@ func (c *AwsCloud) GetUbuntuAMIID(arch string, ubuntuVerLTS string) (string, err
 	return *amiID, nil
 }
 
+// AllRegionsAMIKey is the AMI overrides key that applies to every region
+const AllRegionsAMIKey = ""
+
+var amiIDRegex = regexp.MustCompile(`^ami-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+// ValidateAMIID checks [amiID] has the format of an AWS AMI ID
+func ValidateAMIID(amiID string) error {
+	if !amiIDRegex.MatchString(amiID) {
+		return fmt.Errorf("invalid AMI ID %q: expected ami- followed by 8 or 17 hexadecimal characters", amiID)
+	}
+	return nil
+}
+
+// ParseAMIOverrides parses AMI overrides given as [region=]amiID into a map from
+// region to AMI ID. An AMI ID without region is set under AllRegionsAMIKey
+func ParseAMIOverrides(entries []string) (map[string]string, error) {
+	amiOverrides := map[string]string{}
+	for _, entry := range entries {
+		region, amiID := AllRegionsAMIKey, entry
+		if i := strings.Index(entry, "="); i != -1 {
+			region, amiID = strings.TrimSpace(entry[:i]), entry[i+1:]
+			if region == "" {
+				return nil, fmt.Errorf("invalid AMI override %q: region must not be empty", entry)
+			}
+		}
+		amiID = strings.TrimSpace(amiID)
+		if err := ValidateAMIID(amiID); err != nil {
+			return nil, err
+		}
+		if _, ok := amiOverrides[region]; ok {
+			if region == AllRegionsAMIKey {
+				return nil, fmt.Errorf("only one AMI without region can be given")
+			}
+			return nil, fmt.Errorf("more than one AMI given for region %s", region)
+		}
+		amiOverrides[region] = amiID
+	}
+	return amiOverrides, nil
+}
+
+const (
+	nameTagKey         = "Name"
+	managedByTagKey    = "Managed-By"
+	managedByTagValue  = "avalanche-cli"
+	maxTagsPerResource = 50
+	maxTagKeyLen       = 128
+	maxTagValueLen     = 256
+)
+
+var tagRegex = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+
+// InstanceTags returns the default Name and Managed-By tags of an instance named [name],
+// followed by [tags] sorted by key
+func InstanceTags(name string, tags map[string]string) []types.Tag {
+	instanceTags := []types.Tag{
+		{Key: aws.String(nameTagKey), Value: aws.String(name)},
+		{Key: aws.String(managedByTagKey), Value: aws.String(managedByTagValue)},
+	}
+	keys := maps.Keys(tags)
+	slices.Sort(keys)
+	for _, key := range keys {
+		instanceTags = append(instanceTags, types.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+	}
+	return instanceTags
+}
+
+// ValidateTags checks that [tags] can be added to the default tags of an instance,
+// following the AWS tag restrictions
+func ValidateTags(tags map[string]string) error {
+	if maxTags := maxTagsPerResource - 2; len(tags) > maxTags {
+		return fmt.Errorf("at most %d tags can be given, got %d", maxTags, len(tags))
+	}
+	for key, value := range tags {
+		switch {
+		case key == nameTagKey || key == managedByTagKey:
+			return fmt.Errorf("tag %s is set by avalanche-cli and can't be given", key)
+		case strings.HasPrefix(strings.ToLower(key), "aws:"):
+			return fmt.Errorf("invalid tag %s: the aws: prefix is reserved for AWS use", key)
+		case utf8.RuneCountInString(key) > maxTagKeyLen:
+			return fmt.Errorf("invalid tag %s: key can be at most %d characters long", key, maxTagKeyLen)
+		case utf8.RuneCountInString(value) > maxTagValueLen:
+			return fmt.Errorf("invalid tag %s: value can be at most %d characters long", key, maxTagValueLen)
+		case !tagRegex.MatchString(key) || !tagRegex.MatchString(value):
+			return fmt.Errorf("invalid tag %s=%s: only letters, numbers, spaces and _.:/=+-@ are allowed", key, value)
+		}
+	}
+	return nil
+}
+
+// GetAMIOverride returns the AMI override for [region], if any
+func GetAMIOverride(amiOverrides map[string]string, region string) (string, bool) {
+	if amiID, ok := amiOverrides[region]; ok {
+		return amiID, true
+	}
+	amiID, ok := amiOverrides[AllRegionsAMIKey]
+	return amiID, ok
+}
+
+// CheckAMI checks that [amiID] is available in the region and matches [arch]
+func (c *AwsCloud) CheckAMI(amiID string, arch string) error {
+	images, err := c.ec2Client.DescribeImages(c.ctx, &ec2.DescribeImagesInput{
+		ImageIds: []string{amiID},
+	})
+	if err != nil {
+		return err
+	}
+	if len(images.Images) == 0 {
+		return fmt.Errorf("amazon machine image %s not found", amiID)
+	}
+	if imageArch := string(images.Images[0].Architecture); imageArch != arch {
+		return fmt.Errorf("amazon machine image %s has architecture %s, but the instance type requires %s", amiID, imageArch, arch)
+	}
+	return nil
+}
+
 // ListRegions returns a list of all AWS regions.
 func (c *AwsCloud) ListRegions() ([]string, error) {
 	regions, err := c.ec2Client.DescribeRegions(c.ctx, &ec2.DescribeRegionsInput{})
@@ -596,8 +902,13 @@ func (c *AwsCloud) ListRegions() ([]string, error) {
 
 // isEIPQuotaExceededError checks if the error is related to exceeding the quota for Elastic IP addresses.
 func isEIPQuotaExceededError(err error) bool {
-	// You may need to adjust this function based on the actual error messages returned by AWS
-	return err != nil && (utils.ContainsIgnoreCase(err.Error(), "limit exceeded") || utils.ContainsIgnoreCase(err.Error(), "elastic ip address limit exceeded"))
+	if err == nil {
+		return false
+	}
+	// AWS reports the AddressLimitExceeded error code, older messages only mention the limit
+	return strings.Contains(err.Error(), constants.EIPLimitErr) ||
+		utils.ContainsIgnoreCase(err.Error(), "limit exceeded") ||
+		utils.ContainsIgnoreCase(err.Error(), "elastic ip address limit exceeded")
 }
 
 // GetInstanceTypeArch returns the architecture of the given instance type.
@@ -762,3 +1073,41 @@ func (c *AwsCloud) ChangeInstanceType(instanceID, instanceType string) error {
 	}
 	return nil
 }
+
+var (
+	vpcIDRegex    = regexp.MustCompile(`^vpc-([0-9a-f]{8}|[0-9a-f]{17})$`)
+	subnetIDRegex = regexp.MustCompile(`^subnet-([0-9a-f]{8}|[0-9a-f]{17})$`)
+)
+
+// ValidateVPCPlacement checks that [vpcID] and [subnetID] are either both empty
+// or both given with the format of AWS VPC and subnet IDs
+func ValidateVPCPlacement(vpcID, subnetID string) error {
+	switch {
+	case vpcID == "" && subnetID == "":
+		return nil
+	case vpcID == "" || subnetID == "":
+		return fmt.Errorf("VPC ID and subnet ID must be provided together")
+	case !vpcIDRegex.MatchString(vpcID):
+		return fmt.Errorf("invalid VPC ID %q: expected vpc- followed by 8 or 17 hexadecimal characters", vpcID)
+	case !subnetIDRegex.MatchString(subnetID):
+		return fmt.Errorf("invalid subnet ID %q: expected subnet- followed by 8 or 17 hexadecimal characters", subnetID)
+	}
+	return nil
+}
+
+// CheckSubnetInVPC checks that [subnetID] exists in the region and belongs to [vpcID]
+func (c *AwsCloud) CheckSubnetInVPC(subnetID, vpcID string) error {
+	subnets, err := c.ec2Client.DescribeSubnets(c.ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: []string{subnetID},
+	})
+	if err != nil {
+		return err
+	}
+	if len(subnets.Subnets) == 0 {
+		return fmt.Errorf("subnet %s not found", subnetID)
+	}
+	if subnetVPCID := aws.ToString(subnets.Subnets[0].VpcId); subnetVPCID != vpcID {
+		return fmt.Errorf("subnet %s belongs to VPC %s, not to %s", subnetID, subnetVPCID, vpcID)
+	}
+	return nil
+}
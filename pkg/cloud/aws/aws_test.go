@@ -3,10 +3,17 @@
 package aws
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 )
 
 // TestCheckIPInSg tests the CheckIPInSg function
@@ -96,4 +103,328 @@ func TestCheckIPInSg(t *testing.T) {
 	if !bothSpecific {
 		t.Errorf("Expected both 1.1.1.1/32 IP addresses to match")
 	}
+
+	// a range is only present if a rule contains all of it
+	subRange := CheckIPInSg(sg, "192.168.1.128/25", 80)
+	if !subRange {
+		t.Errorf("Expected 192.168.1.128/25 to be present in 192.168.1.0/24")
+	}
+	widerRange := CheckIPInSg(sg, "192.168.0.0/16", 80)
+	if widerRange {
+		t.Errorf("Expected 192.168.0.0/16 not to be present, as only 192.168.1.0/24 is allowed")
+	}
+	anyOnRestrictedPort := CheckIPInSg(sg, "0.0.0.0/0", 80)
+	if anyOnRestrictedPort {
+		t.Errorf("Expected 0.0.0.0/0 not to be present on port 80")
+	}
+}
+
+func TestSecurityGroupIngressRules(t *testing.T) {
+	rules := securityGroupIngressRules("1.2.3.4", nil, constants.AvalanchegoAPIPort, constants.AvalanchegoP2PPort)
+	require.Contains(t, rules, securityGroupRule{ip: "1.2.3.4", port: constants.AvalanchegoAPIPort})
+	require.Contains(t, rules, securityGroupRule{ip: "0.0.0.0/0", port: constants.AvalanchegoP2PPort})
+	require.Contains(t, rules, securityGroupRule{ip: "1.2.3.4", port: constants.SSHTCPPort})
+
+	rules = securityGroupIngressRules("1.2.3.4", nil, 19650, 19651)
+	require.Contains(t, rules, securityGroupRule{ip: "1.2.3.4", port: 19650})
+	require.Contains(t, rules, securityGroupRule{ip: "0.0.0.0/0", port: 19651})
+	require.Contains(t, rules, securityGroupRule{ip: "1.2.3.4", port: constants.SSHTCPPort})
+	for _, rule := range rules {
+		require.NotEqual(t, int32(constants.AvalanchegoAPIPort), rule.port)
+		require.NotEqual(t, int32(constants.AvalanchegoP2PPort), rule.port)
+	}
+}
+
+func TestSecurityGroupIngressRulesWithAccessCIDRs(t *testing.T) {
+	cidrs := []string{"10.0.0.0/16", "192.168.1.0/24"}
+	rules := securityGroupIngressRules("1.2.3.4", cidrs, constants.AvalanchegoAPIPort, constants.AvalanchegoP2PPort)
+	for _, cidr := range cidrs {
+		require.Contains(t, rules, securityGroupRule{ip: cidr, port: constants.SSHTCPPort})
+		require.Contains(t, rules, securityGroupRule{ip: cidr, port: constants.AvalanchegoAPIPort})
+	}
+	require.NotContains(t, rules, securityGroupRule{ip: "1.2.3.4", port: constants.SSHTCPPort})
+	require.NotContains(t, rules, securityGroupRule{ip: "1.2.3.4", port: constants.AvalanchegoAPIPort})
+	require.Contains(t, rules, securityGroupRule{ip: "1.2.3.4", port: constants.AvalanchegoMonitoringPort})
+	require.Contains(t, rules, securityGroupRule{ip: "0.0.0.0/0", port: constants.AvalanchegoP2PPort})
+}
+
+func TestIPPermission(t *testing.T) {
+	require := require.New(t)
+	permission := ipPermission("tcp", "2001:db8::1", constants.SSHTCPPort)
+	require.Empty(permission.IpRanges)
+	require.Equal([]types.Ipv6Range{{CidrIpv6: aws.String("2001:db8::1/128")}}, permission.Ipv6Ranges)
+	require.Equal(int32(constants.SSHTCPPort), aws.ToInt32(permission.FromPort))
+	require.Equal(int32(constants.SSHTCPPort), aws.ToInt32(permission.ToPort))
+
+	permission = ipPermission("tcp", "2001:db8::/32", constants.SSHTCPPort)
+	require.Equal([]types.Ipv6Range{{CidrIpv6: aws.String("2001:db8::/32")}}, permission.Ipv6Ranges)
+
+	permission = ipPermission("tcp", "1.2.3.4", constants.SSHTCPPort)
+	require.Empty(permission.Ipv6Ranges)
+	require.Equal([]types.IpRange{{CidrIp: aws.String("1.2.3.4/32")}}, permission.IpRanges)
+}
+
+func TestCheckIPInSgIPv6(t *testing.T) {
+	require := require.New(t)
+	sg := &types.SecurityGroup{
+		IpPermissions: []types.IpPermission{
+			ipPermission("tcp", "2001:db8::1", constants.SSHTCPPort),
+			ipPermission("tcp", "2001:db8:1::/48", constants.AvalanchegoAPIPort),
+			ipPermission("tcp", "0.0.0.0/0", constants.AvalanchegoP2PPort),
+		},
+	}
+	require.True(CheckIPInSg(sg, "2001:db8::1", constants.SSHTCPPort))
+	require.True(CheckIPInSg(sg, "2001:db8::1/128", constants.SSHTCPPort))
+	require.False(CheckIPInSg(sg, "2001:db8::2", constants.SSHTCPPort))
+	require.True(CheckIPInSg(sg, "2001:db8:1::5", constants.AvalanchegoAPIPort))
+	require.False(CheckIPInSg(sg, "2001:db8:2::5", constants.AvalanchegoAPIPort))
+	require.True(CheckIPInSg(sg, "2001:db8:1:1::/64", constants.AvalanchegoAPIPort))
+	require.False(CheckIPInSg(sg, "2001:db8::/32", constants.AvalanchegoAPIPort))
+	// IPv4 any does not grant access to IPv6 addresses
+	require.False(CheckIPInSg(sg, "2001:db8::1", constants.AvalanchegoP2PPort))
+	require.True(CheckIPInSg(sg, "1.2.3.4", constants.AvalanchegoP2PPort))
+}
+
+func TestEIPQuotaErrorClassification(t *testing.T) {
+	require := require.New(t)
+	apiErr := errors.New("operation error EC2: AllocateAddress, https response error StatusCode: 400, api error AddressLimitExceeded: The maximum number of addresses has been reached.")
+	require.True(isEIPQuotaExceededError(apiErr))
+	require.True(isEIPQuotaExceededError(errors.New("Elastic IP address limit exceeded")))
+	require.False(isEIPQuotaExceededError(errors.New("api error UnauthorizedOperation: not authorized")))
+	require.False(isEIPQuotaExceededError(nil))
+
+	// quota errors are kept through the wrapping done by CreateEIP and createEC2Instances
+	err := error(&EIPAllocationError{
+		Region:    "us-east-1",
+		Allocated: 2,
+		Requested: 5,
+		Err:       fmt.Errorf("%w: %w", ErrEIPQuotaExceeded, apiErr),
+	})
+	require.ErrorIs(err, ErrEIPQuotaExceeded)
+	require.ErrorIs(err, apiErr)
+	require.ErrorContains(err, "elastic IP 3 of 5 in AWS[us-east-1]")
+	require.ErrorContains(err, "The maximum number of addresses has been reached")
+
+	err = &EIPAllocationError{Region: "us-east-1", Requested: 1, Err: errors.New("association failed")}
+	require.NotErrorIs(err, ErrEIPQuotaExceeded)
+}
+
+func TestParseAMIOverrides(t *testing.T) {
+	require := require.New(t)
+
+	amiOverrides, err := ParseAMIOverrides([]string{"us-east-1=ami-0123456789abcdef0", "eu-west-1 = ami-12345678"})
+	require.NoError(err)
+	require.Equal(map[string]string{
+		"us-east-1": "ami-0123456789abcdef0",
+		"eu-west-1": "ami-12345678",
+	}, amiOverrides)
+
+	amiID, ok := GetAMIOverride(amiOverrides, "us-east-1")
+	require.True(ok)
+	require.Equal("ami-0123456789abcdef0", amiID)
+	_, ok = GetAMIOverride(amiOverrides, "us-west-2")
+	require.False(ok)
+
+	amiOverrides, err = ParseAMIOverrides([]string{"ami-12345678", "us-east-1=ami-87654321"})
+	require.NoError(err)
+	amiID, ok = GetAMIOverride(amiOverrides, "us-west-2")
+	require.True(ok)
+	require.Equal("ami-12345678", amiID)
+	amiID, ok = GetAMIOverride(amiOverrides, "us-east-1")
+	require.True(ok)
+	require.Equal("ami-87654321", amiID)
+
+	amiOverrides, err = ParseAMIOverrides(nil)
+	require.NoError(err)
+	require.Empty(amiOverrides)
+
+	for _, entries := range [][]string{
+		{"us-east-1=ami-123"},
+		{"us-east-1=img-12345678"},
+		{"=ami-12345678"},
+		{"ami-0123456789ABCDEF0"},
+		{"us-east-1=ami-12345678", "us-east-1=ami-87654321"},
+		{"ami-12345678", "ami-87654321"},
+	} {
+		_, err := ParseAMIOverrides(entries)
+		require.Error(err, entries)
+	}
+}
+
+func TestValidateVPCPlacement(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateVPCPlacement("", ""))
+	require.NoError(ValidateVPCPlacement("vpc-12345678", "subnet-0123456789abcdef0"))
+	require.NoError(ValidateVPCPlacement("vpc-0123456789abcdef0", "subnet-12345678"))
+
+	for _, placement := range [][2]string{
+		{"vpc-12345678", ""},
+		{"", "subnet-12345678"},
+		{"vpc-123", "subnet-12345678"},
+		{"subnet-12345678", "subnet-12345678"},
+		{"vpc-12345678", "vpc-12345678"},
+		{"vpc-12345678", "subnet-0123456789ABCDEF0"},
+	} {
+		require.Error(ValidateVPCPlacement(placement[0], placement[1]), placement)
+	}
+}
+
+func TestCreateSecurityGroupInput(t *testing.T) {
+	require := require.New(t)
+
+	sgInput := createSecurityGroupInput("sg-name", "description", "")
+	require.Equal("sg-name", aws.ToString(sgInput.GroupName))
+	require.Equal("description", aws.ToString(sgInput.Description))
+	require.Nil(sgInput.VpcId)
+
+	sgInput = createSecurityGroupInput("sg-name", "description", "vpc-12345678")
+	require.Equal("vpc-12345678", aws.ToString(sgInput.VpcId))
+}
+
+func TestSelectEIPsToReuse(t *testing.T) {
+	require := require.New(t)
+	available := []ElasticIP{
+		{AllocationID: "eipalloc-3", PublicIP: "3.3.3.3"},
+		{AllocationID: "eipalloc-2", PublicIP: "2.2.2.2"},
+		{AllocationID: "eipalloc-1", PublicIP: "1.1.1.1"},
+	}
+
+	// existing addresses are used before allocating new ones
+	reused, toAllocate := SelectEIPsToReuse(available, 2)
+	require.Equal([]ElasticIP{available[2], available[1]}, reused)
+	require.Zero(toAllocate)
+
+	reused, toAllocate = SelectEIPsToReuse(available, 5)
+	require.Equal([]ElasticIP{available[2], available[1], available[0]}, reused)
+	require.Equal(2, toAllocate)
+
+	reused, toAllocate = SelectEIPsToReuse(nil, 2)
+	require.Empty(reused)
+	require.Equal(2, toAllocate)
+
+	// the input is not reordered
+	require.Equal("eipalloc-3", available[0].AllocationID)
+}
+
+func TestInstanceTags(t *testing.T) {
+	require := require.New(t)
+	tags := InstanceTags("node-prefix", map[string]string{"team": "infra", "cost-center": "1234"})
+	require.Equal([]types.Tag{
+		{Key: aws.String("Name"), Value: aws.String("node-prefix")},
+		{Key: aws.String("Managed-By"), Value: aws.String("avalanche-cli")},
+		{Key: aws.String("cost-center"), Value: aws.String("1234")},
+		{Key: aws.String("team"), Value: aws.String("infra")},
+	}, tags)
+	require.Len(InstanceTags("node-prefix", nil), 2)
+}
+
+func TestValidateTags(t *testing.T) {
+	require := require.New(t)
+	require.NoError(ValidateTags(nil))
+	require.NoError(ValidateTags(map[string]string{"team": "infra", "Project Name": "a/b:c=d+e-f@g.h_i", "empty": ""}))
+	require.ErrorContains(ValidateTags(map[string]string{"Name": "other"}), "set by avalanche-cli")
+	require.ErrorContains(ValidateTags(map[string]string{"Managed-By": "me"}), "set by avalanche-cli")
+	require.ErrorContains(ValidateTags(map[string]string{"AWS:team": "infra"}), "reserved for AWS")
+	require.ErrorContains(ValidateTags(map[string]string{strings.Repeat("k", 129): "v"}), "at most 128")
+	require.NoError(ValidateTags(map[string]string{strings.Repeat("k", 128): strings.Repeat("v", 256)}))
+	require.ErrorContains(ValidateTags(map[string]string{"k": strings.Repeat("v", 257)}), "at most 256")
+	require.ErrorContains(ValidateTags(map[string]string{"team": "in$fra"}), "only letters")
+	tooMany := map[string]string{}
+	for i := 0; i < 49; i++ {
+		tooMany[fmt.Sprintf("tag%d", i)] = "v"
+	}
+	require.ErrorContains(ValidateTags(tooMany), "at most 48 tags")
+	delete(tooMany, "tag0")
+	require.NoError(ValidateTags(tooMany))
+}
+
+func TestCreateKeyPairInput(t *testing.T) {
+	require := require.New(t)
+	for _, tt := range []struct {
+		keyAlgorithm    string
+		expectedKeyType types.KeyType
+	}{
+		{"", types.KeyTypeRsa},
+		{SSHKeyAlgorithmRSA, types.KeyTypeRsa},
+		{SSHKeyAlgorithmED25519, types.KeyTypeEd25519},
+	} {
+		keyPairInput := createKeyPairInput("key-name", tt.keyAlgorithm)
+		require.Equal("key-name", aws.ToString(keyPairInput.KeyName))
+		require.Equal(tt.expectedKeyType, keyPairInput.KeyType, tt.keyAlgorithm)
+		require.Equal(types.KeyFormatPem, keyPairInput.KeyFormat)
+	}
+}
+
+func TestNewECDSAKeyPair(t *testing.T) {
+	require := require.New(t)
+	privateKeyMaterial, publicKeyMaterial, err := newECDSAKeyPair()
+	require.NoError(err)
+	signer, err := ssh.ParsePrivateKey(privateKeyMaterial)
+	require.NoError(err)
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey(publicKeyMaterial)
+	require.NoError(err)
+	require.Equal(ssh.KeyAlgoECDSA256, publicKey.Type())
+	require.Equal(publicKey.Marshal(), signer.PublicKey().Marshal())
+}
+
+func TestValidateSSHKeyAlgorithm(t *testing.T) {
+	require := require.New(t)
+	for _, keyAlgorithm := range SSHKeyAlgorithms {
+		require.NoError(ValidateSSHKeyAlgorithm(keyAlgorithm))
+	}
+	require.ErrorContains(ValidateSSHKeyAlgorithm("dsa"), "invalid ssh key algorithm")
+	require.Error(ValidateSSHKeyAlgorithm(""))
+}
+
+func TestUnassociatedEIPsInput(t *testing.T) {
+	require := require.New(t)
+	// only addresses allocated by the CLI are reused, as they are released on destroy
+	input := unassociatedEIPsInput()
+	filters := map[string][]string{}
+	for _, filter := range input.Filters {
+		filters[aws.ToString(filter.Name)] = filter.Values
+	}
+	require.Equal(map[string][]string{
+		"domain":         {"vpc"},
+		"tag:Managed-By": {"avalanche-cli"},
+	}, filters)
+}
+
+func TestSecurityGroupsInput(t *testing.T) {
+	require := require.New(t)
+	filters := func(sgName, vpcID string) map[string][]string {
+		filters := map[string][]string{}
+		for _, filter := range securityGroupsInput(sgName, vpcID).Filters {
+			filters[aws.ToString(filter.Name)] = filter.Values
+		}
+		return filters
+	}
+	require.Equal(map[string][]string{"group-name": {"sg-name"}}, filters("sg-name", ""))
+	require.Equal(map[string][]string{
+		"group-name": {"sg-name"},
+		"vpc-id":     {"vpc-12345678"},
+	}, filters("sg-name", "vpc-12345678"))
+}
+
+func TestSetRunInstancesNetwork(t *testing.T) {
+	require := require.New(t)
+
+	runInput := &ec2.RunInstancesInput{}
+	setRunInstancesNetwork(runInput, "sg-1", "")
+	require.Equal([]string{"sg-1"}, runInput.SecurityGroupIds)
+	require.Nil(runInput.SubnetId)
+	require.Empty(runInput.NetworkInterfaces)
+
+	// security groups and subnet go in the network interface, as they can't be set on both
+	runInput = &ec2.RunInstancesInput{}
+	setRunInstancesNetwork(runInput, "sg-1", "subnet-12345678")
+	require.Empty(runInput.SecurityGroupIds)
+	require.Nil(runInput.SubnetId)
+	require.Len(runInput.NetworkInterfaces, 1)
+	networkInterface := runInput.NetworkInterfaces[0]
+	require.Equal(int32(0), aws.ToInt32(networkInterface.DeviceIndex))
+	require.Equal("subnet-12345678", aws.ToString(networkInterface.SubnetId))
+	require.Equal([]string{"sg-1"}, networkInterface.Groups)
+	require.True(aws.ToBool(networkInterface.AssociatePublicIpAddress))
 }
@@ -0,0 +1,65 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"golang.org/x/exp/slices"
+)
+
+// regionSuggestionMaxDistance is the max edit distance of a known region from an
+// invalid one, for it to be suggested
+const regionSuggestionMaxDistance = 3
+
+// KnownRegions are the AWS commercial regions. aws-sdk-go-v2 does not expose them, so
+// they are listed here to catch typos before calling AWS. Regions launched after this list
+// was updated are rejected by ValidateRegion, so callers must let users skip the check
+var KnownRegions = []string{
+	"af-south-1",
+	"ap-east-1",
+	"ap-northeast-1",
+	"ap-northeast-2",
+	"ap-northeast-3",
+	"ap-south-1",
+	"ap-south-2",
+	"ap-southeast-1",
+	"ap-southeast-2",
+	"ap-southeast-3",
+	"ap-southeast-4",
+	"ap-southeast-5",
+	"ap-southeast-7",
+	"ca-central-1",
+	"ca-west-1",
+	"eu-central-1",
+	"eu-central-2",
+	"eu-north-1",
+	"eu-south-1",
+	"eu-south-2",
+	"eu-west-1",
+	"eu-west-2",
+	"eu-west-3",
+	"il-central-1",
+	"me-central-1",
+	"me-south-1",
+	"mx-central-1",
+	"sa-east-1",
+	"us-east-1",
+	"us-east-2",
+	"us-west-1",
+	"us-west-2",
+}
+
+// ValidateRegion checks that [region] is a known AWS region, suggesting the closest
+// known one if it looks like a typo
+func ValidateRegion(region string) error {
+	if slices.Contains(KnownRegions, region) {
+		return nil
+	}
+	if suggestion, ok := utils.ClosestMatch(region, KnownRegions, regionSuggestionMaxDistance); ok {
+		return fmt.Errorf("invalid AWS region %q, did you mean %q?", region, suggestion)
+	}
+	return fmt.Errorf("invalid AWS region %q, list of regions available at https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/using-regions-availability-zones.html", region)
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRegion(t *testing.T) {
+	require := require.New(t)
+	for _, region := range []string{"us-east-1", "eu-west-3", "ap-southeast-4"} {
+		require.NoError(ValidateRegion(region))
+	}
+	for region, suggestion := range map[string]string{
+		"us-est-1":   "us-east-1",
+		"us-east1":   "us-east-1",
+		"eu-wset-1":  "eu-west-1",
+		"US-EAST-2":  "",
+		"ca-cental1": "ca-central-1",
+	} {
+		err := ValidateRegion(region)
+		require.ErrorContains(err, "invalid AWS region", region)
+		if suggestion != "" {
+			require.ErrorContains(err, "did you mean \""+suggestion+"\"", region)
+		}
+	}
+	err := ValidateRegion("mars-north-1")
+	require.ErrorContains(err, "invalid AWS region \"mars-north-1\"")
+	require.NotContains(err.Error(), "did you mean")
+	require.Error(ValidateRegion(""))
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+// route53Region is the region Route53 clients are created in, as it is a global service
+const route53Region = "us-east-1"
+
+// Route53 manages the DNS records of the nodes in a Route53 hosted zone
+type Route53 struct {
+	route53Client *route53.Client
+	ctx           context.Context
+	zoneID        string
+}
+
+// NewRoute53 creates a Route53 client for the hosted zone [zoneID]
+func NewRoute53(awsProfile, zoneID string) (*Route53, error) {
+	ctx := context.Background()
+	cfg, err := loadAwsConfig(ctx, awsProfile, route53Region)
+	if err != nil {
+		return nil, err
+	}
+	return &Route53{
+		route53Client: route53.NewFromConfig(cfg),
+		ctx:           ctx,
+		zoneID:        zoneID,
+	}, nil
+}
+
+// GetZoneDomain returns the domain of the hosted zone, without the trailing dot
+func (r *Route53) GetZoneDomain() (string, error) {
+	zoneOutput, err := r.route53Client.GetHostedZone(r.ctx, &route53.GetHostedZoneInput{
+		Id: aws.String(r.zoneID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get Route53 hosted zone %s: %w", r.zoneID, err)
+	}
+	return strings.TrimSuffix(aws.ToString(zoneOutput.HostedZone.Name), "."), nil
+}
+
+// changeARecordsInput returns the input to apply [action] to the A records
+// mapping each DNS name of [records] to its IP
+func changeARecordsInput(zoneID string, action types.ChangeAction, records map[string]string) *route53.ChangeResourceRecordSetsInput {
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	changes := make([]types.Change, 0, len(names))
+	for _, name := range names {
+		changes = append(changes, types.Change{
+			Action: action,
+			ResourceRecordSet: &types.ResourceRecordSet{
+				Name:            aws.String(name),
+				Type:            types.RRTypeA,
+				TTL:             aws.Int64(constants.NodeDNSRecordTTL),
+				ResourceRecords: []types.ResourceRecord{{Value: aws.String(records[name])}},
+			},
+		})
+	}
+	return &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch: &types.ChangeBatch{
+			Comment: aws.String("managed by avalanche-cli"),
+			Changes: changes,
+		},
+	}
+}
+
+// CreateARecords creates or updates the A records mapping each DNS name of [records] to its IP
+func (r *Route53) CreateARecords(records map[string]string) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if _, err := r.route53Client.ChangeResourceRecordSets(r.ctx, changeARecordsInput(r.zoneID, types.ChangeActionUpsert, records)); err != nil {
+		return fmt.Errorf("failed to set DNS records in Route53 hosted zone %s: %w", r.zoneID, err)
+	}
+	return nil
+}
+
+// DeleteARecords deletes the A records mapping each DNS name of [records] to its IP
+func (r *Route53) DeleteARecords(records map[string]string) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if _, err := r.route53Client.ChangeResourceRecordSets(r.ctx, changeARecordsInput(r.zoneID, types.ChangeActionDelete, records)); err != nil {
+		return fmt.Errorf("failed to delete DNS records from Route53 hosted zone %s: %w", r.zoneID, err)
+	}
+	return nil
+}
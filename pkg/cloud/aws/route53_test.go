@@ -0,0 +1,42 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package aws
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeARecordsInput(t *testing.T) {
+	require := require.New(t)
+	input := changeARecordsInput("Z0123456789", types.ChangeActionUpsert, map[string]string{
+		"mycluster-i-0b.nodes.example.com": "3.4.5.6",
+		"mycluster-i-0a.nodes.example.com": "1.2.3.4",
+	})
+	require.Equal("Z0123456789", aws.ToString(input.HostedZoneId))
+	changes := input.ChangeBatch.Changes
+	require.Len(changes, 2)
+	// changes are sorted by DNS name
+	for i, expected := range []struct{ name, ip string }{
+		{"mycluster-i-0a.nodes.example.com", "1.2.3.4"},
+		{"mycluster-i-0b.nodes.example.com", "3.4.5.6"},
+	} {
+		require.Equal(types.ChangeActionUpsert, changes[i].Action)
+		recordSet := changes[i].ResourceRecordSet
+		require.Equal(expected.name, aws.ToString(recordSet.Name))
+		require.Equal(types.RRTypeA, recordSet.Type)
+		require.Equal(int64(constants.NodeDNSRecordTTL), aws.ToInt64(recordSet.TTL))
+		require.Len(recordSet.ResourceRecords, 1)
+		require.Equal(expected.ip, aws.ToString(recordSet.ResourceRecords[0].Value))
+	}
+
+	input = changeARecordsInput("Z0123456789", types.ChangeActionDelete, map[string]string{
+		"mycluster-i-0a.nodes.example.com": "1.2.3.4",
+	})
+	require.Len(input.ChangeBatch.Changes, 1)
+	require.Equal(types.ChangeActionDelete, input.ChangeBatch.Changes[0].Action)
+}
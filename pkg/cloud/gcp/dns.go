@@ -0,0 +1,99 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
+)
+
+// CloudDNS manages the DNS records of the nodes in a Cloud DNS managed zone
+type CloudDNS struct {
+	dnsClient   *dns.Service
+	ctx         context.Context
+	projectID   string
+	managedZone string
+}
+
+// NewCloudDNS creates a Cloud DNS client for [managedZone] of [projectID], using
+// the service account key at [credentialsPath]
+func NewCloudDNS(projectID, managedZone, credentialsPath string, ctx context.Context) (*CloudDNS, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	dnsClient, err := dns.NewService(
+		ctx,
+		option.WithCredentialsFile(credentialsPath),
+		option.WithScopes(dns.NdevClouddnsReadwriteScope),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudDNS{
+		dnsClient:   dnsClient,
+		ctx:         ctx,
+		projectID:   projectID,
+		managedZone: managedZone,
+	}, nil
+}
+
+// GetZoneDomain returns the domain of the managed zone, without the trailing dot
+func (c *CloudDNS) GetZoneDomain() (string, error) {
+	zone, err := c.dnsClient.ManagedZones.Get(c.projectID, c.managedZone).Context(c.ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to get Cloud DNS managed zone %s: %w", c.managedZone, err)
+	}
+	return strings.TrimSuffix(zone.DnsName, "."), nil
+}
+
+// aRecordSets returns the A record sets mapping each DNS name of [records] to its IP
+func aRecordSets(records map[string]string) []*dns.ResourceRecordSet {
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	recordSets := make([]*dns.ResourceRecordSet, 0, len(names))
+	for _, name := range names {
+		recordSets = append(recordSets, &dns.ResourceRecordSet{
+			// Cloud DNS names are fully qualified
+			Name:    strings.TrimSuffix(name, ".") + ".",
+			Type:    "A",
+			Ttl:     constants.NodeDNSRecordTTL,
+			Rrdatas: []string{records[name]},
+		})
+	}
+	return recordSets
+}
+
+// CreateARecords creates the A records mapping each DNS name of [records] to its IP
+func (c *CloudDNS) CreateARecords(records map[string]string) error {
+	if len(records) == 0 {
+		return nil
+	}
+	change := &dns.Change{Additions: aRecordSets(records)}
+	if _, err := c.dnsClient.Changes.Create(c.projectID, c.managedZone, change).Context(c.ctx).Do(); err != nil {
+		return fmt.Errorf("failed to set DNS records in Cloud DNS managed zone %s: %w", c.managedZone, err)
+	}
+	return nil
+}
+
+// DeleteARecords deletes the A records mapping each DNS name of [records] to its IP
+func (c *CloudDNS) DeleteARecords(records map[string]string) error {
+	if len(records) == 0 {
+		return nil
+	}
+	change := &dns.Change{Deletions: aRecordSets(records)}
+	if _, err := c.dnsClient.Changes.Create(c.projectID, c.managedZone, change).Context(c.ctx).Do(); err != nil {
+		return fmt.Errorf("failed to delete DNS records from Cloud DNS managed zone %s: %w", c.managedZone, err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package gcp
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/dns/v1"
+)
+
+func TestARecordSets(t *testing.T) {
+	require := require.New(t)
+	require.Equal([]*dns.ResourceRecordSet{
+		{
+			Name:    "mycluster-node-a.nodes.example.com.",
+			Type:    "A",
+			Ttl:     constants.NodeDNSRecordTTL,
+			Rrdatas: []string{"1.2.3.4"},
+		},
+		{
+			Name:    "mycluster-node-b.nodes.example.com.",
+			Type:    "A",
+			Ttl:     constants.NodeDNSRecordTTL,
+			Rrdatas: []string{"3.4.5.6"},
+		},
+	}, aRecordSets(map[string]string{
+		"mycluster-node-b.nodes.example.com":  "3.4.5.6",
+		"mycluster-node-a.nodes.example.com.": "1.2.3.4",
+	}))
+}
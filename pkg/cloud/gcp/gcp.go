@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/crc32"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -31,6 +33,18 @@ const (
 	gcpRegionAPI  = "https://www.googleapis.com/compute/v1/projects/%s/regions/%s"
 )
 
+const (
+	nameLabelKey         = "name"
+	managedByLabelKey    = "managed-by"
+	managedByLabelValue  = "avalanche-cli"
+	maxLabelsPerResource = 64
+)
+
+var (
+	labelKeyRegex   = regexp.MustCompile(`^\p{Ll}[\p{Ll}\p{N}_-]{0,62}$`)
+	labelValueRegex = regexp.MustCompile(`^[\p{Ll}\p{N}_-]{0,63}$`)
+)
+
 var ErrNodeNotFoundToBeRunning = errors.New("node not found to be running")
 
 type GcpCloud struct {
@@ -116,7 +130,8 @@ func (c *GcpCloud) waitForOperation(operation *compute.Operation) error {
 }
 
 // SetupNetwork creates a new network in GCP
-func (c *GcpCloud) SetupNetwork(ipAddress, networkName string) (*compute.Network, error) {
+// If [accessCIDRs] is not empty, ssh and http access is granted to them instead of [ipAddress].
+func (c *GcpCloud) SetupNetwork(ipAddress string, accessCIDRs []string, networkName string, httpPort, stakingPort uint) (*compute.Network, error) {
 	insertOp, err := c.gcpClient.Networks.Insert(c.projectID, &compute.Network{
 		Name:                  networkName,
 		AutoCreateSubnetworks: true, // Use subnet mode
@@ -141,34 +156,55 @@ func (c *GcpCloud) SetupNetwork(ipAddress, networkName string) (*compute.Network
 	if _, err := c.SetFirewallRule("0.0.0.0/0",
 		fmt.Sprintf("%s-%s", networkName, "default"),
 		networkName,
-		[]string{strconv.Itoa(constants.AvalanchegoP2PPort), strconv.Itoa(constants.AvalanchegoLokiPort)}); err != nil {
+		[]string{strconv.Itoa(int(stakingPort)), strconv.Itoa(constants.AvalanchegoLokiPort)}); err != nil {
 		return nil, err
 	}
+	ipAddressPorts := []string{strconv.Itoa(constants.AvalanchegoMonitoringPort), strconv.Itoa(constants.AvalanchegoGrafanaPort)}
+	if len(accessCIDRs) == 0 {
+		ipAddressPorts = append([]string{strconv.Itoa(constants.SSHTCPPort), strconv.Itoa(int(httpPort))}, ipAddressPorts...)
+	} else {
+		if _, err := c.SetFirewallRuleForSourceRanges(accessCIDRs,
+			AccessFirewallName(networkName, accessCIDRs),
+			networkName,
+			[]string{strconv.Itoa(constants.SSHTCPPort), strconv.Itoa(int(httpPort))}); err != nil {
+			return nil, err
+		}
+	}
 	if _, err := c.SetFirewallRule(ipAddress,
 		fmt.Sprintf("%s-%s", networkName, strings.ReplaceAll(ipAddress, ".", "")),
 		networkName,
-		[]string{
-			strconv.Itoa(constants.SSHTCPPort), strconv.Itoa(constants.AvalanchegoAPIPort),
-			strconv.Itoa(constants.AvalanchegoMonitoringPort), strconv.Itoa(constants.AvalanchegoGrafanaPort),
-		}); err != nil {
+		ipAddressPorts); err != nil {
 		return nil, err
 	}
 
 	return createdNetwork, nil
 }
 
+// AccessFirewallName returns the name of the firewall rule granting ssh and http access to [accessCIDRs]
+func AccessFirewallName(networkName string, accessCIDRs []string) string {
+	sortedCIDRs := slices.Clone(accessCIDRs)
+	slices.Sort(sortedCIDRs)
+	return fmt.Sprintf("%s-access-%08x", networkName, crc32.ChecksumIEEE([]byte(strings.Join(sortedCIDRs, ","))))
+}
+
 // SetFirewallRule creates a new firewall rule in GCP
 func (c *GcpCloud) SetFirewallRule(ipAddress, firewallName, networkName string, ports []string) (*compute.Firewall, error) {
-	if !strings.Contains(ipAddress, "/") {
-		ipAddress = fmt.Sprintf("%s/32", ipAddress) // add netmask /32 if missing
-	}
+	return c.SetFirewallRuleForSourceRanges([]string{ipAddress}, firewallName, networkName, ports)
+}
+
+// SetFirewallRuleForSourceRanges creates a new firewall rule in GCP allowing tcp access from all [sourceRanges]
+func (c *GcpCloud) SetFirewallRuleForSourceRanges(sourceRanges []string, firewallName, networkName string, ports []string) (*compute.Firewall, error) {
+	return c.SetFirewallRuleForProtocol(sourceRanges, firewallName, networkName, "tcp", ports)
+}
+
+// SetFirewallRuleForProtocol creates a new firewall rule in GCP allowing [protocol] access from all [sourceRanges]
+func (c *GcpCloud) SetFirewallRuleForProtocol(sourceRanges []string, firewallName, networkName, protocol string, ports []string) (*compute.Firewall, error) {
+	sourceRanges = utils.Map(sourceRanges, utils.IPToCIDR)
 	firewall := &compute.Firewall{
-		Name:    firewallName,
-		Network: fmt.Sprintf("projects/%s/global/networks/%s", c.projectID, networkName),
-		Allowed: []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: ports}},
-		SourceRanges: []string{
-			ipAddress,
-		},
+		Name:         firewallName,
+		Network:      fmt.Sprintf("projects/%s/global/networks/%s", c.projectID, networkName),
+		Allowed:      []*compute.FirewallAllowed{{IPProtocol: protocol, Ports: ports}},
+		SourceRanges: sourceRanges,
 	}
 
 	insertOp, err := c.gcpClient.Firewalls.Insert(c.projectID, firewall).Do()
@@ -217,7 +253,39 @@ func (c *GcpCloud) SetPublicIP(zone, nodeName string, numNodes int) ([]string, e
 	return publicIP, nil
 }
 
-// SetupInstances creates GCP instances
+// InstanceLabels returns the default name and managed-by labels of an instance named [name],
+// together with [labels]
+func InstanceLabels(name string, labels map[string]string) map[string]string {
+	instanceLabels := map[string]string{
+		nameLabelKey:      name,
+		managedByLabelKey: managedByLabelValue,
+	}
+	for key, value := range labels {
+		instanceLabels[key] = value
+	}
+	return instanceLabels
+}
+
+// ValidateLabels checks that [labels] can be added to the default labels of an instance,
+// following the GCP label restrictions
+func ValidateLabels(labels map[string]string) error {
+	if maxLabels := maxLabelsPerResource - 2; len(labels) > maxLabels {
+		return fmt.Errorf("at most %d labels can be given, got %d", maxLabels, len(labels))
+	}
+	for key, value := range labels {
+		switch {
+		case key == nameLabelKey || key == managedByLabelKey:
+			return fmt.Errorf("label %s is set by avalanche-cli and can't be given", key)
+		case !labelKeyRegex.MatchString(key):
+			return fmt.Errorf("invalid label key %s: must start with a lowercase letter, and have at most 63 lowercase letters, numbers, _ or -", key)
+		case !labelValueRegex.MatchString(value):
+			return fmt.Errorf("invalid label %s=%s: value can have at most 63 lowercase letters, numbers, _ or -", key, value)
+		}
+	}
+	return nil
+}
+
+// SetupInstances creates GCP instances. [labels] are added to the default ones
 func (c *GcpCloud) SetupInstances(
 	cliDefaultName,
 	zone,
@@ -229,6 +297,7 @@ func (c *GcpCloud) SetupInstances(
 	staticIP []string,
 	numNodes int,
 	forMonitoring bool,
+	labels map[string]string,
 ) ([]*compute.Instance, error) {
 	parallelism := 8
 	if len(staticIP) > 0 && len(staticIP) != numNodes {
@@ -280,10 +349,7 @@ func (c *GcpCloud) SetupInstances(
 				Scheduling: &compute.Scheduling{
 					AutomaticRestart: &automaticRestart,
 				},
-				Labels: map[string]string{
-					"name":       cliDefaultName,
-					"managed-by": "avalanche-cli",
-				},
+				Labels: InstanceLabels(cliDefaultName, labels),
 			}
 			if staticIP != nil {
 				instance.NetworkInterfaces[0].AccessConfigs[0].NatIP = staticIP[currentIndex]
@@ -436,6 +502,15 @@ func (c *GcpCloud) DestroyGCPNode(nodeConfig models.NodeConfig, clusterName stri
 	return nil
 }
 
+// StopInstance stops GCP instance in given zone, keeping its storage
+func (c *GcpCloud) StopInstance(instanceID, zone string) error {
+	op, err := c.gcpClient.Instances.Stop(c.projectID, zone, instanceID).Do()
+	if err != nil {
+		return err
+	}
+	return c.waitForOperation(op)
+}
+
 // AddFirewall adds firewall into an existing project in GCP
 func (c *GcpCloud) AddFirewall(publicIP, networkName, projectName, firewallName string, ports []string, checkMonitoring bool) error {
 	firewallExists, err := c.CheckFirewallExists(firewallName, checkMonitoring)
@@ -451,7 +526,7 @@ func (c *GcpCloud) AddFirewall(publicIP, networkName, projectName, firewallName
 			Name:         firewallName,
 			Allowed:      []*compute.FirewallAllowed{&allowedFirewall},
 			Network:      fmt.Sprintf("global/networks/%s", networkName),
-			SourceRanges: []string{publicIP + constants.IPAddressSuffix},
+			SourceRanges: []string{utils.IPToCIDR(publicIP)},
 		}
 		instancesStopCall := c.gcpClient.Firewalls.Insert(projectName, &firewall)
 		if _, err = instancesStopCall.Do(); err != nil {
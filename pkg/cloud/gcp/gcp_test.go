@@ -0,0 +1,42 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package gcp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceLabels(t *testing.T) {
+	require := require.New(t)
+	require.Equal(map[string]string{
+		"name":        "node-prefix",
+		"managed-by":  "avalanche-cli",
+		"team":        "infra",
+		"cost-center": "1234",
+	}, InstanceLabels("node-prefix", map[string]string{"team": "infra", "cost-center": "1234"}))
+	require.Len(InstanceLabels("node-prefix", nil), 2)
+}
+
+func TestValidateLabels(t *testing.T) {
+	require := require.New(t)
+	require.NoError(ValidateLabels(nil))
+	require.NoError(ValidateLabels(map[string]string{"team": "infra", "cost_center-2": "1234", "empty": ""}))
+	require.ErrorContains(ValidateLabels(map[string]string{"name": "other"}), "set by avalanche-cli")
+	require.ErrorContains(ValidateLabels(map[string]string{"Team": "infra"}), "invalid label key")
+	require.ErrorContains(ValidateLabels(map[string]string{"1team": "infra"}), "invalid label key")
+	require.ErrorContains(ValidateLabels(map[string]string{strings.Repeat("k", 64): "v"}), "invalid label key")
+	require.NoError(ValidateLabels(map[string]string{strings.Repeat("k", 63): strings.Repeat("v", 63)}))
+	require.ErrorContains(ValidateLabels(map[string]string{"team": "Infra"}), "invalid label team=Infra")
+	require.ErrorContains(ValidateLabels(map[string]string{"team": strings.Repeat("v", 64)}), "invalid label team=")
+	tooMany := map[string]string{}
+	for i := 0; i < 63; i++ {
+		tooMany[fmt.Sprintf("label%d", i)] = "v"
+	}
+	require.ErrorContains(ValidateLabels(tooMany), "at most 62 labels")
+	delete(tooMany, "label0")
+	require.NoError(ValidateLabels(tooMany))
+}
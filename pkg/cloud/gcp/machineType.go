@@ -0,0 +1,81 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gcp
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+const (
+	customMachineTypeMemoryStepMB = 256
+	customMachineTypeMaxCPUs      = 96
+	// memory per vCPU limits, in MB. extended memory machine types have no upper limit
+	customMachineTypeMinMemoryPerCPUMB = 922  // 0.9 GB
+	customMachineTypeMaxMemoryPerCPUMB = 6656 // 6.5 GB
+)
+
+// custom machine types are named [<series>-]custom-<cpus>-<memoryMB>[-ext]
+var customMachineTypeRegex = regexp.MustCompile(`^(?:[a-z][a-z0-9]*-)?custom-(\d+)-(\d+)(-ext)?$`)
+
+// IsCustomMachineType is true if [machineType] is a GCP custom machine type name
+func IsCustomMachineType(machineType string) bool {
+	return customMachineTypeRegex.MatchString(machineType)
+}
+
+// BuildCustomMachineType returns the name of the GCP custom machine type
+// with [cpus] vCPUs and [memoryGB] GB of memory
+func BuildCustomMachineType(cpus int, memoryGB float64) (string, error) {
+	memoryMB := memoryGB * 1024
+	if memoryMB != math.Trunc(memoryMB) {
+		return "", fmt.Errorf("memory %g GB is not a multiple of %d MB", memoryGB, customMachineTypeMemoryStepMB)
+	}
+	if err := validateCustomMachineTypeSize(cpus, int(memoryMB), false); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("custom-%d-%d", cpus, int(memoryMB)), nil
+}
+
+// ValidateCustomMachineType checks that the custom machine type [machineType]
+// has a vCPU and memory combination accepted by GCP
+func ValidateCustomMachineType(machineType string) error {
+	matches := customMachineTypeRegex.FindStringSubmatch(machineType)
+	if matches == nil {
+		return fmt.Errorf("invalid custom machine type %q: expected [<series>-]custom-<cpus>-<memoryMB>[-ext]", machineType)
+	}
+	cpus, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return fmt.Errorf("invalid custom machine type %q: %w", machineType, err)
+	}
+	memoryMB, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return fmt.Errorf("invalid custom machine type %q: %w", machineType, err)
+	}
+	if err := validateCustomMachineTypeSize(cpus, memoryMB, matches[3] != ""); err != nil {
+		return fmt.Errorf("invalid custom machine type %q: %w", machineType, err)
+	}
+	return nil
+}
+
+func validateCustomMachineTypeSize(cpus int, memoryMB int, extendedMemory bool) error {
+	switch {
+	case cpus <= 0:
+		return fmt.Errorf("number of vCPUs must be greater than 0")
+	case cpus > customMachineTypeMaxCPUs:
+		return fmt.Errorf("number of vCPUs must be at most %d", customMachineTypeMaxCPUs)
+	case cpus != 1 && cpus%2 != 0:
+		return fmt.Errorf("number of vCPUs must be 1 or an even number, got %d", cpus)
+	case memoryMB <= 0:
+		return fmt.Errorf("memory must be greater than 0")
+	case memoryMB%customMachineTypeMemoryStepMB != 0:
+		return fmt.Errorf("memory %d MB is not a multiple of %d MB", memoryMB, customMachineTypeMemoryStepMB)
+	case memoryMB < cpus*customMachineTypeMinMemoryPerCPUMB:
+		return fmt.Errorf("memory %d MB is below the minimum of 0.9 GB per vCPU (%d MB for %d vCPUs)", memoryMB, cpus*customMachineTypeMinMemoryPerCPUMB, cpus)
+	case !extendedMemory && memoryMB > cpus*customMachineTypeMaxMemoryPerCPUMB:
+		return fmt.Errorf("memory %d MB is above the maximum of 6.5 GB per vCPU (%d MB for %d vCPUs)", memoryMB, cpus*customMachineTypeMaxMemoryPerCPUMB, cpus)
+	}
+	return nil
+}
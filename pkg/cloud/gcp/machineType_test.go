@@ -0,0 +1,81 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCustomMachineType(t *testing.T) {
+	tests := []struct {
+		name        string
+		cpus        int
+		memoryGB    float64
+		expected    string
+		errContains string
+	}{
+		{name: "standard", cpus: 8, memoryGB: 16, expected: "custom-8-16384"},
+		{name: "single vCPU", cpus: 1, memoryGB: 1, expected: "custom-1-1024"},
+		{name: "fractional memory", cpus: 2, memoryGB: 7.5, expected: "custom-2-7680"},
+		{name: "max memory per vCPU", cpus: 4, memoryGB: 26, expected: "custom-4-26624"},
+		{name: "not a MB amount", cpus: 8, memoryGB: 16.1, errContains: "not a multiple of 256 MB"},
+		{name: "not a multiple of 256MB", cpus: 8, memoryGB: 16.125, errContains: "not a multiple of 256 MB"},
+		{name: "odd vCPUs", cpus: 3, memoryGB: 6, errContains: "1 or an even number"},
+		{name: "zero vCPUs", cpus: 0, memoryGB: 8, errContains: "greater than 0"},
+		{name: "too many vCPUs", cpus: 98, memoryGB: 128, errContains: "at most 96"},
+		{name: "zero memory", cpus: 2, memoryGB: 0, errContains: "memory must be greater than 0"},
+		{name: "memory below minimum", cpus: 8, memoryGB: 7, errContains: "below the minimum"},
+		{name: "memory above maximum", cpus: 2, memoryGB: 13.25, errContains: "above the maximum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineType, err := BuildCustomMachineType(tt.cpus, tt.memoryGB)
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, machineType)
+			require.True(t, IsCustomMachineType(machineType))
+			require.NoError(t, ValidateCustomMachineType(machineType))
+		})
+	}
+}
+
+func TestIsCustomMachineType(t *testing.T) {
+	require.True(t, IsCustomMachineType("custom-8-16384"))
+	require.True(t, IsCustomMachineType("n2-custom-8-16384"))
+	require.True(t, IsCustomMachineType("n2d-custom-2-32768-ext"))
+	require.False(t, IsCustomMachineType("e2-standard-8"))
+	require.False(t, IsCustomMachineType("custom-8"))
+	require.False(t, IsCustomMachineType("custom-8-16gb"))
+}
+
+func TestValidateCustomMachineType(t *testing.T) {
+	tests := []struct {
+		machineType string
+		errContains string
+	}{
+		{machineType: "custom-8-16384"},
+		{machineType: "e2-custom-4-8192"},
+		{machineType: "n2-custom-2-32768-ext"},
+		{machineType: "custom-2-32768", errContains: "above the maximum"},
+		{machineType: "custom-8-16000", errContains: "not a multiple of 256 MB"},
+		{machineType: "custom-5-8192", errContains: "1 or an even number"},
+		{machineType: "custom-4-2048", errContains: "below the minimum"},
+		{machineType: "custom-4-2048-ext", errContains: "below the minimum"},
+		{machineType: "e2-standard-8", errContains: "expected [<series>-]custom-<cpus>-<memoryMB>[-ext]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.machineType, func(t *testing.T) {
+			err := ValidateCustomMachineType(tt.machineType)
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
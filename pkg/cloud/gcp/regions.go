@@ -0,0 +1,82 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gcp
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"golang.org/x/exp/slices"
+)
+
+// regionSuggestionMaxDistance is the max edit distance of a known region from an
+// invalid one, for it to be suggested
+const regionSuggestionMaxDistance = 3
+
+// zoneRegexp splits a zone, as us-east1-b, into its region and zone letter
+var zoneRegexp = regexp.MustCompile(`^([a-z]+-[a-z]+[0-9]+)-([a-z])$`)
+
+// KnownRegions are the GCP compute regions, listed here to catch typos before calling GCP.
+// Regions launched after this list was updated are rejected by ValidateLocation, so callers
+// must let users skip the check
+var KnownRegions = []string{
+	"africa-south1",
+	"asia-east1",
+	"asia-east2",
+	"asia-northeast1",
+	"asia-northeast2",
+	"asia-northeast3",
+	"asia-south1",
+	"asia-south2",
+	"asia-southeast1",
+	"asia-southeast2",
+	"australia-southeast1",
+	"australia-southeast2",
+	"europe-central2",
+	"europe-north1",
+	"europe-southwest1",
+	"europe-west1",
+	"europe-west10",
+	"europe-west12",
+	"europe-west2",
+	"europe-west3",
+	"europe-west4",
+	"europe-west6",
+	"europe-west8",
+	"europe-west9",
+	"me-central1",
+	"me-central2",
+	"me-west1",
+	"northamerica-northeast1",
+	"northamerica-northeast2",
+	"northamerica-south1",
+	"southamerica-east1",
+	"southamerica-west1",
+	"us-central1",
+	"us-east1",
+	"us-east4",
+	"us-east5",
+	"us-south1",
+	"us-west1",
+	"us-west2",
+	"us-west3",
+	"us-west4",
+}
+
+// ValidateLocation checks that [location] is a zone, as us-east1-b, or a region, as us-east1,
+// of a known GCP region, suggesting the closest known location if it looks like a typo
+func ValidateLocation(location string) error {
+	region, zoneSuffix := location, ""
+	if match := zoneRegexp.FindStringSubmatch(location); match != nil {
+		region, zoneSuffix = match[1], "-"+match[2]
+	}
+	if slices.Contains(KnownRegions, region) {
+		return nil
+	}
+	if suggestion, ok := utils.ClosestMatch(region, KnownRegions, regionSuggestionMaxDistance); ok {
+		return fmt.Errorf("invalid GCP location %q, did you mean %q?", location, suggestion+zoneSuffix)
+	}
+	return fmt.Errorf("invalid GCP location %q, list of zones available at https://cloud.google.com/compute/docs/regions-zones/", location)
+}
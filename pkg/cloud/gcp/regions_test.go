@@ -0,0 +1,30 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLocation(t *testing.T) {
+	require := require.New(t)
+	for _, location := range []string{"us-east1", "us-east1-b", "europe-west10-a", "asia-southeast1-c"} {
+		require.NoError(ValidateLocation(location))
+	}
+	for location, suggestion := range map[string]string{
+		"us-est1-b":     "us-east1-b",
+		"us-east-1":     "us-east1",
+		"eruope-west1":  "europe-west1",
+		"us-central1a":  "us-central1",
+		"asia-south3-a": "asia-south1-a",
+	} {
+		require.ErrorContains(ValidateLocation(location), "did you mean \""+suggestion+"\"", location)
+	}
+	err := ValidateLocation("mars-north1-a")
+	require.ErrorContains(err, "invalid GCP location \"mars-north1-a\"")
+	require.NotContains(err.Error(), "did you mean")
+	require.Error(ValidateLocation(""))
+}
@@ -3,6 +3,7 @@
 package cobrautils
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -28,6 +29,28 @@ func NewUsageError(cmd *cobra.Command, err error) UsageError {
 	}
 }
 
+// ExitCodeError is returned by commands that exit with a code other than 1 on error,
+// for them to be used by scripts
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+func NewExitCodeError(code int, err error) ExitCodeError {
+	return ExitCodeError{
+		Code: code,
+		Err:  err,
+	}
+}
+
 func ExactArgs(n int) cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
 		err := cobra.ExactArgs(n)(cmd, args)
@@ -76,7 +99,11 @@ func HandleErrors(err error) {
 			usageErr.cmd.Println()
 			usageErr.cmd.Println(usageErr)
 		} else {
-			ux.Logger.PrintToUser("Error: %s", err)
+			ux.Logger.ErrorToUser("Error: %s", err)
+		}
+		var exitCodeErr ExitCodeError
+		if errors.As(err, &exitCodeErr) {
+			os.Exit(exitCodeErr.Code)
 		}
 		os.Exit(1)
 	}
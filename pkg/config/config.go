@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
@@ -58,6 +59,11 @@ func (*Config) SetConfigValue(key string, value interface{}) error {
 	return err
 }
 
+// BindFlag makes [flag], if set, take precedence over the configuration value of [key]
+func (*Config) BindFlag(key string, flag *pflag.Flag) error {
+	return viper.BindPFlag(key, flag)
+}
+
 func (*Config) ConfigValueIsSet(key string) bool {
 	return viper.IsSet(key)
 }
@@ -70,6 +76,11 @@ func (*Config) GetConfigStringValue(key string) string {
 	return viper.GetString(key)
 }
 
+// GetConfigStringMapValue returns the string values of the configuration map [key], by key.
+func (*Config) GetConfigStringMapValue(key string) map[string]string {
+	return viper.GetStringMapString(key)
+}
+
 func (*Config) LoadNodeConfig() (string, error) {
 	globalConfigs := viper.GetStringMap(constants.ConfigNodeConfigKey)
 	if len(globalConfigs) == 0 {
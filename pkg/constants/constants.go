@@ -29,6 +29,7 @@ const (
 	GenesisSuffix                = SuffixSeparator + GenesisFileName
 	NodeFileName                 = "node.json"
 	NodePrometheusConfigFileName = "prometheus.yml"
+	RemoteWriteConfigFileName    = "prometheus_remote_write.json"
 	NodeCloudConfigFileName      = "node_cloud_config.json"
 	AnsibleDir                   = "ansible"
 	AnsibleHostInventoryFileName = "hosts"
@@ -46,27 +47,22 @@ const (
 	MaxNumOfLogFiles = 5
 	RetainOldFiles   = 0 // retain all old log files
 
-	CloudOperationTimeout = 2 * time.Minute
-
-	ANRRequestTimeout      = 3 * time.Minute
-	APIRequestTimeout      = 30 * time.Second
-	APIRequestLargeTimeout = 2 * time.Minute
-	FastGRPCDialTimeout    = 100 * time.Millisecond
-
-	SSHServerStartTimeout       = 1 * time.Minute
-	SSHScriptTimeout            = 2 * time.Minute
-	SSHLongRunningScriptTimeout = 10 * time.Minute
-	SSHDirOpsTimeout            = 10 * time.Second
-	SSHFileOpsTimeout           = 100 * time.Second
-	SSHPOSTTimeout              = 10 * time.Second
-	SSHSleepBetweenChecks       = 1 * time.Second
-	SSHShell                    = "/bin/bash"
-	AWSVolumeTypeGP3            = "gp3"
-	AWSVolumeTypeIO1            = "io1"
-	AWSVolumeTypeIO2            = "io2"
-	AWSGP3DefaultIOPS           = 3000
-	AWSGP3DefaultThroughput     = 125
-	SimulatePublicNetwork       = "SIMULATE_PUBLIC_NETWORK"
+	FastGRPCDialTimeout = 100 * time.Millisecond
+
+	SSHServerStartLogInterval  = 15 * time.Second
+	SSHSleepBetweenChecks      = 1 * time.Second
+	SSHDownloadMaxAttempts     = 4
+	SSHDownloadRetryInterval   = 5 * time.Second
+	SSHPOSTRetries             = 4
+	SSHPOSTRetryInterval       = 500 * time.Millisecond
+	DBSnapshotProgressInterval = 30 * time.Second
+	SSHShell                   = "/bin/bash"
+	AWSVolumeTypeGP3           = "gp3"
+	AWSVolumeTypeIO1           = "io1"
+	AWSVolumeTypeIO2           = "io2"
+	AWSGP3DefaultIOPS          = 3000
+	AWSGP3DefaultThroughput    = 125
+	SimulatePublicNetwork      = "SIMULATE_PUBLIC_NETWORK"
 
 	FujiAPIEndpoint    = "https://api.avax-test.network"
 	MainnetAPIEndpoint = "https://api.avax.network"
@@ -84,6 +80,9 @@ const (
 
 	HealthCheckInterval = 100 * time.Millisecond
 
+	NodeWaitHealthyTimeout      = 15 * time.Minute
+	NodeWaitHealthyPollInterval = 10 * time.Second
+
 	// it's unlikely anyone would want to name a snapshot `default`
 	// but let's add some more entropy
 	SnapshotsDirName = "snapshots"
@@ -170,6 +169,7 @@ const (
 	EnableSetupCLIFromSource           = false
 	SetupCLIFromSourceBranch           = "main"
 	BuildEnvGolangVersion              = "1.22.1"
+	CustomVMBuildImage                 = "golang:" + BuildEnvGolangVersion
 	IsHealthyJSONFile                  = "isHealthy.json"
 	IsBootstrappedJSONFile             = "isBootstrapped.json"
 	AvalancheGoVersionJSONFile         = "avalancheGoVersion.json"
@@ -186,6 +186,8 @@ const (
 	ConfigAPMCredentialsFileKey   = "credentials-file"
 	ConfigAPMAdminAPIEndpointKey  = "admin-api-endpoint"
 	ConfigNodeConfigKey           = "node-config"
+	ConfigTimeoutsKey             = "timeouts"
+	ConfigNoMetricsKey            = "no-metrics"
 	ConfigMetricsEnabledKey       = "MetricsEnabled"
 	ConfigAuthorizeCloudAccessKey = "AuthorizeCloudAccess"
 	ConfigSingleNodeEnabledKey    = "SingleNodeEnabled"
@@ -210,16 +212,18 @@ const (
 	CliRepoName                   = "avalanche-cli"
 	TeleporterRepoName            = "teleporter"
 	AWMRelayerRepoName            = "awm-relayer"
-	SubnetEVMReleaseURL           = "https://github.com/ava-labs/subnet-evm/releases/download/%s/%s"
 	SubnetEVMArchive              = "subnet-evm_%s_linux_amd64.tar.gz"
-	CloudNodeConfigBasePath       = "/home/ubuntu/.avalanchego/"
-	CloudNodeSubnetEvmBinaryPath  = "/home/ubuntu/.avalanchego/plugins/%s"
-	CloudNodeStakingPath          = "/home/ubuntu/.avalanchego/staking/"
-	CloudNodeConfigPath           = "/home/ubuntu/.avalanchego/configs/"
-	CloudNodePluginsPath          = "/home/ubuntu/.avalanchego/plugins/"
+	CloudNodeConfigBasePath       = "~/.avalanchego/"
+	CloudNodeSubnetEvmBinaryPath  = "~/.avalanchego/plugins/%s"
+	CloudNodeStakingPath          = "~/.avalanchego/staking/"
+	CloudNodeDBPath               = "~/.avalanchego/db/"
+	CloudNodeLogsPath             = "~/.avalanchego/logs/"
+	CloudNodeConfigPath           = "~/.avalanchego/configs/"
+	CloudNodePluginsPath          = "~/.avalanchego/plugins/"
 	DockerNodeConfigPath          = "/.avalanchego/configs/"
 	CloudNodePrometheusConfigPath = "/etc/prometheus/prometheus.yml"
-	CloudNodeCLIConfigBasePath    = "/home/ubuntu/.avalanche-cli/"
+	CloudNodeCLIConfigBasePath    = "~/.avalanche-cli/"
+	CloudNodeDBSnapshotsPath      = "~/.avalanchego-snapshots/"
 	AvalanchegoMonitoringPort     = 9090
 	AvalanchegoMachineMetricsPort = 9100
 	MonitoringDir                 = "monitoring"
@@ -260,6 +264,7 @@ const (
 	ReposDir                    = "repos"
 	SubnetDir                   = "subnets"
 	NodesDir                    = "nodes"
+	DBSnapshotsDir              = "db-snapshots"
 	VMDir                       = "vms"
 	ChainConfigDir              = "chains"
 	AVMKeyName                  = "avm"
@@ -276,6 +281,7 @@ const (
 	MetricsSubnetVM             = "subnet-vm"
 	MetricsCustomVMRepoURL      = "custom-vm-repo-url"
 	MetricsCustomVMBranch       = "custom-vm-branch"
+	MetricsCustomVMTag          = "custom-vm-tag"
 	MetricsCustomVMBuildScript  = "custom-vm-build-script"
 	MetricsCalledFromWiz        = "called-from-wiz"
 	MetricsNumRegions           = "num-region"
@@ -347,8 +353,28 @@ const (
 	// Docker
 	RemoteDockeSocketPath = "/var/run/docker.sock"
 
+	// TTL in seconds of the DNS records created for the nodes with node create --dns-zone
+	NodeDNSRecordTTL = 300
+
 	// Avalanche InterChain Token Transfer
 	ICTTDir    = "avalanche-interchain-token-transfer"
 	ICTTURL    = "https://github.com/ava-labs/avalanche-interchain-token-transfer"
 	ICTTBranch = "main"
 )
+
+// operational timeouts, that can be overridden in the timeouts section of the config file
+var (
+	CloudOperationTimeout = 2 * time.Minute
+
+	ANRRequestTimeout      = 3 * time.Minute
+	APIRequestTimeout      = 30 * time.Second
+	APIRequestLargeTimeout = 2 * time.Minute
+
+	SSHServerStartTimeout       = 1 * time.Minute
+	SSHScriptTimeout            = 2 * time.Minute
+	SSHLongRunningScriptTimeout = 10 * time.Minute
+	SSHDirOpsTimeout            = 10 * time.Second
+	SSHFileOpsTimeout           = 100 * time.Second
+	SSHPOSTTimeout              = 10 * time.Second
+	SSHDBSnapshotTimeout        = 6 * time.Hour
+)
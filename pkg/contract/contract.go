@@ -290,6 +290,21 @@ func TxToMethod(
 	payment *big.Int,
 	methodEsp string,
 	params ...interface{},
+) (*types.Transaction, *types.Receipt, error) {
+	signer, err := evm.NewSignerFromPrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return TxToMethodWithSigner(rpcURL, signer, contractAddress, payment, methodEsp, params...)
+}
+
+func TxToMethodWithSigner(
+	rpcURL string,
+	signer *evm.Signer,
+	contractAddress common.Address,
+	payment *big.Int,
+	methodEsp string,
+	params ...interface{},
 ) (*types.Transaction, *types.Receipt, error) {
 	methodName, methodABI, err := ParseEsp(methodEsp, nil, false, false, payment != nil, false, params...)
 	if err != nil {
@@ -308,7 +323,7 @@ func TxToMethod(
 	}
 	defer client.Close()
 	contract := bind.NewBoundContract(contractAddress, *abi, client, client, client)
-	txOpts, err := evm.GetTxOptsWithSigner(client, privateKey)
+	txOpts, err := signer.GetTxOpts(client)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -363,6 +378,20 @@ func DeployContract(
 	binBytes []byte,
 	methodEsp string,
 	params ...interface{},
+) (common.Address, error) {
+	signer, err := evm.NewSignerFromPrivateKey(privateKey)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return DeployContractWithSigner(rpcURL, signer, binBytes, methodEsp, params...)
+}
+
+func DeployContractWithSigner(
+	rpcURL string,
+	signer *evm.Signer,
+	binBytes []byte,
+	methodEsp string,
+	params ...interface{},
 ) (common.Address, error) {
 	_, methodABI, err := ParseEsp(methodEsp, nil, true, false, false, false, params...)
 	if err != nil {
@@ -382,7 +411,7 @@ func DeployContract(
 		return common.Address{}, err
 	}
 	defer client.Close()
-	txOpts, err := evm.GetTxOptsWithSigner(client, privateKey)
+	txOpts, err := signer.GetTxOpts(client)
 	if err != nil {
 		return common.Address{}, err
 	}
@@ -0,0 +1,98 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+)
+
+const dockerHubTagURLFmt = "https://hub.docker.com/v2/repositories/%s/tags/%s"
+
+var imageDigestRegex = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// ImageTagDigestURL returns the Docker Hub URL that publishes the digest of [image]:[tag]
+func ImageTagDigestURL(image string, tag string) string {
+	if !strings.Contains(image, "/") {
+		// official images live in the library namespace
+		image = "library/" + image
+	}
+	return fmt.Sprintf(dockerHubTagURLFmt, image, tag)
+}
+
+// parseImageTagDigest gets the sha256 digest out of a Docker Hub tag description
+func parseImageTagDigest(tagInfo []byte) (string, error) {
+	var tag struct {
+		Digest string `json:"digest"`
+	}
+	if err := json.Unmarshal(tagInfo, &tag); err != nil {
+		return "", fmt.Errorf("invalid image tag description: %w", err)
+	}
+	if !imageDigestRegex.MatchString(tag.Digest) {
+		return "", fmt.Errorf("invalid image digest %q", tag.Digest)
+	}
+	return tag.Digest, nil
+}
+
+// GetPublishedImageDigest downloads with [downloader] the sha256 digest published for [image]:[tag]
+func GetPublishedImageDigest(downloader application.Downloader, image string, tag string) (string, error) {
+	tagInfo, err := downloader.Download(ImageTagDigestURL(image, tag))
+	if err != nil {
+		return "", fmt.Errorf("failed to get published checksum of image %s:%s: %w", image, tag, err)
+	}
+	digest, err := parseImageTagDigest(tagInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get published checksum of image %s:%s: %w", image, tag, err)
+	}
+	return digest, nil
+}
+
+// parseImageRepoDigests parses the output of docker image inspect for the repo digests
+// of an image, as a JSON list of repo@sha256:<hex> entries
+func parseImageRepoDigests(output []byte) ([]string, error) {
+	repoDigests := []string{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(output))), &repoDigests); err != nil {
+		return nil, fmt.Errorf("invalid image repo digests: %w", err)
+	}
+	return repoDigests, nil
+}
+
+// checkImageDigest checks that [image] was pulled from its repo with content matching [expectedDigest]
+func checkImageDigest(image string, repoDigests []string, expectedDigest string) error {
+	repo, _, _ := strings.Cut(image, ":")
+	actualDigests := []string{}
+	for _, repoDigest := range repoDigests {
+		digestRepo, digest, found := strings.Cut(repoDigest, "@")
+		if !found || digestRepo != repo {
+			continue
+		}
+		if digest == expectedDigest {
+			return nil
+		}
+		actualDigests = append(actualDigests, digest)
+	}
+	if len(actualDigests) == 0 {
+		return fmt.Errorf("checksum of image %s can't be verified: it was not pulled from %s (built locally?)", image, repo)
+	}
+	return fmt.Errorf("checksum mismatch for image %s: expected %s, got %s", image, expectedDigest, strings.Join(actualDigests, ", "))
+}
+
+// VerifyImageDigest checks that [image] on a remote host matches the published [expectedDigest]
+func VerifyImageDigest(host *models.Host, image string, expectedDigest string) error {
+	output, err := host.Command(fmt.Sprintf("docker image inspect --format '{{json .RepoDigests}}' %s", image), nil, constants.SSHScriptTimeout)
+	if err != nil {
+		return err
+	}
+	repoDigests, err := parseImageRepoDigests(output)
+	if err != nil {
+		return err
+	}
+	return checkImageDigest(image, repoDigests, expectedDigest)
+}
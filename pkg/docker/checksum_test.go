@@ -0,0 +1,93 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package docker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageTagDigestURL(t *testing.T) {
+	require.Equal(t,
+		"https://hub.docker.com/v2/repositories/avaplatform/avalanchego/tags/v1.11.5",
+		ImageTagDigestURL("avaplatform/avalanchego", "v1.11.5"),
+	)
+	require.Equal(t,
+		"https://hub.docker.com/v2/repositories/library/ubuntu/tags/22.04",
+		ImageTagDigestURL("ubuntu", "22.04"),
+	)
+}
+
+func TestParseImageTagDigest(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	parsed, err := parseImageTagDigest([]byte(`{"name":"v1.11.5","digest":"` + digest + `","images":[]}`))
+	require.NoError(t, err)
+	require.Equal(t, digest, parsed)
+
+	_, err = parseImageTagDigest([]byte(`{"name":"v1.11.5"}`))
+	require.ErrorContains(t, err, "invalid image digest")
+	_, err = parseImageTagDigest([]byte(`{"digest":"sha256:1234"}`))
+	require.ErrorContains(t, err, "invalid image digest")
+	_, err = parseImageTagDigest([]byte(`not json`))
+	require.ErrorContains(t, err, "invalid image tag description")
+}
+
+func TestGetPublishedImageDigest(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	url := "https://hub.docker.com/v2/repositories/avaplatform/avalanchego/tags/v1.11.5"
+	downloader := &mocks.Downloader{}
+	downloader.On("Download", url).Return([]byte(`{"digest":"`+digest+`"}`), nil).Once()
+	parsed, err := GetPublishedImageDigest(downloader, "avaplatform/avalanchego", "v1.11.5")
+	require.NoError(t, err)
+	require.Equal(t, digest, parsed)
+
+	downloader.On("Download", url).Return(nil, errors.New("connection refused")).Once()
+	_, err = GetPublishedImageDigest(downloader, "avaplatform/avalanchego", "v1.11.5")
+	require.ErrorContains(t, err, "failed to get published checksum of image avaplatform/avalanchego:v1.11.5: connection refused")
+	downloader.AssertExpectations(t)
+}
+
+func TestParseImageRepoDigests(t *testing.T) {
+	repoDigests, err := parseImageRepoDigests([]byte("[\"avaplatform/avalanchego@sha256:abcd\"]\n"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"avaplatform/avalanchego@sha256:abcd"}, repoDigests)
+
+	repoDigests, err = parseImageRepoDigests([]byte("[]\n"))
+	require.NoError(t, err)
+	require.Empty(t, repoDigests)
+
+	_, err = parseImageRepoDigests([]byte("Error: No such image"))
+	require.Error(t, err)
+}
+
+func TestCheckImageDigest(t *testing.T) {
+	image := "avaplatform/avalanchego:v1.11.5"
+	expected := "sha256:" + strings.Repeat("ab", 32)
+	other := "sha256:" + strings.Repeat("cd", 32)
+	tests := []struct {
+		name        string
+		repoDigests []string
+		errContains string
+	}{
+		{name: "match", repoDigests: []string{"avaplatform/avalanchego@" + expected}},
+		{name: "match among several", repoDigests: []string{"mirror.example.com/avalanchego@" + other, "avaplatform/avalanchego@" + expected}},
+		{name: "mismatch", repoDigests: []string{"avaplatform/avalanchego@" + other}, errContains: "checksum mismatch for image " + image + ": expected " + expected + ", got " + other},
+		{name: "locally built", repoDigests: []string{}, errContains: "can't be verified"},
+		{name: "other repo", repoDigests: []string{"mirror.example.com/avalanchego@" + expected}, errContains: "can't be verified"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkImageDigest(image, tt.repoDigests, expected)
+			if tt.errContains == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.errContains)
+			}
+		})
+	}
+}
@@ -7,16 +7,17 @@ import (
 	"bytes"
 	"embed"
 	"fmt"
-	"html/template"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"gopkg.in/yaml.v3"
 )
 
 type dockerComposeInputs struct {
@@ -26,6 +27,12 @@ type dockerComposeInputs struct {
 	E2E                bool
 	E2EIP              string
 	E2ESuffix          string
+	HTTPPort           uint
+	StakingPort        uint
+	ServiceEnv         map[string]map[string]string
+	// remote dirs mounted by the services, under the home of the remote user
+	AvalancheGoDir string
+	ServicesDir    string
 }
 
 //go:embed templates/*.docker-compose.yml
@@ -36,6 +43,9 @@ func renderComposeFile(composePath string, composeDesc string, templateVars dock
 	if err != nil {
 		return nil, err
 	}
+	if templateVars.ServiceEnv, err = quoteServiceEnv(templateVars.ServiceEnv); err != nil {
+		return nil, err
+	}
 	var composeBytes bytes.Buffer
 	t, err := template.New(composeDesc).Parse(string(compose))
 	if err != nil {
@@ -116,7 +126,8 @@ func mergeComposeFiles(host *models.Host, currentComposeFile string, newComposeF
 	if _, err := tmpFile.Write(output); err != nil {
 		return err
 	}
-	ux.Logger.Info("Merged compose files as %s", output)
+	// merged output is not logged as it may include service env secrets
+	ux.Logger.Info("Merged compose files on %s", host.NodeID)
 	if err := pushComposeFile(host, tmpFile.Name(), currentComposeFile, false); err != nil {
 		return err
 	}
@@ -207,24 +218,31 @@ func ComposeOverSSH(
 	timeout time.Duration,
 	composePath string,
 	composeVars dockerComposeInputs,
+) error {
+	return composeFileOverSSH(composeDesc, host, timeout, composePath, composeVars, "")
+}
+
+// composeFileOverSSH sets up a docker-compose file on a remote host over SSH. If [customComposeFile]
+// is given, it replaces the remote compose file instead of the one rendered from [composePath]
+func composeFileOverSSH(
+	composeDesc string,
+	host *models.Host,
+	timeout time.Duration,
+	composePath string,
+	composeVars dockerComposeInputs,
+	customComposeFile string,
 ) error {
 	remoteComposeFile := utils.GetRemoteComposeFile()
 	startTime := time.Now()
-	tmpFile, err := os.CreateTemp("", "avalanchecli-docker-compose-*.yml")
+	composeVars.AvalancheGoDir = host.ExpandHome(constants.CloudNodeConfigBasePath)
+	composeVars.ServicesDir = host.ExpandHome(utils.GetRemoteComposeServicePath(""))
+	localComposeFile, merge, cleanup, err := prepareLocalComposeFile(composePath, composeDesc, composeVars, customComposeFile)
 	if err != nil {
 		return err
 	}
-	defer os.Remove(tmpFile.Name())
-	composeData, err := renderComposeFile(composePath, composeDesc, composeVars)
-	if err != nil {
-		return err
-	}
-
-	if _, err := tmpFile.Write(composeData); err != nil {
-		return err
-	}
+	defer cleanup()
 	ux.Logger.Info("pushComposeFile [%s]%s", host.NodeID, composeDesc)
-	if err := pushComposeFile(host, tmpFile.Name(), remoteComposeFile, true); err != nil {
+	if err := pushComposeFile(host, localComposeFile, remoteComposeFile, merge); err != nil {
 		return err
 	}
 	ux.Logger.Info("ValidateComposeFile [%s]%s", host.NodeID, composeDesc)
@@ -241,6 +259,60 @@ func ComposeOverSSH(
 	return nil
 }
 
+// prepareLocalComposeFile returns the local compose file to push to the remote compose path, whether
+// it should be merged into an existing remote one, and a cleanup func for it. A [customComposeFile]
+// is validated and pushed as is, replacing the remote one. Otherwise, [composePath] is rendered into
+// a temp file
+func prepareLocalComposeFile(
+	composePath string,
+	composeDesc string,
+	composeVars dockerComposeInputs,
+	customComposeFile string,
+) (string, bool, func(), error) {
+	if customComposeFile != "" {
+		if err := ValidateCustomComposeFile(customComposeFile); err != nil {
+			return "", false, nil, err
+		}
+		return customComposeFile, false, func() {}, nil
+	}
+	tmpFile, err := os.CreateTemp("", "avalanchecli-docker-compose-*.yml")
+	if err != nil {
+		return "", false, nil, err
+	}
+	cleanup := func() {
+		_ = os.Remove(tmpFile.Name())
+	}
+	composeData, err := renderComposeFile(composePath, composeDesc, composeVars)
+	if err != nil {
+		cleanup()
+		return "", false, nil, err
+	}
+	if _, err := tmpFile.Write(composeData); err != nil {
+		cleanup()
+		return "", false, nil, err
+	}
+	return tmpFile.Name(), true, cleanup, nil
+}
+
+// ValidateCustomComposeFile checks that a user provided compose file can be parsed, and defines
+// the avalanchego service that node lifecycle commands operate on
+func ValidateCustomComposeFile(composeFile string) error {
+	composeData, err := os.ReadFile(composeFile)
+	if err != nil {
+		return err
+	}
+	var compose struct {
+		Services map[string]interface{} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(composeData, &compose); err != nil {
+		return fmt.Errorf("invalid compose file %s: %w", composeFile, err)
+	}
+	if _, ok := compose.Services[ServiceEnvDefaultService]; !ok {
+		return fmt.Errorf("compose file %s must define the %s service", composeFile, ServiceEnvDefaultService)
+	}
+	return nil
+}
+
 // ListRemoteComposeServices lists the services in a remote docker-compose file.
 func ListRemoteComposeServices(host *models.Host, composeFile string, timeout time.Duration) ([]string, error) {
 	output, err := host.Command(fmt.Sprintf("docker compose -f %s config --services", composeFile), nil, timeout)
@@ -0,0 +1,159 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseServiceEnv(t *testing.T) {
+	require := require.New(t)
+	serviceEnv, err := ParseServiceEnv([]string{
+		"AVAGO_HTTP_ALLOWED_HOSTS=*",
+		"promtail:JAVA_OPTS=-Xmx1g -Dx=y",
+		"avalanchego:EMPTY=",
+	})
+	require.NoError(err)
+	require.Equal(map[string]map[string]string{
+		"avalanchego": {"AVAGO_HTTP_ALLOWED_HOSTS": "*", "EMPTY": ""},
+		"promtail":    {"JAVA_OPTS": "-Xmx1g -Dx=y"},
+	}, serviceEnv)
+
+	for _, entry := range []string{"NOVALUE", "1KEY=v", "unknown:KEY=v", "=v", "avalanchego:BAD-KEY=v"} {
+		_, err := ParseServiceEnv([]string{entry})
+		require.Error(err, entry)
+	}
+	_, err = ParseServiceEnv([]string{"BAD KEY=supersecret"})
+	require.Error(err)
+	require.NotContains(err.Error(), "supersecret")
+}
+
+func TestRenderComposeFileServiceEnv(t *testing.T) {
+	require := require.New(t)
+	serviceEnv := map[string]map[string]string{
+		"avalanchego": {"AVAGO_TOKEN": `s3cr"et $HOME`, "JAVA_OPTS": "-Xmx1g"},
+		"promtail":    {"LEVEL": "debug"},
+	}
+	composeBytes, err := renderComposeFile("templates/avalanchego.docker-compose.yml", "Compose Node", dockerComposeInputs{
+		WithAvalanchego:    true,
+		WithMonitoring:     true,
+		AvalanchegoVersion: "v1.11.0",
+		HTTPPort:           9650,
+		StakingPort:        9651,
+		ServiceEnv:         serviceEnv,
+	})
+	require.NoError(err)
+	var compose struct {
+		Services map[string]struct {
+			Environment map[string]string `yaml:"environment"`
+		} `yaml:"services"`
+	}
+	require.NoError(yaml.Unmarshal(composeBytes, &compose))
+	require.Equal(map[string]string{"AVAGO_TOKEN": `s3cr"et $$HOME`, "JAVA_OPTS": "-Xmx1g"}, compose.Services["avalanchego"].Environment)
+	require.Equal(map[string]string{"LEVEL": "debug"}, compose.Services["promtail"].Environment)
+	require.Empty(compose.Services["node-exporter"].Environment)
+
+	composeBytes, err = renderComposeFile("templates/avalanchego.docker-compose.yml", "Compose Node", dockerComposeInputs{
+		WithAvalanchego:    true,
+		AvalanchegoVersion: "v1.11.0",
+	})
+	require.NoError(err)
+	require.NotContains(string(composeBytes), "environment:")
+}
+
+func TestRenderComposeFileRemoteDirs(t *testing.T) {
+	require := require.New(t)
+	for _, composePath := range []string{
+		"templates/avalanchego.docker-compose.yml",
+		"templates/monitoring.docker-compose.yml",
+		"templates/awmrelayer.docker-compose.yml",
+	} {
+		composeBytes, err := renderComposeFile(composePath, "Compose", dockerComposeInputs{
+			WithAvalanchego: true,
+			WithMonitoring:  true,
+			AvalancheGoDir:  "/home/admin/.avalanchego",
+			ServicesDir:     "/home/admin/.avalanche-cli/services",
+		})
+		require.NoError(err)
+		require.NotContains(string(composeBytes), "/home/ubuntu", composePath)
+	}
+	composeBytes, err := renderComposeFile("templates/avalanchego.docker-compose.yml", "Compose Node", dockerComposeInputs{
+		WithAvalanchego:    true,
+		WithMonitoring:     true,
+		AvalanchegoVersion: "v1.11.0",
+		AvalancheGoDir:     "/home/admin/.avalanchego",
+		ServicesDir:        "/home/admin/.avalanche-cli/services",
+	})
+	require.NoError(err)
+	var compose struct {
+		Services map[string]struct {
+			Volumes []string `yaml:"volumes"`
+		} `yaml:"services"`
+	}
+	require.NoError(yaml.Unmarshal(composeBytes, &compose))
+	require.Equal([]string{"/home/admin/.avalanchego:/.avalanchego:rw"}, compose.Services["avalanchego"].Volumes)
+	require.Equal([]string{
+		"/home/admin/.avalanchego/logs:/logs:ro",
+		"/home/admin/.avalanche-cli/services/promtail:/etc/promtail:ro",
+	}, compose.Services["promtail"].Volumes)
+}
+
+func TestValidateCustomComposeFile(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	writeCompose := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	require.NoError(ValidateCustomComposeFile(writeCompose("valid.yml", `
+services:
+  avalanchego:
+    image: avaplatform/avalanchego:v1.11.0
+  sidecar:
+    image: busybox
+`)))
+	err := ValidateCustomComposeFile(writeCompose("noavalanchego.yml", `
+services:
+  sidecar:
+    image: busybox
+`))
+	require.ErrorContains(err, "must define the avalanchego service")
+	err = ValidateCustomComposeFile(writeCompose("invalid.yml", "services: [avalanchego"))
+	require.ErrorContains(err, "invalid compose file")
+	require.Error(ValidateCustomComposeFile(filepath.Join(dir, "missing.yml")))
+}
+
+func TestPrepareLocalComposeFile(t *testing.T) {
+	require := require.New(t)
+	composeVars := dockerComposeInputs{WithAvalanchego: true, AvalanchegoVersion: "v1.11.0"}
+
+	customComposeFile := filepath.Join(t.TempDir(), "custom.yml")
+	require.NoError(os.WriteFile(customComposeFile, []byte("services:\n  avalanchego:\n    image: custom\n"), 0o600))
+	localComposeFile, merge, cleanup, err := prepareLocalComposeFile("templates/avalanchego.docker-compose.yml", "Compose Node", composeVars, customComposeFile)
+	require.NoError(err)
+	// the custom file is pushed as is, replacing the remote compose file
+	require.Equal(customComposeFile, localComposeFile)
+	require.False(merge)
+	cleanup()
+	require.FileExists(customComposeFile)
+
+	localComposeFile, merge, cleanup, err = prepareLocalComposeFile("templates/avalanchego.docker-compose.yml", "Compose Node", composeVars, "")
+	require.NoError(err)
+	require.NotEqual(customComposeFile, localComposeFile)
+	require.True(merge)
+	composeData, err := os.ReadFile(localComposeFile)
+	require.NoError(err)
+	require.Contains(string(composeData), "v1.11.0")
+	cleanup()
+	require.NoFileExists(localComposeFile)
+
+	_, _, _, err = prepareLocalComposeFile("templates/avalanchego.docker-compose.yml", "Compose Node", composeVars, filepath.Join(t.TempDir(), "missing.yml"))
+	require.Error(err)
+}
@@ -11,8 +11,16 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/remoteconfig"
 )
 
-func prepareAvalanchegoConfig(host *models.Host, networkID string) (string, string, error) {
-	avagoConf := remoteconfig.PrepareAvalancheConfig(host.IP, networkID, nil)
+func prepareAvalanchegoConfig(
+	host *models.Host,
+	networkID string,
+	logLevel string,
+	cChainDBConfig remoteconfig.CChainDBConfig,
+) (string, string, error) {
+	avagoConf := remoteconfig.PrepareAvalancheConfig(host.IP, networkID, nil, host.HTTPPort, host.StakingPort)
+	avagoConf.LogLevel = logLevel
+	avagoConf.PruningEnabled = cChainDBConfig.PruningEnabled
+	avagoConf.StateSyncEnabled = cChainDBConfig.StateSyncEnabled
 	nodeConf, err := remoteconfig.RenderAvalancheNodeConfig(avagoConf)
 	if err != nil {
 		return "", "", err
@@ -0,0 +1,69 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// ServiceEnvDefaultService is the compose service env entries without an
+// explicit service prefix are applied to
+const ServiceEnvDefaultService = "avalanchego"
+
+// ServiceEnvServices are the node compose services that accept extra env
+var ServiceEnvServices = []string{"avalanchego", "promtail", "node-exporter"}
+
+var envKeyRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseServiceEnv parses env entries of the form [service:]KEY=VALUE into a map of
+// service name to env vars. Entries without service are set on avalanchego.
+// Errors never include the entry value, as it may hold secrets.
+func ParseServiceEnv(entries []string) (map[string]map[string]string, error) {
+	serviceEnv := map[string]map[string]string{}
+	for _, entry := range entries {
+		keyPart, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid env entry %q: expected format [service:]KEY=VALUE", keyPart)
+		}
+		service := ServiceEnvDefaultService
+		key := keyPart
+		if serviceName, envKey, hasService := strings.Cut(keyPart, ":"); hasService {
+			service = serviceName
+			key = envKey
+		}
+		if !slices.Contains(ServiceEnvServices, service) {
+			return nil, fmt.Errorf("invalid env entry for %q: unknown service %q, expected one of %s", keyPart, service, ServiceEnvServices)
+		}
+		if !envKeyRegexp.MatchString(key) {
+			return nil, fmt.Errorf("invalid env entry for %q: invalid variable name %q", keyPart, key)
+		}
+		if _, ok := serviceEnv[service]; !ok {
+			serviceEnv[service] = map[string]string{}
+		}
+		serviceEnv[service][key] = value
+	}
+	return serviceEnv, nil
+}
+
+// quoteServiceEnv converts env values into double quoted YAML scalars, escaping
+// $ so that docker compose does not interpolate them
+func quoteServiceEnv(serviceEnv map[string]map[string]string) (map[string]map[string]string, error) {
+	quoted := map[string]map[string]string{}
+	for service, env := range serviceEnv {
+		quoted[service] = map[string]string{}
+		for key, value := range env {
+			quotedValue, err := json.Marshal(strings.ReplaceAll(value, "$", "$$"))
+			if err != nil {
+				return nil, err
+			}
+			quoted[service][key] = string(quotedValue)
+		}
+	}
+	return quoted, nil
+}
@@ -5,10 +5,12 @@ package docker
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 )
 
@@ -88,3 +90,28 @@ func PrepareDockerImageWithRepo(host *models.Host, image string, gitRepo string,
 	ux.Logger.Info("Docker image %s is READY on %s", image, host.NodeID)
 	return nil
 }
+
+// BuildInContainer runs [buildCmd] on a remote host inside a throwaway container
+// of [image], with [outputDir] mounted at /out so build artifacts are kept on the host.
+// On failure, the container logs are included in the returned error.
+func BuildInContainer(host *models.Host, image string, outputDir string, buildCmd string) error {
+	containerName := "avalanche-cli-build-" + filepath.Base(outputDir)
+	defer func() {
+		if _, err := host.Command("docker rm -f "+containerName, nil, constants.SSHScriptTimeout); err != nil {
+			ux.Logger.Error("Error removing build container %s: %s", containerName, err)
+		}
+	}()
+	if _, err := host.Command(
+		fmt.Sprintf("docker run --name %s -v %s:/out %s sh -c %s", containerName, outputDir, image, utils.ShellQuote(buildCmd)),
+		nil,
+		constants.SSHLongRunningScriptTimeout,
+	); err != nil {
+		logs, logsErr := host.Command("docker logs "+containerName, nil, constants.SSHScriptTimeout)
+		if logsErr != nil {
+			return fmt.Errorf("build in container %s failed: %w", image, err)
+		}
+		return fmt.Errorf("build in container %s failed: %w\n%s", image, err, string(logs))
+	}
+	ux.Logger.Info("Build in container %s completed on %s", image, host.NodeID)
+	return nil
+}
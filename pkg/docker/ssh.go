@@ -25,7 +25,22 @@ func ValidateComposeFile(host *models.Host, composeFile string, timeout time.Dur
 }
 
 // ComposeSSHSetupNode sets up an AvalancheGo node and dependencies on a remote host over SSH.
-func ComposeSSHSetupNode(host *models.Host, network models.Network, avalancheGoVersion string, withMonitoring bool) error {
+// avalanchego default log level is used if [logLevel] is empty.
+// [serviceEnv] maps compose service names to extra environment variables for them.
+// [cChainDBConfig] sets the C-Chain pruning and state sync settings.
+// If [avalancheGoImageDigest] is not empty, the AvalancheGo image is verified against it.
+// If [customComposeFile] is not empty, it is used as the node compose file instead of the generated one
+func ComposeSSHSetupNode(
+	host *models.Host,
+	network models.Network,
+	avalancheGoVersion string,
+	logLevel string,
+	cChainDBConfig remoteconfig.CChainDBConfig,
+	withMonitoring bool,
+	serviceEnv map[string]map[string]string,
+	avalancheGoImageDigest string,
+	customComposeFile string,
+) error {
 	startTime := time.Now()
 	folderStructure := remoteconfig.RemoteFoldersToCreateAvalanchego()
 	for _, dir := range folderStructure {
@@ -45,8 +60,14 @@ func ComposeSSHSetupNode(host *models.Host, network models.Network, avalancheGoV
 	if err := PrepareDockerImageWithRepo(host, avagoDockerImage, constants.AvalancheGoGitRepo, avalancheGoVersion); err != nil {
 		return err
 	}
+	if avalancheGoImageDigest != "" {
+		if err := VerifyImageDigest(host, avagoDockerImage, avalancheGoImageDigest); err != nil {
+			return err
+		}
+		ux.Logger.Info("AvalancheGo Docker image %s checksum verified on %s[%s]", avagoDockerImage, host.NodeID, host.IP)
+	}
 	ux.Logger.Info("AvalancheGo Docker image %s ready on %s[%s] after %s", avagoDockerImage, host.NodeID, host.IP, time.Since(startTime))
-	nodeConfFile, cChainConfFile, err := prepareAvalanchegoConfig(host, networkID)
+	nodeConfFile, cChainConfFile, err := prepareAvalanchegoConfig(host, networkID, logLevel, cChainDBConfig)
 	if err != nil {
 		return err
 	}
@@ -66,7 +87,7 @@ func ComposeSSHSetupNode(host *models.Host, network models.Network, avalancheGoV
 		return err
 	}
 	ux.Logger.Info("AvalancheGo configs uploaded to %s[%s] after %s", host.NodeID, host.IP, time.Since(startTime))
-	return ComposeOverSSH("Compose Node",
+	return composeFileOverSSH("Compose Node",
 		host,
 		constants.SSHScriptTimeout,
 		"templates/avalanchego.docker-compose.yml",
@@ -77,7 +98,12 @@ func ComposeSSHSetupNode(host *models.Host, network models.Network, avalancheGoV
 			E2E:                utils.IsE2E(),
 			E2EIP:              utils.E2EConvertIP(host.IP),
 			E2ESuffix:          utils.E2ESuffix(host.IP),
-		})
+			HTTPPort:           host.GetHTTPPort(),
+			StakingPort:        host.GetStakingPort(),
+			ServiceEnv:         serviceEnv,
+		},
+		customComposeFile,
+	)
 }
 
 func ComposeSSHSetupLoadTest(host *models.Host) error {
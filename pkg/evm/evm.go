@@ -182,11 +182,20 @@ func FundAddress(
 	targetAddressStr string,
 	amount *big.Int,
 ) error {
-	sourceAddressPrivateKey, err := crypto.HexToECDSA(sourceAddressPrivateKeyStr)
+	signer, err := NewSignerFromPrivateKey(sourceAddressPrivateKeyStr)
 	if err != nil {
 		return err
 	}
-	sourceAddress := crypto.PubkeyToAddress(sourceAddressPrivateKey.PublicKey)
+	return FundAddressWithSigner(client, signer, targetAddressStr, amount)
+}
+
+func FundAddressWithSigner(
+	client ethclient.Client,
+	signer *Signer,
+	targetAddressStr string,
+	amount *big.Int,
+) error {
+	sourceAddress := signer.Address()
 	gasFeeCap, gasTipCap, nonce, err := CalculateTxParams(client, sourceAddress.Hex())
 	if err != nil {
 		return err
@@ -205,8 +214,7 @@ func FundAddress(
 		GasTipCap: gasTipCap,
 		Value:     amount,
 	})
-	txSigner := types.LatestSignerForChainID(chainID)
-	signedTx, err := types.SignTx(tx, txSigner, sourceAddressPrivateKey)
+	signedTx, err := signer.SignTx(tx, chainID)
 	if err != nil {
 		return err
 	}
@@ -301,15 +309,11 @@ func GetTxOptsWithSigner(
 	client ethclient.Client,
 	prefundedPrivateKeyStr string,
 ) (*bind.TransactOpts, error) {
-	prefundedPrivateKey, err := crypto.HexToECDSA(prefundedPrivateKeyStr)
+	signer, err := NewSignerFromPrivateKey(prefundedPrivateKeyStr)
 	if err != nil {
 		return nil, err
 	}
-	chainID, err := GetChainID(client)
-	if err != nil {
-		return nil, fmt.Errorf("failure generating signer: %w", err)
-	}
-	return bind.NewKeyedTransactorWithChainID(prefundedPrivateKey, chainID)
+	return signer.GetTxOpts(client)
 }
 
 func WaitForTransaction(
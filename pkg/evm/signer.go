@@ -0,0 +1,126 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ledger "github.com/ava-labs/ledger-avalanche/go"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// BIP44 root path used by the avalanche ledger app: m / purpose' / coin_type' / account'
+	ledgerRootPath = "m/44'/9000'/0'"
+	// secp256k1 signature length in [R || S || V] format
+	signatureLen = 65
+)
+
+var ErrSignerNotAuthorized = errors.New("signer not authorized to sign for the given address")
+
+// Signer signs EVM transactions, either with a stored private key
+// or with a ledger device
+type Signer struct {
+	address     common.Address
+	privateKey  *ecdsa.PrivateKey
+	ledger      *ledger.LedgerAvalanche
+	ledgerIndex uint32
+}
+
+// NewSignerFromPrivateKey creates a signer from a hex encoded private key
+func NewSignerFromPrivateKey(privateKeyStr string) (*Signer, error) {
+	privateKey, err := crypto.HexToECDSA(privateKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		privateKey: privateKey,
+	}, nil
+}
+
+// NewLedgerSigner creates a signer that delegates signing to the ledger
+// address at [ledgerIndex]
+func NewLedgerSigner(ledgerIndex uint32) (*Signer, error) {
+	device, err := ledger.FindLedgerAvalancheApp()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := device.GetPubKey(fmt.Sprintf("%s/%s", ledgerRootPath, ledgerSigningPath(ledgerIndex)), false, "", "")
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := crypto.DecompressPubkey(resp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failure parsing ledger public key: %w", err)
+	}
+	return &Signer{
+		address:     crypto.PubkeyToAddress(*publicKey),
+		ledger:      device,
+		ledgerIndex: ledgerIndex,
+	}, nil
+}
+
+func ledgerSigningPath(ledgerIndex uint32) string {
+	return fmt.Sprintf("0/%d", ledgerIndex)
+}
+
+func (s *Signer) Address() common.Address {
+	return s.address
+}
+
+func (s *Signer) IsLedger() bool {
+	return s.ledger != nil
+}
+
+// SignTx signs [tx] for the chain with id [chainID]
+func (s *Signer) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	txSigner := types.LatestSignerForChainID(chainID)
+	if !s.IsLedger() {
+		return types.SignTx(tx, txSigner, s.privateKey)
+	}
+	signingPath := ledgerSigningPath(s.ledgerIndex)
+	resp, err := s.ledger.SignHash(ledgerRootPath, []string{signingPath}, txSigner.Hash(tx).Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to sign tx with ledger", err)
+	}
+	signature, ok := resp.Signature[signingPath]
+	if !ok {
+		return nil, fmt.Errorf("missing ledger signature for %s", signingPath)
+	}
+	if len(signature) != signatureLen {
+		return nil, fmt.Errorf("unexpected ledger signature length %d", len(signature))
+	}
+	return tx.WithSignature(txSigner, signature)
+}
+
+// GetTxOptsWithChainID returns transaction options that sign with [s] for
+// the chain with id [chainID]
+func (s *Signer) GetTxOptsWithChainID(chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: s.address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.address {
+				return nil, ErrSignerNotAuthorized
+			}
+			return s.SignTx(tx, chainID)
+		},
+	}
+}
+
+// GetTxOpts returns transaction options that sign with [s] for the chain
+// [client] is connected to
+func (s *Signer) GetTxOpts(client ethclient.Client) (*bind.TransactOpts, error) {
+	chainID, err := GetChainID(client)
+	if err != nil {
+		return nil, fmt.Errorf("failure generating signer: %w", err)
+	}
+	return s.GetTxOptsWithChainID(chainID), nil
+}
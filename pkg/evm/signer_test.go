@@ -0,0 +1,54 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateKeySigner(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewSignerFromPrivateKey("invalid")
+	require.Error(err)
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	expectedAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
+	signer, err := NewSignerFromPrivateKey(common.Bytes2Hex(crypto.FromECDSA(privateKey)))
+	require.NoError(err)
+	require.False(signer.IsLedger())
+	require.Equal(expectedAddress, signer.Address())
+
+	chainID := big.NewInt(43112)
+	target := common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		To:        &target,
+		Gas:       NativeTransferGas,
+		GasFeeCap: big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		Value:     big.NewInt(1),
+	})
+	signedTx, err := signer.SignTx(tx, chainID)
+	require.NoError(err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	require.NoError(err)
+	require.Equal(expectedAddress, sender)
+
+	txOpts := signer.GetTxOptsWithChainID(chainID)
+	require.Equal(expectedAddress, txOpts.From)
+	_, err = txOpts.Signer(target, tx)
+	require.ErrorIs(err, ErrSignerNotAuthorized)
+	signedTx, err = txOpts.Signer(expectedAddress, tx)
+	require.NoError(err)
+	sender, err = types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	require.NoError(err)
+	require.Equal(expectedAddress, sender)
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -28,15 +29,15 @@ type TokenRemoteSettings struct {
 
 func RegisterERC20Remote(
 	rpcURL string,
-	privateKey string,
+	signer *evm.Signer,
 	remoteAddress common.Address,
 ) error {
 	feeInfo := TeleporterFeeInfo{
 		Amount: big.NewInt(0),
 	}
-	_, _, err := contract.TxToMethod(
+	_, _, err := contract.TxToMethodWithSigner(
 		rpcURL,
-		privateKey,
+		signer,
 		remoteAddress,
 		nil,
 		"registerWithHome((address, uint256))",
@@ -48,7 +49,7 @@ func RegisterERC20Remote(
 func DeployERC20Remote(
 	srcDir string,
 	rpcURL string,
-	privateKey string,
+	signer *evm.Signer,
 	teleporterRegistryAddress common.Address,
 	teleporterManagerAddress common.Address,
 	tokenHomeBlockchainID [32]byte,
@@ -70,9 +71,9 @@ func DeployERC20Remote(
 		// TODO: user case for home having diff decimals
 		TokenHomeDecimals: tokenDecimals,
 	}
-	return contract.DeployContract(
+	return contract.DeployContractWithSigner(
 		rpcURL,
-		privateKey,
+		signer,
 		binBytes,
 		"((address, address, bytes32, address, uint8), string, string, uint8)",
 		tokenRemoteSettings,
@@ -85,7 +86,7 @@ func DeployERC20Remote(
 func DeployERC20Home(
 	srcDir string,
 	rpcURL string,
-	privateKey string,
+	signer *evm.Signer,
 	teleporterRegistryAddress common.Address,
 	teleporterManagerAddress common.Address,
 	erc20TokenAddress common.Address,
@@ -96,9 +97,9 @@ func DeployERC20Home(
 	if err != nil {
 		return common.Address{}, err
 	}
-	return contract.DeployContract(
+	return contract.DeployContractWithSigner(
 		rpcURL,
-		privateKey,
+		signer,
 		binBytes,
 		"(address, address, address, uint8)",
 		teleporterRegistryAddress,
@@ -111,7 +112,7 @@ func DeployERC20Home(
 func DeployNativeHome(
 	srcDir string,
 	rpcURL string,
-	privateKey string,
+	signer *evm.Signer,
 	teleporterRegistryAddress common.Address,
 	teleporterManagerAddress common.Address,
 	wrappedNativeTokenAddress common.Address,
@@ -121,9 +122,9 @@ func DeployNativeHome(
 	if err != nil {
 		return common.Address{}, err
 	}
-	return contract.DeployContract(
+	return contract.DeployContractWithSigner(
 		rpcURL,
-		privateKey,
+		signer,
 		binBytes,
 		"(address, address, address)",
 		teleporterRegistryAddress,
@@ -135,7 +136,7 @@ func DeployNativeHome(
 func DeployWrappedNativeToken(
 	srcDir string,
 	rpcURL string,
-	privateKey string,
+	signer *evm.Signer,
 	tokenSymbol string,
 ) (common.Address, error) {
 	binPath := filepath.Join(utils.ExpandHome(srcDir), "contracts/out/WrappedNativeToken.sol/WrappedNativeToken.bin")
@@ -143,9 +144,9 @@ func DeployWrappedNativeToken(
 	if err != nil {
 		return common.Address{}, err
 	}
-	return contract.DeployContract(
+	return contract.DeployContractWithSigner(
 		rpcURL,
-		privateKey,
+		signer,
 		binBytes,
 		"(string)",
 		tokenSymbol,
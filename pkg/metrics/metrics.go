@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
@@ -27,6 +28,12 @@ var (
 	telemetryInstance = "https://app.posthog.com"
 )
 
+var (
+	// trackMetrics sends the metrics, replaced in tests
+	trackMetrics           = TrackMetrics
+	logMetricsDisabledOnce sync.Once
+)
+
 func GetCLIVersion() string {
 	wdPath, err := os.Getwd()
 	if err != nil {
@@ -47,12 +54,29 @@ func userIsOptedIn(app *application.Avalanche) bool {
 	return false
 }
 
+// metricsDisabled tells if metrics are turned off with --no-metrics or the no-metrics config
+// setting, regardless of the user opt in. It is logged only once
+func metricsDisabled(app *application.Avalanche) bool {
+	if app.Conf == nil || !app.Conf.GetConfigBoolValue(constants.ConfigNoMetricsKey) {
+		return false
+	}
+	logMetricsDisabledOnce.Do(func() {
+		app.Log.Info("metrics are disabled by the no-metrics setting")
+	})
+	return true
+}
+
+// HandleTracking sends the metrics of [commandPath], unless metrics are disabled or the
+// user has not opted in. All metrics go through it
 func HandleTracking(cmd *cobra.Command, commandPath string, app *application.Avalanche, flags map[string]string) {
+	if metricsDisabled(app) {
+		return
+	}
 	if !userIsOptedIn(app) {
 		return
 	}
 	if !cmd.HasSubCommands() && CheckCommandIsNotCompletion(cmd) {
-		TrackMetrics(commandPath, flags)
+		trackMetrics(commandPath, flags)
 	}
 }
 
@@ -0,0 +1,57 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/config"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleTrackingNoMetrics(t *testing.T) {
+	require := require.New(t)
+	defer viper.Reset()
+	tracked := []string{}
+	trackMetrics = func(commandPath string, _ map[string]string) {
+		tracked = append(tracked, commandPath)
+	}
+	defer func() {
+		trackMetrics = TrackMetrics
+	}()
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewMockPrompter(), nil)
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(os.WriteFile(configPath, []byte(`{"MetricsEnabled": true}`), constants.WriteReadReadPerms))
+	app.Conf.SetConfig(app.Log, configPath)
+	cmd := &cobra.Command{Use: "create"}
+
+	HandleTracking(cmd, "subnet create", app, nil)
+	require.Equal([]string{"subnet create"}, tracked)
+
+	// the setting turns metrics off even if the user opted in
+	require.NoError(app.Conf.SetConfigValue(constants.ConfigNoMetricsKey, true))
+	HandleTracking(cmd, "subnet create", app, nil)
+	require.Equal([]string{"subnet create"}, tracked)
+
+	// as does the flag
+	viper.Reset()
+	require.NoError(os.WriteFile(configPath, []byte(`{"MetricsEnabled": true}`), constants.WriteReadReadPerms))
+	app.Conf.SetConfig(app.Log, configPath)
+	flags := cobra.Command{}
+	flags.Flags().Bool(constants.ConfigNoMetricsKey, false, "")
+	require.NoError(app.Conf.BindFlag(constants.ConfigNoMetricsKey, flags.Flags().Lookup(constants.ConfigNoMetricsKey)))
+	HandleTracking(cmd, "subnet deploy", app, nil)
+	require.Equal([]string{"subnet create", "subnet deploy"}, tracked)
+	require.NoError(flags.Flags().Set(constants.ConfigNoMetricsKey, "true"))
+	HandleTracking(cmd, "subnet deploy", app, nil)
+	require.Equal([]string{"subnet create", "subnet deploy"}, tracked)
+}
@@ -2,7 +2,10 @@
 // See the file LICENSE for licensing terms.
 package models
 
-import "golang.org/x/exp/maps"
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"golang.org/x/exp/maps"
+)
 
 type RegionConfig struct {
 	InstanceIDs       []string
@@ -17,6 +20,19 @@ type RegionConfig struct {
 	SecurityGroupName string
 	NumNodes          int
 	InstanceType      string
+	HTTPPort          uint     // avalanchego http port, default is used if 0
+	StakingPort       uint     // avalanchego staking port, default is used if 0
+	SSHUser           string   // remote user of the nodes, default is used if empty
+	DNSZone           string   // Route53 hosted zone ID (AWS) or Cloud DNS managed zone (GCP) of the node DNS records, if any
+	DNSNames          []string // DNS names of the nodes, in the same order as InstanceIDs
+}
+
+// GetHTTPPort returns the avalanchego http port of the nodes in the region
+func (rc *RegionConfig) GetHTTPPort() uint {
+	if rc.HTTPPort == 0 {
+		return constants.AvalanchegoAPIPort
+	}
+	return rc.HTTPPort
 }
 
 type CloudConfig map[string]RegionConfig
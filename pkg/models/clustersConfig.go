@@ -28,6 +28,7 @@ type ClusterConfig struct {
 	ExtraNetworkData   ExtraNetworkData
 	Subnets            []string
 	External           bool
+	MeshIPs            map[string]string // maps cloud ID to the wireguard mesh IP of the node (if the cluster has a mesh)
 }
 
 type ClustersConfig struct {
@@ -37,6 +38,16 @@ type ClustersConfig struct {
 	GCPConfig GCPConfig                // stores GCP project name and filepath to service account JSON key
 }
 
+// GetNodeMeshIP returns the wireguard mesh IP of node [cloudID], if it is part of the mesh of a cluster
+func (cc *ClustersConfig) GetNodeMeshIP(cloudID string) (string, bool) {
+	for _, clusterConfig := range cc.Clusters {
+		if meshIP, ok := clusterConfig.MeshIPs[cloudID]; ok {
+			return meshIP, true
+		}
+	}
+	return "", false
+}
+
 // GetAPINodes returns a filtered list of API nodes based on the ClusterConfig and given hosts.
 func (cc *ClusterConfig) GetAPIHosts(hosts []*Host) []*Host {
 	return utils.Filter(hosts, func(h *Host) bool {
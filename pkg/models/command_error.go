@@ -0,0 +1,71 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// NoExitCode is set as CommandError exit code when the remote command didn't exit
+	NoExitCode = -1
+	// commandNotFoundExitCode is the exit code used by shells when the command can't be found
+	commandNotFoundExitCode = 127
+)
+
+// CommandError is returned by Host.Command when a remote command fails
+type CommandError struct {
+	ExitCode int    // remote exit code, NoExitCode if the command didn't exit
+	Stderr   string // remote standard error
+	Err      error  // underlying ssh error
+}
+
+func newCommandError(err error, stderr []byte) *CommandError {
+	exitCode := NoExitCode
+	// satisfied by *ssh.ExitError
+	var exitErr interface{ ExitStatus() int }
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitStatus()
+	}
+	return &CommandError{
+		ExitCode: exitCode,
+		Stderr:   strings.TrimSpace(string(stderr)),
+		Err:      err,
+	}
+}
+
+func (e *CommandError) Error() string {
+	switch {
+	case e.IsTimeout():
+		return fmt.Sprintf("remote command timed out: %s", e.Err)
+	case e.IsConnectionLost():
+		return fmt.Sprintf("connection lost while running remote command: %s", e.Err)
+	case e.IsCommandNotFound():
+		return fmt.Sprintf("remote command not found: %s", e.Stderr)
+	default:
+		return fmt.Sprintf("remote command exited with status %d: %s", e.ExitCode, e.Stderr)
+	}
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// IsCommandNotFound is true if the remote shell could not find the command to run
+func (e *CommandError) IsCommandNotFound() bool {
+	return e.ExitCode == commandNotFoundExitCode
+}
+
+// IsTimeout is true if the command was interrupted by the command timeout
+func (e *CommandError) IsTimeout() bool {
+	return errors.Is(e.Err, context.DeadlineExceeded)
+}
+
+// IsConnectionLost is true if the command didn't exit, because the ssh
+// connection or session failed
+func (e *CommandError) IsConnectionLost() bool {
+	return e.ExitCode == NoExitCode && !e.IsTimeout()
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExitError simulates a *ssh.ExitError returned for a command that exited
+type fakeExitError struct {
+	status int
+}
+
+func (e fakeExitError) Error() string {
+	return fmt.Sprintf("Process exited with status %d", e.status)
+}
+
+func (e fakeExitError) ExitStatus() int {
+	return e.status
+}
+
+func TestCommandError(t *testing.T) {
+	require := require.New(t)
+
+	// non zero exit
+	err := error(newCommandError(fakeExitError{status: 2}, []byte("  tar: invalid archive\n")))
+	var cmdErr *CommandError
+	require.ErrorAs(err, &cmdErr)
+	require.Equal(2, cmdErr.ExitCode)
+	require.Equal("tar: invalid archive", cmdErr.Stderr)
+	require.False(cmdErr.IsCommandNotFound())
+	require.False(cmdErr.IsConnectionLost())
+	require.False(cmdErr.IsTimeout())
+	require.Equal("remote command exited with status 2: tar: invalid archive", err.Error())
+
+	// command not found, wrapped as RunOverSSH does
+	err = fmt.Errorf("%w: %s", newCommandError(fakeExitError{status: 127}, []byte("bash: busybox: command not found")), "output")
+	require.ErrorAs(err, &cmdErr)
+	require.Equal(127, cmdErr.ExitCode)
+	require.True(cmdErr.IsCommandNotFound())
+	require.False(cmdErr.IsConnectionLost())
+
+	// connection lost
+	err = newCommandError(io.EOF, nil)
+	require.ErrorAs(err, &cmdErr)
+	require.Equal(NoExitCode, cmdErr.ExitCode)
+	require.Empty(cmdErr.Stderr)
+	require.True(cmdErr.IsConnectionLost())
+	require.False(cmdErr.IsTimeout())
+	require.ErrorIs(err, io.EOF)
+
+	// timeout
+	err = newCommandError(context.DeadlineExceeded, nil)
+	require.ErrorAs(err, &cmdErr)
+	require.Equal(NoExitCode, cmdErr.ExitCode)
+	require.True(cmdErr.IsTimeout())
+	require.False(cmdErr.IsConnectionLost())
+	require.True(errors.Is(err, context.DeadlineExceeded))
+}
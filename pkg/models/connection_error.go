@@ -0,0 +1,62 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// ConnectionErrorKind classifies why a host could not be reached
+type ConnectionErrorKind int
+
+const (
+	ConnectionErrorUnknown ConnectionErrorKind = iota
+	ConnectionErrorDNS
+	ConnectionErrorRefused
+	ConnectionErrorAuth
+	ConnectionErrorTimeout
+)
+
+func (k ConnectionErrorKind) String() string {
+	switch k {
+	case ConnectionErrorDNS:
+		return "DNS resolution failure"
+	case ConnectionErrorRefused:
+		return "connection refused"
+	case ConnectionErrorAuth:
+		return "SSH authentication failure"
+	case ConnectionErrorTimeout:
+		return "connection timeout"
+	default:
+		return "unknown connection failure"
+	}
+}
+
+// ClassifyConnectionError returns the kind of the innermost cause of a failure
+// to reach a host, so as a timeout wrapping a refused connection is a refused connection
+func ClassifyConnectionError(err error) ConnectionErrorKind {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ConnectionErrorUnknown
+	case errors.As(err, &dnsErr):
+		return ConnectionErrorDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ConnectionErrorRefused
+	// x/crypto/ssh doesn't export an error type for handshake auth failures
+	case strings.Contains(err.Error(), "unable to authenticate"):
+		return ConnectionErrorAuth
+	case errors.Is(err, os.ErrDeadlineExceeded),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ConnectionErrorTimeout
+	default:
+		return ConnectionErrorUnknown
+	}
+}
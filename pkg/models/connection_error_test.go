@@ -0,0 +1,53 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyConnectionError(t *testing.T) {
+	require := require.New(t)
+
+	refusedErr := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	for _, test := range []struct {
+		err  error
+		kind ConnectionErrorKind
+	}{
+		{nil, ConnectionErrorUnknown},
+		{errors.New("something else"), ConnectionErrorUnknown},
+		{&net.DNSError{Err: "no such host", Name: "node.invalid", IsNotFound: true}, ConnectionErrorDNS},
+		{refusedErr, ConnectionErrorRefused},
+		{errors.New("ssh: handshake failed: ssh: unable to authenticate, attempted methods [none publickey]"), ConnectionErrorAuth},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}, ConnectionErrorTimeout},
+		{context.DeadlineExceeded, ConnectionErrorTimeout},
+		// the last cause of a wait timeout is reported
+		{fmt.Errorf("timeout: SSH port 22 on host 1.2.3.4 is not available after 60s: %w", refusedErr), ConnectionErrorRefused},
+	} {
+		require.Equal(test.kind, ClassifyConnectionError(test.err), test.err)
+	}
+}
+
+func TestWaitForPortLastError(t *testing.T) {
+	require := require.New(t)
+
+	// get a free port, and close it so connections are refused
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(listener.Close())
+
+	host := &Host{IP: "127.0.0.1"}
+	err = host.WaitForPort(uint(port), 500*time.Millisecond)
+	require.ErrorContains(err, "timeout")
+	require.Equal(ConnectionErrorRefused, ClassifyConnectionError(err))
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatYAML = "yaml"
+	ExportFormatTOML = "toml"
+)
+
+// ExportFormats are the supported formats of subnet export files. JSON, the first one, is the default
+var ExportFormats = []string{ExportFormatJSON, ExportFormatYAML, ExportFormatTOML}
+
+// ExportSerializer writes and reads subnet export data in a given format
+type ExportSerializer interface {
+	Marshal(exportable Exportable) ([]byte, error)
+	Unmarshal(data []byte, exportable *Exportable) error
+}
+
+// GetExportSerializer returns the serializer of [format]
+func GetExportSerializer(format string) (ExportSerializer, error) {
+	switch format {
+	case ExportFormatJSON:
+		return jsonExportSerializer{}, nil
+	case ExportFormatYAML:
+		return yamlExportSerializer{}, nil
+	case ExportFormatTOML:
+		return tomlExportSerializer{}, nil
+	}
+	return nil, fmt.Errorf("invalid export format %q, expected one of %s", format, strings.Join(ExportFormats, ", "))
+}
+
+// ExportFormatFromPath returns the export format matching the extension of [path], if any
+func ExportFormatFromPath(path string) (string, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ExportFormatJSON, true
+	case ".yaml", ".yml":
+		return ExportFormatYAML, true
+	case ".toml":
+		return ExportFormatTOML, true
+	}
+	return "", false
+}
+
+// UnmarshalExportable reads subnet export data from [data]. If [format] is empty, the formats
+// are tried in order, JSON first, so that exports written before formats were added keep working
+func UnmarshalExportable(data []byte, format string) (Exportable, error) {
+	formats := []string{format}
+	if format == "" {
+		// YAML accepts most JSON and some TOML, so it is tried last
+		formats = []string{ExportFormatJSON, ExportFormatTOML, ExportFormatYAML}
+	}
+	var firstErr error
+	for _, format := range formats {
+		serializer, err := GetExportSerializer(format)
+		if err != nil {
+			return Exportable{}, err
+		}
+		exportable := Exportable{}
+		err = serializer.Unmarshal(data, &exportable)
+		if err == nil {
+			return exportable, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return Exportable{}, firstErr
+}
+
+type jsonExportSerializer struct{}
+
+func (jsonExportSerializer) Marshal(exportable Exportable) ([]byte, error) {
+	return json.Marshal(exportable)
+}
+
+func (jsonExportSerializer) Unmarshal(data []byte, exportable *Exportable) error {
+	return json.Unmarshal(data, exportable)
+}
+
+// yamlExportSerializer and tomlExportSerializer convert the JSON representation of the export data,
+// so ids and raw config bytes are written and read the same way in all formats
+type yamlExportSerializer struct{}
+
+func (yamlExportSerializer) Marshal(exportable Exportable) ([]byte, error) {
+	tree, err := toJSONTree(exportable, false)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(tree)
+}
+
+func (yamlExportSerializer) Unmarshal(data []byte, exportable *Exportable) error {
+	var tree map[string]interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+	return fromJSONTree(tree, exportable)
+}
+
+type tomlExportSerializer struct{}
+
+func (tomlExportSerializer) Marshal(exportable Exportable) ([]byte, error) {
+	// TOML has no null, so null values are left out. They read back as the zero value
+	tree, err := toJSONTree(exportable, true)
+	if err != nil {
+		return nil, err
+	}
+	return toml.Marshal(tree)
+}
+
+func (tomlExportSerializer) Unmarshal(data []byte, exportable *Exportable) error {
+	var tree map[string]interface{}
+	if err := toml.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+	return fromJSONTree(tree, exportable)
+}
+
+// toJSONTree returns the generic JSON representation of [v], with its numbers converted to
+// int64, uint64 or float64. If [tomlCompatible], null values are left out, and numbers that
+// don't fit a TOML integer are rejected
+func toJSONTree(v interface{}, tomlCompatible bool) (interface{}, error) {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return normalizeJSONTree(tree, tomlCompatible)
+}
+
+func normalizeJSONTree(node interface{}, tomlCompatible bool) (interface{}, error) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if child == nil && tomlCompatible {
+				delete(value, key)
+				continue
+			}
+			normalized, err := normalizeJSONTree(child, tomlCompatible)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = normalized
+		}
+		return value, nil
+	case []interface{}:
+		for i, child := range value {
+			if child == nil && tomlCompatible {
+				return nil, fmt.Errorf("null array elements are not supported")
+			}
+			normalized, err := normalizeJSONTree(child, tomlCompatible)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = normalized
+		}
+		return value, nil
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			if tomlCompatible {
+				return nil, fmt.Errorf("number %s does not fit a TOML integer", value)
+			}
+			return u, nil
+		}
+		return value.Float64()
+	}
+	return node, nil
+}
+
+// fromJSONTree reads [exportable] from its generic JSON representation [tree]
+func fromJSONTree(tree map[string]interface{}, exportable *Exportable) error {
+	jsonBytes, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonBytes, exportable)
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExportable() Exportable {
+	return Exportable{
+		Sidecar: Sidecar{
+			Name:        "testSubnet",
+			VM:          SubnetEvm,
+			VMVersion:   "v0.6.6",
+			RPCVersion:  35,
+			Subnet:      "testSubnet",
+			TokenName:   "Test Token",
+			TokenSymbol: "TEST",
+			ChainID:     "12345",
+			Version:     "1.4.0",
+			Networks: map[string]NetworkData{
+				"Fuji": {
+					SubnetID:                   ids.GenerateTestID(),
+					BlockchainID:               ids.GenerateTestID(),
+					RPCVersion:                 35,
+					TeleporterMessengerAddress: "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf",
+				},
+			},
+			ElasticSubnet: map[string]ElasticSubnet{
+				"Fuji": {
+					SubnetID:   ids.GenerateTestID(),
+					AssetID:    ids.GenerateTestID(),
+					Validators: map[string]PermissionlessValidators{ids.GenerateTestNodeID().String(): {TxID: ids.GenerateTestID()}},
+					Txs:        map[string]ids.ID{"TransformSubnetTx": ids.GenerateTestID()},
+				},
+			},
+			TeleporterReady:         true,
+			RunRelayer:              true,
+			SubnetEVMMainnetChainID: 43114,
+		},
+		Genesis:     []byte(`{"config":{"chainId":12345},"alloc":{"8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC":{"balance":"0x1"}}}`),
+		ChainConfig: []byte(`{"log-level":"info"}`),
+	}
+}
+
+func TestExportSerializersRoundTrip(t *testing.T) {
+	export := newTestExportable()
+	for _, format := range ExportFormats {
+		t.Run(format, func(t *testing.T) {
+			require := require.New(t)
+			serializer, err := GetExportSerializer(format)
+			require.NoError(err)
+			data, err := serializer.Marshal(export)
+			require.NoError(err)
+
+			imported := Exportable{}
+			require.NoError(serializer.Unmarshal(data, &imported))
+			require.Equal(export, imported)
+
+			// the format is also detected from the content
+			imported, err = UnmarshalExportable(data, "")
+			require.NoError(err)
+			require.Equal(export, imported)
+		})
+	}
+}
+
+func TestGetExportSerializer(t *testing.T) {
+	require := require.New(t)
+	_, err := GetExportSerializer("xml")
+	require.ErrorContains(err, "invalid export format \"xml\"")
+
+	for path, expected := range map[string]string{
+		"export.json": ExportFormatJSON,
+		"export.YAML": ExportFormatYAML,
+		"export.yml":  ExportFormatYAML,
+		"export.toml": ExportFormatTOML,
+	} {
+		format, ok := ExportFormatFromPath(path)
+		require.True(ok, path)
+		require.Equal(expected, format, path)
+	}
+	_, ok := ExportFormatFromPath("export")
+	require.False(ok)
+
+	_, err = UnmarshalExportable([]byte("{not valid"), "")
+	require.Error(err)
+}
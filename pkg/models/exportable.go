@@ -3,6 +3,11 @@
 
 package models
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type Exportable struct {
 	Sidecar         Sidecar
 	Genesis         []byte
@@ -11,3 +16,71 @@ type Exportable struct {
 	NetworkUpgrades []byte
 	NodeConfig      []byte
 }
+
+// sensitiveConfigKeyMarkers identify config entries holding key material,
+// after lower casing the entry name and removing '-' and '_'
+var sensitiveConfigKeyMarkers = []string{
+	"privatekey",
+	"keyfilecontent",
+	"mnemonic",
+}
+
+// WithoutKeys returns a copy of the export with all key material removed,
+// so that it can be shared while still being deployable.
+// Genesis and network upgrades are kept as is
+func (e Exportable) WithoutKeys() (Exportable, error) {
+	var err error
+	sanitized := e
+	sanitized.Sidecar.TeleporterKey = ""
+	if sanitized.NodeConfig, err = removeSensitiveConfigKeys(e.NodeConfig); err != nil {
+		return Exportable{}, err
+	}
+	if sanitized.ChainConfig, err = removeSensitiveConfigKeys(e.ChainConfig); err != nil {
+		return Exportable{}, err
+	}
+	if sanitized.SubnetConfig, err = removeSensitiveConfigKeys(e.SubnetConfig); err != nil {
+		return Exportable{}, err
+	}
+	return sanitized, nil
+}
+
+func isSensitiveConfigKey(key string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+	for _, marker := range sensitiveConfigKeyMarkers {
+		if strings.Contains(normalized, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeSensitiveConfigKeys drops, at any depth, the entries of a JSON config
+// that hold key material
+func removeSensitiveConfigKeys(configBytes []byte) ([]byte, error) {
+	if len(configBytes) == 0 {
+		return configBytes, nil
+	}
+	var config interface{}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return nil, err
+	}
+	return json.Marshal(removeSensitiveEntries(config))
+}
+
+func removeSensitiveEntries(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, entry := range v {
+			if isSensitiveConfigKey(key) {
+				delete(v, key)
+				continue
+			}
+			v[key] = removeSensitiveEntries(entry)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = removeSensitiveEntries(v[i])
+		}
+	}
+	return value
+}
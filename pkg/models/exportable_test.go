@@ -0,0 +1,59 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportableWithoutKeys(t *testing.T) {
+	require := require.New(t)
+	subnetID := ids.GenerateTestID()
+	blockchainID := ids.GenerateTestID()
+	genesis := []byte(`{"config":{"chainId":12345},"alloc":{"8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC":{"balance":"0x1"}}}`)
+	export := Exportable{
+		Sidecar: Sidecar{
+			Name:          "testSubnet",
+			TeleporterKey: "cli-teleporter-deployer",
+			Networks: map[string]NetworkData{
+				"Fuji": {SubnetID: subnetID, BlockchainID: blockchainID},
+			},
+		},
+		Genesis:     genesis,
+		NodeConfig:  []byte(`{"staking-signer-key-file-content":"secret","staking-tls-key-file-content":"secret","log-level":"info"}`),
+		ChainConfig: []byte(`{"feeRecipient":"0x1","warp":{"privateKey":"secret","enabled":true}}`),
+	}
+
+	sanitized, err := export.WithoutKeys()
+	require.NoError(err)
+
+	require.Empty(sanitized.Sidecar.TeleporterKey)
+	require.Equal("testSubnet", sanitized.Sidecar.Name)
+	require.Equal(subnetID, sanitized.Sidecar.Networks["Fuji"].SubnetID)
+	require.Equal(blockchainID, sanitized.Sidecar.Networks["Fuji"].BlockchainID)
+	require.Equal(genesis, sanitized.Genesis)
+	require.Empty(sanitized.SubnetConfig)
+
+	var nodeConfig map[string]interface{}
+	require.NoError(json.Unmarshal(sanitized.NodeConfig, &nodeConfig))
+	require.Equal(map[string]interface{}{"log-level": "info"}, nodeConfig)
+
+	var chainConfig map[string]interface{}
+	require.NoError(json.Unmarshal(sanitized.ChainConfig, &chainConfig))
+	require.Equal(map[string]interface{}{
+		"feeRecipient": "0x1",
+		"warp":         map[string]interface{}{"enabled": true},
+	}, chainConfig)
+
+	// original export is not modified
+	require.Equal("cli-teleporter-deployer", export.Sidecar.TeleporterKey)
+	require.Contains(string(export.NodeConfig), "staking-signer-key-file-content")
+
+	_, err = Exportable{NodeConfig: []byte("not json")}.WithoutKeys()
+	require.Error(err)
+}
@@ -6,11 +6,13 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -18,6 +20,7 @@ import (
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/melbahja/goph"
 	"golang.org/x/crypto/ssh"
 )
@@ -28,13 +31,26 @@ const (
 	sshConnectionRetries = 5
 )
 
+// newHostConnection dials a new SSH connection to a host. Replaced in tests
+var newHostConnection = NewHostConnection
+
+// Host is a node reachable over SSH. A single SSH connection is established on first
+// use and reused by all the host operations until Disconnect. A host is meant to be
+// operated by a single goroutine at a time, as done by the per host goroutines of the
+// node commands, but connecting and disconnecting are safe to call concurrently.
 type Host struct {
 	NodeID            string
 	IP                string
-	SSHUser           string
+	SSHUser           string // remote user, default is used if empty
 	SSHPrivateKeyPath string
 	SSHCommonArgs     string
+	HTTPPort          uint // avalanchego http port, default is used if 0
+	StakingPort       uint // avalanchego staking port, default is used if 0
 	Connection        *goph.Client
+
+	connectionLock sync.Mutex
+	// number of operations that reused the current connection
+	connectionReuses uint
 }
 
 func NewHostConnection(h *Host, port uint) (*goph.Client, error) {
@@ -55,7 +71,7 @@ func NewHostConnection(h *Host, port uint) (*goph.Client, error) {
 		return nil, err
 	}
 	cl, err := goph.NewConn(&goph.Config{
-		User:    h.SSHUser,
+		User:    h.GetSSHUser(),
 		Addr:    h.IP,
 		Port:    port,
 		Auth:    auth,
@@ -69,52 +85,104 @@ func NewHostConnection(h *Host, port uint) (*goph.Client, error) {
 	return cl, nil
 }
 
+// GetHTTPPort returns the avalanchego http port of the host
+func (h *Host) GetHTTPPort() uint {
+	if h.HTTPPort == 0 {
+		return constants.AvalanchegoAPIPort
+	}
+	return h.HTTPPort
+}
+
+// GetSSHUser returns the remote user of the host
+func (h *Host) GetSSHUser() string {
+	if h.SSHUser == "" {
+		return constants.AnsibleSSHUser
+	}
+	return h.SSHUser
+}
+
+// GetHomeDir returns the home directory of the remote user of the host
+func (h *Host) GetHomeDir() string {
+	if h.GetSSHUser() == "root" {
+		return "/root"
+	}
+	return path.Join("/home", h.GetSSHUser())
+}
+
+// GetStakingPort returns the avalanchego staking port of the host
+func (h *Host) GetStakingPort() uint {
+	if h.StakingPort == 0 {
+		return constants.AvalanchegoP2PPort
+	}
+	return h.StakingPort
+}
+
 // GetCloudID returns the node ID of the host.
 func (h *Host) GetCloudID() string {
 	_, cloudID, _ := HostAnsibleIDToCloudID(h.NodeID)
 	return cloudID
 }
 
-// Connect starts a new SSH connection with the provided private key.
+// Connect starts a new SSH connection with the provided private key, unless the host
+// is already connected.
 func (h *Host) Connect(port uint) error {
+	_, err := h.connect(port)
+	return err
+}
+
+// connect returns the SSH connection of the host, establishing it if needed
+func (h *Host) connect(port uint) (*goph.Client, error) {
 	if port == 0 {
 		port = constants.SSHTCPPort
 	}
+	h.connectionLock.Lock()
+	defer h.connectionLock.Unlock()
 	if h.Connection != nil {
-		return nil
+		h.connectionReuses++
+		ux.Logger.Debug("reusing ssh connection to host %s (%d reuses)", h.IP, h.connectionReuses)
+		return h.Connection, nil
 	}
 	var err error
 	for i := 0; h.Connection == nil && i < sshConnectionRetries; i++ {
-		h.Connection, err = NewHostConnection(h, port)
+		h.Connection, err = newHostConnection(h, port)
 	}
 	if err != nil {
-		return fmt.Errorf("failed to connect to host %s: %w", h.IP, err)
+		return nil, fmt.Errorf("failed to connect to host %s: %w", h.IP, err)
 	}
-	return nil
+	h.connectionReuses = 0
+	ux.Logger.Debug("established ssh connection to host %s", h.IP)
+	return h.Connection, nil
 }
 
 func (h *Host) Connected() bool {
+	h.connectionLock.Lock()
+	defer h.connectionLock.Unlock()
 	return h.Connection != nil
 }
 
+// Disconnect closes the SSH connection of the host, if any. Later operations connect again
 func (h *Host) Disconnect() error {
+	h.connectionLock.Lock()
+	defer h.connectionLock.Unlock()
 	if h.Connection == nil {
 		return nil
 	}
+	ux.Logger.Debug("closing ssh connection to host %s after %d reuses", h.IP, h.connectionReuses)
 	err := h.Connection.Close()
+	h.Connection = nil
 	return err
 }
 
 // Upload uploads a local file to a remote file on the host.
 func (h *Host) Upload(localFile string, remoteFile string, timeout time.Duration) error {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return err
-		}
+	remoteFile = h.ExpandHome(remoteFile)
+	conn, err := h.connect(0)
+	if err != nil {
+		return err
 	}
-	_, err := utils.TimedFunction(
+	_, err = utils.TimedFunction(
 		func() (interface{}, error) {
-			return nil, h.Connection.Upload(localFile, remoteFile)
+			return nil, conn.Upload(localFile, remoteFile)
 		},
 		"upload",
 		timeout,
@@ -143,17 +211,17 @@ func (h *Host) UploadBytes(data []byte, remoteFile string, timeout time.Duration
 
 // Download downloads a file from the remote server to the local machine.
 func (h *Host) Download(remoteFile string, localFile string, timeout time.Duration) error {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return err
-		}
+	remoteFile = h.ExpandHome(remoteFile)
+	conn, err := h.connect(0)
+	if err != nil {
+		return err
 	}
 	if err := os.MkdirAll(filepath.Dir(localFile), os.ModePerm); err != nil {
 		return err
 	}
-	_, err := utils.TimedFunction(
+	_, err = utils.TimedFunction(
 		func() (interface{}, error) {
-			return nil, h.Connection.Download(remoteFile, localFile)
+			return nil, conn.Download(remoteFile, localFile)
 		},
 		"download",
 		timeout,
@@ -177,9 +245,10 @@ func (h *Host) ReadFileBytes(remoteFile string, timeout time.Duration) ([]byte,
 	return os.ReadFile(tmpFile.Name())
 }
 
-// ExpandHome expands the ~ symbol to the home directory.
+// ExpandHome expands the ~ symbol to the home directory of the remote user.
+// Remote paths given to the file operations of the host are expanded with it
 func (h *Host) ExpandHome(path string) string {
-	userHome := filepath.Join("/home", h.SSHUser)
+	userHome := h.GetHomeDir()
 	if path == "" {
 		return userHome
 	}
@@ -192,10 +261,8 @@ func (h *Host) ExpandHome(path string) string {
 // MkdirAll creates a folder on the remote server.
 func (h *Host) MkdirAll(remoteDir string, timeout time.Duration) error {
 	remoteDir = h.ExpandHome(remoteDir)
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return err
-		}
+	if _, err := h.connect(0); err != nil {
+		return err
 	}
 	_, err := utils.TimedFunction(
 		func() (interface{}, error) {
@@ -213,12 +280,12 @@ func (h *Host) MkdirAll(remoteDir string, timeout time.Duration) error {
 // UntimedMkdirAll creates a folder on the remote server.
 // Does not support timeouts on the operation.
 func (h *Host) UntimedMkdirAll(remoteDir string) error {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return err
-		}
+	remoteDir = h.ExpandHome(remoteDir)
+	conn, err := h.connect(0)
+	if err != nil {
+		return err
 	}
-	sftp, err := h.Connection.NewSftp()
+	sftp, err := conn.NewSftp()
 	if err != nil {
 		return err
 	}
@@ -228,74 +295,70 @@ func (h *Host) UntimedMkdirAll(remoteDir string) error {
 
 // Command executes a shell command on a remote host.
 func (h *Host) Command(script string, env []string, timeout time.Duration) ([]byte, error) {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return nil, err
-		}
+	conn, err := h.connect(0)
+	if err != nil {
+		return nil, err
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	cmd, err := h.Connection.CommandContext(ctx, "", script)
+	cmd, err := conn.CommandContext(ctx, "", script)
 	if err != nil {
 		return nil, err
 	}
 	if env != nil {
 		cmd.Env = env
 	}
-	output, err := cmd.CombinedOutput()
-	return output, err
+	var stderr bytes.Buffer
+	output := &lockedWriter{}
+	cmd.Stdout = output
+	cmd.Stderr = io.MultiWriter(output, &stderr)
+	if err := cmd.Run(); err != nil {
+		return output.Bytes(), newCommandError(err, stderr.Bytes())
+	}
+	return output.Bytes(), nil
 }
 
-// Forward forwards the TCP connection to a remote address.
-func (h *Host) Forward(httpRequest string, timeout time.Duration) ([]byte, error) {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return nil, err
-		}
-	}
-	retI, err := utils.TimedFunctionWithRetry(
-		func() (interface{}, error) {
-			return h.UntimedForward(httpRequest)
-		},
-		"post over ssh",
-		timeout,
-		3,
-		2*time.Second,
-	)
-	if err != nil {
-		err = fmt.Errorf("%w for host %s", err, h.IP)
-	}
-	ret := []byte(nil)
-	if retI != nil {
-		ret = retI.([]byte)
-	}
-	return ret, err
+// lockedWriter is a buffer safe to be written from stdout and stderr copiers at the same time
+type lockedWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *lockedWriter) Bytes() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return bytes.Clone(w.buf.Bytes())
 }
 
 // UntimedForward forwards the TCP connection to a remote address.
 // Does not support timeouts on the operation.
 func (h *Host) UntimedForward(httpRequest string) ([]byte, error) {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return nil, err
-		}
+	conn, err := h.connect(0)
+	if err != nil {
+		return nil, err
 	}
-	avalancheGoEndpoint := strings.TrimPrefix(constants.LocalAPIEndpoint, "http://")
+	avalancheGoEndpoint := fmt.Sprintf("127.0.0.1:%d", h.GetHTTPPort())
 	avalancheGoAddr, err := net.ResolveTCPAddr("tcp", avalancheGoEndpoint)
 	if err != nil {
 		return nil, err
 	}
 	var proxy net.Conn
 	if utils.IsE2E() {
-		avalancheGoEndpoint = fmt.Sprintf("%s:%d", utils.E2EConvertIP(h.IP), constants.AvalanchegoAPIPort)
+		avalancheGoEndpoint = fmt.Sprintf("%s:%d", utils.E2EConvertIP(h.IP), h.GetHTTPPort())
 		proxy, err = net.Dial("tcp", avalancheGoEndpoint)
 		if err != nil {
-			return nil, fmt.Errorf("unable to port forward E2E to %s", avalancheGoEndpoint)
+			return nil, fmt.Errorf("unable to port forward E2E to %s: %w", avalancheGoEndpoint, err)
 		}
 	} else {
-		proxy, err = h.Connection.DialTCP("tcp", nil, avalancheGoAddr)
+		proxy, err = conn.DialTCP("tcp", nil, avalancheGoAddr)
 		if err != nil {
-			return nil, fmt.Errorf("unable to port forward to %s via %s", h.Connection.RemoteAddr(), "ssh")
+			return nil, fmt.Errorf("unable to port forward to %s via %s: %w", conn.RemoteAddr(), "ssh", err)
 		}
 	}
 
@@ -324,13 +387,13 @@ func (h *Host) UntimedForward(httpRequest string) ([]byte, error) {
 
 // FileExists checks if a file exists on the remote server.
 func (h *Host) FileExists(path string) (bool, error) {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return false, err
-		}
+	path = h.ExpandHome(path)
+	conn, err := h.connect(0)
+	if err != nil {
+		return false, err
 	}
 
-	sftp, err := h.Connection.NewSftp()
+	sftp, err := conn.NewSftp()
 	if err != nil {
 		return false, nil
 	}
@@ -342,14 +405,45 @@ func (h *Host) FileExists(path string) (bool, error) {
 	return true, nil
 }
 
+// ListFiles returns the paths of all regular files under [remoteDir] on the remote server.
+// An empty list is returned if [remoteDir] does not exist
+func (h *Host) ListFiles(remoteDir string) ([]string, error) {
+	remoteDir = h.ExpandHome(remoteDir)
+	conn, err := h.connect(0)
+	if err != nil {
+		return nil, err
+	}
+	sftp, err := conn.NewSftp()
+	if err != nil {
+		return nil, err
+	}
+	defer sftp.Close()
+	if _, err := sftp.Stat(remoteDir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	files := []string{}
+	walker := sftp.Walk(remoteDir)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return nil, err
+		}
+		if walker.Stat().Mode().IsRegular() {
+			files = append(files, walker.Path())
+		}
+	}
+	return files, nil
+}
+
 // CreateTemp creates a temporary file on the remote server.
 func (h *Host) CreateTempFile() (string, error) {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return "", err
-		}
+	conn, err := h.connect(0)
+	if err != nil {
+		return "", err
 	}
-	sftp, err := h.Connection.NewSftp()
+	sftp, err := conn.NewSftp()
 	if err != nil {
 		return "", err
 	}
@@ -364,12 +458,11 @@ func (h *Host) CreateTempFile() (string, error) {
 
 // CreateTempDir creates a temporary directory on the remote server.
 func (h *Host) CreateTempDir() (string, error) {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return "", err
-		}
+	conn, err := h.connect(0)
+	if err != nil {
+		return "", err
 	}
-	sftp, err := h.Connection.NewSftp()
+	sftp, err := conn.NewSftp()
 	if err != nil {
 		return "", err
 	}
@@ -384,12 +477,12 @@ func (h *Host) CreateTempDir() (string, error) {
 
 // Remove removes a file on the remote server.
 func (h *Host) Remove(path string, recursive bool) error {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return err
-		}
+	path = h.ExpandHome(path)
+	conn, err := h.connect(0)
+	if err != nil {
+		return err
 	}
-	sftp, err := h.Connection.NewSftp()
+	sftp, err := conn.NewSftp()
 	if err != nil {
 		return err
 	}
@@ -407,7 +500,7 @@ func (h *Host) GetAnsibleInventoryRecord() string {
 	return strings.Join([]string{
 		h.NodeID,
 		fmt.Sprintf("ansible_host=%s", h.IP),
-		fmt.Sprintf("ansible_user=%s", h.SSHUser),
+		fmt.Sprintf("ansible_user=%s", h.GetSSHUser()),
 		fmt.Sprintf("ansible_ssh_private_key_file=%s", h.SSHPrivateKeyPath),
 		fmt.Sprintf("ansible_ssh_common_args='%s'", h.SSHCommonArgs),
 	}, " ")
@@ -451,13 +544,20 @@ func (h *Host) WaitForPort(port uint, timeout time.Duration) error {
 	}
 	start := time.Now()
 	deadline := start.Add(timeout)
+	var lastErr error
 	for {
 		if time.Now().After(deadline) {
+			if lastErr != nil {
+				return fmt.Errorf("timeout: SSH port %d on host %s is not available after %vs: %w", port, h.IP, timeout.Seconds(), lastErr)
+			}
 			return fmt.Errorf("timeout: SSH port %d on host %s is not available after %vs", port, h.IP, timeout.Seconds())
 		}
-		if _, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", h.IP, port), time.Second); err == nil {
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", h.IP, port), time.Second)
+		if err == nil {
+			_ = conn.Close()
 			return nil
 		}
+		lastErr = err
 		time.Sleep(constants.SSHSleepBetweenChecks)
 	}
 }
@@ -473,11 +573,16 @@ func (h *Host) WaitForSSHShell(timeout time.Duration) error {
 	}
 
 	deadline := start.Add(timeout)
+	var lastErr error
 	for {
 		if time.Now().After(deadline) {
+			if lastErr != nil {
+				return fmt.Errorf("timeout: SSH shell on host %s is not available after %ds: %w", h.IP, int(timeout.Seconds()), lastErr)
+			}
 			return fmt.Errorf("timeout: SSH shell on host %s is not available after %ds", h.IP, int(timeout.Seconds()))
 		}
 		if err := h.Connect(0); err != nil {
+			lastErr = err
 			time.Sleep(constants.SSHSleepBetweenChecks)
 			continue
 		}
@@ -486,6 +591,7 @@ func (h *Host) WaitForSSHShell(timeout time.Duration) error {
 			if err == nil || len(output) > 0 {
 				return nil
 			}
+			lastErr = err
 		}
 		time.Sleep(constants.SSHSleepBetweenChecks)
 	}
@@ -493,16 +599,25 @@ func (h *Host) WaitForSSHShell(timeout time.Duration) error {
 
 // StreamSSHCommand streams the execution of an SSH command on the host.
 func (h *Host) StreamSSHCommand(command string, env []string, timeout time.Duration) error {
-	if !h.Connected() {
-		if err := h.Connect(0); err != nil {
-			return err
-		}
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
+	return h.streamSSHCommand(ctx, command, env, false)
+}
 
-	session, err := h.Connection.NewSession()
+// StreamSSHCommandContext streams the execution of an SSH command on the host until it
+// finishes, or until [ctx] is done, in which case the command is interrupted and its
+// SSH session closed without error. Used for commands that don't end by themselves.
+func (h *Host) StreamSSHCommandContext(ctx context.Context, command string, env []string) error {
+	return h.streamSSHCommand(ctx, command, env, true)
+}
+
+func (h *Host) streamSSHCommand(ctx context.Context, command string, env []string, interruptOnDone bool) error {
+	conn, err := h.connect(0)
+	if err != nil {
+		return err
+	}
+
+	session, err := conn.NewSession()
 	if err != nil {
 		return err
 	}
@@ -543,7 +658,23 @@ func (h *Host) StreamSSHCommand(command string, env []string, timeout time.Durat
 		}
 	}()
 
+	if interruptOnDone {
+		runDone := make(chan struct{})
+		defer close(runDone)
+		go func() {
+			select {
+			case <-ctx.Done():
+				_ = session.Signal(ssh.SIGINT)
+				_ = session.Close()
+			case <-runDone:
+			}
+		}()
+	}
+
 	if err := session.Run(command); err != nil {
+		if interruptOnDone && ctx.Err() != nil {
+			return nil
+		}
 		return fmt.Errorf("failed to run command %s: %w", command, err)
 	}
 	wg.Wait()
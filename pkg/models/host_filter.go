@@ -0,0 +1,59 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+)
+
+var ErrNoHostsAfterFilter = errors.New("no nodes left after applying node filters")
+
+// HostFilter selects the hosts of a cluster an operation is applied to.
+// Hosts are identified either by cloud ID or by ansible ID
+type HostFilter struct {
+	Only    []string // if not empty, only these hosts are selected
+	Exclude []string // these hosts are not selected
+}
+
+// IsEmpty is true if the filter selects every host
+func (f HostFilter) IsEmpty() bool {
+	return len(f.Only) == 0 && len(f.Exclude) == 0
+}
+
+func hostIDMatches(ids []string, cloudID string, ansibleID string) bool {
+	return slices.Contains(ids, cloudID) || slices.Contains(ids, ansibleID)
+}
+
+// Selects is true if the host with [cloudID] and [ansibleID] is selected by the filter
+func (f HostFilter) Selects(cloudID string, ansibleID string) bool {
+	if len(f.Only) > 0 && !hostIDMatches(f.Only, cloudID, ansibleID) {
+		return false
+	}
+	return !hostIDMatches(f.Exclude, cloudID, ansibleID)
+}
+
+// Apply returns the hosts selected by the filter. It fails if the filter references
+// unknown hosts, or if no host is selected
+func (f HostFilter) Apply(hosts []*Host) ([]*Host, error) {
+	if f.IsEmpty() {
+		return hosts, nil
+	}
+	for _, id := range append(slices.Clone(f.Only), f.Exclude...) {
+		if !slices.ContainsFunc(hosts, func(h *Host) bool { return h.GetCloudID() == id || h.NodeID == id }) {
+			return nil, fmt.Errorf("node %s not found in cluster", id)
+		}
+	}
+	selected := []*Host{}
+	for _, h := range hosts {
+		if f.Selects(h.GetCloudID(), h.NodeID) {
+			selected = append(selected, h)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, ErrNoHostsAfterFilter
+	}
+	return selected, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostFilterApply(t *testing.T) {
+	require := require.New(t)
+
+	hosts := []*Host{
+		{NodeID: "aws_node_i-0001"},
+		{NodeID: "aws_node_i-0002"},
+		{NodeID: "gcp_node_node-0003"},
+	}
+	nodeIDs := func(hosts []*Host) []string {
+		ids := []string{}
+		for _, h := range hosts {
+			ids = append(ids, h.NodeID)
+		}
+		return ids
+	}
+
+	selected, err := HostFilter{}.Apply(hosts)
+	require.NoError(err)
+	require.Equal(hosts, selected)
+
+	// by cloud ID
+	selected, err = HostFilter{Exclude: []string{"i-0002"}}.Apply(hosts)
+	require.NoError(err)
+	require.Equal([]string{"aws_node_i-0001", "gcp_node_node-0003"}, nodeIDs(selected))
+
+	// by ansible ID
+	selected, err = HostFilter{Only: []string{"aws_node_i-0002", "node-0003"}}.Apply(hosts)
+	require.NoError(err)
+	require.Equal([]string{"aws_node_i-0002", "gcp_node_node-0003"}, nodeIDs(selected))
+
+	selected, err = HostFilter{Only: []string{"i-0001", "i-0002"}, Exclude: []string{"i-0001"}}.Apply(hosts)
+	require.NoError(err)
+	require.Equal([]string{"aws_node_i-0002"}, nodeIDs(selected))
+
+	_, err = HostFilter{Exclude: []string{"i-9999"}}.Apply(hosts)
+	require.ErrorContains(err, "node i-9999 not found")
+
+	_, err = HostFilter{Only: []string{"i-0001"}, Exclude: []string{"aws_node_i-0001"}}.Apply(hosts)
+	require.ErrorIs(err, ErrNoHostsAfterFilter)
+
+	_, err = HostFilter{Exclude: []string{"i-0001", "i-0002", "node-0003"}}.Apply(hosts)
+	require.ErrorIs(err, ErrNoHostsAfterFilter)
+}
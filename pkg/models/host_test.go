@@ -12,6 +12,7 @@ import (
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/melbahja/goph"
 	oos "github.com/okteto/remote/pkg/os"
 	ossh "github.com/okteto/remote/pkg/ssh"
 	"github.com/stretchr/testify/require"
@@ -218,6 +219,60 @@ func hostRunTest(t *testing.T) {
 	}
 }
 
+func TestHostConnectionReuse(t *testing.T) {
+	require := require.New(t)
+	const reuseSSHPort = sshPort + 1
+
+	tmpDir := t.TempDir()
+	privKeyPath := filepath.Join(tmpDir, "unit-test-ssh-private-key")
+	require.NoError(os.WriteFile(privKeyPath, privateBytes, constants.WriteReadUserOnlyPerms))
+	block, _ := pem.Decode(privateBytes)
+	require.NotNil(block)
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	require.NoError(err)
+	sshPublicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	require.NoError(err)
+	authKeyPath := filepath.Join(tmpDir, "unit-test-ssh-auth-key")
+	require.NoError(os.WriteFile(authKeyPath, ssh.MarshalAuthorizedKey(sshPublicKey), constants.WriteReadUserOnlyPerms))
+
+	require.NoError(startSSHServer(reuseSSHPort, authKeyPath, t))
+	defer stopSSHServer()
+
+	dials := 0
+	newHostConnection = func(h *Host, port uint) (*goph.Client, error) {
+		dials++
+		return NewHostConnection(h, port)
+	}
+	defer func() {
+		newHostConnection = NewHostConnection
+	}()
+
+	host := &Host{
+		NodeID:            constants.E2EDocker + "_reuse",
+		IP:                localhost,
+		SSHPrivateKeyPath: privKeyPath,
+		SSHUser:           constants.AnsibleSSHUser,
+	}
+	require.NoError(host.WaitForPort(reuseSSHPort, 10*time.Second))
+	require.NoError(host.Connect(reuseSSHPort))
+	remoteDir := filepath.Join(tmpDir, "remote")
+	require.NoError(host.MkdirAll(remoteDir, time.Second))
+	for i := 0; i < 3; i++ {
+		_, err := host.Command("true", nil, 10*time.Second)
+		require.NoError(err)
+	}
+	exists, err := host.FileExists(remoteDir)
+	require.NoError(err)
+	require.True(exists)
+	require.Equal(1, dials)
+
+	require.NoError(host.Disconnect())
+	require.False(host.Connected())
+	require.NoError(host.Connect(reuseSSHPort))
+	require.Equal(2, dials)
+	require.NoError(host.Disconnect())
+}
+
 func startSSHServer(sshPort int, keyFileName string, t *testing.T) error {
 	stopChan = make(chan struct{})
 	// Get shell
@@ -314,3 +369,23 @@ func TestExpandHome(t *testing.T) {
 		t.Errorf("Expected: %s, Got: %s", expected5, result5)
 	}
 }
+
+func TestHostSSHUser(t *testing.T) {
+	require := require.New(t)
+
+	host := &Host{NodeID: "node1", IP: "127.0.0.1"}
+	require.Equal(constants.AnsibleSSHUser, host.GetSSHUser())
+	require.Equal("/home/ubuntu", host.GetHomeDir())
+	require.Equal("/home/ubuntu/.ssh/authorized_keys", host.ExpandHome("~/.ssh/authorized_keys"))
+	require.Contains(host.GetAnsibleInventoryRecord(), "ansible_user=ubuntu")
+
+	host.SSHUser = "admin"
+	require.Equal("admin", host.GetSSHUser())
+	require.Equal("/home/admin", host.GetHomeDir())
+	require.Equal("/home/admin/.avalanchego/db", host.ExpandHome("~/.avalanchego/db"))
+	require.Contains(host.GetAnsibleInventoryRecord(), "ansible_user=admin")
+
+	host.SSHUser = "root"
+	require.Equal("/root", host.GetHomeDir())
+	require.Equal("/root/.avalanchego/db", host.ExpandHome("~/.avalanchego/db"))
+}
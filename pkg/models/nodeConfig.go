@@ -2,6 +2,8 @@
 // See the file LICENSE for licensing terms.
 package models
 
+import "github.com/ava-labs/avalanche-cli/pkg/constants"
+
 type NodeConfig struct {
 	NodeID        string // instance id on cloud server
 	Region        string // region where cloud server instance is deployed
@@ -15,4 +17,25 @@ type NodeConfig struct {
 	IsMonitor     bool   // node has a monitoring dashboard
 	IsAWMRelayer  bool   // node has an AWM relayer service
 	IsLoadTest    bool   // node is used to host load test
+	HTTPPort      uint   // avalanchego http port, default is used if 0
+	StakingPort   uint   // avalanchego staking port, default is used if 0
+	SSHUser       string // remote user of the cloud server, default is used if empty
+	// set by node create --dns-zone
+	DNSZone string // Route53 hosted zone ID (AWS) or Cloud DNS managed zone (GCP) of the DNS record
+	DNSName string // DNS name of the A record pointing at ElasticIP
+	// set by node stop
+	AvalancheGoStopped bool // avalanchego service is stopped
+	InstanceStopped    bool // cloud server instance is stopped
+	// set by node create --tags
+	Tags map[string]string // tags (AWS) or labels (GCP) added to the cloud server
+	// set by node id
+	AvalancheGoNodeID string // avalanchego node ID reported by the node, cleared when its staking keys are rotated
+}
+
+// GetHTTPPort returns the avalanchego http port of the node
+func (nc *NodeConfig) GetHTTPPort() uint {
+	if nc.HTTPPort == 0 {
+		return constants.AvalanchegoAPIPort
+	}
+	return nc.HTTPPort
 }
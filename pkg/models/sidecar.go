@@ -45,6 +45,7 @@ type Sidecar struct {
 	ImportedVMID        string
 	CustomVMRepoURL     string
 	CustomVMBranch      string
+	CustomVMTag         string `json:",omitempty"`
 	CustomVMBuildScript string
 	// Teleporter related
 	TeleporterReady   bool
@@ -55,6 +56,15 @@ type Sidecar struct {
 	SubnetEVMMainnetChainID uint
 }
 
+// GetCustomVMRef returns the git ref the custom VM is built from: its tag if
+// pinned to one, or else its branch or commit
+func (sc Sidecar) GetCustomVMRef() string {
+	if sc.CustomVMTag != "" {
+		return sc.CustomVMTag
+	}
+	return sc.CustomVMBranch
+}
+
 func (sc Sidecar) GetVMID() (string, error) {
 	// get vmid
 	var vmid string
@@ -38,3 +38,11 @@ func TestGetVMID_derived(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(expectedVMID.String(), vmid)
 }
+
+func TestGetCustomVMRef(t *testing.T) {
+	require := require.New(t)
+	sc := Sidecar{CustomVMBranch: "main"}
+	require.Equal("main", sc.GetCustomVMRef())
+	sc.CustomVMTag = "v1.2.3"
+	require.Equal("v1.2.3", sc.GetCustomVMRef())
+}
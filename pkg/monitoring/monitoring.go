@@ -6,25 +6,94 @@ package monitoring
 import (
 	"bytes"
 	"embed"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanchego/ids"
 )
 
 type configInputs struct {
 	AvalancheGoPorts string
 	MachinePorts     string
 	LoadTestPorts    string
+	SubnetMetrics    []subnetMetricsInputs
 	IP               string
 	Port             string
 	Host             string
 	NodeID           string
 	ChainID          string
+	RemoteWrite      *RemoteWriteConfig
+}
+
+// RemoteWriteConfig describes a prometheus remote write target, authenticated
+// either with basic auth or with a bearer token
+type RemoteWriteConfig struct {
+	URL         string
+	Username    string
+	Password    string
+	BearerToken string
+}
+
+// Validate checks that the remote write URL is a valid http(s) URL, and that
+// at most one authentication method is set
+func (rw *RemoteWriteConfig) Validate() error {
+	u, err := url.Parse(rw.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid prometheus remote write URL: %s", rw.URL)
+	}
+	if (rw.Username == "") != (rw.Password == "") {
+		return errors.New("prometheus remote write basic auth requires both username and password")
+	}
+	if rw.Username != "" && rw.BearerToken != "" {
+		return errors.New("prometheus remote write basic auth and bearer token are mutually exclusive")
+	}
+	for _, value := range []string{rw.URL, rw.Username, rw.Password, rw.BearerToken} {
+		if strings.ContainsAny(value, "'\n\r") {
+			return errors.New("prometheus remote write settings can't contain quotes or line breaks")
+		}
+	}
+	return nil
+}
+
+// SubnetMetricsTarget is a blockchain whose VM metrics, served at /ext/bc/<blockchainID>/metrics,
+// are scraped from [Targets], the host:port avalanchego http endpoints of the nodes tracking it
+type SubnetMetricsTarget struct {
+	BlockchainID string
+	Targets      []string
+}
+
+// Validate checks that the blockchain ID is valid, and that the targets are host:port endpoints
+func (t SubnetMetricsTarget) Validate() error {
+	if _, err := ids.FromString(t.BlockchainID); err != nil {
+		return fmt.Errorf("invalid subnet metrics blockchain ID %q: %w", t.BlockchainID, err)
+	}
+	if len(t.Targets) == 0 {
+		return fmt.Errorf("no subnet metrics targets given for blockchain %s", t.BlockchainID)
+	}
+	for _, target := range t.Targets {
+		host, port, err := net.SplitHostPort(strings.Trim(target, "'"))
+		if err != nil || host == "" {
+			return fmt.Errorf("invalid subnet metrics target %q: expected host:port", target)
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return fmt.Errorf("invalid subnet metrics target %q: invalid port", target)
+		}
+	}
+	return nil
+}
+
+type subnetMetricsInputs struct {
+	BlockchainID string
+	Targets      string
 }
 
 //go:embed dashboards/*
@@ -77,16 +146,49 @@ func GenerateConfig(configPath string, configDesc string, templateVars configInp
 	return config.String(), nil
 }
 
-func WritePrometheusConfig(filePath string, avalancheGoPorts []string, machinePorts []string, loadTestPorts []string) error {
+// WritePrometheusConfig writes prometheus config to [filePath]. A scrape job is added for the
+// VM metrics of each blockchain of [subnetMetrics]. If [remoteWrite] is not nil, a remote_write
+// block is added, and the file is only readable by the user as it may contain secrets
+func WritePrometheusConfig(
+	filePath string,
+	avalancheGoPorts []string,
+	machinePorts []string,
+	loadTestPorts []string,
+	subnetMetrics []SubnetMetricsTarget,
+	remoteWrite *RemoteWriteConfig,
+) error {
+	for _, target := range subnetMetrics {
+		if err := target.Validate(); err != nil {
+			return err
+		}
+	}
+	perms := os.FileMode(constants.WriteReadReadPerms)
+	if remoteWrite != nil {
+		if err := remoteWrite.Validate(); err != nil {
+			return err
+		}
+		perms = constants.WriteReadUserOnlyPerms
+	}
 	config, err := GenerateConfig("configs/prometheus.yml", "Prometheus Config", configInputs{
 		AvalancheGoPorts: strings.Join(utils.AddSingleQuotes(avalancheGoPorts), ","),
 		MachinePorts:     strings.Join(utils.AddSingleQuotes(machinePorts), ","),
 		LoadTestPorts:    strings.Join(utils.AddSingleQuotes(loadTestPorts), ","),
+		SubnetMetrics: utils.Map(subnetMetrics, func(target SubnetMetricsTarget) subnetMetricsInputs {
+			return subnetMetricsInputs{
+				BlockchainID: target.BlockchainID,
+				Targets:      strings.Join(utils.AddSingleQuotes(target.Targets), ","),
+			}
+		}),
+		RemoteWrite: remoteWrite,
 	})
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filePath, []byte(config), constants.WriteReadReadPerms)
+	if err := os.WriteFile(filePath, []byte(config), perms); err != nil {
+		return err
+	}
+	// WriteFile does not change perms of existing files
+	return os.Chmod(filePath, perms)
 }
 
 func WriteLokiConfig(filePath string, port string) error {
@@ -0,0 +1,123 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package monitoring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestWritePrometheusConfigRemoteWrite(t *testing.T) {
+	require := require.New(t)
+	avalancheGoPorts := []string{"1.2.3.4:9650"}
+	machinePorts := []string{"1.2.3.4:9100"}
+
+	type promConfig struct {
+		RemoteWrite []map[string]interface{} `yaml:"remote_write"`
+	}
+	readConfig := func(filePath string) promConfig {
+		configBytes, err := os.ReadFile(filePath)
+		require.NoError(err)
+		config := promConfig{}
+		require.NoError(yaml.Unmarshal(configBytes, &config))
+		return config
+	}
+
+	// no remote write
+	filePath := filepath.Join(t.TempDir(), "prometheus.yml")
+	require.NoError(WritePrometheusConfig(filePath, avalancheGoPorts, machinePorts, nil, nil, nil))
+	require.Empty(readConfig(filePath).RemoteWrite)
+	info, err := os.Stat(filePath)
+	require.NoError(err)
+	require.Equal(os.FileMode(constants.WriteReadReadPerms), info.Mode().Perm())
+
+	// remote write with basic auth
+	filePath = filepath.Join(t.TempDir(), "prometheus.yml")
+	require.NoError(WritePrometheusConfig(filePath, avalancheGoPorts, machinePorts, nil, nil, &RemoteWriteConfig{
+		URL:      "https://prometheus.example.com/api/prom/push",
+		Username: "user",
+		Password: "secret",
+	}))
+	remoteWrite := readConfig(filePath).RemoteWrite
+	require.Len(remoteWrite, 1)
+	require.Equal("https://prometheus.example.com/api/prom/push", remoteWrite[0]["url"])
+	require.Equal(map[string]interface{}{"username": "user", "password": "secret"}, remoteWrite[0]["basic_auth"])
+	require.NotContains(remoteWrite[0], "authorization")
+	info, err = os.Stat(filePath)
+	require.NoError(err)
+	require.Equal(os.FileMode(constants.WriteReadUserOnlyPerms), info.Mode().Perm())
+
+	// remote write with bearer token
+	filePath = filepath.Join(t.TempDir(), "prometheus.yml")
+	require.NoError(WritePrometheusConfig(filePath, avalancheGoPorts, machinePorts, nil, nil, &RemoteWriteConfig{
+		URL:         "https://prometheus.example.com/api/prom/push",
+		BearerToken: "token",
+	}))
+	remoteWrite = readConfig(filePath).RemoteWrite
+	require.Len(remoteWrite, 1)
+	require.Equal(map[string]interface{}{"type": "Bearer", "credentials": "token"}, remoteWrite[0]["authorization"])
+	require.NotContains(remoteWrite[0], "basic_auth")
+}
+
+func TestRemoteWriteConfigValidate(t *testing.T) {
+	require := require.New(t)
+	require.NoError((&RemoteWriteConfig{URL: "http://10.0.0.1:9009/api/v1/push"}).Validate())
+	require.Error((&RemoteWriteConfig{URL: "not a url"}).Validate())
+	require.Error((&RemoteWriteConfig{URL: "ftp://example.com"}).Validate())
+	require.Error((&RemoteWriteConfig{URL: "https://example.com", Username: "user"}).Validate())
+	require.Error((&RemoteWriteConfig{URL: "https://example.com", Username: "user", Password: "pass", BearerToken: "token"}).Validate())
+	require.Error((&RemoteWriteConfig{URL: "https://example.com", BearerToken: "tok'en"}).Validate())
+}
+
+func TestWritePrometheusConfigSubnetMetrics(t *testing.T) {
+	require := require.New(t)
+	avalancheGoPorts := []string{"1.2.3.4:9650"}
+	machinePorts := []string{"1.2.3.4:9100"}
+	blockchainID := "2b175hLJhGdj3CzgXENso9CmwMgejaCQXhMFzBsm8hXbH2MF7H"
+
+	type scrapeConfig struct {
+		JobName       string `yaml:"job_name"`
+		MetricsPath   string `yaml:"metrics_path"`
+		StaticConfigs []struct {
+			Targets []string          `yaml:"targets"`
+			Labels  map[string]string `yaml:"labels"`
+		} `yaml:"static_configs"`
+	}
+	type promConfig struct {
+		ScrapeConfigs []scrapeConfig `yaml:"scrape_configs"`
+	}
+
+	filePath := filepath.Join(t.TempDir(), "prometheus.yml")
+	require.NoError(WritePrometheusConfig(filePath, avalancheGoPorts, machinePorts, nil, []SubnetMetricsTarget{
+		{BlockchainID: blockchainID, Targets: []string{"1.2.3.4:9650", "5.6.7.8:9652"}},
+	}, nil))
+	configBytes, err := os.ReadFile(filePath)
+	require.NoError(err)
+	config := promConfig{}
+	require.NoError(yaml.Unmarshal(configBytes, &config))
+	var subnetJob *scrapeConfig
+	for i := range config.ScrapeConfigs {
+		if config.ScrapeConfigs[i].JobName == "subnet-evm-"+blockchainID {
+			subnetJob = &config.ScrapeConfigs[i]
+		}
+	}
+	require.NotNil(subnetJob)
+	require.Equal("/ext/bc/"+blockchainID+"/metrics", subnetJob.MetricsPath)
+	require.Len(subnetJob.StaticConfigs, 1)
+	require.Equal([]string{"1.2.3.4:9650", "5.6.7.8:9652"}, subnetJob.StaticConfigs[0].Targets)
+	require.Equal(blockchainID, subnetJob.StaticConfigs[0].Labels["blockchain_id"])
+
+	for _, target := range []SubnetMetricsTarget{
+		{BlockchainID: "invalid", Targets: []string{"1.2.3.4:9650"}},
+		{BlockchainID: blockchainID},
+		{BlockchainID: blockchainID, Targets: []string{"1.2.3.4"}},
+		{BlockchainID: blockchainID, Targets: []string{"1.2.3.4:port"}},
+	} {
+		require.Error(WritePrometheusConfig(filePath, avalancheGoPorts, machinePorts, nil, []SubnetMetricsTarget{target}, nil))
+	}
+}
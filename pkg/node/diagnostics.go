@@ -0,0 +1,295 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+)
+
+const (
+	DiagnosticsManifestFile   = "manifest.json"
+	DefaultDiagnosticsLogTail = 10000
+	diagnosticsRedactedValue  = "<redacted>"
+)
+
+// secretKeyRegex matches the config keys whose values are not included in a diagnostics bundle
+var secretKeyRegex = regexp.MustCompile(`(?i)(private[-_]?key|key[-_]?file[-_]?content|secret|password)`)
+
+var errNotRedactable = errors.New("not valid JSON, so private keys can't be redacted. not included")
+
+// DiagnosticsHost is what is needed from a node to collect its diagnostics
+type DiagnosticsHost interface {
+	Command(script string) ([]byte, error)
+	DownloadFile(remoteFile string, localFile string) error
+	CheckHealthy() ([]byte, error)
+	GetVersion() ([]byte, error)
+	// ExpandHome expands the ~ symbol of a remote path to the home directory of the remote user
+	ExpandHome(path string) string
+}
+
+// DiagnosticsEntry describes one file of a diagnostics bundle. If the data could not be
+// collected, Error tells why and the file is not in the bundle
+type DiagnosticsEntry struct {
+	File     string `json:"file"`
+	Source   string `json:"source"`
+	Size     int    `json:"size,omitempty"`
+	Redacted bool   `json:"redacted,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// DiagnosticsManifest lists the contents of a diagnostics bundle
+type DiagnosticsManifest struct {
+	NodeID    string             `json:"nodeID"`
+	CreatedAt time.Time          `json:"createdAt"`
+	Entries   []DiagnosticsEntry `json:"entries"`
+}
+
+// Failed returns the entries that could not be collected
+func (m DiagnosticsManifest) Failed() []DiagnosticsEntry {
+	return utils.Filter(m.Entries, func(e DiagnosticsEntry) bool { return e.Error != "" })
+}
+
+type diagnosticsCollector struct {
+	host     DiagnosticsHost
+	workDir  string
+	manifest *DiagnosticsManifest
+}
+
+func (c *diagnosticsCollector) add(file string, source string, collect func() ([]byte, bool, error)) {
+	entry := DiagnosticsEntry{File: file, Source: source}
+	data, redacted, err := collect()
+	if err == nil {
+		localFile := filepath.Join(c.workDir, filepath.FromSlash(file))
+		if err = os.MkdirAll(filepath.Dir(localFile), constants.DefaultPerms755); err == nil {
+			err = os.WriteFile(localFile, data, constants.WriteReadUserOnlyPerms)
+		}
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Size = len(data)
+		entry.Redacted = redacted
+	}
+	c.manifest.Entries = append(c.manifest.Entries, entry)
+}
+
+func (c *diagnosticsCollector) addCommand(file string, script string) {
+	c.add(file, script, func() ([]byte, bool, error) {
+		output, err := c.host.Command(script)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return output, false, nil
+	})
+}
+
+func (c *diagnosticsCollector) addRPCResult(file string, source string, call func() ([]byte, error)) {
+	c.add(file, source, func() ([]byte, bool, error) {
+		output, err := call()
+		if err != nil {
+			return nil, false, err
+		}
+		result, err := parseRPCResult(output)
+		return result, false, err
+	})
+}
+
+// addConfig downloads the JSON config [remoteFile] with its private keys redacted
+func (c *diagnosticsCollector) addConfig(file string, remoteFile string) {
+	c.add(file, remoteFile, func() ([]byte, bool, error) {
+		tmpFile, err := os.CreateTemp("", "diagnostics-config-*")
+		if err != nil {
+			return nil, false, err
+		}
+		_ = tmpFile.Close()
+		defer os.Remove(tmpFile.Name())
+		if err := c.host.DownloadFile(remoteFile, tmpFile.Name()); err != nil {
+			return nil, false, err
+		}
+		data, err := os.ReadFile(tmpFile.Name())
+		if err != nil {
+			return nil, false, err
+		}
+		return RedactPrivateKeys(data)
+	})
+}
+
+// listRemoteFiles lists the files of [remoteDir] matching [namePattern], relative to [remoteDir]
+func (c *diagnosticsCollector) listRemoteFiles(remoteDir string, namePattern string, maxDepth int) ([]string, error) {
+	script := fmt.Sprintf("find %s -maxdepth %d -type f -name '%s'", remoteDir, maxDepth, namePattern)
+	output, err := c.host.Command(script)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	files := []string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		relPath, err := filepath.Rel(remoteDir, line)
+		if err != nil || strings.HasPrefix(relPath, "..") {
+			continue
+		}
+		files = append(files, filepath.ToSlash(relPath))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// CollectDiagnostics gathers the health, version, configs, logs and docker status of [host]
+// into [workDir], keeping the last [logTail] lines of each log. Data that can't be collected
+// is recorded as failed in the returned manifest, without stopping the collection
+func CollectDiagnostics(host DiagnosticsHost, nodeID string, workDir string, logTail int) DiagnosticsManifest {
+	manifest := DiagnosticsManifest{
+		NodeID:    nodeID,
+		CreatedAt: time.Now().UTC(),
+		Entries:   []DiagnosticsEntry{},
+	}
+	c := &diagnosticsCollector{host: host, workDir: workDir, manifest: &manifest}
+
+	c.addRPCResult("health.json", "health.health", host.CheckHealthy)
+	c.addRPCResult("version.json", "info.getNodeVersion", host.GetVersion)
+
+	composeFile := utils.GetRemoteComposeFile()
+	c.addCommand("docker-compose-ps.txt", fmt.Sprintf("docker compose -f %s ps -a", composeFile))
+	c.addCommand("docker-compose-logs.txt", fmt.Sprintf("docker compose -f %s logs --no-color --tail %d", composeFile, logTail))
+	c.addCommand("disk-usage.txt", "df -h")
+	c.addCommand("memory-usage.txt", "free -m")
+
+	configPath := host.ExpandHome(constants.CloudNodeConfigPath)
+	if configFiles, err := c.listRemoteFiles(configPath, "*.json", 4); err != nil {
+		manifest.Entries = append(manifest.Entries, DiagnosticsEntry{File: "configs/", Source: configPath, Error: err.Error()})
+	} else {
+		for _, configFile := range configFiles {
+			c.addConfig(filepath.ToSlash(filepath.Join("configs", configFile)), filepath.Join(configPath, configFile))
+		}
+	}
+
+	logsPath := host.ExpandHome(constants.CloudNodeLogsPath)
+	if logFiles, err := c.listRemoteFiles(logsPath, "*.log", 1); err != nil {
+		manifest.Entries = append(manifest.Entries, DiagnosticsEntry{File: "logs/", Source: logsPath, Error: err.Error()})
+	} else {
+		for _, logFile := range logFiles {
+			c.addCommand(filepath.ToSlash(filepath.Join("logs", logFile)), fmt.Sprintf("tail -n %d %s", logTail, filepath.Join(logsPath, logFile)))
+		}
+	}
+	return manifest
+}
+
+// parseRPCResult gets the indented result out of a JSON RPC response
+func parseRPCResult(response []byte) ([]byte, error) {
+	reply := struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}{}
+	if err := json.Unmarshal(response, &reply); err != nil {
+		return nil, fmt.Errorf("invalid response %q: %w", string(response), err)
+	}
+	if len(reply.Error) != 0 && string(reply.Error) != "null" {
+		return nil, fmt.Errorf("request failed: %s", string(reply.Error))
+	}
+	if len(reply.Result) == 0 {
+		return nil, fmt.Errorf("no result in response %q", string(response))
+	}
+	return json.MarshalIndent(reply.Result, "", "  ")
+}
+
+// RedactPrivateKeys replaces the values of the private key, secret and password fields of the
+// JSON config [data]. Returns if any value was redacted. Fails if [data] is not JSON
+func RedactPrivateKeys(data []byte) ([]byte, bool, error) {
+	var config interface{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, false, errNotRedactable
+	}
+	redacted := false
+	var redact func(value interface{}) interface{}
+	redact = func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for k, fieldValue := range v {
+				if secretKeyRegex.MatchString(k) {
+					v[k] = diagnosticsRedactedValue
+					redacted = true
+					continue
+				}
+				v[k] = redact(fieldValue)
+			}
+		case []interface{}:
+			for i := range v {
+				v[i] = redact(v[i])
+			}
+		}
+		return value
+	}
+	config = redact(config)
+	if !redacted {
+		return data, false, nil
+	}
+	redactedData, err := json.MarshalIndent(config, "", "  ")
+	return redactedData, true, err
+}
+
+// WriteDiagnosticsBundle writes the files collected in [workDir], together with [manifest],
+// into the tar.gz file [bundlePath]
+func WriteDiagnosticsBundle(workDir string, manifest DiagnosticsManifest, bundlePath string) error {
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	bundleFile, err := os.OpenFile(bundlePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, constants.WriteReadUserOnlyPerms)
+	if err != nil {
+		return err
+	}
+	defer bundleFile.Close()
+	gzipWriter := gzip.NewWriter(bundleFile)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := writeTarFile(tarWriter, DiagnosticsManifestFile, manifestBytes, manifest.CreatedAt); err != nil {
+		return err
+	}
+	for _, entry := range manifest.Entries {
+		if entry.Error != "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(workDir, filepath.FromSlash(entry.File)))
+		if err != nil {
+			return err
+		}
+		if err := writeTarFile(tarWriter, entry.File, data, manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return bundleFile.Close()
+}
+
+func writeTarFile(tarWriter *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    int64(constants.WriteReadUserOnlyPerms),
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := tarWriter.Write(data)
+	return err
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDiagnosticsHost struct {
+	home     string
+	commands map[string]string // script prefix -> output
+	files    map[string]string // remote path -> content
+}
+
+func (h *fakeDiagnosticsHost) Command(script string) ([]byte, error) {
+	for prefix, output := range h.commands {
+		if strings.HasPrefix(script, prefix) {
+			return []byte(output), nil
+		}
+	}
+	return []byte("command not found"), errors.New("exit status 127")
+}
+
+func (h *fakeDiagnosticsHost) DownloadFile(remoteFile string, localFile string) error {
+	content, ok := h.files[remoteFile]
+	if !ok {
+		return errors.New("file does not exist")
+	}
+	return os.WriteFile(localFile, []byte(content), constants.WriteReadUserOnlyPerms)
+}
+
+func (*fakeDiagnosticsHost) CheckHealthy() ([]byte, error) {
+	return []byte(`{"jsonrpc":"2.0","result":{"healthy":true},"id":1}`), nil
+}
+
+func (*fakeDiagnosticsHost) GetVersion() ([]byte, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (h *fakeDiagnosticsHost) ExpandHome(path string) string {
+	if strings.HasPrefix(path, "~") {
+		return filepath.Join(h.home, path[1:])
+	}
+	return path
+}
+
+func newFakeDiagnosticsHost() *fakeDiagnosticsHost {
+	// the remote user is not ubuntu
+	home := "/home/admin"
+	configPath := filepath.Join(home, ".avalanchego", "configs")
+	nodeConfig := filepath.Join(configPath, "node.json")
+	cChainConfig := filepath.Join(configPath, "chains/C/config.json")
+	brokenConfig := filepath.Join(configPath, "subnets/broken.json")
+	logsPath := filepath.Join(home, ".avalanchego/logs")
+	return &fakeDiagnosticsHost{
+		home: home,
+		commands: map[string]string{
+			"docker compose":         "NAME STATUS\navalanchego Up\n",
+			"df -h":                  "/dev/root 1T 10G\n",
+			"find " + configPath:     strings.Join([]string{nodeConfig, cChainConfig, brokenConfig}, "\n") + "\n",
+			"find " + logsPath:       filepath.Join(logsPath, "main.log") + "\n",
+			"tail -n 10 " + logsPath: "[10-17|12:00:00.000] INFO started\n",
+		},
+		files: map[string]string{
+			nodeConfig:   `{"network-id":"fuji","staking-signer-key-file-content":"c2VjcmV0"}`,
+			cChainConfig: `{"log-level":"info","eth-apis":["eth"]}`,
+			brokenConfig: `private-key: 1234`,
+		},
+	}
+}
+
+func TestCollectDiagnostics(t *testing.T) {
+	require := require.New(t)
+	workDir := t.TempDir()
+	manifest := CollectDiagnostics(newFakeDiagnosticsHost(), "NodeID-test", workDir, 10)
+
+	require.Equal("NodeID-test", manifest.NodeID)
+	entries := map[string]DiagnosticsEntry{}
+	files := []string{}
+	for _, entry := range manifest.Entries {
+		entries[entry.File] = entry
+		files = append(files, entry.File)
+	}
+	require.Equal([]string{
+		"health.json",
+		"version.json",
+		"docker-compose-ps.txt",
+		"docker-compose-logs.txt",
+		"disk-usage.txt",
+		"memory-usage.txt",
+		"configs/chains/C/config.json",
+		"configs/node.json",
+		"configs/subnets/broken.json",
+		"logs/main.log",
+	}, files)
+
+	failed := []string{}
+	for _, entry := range manifest.Failed() {
+		failed = append(failed, entry.File)
+	}
+	require.Equal([]string{"version.json", "memory-usage.txt", "configs/subnets/broken.json"}, failed)
+	require.Contains(entries["version.json"].Error, "connection refused")
+	require.Contains(entries["memory-usage.txt"].Error, "command not found")
+	require.Contains(entries["configs/subnets/broken.json"].Error, "can't be redacted")
+	require.NoFileExists(filepath.Join(workDir, "configs", "subnets", "broken.json"))
+
+	health, err := os.ReadFile(filepath.Join(workDir, "health.json"))
+	require.NoError(err)
+	require.JSONEq(`{"healthy":true}`, string(health))
+
+	require.True(entries["configs/node.json"].Redacted)
+	nodeConfig, err := os.ReadFile(filepath.Join(workDir, "configs", "node.json"))
+	require.NoError(err)
+	require.NotContains(string(nodeConfig), "c2VjcmV0")
+	require.JSONEq(`{"network-id":"fuji","staking-signer-key-file-content":"<redacted>"}`, string(nodeConfig))
+	require.False(entries["configs/chains/C/config.json"].Redacted)
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(WriteDiagnosticsBundle(workDir, manifest, bundlePath))
+	bundleFile, err := os.Open(bundlePath)
+	require.NoError(err)
+	defer bundleFile.Close()
+	gzipReader, err := gzip.NewReader(bundleFile)
+	require.NoError(err)
+	tarReader := tar.NewReader(gzipReader)
+	bundleFiles := map[string][]byte{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		data, err := io.ReadAll(tarReader)
+		require.NoError(err)
+		bundleFiles[header.Name] = data
+	}
+	require.Len(bundleFiles, len(manifest.Entries)-len(manifest.Failed())+1)
+	require.Equal("[10-17|12:00:00.000] INFO started\n", string(bundleFiles["logs/main.log"]))
+	bundleManifest := DiagnosticsManifest{}
+	require.NoError(json.Unmarshal(bundleFiles[DiagnosticsManifestFile], &bundleManifest))
+	require.Equal(manifest.Entries, bundleManifest.Entries)
+}
+
+func TestRedactPrivateKeys(t *testing.T) {
+	require := require.New(t)
+	data, redacted, err := RedactPrivateKeys([]byte(`{"destination-blockchains":[{"account-private-key":"56289e99"}],"log-level":"info"}`))
+	require.NoError(err)
+	require.True(redacted)
+	require.JSONEq(`{"destination-blockchains":[{"account-private-key":"<redacted>"}],"log-level":"info"}`, string(data))
+
+	original := []byte(`{"log-level":"info"}`)
+	data, redacted, err = RedactPrivateKeys(original)
+	require.NoError(err)
+	require.False(redacted)
+	require.Equal(original, data)
+
+	_, _, err = RedactPrivateKeys([]byte("not json"))
+	require.ErrorIs(err, errNotRedactable)
+}
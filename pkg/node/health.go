@@ -0,0 +1,117 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+)
+
+type HealthStatus string
+
+const (
+	HealthStatusHealthy     HealthStatus = "healthy"
+	HealthStatusUnhealthy   HealthStatus = "unhealthy"
+	HealthStatusUnreachable HealthStatus = "unreachable"
+)
+
+// Exit codes of node health. 1 is left for errors that prevented to check the nodes
+const (
+	HealthExitCodeHealthy     = 0
+	HealthExitCodeUnhealthy   = 2
+	HealthExitCodeUnreachable = 3
+)
+
+// HostHealth is the health of a node, together with the error that prevented
+// to check it, if any
+type HostHealth struct {
+	Host   *models.Host
+	Status HealthStatus
+	Err    error
+}
+
+// ParseHealthyOutput parses the response of a health.health call
+func ParseHealthyOutput(byteValue []byte) (bool, error) {
+	var result map[string]interface{}
+	if err := json.Unmarshal(byteValue, &result); err != nil {
+		return false, err
+	}
+	isHealthyInterface, ok := result["result"].(map[string]interface{})
+	if ok {
+		isHealthy, ok := isHealthyInterface["healthy"].(bool)
+		if ok {
+			return isHealthy, nil
+		}
+	}
+	return false, fmt.Errorf("unable to parse node healthy status")
+}
+
+// CheckHostsHealth calls [checkHealthyFunc] in parallel for each of [hosts], and classifies them,
+// keeping the order of [hosts]:
+//   - unreachable, if the health call failed
+//   - unhealthy, if the node reports unhealthy, or its response could not be parsed
+//   - healthy, otherwise
+func CheckHostsHealth(
+	hosts []*models.Host,
+	checkHealthyFunc func(*models.Host) ([]byte, error),
+) []HostHealth {
+	hostsHealth := make([]HostHealth, len(hosts))
+	wg := sync.WaitGroup{}
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host *models.Host) {
+			defer wg.Done()
+			hostsHealth[i] = HostHealth{Host: host}
+			resp, err := checkHealthyFunc(host)
+			if err != nil {
+				hostsHealth[i].Status = HealthStatusUnreachable
+				hostsHealth[i].Err = err
+				return
+			}
+			isHealthy, err := ParseHealthyOutput(resp)
+			switch {
+			case err != nil:
+				hostsHealth[i].Status = HealthStatusUnhealthy
+				hostsHealth[i].Err = err
+			case isHealthy:
+				hostsHealth[i].Status = HealthStatusHealthy
+			default:
+				hostsHealth[i].Status = HealthStatusUnhealthy
+			}
+		}(i, host)
+	}
+	wg.Wait()
+	return hostsHealth
+}
+
+// HealthExitCode maps [hostsHealth] to the exit code of node health: HealthExitCodeHealthy only if all
+// the nodes are healthy, else HealthExitCodeUnreachable if any node is unreachable, else HealthExitCodeUnhealthy
+func HealthExitCode(hostsHealth []HostHealth) int {
+	exitCode := HealthExitCodeHealthy
+	for _, hostHealth := range hostsHealth {
+		switch hostHealth.Status {
+		case HealthStatusUnreachable:
+			return HealthExitCodeUnreachable
+		case HealthStatusUnhealthy:
+			exitCode = HealthExitCodeUnhealthy
+		}
+	}
+	return exitCode
+}
+
+// FilterHostsByHealth returns the hosts of [hostsHealth] that have any of [statuses]
+func FilterHostsByHealth(hostsHealth []HostHealth, statuses ...HealthStatus) []*models.Host {
+	hosts := []*models.Host{}
+	for _, hostHealth := range hostsHealth {
+		for _, status := range statuses {
+			if hostHealth.Status == status {
+				hosts = append(hosts, hostHealth.Host)
+				break
+			}
+		}
+	}
+	return hosts
+}
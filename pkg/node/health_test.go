@@ -0,0 +1,88 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHealthyOutput(t *testing.T) {
+	require := require.New(t)
+	isHealthy, err := ParseHealthyOutput([]byte(`{"jsonrpc":"2.0","result":{"healthy":true},"id":1}`))
+	require.NoError(err)
+	require.True(isHealthy)
+	isHealthy, err = ParseHealthyOutput([]byte(`{"jsonrpc":"2.0","result":{"healthy":false},"id":1}`))
+	require.NoError(err)
+	require.False(isHealthy)
+	_, err = ParseHealthyOutput([]byte(`{"jsonrpc":"2.0","id":1}`))
+	require.ErrorContains(err, "unable to parse node healthy status")
+	_, err = ParseHealthyOutput([]byte(`not json`))
+	require.Error(err)
+}
+
+func TestCheckHostsHealth(t *testing.T) {
+	require := require.New(t)
+	hosts := newTestHosts(5)
+	responses := map[string]string{
+		"node0": `{"result":{"healthy":true}}`,
+		"node1": `{"result":{"healthy":false}}`,
+		"node3": `{"error":"unexpected"}`,
+		"node4": `{"result":{"healthy":true}}`,
+	}
+	checkHealthy := func(host *models.Host) ([]byte, error) {
+		resp, ok := responses[host.NodeID]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		return []byte(resp), nil
+	}
+
+	hostsHealth := CheckHostsHealth(hosts, checkHealthy)
+	require.Len(hostsHealth, len(hosts))
+	statuses := []HealthStatus{}
+	for i, hostHealth := range hostsHealth {
+		require.Equal(hosts[i], hostHealth.Host)
+		statuses = append(statuses, hostHealth.Status)
+	}
+	require.Equal([]HealthStatus{
+		HealthStatusHealthy,
+		HealthStatusUnhealthy,
+		HealthStatusUnreachable,
+		HealthStatusUnhealthy,
+		HealthStatusHealthy,
+	}, statuses)
+	require.NoError(hostsHealth[1].Err)
+	require.ErrorContains(hostsHealth[2].Err, "connection refused")
+	require.ErrorContains(hostsHealth[3].Err, "unable to parse node healthy status")
+
+	require.Equal([]*models.Host{hosts[0], hosts[4]}, FilterHostsByHealth(hostsHealth, HealthStatusHealthy))
+	require.Equal(
+		[]*models.Host{hosts[1], hosts[2], hosts[3]},
+		FilterHostsByHealth(hostsHealth, HealthStatusUnhealthy, HealthStatusUnreachable),
+	)
+	require.Empty(FilterHostsByHealth(nil, HealthStatusHealthy))
+}
+
+func TestHealthExitCode(t *testing.T) {
+	require := require.New(t)
+	hosts := newTestHosts(3)
+	withStatuses := func(statuses ...HealthStatus) []HostHealth {
+		hostsHealth := []HostHealth{}
+		for i, status := range statuses {
+			hostsHealth = append(hostsHealth, HostHealth{Host: hosts[i], Status: status})
+		}
+		return hostsHealth
+	}
+	require.Equal(HealthExitCodeHealthy, HealthExitCode(withStatuses(HealthStatusHealthy, HealthStatusHealthy, HealthStatusHealthy)))
+	require.Equal(HealthExitCodeHealthy, HealthExitCode(nil))
+	require.Equal(HealthExitCodeUnhealthy, HealthExitCode(withStatuses(HealthStatusHealthy, HealthStatusUnhealthy, HealthStatusHealthy)))
+	// an unreachable node takes precedence over an unhealthy one
+	require.Equal(HealthExitCodeUnreachable, HealthExitCode(withStatuses(HealthStatusUnreachable, HealthStatusUnhealthy, HealthStatusHealthy)))
+	require.Equal(HealthExitCodeUnreachable, HealthExitCode(withStatuses(HealthStatusHealthy, HealthStatusUnhealthy, HealthStatusUnreachable)))
+	require.NotEqual(1, HealthExitCodeUnhealthy)
+	require.NotEqual(1, HealthExitCodeUnreachable)
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+)
+
+const (
+	DefaultLogsLines          = 100
+	avalancheGoComposeService = "avalanchego"
+)
+
+var logFileNameRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+\.log$`)
+
+// LogsOptions selects the AvalancheGo logs to get from a node
+type LogsOptions struct {
+	// File is a log file in the AvalancheGo logs dir, as main.log. If empty, the
+	// docker compose logs of the avalanchego service are used
+	File string
+	// LogsDir is the AvalancheGo logs dir of the node, with its home expanded, as
+	// host.ExpandHome(constants.CloudNodeLogsPath). Only used with File
+	LogsDir string
+	// Lines is the number of lines to get from the end of the log
+	Lines int
+	// Since, if not zero, only includes the lines newer than it. Docker compose logs only
+	Since time.Duration
+	// Follow keeps streaming new lines
+	Follow bool
+}
+
+// LogsCommand returns the remote command that prints the AvalancheGo logs of a node according
+// to [opts], in snapshot mode, or in follow mode if [opts.Follow] is set
+func LogsCommand(opts LogsOptions) (string, error) {
+	if opts.Lines < 1 {
+		return "", fmt.Errorf("lines must be at least 1")
+	}
+	if opts.Since < 0 {
+		return "", fmt.Errorf("since must not be negative")
+	}
+	if opts.File == "" {
+		cmd := fmt.Sprintf("docker compose -f %s logs --no-color --tail %d", utils.GetRemoteComposeFile(), opts.Lines)
+		if opts.Since > 0 {
+			cmd += fmt.Sprintf(" --since %s", opts.Since)
+		}
+		if opts.Follow {
+			cmd += " --follow"
+		}
+		return cmd + " " + avalancheGoComposeService, nil
+	}
+	if opts.Since > 0 {
+		return "", fmt.Errorf("since can only be used with the docker compose logs, not with log file %s", opts.File)
+	}
+	if !logFileNameRegex.MatchString(opts.File) {
+		return "", fmt.Errorf("invalid log file %q, expected a file name in %s, as main.log", opts.File, constants.CloudNodeLogsPath)
+	}
+	cmd := fmt.Sprintf("tail -n %d", opts.Lines)
+	if opts.Follow {
+		cmd += " -F"
+	}
+	return cmd + " " + filepath.Join(opts.LogsDir, opts.File), nil
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsCommand(t *testing.T) {
+	require := require.New(t)
+	composeFile := utils.GetRemoteComposeFile()
+	for _, tc := range []struct {
+		name     string
+		opts     LogsOptions
+		expected string
+	}{
+		{
+			name:     "compose snapshot",
+			opts:     LogsOptions{Lines: 100},
+			expected: "docker compose -f " + composeFile + " logs --no-color --tail 100 avalanchego",
+		},
+		{
+			name:     "compose follow since",
+			opts:     LogsOptions{Lines: 10, Since: 15 * time.Minute, Follow: true},
+			expected: "docker compose -f " + composeFile + " logs --no-color --tail 10 --since 15m0s --follow avalanchego",
+		},
+		{
+			name:     "file snapshot",
+			opts:     LogsOptions{File: "main.log", Lines: 50, LogsDir: "/home/ubuntu/.avalanchego/logs"},
+			expected: "tail -n 50 /home/ubuntu/.avalanchego/logs/main.log",
+		},
+		{
+			name:     "file follow",
+			opts:     LogsOptions{File: "C.log", Lines: 50, Follow: true, LogsDir: "/home/admin/.avalanchego/logs"},
+			expected: "tail -n 50 -F /home/admin/.avalanchego/logs/C.log",
+		},
+	} {
+		cmd, err := LogsCommand(tc.opts)
+		require.NoError(err, tc.name)
+		require.Equal(tc.expected, cmd, tc.name)
+	}
+
+	_, err := LogsCommand(LogsOptions{Lines: 0})
+	require.ErrorContains(err, "lines must be at least 1")
+	_, err = LogsCommand(LogsOptions{Lines: 10, Since: -time.Minute})
+	require.ErrorContains(err, "since must not be negative")
+	_, err = LogsCommand(LogsOptions{File: "main.log", Lines: 10, Since: time.Minute})
+	require.ErrorContains(err, "since can only be used with the docker compose logs")
+	for _, file := range []string{"../../.ssh/id_rsa", "main.log; rm -rf /", "main"} {
+		_, err = LogsCommand(LogsOptions{File: file, Lines: 10})
+		require.ErrorContains(err, "invalid log file", file)
+	}
+}
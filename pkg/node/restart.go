@@ -0,0 +1,58 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+)
+
+var ErrRestartSkipped = errors.New("not restarted because a previous node failed to restart")
+
+// RollingRestart restarts [hosts] in batches of at most [maxUnavailable] nodes. Each node of
+// a batch is restarted with [restartFunc] and waited for with [waitHealthyFunc], and the next
+// batch starts only after the whole batch is done. If a node fails and [continueOnFailure] is
+// false, the remaining nodes are not restarted and get ErrRestartSkipped.
+// [onNodeDone], if given, is called with the outcome of each node, concurrently within a batch
+func RollingRestart(
+	hosts []*models.Host,
+	maxUnavailable int,
+	restartFunc func(*models.Host) error,
+	waitHealthyFunc func(*models.Host) error,
+	continueOnFailure bool,
+	onNodeDone func(host *models.Host, err error),
+) *models.NodeResults {
+	nodeResults := models.NodeResults{}
+	addResult := func(host *models.Host, err error) {
+		nodeResults.AddResult(host.NodeID, err == nil, err)
+		if onNodeDone != nil {
+			onNodeDone(host, err)
+		}
+	}
+	maxUnavailable = max(1, maxUnavailable)
+	for start := 0; start < len(hosts); start += maxUnavailable {
+		if nodeResults.HasErrors() && !continueOnFailure {
+			for _, host := range hosts[start:] {
+				addResult(host, ErrRestartSkipped)
+			}
+			break
+		}
+		batch := hosts[start:min(start+maxUnavailable, len(hosts))]
+		wg := sync.WaitGroup{}
+		for _, host := range batch {
+			wg.Add(1)
+			go func(host *models.Host) {
+				defer wg.Done()
+				if err := restartFunc(host); err != nil {
+					addResult(host, err)
+					return
+				}
+				addResult(host, waitHealthyFunc(host))
+			}(host)
+		}
+		wg.Wait()
+	}
+	return &nodeResults
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+// restartRecorder fakes node restarts, tracking how many nodes are unavailable at once
+type restartRecorder struct {
+	lock           sync.Mutex
+	restarted      []string
+	unavailable    int
+	maxUnavailable int
+	unhealthy      map[string]bool
+}
+
+func (r *restartRecorder) restart(host *models.Host) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.restarted = append(r.restarted, host.NodeID)
+	r.unavailable++
+	r.maxUnavailable = max(r.maxUnavailable, r.unavailable)
+	return nil
+}
+
+func (r *restartRecorder) waitHealthy(host *models.Host) error {
+	time.Sleep(10 * time.Millisecond)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.unavailable--
+	if r.unhealthy[host.NodeID] {
+		return errors.New("not healthy")
+	}
+	return nil
+}
+
+func newTestHosts(n int) []*models.Host {
+	hosts := []*models.Host{}
+	for i := 0; i < n; i++ {
+		hosts = append(hosts, &models.Host{NodeID: fmt.Sprintf("node%d", i)})
+	}
+	return hosts
+}
+
+func TestRollingRestart(t *testing.T) {
+	for _, maxUnavailable := range []int{1, 2, 5, 10} {
+		t.Run(fmt.Sprintf("max unavailable %d", maxUnavailable), func(t *testing.T) {
+			require := require.New(t)
+			hosts := newTestHosts(5)
+			recorder := &restartRecorder{}
+			doneLock := sync.Mutex{}
+			done := []string{}
+			results := RollingRestart(hosts, maxUnavailable, recorder.restart, recorder.waitHealthy, false, func(host *models.Host, err error) {
+				doneLock.Lock()
+				defer doneLock.Unlock()
+				require.NoError(err)
+				done = append(done, host.NodeID)
+			})
+			require.False(results.HasErrors())
+			require.Len(results.GetResults(), len(hosts))
+			require.Len(recorder.restarted, len(hosts))
+			require.Equal(min(maxUnavailable, len(hosts)), recorder.maxUnavailable)
+			// a batch is completed before the next one is restarted
+			hostIDs := []string{}
+			for _, host := range hosts {
+				hostIDs = append(hostIDs, host.NodeID)
+			}
+			for end := maxUnavailable; end < len(hosts); end += maxUnavailable {
+				require.ElementsMatch(hostIDs[:end], done[:end])
+			}
+		})
+	}
+}
+
+func TestRollingRestartSequencing(t *testing.T) {
+	require := require.New(t)
+	hosts := newTestHosts(3)
+	events := []string{}
+	lock := sync.Mutex{}
+	record := func(event string) {
+		lock.Lock()
+		defer lock.Unlock()
+		events = append(events, event)
+	}
+	restart := func(host *models.Host) error {
+		record("restart " + host.NodeID)
+		return nil
+	}
+	waitHealthy := func(host *models.Host) error {
+		record("healthy " + host.NodeID)
+		return nil
+	}
+	results := RollingRestart(hosts, 1, restart, waitHealthy, false, nil)
+	require.False(results.HasErrors())
+	require.Equal([]string{
+		"restart node0", "healthy node0",
+		"restart node1", "healthy node1",
+		"restart node2", "healthy node2",
+	}, events)
+}
+
+func TestRollingRestartFailure(t *testing.T) {
+	restartErr := errors.New("restart failed")
+	tests := []struct {
+		name              string
+		continueOnFailure bool
+		expectedRestarted []string
+		expectedErrs      map[string]error
+	}{
+		{
+			name:              "abort",
+			expectedRestarted: []string{"node0", "node1"},
+			expectedErrs: map[string]error{
+				"node1": restartErr,
+				"node2": ErrRestartSkipped,
+				"node3": ErrRestartSkipped,
+			},
+		},
+		{
+			name:              "continue",
+			continueOnFailure: true,
+			expectedRestarted: []string{"node0", "node1", "node2", "node3"},
+			expectedErrs: map[string]error{
+				"node1": restartErr,
+				"node3": errors.New("not healthy"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			hosts := newTestHosts(4)
+			recorder := &restartRecorder{unhealthy: map[string]bool{"node3": true}}
+			restart := func(host *models.Host) error {
+				if err := recorder.restart(host); err != nil {
+					return err
+				}
+				if host.NodeID == "node1" {
+					recorder.lock.Lock()
+					recorder.unavailable--
+					recorder.lock.Unlock()
+					return restartErr
+				}
+				return nil
+			}
+			results := RollingRestart(hosts, 1, restart, recorder.waitHealthy, tt.continueOnFailure, nil)
+			require.Equal(tt.expectedRestarted, recorder.restarted)
+			require.Len(results.GetResults(), len(hosts))
+			require.Equal(tt.expectedErrs, results.GetErrorHostMap())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	stakingKeysRotationDir  = "staking-rotation"
+	stakingKeysBackupDirFmt = "staking-backup-%s"
+)
+
+var stakingKeyFiles = []string{constants.StakerCertFileName, constants.StakerKeyFileName, constants.BLSKeyFileName}
+
+// RotateStakingKeys replaces the staking cert, key and BLS key that are kept in [keyDir] for a node:
+//   - new keys are generated with [generateFunc] into a separate dir
+//   - they are uploaded to the node from that dir with [uploadFunc]. If the upload fails, the current
+//     keys are uploaded again, so that the node is left with a consistent set of keys
+//   - the current keys are moved into a backup dir inside [keyDir], and the new ones take their place
+//   - the node is restarted with [restartFunc]
+//
+// Returns the new NodeID, and the backup dir of the previous keys
+func RotateStakingKeys(
+	keyDir string,
+	generateFunc func(certPath string, keyPath string, blsKeyPath string) (ids.NodeID, error),
+	uploadFunc func(keyDir string) error,
+	restartFunc func() error,
+) (ids.NodeID, string, error) {
+	rotationDir := filepath.Join(keyDir, stakingKeysRotationDir)
+	if err := os.RemoveAll(rotationDir); err != nil {
+		return ids.EmptyNodeID, "", err
+	}
+	defer os.RemoveAll(rotationDir)
+	nodeID, err := generateFunc(
+		filepath.Join(rotationDir, constants.StakerCertFileName),
+		filepath.Join(rotationDir, constants.StakerKeyFileName),
+		filepath.Join(rotationDir, constants.BLSKeyFileName),
+	)
+	if err != nil {
+		return ids.EmptyNodeID, "", fmt.Errorf("failed to generate new staking keys: %w", err)
+	}
+	if err := uploadFunc(rotationDir); err != nil {
+		err = fmt.Errorf("failed to upload new staking keys: %w", err)
+		if restoreErr := uploadFunc(keyDir); restoreErr != nil {
+			return ids.EmptyNodeID, "", errors.Join(err, fmt.Errorf("failed to restore previous staking keys on node: %w", restoreErr))
+		}
+		return ids.EmptyNodeID, "", err
+	}
+	backupDir := filepath.Join(keyDir, fmt.Sprintf(stakingKeysBackupDirFmt, time.Now().UTC().Format("20060102T150405Z")))
+	if err := os.MkdirAll(backupDir, constants.DefaultPerms755); err != nil {
+		return ids.EmptyNodeID, "", err
+	}
+	for _, keyFile := range stakingKeyFiles {
+		if err := os.Rename(filepath.Join(keyDir, keyFile), filepath.Join(backupDir, keyFile)); err != nil && !os.IsNotExist(err) {
+			return ids.EmptyNodeID, "", err
+		}
+		if err := os.Rename(filepath.Join(rotationDir, keyFile), filepath.Join(keyDir, keyFile)); err != nil {
+			return ids.EmptyNodeID, "", err
+		}
+	}
+	if err := restartFunc(); err != nil {
+		return nodeID, backupDir, fmt.Errorf("new staking keys were installed, but the node failed to restart: %w", err)
+	}
+	return nodeID, backupDir, nil
+}
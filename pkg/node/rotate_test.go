@@ -0,0 +1,145 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+type stakingKeysRotationRecorder struct {
+	t            *testing.T
+	newNodeID    ids.NodeID
+	uploadErrs   []error
+	restartErr   error
+	steps        []string
+	uploadedKeys []map[string]string
+}
+
+func (r *stakingKeysRotationRecorder) generate(certPath string, keyPath string, blsKeyPath string) (ids.NodeID, error) {
+	r.steps = append(r.steps, "generate")
+	for path, content := range map[string]string{certPath: "new-cert", keyPath: "new-key", blsKeyPath: "new-bls"} {
+		require.NoError(r.t, os.MkdirAll(filepath.Dir(path), constants.DefaultPerms755))
+		require.NoError(r.t, os.WriteFile(path, []byte(content), constants.WriteReadUserOnlyPerms))
+	}
+	return r.newNodeID, nil
+}
+
+func (r *stakingKeysRotationRecorder) upload(keyDir string) error {
+	r.steps = append(r.steps, "upload")
+	r.uploadedKeys = append(r.uploadedKeys, readStakingKeys(r.t, keyDir))
+	if len(r.uploadErrs) > 0 {
+		err := r.uploadErrs[0]
+		r.uploadErrs = r.uploadErrs[1:]
+		return err
+	}
+	return nil
+}
+
+func (r *stakingKeysRotationRecorder) restart() error {
+	r.steps = append(r.steps, "restart")
+	return r.restartErr
+}
+
+func readStakingKeys(t *testing.T, keyDir string) map[string]string {
+	keys := map[string]string{}
+	for _, keyFile := range stakingKeyFiles {
+		content, err := os.ReadFile(filepath.Join(keyDir, keyFile))
+		require.NoError(t, err)
+		keys[keyFile] = string(content)
+	}
+	return keys
+}
+
+func newTestKeyDir(t *testing.T) string {
+	keyDir := t.TempDir()
+	for keyFile, content := range map[string]string{
+		constants.StakerCertFileName: "old-cert",
+		constants.StakerKeyFileName:  "old-key",
+		constants.BLSKeyFileName:     "old-bls",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(keyDir, keyFile), []byte(content), constants.WriteReadUserOnlyPerms))
+	}
+	return keyDir
+}
+
+var (
+	oldStakingKeys = map[string]string{
+		constants.StakerCertFileName: "old-cert",
+		constants.StakerKeyFileName:  "old-key",
+		constants.BLSKeyFileName:     "old-bls",
+	}
+	newStakingKeys = map[string]string{
+		constants.StakerCertFileName: "new-cert",
+		constants.StakerKeyFileName:  "new-key",
+		constants.BLSKeyFileName:     "new-bls",
+	}
+)
+
+func TestRotateStakingKeys(t *testing.T) {
+	require := require.New(t)
+	keyDir := newTestKeyDir(t)
+	r := &stakingKeysRotationRecorder{t: t, newNodeID: ids.GenerateTestNodeID()}
+
+	nodeID, backupDir, err := RotateStakingKeys(keyDir, r.generate, r.upload, r.restart)
+	require.NoError(err)
+	require.Equal(r.newNodeID, nodeID)
+	require.Equal([]string{"generate", "upload", "restart"}, r.steps)
+	require.Equal([]map[string]string{newStakingKeys}, r.uploadedKeys)
+	require.Equal(newStakingKeys, readStakingKeys(t, keyDir))
+	require.Equal(keyDir, filepath.Dir(backupDir))
+	require.Equal(oldStakingKeys, readStakingKeys(t, backupDir))
+	require.NoDirExists(filepath.Join(keyDir, stakingKeysRotationDir))
+}
+
+func TestRotateStakingKeysUploadFailure(t *testing.T) {
+	require := require.New(t)
+	keyDir := newTestKeyDir(t)
+	r := &stakingKeysRotationRecorder{t: t, newNodeID: ids.GenerateTestNodeID(), uploadErrs: []error{errors.New("connection lost")}}
+
+	_, _, err := RotateStakingKeys(keyDir, r.generate, r.upload, r.restart)
+	require.ErrorContains(err, "connection lost")
+	// previous keys are uploaded again, and the node is not restarted
+	require.Equal([]string{"generate", "upload", "upload"}, r.steps)
+	require.Equal([]map[string]string{newStakingKeys, oldStakingKeys}, r.uploadedKeys)
+	require.Equal(oldStakingKeys, readStakingKeys(t, keyDir))
+	require.NoDirExists(filepath.Join(keyDir, stakingKeysRotationDir))
+
+	r = &stakingKeysRotationRecorder{t: t, uploadErrs: []error{errors.New("connection lost"), errors.New("host unreachable")}}
+	_, _, err = RotateStakingKeys(keyDir, r.generate, r.upload, r.restart)
+	require.ErrorContains(err, "connection lost")
+	require.ErrorContains(err, "failed to restore previous staking keys on node: host unreachable")
+}
+
+func TestRotateStakingKeysRestartFailure(t *testing.T) {
+	require := require.New(t)
+	keyDir := newTestKeyDir(t)
+	r := &stakingKeysRotationRecorder{t: t, newNodeID: ids.GenerateTestNodeID(), restartErr: errors.New("docker not running")}
+
+	nodeID, backupDir, err := RotateStakingKeys(keyDir, r.generate, r.upload, r.restart)
+	require.ErrorContains(err, "docker not running")
+	// keys were rotated on the node, so the local ones must match
+	require.Equal(r.newNodeID, nodeID)
+	require.Equal(newStakingKeys, readStakingKeys(t, keyDir))
+	require.Equal(oldStakingKeys, readStakingKeys(t, backupDir))
+}
+
+func TestRotateStakingKeysGenerateFailure(t *testing.T) {
+	require := require.New(t)
+	keyDir := newTestKeyDir(t)
+	r := &stakingKeysRotationRecorder{t: t}
+	generate := func(string, string, string) (ids.NodeID, error) {
+		return ids.EmptyNodeID, errors.New("no entropy")
+	}
+
+	_, _, err := RotateStakingKeys(keyDir, generate, r.upload, r.restart)
+	require.ErrorContains(err, "no entropy")
+	require.Empty(r.steps)
+	require.Equal(oldStakingKeys, readStakingKeys(t, keyDir))
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+)
+
+const (
+	dbSnapshotPrefix     = "db-"
+	dbSnapshotExtension  = ".tar.gz"
+	dbSnapshotTimeFormat = "20060102T150405Z"
+)
+
+var ErrSnapshotFailed = errors.New("db snapshot failed, node not upgraded")
+
+// DBSnapshotName returns the name of the archive of a DB snapshot taken at [t]
+func DBSnapshotName(t time.Time) string {
+	return dbSnapshotPrefix + t.UTC().Format(dbSnapshotTimeFormat) + dbSnapshotExtension
+}
+
+// LatestDBSnapshot returns the most recent DB snapshot archive of [fileNames], or an empty
+// string if there is none
+func LatestDBSnapshot(fileNames []string) string {
+	snapshots := []string{}
+	for _, fileName := range fileNames {
+		if !strings.HasPrefix(fileName, dbSnapshotPrefix) || !strings.HasSuffix(fileName, dbSnapshotExtension) {
+			continue
+		}
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(fileName, dbSnapshotPrefix), dbSnapshotExtension)
+		if _, err := time.Parse(dbSnapshotTimeFormat, timestamp); err == nil {
+			snapshots = append(snapshots, fileName)
+		}
+	}
+	if len(snapshots) == 0 {
+		return ""
+	}
+	// the timestamp format sorts chronologically
+	sort.Strings(snapshots)
+	return snapshots[len(snapshots)-1]
+}
+
+// SnapshotThenUpgrade stops [host] with [stopFunc], snapshots its DB with [snapshotFunc] and
+// only then upgrades it with [upgradeFunc], which is expected to start it again. If the
+// snapshot fails, the node is started again with [startFunc] and not upgraded
+func SnapshotThenUpgrade(
+	host *models.Host,
+	stopFunc func(*models.Host) error,
+	snapshotFunc func(*models.Host) error,
+	startFunc func(*models.Host) error,
+	upgradeFunc func(*models.Host) error,
+) error {
+	if err := stopFunc(host); err != nil {
+		return err
+	}
+	if err := snapshotFunc(host); err != nil {
+		if startErr := startFunc(host); startErr != nil {
+			return fmt.Errorf("%w: %w, and node failed to start again: %w", ErrSnapshotFailed, err, startErr)
+		}
+		return fmt.Errorf("%w: %w", ErrSnapshotFailed, err)
+	}
+	return upgradeFunc(host)
+}
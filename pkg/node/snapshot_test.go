@@ -0,0 +1,103 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBSnapshotName(t *testing.T) {
+	require := require.New(t)
+	at := time.Date(2024, 3, 5, 14, 7, 9, 0, time.FixedZone("UTC+2", 2*60*60))
+	require.Equal("db-20240305T120709Z.tar.gz", DBSnapshotName(at))
+}
+
+func TestLatestDBSnapshot(t *testing.T) {
+	require := require.New(t)
+	require.Equal("", LatestDBSnapshot(nil))
+	require.Equal("", LatestDBSnapshot([]string{"db-latest.tar.gz", "other.tar.gz", "db-20240305T120709Z.tar"}))
+	require.Equal("db-20240305T120709Z.tar.gz", LatestDBSnapshot([]string{
+		"db-20231231T235959Z.tar.gz",
+		"db-20240305T120709Z.tar.gz",
+		"db-20240305T120709Z.tar.gz.partial",
+		"db-20240101T000000Z.tar.gz",
+	}))
+}
+
+// fakeNode records the operations run on a fake host, failing the ones in [failing]
+type fakeNode struct {
+	mu      sync.Mutex
+	calls   []string
+	failing map[string]bool
+}
+
+func (f *fakeNode) op(name string) func(*models.Host) error {
+	return func(host *models.Host) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.calls = append(f.calls, name+":"+host.NodeID)
+		if f.failing[name] {
+			return errors.New(name + " failed")
+		}
+		return nil
+	}
+}
+
+func TestSnapshotThenUpgrade(t *testing.T) {
+	host := &models.Host{NodeID: "node0"}
+	tests := []struct {
+		name          string
+		failing       map[string]bool
+		expectedCalls []string
+		expectedErr   string
+	}{
+		{
+			name:          "snapshot before upgrade",
+			expectedCalls: []string{"stop:node0", "snapshot:node0", "upgrade:node0"},
+		},
+		{
+			name:          "stop failure",
+			failing:       map[string]bool{"stop": true},
+			expectedCalls: []string{"stop:node0"},
+			expectedErr:   "stop failed",
+		},
+		{
+			name:          "snapshot failure starts the node without upgrading it",
+			failing:       map[string]bool{"snapshot": true},
+			expectedCalls: []string{"stop:node0", "snapshot:node0", "start:node0"},
+			expectedErr:   "db snapshot failed, node not upgraded: snapshot failed",
+		},
+		{
+			name:          "snapshot and start failure",
+			failing:       map[string]bool{"snapshot": true, "start": true},
+			expectedCalls: []string{"stop:node0", "snapshot:node0", "start:node0"},
+			expectedErr:   "node failed to start again: start failed",
+		},
+		{
+			name:          "upgrade failure",
+			failing:       map[string]bool{"upgrade": true},
+			expectedCalls: []string{"stop:node0", "snapshot:node0", "upgrade:node0"},
+			expectedErr:   "upgrade failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			node := &fakeNode{failing: tt.failing}
+			err := SnapshotThenUpgrade(host, node.op("stop"), node.op("snapshot"), node.op("start"), node.op("upgrade"))
+			require.Equal(tt.expectedCalls, node.calls)
+			if tt.expectedErr == "" {
+				require.NoError(err)
+				return
+			}
+			require.ErrorContains(err, tt.expectedErr)
+			require.Equal(tt.failing["snapshot"], errors.Is(err, ErrSnapshotFailed))
+		})
+	}
+}
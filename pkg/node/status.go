@@ -0,0 +1,77 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+)
+
+// HostStatus is the status of a node as reported by node status
+type HostStatus struct {
+	Bootstrapped       bool
+	Healthy            bool
+	AvalancheGoVersion string
+	// SubnetSyncStatus is only set for bootstrapped nodes, when a subnet is checked
+	SubnetSyncStatus string
+	// Err is the error of the first check that failed for the node, if any
+	Err error
+}
+
+// HostStatusChecks are the queries that make up the status of a node
+type HostStatusChecks struct {
+	Bootstrapped       func(*models.Host) (bool, error)
+	Healthy            func(*models.Host) (bool, error)
+	AvalancheGoVersion func(*models.Host) (string, error)
+	// SubnetSyncStatus, if not nil, is only called for bootstrapped nodes
+	SubnetSyncStatus func(*models.Host) (string, error)
+}
+
+// GetHostsStatus runs [checks] on each of [hosts], with at most [parallelism] nodes queried at the
+// same time. A node that fails a check, for example because it is unreachable, gets the error in its
+// status, and doesn't prevent getting the status of the other nodes.
+// Returns [hosts] sorted by cloud ID, and the status of each of them by cloud ID
+func GetHostsStatus(hosts []*models.Host, checks HostStatusChecks, parallelism int) ([]*models.Host, map[string]HostStatus) {
+	sortedHosts := append([]*models.Host{}, hosts...)
+	sort.SliceStable(sortedHosts, func(i, j int) bool {
+		return sortedHosts[i].GetCloudID() < sortedHosts[j].GetCloudID()
+	})
+	statuses := map[string]HostStatus{}
+	statusesLock := sync.Mutex{}
+	semaphore := utils.NewSemaphore(max(1, parallelism))
+	wg := sync.WaitGroup{}
+	for _, host := range sortedHosts {
+		wg.Add(1)
+		go func(host *models.Host) {
+			defer wg.Done()
+			semaphore.Acquire()
+			defer semaphore.Release()
+			hostStatus := getHostStatus(host, checks)
+			statusesLock.Lock()
+			defer statusesLock.Unlock()
+			statuses[host.GetCloudID()] = hostStatus
+		}(host)
+	}
+	wg.Wait()
+	return sortedHosts, statuses
+}
+
+func getHostStatus(host *models.Host, checks HostStatusChecks) HostStatus {
+	hostStatus := HostStatus{}
+	if hostStatus.Bootstrapped, hostStatus.Err = checks.Bootstrapped(host); hostStatus.Err != nil {
+		return hostStatus
+	}
+	if hostStatus.Healthy, hostStatus.Err = checks.Healthy(host); hostStatus.Err != nil {
+		return hostStatus
+	}
+	if hostStatus.AvalancheGoVersion, hostStatus.Err = checks.AvalancheGoVersion(host); hostStatus.Err != nil {
+		return hostStatus
+	}
+	if checks.SubnetSyncStatus != nil && hostStatus.Bootstrapped {
+		hostStatus.SubnetSyncStatus, hostStatus.Err = checks.SubnetSyncStatus(host)
+	}
+	return hostStatus
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHostsStatus(t *testing.T) {
+	require := require.New(t)
+	hosts := []*models.Host{
+		{NodeID: "aws_node_i-c"},
+		{NodeID: "aws_node_i-a"},
+		{NodeID: "aws_node_i-d"},
+		{NodeID: "aws_node_i-b"},
+		{NodeID: "aws_node_i-e"},
+	}
+	queriedLock := sync.Mutex{}
+	queried := map[string]int{}
+	running, maxRunning := 0, 0
+	syncChecked := []string{}
+	checks := HostStatusChecks{
+		Bootstrapped: func(host *models.Host) (bool, error) {
+			queriedLock.Lock()
+			queried[host.GetCloudID()]++
+			running++
+			maxRunning = max(maxRunning, running)
+			queriedLock.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			queriedLock.Lock()
+			running--
+			queriedLock.Unlock()
+			switch host.GetCloudID() {
+			case "i-b":
+				return false, errors.New("connection refused")
+			case "i-d":
+				return false, nil
+			}
+			return true, nil
+		},
+		Healthy: func(host *models.Host) (bool, error) {
+			return host.GetCloudID() != "i-e", nil
+		},
+		AvalancheGoVersion: func(*models.Host) (string, error) {
+			return "avalanchego/1.11.8", nil
+		},
+		SubnetSyncStatus: func(host *models.Host) (string, error) {
+			queriedLock.Lock()
+			defer queriedLock.Unlock()
+			syncChecked = append(syncChecked, host.GetCloudID())
+			return "Syncing", nil
+		},
+	}
+
+	for _, parallelism := range []int{0, 2, 10} {
+		queried = map[string]int{}
+		maxRunning = 0
+		syncChecked = []string{}
+		sortedHosts, statuses := GetHostsStatus(hosts, checks, parallelism)
+		require.Equal([]string{"i-a", "i-b", "i-c", "i-d", "i-e"}, cloudIDs(sortedHosts))
+		require.Equal(map[string]int{"i-a": 1, "i-b": 1, "i-c": 1, "i-d": 1, "i-e": 1}, queried)
+		require.LessOrEqual(maxRunning, max(1, parallelism))
+		// sync status is not checked for unreachable or not bootstrapped nodes
+		require.ElementsMatch([]string{"i-a", "i-c", "i-e"}, syncChecked)
+		// input is not reordered
+		require.Equal("i-c", hosts[0].GetCloudID())
+
+		require.Equal(HostStatus{Bootstrapped: true, Healthy: true, AvalancheGoVersion: "avalanchego/1.11.8", SubnetSyncStatus: "Syncing"}, statuses["i-a"])
+		require.ErrorContains(statuses["i-b"].Err, "connection refused")
+		require.Equal(HostStatus{Bootstrapped: false, Healthy: true, AvalancheGoVersion: "avalanchego/1.11.8"}, statuses["i-d"])
+		require.False(statuses["i-e"].Healthy)
+		require.NoError(statuses["i-e"].Err)
+	}
+
+	checks.SubnetSyncStatus = nil
+	_, statuses := GetHostsStatus(hosts, checks, 2)
+	require.Empty(statuses["i-a"].SubnetSyncStatus)
+}
+
+func cloudIDs(hosts []*models.Host) []string {
+	ids := []string{}
+	for _, host := range hosts {
+		ids = append(ids, host.GetCloudID())
+	}
+	return ids
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+)
+
+// SplitHostsBySubnetTracking checks in parallel, with [isTracking], which of [hosts] already track a subnet.
+// Returns, keeping the order of [hosts], the hosts that don't track it yet and the ones that do,
+// together with the errors of the hosts that could not be checked
+func SplitHostsBySubnetTracking(
+	hosts []*models.Host,
+	isTracking func(*models.Host) (bool, error),
+) ([]*models.Host, []*models.Host, map[string]error) {
+	tracking := make([]bool, len(hosts))
+	errs := make([]error, len(hosts))
+	wg := sync.WaitGroup{}
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host *models.Host) {
+			defer wg.Done()
+			tracking[i], errs[i] = isTracking(host)
+		}(i, host)
+	}
+	wg.Wait()
+	untrackingHosts := []*models.Host{}
+	trackingHosts := []*models.Host{}
+	failedHosts := map[string]error{}
+	for i, host := range hosts {
+		switch {
+		case errs[i] != nil:
+			failedHosts[host.NodeID] = errs[i]
+		case tracking[i]:
+			trackingHosts = append(trackingHosts, host)
+		default:
+			untrackingHosts = append(untrackingHosts, host)
+		}
+	}
+	return untrackingHosts, trackingHosts, failedHosts
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitHostsBySubnetTracking(t *testing.T) {
+	require := require.New(t)
+	hosts := []*models.Host{
+		{NodeID: "aws_node_1"},
+		{NodeID: "aws_node_2"},
+		{NodeID: "aws_node_3"},
+		{NodeID: "aws_node_4"},
+	}
+	errUnreachable := errors.New("unreachable")
+	untracking, tracking, failed := SplitHostsBySubnetTracking(hosts, func(host *models.Host) (bool, error) {
+		switch host.NodeID {
+		case "aws_node_2", "aws_node_4":
+			return true, nil
+		case "aws_node_3":
+			return false, errUnreachable
+		}
+		return false, nil
+	})
+	require.Equal([]*models.Host{hosts[0]}, untracking)
+	require.Equal([]*models.Host{hosts[1], hosts[3]}, tracking)
+	require.Equal(map[string]error{"aws_node_3": errUnreachable}, failed)
+
+	untracking, tracking, failed = SplitHostsBySubnetTracking(hosts, func(*models.Host) (bool, error) {
+		return true, nil
+	})
+	require.Empty(untracking)
+	require.Equal(hosts, tracking)
+	require.Empty(failed)
+}
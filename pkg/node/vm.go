@@ -0,0 +1,35 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrIncompatibleRPCVersion = errors.New("incompatible RPC protocol version")
+
+// CheckVMRPCCompatibility checks that the AvalancheGo RPC protocol version of each node of
+// [hostsRPCVersion], by node, matches [vmRPCVersion], the one of version [vmVersion] of the VM
+// to be installed. Otherwise, the nodes would not be able to run the VM
+func CheckVMRPCCompatibility(hostsRPCVersion map[string]uint32, vmVersion string, vmRPCVersion int) error {
+	incompatible := []string{}
+	for hostID, rpcVersion := range hostsRPCVersion {
+		if int(rpcVersion) != vmRPCVersion {
+			incompatible = append(incompatible, fmt.Sprintf("%s (RPC %d)", hostID, rpcVersion))
+		}
+	}
+	if len(incompatible) == 0 {
+		return nil
+	}
+	sort.Strings(incompatible)
+	return fmt.Errorf(
+		"%w: VM version %s requires AvalancheGo RPC protocol version %d, but node(s) %s run a different one. Please upgrade AvalancheGo first",
+		ErrIncompatibleRPCVersion,
+		vmVersion,
+		vmRPCVersion,
+		strings.Join(incompatible, ", "),
+	)
+}
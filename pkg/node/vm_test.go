@@ -0,0 +1,21 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVMRPCCompatibility(t *testing.T) {
+	require := require.New(t)
+	require.NoError(CheckVMRPCCompatibility(map[string]uint32{"node0": 35, "node1": 35}, "v0.6.6", 35))
+	require.NoError(CheckVMRPCCompatibility(map[string]uint32{}, "v0.6.6", 35))
+
+	err := CheckVMRPCCompatibility(map[string]uint32{"node0": 35, "node2": 34, "node1": 33}, "v0.6.6", 35)
+	require.ErrorIs(err, ErrIncompatibleRPCVersion)
+	require.ErrorContains(err, "VM version v0.6.6 requires AvalancheGo RPC protocol version 35")
+	require.ErrorContains(err, "node(s) node1 (RPC 33), node2 (RPC 34) run a different one")
+	require.NotContains(err.Error(), "node0")
+}
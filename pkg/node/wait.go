@@ -0,0 +1,57 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+)
+
+// WaitForHosts concurrently waits for each host in [hosts] using [waitFunc], keeping
+// the error of every host that failed. While hosts are pending, [onProgress] is
+// called every [progressInterval] with the number of pending hosts and the total
+func WaitForHosts(
+	hosts []*models.Host,
+	waitFunc func(*models.Host) error,
+	progressInterval time.Duration,
+	onProgress func(pending int, total int),
+) *models.NodeResults {
+	hostErrors := models.NodeResults{}
+	wg := sync.WaitGroup{}
+	pending := len(hosts)
+	pendingLock := sync.Mutex{}
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(nodeResults *models.NodeResults, host *models.Host) {
+			defer wg.Done()
+			if err := waitFunc(host); err != nil {
+				nodeResults.AddResult(host.NodeID, nil, err)
+			}
+			pendingLock.Lock()
+			pending--
+			pendingLock.Unlock()
+		}(&hostErrors, host)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return &hostErrors
+		case <-ticker.C:
+			pendingLock.Lock()
+			stillPending := pending
+			pendingLock.Unlock()
+			if stillPending > 0 && onProgress != nil {
+				onProgress(stillPending, len(hosts))
+			}
+		}
+	}
+}
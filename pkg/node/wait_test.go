@@ -0,0 +1,83 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForHosts(t *testing.T) {
+	require := require.New(t)
+
+	hostErrs := map[string]error{
+		"node-ok":  nil,
+		"node-dns": fmt.Errorf("timeout: %w", &net.DNSError{Err: "no such host", Name: "node.invalid", IsNotFound: true}),
+		"node-refused": fmt.Errorf("timeout: %w", &net.OpError{
+			Op:  "dial",
+			Net: "tcp",
+			Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+		}),
+		"node-auth": fmt.Errorf("timeout: %w", errors.New("ssh: handshake failed: ssh: unable to authenticate")),
+	}
+	hosts := []*models.Host{}
+	for nodeID := range hostErrs {
+		hosts = append(hosts, &models.Host{NodeID: nodeID})
+	}
+	// the failing hosts take longer, so progress is reported while they are pending
+	waitFunc := func(host *models.Host) error {
+		if err := hostErrs[host.NodeID]; err != nil {
+			time.Sleep(50 * time.Millisecond)
+			return err
+		}
+		return nil
+	}
+	progressLock := sync.Mutex{}
+	progress := [][2]int{}
+	onProgress := func(pending int, total int) {
+		progressLock.Lock()
+		defer progressLock.Unlock()
+		progress = append(progress, [2]int{pending, total})
+	}
+
+	results := WaitForHosts(hosts, waitFunc, 10*time.Millisecond, onProgress)
+	require.Equal(map[string]models.ConnectionErrorKind{
+		"node-dns":     models.ConnectionErrorDNS,
+		"node-refused": models.ConnectionErrorRefused,
+		"node-auth":    models.ConnectionErrorAuth,
+	}, classifyResults(results))
+
+	progressLock.Lock()
+	defer progressLock.Unlock()
+	require.NotEmpty(progress)
+	for _, p := range progress {
+		require.Equal(len(hosts), p[1])
+		require.Positive(p[0])
+		require.LessOrEqual(p[0], len(hosts))
+	}
+}
+
+func TestWaitForHostsNoErrors(t *testing.T) {
+	require := require.New(t)
+
+	hosts := []*models.Host{{NodeID: "node-1"}, {NodeID: "node-2"}}
+	results := WaitForHosts(hosts, func(*models.Host) error { return nil }, time.Hour, nil)
+	require.Zero(results.Len())
+}
+
+func classifyResults(results *models.NodeResults) map[string]models.ConnectionErrorKind {
+	kinds := map[string]models.ConnectionErrorKind{}
+	for nodeID, err := range results.GetErrorHostMap() {
+		kinds[nodeID] = models.ClassifyConnectionError(err)
+	}
+	return kinds
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrValueRequired = errors.New("a value is required and can't be auto answered")
+
+// AutoConfirmPrompter decorates a Prompter for non interactive usage:
+//   - yes/no confirmations (CaptureYesNo, CaptureNoYes) are answered yes
+//   - list choices (CaptureList, CaptureListWithSize, CaptureIndex) are answered
+//     with the only option available, if there is just one
+//
+// Every other prompt needs a value only the user can give, such as an address,
+// a version or a key choice, so it fails with ErrValueRequired instead of
+// waiting for input. The decorated Prompter is never asked.
+type AutoConfirmPrompter struct {
+	Prompter
+}
+
+var _ Prompter = (*AutoConfirmPrompter)(nil)
+
+func NewAutoConfirmPrompter(prompter Prompter) *AutoConfirmPrompter {
+	return &AutoConfirmPrompter{Prompter: prompter}
+}
+
+func valueRequired(promptStr string) error {
+	return fmt.Errorf("%w: %q. Please provide it with the command flags", ErrValueRequired, promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureYesNo(string) (bool, error) {
+	return true, nil
+}
+
+func (*AutoConfirmPrompter) CaptureNoYes(string) (bool, error) {
+	return true, nil
+}
+
+func (*AutoConfirmPrompter) CaptureList(promptStr string, options []string) (string, error) {
+	if len(options) != 1 {
+		return "", valueRequired(promptStr)
+	}
+	return options[0], nil
+}
+
+func (p *AutoConfirmPrompter) CaptureListWithSize(promptStr string, options []string, _ int) (string, error) {
+	return p.CaptureList(promptStr, options)
+}
+
+func (*AutoConfirmPrompter) CaptureIndex(promptStr string, options []any) (int, error) {
+	if len(options) != 1 {
+		return 0, valueRequired(promptStr)
+	}
+	return 0, nil
+}
+
+func (*AutoConfirmPrompter) CaptureListMultiple(promptStr string, _ []string) ([]string, error) {
+	return nil, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CapturePositiveBigInt(promptStr string) (*big.Int, error) {
+	return nil, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureAddress(promptStr string) (common.Address, error) {
+	return common.Address{}, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureAddresses(promptStr string) ([]common.Address, error) {
+	return nil, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureNewFilepath(promptStr string) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureExistingFilepath(promptStr string) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureString(promptStr string) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureValidatedString(promptStr string, _ func(string) error) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureHexBytes(promptStr string, _ int) ([]byte, error) {
+	return nil, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureURL(promptStr string, _ bool) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureRepoBranch(promptStr string, _ string) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureRepoFile(promptStr string, _ string, _ string) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureGitURL(promptStr string) (*url.URL, error) {
+	return nil, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureStringAllowEmpty(promptStr string) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureEmail(promptStr string) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureVersion(promptStr string) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureFujiDuration(promptStr string) (time.Duration, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureMainnetDuration(promptStr string) (time.Duration, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureDate(promptStr string) (time.Time, error) {
+	return time.Time{}, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureNodeID(promptStr string) (ids.NodeID, error) {
+	return ids.EmptyNodeID, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureID(promptStr string) (ids.ID, error) {
+	return ids.Empty, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureWeight(promptStr string) (uint64, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CapturePositiveInt(promptStr string, _ []Comparator) (int, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureInt(promptStr string) (int, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureSignedInt(promptStr string, _ []SignedComparator) (int, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureUint32(promptStr string) (uint32, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureUint64(promptStr string) (uint64, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureFloat(promptStr string, _ func(float64) error) (float64, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureUint64Compare(promptStr string, _ []Comparator) (uint64, error) {
+	return 0, valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CapturePChainAddress(promptStr string, _ models.Network) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureXChainAddress(promptStr string, _ models.Network) (string, error) {
+	return "", valueRequired(promptStr)
+}
+
+func (*AutoConfirmPrompter) CaptureFutureDate(promptStr string, _ time.Time) (time.Time, error) {
+	return time.Time{}, valueRequired(promptStr)
+}
+
+// ChooseKeyOrLedger picks how transactions are signed, which is not a confirmation
+func (*AutoConfirmPrompter) ChooseKeyOrLedger(goal string) (bool, error) {
+	return false, valueRequired("Which key source should be used to " + goal + "?")
+}
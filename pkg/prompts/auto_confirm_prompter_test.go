@@ -0,0 +1,87 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package prompts
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoConfirmPrompterConfirmations(t *testing.T) {
+	require := require.New(t)
+	inner := NewMockPrompterWithYesNo(false, false).Queue("CaptureNoYes", false)
+	prompter := NewAutoConfirmPrompter(inner)
+
+	yes, err := prompter.CaptureYesNo("Continue?")
+	require.NoError(err)
+	require.True(yes)
+	yes, err = prompter.CaptureNoYes("Overwrite?")
+	require.NoError(err)
+	require.True(yes)
+	require.Empty(inner.Calls())
+}
+
+func TestAutoConfirmPrompterLists(t *testing.T) {
+	require := require.New(t)
+	inner := NewMockPrompter()
+	prompter := NewAutoConfirmPrompter(inner)
+
+	choice, err := prompter.CaptureList("Choose", []string{"only"})
+	require.NoError(err)
+	require.Equal("only", choice)
+	choice, err = prompter.CaptureListWithSize("Choose", []string{"only"}, 5)
+	require.NoError(err)
+	require.Equal("only", choice)
+	index, err := prompter.CaptureIndex("Choose", []any{"only"})
+	require.NoError(err)
+	require.Equal(0, index)
+
+	_, err = prompter.CaptureList("Choose a network", []string{"a", "b"})
+	require.ErrorIs(err, ErrValueRequired)
+	require.ErrorContains(err, "Choose a network")
+	_, err = prompter.CaptureListWithSize("Choose", []string{"a", "b"}, 5)
+	require.ErrorIs(err, ErrValueRequired)
+	_, err = prompter.CaptureIndex("Choose", []any{})
+	require.ErrorIs(err, ErrValueRequired)
+	_, err = prompter.CaptureListMultiple("Choose", []string{"only"})
+	require.ErrorIs(err, ErrValueRequired)
+	require.Empty(inner.Calls())
+}
+
+func TestAutoConfirmPrompterValuesRequired(t *testing.T) {
+	require := require.New(t)
+	inner := NewMockPrompter()
+	prompter := NewAutoConfirmPrompter(inner)
+
+	_, err := prompter.CaptureAddress("Enter address")
+	require.ErrorIs(err, ErrValueRequired)
+	require.ErrorContains(err, "Enter address")
+	_, err = prompter.CaptureVersion("Enter version")
+	require.ErrorIs(err, ErrValueRequired)
+	_, err = prompter.ChooseKeyOrLedger("pay fees")
+	require.ErrorIs(err, ErrValueRequired)
+
+	// no prompt reaches the decorated prompter, which would wait for user input
+	prompterType := reflect.TypeOf((*Prompter)(nil)).Elem()
+	prompterValue := reflect.ValueOf(prompter)
+	for i := 0; i < prompterType.NumMethod(); i++ {
+		method := prompterType.Method(i)
+		args := []reflect.Value{}
+		for j := 0; j < method.Type.NumIn(); j++ {
+			args = append(args, reflect.Zero(method.Type.In(j)))
+		}
+		results := prompterValue.MethodByName(method.Name).Call(args)
+		err, _ := results[len(results)-1].Interface().(error)
+		switch method.Name {
+		case "CaptureYesNo", "CaptureNoYes":
+			require.NoError(err, method.Name)
+			require.True(results[0].Bool(), method.Name)
+		default:
+			require.True(errors.Is(err, ErrValueRequired), method.Name)
+		}
+	}
+	require.Empty(inner.Calls())
+}
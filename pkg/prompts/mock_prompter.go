@@ -0,0 +1,389 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrNoMockAnswer = errors.New("no queued answer")
+
+// MockPrompterCall records a call made to a MockPrompter
+type MockPrompterCall struct {
+	Method    string
+	PromptStr string
+}
+
+type mockAnswer struct {
+	value any
+	err   error
+}
+
+// MockPrompter is a Prompter that returns answers queued per method, in order,
+// so that flows using prompts can be tested non interactively. It fails with
+// ErrNoMockAnswer when a method has no answers left, and records all calls.
+// Validators and comparators given to the capture methods are applied to the
+// queued answers, as done for user input.
+type MockPrompter struct {
+	lock    sync.Mutex
+	answers map[string][]mockAnswer
+	calls   []MockPrompterCall
+}
+
+var _ Prompter = (*MockPrompter)(nil)
+
+func NewMockPrompter() *MockPrompter {
+	return &MockPrompter{
+		answers: map[string][]mockAnswer{},
+	}
+}
+
+// NewMockPrompterWithYesNo returns a MockPrompter that answers CaptureYesNo with [answers]
+func NewMockPrompterWithYesNo(answers ...bool) *MockPrompter {
+	return NewMockPrompter().QueueYesNo(answers...)
+}
+
+// NewMockPrompterWithListChoices returns a MockPrompter that answers CaptureList with [choices]
+func NewMockPrompterWithListChoices(choices ...string) *MockPrompter {
+	return NewMockPrompter().QueueList(choices...)
+}
+
+// Queue adds [values] as the next answers of [method]
+func (m *MockPrompter) Queue(method string, values ...any) *MockPrompter {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, value := range values {
+		m.answers[method] = append(m.answers[method], mockAnswer{value: value})
+	}
+	return m
+}
+
+// QueueError makes the next call to [method] fail with [err]
+func (m *MockPrompter) QueueError(method string, err error) *MockPrompter {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.answers[method] = append(m.answers[method], mockAnswer{err: err})
+	return m
+}
+
+// QueueYesNo adds [answers] as the next answers of CaptureYesNo
+func (m *MockPrompter) QueueYesNo(answers ...bool) *MockPrompter {
+	for _, answer := range answers {
+		m.Queue("CaptureYesNo", answer)
+	}
+	return m
+}
+
+// QueueList adds [choices] as the next answers of CaptureList
+func (m *MockPrompter) QueueList(choices ...string) *MockPrompter {
+	for _, choice := range choices {
+		m.Queue("CaptureList", choice)
+	}
+	return m
+}
+
+// QueueString adds [answers] as the next answers of CaptureString
+func (m *MockPrompter) QueueString(answers ...string) *MockPrompter {
+	for _, answer := range answers {
+		m.Queue("CaptureString", answer)
+	}
+	return m
+}
+
+// Calls returns the calls made so far
+func (m *MockPrompter) Calls() []MockPrompterCall {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]MockPrompterCall{}, m.calls...)
+}
+
+// CheckExhausted fails if some queued answers were not used
+func (m *MockPrompter) CheckExhausted() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	pending := []string{}
+	for method, answers := range m.answers {
+		if len(answers) > 0 {
+			pending = append(pending, fmt.Sprintf("%s (%d)", method, len(answers)))
+		}
+	}
+	if len(pending) > 0 {
+		sort.Strings(pending)
+		return fmt.Errorf("unused queued answers for %s", pending)
+	}
+	return nil
+}
+
+func nextMockAnswer[T any](m *MockPrompter, method string, promptStr string) (T, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var zero T
+	m.calls = append(m.calls, MockPrompterCall{Method: method, PromptStr: promptStr})
+	answers := m.answers[method]
+	if len(answers) == 0 {
+		return zero, fmt.Errorf("%w for %s(%q)", ErrNoMockAnswer, method, promptStr)
+	}
+	answer := answers[0]
+	m.answers[method] = answers[1:]
+	if answer.err != nil {
+		return zero, answer.err
+	}
+	value, ok := answer.value.(T)
+	if !ok {
+		return zero, fmt.Errorf("queued answer for %s has type %T, expected %T", method, answer.value, zero)
+	}
+	return value, nil
+}
+
+func validateComparators(val uint64, comparators []Comparator) error {
+	for _, comparator := range comparators {
+		if err := comparator.Validate(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSignedComparators(val int64, comparators []SignedComparator) error {
+	for _, comparator := range comparators {
+		if err := comparator.Validate(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockPrompter) CapturePositiveBigInt(promptStr string) (*big.Int, error) {
+	return nextMockAnswer[*big.Int](m, "CapturePositiveBigInt", promptStr)
+}
+
+func (m *MockPrompter) CaptureAddress(promptStr string) (common.Address, error) {
+	return nextMockAnswer[common.Address](m, "CaptureAddress", promptStr)
+}
+
+func (m *MockPrompter) CaptureAddresses(promptStr string) ([]common.Address, error) {
+	return nextMockAnswer[[]common.Address](m, "CaptureAddresses", promptStr)
+}
+
+func (m *MockPrompter) CaptureNewFilepath(promptStr string) (string, error) {
+	return nextMockAnswer[string](m, "CaptureNewFilepath", promptStr)
+}
+
+func (m *MockPrompter) CaptureExistingFilepath(promptStr string) (string, error) {
+	return nextMockAnswer[string](m, "CaptureExistingFilepath", promptStr)
+}
+
+func (m *MockPrompter) CaptureYesNo(promptStr string) (bool, error) {
+	return nextMockAnswer[bool](m, "CaptureYesNo", promptStr)
+}
+
+func (m *MockPrompter) CaptureNoYes(promptStr string) (bool, error) {
+	return nextMockAnswer[bool](m, "CaptureNoYes", promptStr)
+}
+
+func (m *MockPrompter) CaptureList(promptStr string, options []string) (string, error) {
+	choice, err := nextMockAnswer[string](m, "CaptureList", promptStr)
+	if err != nil {
+		return "", err
+	}
+	return choice, checkMockChoice(choice, options)
+}
+
+func (m *MockPrompter) CaptureListWithSize(promptStr string, options []string, _ int) (string, error) {
+	choice, err := nextMockAnswer[string](m, "CaptureListWithSize", promptStr)
+	if err != nil {
+		return "", err
+	}
+	return choice, checkMockChoice(choice, options)
+}
+
+func (m *MockPrompter) CaptureListMultiple(promptStr string, options []string) ([]string, error) {
+	choices, err := nextMockAnswer[[]string](m, "CaptureListMultiple", promptStr)
+	if err != nil {
+		return nil, err
+	}
+	for _, choice := range choices {
+		if err := checkMockChoice(choice, options); err != nil {
+			return nil, err
+		}
+	}
+	return choices, nil
+}
+
+func (m *MockPrompter) CaptureString(promptStr string) (string, error) {
+	return nextMockAnswer[string](m, "CaptureString", promptStr)
+}
+
+func (m *MockPrompter) CaptureValidatedString(promptStr string, validator func(string) error) (string, error) {
+	s, err := nextMockAnswer[string](m, "CaptureValidatedString", promptStr)
+	if err != nil {
+		return "", err
+	}
+	return s, validator(s)
+}
+
+// CaptureHexBytes takes queued hexa strings, parsed as done for user input
+func (m *MockPrompter) CaptureHexBytes(promptStr string, expectedLen int) ([]byte, error) {
+	s, err := nextMockAnswer[string](m, "CaptureHexBytes", promptStr)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHexBytes(s, expectedLen)
+}
+
+func (m *MockPrompter) CaptureURL(promptStr string, _ bool) (string, error) {
+	return nextMockAnswer[string](m, "CaptureURL", promptStr)
+}
+
+func (m *MockPrompter) CaptureRepoBranch(promptStr string, _ string) (string, error) {
+	return nextMockAnswer[string](m, "CaptureRepoBranch", promptStr)
+}
+
+func (m *MockPrompter) CaptureRepoFile(promptStr string, _ string, _ string) (string, error) {
+	return nextMockAnswer[string](m, "CaptureRepoFile", promptStr)
+}
+
+func (m *MockPrompter) CaptureGitURL(promptStr string) (*url.URL, error) {
+	return nextMockAnswer[*url.URL](m, "CaptureGitURL", promptStr)
+}
+
+func (m *MockPrompter) CaptureStringAllowEmpty(promptStr string) (string, error) {
+	return nextMockAnswer[string](m, "CaptureStringAllowEmpty", promptStr)
+}
+
+func (m *MockPrompter) CaptureEmail(promptStr string) (string, error) {
+	email, err := nextMockAnswer[string](m, "CaptureEmail", promptStr)
+	if err != nil {
+		return "", err
+	}
+	return email, validateEmail(email)
+}
+
+func (m *MockPrompter) CaptureIndex(promptStr string, options []any) (int, error) {
+	index, err := nextMockAnswer[int](m, "CaptureIndex", promptStr)
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 || index >= len(options) {
+		return 0, fmt.Errorf("queued index %d out of range for %d options", index, len(options))
+	}
+	return index, nil
+}
+
+func (m *MockPrompter) CaptureVersion(promptStr string) (string, error) {
+	return nextMockAnswer[string](m, "CaptureVersion", promptStr)
+}
+
+func (m *MockPrompter) CaptureFujiDuration(promptStr string) (time.Duration, error) {
+	return nextMockAnswer[time.Duration](m, "CaptureFujiDuration", promptStr)
+}
+
+func (m *MockPrompter) CaptureMainnetDuration(promptStr string) (time.Duration, error) {
+	return nextMockAnswer[time.Duration](m, "CaptureMainnetDuration", promptStr)
+}
+
+func (m *MockPrompter) CaptureDate(promptStr string) (time.Time, error) {
+	return nextMockAnswer[time.Time](m, "CaptureDate", promptStr)
+}
+
+func (m *MockPrompter) CaptureNodeID(promptStr string) (ids.NodeID, error) {
+	return nextMockAnswer[ids.NodeID](m, "CaptureNodeID", promptStr)
+}
+
+func (m *MockPrompter) CaptureID(promptStr string) (ids.ID, error) {
+	return nextMockAnswer[ids.ID](m, "CaptureID", promptStr)
+}
+
+func (m *MockPrompter) CaptureWeight(promptStr string) (uint64, error) {
+	return nextMockAnswer[uint64](m, "CaptureWeight", promptStr)
+}
+
+func (m *MockPrompter) CapturePositiveInt(promptStr string, comparators []Comparator) (int, error) {
+	val, err := nextMockAnswer[int](m, "CapturePositiveInt", promptStr)
+	if err != nil {
+		return 0, err
+	}
+	if val < 0 {
+		return 0, errors.New("input is less than 0")
+	}
+	return val, validateComparators(uint64(val), comparators)
+}
+
+func (m *MockPrompter) CaptureInt(promptStr string) (int, error) {
+	return nextMockAnswer[int](m, "CaptureInt", promptStr)
+}
+
+func (m *MockPrompter) CaptureSignedInt(promptStr string, comparators []SignedComparator) (int, error) {
+	val, err := nextMockAnswer[int](m, "CaptureSignedInt", promptStr)
+	if err != nil {
+		return 0, err
+	}
+	return val, validateSignedComparators(int64(val), comparators)
+}
+
+func (m *MockPrompter) CaptureUint32(promptStr string) (uint32, error) {
+	return nextMockAnswer[uint32](m, "CaptureUint32", promptStr)
+}
+
+func (m *MockPrompter) CaptureUint64(promptStr string) (uint64, error) {
+	return nextMockAnswer[uint64](m, "CaptureUint64", promptStr)
+}
+
+func (m *MockPrompter) CaptureFloat(promptStr string, validator func(float64) error) (float64, error) {
+	val, err := nextMockAnswer[float64](m, "CaptureFloat", promptStr)
+	if err != nil {
+		return 0, err
+	}
+	return val, validator(val)
+}
+
+func (m *MockPrompter) CaptureUint64Compare(promptStr string, comparators []Comparator) (uint64, error) {
+	val, err := nextMockAnswer[uint64](m, "CaptureUint64Compare", promptStr)
+	if err != nil {
+		return 0, err
+	}
+	return val, validateComparators(val, comparators)
+}
+
+func (m *MockPrompter) CapturePChainAddress(promptStr string, _ models.Network) (string, error) {
+	return nextMockAnswer[string](m, "CapturePChainAddress", promptStr)
+}
+
+func (m *MockPrompter) CaptureXChainAddress(promptStr string, _ models.Network) (string, error) {
+	return nextMockAnswer[string](m, "CaptureXChainAddress", promptStr)
+}
+
+func (m *MockPrompter) CaptureFutureDate(promptStr string, minDate time.Time) (time.Time, error) {
+	date, err := nextMockAnswer[time.Time](m, "CaptureFutureDate", promptStr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if date.Before(minDate) {
+		return time.Time{}, fmt.Errorf("the provided date is before %s", minDate.UTC().Format(constants.TimeParseLayout))
+	}
+	return date, nil
+}
+
+func (m *MockPrompter) ChooseKeyOrLedger(goal string) (bool, error) {
+	return nextMockAnswer[bool](m, "ChooseKeyOrLedger", goal)
+}
+
+func checkMockChoice(choice string, options []string) error {
+	for _, option := range options {
+		if option == choice {
+			return nil
+		}
+	}
+	return fmt.Errorf("queued choice %q is not one of the options %v", choice, options)
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package prompts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockPrompterQueuedAnswers(t *testing.T) {
+	require := require.New(t)
+	prompter := NewMockPrompterWithYesNo(true, false).
+		QueueList("b").
+		QueueString("name").
+		Queue("CaptureUint64Compare", uint64(5))
+
+	yes, err := prompter.CaptureYesNo("first")
+	require.NoError(err)
+	require.True(yes)
+	yes, err = prompter.CaptureYesNo("second")
+	require.NoError(err)
+	require.False(yes)
+	choice, err := prompter.CaptureList("choose", []string{"a", "b"})
+	require.NoError(err)
+	require.Equal("b", choice)
+	s, err := prompter.CaptureString("name")
+	require.NoError(err)
+	require.Equal("name", s)
+	val, err := prompter.CaptureUint64Compare("value", []Comparator{{Label: "max", Type: LessThanEq, Value: 10}})
+	require.NoError(err)
+	require.Equal(uint64(5), val)
+
+	require.NoError(prompter.CheckExhausted())
+	require.Equal([]MockPrompterCall{
+		{Method: "CaptureYesNo", PromptStr: "first"},
+		{Method: "CaptureYesNo", PromptStr: "second"},
+		{Method: "CaptureList", PromptStr: "choose"},
+		{Method: "CaptureString", PromptStr: "name"},
+		{Method: "CaptureUint64Compare", PromptStr: "value"},
+	}, prompter.Calls())
+}
+
+func TestMockPrompterQueueExhaustion(t *testing.T) {
+	require := require.New(t)
+	prompter := NewMockPrompterWithYesNo(true)
+
+	_, err := prompter.CaptureYesNo("first")
+	require.NoError(err)
+	_, err = prompter.CaptureYesNo("second")
+	require.ErrorIs(err, ErrNoMockAnswer)
+	require.ErrorContains(err, "CaptureYesNo")
+	_, err = prompter.CaptureString("never queued")
+	require.ErrorIs(err, ErrNoMockAnswer)
+	require.Len(prompter.Calls(), 3)
+}
+
+func TestMockPrompterErrors(t *testing.T) {
+	require := require.New(t)
+	testErr := errors.New("prompt failed")
+	prompter := NewMockPrompter().
+		QueueError("CaptureString", testErr).
+		Queue("CaptureString", 1).
+		QueueList("c").
+		Queue("CaptureUint64Compare", uint64(20)).
+		Queue("CaptureValidatedString", "bad")
+
+	_, err := prompter.CaptureString("error")
+	require.ErrorIs(err, testErr)
+	_, err = prompter.CaptureString("wrong type")
+	require.ErrorContains(err, "has type int")
+	_, err = prompter.CaptureList("not an option", []string{"a", "b"})
+	require.ErrorContains(err, "not one of the options")
+	_, err = prompter.CaptureUint64Compare("too big", []Comparator{{Label: "max", Type: LessThanEq, Value: 10}})
+	require.Error(err)
+	_, err = prompter.CaptureValidatedString("invalid", func(string) error { return testErr })
+	require.ErrorIs(err, testErr)
+	require.NoError(prompter.CheckExhausted())
+
+	prompter.QueueYesNo(true, true)
+	require.ErrorContains(prompter.CheckExhausted(), "CaptureYesNo (2)")
+}
+
+func TestMockPrompterCaptureSignedInt(t *testing.T) {
+	require := require.New(t)
+
+	comparators := []SignedComparator{{Label: "min", Type: MoreThanEq, Value: -10}}
+	prompter := NewMockPrompter()
+	prompter.Queue("CaptureSignedInt", -10, 0, -11)
+	val, err := prompter.CaptureSignedInt("offset", comparators)
+	require.NoError(err)
+	require.Equal(-10, val)
+	val, err = prompter.CaptureSignedInt("offset", comparators)
+	require.NoError(err)
+	require.Zero(val)
+	_, err = prompter.CaptureSignedInt("offset", comparators)
+	require.ErrorContains(err, "bigger than or equal to min (-10)")
+}
+
+func TestMockPrompterCaptureHexBytes(t *testing.T) {
+	require := require.New(t)
+	prompter := NewMockPrompter().Queue("CaptureHexBytes", "0x0aff", "0x0a", "0x0g")
+
+	bs, err := prompter.CaptureHexBytes("public key", 2)
+	require.NoError(err)
+	require.Equal([]byte{0x0a, 0xff}, bs)
+	_, err = prompter.CaptureHexBytes("public key", 2)
+	require.ErrorContains(err, "expected 2 bytes, got 1")
+	_, err = prompter.CaptureHexBytes("calldata", 0)
+	require.ErrorContains(err, "not in hexa format")
+}
@@ -3,6 +3,7 @@
 package prompts
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"math/big"
@@ -56,23 +57,42 @@ type Comparator struct {
 	Value uint64 // Value to Compare To
 }
 
+// Validate checks [val] against the comparator Value
 func (comparator *Comparator) Validate(val uint64) error {
-	switch comparator.Type {
+	return validateOrder(comparator.Label, comparator.Type, cmp.Compare(val, comparator.Value), strconv.FormatUint(comparator.Value, 10))
+}
+
+// SignedComparator is a Comparator with a reference value that may be negative, used by CaptureSignedInt
+type SignedComparator struct {
+	Label string // Label that identifies reference value
+	Type  string // Less Than Eq or More than Eq
+	Value int64  // Value to Compare To
+}
+
+// Validate checks [val] against the comparator Value
+func (comparator *SignedComparator) Validate(val int64) error {
+	return validateOrder(comparator.Label, comparator.Type, cmp.Compare(val, comparator.Value), strconv.FormatInt(comparator.Value, 10))
+}
+
+// validateOrder checks that [order], the comparison result of a value against the
+// reference value [label], satisfies [comparatorType]
+func validateOrder(label string, comparatorType string, order int, refValue string) error {
+	switch comparatorType {
 	case LessThanEq:
-		if val > comparator.Value {
-			return fmt.Errorf(fmt.Sprintf("the value must be smaller than or equal to %s (%d)", comparator.Label, comparator.Value))
+		if order > 0 {
+			return fmt.Errorf("the value must be smaller than or equal to %s (%s)", label, refValue)
 		}
 	case MoreThan:
-		if val <= comparator.Value {
-			return fmt.Errorf(fmt.Sprintf("the value must be bigger than %s (%d)", comparator.Label, comparator.Value))
+		if order <= 0 {
+			return fmt.Errorf("the value must be bigger than %s (%s)", label, refValue)
 		}
 	case MoreThanEq:
-		if val < comparator.Value {
-			return fmt.Errorf(fmt.Sprintf("the value must be bigger than or equal to %s (%d)", comparator.Label, comparator.Value))
+		if order < 0 {
+			return fmt.Errorf("the value must be bigger than or equal to %s (%s)", label, refValue)
 		}
 	case NotEq:
-		if val == comparator.Value {
-			return fmt.Errorf(fmt.Sprintf("the value must be different than %s (%d)", comparator.Label, comparator.Value))
+		if order == 0 {
+			return fmt.Errorf("the value must be different than %s (%s)", label, refValue)
 		}
 	}
 	return nil
@@ -88,8 +108,10 @@ type Prompter interface {
 	CaptureNoYes(promptStr string) (bool, error)
 	CaptureList(promptStr string, options []string) (string, error)
 	CaptureListWithSize(promptStr string, options []string, size int) (string, error)
+	CaptureListMultiple(promptStr string, options []string) ([]string, error)
 	CaptureString(promptStr string) (string, error)
 	CaptureValidatedString(promptStr string, validator func(string) error) (string, error)
+	CaptureHexBytes(promptStr string, expectedLen int) ([]byte, error)
 	CaptureURL(promptStr string, validateConnection bool) (string, error)
 	CaptureRepoBranch(promptStr string, repo string) (string, error)
 	CaptureRepoFile(promptStr string, repo string, branch string) (string, error)
@@ -106,6 +128,7 @@ type Prompter interface {
 	CaptureWeight(promptStr string) (uint64, error)
 	CapturePositiveInt(promptStr string, comparators []Comparator) (int, error)
 	CaptureInt(promptStr string) (int, error)
+	CaptureSignedInt(promptStr string, comparators []SignedComparator) (int, error)
 	CaptureUint32(promptStr string) (uint32, error)
 	CaptureUint64(promptStr string) (uint64, error)
 	CaptureFloat(promptStr string, validator func(float64) error) (float64, error)
@@ -382,6 +405,31 @@ func (*realPrompter) CapturePositiveInt(promptStr string, comparators []Comparat
 	return strconv.Atoi(amountStr)
 }
 
+// CaptureSignedInt captures an integer that may be negative, validated against [comparators]
+func (*realPrompter) CaptureSignedInt(promptStr string, comparators []SignedComparator) (int, error) {
+	prompt := promptui.Prompt{
+		Label: promptStr,
+		Validate: func(input string) error {
+			val, err := strconv.Atoi(input)
+			if err != nil {
+				return err
+			}
+			for _, comparator := range comparators {
+				if err := comparator.Validate(int64(val)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	amountStr, err := prompt.Run()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(amountStr)
+}
+
 func (*realPrompter) CaptureUint64Compare(promptStr string, comparators []Comparator) (uint64, error) {
 	prompt := promptui.Prompt{
 		Label: promptStr,
@@ -557,6 +605,47 @@ func (*realPrompter) CaptureListWithSize(promptStr string, options []string, siz
 	return listDecision, nil
 }
 
+// CaptureListMultiple lets the user toggle any number of [options] on and off,
+// finishing with Done. The selected options are returned in the same order
+// as given in [options]. Selecting none is allowed.
+func (*realPrompter) CaptureListMultiple(promptStr string, options []string) ([]string, error) {
+	selected := make([]bool, len(options))
+	cursor := 0
+	for {
+		items := make([]string, 0, len(options)+1)
+		for i, option := range options {
+			mark := " "
+			if selected[i] {
+				mark = "x"
+			}
+			items = append(items, fmt.Sprintf("[%s] %s", mark, option))
+		}
+		items = append(items, Done)
+		prompt := promptui.Select{
+			Label:     promptStr,
+			Items:     items,
+			CursorPos: cursor,
+			Size:      min(len(items), 10),
+		}
+		index, _, err := prompt.Run()
+		if err != nil {
+			return nil, err
+		}
+		if index == len(options) {
+			break
+		}
+		selected[index] = !selected[index]
+		cursor = index
+	}
+	choices := []string{}
+	for i, option := range options {
+		if selected[i] {
+			choices = append(choices, option)
+		}
+	}
+	return choices, nil
+}
+
 func (*realPrompter) CaptureEmail(promptStr string) (string, error) {
 	prompt := promptui.Prompt{
 		Label:    promptStr,
@@ -668,6 +757,25 @@ func (*realPrompter) CaptureValidatedString(promptStr string, validator func(str
 	return str, nil
 }
 
+// CaptureHexBytes asks for an hexa string, with optional 0x prefix, and returns the decoded bytes.
+// If [expectedLen] is greater than 0, exactly that number of bytes is required
+func (*realPrompter) CaptureHexBytes(promptStr string, expectedLen int) ([]byte, error) {
+	prompt := promptui.Prompt{
+		Label: promptStr,
+		Validate: func(s string) error {
+			_, err := ParseHexBytes(s, expectedLen)
+			return err
+		},
+	}
+
+	str, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseHexBytes(str, expectedLen)
+}
+
 func (*realPrompter) CaptureGitURL(promptStr string) (*url.URL, error) {
 	prompt := promptui.Prompt{
 		Label:    promptStr,
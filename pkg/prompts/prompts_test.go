@@ -36,3 +36,68 @@ func TestContains(t *testing.T) {
 	require.True(contains(addrList, addr2))
 	require.False(contains(addrList, addr3))
 }
+
+func TestComparatorValidate(t *testing.T) {
+	require := require.New(t)
+
+	comparator := Comparator{Label: "max", Type: LessThanEq, Value: 10}
+	require.NoError(comparator.Validate(10))
+	require.EqualError(comparator.Validate(11), "the value must be smaller than or equal to max (10)")
+
+	comparator = Comparator{Label: "min", Type: MoreThan, Value: 10}
+	require.NoError(comparator.Validate(11))
+	require.EqualError(comparator.Validate(10), "the value must be bigger than min (10)")
+}
+
+func TestSignedComparatorValidate(t *testing.T) {
+	require := require.New(t)
+
+	comparators := []SignedComparator{
+		{Label: "min delta", Type: MoreThanEq, Value: -100},
+		{Label: "max delta", Type: LessThanEq, Value: 100},
+	}
+	for _, val := range []int64{-100, -1, 0, 1, 100} {
+		require.NoError(validateSignedComparators(val, comparators), val)
+	}
+	require.EqualError(validateSignedComparators(-101, comparators), "the value must be bigger than or equal to min delta (-100)")
+	require.EqualError(validateSignedComparators(101, comparators), "the value must be smaller than or equal to max delta (100)")
+
+	comparator := SignedComparator{Label: "zero", Type: NotEq}
+	require.EqualError(comparator.Validate(0), "the value must be different than zero (0)")
+	require.NoError(comparator.Validate(-1))
+
+	comparator = SignedComparator{Label: "negative bound", Type: MoreThan, Value: -5}
+	require.NoError(comparator.Validate(-4))
+	require.Error(comparator.Validate(-5))
+}
+
+func TestParseHexBytes(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedLen int
+		expected    []byte
+		errContains string
+	}{
+		{name: "0x prefix", input: "0x0aff", expected: []byte{0x0a, 0xff}},
+		{name: "0X prefix", input: "0X0AFF", expected: []byte{0x0a, 0xff}},
+		{name: "no prefix", input: "0aff", expected: []byte{0x0a, 0xff}},
+		{name: "expected length", input: "0x0aff01", expectedLen: 3, expected: []byte{0x0a, 0xff, 0x01}},
+		{name: "empty", input: "", errContains: "no hexa digits"},
+		{name: "only prefix", input: "0x", errContains: "no hexa digits"},
+		{name: "odd length", input: "0x0af", errContains: "odd number of digits"},
+		{name: "non hex", input: "0x0g", errContains: "not in hexa format"},
+		{name: "length mismatch", input: "0x0aff", expectedLen: 48, errContains: "expected 48 bytes, got 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs, err := ParseHexBytes(tt.input, tt.expectedLen)
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, bs)
+		})
+	}
+}
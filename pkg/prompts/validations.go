@@ -348,3 +348,26 @@ func ValidateHexa(input string) error {
 	}
 	return err
 }
+
+// ParseHexBytes decodes the hexa string [input], that can have a 0x prefix. If [expectedLen]
+// is greater than 0, the decoded bytes must have exactly that length
+func ParseHexBytes(input string, expectedLen int) ([]byte, error) {
+	input = strings.TrimSpace(input)
+	if len(input) >= 2 && strings.ToLower(input[:2]) == "0x" {
+		input = input[2:]
+	}
+	if input == "" {
+		return nil, errors.New("no hexa digits in string")
+	}
+	if len(input)%2 != 0 {
+		return nil, errors.New("hexa string has an odd number of digits")
+	}
+	bs, err := hex.DecodeString(input)
+	if err != nil {
+		return nil, errors.New("string not in hexa format")
+	}
+	if expectedLen > 0 && len(bs) != expectedLen {
+		return nil, fmt.Errorf("expected %d bytes, got %d", expectedLen, len(bs))
+	}
+	return bs, nil
+}
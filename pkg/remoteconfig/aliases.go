@@ -0,0 +1,55 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remoteconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+)
+
+var chainAliasRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// GetRemoteAvalancheChainAliases returns the path of the chain aliases file read by avalanchego
+func GetRemoteAvalancheChainAliases() string {
+	return filepath.Join(constants.CloudNodeConfigPath, "chains", "aliases.json")
+}
+
+// ValidateChainAlias checks that [alias] can be used in RPC URLs, as /ext/bc/<alias>/rpc
+func ValidateChainAlias(alias string) error {
+	if !chainAliasRegex.MatchString(alias) {
+		return fmt.Errorf("invalid chain alias %q: only letters, numbers, '.', '_' and '-' are allowed", alias)
+	}
+	return nil
+}
+
+// MergeChainAliases returns the chain aliases file [aliasesFile], that maps blockchain IDs to
+// their aliases, with the aliases of [blockchainID] set to [alias]. Aliases of other chains are
+// kept, and [alias] must not be one of them. An empty [aliasesFile] is taken as no aliases
+func MergeChainAliases(aliasesFile []byte, blockchainID string, alias string) ([]byte, error) {
+	if err := ValidateChainAlias(alias); err != nil {
+		return nil, err
+	}
+	aliases := map[string][]string{}
+	if len(aliasesFile) > 0 {
+		if err := json.Unmarshal(aliasesFile, &aliases); err != nil {
+			return nil, fmt.Errorf("invalid chain aliases file: %w", err)
+		}
+	}
+	for chainID, chainAliases := range aliases {
+		if chainID == blockchainID {
+			continue
+		}
+		for _, chainAlias := range chainAliases {
+			if chainAlias == alias {
+				return nil, fmt.Errorf("chain alias %q is already used by blockchain %s", alias, chainID)
+			}
+		}
+	}
+	aliases[blockchainID] = []string{alias}
+	return json.MarshalIndent(aliases, "", " ")
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remoteconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeChainAliases(t *testing.T) {
+	require := require.New(t)
+	decode := func(aliasesFile []byte) map[string][]string {
+		aliases := map[string][]string{}
+		require.NoError(json.Unmarshal(aliasesFile, &aliases))
+		return aliases
+	}
+
+	// a missing file is taken as no aliases
+	aliasesFile, err := MergeChainAliases(nil, "chain1", "mysubnet")
+	require.NoError(err)
+	require.Equal(map[string][]string{"chain1": {"mysubnet"}}, decode(aliasesFile))
+
+	// aliases of other chains are kept
+	aliasesFile, err = MergeChainAliases([]byte(`{"chain0": ["other", "another"]}`), "chain1", "mysubnet")
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"chain0": {"other", "another"},
+		"chain1": {"mysubnet"},
+	}, decode(aliasesFile))
+
+	// the alias of the chain is replaced
+	aliasesFile, err = MergeChainAliases(aliasesFile, "chain1", "renamed")
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"chain0": {"other", "another"},
+		"chain1": {"renamed"},
+	}, decode(aliasesFile))
+
+	// aliases must be unique
+	_, err = MergeChainAliases(aliasesFile, "chain1", "another")
+	require.ErrorContains(err, `chain alias "another" is already used by blockchain chain0`)
+
+	_, err = MergeChainAliases([]byte("not json"), "chain1", "mysubnet")
+	require.ErrorContains(err, "invalid chain aliases file")
+	_, err = MergeChainAliases(nil, "chain1", "my subnet")
+	require.ErrorContains(err, "invalid chain alias")
+}
+
+func TestValidateChainAlias(t *testing.T) {
+	require := require.New(t)
+	for _, alias := range []string{"mysubnet", "my-subnet_1.0"} {
+		require.NoError(ValidateChainAlias(alias))
+	}
+	for _, alias := range []string{"", "my subnet", "a/b", "subnet?"} {
+		require.Error(ValidateChainAlias(alias), alias)
+	}
+}
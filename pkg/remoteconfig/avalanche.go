@@ -5,11 +5,14 @@ package remoteconfig
 
 import (
 	"bytes"
+	"fmt"
 	"html/template"
 	"path/filepath"
 	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
 type AvalancheConfigInputs struct {
@@ -26,19 +29,94 @@ type AvalancheConfigInputs struct {
 	BootstrapIDs     string
 	BootstrapIPs     string
 	GenesisPath      string
+	HTTPPort         uint
+	StakingPort      uint
+	LogLevel         string
 }
 
-func PrepareAvalancheConfig(publicIP string, networkID string, subnets []string) AvalancheConfigInputs {
+// PrepareAvalancheConfig returns the node config inputs. [httpPort] and [stakingPort]
+// are only rendered if not 0, so that avalanchego defaults are used otherwise
+func PrepareAvalancheConfig(publicIP string, networkID string, subnets []string, httpPort uint, stakingPort uint) AvalancheConfigInputs {
+	cChainDBConfig := DefaultCChainDBConfig()
 	return AvalancheConfigInputs{
 		HTTPHost:         "0.0.0.0",
 		NetworkID:        networkID,
 		DBDir:            "/.avalanchego/db/",
 		LogDir:           "/.avalanchego/logs/",
 		PublicIP:         publicIP,
-		StateSyncEnabled: true,
-		PruningEnabled:   false,
+		StateSyncEnabled: cChainDBConfig.StateSyncEnabled,
+		PruningEnabled:   cChainDBConfig.PruningEnabled,
 		TrackSubnets:     strings.Join(subnets, ","),
+		HTTPPort:         httpPort,
+		StakingPort:      stakingPort,
+	}
+}
+
+// CChainDBConfig holds the C-Chain state pruning and state sync settings of a node
+type CChainDBConfig struct {
+	PruningEnabled   bool
+	StateSyncEnabled bool
+}
+
+// DefaultCChainDBConfig returns the C-Chain settings used by PrepareAvalancheConfig
+func DefaultCChainDBConfig() CChainDBConfig {
+	return CChainDBConfig{
+		PruningEnabled:   false,
+		StateSyncEnabled: true,
+	}
+}
+
+// NewCChainDBConfig returns the C-Chain settings for the requested [pruningEnabled] and
+// [stateSyncEnabled] values, using defaults for nil ones. An archival node, that is, one
+// with pruning explicitly disabled, needs the full chain state, so state sync is disabled
+// for it, and it is an error to explicitly request both
+func NewCChainDBConfig(pruningEnabled *bool, stateSyncEnabled *bool) (CChainDBConfig, error) {
+	conf := DefaultCChainDBConfig()
+	if stateSyncEnabled != nil {
+		conf.StateSyncEnabled = *stateSyncEnabled
+	}
+	if pruningEnabled != nil {
+		conf.PruningEnabled = *pruningEnabled
+		if !conf.PruningEnabled {
+			if stateSyncEnabled != nil && *stateSyncEnabled {
+				return CChainDBConfig{}, fmt.Errorf("state sync can't be enabled on archival nodes (pruning disabled)")
+			}
+			conf.StateSyncEnabled = false
+		}
+	}
+	return conf, nil
+}
+
+// GetCChainDBConfig obtains the C-Chain settings from a parsed C-Chain config,
+// using defaults for the missing ones
+func GetCChainDBConfig(cChainConfig map[string]interface{}) CChainDBConfig {
+	conf := DefaultCChainDBConfig()
+	if pruningEnabled, ok := cChainConfig["pruning-enabled"].(bool); ok {
+		conf.PruningEnabled = pruningEnabled
+	}
+	if stateSyncEnabled, ok := cChainConfig["state-sync-enabled"].(bool); ok {
+		conf.StateSyncEnabled = stateSyncEnabled
+	}
+	return conf
+}
+
+// IsTrackingSubnet tells if the parsed node config [nodeConfig] has [subnetID] in its track-subnets
+func IsTrackingSubnet(nodeConfig map[string]interface{}, subnetID string) bool {
+	trackSubnets, _ := nodeConfig["track-subnets"].(string)
+	for _, trackedSubnetID := range strings.Split(trackSubnets, ",") {
+		if strings.TrimSpace(trackedSubnetID) == subnetID {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateLogLevel checks that [logLevel] is accepted by avalanchego as log-level
+func ValidateLogLevel(logLevel string) error {
+	if _, err := logging.ToLevel(logLevel); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", logLevel, err)
 	}
+	return nil
 }
 
 func RenderAvalancheTemplate(templateName string, config AvalancheConfigInputs) ([]byte, error) {
@@ -89,13 +167,13 @@ func GetRemoteAvalancheGenesis() string {
 
 func AvalancheFolderToCreate() []string {
 	return []string{
-		"/home/ubuntu/.avalanchego/db",
-		"/home/ubuntu/.avalanchego/logs",
-		"/home/ubuntu/.avalanchego/configs",
-		"/home/ubuntu/.avalanchego/configs/subnets/",
-		"/home/ubuntu/.avalanchego/configs/chains/C",
-		"/home/ubuntu/.avalanchego/staking",
-		"/home/ubuntu/.avalanchego/plugins",
-		"/home/ubuntu/.avalanche-cli/services/awm-relayer",
+		constants.CloudNodeDBPath,
+		constants.CloudNodeLogsPath,
+		constants.CloudNodeConfigPath,
+		filepath.Join(constants.CloudNodeConfigPath, "subnets"),
+		filepath.Join(constants.CloudNodeConfigPath, "chains", "C"),
+		constants.CloudNodeStakingPath,
+		constants.CloudNodePluginsPath,
+		utils.GetRemoteComposeServicePath(constants.AWMRelayerInstallDir),
 	}
 }
@@ -0,0 +1,96 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remoteconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLogLevel(t *testing.T) {
+	require := require.New(t)
+	for _, logLevel := range []string{"off", "fatal", "error", "warn", "info", "trace", "debug", "verbo", "DEBUG"} {
+		require.NoError(ValidateLogLevel(logLevel), logLevel)
+	}
+	for _, logLevel := range []string{"", "verbose", "warning", "1"} {
+		require.Error(ValidateLogLevel(logLevel), logLevel)
+	}
+}
+
+func TestRenderAvalancheNodeConfigLogLevel(t *testing.T) {
+	require := require.New(t)
+	config := PrepareAvalancheConfig("1.2.3.4", "fuji", nil, 0, 0)
+
+	nodeConf, err := RenderAvalancheNodeConfig(config)
+	require.NoError(err)
+	var rendered map[string]interface{}
+	require.NoError(json.Unmarshal(nodeConf, &rendered))
+	require.NotContains(rendered, "log-level")
+
+	config.LogLevel = "debug"
+	nodeConf, err = RenderAvalancheNodeConfig(config)
+	require.NoError(err)
+	rendered = map[string]interface{}{}
+	require.NoError(json.Unmarshal(nodeConf, &rendered))
+	require.Equal("debug", rendered["log-level"])
+}
+
+func TestRenderAvalancheCChainConfigDBSettings(t *testing.T) {
+	require := require.New(t)
+	enabled, disabled := true, false
+	tests := []struct {
+		name              string
+		pruningEnabled    *bool
+		stateSyncEnabled  *bool
+		expectedPruning   bool
+		expectedStateSync bool
+		shouldFail        bool
+	}{
+		{name: "defaults", expectedPruning: false, expectedStateSync: true},
+		{name: "pruning enabled", pruningEnabled: &enabled, expectedPruning: true, expectedStateSync: true},
+		{name: "archival", pruningEnabled: &disabled, expectedPruning: false, expectedStateSync: false},
+		{name: "state sync disabled", stateSyncEnabled: &disabled, expectedPruning: false, expectedStateSync: false},
+		{name: "pruning and state sync enabled", pruningEnabled: &enabled, stateSyncEnabled: &enabled, expectedPruning: true, expectedStateSync: true},
+		{name: "pruning enabled and state sync disabled", pruningEnabled: &enabled, stateSyncEnabled: &disabled, expectedPruning: true, expectedStateSync: false},
+		{name: "archival and state sync disabled", pruningEnabled: &disabled, stateSyncEnabled: &disabled, expectedPruning: false, expectedStateSync: false},
+		{name: "archival and state sync enabled", pruningEnabled: &disabled, stateSyncEnabled: &enabled, shouldFail: true},
+	}
+	for _, tt := range tests {
+		cChainDBConfig, err := NewCChainDBConfig(tt.pruningEnabled, tt.stateSyncEnabled)
+		if tt.shouldFail {
+			require.Error(err, tt.name)
+			continue
+		}
+		require.NoError(err, tt.name)
+		config := PrepareAvalancheConfig("1.2.3.4", "fuji", nil, 0, 0)
+		config.PruningEnabled = cChainDBConfig.PruningEnabled
+		config.StateSyncEnabled = cChainDBConfig.StateSyncEnabled
+		cChainConf, err := RenderAvalancheCChainConfig(config)
+		require.NoError(err, tt.name)
+		var rendered map[string]interface{}
+		require.NoError(json.Unmarshal(cChainConf, &rendered), tt.name)
+		require.Equal(tt.expectedPruning, rendered["pruning-enabled"], tt.name)
+		require.Equal(tt.expectedStateSync, rendered["state-sync-enabled"], tt.name)
+		// settings read back from the rendered config are kept on upgrades
+		require.Equal(cChainDBConfig, GetCChainDBConfig(rendered), tt.name)
+	}
+}
+
+func TestGetCChainDBConfigDefaults(t *testing.T) {
+	require := require.New(t)
+	require.Equal(DefaultCChainDBConfig(), GetCChainDBConfig(nil))
+	require.Equal(CChainDBConfig{PruningEnabled: true, StateSyncEnabled: true}, GetCChainDBConfig(map[string]interface{}{"pruning-enabled": true}))
+}
+
+func TestIsTrackingSubnet(t *testing.T) {
+	require := require.New(t)
+	subnetID := "2b175hLJhGdj3CzgXENso9CmwMgejaCQXhMFzBsm8hXbH2MF7H"
+	require.False(IsTrackingSubnet(map[string]interface{}{}, subnetID))
+	require.False(IsTrackingSubnet(map[string]interface{}{"track-subnets": ""}, subnetID))
+	require.False(IsTrackingSubnet(map[string]interface{}{"track-subnets": "29uVeLPJB1eQJkzRemU8g8wZDw5uJRqpab5U2mX9euieVwiEbL"}, subnetID))
+	require.True(IsTrackingSubnet(map[string]interface{}{"track-subnets": subnetID}, subnetID))
+	require.True(IsTrackingSubnet(map[string]interface{}{"track-subnets": "29uVeLPJB1eQJkzRemU8g8wZDw5uJRqpab5U2mX9euieVwiEbL," + subnetID}, subnetID))
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remoteconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// BootstrapPeers are the nodes, given by NodeID and ip:port, that a node
+// connects to in order to bootstrap
+type BootstrapPeers struct {
+	IDs []string
+	IPs []string
+}
+
+// NewBootstrapPeers validates [bootstrapIDs] and their matching [bootstrapIPs]
+func NewBootstrapPeers(bootstrapIDs []string, bootstrapIPs []string) (BootstrapPeers, error) {
+	if len(bootstrapIDs) != len(bootstrapIPs) {
+		return BootstrapPeers{}, fmt.Errorf("got %d bootstrap IDs and %d bootstrap IPs, they must be given in pairs", len(bootstrapIDs), len(bootstrapIPs))
+	}
+	for _, bootstrapID := range bootstrapIDs {
+		if _, err := ids.NodeIDFromString(bootstrapID); err != nil {
+			return BootstrapPeers{}, fmt.Errorf("invalid bootstrap ID %q: %w", bootstrapID, err)
+		}
+	}
+	for _, bootstrapIP := range bootstrapIPs {
+		addrPort, err := netip.ParseAddrPort(bootstrapIP)
+		if err != nil {
+			return BootstrapPeers{}, fmt.Errorf("invalid bootstrap IP %q, expected ip:port: %w", bootstrapIP, err)
+		}
+		if addrPort.Port() == 0 {
+			return BootstrapPeers{}, fmt.Errorf("invalid bootstrap IP %q: port must not be 0", bootstrapIP)
+		}
+	}
+	return BootstrapPeers{IDs: bootstrapIDs, IPs: bootstrapIPs}, nil
+}
+
+func (p BootstrapPeers) IsEmpty() bool {
+	return len(p.IDs) == 0
+}
+
+// SetNodeConfigBootstrapPeers returns the node config [nodeConfig] with its
+// bootstrap-ids and bootstrap-ips replaced by [peers]
+func SetNodeConfigBootstrapPeers(nodeConfig []byte, peers BootstrapPeers) ([]byte, error) {
+	confMap := map[string]interface{}{}
+	if err := json.Unmarshal(nodeConfig, &confMap); err != nil {
+		return nil, fmt.Errorf("invalid node config: %w", err)
+	}
+	confMap["bootstrap-ids"] = strings.Join(peers.IDs, ",")
+	confMap["bootstrap-ips"] = strings.Join(peers.IPs, ",")
+	return json.MarshalIndent(confMap, "", " ")
+}
+
+// ParseGenesisNetworkID returns the network ID of the avalanchego [genesis]
+func ParseGenesisNetworkID(genesis []byte) (uint32, error) {
+	var genesisMap struct {
+		NetworkID uint32 `json:"networkID"`
+	}
+	if err := json.Unmarshal(genesis, &genesisMap); err != nil {
+		return 0, fmt.Errorf("invalid genesis: %w", err)
+	}
+	if genesisMap.NetworkID == 0 {
+		return 0, fmt.Errorf("invalid genesis: missing networkID")
+	}
+	return genesisMap.NetworkID, nil
+}
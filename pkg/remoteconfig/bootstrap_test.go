@@ -0,0 +1,84 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remoteconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBootstrapID1 = "NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg"
+	testBootstrapID2 = "NodeID-MFrZFVCXPv5iCn6M9K6XduxGTYp891xXZ"
+)
+
+func TestNewBootstrapPeers(t *testing.T) {
+	tests := []struct {
+		name        string
+		ids         []string
+		ips         []string
+		errContains string
+	}{
+		{name: "empty"},
+		{name: "ipv4 and ipv6", ids: []string{testBootstrapID1, testBootstrapID2}, ips: []string{"10.0.0.1:9651", "[2001:db8::1]:9651"}},
+		{name: "missing ip", ids: []string{testBootstrapID1, testBootstrapID2}, ips: []string{"10.0.0.1:9651"}, errContains: "must be given in pairs"},
+		{name: "invalid node id", ids: []string{"7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg"}, ips: []string{"10.0.0.1:9651"}, errContains: "invalid bootstrap ID"},
+		{name: "missing port", ids: []string{testBootstrapID1}, ips: []string{"10.0.0.1"}, errContains: "expected ip:port"},
+		{name: "host name", ids: []string{testBootstrapID1}, ips: []string{"node.example.com:9651"}, errContains: "expected ip:port"},
+		{name: "zero port", ids: []string{testBootstrapID1}, ips: []string{"10.0.0.1:0"}, errContains: "port must not be 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peers, err := NewBootstrapPeers(tt.ids, tt.ips)
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, len(tt.ids) == 0, peers.IsEmpty())
+		})
+	}
+}
+
+func TestSetNodeConfigBootstrapPeers(t *testing.T) {
+	require := require.New(t)
+	nodeConfig := []byte(`{
+ "bootstrap-ids": "NodeID-P7oB2McjBGgW2NXXWVYjV8JEDFoW9xDE5",
+ "bootstrap-ips": "192.168.1.1:9651",
+ "genesis-file": "/.avalanchego/configs/genesis.json",
+ "network-id": "network-1338",
+ "public-ip": "192.168.1.2"
+}`)
+	peers, err := NewBootstrapPeers([]string{testBootstrapID1, testBootstrapID2}, []string{"10.0.0.1:9651", "10.0.0.2:9651"})
+	require.NoError(err)
+	updatedConfig, err := SetNodeConfigBootstrapPeers(nodeConfig, peers)
+	require.NoError(err)
+	confMap := map[string]interface{}{}
+	require.NoError(json.Unmarshal(updatedConfig, &confMap))
+	require.Equal(map[string]interface{}{
+		"bootstrap-ids": testBootstrapID1 + "," + testBootstrapID2,
+		"bootstrap-ips": "10.0.0.1:9651,10.0.0.2:9651",
+		"genesis-file":  "/.avalanchego/configs/genesis.json",
+		"network-id":    "network-1338",
+		"public-ip":     "192.168.1.2",
+	}, confMap)
+
+	_, err = SetNodeConfigBootstrapPeers([]byte("not json"), peers)
+	require.ErrorContains(err, "invalid node config")
+}
+
+func TestParseGenesisNetworkID(t *testing.T) {
+	require := require.New(t)
+	networkID, err := ParseGenesisNetworkID([]byte(`{"networkID": 1338, "allocations": []}`))
+	require.NoError(err)
+	require.Equal(uint32(1338), networkID)
+
+	_, err = ParseGenesisNetworkID([]byte(`{"allocations": []}`))
+	require.ErrorContains(err, "missing networkID")
+
+	_, err = ParseGenesisNetworkID([]byte("not json"))
+	require.ErrorContains(err, "invalid genesis")
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remoteconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ConfigDiff is a config key whose value differs between an expected and an actual config.
+// Nested keys are joined by dots
+type ConfigDiff struct {
+	Key      string
+	Expected interface{}
+	Actual   interface{}
+	// InExpected and InActual tell if the key is present in each config
+	InExpected bool
+	InActual   bool
+}
+
+func (d ConfigDiff) String() string {
+	switch {
+	case !d.InActual:
+		return fmt.Sprintf("%s: missing, expected %s", d.Key, configValueString(d.Expected))
+	case !d.InExpected:
+		return fmt.Sprintf("%s: unexpected, found %s", d.Key, configValueString(d.Actual))
+	default:
+		return fmt.Sprintf("%s: expected %s, found %s", d.Key, configValueString(d.Expected), configValueString(d.Actual))
+	}
+}
+
+func configValueString(value interface{}) string {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(valueBytes)
+}
+
+// DiffConfigs returns the keys of the JSON configs [expected] and [actual] whose values
+// differ, sorted by key. Objects present in both configs are compared key by key
+func DiffConfigs(expected []byte, actual []byte) ([]ConfigDiff, error) {
+	var expectedConfig, actualConfig map[string]interface{}
+	if err := json.Unmarshal(expected, &expectedConfig); err != nil {
+		return nil, fmt.Errorf("invalid expected config: %w", err)
+	}
+	if err := json.Unmarshal(actual, &actualConfig); err != nil {
+		return nil, fmt.Errorf("invalid actual config: %w", err)
+	}
+	diffs := diffConfigMaps("", expectedConfig, actualConfig)
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Key < diffs[j].Key })
+	return diffs, nil
+}
+
+func diffConfigMaps(prefix string, expected map[string]interface{}, actual map[string]interface{}) []ConfigDiff {
+	diffs := []ConfigDiff{}
+	for key, expectedValue := range expected {
+		actualValue, inActual := actual[key]
+		expectedMap, expectedIsMap := expectedValue.(map[string]interface{})
+		actualMap, actualIsMap := actualValue.(map[string]interface{})
+		switch {
+		case expectedIsMap && actualIsMap:
+			diffs = append(diffs, diffConfigMaps(prefix+key+".", expectedMap, actualMap)...)
+		case !inActual || !reflect.DeepEqual(expectedValue, actualValue):
+			diffs = append(diffs, ConfigDiff{
+				Key:        prefix + key,
+				Expected:   expectedValue,
+				Actual:     actualValue,
+				InExpected: true,
+				InActual:   inActual,
+			})
+		}
+	}
+	for key, actualValue := range actual {
+		if _, ok := expected[key]; !ok {
+			diffs = append(diffs, ConfigDiff{
+				Key:      prefix + key,
+				Actual:   actualValue,
+				InActual: true,
+			})
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remoteconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	require := require.New(t)
+
+	expected, err := RenderAvalancheNodeConfig(PrepareAvalancheConfig("1.2.3.4", "fuji", []string{"subnet1"}, 0, 0))
+	require.NoError(err)
+	diffs, err := DiffConfigs(expected, expected)
+	require.NoError(err)
+	require.Empty(diffs)
+
+	actual := []byte(`{
+		"http-host": "0.0.0.0",
+		"api-admin-enabled": false,
+		"index-enabled": false,
+		"network-id": "fuji",
+		"public-ip": "5.6.7.8",
+		"track-subnets": "subnet1",
+		"db-dir": "/.avalanchego/db/",
+		"http-port": 9652,
+		"log-dir": "/.avalanchego/logs/",
+		"consensus-app-concurrency": {"x": 1}
+	}`)
+	diffs, err = DiffConfigs(expected, actual)
+	require.NoError(err)
+	require.Equal([]ConfigDiff{
+		{Key: "consensus-app-concurrency", Actual: map[string]interface{}{"x": float64(1)}, InActual: true},
+		{Key: "http-port", Actual: float64(9652), InActual: true},
+		{Key: "public-ip", Expected: "1.2.3.4", Actual: "5.6.7.8", InExpected: true, InActual: true},
+	}, diffs)
+	require.Equal(`public-ip: expected "1.2.3.4", found "5.6.7.8"`, diffs[2].String())
+	require.Equal(`http-port: unexpected, found 9652`, diffs[1].String())
+
+	// nested objects are compared key by key
+	diffs, err = DiffConfigs([]byte(`{"a": {"b": 1, "c": true}}`), []byte(`{"a": {"b": 2}}`))
+	require.NoError(err)
+	require.Equal([]ConfigDiff{
+		{Key: "a.b", Expected: float64(1), Actual: float64(2), InExpected: true, InActual: true},
+		{Key: "a.c", Expected: true, InExpected: true},
+	}, diffs)
+	require.Equal(`a.c: missing, expected true`, diffs[1].String())
+
+	_, err = DiffConfigs(expected, []byte(`not json`))
+	require.Error(err)
+}
@@ -3,11 +3,14 @@
 
 package remoteconfig
 
-import "github.com/ava-labs/avalanche-cli/pkg/utils"
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+)
 
 func PromtailFoldersToCreate() []string {
 	return []string{
 		utils.GetRemoteComposeServicePath("promtail"),
-		"/home/ubuntu/.avalanchego/logs",
+		constants.CloudNodeLogsPath,
 	}
 }
@@ -1,16 +1,22 @@
 #!/usr/bin/env bash
 
-if [ -d {{ .CustomVMRepoDir }} ]; then
-  rm -rf {{ .CustomVMRepoDir }}
+if [ -d {{ quote .CustomVMRepoDir }} ]; then
+  rm -rf {{ quote .CustomVMRepoDir }}
 fi
+mkdir -p {{ quote .CustomVMRepoDir }}
 
-cd {{ .CustomVMRepoDir }}
+cd {{ quote .CustomVMRepoDir }}
 git init -q
-git remote add origin {{ .CustomVMRepoURL }}
-git fetch --depth 1 origin {{ .CustomVMBranch }} -q
-git checkout {{ .CustomVMBranch }}
-chmod +x {{ .CustomVMBuildScript }}
-./{{ .CustomVMBuildScript }} {{ .VMBinaryPath }}
-echo {{ .VMBinaryPath }} [ok]
+git remote add origin {{ quote .CustomVMRepoURL }}
+{{- if .CustomVMTag }}
+git fetch --depth 1 origin {{ quote (printf "refs/tags/%s:refs/tags/%s" .CustomVMTag .CustomVMTag) }} -q
+git checkout -q {{ quote (printf "tags/%s" .CustomVMTag) }}
+{{- else }}
+git fetch --depth 1 origin {{ quote .CustomVMBranch }} -q
+git checkout {{ quote .CustomVMBranch }}
+{{- end }}
+chmod +x {{ quote .CustomVMBuildScript }}
+{{ quote (printf "./%s" .CustomVMBuildScript) }} {{ quote .VMBinaryPath }}
+echo {{ quote .VMBinaryPath }} [ok]
 
 
@@ -1,6 +1,16 @@
 #!/usr/bin/env bash
 set -e
 #name:TASK [download new subnet EVM release] 
-busybox wget "{{ .SubnetEVMReleaseURL }}" -O "{{ .SubnetEVMArchive }}"
+busybox wget -c "{{ .SubnetEVMReleaseURL }}" -O "{{ .SubnetEVMArchive }}"
 #name:TASK [unpack new subnet EVM release] 
-tar xvf "{{ .SubnetEVMArchive}}"
+if ! tar xvf "{{ .SubnetEVMArchive }}"; then
+    # archive is corrupted, so resuming it won't help: start the next attempt from zero
+    rm -f "{{ .SubnetEVMArchive }}"
+    echo "failed to unpack subnet-evm archive"
+    exit 1
+fi
+#name:TASK [validate new subnet EVM binary] 
+if [ ! -s subnet-evm ] || [ ! -x subnet-evm ]; then
+    echo "{{ .InvalidBinaryMarker }}"
+    exit 1
+fi
@@ -0,0 +1,14 @@
+#!/usr/bin/env bash
+set -e
+#name:TASK [restore db snapshot]
+test -s {{ .DBSnapshotPath }}
+sudo rm -rf {{ .AvalancheGoDir }}/db.restore
+sudo mkdir -p {{ .AvalancheGoDir }}/db.restore
+echo "{{ .NodeID }} extracting {{ .DBSnapshotPath }}"
+# progress is printed every GiB written
+sudo tar -C {{ .AvalancheGoDir }}/db.restore -b 2048 --checkpoint=1024 --checkpoint-action="echo={{ .NodeID }} %T" -xzf {{ .DBSnapshotPath }}
+# the current db is only replaced once the snapshot is fully extracted
+sudo rm -rf {{ .AvalancheGoDir }}/db
+sudo mv {{ .AvalancheGoDir }}/db.restore/db {{ .AvalancheGoDir }}/db
+sudo rmdir {{ .AvalancheGoDir }}/db.restore
+echo "{{ .NodeID }} db restored from {{ .DBSnapshotPath }}"
@@ -1,9 +1,22 @@
 #!/usr/bin/env bash
-# run load test command
-mkdir -p `dirname {{ .LoadTestResultFile }}`
-chown -R ubuntu:ubuntu `dirname {{ .LoadTestResultFile }}`
-if [ -e {{ .LoadTestResultFile }} ]; then
-  rm {{ .LoadTestResultFile }}
+# run load test commands, each one writing to its own result file
+{{- range .LoadTestRuns }}
+mkdir -p `dirname {{ .ResultFile }}`
+chown -R {{ $.SSHUser }}:{{ $.SSHUser }} `dirname {{ .ResultFile }}`
+if [ -e {{ .ResultFile }} ]; then
+  rm {{ .ResultFile }}
 fi
-nohup {{ .LoadTestCommand }} > {{ .LoadTestResultFile }} 2>&1 &
+{{- end }}
+{{- if .LoadTestParallel }}
+{{- range .LoadTestRuns }}
+nohup {{ .Command }} > {{ .ResultFile }} 2>&1 &
+{{- end }}
+{{- else }}
+nohup bash -c "$(cat <<'LOADTEST'
+{{- range .LoadTestRuns }}
+{{ .Command }} > {{ .ResultFile }} 2>&1
+{{- end }}
+LOADTEST
+)" > /dev/null 2>&1 &
+{{- end }}
 exit
@@ -8,11 +8,11 @@ Requires=docker.service
 After=docker.service
 
 [Service]
-User=ubuntu
-Group=ubuntu
+User={{ .SSHUser }}
+Group={{ .SSHUser }}
 Restart=on-failure
-ExecStart=/usr/bin/docker compose -f /home/ubuntu/.avalanche-cli/services/docker-compose.yml up 
-ExecStop=/usr/bin/docker compose -f /home/ubuntu/.avalanche-cli/services/docker-compose.yml down
+ExecStart=/usr/bin/docker compose -f {{ .ComposeFile }} up 
+ExecStop=/usr/bin/docker compose -f {{ .ComposeFile }} down
 
 [Install]
 WantedBy=default.target
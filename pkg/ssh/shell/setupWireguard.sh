@@ -0,0 +1,14 @@
+#!/usr/bin/env bash
+set -e
+#name:TASK [install wireguard]
+export DEBIAN_FRONTEND=noninteractive
+if ! command -v wg-quick >/dev/null 2>&1; then
+  sudo apt-get -y update
+  sudo apt-get -y install wireguard-tools
+fi
+#name:TASK [configure wireguard]
+sudo install -m 600 -o root -g root {{ .WireguardConfigPath }} /etc/wireguard/{{ .WireguardInterface }}.conf
+rm -f {{ .WireguardConfigPath }}
+#name:TASK [start wireguard]
+sudo systemctl enable wg-quick@{{ .WireguardInterface }}
+sudo systemctl restart wg-quick@{{ .WireguardInterface }}
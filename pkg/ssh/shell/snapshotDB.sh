@@ -0,0 +1,10 @@
+#!/usr/bin/env bash
+set -e
+#name:TASK [snapshot db]
+mkdir -p {{ .DBSnapshotsDir }}
+echo "{{ .NodeID }} archiving $(sudo du -sh {{ .AvalancheGoDir }}/db | cut -f1) of db to {{ .DBSnapshotPath }}"
+# progress is printed every GiB read
+sudo tar -C {{ .AvalancheGoDir }} -b 2048 --checkpoint=1024 --checkpoint-action="echo={{ .NodeID }} %T" -czf {{ .DBSnapshotPath }}.partial db
+sudo chown {{ .SSHUser }}:{{ .SSHUser }} {{ .DBSnapshotPath }}.partial
+mv {{ .DBSnapshotPath }}.partial {{ .DBSnapshotPath }}
+echo "{{ .NodeID }} db snapshot {{ .DBSnapshotPath }} done, $(du -sh {{ .DBSnapshotPath }} | cut -f1)"
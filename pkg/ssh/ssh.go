@@ -8,12 +8,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"syscall"
 	"text/template"
 	"time"
 
@@ -24,7 +27,9 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/remoteconfig"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/wireguard"
 	"github.com/ava-labs/avalanchego/ids"
+	goSSH "golang.org/x/crypto/ssh"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
@@ -42,27 +47,44 @@ type scriptInputs struct {
 	VMBinaryPath            string
 	SubnetEVMReleaseURL     string
 	SubnetEVMArchive        string
+	InvalidBinaryMarker     string
 	MonitoringDashboardPath string
 	LoadTestRepoDir         string
 	LoadTestRepo            string
 	LoadTestPath            string
-	LoadTestCommand         string
 	LoadTestBranch          string
 	LoadTestGitCommit       string
 	CheckoutCommit          bool
-	LoadTestResultFile      string
+	LoadTestRuns            []loadTestRunInputs
+	LoadTestParallel        bool
 	GrafanaPkg              string
 	CustomVMRepoDir         string
 	CustomVMRepoURL         string
 	CustomVMBranch          string
+	CustomVMTag             string
 	CustomVMBuildScript     string
+	WireguardConfigPath     string
+	WireguardInterface      string
+	NodeID                  string
+	AvalancheGoDir          string
+	DBSnapshotsDir          string
+	DBSnapshotPath          string
+	SSHUser                 string
+	ComposeFile             string
+}
+
+// loadTestRunInputs are the inputs of a load test run of runLoadTest.sh
+type loadTestRunInputs struct {
+	Command    string
+	ResultFile string
 }
 
 //go:embed shell/*.sh
 var script embed.FS
 
 // RunOverSSH runs provided script path over ssh.
-// This script can be template as it will be rendered using scriptInputs vars
+// This script can be template as it will be rendered using scriptInputs vars,
+// with SSHUser set to the remote user of [host]
 func RunOverSSH(
 	scriptDesc string,
 	host *models.Host,
@@ -71,21 +93,13 @@ func RunOverSSH(
 	templateVars scriptInputs,
 ) error {
 	startTime := time.Now()
-	shellScript, err := script.ReadFile(scriptPath)
-	if err != nil {
-		return err
-	}
-	var script bytes.Buffer
-	t, err := template.New(scriptDesc).Parse(string(shellScript))
-	if err != nil {
-		return err
-	}
-	err = t.Execute(&script, templateVars)
+	templateVars.SSHUser = host.GetSSHUser()
+	script, err := renderScript(scriptDesc, scriptPath, templateVars)
 	if err != nil {
 		return err
 	}
 
-	if output, err := host.Command(script.String(), nil, timeout); err != nil {
+	if output, err := host.Command(script, nil, timeout); err != nil {
 		return fmt.Errorf("%w: %s", err, string(output))
 	}
 	executionTime := time.Since(startTime)
@@ -93,7 +107,33 @@ func RunOverSSH(
 	return nil
 }
 
-func PostOverSSH(host *models.Host, path string, requestBody string) ([]byte, error) {
+// renderScript renders the embedded script at [scriptPath] with [templateVars]
+func renderScript(scriptDesc string, scriptPath string, templateVars scriptInputs) (string, error) {
+	shellScript, err := script.ReadFile(scriptPath)
+	if err != nil {
+		return "", err
+	}
+	var script bytes.Buffer
+	// quote passes a value to the script as a single shell word
+	t, err := template.New(scriptDesc).Funcs(template.FuncMap{"quote": utils.ShellQuote}).Parse(string(shellScript))
+	if err != nil {
+		return "", err
+	}
+	if err := t.Execute(&script, templateVars); err != nil {
+		return "", err
+	}
+	return script.String(), nil
+}
+
+var errEmptyResponse = errors.New("empty response")
+
+// forwarder forwards an HTTP request to the avalanchego API of a host, and returns the response body
+type forwarder func(httpRequest string) ([]byte, error)
+
+// PostOverSSH posts [requestBody] to the avalanchego API [path] of [host], forwarded over SSH,
+// and returns the JSON response. Requests failing because the API is not up yet, as
+// during bootstrap, are retried up to [retries] times with backoff, within constants.SSHPOSTTimeout
+func PostOverSSH(host *models.Host, path string, requestBody string, retries int) ([]byte, error) {
 	if path == "" {
 		path = "/ext/info"
 	}
@@ -101,12 +141,71 @@ func PostOverSSH(host *models.Host, path string, requestBody string) ([]byte, er
 	if err != nil {
 		return nil, err
 	}
+	requestHost := fmt.Sprintf("%s:%d", localhost.Hostname(), host.GetHTTPPort())
 	requestHeaders := fmt.Sprintf("POST %s HTTP/1.1\r\n"+
 		"Host: %s\r\n"+
 		"Content-Length: %d\r\n"+
-		"Content-Type: application/json\r\n\r\n", path, localhost.Host, len(requestBody))
+		"Content-Type: application/json\r\n\r\n", path, requestHost, len(requestBody))
 	httpRequest := requestHeaders + requestBody
-	return host.Forward(httpRequest, constants.SSHPOSTTimeout)
+	response, err := postWithRetries(host.UntimedForward, httpRequest, retries, constants.SSHPOSTTimeout, constants.SSHPOSTRetryInterval)
+	if err != nil {
+		return nil, fmt.Errorf("%w for host %s", err, host.IP)
+	}
+	return response, nil
+}
+
+// postWithRetries forwards [httpRequest] with [forward], retrying up to [retries] times on
+// retryable errors, waiting [retryInterval] doubled on each retry. All attempts are bounded by [timeout]
+func postWithRetries(
+	forward forwarder,
+	httpRequest string,
+	retries int,
+	timeout time.Duration,
+	retryInterval time.Duration,
+) ([]byte, error) {
+	deadline := time.Now().Add(timeout)
+	for attempt := 0; ; attempt++ {
+		responseI, err := utils.TimedFunction(
+			func() (interface{}, error) {
+				return forward(httpRequest)
+			},
+			"post over ssh",
+			time.Until(deadline),
+		)
+		if err == nil {
+			response, _ := responseI.([]byte)
+			if len(bytes.TrimSpace(response)) == 0 {
+				err = errEmptyResponse
+			} else if !json.Valid(response) {
+				return nil, fmt.Errorf("invalid JSON response: %q", response)
+			} else {
+				return response, nil
+			}
+		}
+		if attempt >= retries || !isRetryablePostError(err) || time.Until(deadline) <= retryInterval {
+			return nil, err
+		}
+		ux.Logger.Info("post over ssh attempt %d failed, retrying in %s: %s", attempt+1, retryInterval, err)
+		time.Sleep(retryInterval)
+		retryInterval *= 2
+	}
+}
+
+// isRetryablePostError tells if [err] may be caused by the avalanchego API not being up yet
+func isRetryablePostError(err error) bool {
+	var openChannelErr *goSSH.OpenChannelError
+	switch {
+	case errors.Is(err, errEmptyResponse), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET):
+		// E2E forwards directly to the API port
+		return true
+	case errors.As(err, &openChannelErr):
+		// the SSH server could not connect to the API port
+		return openChannelErr.Reason == goSSH.ConnectionFailed
+	default:
+		return false
+	}
 }
 
 // RunSSHSetupNode runs script to setup node
@@ -140,7 +239,7 @@ func RunSSHSetupDockerService(host *models.Host) error {
 			host,
 			constants.SSHLongRunningScriptTimeout,
 			"shell/setupDockerService.sh",
-			scriptInputs{},
+			scriptInputs{ComposeFile: host.ExpandHome(utils.GetRemoteComposeFile())},
 		)
 	} else {
 		// no need to setup docker service
@@ -176,14 +275,30 @@ func RunSSHStopAWMRelayerService(host *models.Host) error {
 	return docker.StopDockerComposeService(host, utils.GetRemoteComposeFile(), "awm-relayer", constants.SSHLongRunningScriptTimeout)
 }
 
+// RunSSHRestartAWMRelayerService restarts the AWM Relayer Service, if it is running
+func RunSSHRestartAWMRelayerService(host *models.Host) error {
+	return docker.RestartDockerComposeService(host, utils.GetRemoteComposeFile(), "awm-relayer", constants.SSHLongRunningScriptTimeout)
+}
+
 // RunSSHUpgradeAvalanchego runs script to upgrade avalanchego
-func RunSSHUpgradeAvalanchego(host *models.Host, network models.Network, avalancheGoVersion string) error {
+func RunSSHUpgradeAvalanchego(host *models.Host, network models.Network, avalancheGoVersion string, avalancheGoImageDigest string) error {
 	withMonitoring, err := docker.WasNodeSetupWithMonitoring(host)
 	if err != nil {
 		return err
 	}
 
-	if err := docker.ComposeSSHSetupNode(host, network, avalancheGoVersion, withMonitoring); err != nil {
+	// service env set at creation is kept when merging into the existing compose file
+	if err := docker.ComposeSSHSetupNode(
+		host,
+		network,
+		avalancheGoVersion,
+		getRemoteLogLevel(host),
+		getRemoteCChainDBConfig(host),
+		withMonitoring,
+		nil,
+		avalancheGoImageDigest,
+		"",
+	); err != nil {
 		return err
 	}
 	return docker.RestartDockerCompose(host, constants.SSHLongRunningScriptTimeout)
@@ -307,7 +422,7 @@ func RunSSHCopyMonitoringDashboards(host *models.Host, monitoringDashboardPath s
 func RunSSHCopyYAMLFile(host *models.Host, yamlFilePath string) error {
 	if err := host.Upload(
 		yamlFilePath,
-		fmt.Sprintf("/home/ubuntu/%s", filepath.Base(yamlFilePath)),
+		host.ExpandHome(filepath.Join("~", filepath.Base(yamlFilePath))),
 		constants.SSHFileOpsTimeout,
 	); err != nil {
 		return err
@@ -315,7 +430,15 @@ func RunSSHCopyYAMLFile(host *models.Host, yamlFilePath string) error {
 	return nil
 }
 
-func RunSSHSetupPrometheusConfig(host *models.Host, avalancheGoPorts, machinePorts, loadTestPorts []string) error {
+// RunSSHSetupPrometheusConfig uploads prometheus config to the monitoring host, scraping also
+// the VM metrics of [subnetMetrics] blockchains. If [remoteWrite] is not nil, prometheus also
+// remote writes metrics to the given target
+func RunSSHSetupPrometheusConfig(
+	host *models.Host,
+	avalancheGoPorts, machinePorts, loadTestPorts []string,
+	subnetMetrics []monitoring.SubnetMetricsTarget,
+	remoteWrite *monitoring.RemoteWriteConfig,
+) error {
 	for _, folder := range remoteconfig.PrometheusFoldersToCreate() {
 		if err := host.MkdirAll(folder, constants.SSHDirOpsTimeout); err != nil {
 			return err
@@ -327,15 +450,24 @@ func RunSSHSetupPrometheusConfig(host *models.Host, avalancheGoPorts, machinePor
 		return err
 	}
 	defer os.Remove(promConfig.Name())
-	if err := monitoring.WritePrometheusConfig(promConfig.Name(), avalancheGoPorts, machinePorts, loadTestPorts); err != nil {
+	if err := monitoring.WritePrometheusConfig(promConfig.Name(), avalancheGoPorts, machinePorts, loadTestPorts, subnetMetrics, remoteWrite); err != nil {
 		return err
 	}
 
-	return host.Upload(
+	if err := host.Upload(
 		promConfig.Name(),
 		cloudNodePrometheusConfigTemp,
 		constants.SSHFileOpsTimeout,
-	)
+	); err != nil {
+		return err
+	}
+	if remoteWrite != nil {
+		// config contains remote write credentials
+		if _, err := host.Command(fmt.Sprintf("chmod 600 %s", cloudNodePrometheusConfigTemp), nil, constants.SSHFileOpsTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func RunSSHSetupLokiConfig(host *models.Host, port int) error {
@@ -403,19 +535,68 @@ func RunSSHUploadNodeAWMRelayerConfig(host *models.Host, nodeInstanceDirPath str
 	)
 }
 
-// RunSSHGetNewSubnetEVMRelease runs script to download new subnet evm
+// invalidSubnetEVMBinaryMarker is printed by getNewSubnetEVMRelease.sh when the
+// unpacked binary is missing, empty or not executable
+const invalidSubnetEVMBinaryMarker = "invalid subnet-evm binary"
+
+// nonRetriableDownloadErrors are failures that will not go away by downloading again
+var nonRetriableDownloadErrors = []string{
+	"404 Not Found",
+	"403 Forbidden",
+	invalidSubnetEVMBinaryMarker,
+}
+
+// shouldRetryDownload decides if a failed download attempt is worth retrying
+func shouldRetryDownload(err error, attempt int, maxAttempts int) bool {
+	if err == nil || attempt >= maxAttempts {
+		return false
+	}
+	var cmdErr *models.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.IsCommandNotFound() {
+		return false
+	}
+	for _, nonRetriable := range nonRetriableDownloadErrors {
+		if strings.Contains(err.Error(), nonRetriable) {
+			return false
+		}
+	}
+	return true
+}
+
+// RunSSHGetNewSubnetEVMRelease runs script to download new subnet evm.
+// The download is resumed and retried with backoff on transient failures
 func RunSSHGetNewSubnetEVMRelease(host *models.Host, subnetEVMReleaseURL, subnetEVMArchive string) error {
-	return RunOverSSH(
-		"Get Subnet EVM Release",
-		host,
-		constants.SSHScriptTimeout,
-		"shell/getNewSubnetEVMRelease.sh",
-		scriptInputs{SubnetEVMReleaseURL: subnetEVMReleaseURL, SubnetEVMArchive: subnetEVMArchive},
-	)
+	var err error
+	retryInterval := constants.SSHDownloadRetryInterval
+	for attempt := 1; ; attempt++ {
+		err = RunOverSSH(
+			"Get Subnet EVM Release",
+			host,
+			constants.SSHScriptTimeout,
+			"shell/getNewSubnetEVMRelease.sh",
+			scriptInputs{
+				SubnetEVMReleaseURL: subnetEVMReleaseURL,
+				SubnetEVMArchive:    subnetEVMArchive,
+				InvalidBinaryMarker: invalidSubnetEVMBinaryMarker,
+			},
+		)
+		if !shouldRetryDownload(err, attempt, constants.SSHDownloadMaxAttempts) {
+			break
+		}
+		ux.Logger.Info("attempt %d to get subnet evm release %s on %s failed: %s. retrying in %s", attempt, subnetEVMReleaseURL, host.NodeID, err, retryInterval)
+		time.Sleep(retryInterval)
+		retryInterval *= 2
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get subnet evm release %s on %s: %w", subnetEVMReleaseURL, host.NodeID, err)
+	}
+	return nil
 }
 
 // RunSSHSetupDevNet runs script to setup devnet
-func RunSSHSetupDevNet(host *models.Host, nodeInstanceDirPath string) error {
+// If [bootstrapPeers] is not empty, it replaces the bootstrap peers of the node config before uploading it,
+// so that the node joins an existing devnet instead of the cluster one
+func RunSSHSetupDevNet(host *models.Host, nodeInstanceDirPath string, bootstrapPeers remoteconfig.BootstrapPeers) error {
 	if err := host.MkdirAll(
 		constants.CloudNodeConfigPath,
 		constants.SSHDirOpsTimeout,
@@ -429,8 +610,14 @@ func RunSSHSetupDevNet(host *models.Host, nodeInstanceDirPath string) error {
 	); err != nil {
 		return err
 	}
+	nodeConfigPath := filepath.Join(nodeInstanceDirPath, constants.NodeFileName)
+	if !bootstrapPeers.IsEmpty() {
+		if err := setNodeConfigFileBootstrapPeers(nodeConfigPath, bootstrapPeers); err != nil {
+			return err
+		}
+	}
 	if err := host.Upload(
-		filepath.Join(nodeInstanceDirPath, constants.NodeFileName),
+		nodeConfigPath,
 		filepath.Join(constants.CloudNodeConfigPath, constants.NodeFileName),
 		constants.SSHFileOpsTimeout,
 	); err != nil {
@@ -439,21 +626,34 @@ func RunSSHSetupDevNet(host *models.Host, nodeInstanceDirPath string) error {
 	if err := docker.StopDockerCompose(host, constants.SSHLongRunningScriptTimeout); err != nil {
 		return err
 	}
-	if err := host.Remove("/home/ubuntu/.avalanchego/db", true); err != nil {
+	if err := host.Remove(constants.CloudNodeDBPath, true); err != nil {
 		return err
 	}
-	if err := host.MkdirAll("/home/ubuntu/.avalanchego/db", constants.SSHDirOpsTimeout); err != nil {
+	if err := host.MkdirAll(constants.CloudNodeDBPath, constants.SSHDirOpsTimeout); err != nil {
 		return err
 	}
-	if err := host.Remove("/home/ubuntu/.avalanchego/logs", true); err != nil {
+	if err := host.Remove(constants.CloudNodeLogsPath, true); err != nil {
 		return err
 	}
-	if err := host.MkdirAll("/home/ubuntu/.avalanchego/logs", constants.SSHDirOpsTimeout); err != nil {
+	if err := host.MkdirAll(constants.CloudNodeLogsPath, constants.SSHDirOpsTimeout); err != nil {
 		return err
 	}
 	return docker.StartDockerCompose(host, constants.SSHLongRunningScriptTimeout)
 }
 
+// setNodeConfigFileBootstrapPeers replaces the bootstrap peers of the local node config [nodeConfigPath]
+func setNodeConfigFileBootstrapPeers(nodeConfigPath string, bootstrapPeers remoteconfig.BootstrapPeers) error {
+	nodeConfig, err := os.ReadFile(nodeConfigPath)
+	if err != nil {
+		return err
+	}
+	nodeConfig, err = remoteconfig.SetNodeConfigBootstrapPeers(nodeConfig, bootstrapPeers)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(nodeConfigPath, nodeConfig, constants.WriteReadReadPerms)
+}
+
 // RunSSHUploadStakingFiles uploads staking files to a remote host via SSH.
 func RunSSHUploadStakingFiles(host *models.Host, nodeInstanceDirPath string) error {
 	if err := host.MkdirAll(
@@ -485,6 +685,69 @@ func RunSSHUploadStakingFiles(host *models.Host, nodeInstanceDirPath string) err
 
 // RunSSHRenderAvalancheNodeConfig renders avalanche node config to a remote host via SSH.
 func RunSSHRenderAvalancheNodeConfig(app *application.Avalanche, host *models.Host, network models.Network, trackSubnets []string) error {
+	return RunSSHRenderAvalancheNodeConfigWithLogLevel(app, host, network, trackSubnets, "")
+}
+
+// RunSSHRenderAvalancheNodeConfigWithLogLevel renders avalanche node config to a remote host via SSH,
+// setting its log level. Current remote log level is preserved if [logLevel] is empty
+func RunSSHRenderAvalancheNodeConfigWithLogLevel(
+	app *application.Avalanche,
+	host *models.Host,
+	network models.Network,
+	trackSubnets []string,
+	logLevel string,
+) error {
+	nodeConf, err := renderAvalancheNodeConfig(app, host, network, trackSubnets, logLevel)
+	if err != nil {
+		return err
+	}
+	return host.UploadBytes(nodeConf, remoteconfig.GetRemoteAvalancheNodeConfig(), constants.SSHFileOpsTimeout)
+}
+
+// RunSSHDiffAvalancheNodeConfig compares the node config of [host] against the one that
+// RunSSHRenderAvalancheNodeConfig would render for it, returning the keys that differ
+func RunSSHDiffAvalancheNodeConfig(
+	app *application.Avalanche,
+	host *models.Host,
+	network models.Network,
+	trackSubnets []string,
+) ([]remoteconfig.ConfigDiff, error) {
+	expectedNodeConf, err := renderAvalancheNodeConfig(app, host, network, trackSubnets, "")
+	if err != nil {
+		return nil, err
+	}
+	remoteNodeConf, err := host.ReadFileBytes(remoteconfig.GetRemoteAvalancheNodeConfig(), constants.SSHFileOpsTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return remoteconfig.DiffConfigs(expectedNodeConf, remoteNodeConf)
+}
+
+// getNodePublicIP returns the IP [host] advertises to its peers: its mesh IP if it is part
+// of the mesh of a cluster, as node to node traffic goes through it, or else its public IP
+func getNodePublicIP(app *application.Avalanche, host *models.Host) (string, error) {
+	if !app.ClustersConfigExists() {
+		return host.IP, nil
+	}
+	clustersConfig, err := app.LoadClustersConfig()
+	if err != nil {
+		return "", err
+	}
+	if meshIP, ok := clustersConfig.GetNodeMeshIP(host.GetCloudID()); ok {
+		return meshIP, nil
+	}
+	return host.IP, nil
+}
+
+// renderAvalancheNodeConfig renders the node config of [host], keeping the bootstrap
+// data of its current config, and its log level if [logLevel] is empty
+func renderAvalancheNodeConfig(
+	app *application.Avalanche,
+	host *models.Host,
+	network models.Network,
+	trackSubnets []string,
+	logLevel string,
+) ([]byte, error) {
 	// get subnet ids
 	subnetIDs, err := utils.MapWithError(trackSubnets, func(subnetName string) (string, error) {
 		sc, err := app.LoadSidecar(subnetName)
@@ -495,43 +758,124 @@ func RunSSHRenderAvalancheNodeConfig(app *application.Avalanche, host *models.Ho
 		}
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	avagoConf := remoteconfig.PrepareAvalancheConfig(host.IP, network.NetworkIDFlagValue(), subnetIDs)
+	publicIP, err := getNodePublicIP(app, host)
+	if err != nil {
+		return nil, err
+	}
+	avagoConf := remoteconfig.PrepareAvalancheConfig(publicIP, network.NetworkIDFlagValue(), subnetIDs, host.HTTPPort, host.StakingPort)
 	// make sure that genesis and bootstrap data is preserved
 	if genesisFileExists(host) {
 		avagoConf.GenesisPath = filepath.Join(constants.DockerNodeConfigPath, constants.GenesisFileName)
 	}
 	remoteAvagoConf, err := getAvalancheGoConfigData(host)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	bootstrapIDs, err := utils.StringValue(remoteAvagoConf, "bootstrap-ids")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	bootstrapIPs, err := utils.StringValue(remoteAvagoConf, "bootstrap-ips")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	avagoConf.BootstrapIDs = bootstrapIDs
 	avagoConf.BootstrapIPs = bootstrapIPs
+	avagoConf.LogLevel = logLevel
+	if avagoConf.LogLevel == "" {
+		avagoConf.LogLevel, _ = remoteAvagoConf["log-level"].(string)
+	}
 	// ready to render node config
 	nodeConf, err := remoteconfig.RenderAvalancheNodeConfig(avagoConf)
 	if err != nil {
+		return nil, err
+	}
+	// subnet node configs are merged on top, as done when syncing the subnets
+	subnetNodeConfs := [][]byte{}
+	for _, subnetName := range trackSubnets {
+		subnetNodeConfigPath := app.GetAvagoNodeConfigPath(subnetName)
+		if !utils.FileExists(subnetNodeConfigPath) {
+			continue
+		}
+		subnetNodeConf, err := os.ReadFile(subnetNodeConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading subnet node config: %w", err)
+		}
+		subnetNodeConfs = append(subnetNodeConfs, subnetNodeConf)
+	}
+	if len(subnetNodeConfs) == 0 {
+		return nodeConf, nil
+	}
+	if nodeConf, err = mergeNodeConfigs(nodeConf, subnetNodeConfs...); err != nil {
+		return nil, err
+	}
+	if logLevel == "" {
+		return nodeConf, nil
+	}
+	// an explicitly requested log level takes precedence over the subnet node configs
+	return mergeNodeConfigs(nodeConf, []byte(fmt.Sprintf(`{"log-level": %q}`, logLevel)))
+}
+
+// mergeNodeConfigs merges [subnetNodeConfigs] into [nodeConfig], in order. Keys of
+// the subnet node configs take precedence
+func mergeNodeConfigs(nodeConfig []byte, subnetNodeConfigs ...[]byte) ([]byte, error) {
+	var mergedNodeConfig map[string]interface{}
+	if err := json.Unmarshal(nodeConfig, &mergedNodeConfig); err != nil {
+		return nil, fmt.Errorf("error unmarshalling node config: %w", err)
+	}
+	for _, subnetNodeConfigBytes := range subnetNodeConfigs {
+		var subnetNodeConfig map[string]interface{}
+		if err := json.Unmarshal(subnetNodeConfigBytes, &subnetNodeConfig); err != nil {
+			return nil, fmt.Errorf("error unmarshalling subnet node config: %w", err)
+		}
+		maps.Copy(mergedNodeConfig, subnetNodeConfig)
+	}
+	mergedNodeConfigBytes, err := json.MarshalIndent(mergedNodeConfig, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("error creating merged node config: %w", err)
+	}
+	return mergedNodeConfigBytes, nil
+}
+
+// customVMContainerBuildCmd returns the shell command that builds the custom VM of [sc] to
+// /out/vm inside a build container. The ref is fetched by name, so that branches, tags and
+// commit SHAs are all supported
+func customVMContainerBuildCmd(sc models.Sidecar) string {
+	return fmt.Sprintf(
+		"mkdir -p /src && cd /src && git init -q && git remote add origin %s && git fetch -q --depth 1 origin %s && git checkout -q FETCH_HEAD && chmod +x %s && %s /out/vm",
+		utils.ShellQuote(sc.CustomVMRepoURL),
+		utils.ShellQuote(sc.GetCustomVMRef()),
+		utils.ShellQuote(sc.CustomVMBuildScript),
+		utils.ShellQuote("./"+sc.CustomVMBuildScript),
+	)
+}
+
+// buildCustomVMInContainer builds the custom VM of [sc] inside a container of [image],
+// and copies the resulting binary to [vmBinaryPath]
+func buildCustomVMInContainer(host *models.Host, sc models.Sidecar, image string, outputDir string, vmBinaryPath string) error {
+	ux.Logger.Info("Building Custom VM for %s inside container %s", host.NodeID, image)
+	if err := docker.BuildInContainer(host, image, outputDir, customVMContainerBuildCmd(sc)); err != nil {
 		return err
 	}
-	return host.UploadBytes(nodeConf, remoteconfig.GetRemoteAvalancheNodeConfig(), constants.SSHFileOpsTimeout)
+	quotedVMBinaryPath := utils.ShellQuote(vmBinaryPath)
+	if _, err := host.Command(fmt.Sprintf("cp -f %s %s && chmod +x %s", utils.ShellQuote(outputDir+"/vm"), quotedVMBinaryPath, quotedVMBinaryPath), nil, constants.SSHFileOpsTimeout); err != nil {
+		return err
+	}
+	return nil
 }
 
-// RunSSHCreatePlugin runs script to create plugin
-func RunSSHCreatePlugin(host *models.Host, sc models.Sidecar) error {
+// RunSSHCreatePlugin runs script to create plugin.
+// If [customVMBuildImage] is given, custom VMs are built inside a container of that
+// image instead of directly on the host
+func RunSSHCreatePlugin(host *models.Host, sc models.Sidecar, customVMBuildImage string) error {
 	vmID, err := sc.GetVMID()
 	if err != nil {
 		return err
 	}
-	subnetVMBinaryPath := fmt.Sprintf(constants.CloudNodeSubnetEvmBinaryPath, vmID)
+	subnetVMBinaryPath := host.ExpandHome(fmt.Sprintf(constants.CloudNodeSubnetEvmBinaryPath, vmID))
 	hostInstaller := NewHostInstaller(host)
 	tmpDir, err := host.CreateTempDir()
 	if err != nil {
@@ -543,7 +887,13 @@ func RunSSHCreatePlugin(host *models.Host, sc models.Sidecar) error {
 	switch {
 	case sc.VM == models.CustomVM:
 		ux.Logger.Info("Building Custom VM for %s to %s", host.NodeID, subnetVMBinaryPath)
-		ux.Logger.Info("Custom VM Params: repo %s branch %s via %s", sc.CustomVMRepoURL, sc.CustomVMBranch, sc.CustomVMBuildScript)
+		ux.Logger.Info("Custom VM Params: repo %s ref %s via %s", sc.CustomVMRepoURL, sc.GetCustomVMRef(), sc.CustomVMBuildScript)
+		if customVMBuildImage != "" {
+			if err := buildCustomVMInContainer(host, sc, customVMBuildImage, tmpDir, subnetVMBinaryPath); err != nil {
+				return err
+			}
+			break
+		}
 		if err := RunOverSSH(
 			"Build CustomVM",
 			host,
@@ -553,6 +903,7 @@ func RunSSHCreatePlugin(host *models.Host, sc models.Sidecar) error {
 				CustomVMRepoDir:     tmpDir,
 				CustomVMRepoURL:     sc.CustomVMRepoURL,
 				CustomVMBranch:      sc.CustomVMBranch,
+				CustomVMTag:         sc.CustomVMTag,
 				CustomVMBuildScript: sc.CustomVMBuildScript,
 				VMBinaryPath:        subnetVMBinaryPath,
 			},
@@ -598,28 +949,21 @@ func mergeSubnetNodeConfig(host *models.Host, subnetNodeConfigPath string) error
 	if err != nil {
 		return fmt.Errorf("error reading remote node config: %w", err)
 	}
-	var remoteNodeConfig map[string]interface{}
-	if err := json.Unmarshal(remoteNodeConfigBytes, &remoteNodeConfig); err != nil {
-		return fmt.Errorf("error unmarshalling remote node config: %w", err)
-	}
 	subnetNodeConfigBytes, err := os.ReadFile(subnetNodeConfigPath)
 	if err != nil {
 		return fmt.Errorf("error reading subnet node config: %w", err)
 	}
-	var subnetNodeConfig map[string]interface{}
-	if err := json.Unmarshal(subnetNodeConfigBytes, &subnetNodeConfig); err != nil {
-		return fmt.Errorf("error unmarshalling subnet node config: %w", err)
-	}
-	maps.Copy(remoteNodeConfig, subnetNodeConfig) // merge remote config into local subnet config. subnetNodeConfig takes precedence
-	mergedNodeConfigBytes, err := json.MarshalIndent(remoteNodeConfig, "", " ")
+	// subnetNodeConfig takes precedence over the remote node config
+	mergedNodeConfigBytes, err := mergeNodeConfigs(remoteNodeConfigBytes, subnetNodeConfigBytes)
 	if err != nil {
-		return fmt.Errorf("error creating merged node config: %w", err)
+		return err
 	}
 	return host.UploadBytes(mergedNodeConfigBytes, remoteconfig.GetRemoteAvalancheNodeConfig(), constants.SSHFileOpsTimeout)
 }
 
-// RunSSHSyncSubnetData syncs subnet data required
-func RunSSHSyncSubnetData(app *application.Avalanche, host *models.Host, network models.Network, subnetName string) error {
+// RunSSHSyncSubnetData syncs subnet data required. If [chainAlias] is not empty, it is set as
+// the alias of the subnet blockchain, keeping the aliases of other chains
+func RunSSHSyncSubnetData(app *application.Avalanche, host *models.Host, network models.Network, subnetName string, chainAlias string) error {
 	sc, err := app.LoadSidecar(subnetName)
 	if err != nil {
 		return err
@@ -676,12 +1020,12 @@ func RunSSHSyncSubnetData(app *application.Avalanche, host *models.Host, network
 	// end chain config
 
 	// network upgrade
-	if app.NetworkUpgradeExists(subnetName) {
+	if blockchainID != ids.Empty && app.NetworkUpgradeExists(subnetName) {
 		networkUpgrades, err := app.LoadRawNetworkUpgrades(subnetName)
 		if err != nil {
 			return fmt.Errorf("error loading network upgrades: %w", err)
 		}
-		networkUpgradesPath := filepath.Join(constants.CloudNodeConfigPath, "subnets", "chains", blockchainID.String(), "upgrade.json")
+		networkUpgradesPath := filepath.Join(constants.CloudNodeConfigPath, "chains", blockchainID.String(), constants.UpgradeBytesFileName)
 		if err := host.MkdirAll(filepath.Dir(networkUpgradesPath), constants.SSHDirOpsTimeout); err != nil {
 			return err
 		}
@@ -691,6 +1035,40 @@ func RunSSHSyncSubnetData(app *application.Avalanche, host *models.Host, network
 	}
 	// end network upgrade
 
+	// chain aliases
+	if blockchainID != ids.Empty && chainAlias != "" {
+		if err := mergeChainAliases(host, blockchainID.String(), chainAlias); err != nil {
+			return err
+		}
+	}
+	// end chain aliases
+
+	return nil
+}
+
+// mergeChainAliases sets [chainAlias] as the alias of [blockchainID] in the chain aliases
+// file of [host], keeping the aliases of other chains
+func mergeChainAliases(host *models.Host, blockchainID string, chainAlias string) error {
+	aliasesPath := remoteconfig.GetRemoteAvalancheChainAliases()
+	var aliasesFile []byte
+	if exists, err := host.FileExists(aliasesPath); err != nil {
+		return err
+	} else if exists {
+		aliasesFile, err = host.ReadFileBytes(aliasesPath, constants.SSHFileOpsTimeout)
+		if err != nil {
+			return fmt.Errorf("error reading chain aliases: %w", err)
+		}
+	}
+	mergedAliasesFile, err := remoteconfig.MergeChainAliases(aliasesFile, blockchainID, chainAlias)
+	if err != nil {
+		return err
+	}
+	if err := host.MkdirAll(filepath.Dir(aliasesPath), constants.SSHDirOpsTimeout); err != nil {
+		return err
+	}
+	if err := host.UploadBytes(mergedAliasesFile, aliasesPath, constants.SSHFileOpsTimeout); err != nil {
+		return fmt.Errorf("error uploading chain aliases to %s: %w", aliasesPath, err)
+	}
 	return nil
 }
 
@@ -718,53 +1096,90 @@ func RunSSHBuildLoadTestDependencies(host *models.Host) error {
 	)
 }
 
+// LoadTestRun is a named load test command, that writes its output to its own result file
+type LoadTestRun struct {
+	Name    string
+	Command string
+}
+
+// LoadTestResultFileName returns the name of the result file of the run [runName] of the
+// load test [loadTestName]. An empty [runName] is the single run of a load test
+func LoadTestResultFileName(loadTestName string, runName string) string {
+	if runName == "" {
+		return fmt.Sprintf("loadtest_%s.txt", loadTestName)
+	}
+	return fmt.Sprintf("loadtest_%s.%s.txt", loadTestName, runName)
+}
+
+// GetRemoteLoadTestResultFiles returns a pattern matching the result files of all the runs
+// of the load test [loadTestName]
+func GetRemoteLoadTestResultFiles(loadTestName string) string {
+	return filepath.Join(constants.CloudNodeLogsPath, fmt.Sprintf("loadtest_%s.*", loadTestName))
+}
+
+// RunSSHRunLoadTest runs [loadTestCommand] in background, as the single run of [loadTestName]
 func RunSSHRunLoadTest(host *models.Host, loadTestCommand, loadTestName string) error {
+	return RunSSHRunLoadTests(host, loadTestName, []LoadTestRun{{Command: loadTestCommand}}, false)
+}
+
+// RunSSHRunLoadTests runs in background the load test commands of [runs], one after the
+// other, or all at once if [parallel] is set. Each run writes to its own result file
+func RunSSHRunLoadTests(host *models.Host, loadTestName string, runs []LoadTestRun, parallel bool) error {
 	return RunOverSSH(
 		"Run Load Test",
 		host,
 		constants.SSHLongRunningScriptTimeout,
 		"shell/runLoadTest.sh",
-		scriptInputs{
-			GoVersion:          constants.BuildEnvGolangVersion,
-			LoadTestCommand:    loadTestCommand,
-			LoadTestResultFile: fmt.Sprintf("/home/ubuntu/.avalanchego/logs/loadtest_%s.txt", loadTestName),
-		},
+		loadTestScriptInputs(host, loadTestName, runs, parallel),
 	)
 }
 
+func loadTestScriptInputs(host *models.Host, loadTestName string, runs []LoadTestRun, parallel bool) scriptInputs {
+	return scriptInputs{
+		GoVersion: constants.BuildEnvGolangVersion,
+		LoadTestRuns: utils.Map(runs, func(run LoadTestRun) loadTestRunInputs {
+			return loadTestRunInputs{
+				Command:    run.Command,
+				ResultFile: host.ExpandHome(filepath.Join(constants.CloudNodeLogsPath, LoadTestResultFileName(loadTestName, run.Name))),
+			}
+		}),
+		LoadTestParallel: parallel,
+	}
+}
+
 // RunSSHCheckAvalancheGoVersion checks node avalanchego version
 func RunSSHCheckAvalancheGoVersion(host *models.Host) ([]byte, error) {
 	// Craft and send the HTTP POST request
 	requestBody := "{\"jsonrpc\":\"2.0\", \"id\":1,\"method\" :\"info.getNodeVersion\"}"
-	return PostOverSSH(host, "", requestBody)
+	return PostOverSSH(host, "", requestBody, constants.SSHPOSTRetries)
 }
 
 // RunSSHCheckBootstrapped checks if node is bootstrapped to primary network
 func RunSSHCheckBootstrapped(host *models.Host) ([]byte, error) {
 	// Craft and send the HTTP POST request
 	requestBody := "{\"jsonrpc\":\"2.0\", \"id\":1,\"method\" :\"info.isBootstrapped\", \"params\": {\"chain\":\"X\"}}"
-	return PostOverSSH(host, "", requestBody)
+	return PostOverSSH(host, "", requestBody, constants.SSHPOSTRetries)
 }
 
 // RunSSHCheckHealthy checks if node is healthy
 func RunSSHCheckHealthy(host *models.Host) ([]byte, error) {
 	// Craft and send the HTTP POST request
 	requestBody := "{\"jsonrpc\":\"2.0\", \"id\":1,\"method\":\"health.health\",\"params\": {\"tags\": [\"P\"]}}"
-	return PostOverSSH(host, "/ext/health", requestBody)
+	return PostOverSSH(host, "/ext/health", requestBody, constants.SSHPOSTRetries)
 }
 
 // RunSSHGetNodeID reads nodeID from avalanchego
 func RunSSHGetNodeID(host *models.Host) ([]byte, error) {
 	// Craft and send the HTTP POST request
 	requestBody := "{\"jsonrpc\":\"2.0\", \"id\":1,\"method\" :\"info.getNodeID\"}"
-	return PostOverSSH(host, "", requestBody)
+	return PostOverSSH(host, "", requestBody, constants.SSHPOSTRetries)
 }
 
 // SubnetSyncStatus checks if node is synced to subnet
 func RunSSHSubnetSyncStatus(host *models.Host, blockchainID string) ([]byte, error) {
 	// Craft and send the HTTP POST request
 	requestBody := fmt.Sprintf("{\"jsonrpc\":\"2.0\", \"id\":1,\"method\" :\"platform.getBlockchainStatus\", \"params\": {\"blockchainID\":\"%s\"}}", blockchainID)
-	return PostOverSSH(host, "/ext/bc/P", requestBody)
+	return PostOverSSH(host, "/ext/bc/P", requestBody, constants.SSHPOSTRetries)
 }
 
 // StreamOverSSH runs provided script path over ssh.
@@ -798,20 +1213,49 @@ func StreamOverSSH(
 
 // RunSSHWhitelistPubKey downloads the authorized_keys file from the specified host, appends the provided sshPubKey to it, and uploads the file back to the host.
 func RunSSHWhitelistPubKey(host *models.Host, sshPubKey string) error {
-	const sshAuthFile = "/home/ubuntu/.ssh/authorized_keys"
+	return RunSSHWhitelistPubKeys(host, []string{sshPubKey})
+}
+
+// RunSSHWhitelistPubKeys appends the given ssh public keys to the authorized keys
+// of the host, skipping the ones already present
+func RunSSHWhitelistPubKeys(host *models.Host, sshPubKeys []string) error {
+	sshAuthFile := host.ExpandHome("~/.ssh/authorized_keys")
 	tmpName := filepath.Join(os.TempDir(), utils.RandomString(10))
 	defer os.Remove(tmpName)
 	if err := host.Download(sshAuthFile, tmpName, constants.SSHFileOpsTimeout); err != nil {
 		return err
 	}
-	// write ssh public key
-	tmpFile, err := os.OpenFile(tmpName, os.O_APPEND|os.O_WRONLY, 0o644)
+	authorizedKeys, err := os.ReadFile(tmpName)
 	if err != nil {
 		return err
 	}
-	if _, err := tmpFile.WriteString(sshPubKey + "\n"); err != nil {
+	existingKeys := utils.Map(strings.Split(string(authorizedKeys), "\n"), strings.TrimSpace)
+	newKeys := []string{}
+	for _, sshPubKey := range sshPubKeys {
+		sshPubKey = strings.TrimSpace(sshPubKey)
+		if slices.Contains(existingKeys, sshPubKey) || slices.Contains(newKeys, sshPubKey) {
+			continue
+		}
+		newKeys = append(newKeys, sshPubKey)
+	}
+	if len(newKeys) == 0 {
+		return nil
+	}
+	// write ssh public keys
+	tmpFile, err := os.OpenFile(tmpName, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
 		return err
 	}
+	if len(authorizedKeys) > 0 && !strings.HasSuffix(string(authorizedKeys), "\n") {
+		if _, err := tmpFile.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+	for _, sshPubKey := range newKeys {
+		if _, err := tmpFile.WriteString(sshPubKey + "\n"); err != nil {
+			return err
+		}
+	}
 	if err := tmpFile.Close(); err != nil {
 		return err
 	}
@@ -823,6 +1267,258 @@ func RunSSHDownloadFile(host *models.Host, filePath string, localFilePath string
 	return host.Download(filePath, localFilePath, constants.SSHFileOpsTimeout)
 }
 
+// RunSSHListRemoteFiles returns the regular files of [host] that match [pattern],
+// a path that can contain shell globs
+func RunSSHListRemoteFiles(host *models.Host, pattern string) ([]string, error) {
+	if err := utils.ValidateRemoteGlob(pattern); err != nil {
+		return nil, err
+	}
+	pattern = host.ExpandHome(pattern)
+	script := fmt.Sprintf(`for f in %s; do if [ -f "$f" ]; then echo "$f"; fi; done`, pattern)
+	output, err := host.Command(script, nil, constants.SSHScriptTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(output))
+	}
+	return parseRemoteFileList(string(output)), nil
+}
+
+func parseRemoteFileList(output string) []string {
+	files := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files
+}
+
+// RunSSHDownloadFiles downloads the files of [host] that match [pattern] into [localDir],
+// keeping their paths relative to the directory of [pattern] previous to any glob.
+// Returns the downloaded files, relative to [localDir]
+func RunSSHDownloadFiles(host *models.Host, pattern string, localDir string) ([]string, error) {
+	remoteFiles, err := RunSSHListRemoteFiles(host, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(remoteFiles) == 0 {
+		return nil, fmt.Errorf("no files found matching %s", pattern)
+	}
+	baseDir := utils.RemoteGlobBaseDir(host.ExpandHome(pattern))
+	downloadedFiles := []string{}
+	for _, remoteFile := range remoteFiles {
+		relPath, err := filepath.Rel(baseDir, remoteFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := RunSSHDownloadFile(host, remoteFile, filepath.Join(localDir, relPath)); err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", remoteFile, err)
+		}
+		downloadedFiles = append(downloadedFiles, relPath)
+	}
+	return downloadedFiles, nil
+}
+
+// RunSSHDownloadAWMRelayerConfig downloads the AWM relayer service config of [host] to [localFilePath]
+func RunSSHDownloadAWMRelayerConfig(host *models.Host, localFilePath string) error {
+	remoteConfigPath := filepath.Join(constants.CloudNodeCLIConfigBasePath, constants.ServicesDir, constants.AWMRelayerInstallDir, constants.AWMRelayerConfigFilename)
+	return RunSSHDownloadFile(host, remoteConfigPath, localFilePath)
+}
+
+// RunSSHDownloadAWMRelayerLogs dumps the AWM Relayer container logs into a remote temp file
+// and downloads it into [localFilePath]
+func RunSSHDownloadAWMRelayerLogs(host *models.Host, localFilePath string) error {
+	remoteLogFile, err := host.CreateTempFile()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := host.Remove(remoteLogFile, false); err != nil {
+			ux.Logger.Error("Error removing temporary file %s:%s %s", host.NodeID, remoteLogFile, err)
+		}
+	}()
+	if output, err := host.Command(fmt.Sprintf("docker logs awm-relayer > %s 2>&1", remoteLogFile), nil, constants.SSHScriptTimeout); err != nil {
+		return fmt.Errorf("%w: %s", err, string(output))
+	}
+	return RunSSHDownloadFile(host, remoteLogFile, localFilePath)
+}
+
+// RunSSHSetupWireguard installs Wireguard on [host] and brings up its mesh interface,
+// configured as in the local wg-quick config file [configPath]
+func RunSSHSetupWireguard(host *models.Host, configPath string) error {
+	remoteConfigPath := filepath.Join(constants.CloudNodeCLIConfigBasePath, wireguard.InterfaceName+".conf")
+	if err := host.MkdirAll(constants.CloudNodeCLIConfigBasePath, constants.SSHDirOpsTimeout); err != nil {
+		return err
+	}
+	if err := host.Upload(configPath, remoteConfigPath, constants.SSHFileOpsTimeout); err != nil {
+		return err
+	}
+	return RunOverSSH(
+		"Setup Wireguard",
+		host,
+		constants.SSHLongRunningScriptTimeout,
+		"shell/setupWireguard.sh",
+		scriptInputs{
+			WireguardConfigPath: host.ExpandHome(remoteConfigPath),
+			WireguardInterface:  wireguard.InterfaceName,
+		},
+	)
+}
+
+// RunSSHSnapshotDB archives the avalanchego DB of [host] to [snapshotName] in the DB snapshots
+// dir of the host, streaming the progress. The node is expected to be stopped
+func RunSSHSnapshotDB(host *models.Host, snapshotName string) (string, error) {
+	snapshotPath := host.ExpandHome(filepath.Join(constants.CloudNodeDBSnapshotsPath, snapshotName))
+	script, err := renderScript("Snapshot DB", "shell/snapshotDB.sh", scriptInputs{
+		NodeID:         host.NodeID,
+		SSHUser:        host.GetSSHUser(),
+		AvalancheGoDir: host.ExpandHome(constants.CloudNodeConfigBasePath),
+		DBSnapshotsDir: host.ExpandHome(constants.CloudNodeDBSnapshotsPath),
+		DBSnapshotPath: snapshotPath,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := host.StreamSSHCommand(script, nil, constants.SSHDBSnapshotTimeout); err != nil {
+		return "", err
+	}
+	return snapshotPath, nil
+}
+
+// RunSSHRestoreDBSnapshot replaces the avalanchego DB of [host] with the DB snapshot at
+// [snapshotPath] on the host, streaming the progress. The node is expected to be stopped
+func RunSSHRestoreDBSnapshot(host *models.Host, snapshotPath string) error {
+	script, err := renderScript("Restore DB Snapshot", "shell/restoreDBSnapshot.sh", scriptInputs{
+		NodeID:         host.NodeID,
+		AvalancheGoDir: host.ExpandHome(constants.CloudNodeConfigBasePath),
+		DBSnapshotPath: host.ExpandHome(snapshotPath),
+	})
+	if err != nil {
+		return err
+	}
+	return host.StreamSSHCommand(script, nil, constants.SSHDBSnapshotTimeout)
+}
+
+// RunSSHCheckDBSnapshotDiskSpace checks that [host] has enough free disk space to extract the DB
+// snapshot at [snapshotPath] on the host, as the current DB is only removed once it is extracted.
+// The snapshot is listed to get its extracted size, so it takes a while for large DBs
+func RunSSHCheckDBSnapshotDiskSpace(host *models.Host, snapshotPath string) error {
+	snapshotPath = host.ExpandHome(snapshotPath)
+	output, err := host.Command(
+		fmt.Sprintf(
+			"test -s %[1]s && tar -tvzf %[1]s | awk '{s+=$3} END {print s+0}' && df --output=avail -B1 %[2]s | tail -1",
+			utils.ShellQuote(snapshotPath),
+			utils.ShellQuote(host.ExpandHome(constants.CloudNodeConfigBasePath)),
+		),
+		nil,
+		constants.SSHDBSnapshotTimeout,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to check free disk space for db snapshot %s: %w: %s", snapshotPath, err, string(output))
+	}
+	return checkDBSnapshotDiskSpace(snapshotPath, output)
+}
+
+// checkDBSnapshotDiskSpace checks, given the extracted size of [snapshotPath] and the free disk space
+// in bytes on consecutive lines of [output], that the snapshot can be extracted
+func checkDBSnapshotDiskSpace(snapshotPath string, output []byte) error {
+	lines := strings.Fields(string(output))
+	if len(lines) != 2 {
+		return fmt.Errorf("unexpected disk space output %q", string(output))
+	}
+	needed, err := strconv.ParseInt(lines[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid db snapshot size %q: %w", lines[0], err)
+	}
+	available, err := strconv.ParseInt(lines[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid free disk space %q: %w", lines[1], err)
+	}
+	if needed > available {
+		return fmt.Errorf(
+			"not enough free disk space to restore db snapshot %s: %s needed, %s available",
+			snapshotPath,
+			utils.FormatBytes(needed),
+			utils.FormatBytes(available),
+		)
+	}
+	return nil
+}
+
+// RunSSHListDBSnapshots returns the file names in the DB snapshots dir of [host]
+func RunSSHListDBSnapshots(host *models.Host) ([]string, error) {
+	files, err := RunSSHListRemoteFiles(host, filepath.Join(constants.CloudNodeDBSnapshotsPath, "*"))
+	if err != nil {
+		return nil, err
+	}
+	return utils.Map(files, filepath.Base), nil
+}
+
+// RunSSHDownloadDBSnapshot downloads the DB snapshot at [snapshotPath] on [host] to [localPath],
+// printing the progress
+func RunSSHDownloadDBSnapshot(host *models.Host, snapshotPath string, localPath string) error {
+	total, err := remoteFileSize(host, snapshotPath)
+	if err != nil {
+		return err
+	}
+	stop := reportTransferProgress(host, "downloaded", total, func() (int64, error) {
+		info, err := os.Stat(localPath)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	})
+	defer stop()
+	return host.Download(snapshotPath, localPath, constants.SSHDBSnapshotTimeout)
+}
+
+// RunSSHUploadDBSnapshot uploads the DB snapshot at [localPath] to the DB snapshots dir of [host],
+// printing the progress. Returns the path of the snapshot on the host
+func RunSSHUploadDBSnapshot(host *models.Host, localPath string) (string, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return "", err
+	}
+	if err := host.MkdirAll(constants.CloudNodeDBSnapshotsPath, constants.SSHDirOpsTimeout); err != nil {
+		return "", err
+	}
+	snapshotPath := host.ExpandHome(filepath.Join(constants.CloudNodeDBSnapshotsPath, filepath.Base(localPath)))
+	stop := reportTransferProgress(host, "uploaded", info.Size(), func() (int64, error) {
+		return remoteFileSize(host, snapshotPath)
+	})
+	defer stop()
+	return snapshotPath, host.Upload(localPath, snapshotPath, constants.SSHDBSnapshotTimeout)
+}
+
+// remoteFileSize returns the size in bytes of [path] on [host]
+func remoteFileSize(host *models.Host, path string) (int64, error) {
+	output, err := host.Command(fmt.Sprintf("stat -c %%s %s", host.ExpandHome(path)), nil, constants.SSHScriptTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", err, string(output))
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+}
+
+// reportTransferProgress prints, every DBSnapshotProgressInterval, how much of [total] bytes were
+// transferred to or from [host], as given by [transferred], until the returned func is called
+func reportTransferProgress(host *models.Host, action string, total int64, transferred func() (int64, error)) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(constants.DBSnapshotProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if n, err := transferred(); err == nil && total > 0 {
+					ux.Logger.PrintToUser("%s %s %s of %s (%d%%)", host.NodeID, action, utils.FormatBytes(n), utils.FormatBytes(total), n*100/total)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
 func RunSSHUpsizeRootDisk(host *models.Host) error {
 	return RunOverSSH(
 		"Upsize Disk",
@@ -844,6 +1540,30 @@ func genesisFileExists(host *models.Host) bool {
 	return genesisFileExists
 }
 
+// getRemoteLogLevel returns the log level set on the remote node config, if any
+func getRemoteLogLevel(host *models.Host) string {
+	remoteAvagoConf, err := getAvalancheGoConfigData(host)
+	if err != nil {
+		return ""
+	}
+	logLevel, _ := remoteAvagoConf["log-level"].(string)
+	return logLevel
+}
+
+// getRemoteCChainDBConfig returns the C-Chain pruning and state sync settings of [host],
+// defaulting to the ones of new nodes if its C-Chain config can't be read
+func getRemoteCChainDBConfig(host *models.Host) remoteconfig.CChainDBConfig {
+	cChainConfigBytes, err := host.ReadFileBytes(remoteconfig.GetRemoteAvalancheCChainConfig(), constants.SSHFileOpsTimeout)
+	if err != nil {
+		return remoteconfig.DefaultCChainDBConfig()
+	}
+	var cChainConfig map[string]interface{}
+	if err := json.Unmarshal(cChainConfigBytes, &cChainConfig); err != nil {
+		return remoteconfig.DefaultCChainDBConfig()
+	}
+	return remoteconfig.GetCChainDBConfig(cChainConfig)
+}
+
 func getAvalancheGoConfigData(host *models.Host) (map[string]interface{}, error) {
 	// get remote node.json file
 	nodeJSONPath := filepath.Join(constants.CloudNodeConfigPath, constants.NodeFileName)
@@ -858,3 +1578,46 @@ func getAvalancheGoConfigData(host *models.Host) (map[string]interface{}, error)
 	}
 	return avagoConfig, nil
 }
+
+// RunSSHIsTrackingSubnet tells if the node config of [host] tracks [subnetID]
+func RunSSHIsTrackingSubnet(host *models.Host, subnetID string) (bool, error) {
+	avagoConfig, err := getAvalancheGoConfigData(host)
+	if err != nil {
+		return false, fmt.Errorf("failed to read node config: %w", err)
+	}
+	return remoteconfig.IsTrackingSubnet(avagoConfig, subnetID), nil
+}
+
+// RunSSHExportNodeConfig downloads the avalanchego configs of [host] into [outDir], keeping the layout
+// of the remote configs dir: node config, and if present, genesis and subnet and chain configs.
+// Returns the parsed node config and the exported files, relative to [outDir]
+func RunSSHExportNodeConfig(host *models.Host, outDir string) (map[string]interface{}, []string, error) {
+	avagoConfig, err := getAvalancheGoConfigData(host)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read node config: %w", err)
+	}
+	remoteFiles := []string{host.ExpandHome(remoteconfig.GetRemoteAvalancheNodeConfig())}
+	if genesisFileExists(host) {
+		remoteFiles = append(remoteFiles, host.ExpandHome(remoteconfig.GetRemoteAvalancheGenesis()))
+	}
+	for _, configDir := range []string{"chains", "subnets"} {
+		// not all nodes track subnets, so config dirs may be missing or empty
+		files, err := host.ListFiles(filepath.Join(constants.CloudNodeConfigPath, configDir))
+		if err != nil {
+			return nil, nil, err
+		}
+		remoteFiles = append(remoteFiles, files...)
+	}
+	exportedFiles := []string{}
+	for _, remoteFile := range remoteFiles {
+		relPath, err := filepath.Rel(host.ExpandHome(constants.CloudNodeConfigPath), remoteFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := host.Download(remoteFile, filepath.Join(outDir, relPath), constants.SSHFileOpsTimeout); err != nil {
+			return nil, nil, err
+		}
+		exportedFiles = append(exportedFiles, relPath)
+	}
+	return avagoConfig, exportedFiles, nil
+}
@@ -3,11 +3,26 @@
 package ssh
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/remoteconfig"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+	goSSH "golang.org/x/crypto/ssh"
 )
 
 func TestReplaceCustomVarDashboardValues(t *testing.T) {
@@ -97,3 +112,291 @@ func TestReplaceCustomVarDashboardValues(t *testing.T) {
 		t.Errorf("Expected content after replacement:\n%s\nGot:\n%s", expectedContent, string(modifiedContent))
 	}
 }
+
+func TestShouldRetryDownload(t *testing.T) {
+	require := require.New(t)
+	transientErr := errors.New("Process exited with status 1: wget: short read")
+	require.False(shouldRetryDownload(nil, 1, 3))
+	require.True(shouldRetryDownload(transientErr, 1, 3))
+	require.True(shouldRetryDownload(transientErr, 2, 3))
+	require.False(shouldRetryDownload(transientErr, 3, 3))
+	require.False(shouldRetryDownload(errors.New("wget: server returned error: HTTP/1.1 404 Not Found"), 1, 3))
+	require.False(shouldRetryDownload(errors.New("wget: server returned error: HTTP/1.1 403 Forbidden"), 1, 3))
+	require.False(shouldRetryDownload(fmt.Errorf("exit 1: %s", invalidSubnetEVMBinaryMarker), 1, 3))
+	require.False(shouldRetryDownload(&models.CommandError{ExitCode: 127}, 1, 3))
+	require.True(shouldRetryDownload(&models.CommandError{ExitCode: models.NoExitCode, Err: errors.New("EOF")}, 1, 3))
+}
+
+func TestParseRemoteFileList(t *testing.T) {
+	require := require.New(t)
+	require.Equal(
+		[]string{"/home/ubuntu/loadtest_a.txt", "/home/ubuntu/loadtest_b.txt"},
+		parseRemoteFileList("/home/ubuntu/loadtest_a.txt\n/home/ubuntu/loadtest_b.txt\n"),
+	)
+	require.Empty(parseRemoteFileList(""))
+	require.Empty(parseRemoteFileList("\n \n"))
+}
+
+func TestSetNodeConfigFileBootstrapPeers(t *testing.T) {
+	require := require.New(t)
+	nodeConfigPath := filepath.Join(t.TempDir(), constants.NodeFileName)
+	require.NoError(os.WriteFile(nodeConfigPath, []byte(`{"bootstrap-ids":"","bootstrap-ips":"","public-ip":"10.0.0.3"}`), constants.WriteReadReadPerms))
+	peers, err := remoteconfig.NewBootstrapPeers(
+		[]string{"NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg"},
+		[]string{"10.0.0.1:9651"},
+	)
+	require.NoError(err)
+	require.NoError(setNodeConfigFileBootstrapPeers(nodeConfigPath, peers))
+	nodeConfig, err := os.ReadFile(nodeConfigPath)
+	require.NoError(err)
+	require.JSONEq(`{"bootstrap-ids":"NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg","bootstrap-ips":"10.0.0.1:9651","public-ip":"10.0.0.3"}`, string(nodeConfig))
+}
+
+func TestRenderBuildCustomVMScript(t *testing.T) {
+	require := require.New(t)
+	inputs := scriptInputs{
+		CustomVMRepoDir:     "/tmp/repo",
+		CustomVMRepoURL:     "https://github.com/ava-labs/hypersdk",
+		CustomVMBranch:      "main",
+		CustomVMBuildScript: "scripts/build.sh",
+		VMBinaryPath:        "/home/ubuntu/.avalanchego/plugins/vmid",
+	}
+	script, err := renderScript("Build CustomVM", "shell/buildCustomVM.sh", inputs)
+	require.NoError(err)
+	require.Contains(script, "git remote add origin 'https://github.com/ava-labs/hypersdk'\ngit fetch --depth 1 origin 'main' -q\ngit checkout 'main'\n")
+	require.NotContains(script, "tags/")
+
+	inputs.CustomVMBranch = ""
+	inputs.CustomVMTag = "v0.0.16"
+	script, err = renderScript("Build CustomVM", "shell/buildCustomVM.sh", inputs)
+	require.NoError(err)
+	require.Contains(script, "git remote add origin 'https://github.com/ava-labs/hypersdk'\n"+
+		"git fetch --depth 1 origin 'refs/tags/v0.0.16:refs/tags/v0.0.16' -q\n"+
+		"git checkout -q 'tags/v0.0.16'\n"+
+		"chmod +x 'scripts/build.sh'\n")
+	require.Contains(script, "'./scripts/build.sh' '/home/ubuntu/.avalanchego/plugins/vmid'")
+
+	// values are passed as single shell words
+	inputs.CustomVMRepoURL = "https://example.com/repo; rm -rf ~"
+	script, err = renderScript("Build CustomVM", "shell/buildCustomVM.sh", inputs)
+	require.NoError(err)
+	require.Contains(script, "git remote add origin 'https://example.com/repo; rm -rf ~'\n")
+}
+
+func TestRenderDBSnapshotScripts(t *testing.T) {
+	require := require.New(t)
+	inputs := scriptInputs{
+		NodeID:         "aws_node_i-123",
+		AvalancheGoDir: "/home/ubuntu/.avalanchego",
+		DBSnapshotsDir: "/home/ubuntu/.avalanchego-snapshots",
+		DBSnapshotPath: "/home/ubuntu/.avalanchego-snapshots/db-20240305T120709Z.tar.gz",
+	}
+	script, err := renderScript("Snapshot DB", "shell/snapshotDB.sh", inputs)
+	require.NoError(err)
+	// the archive only gets its final name once complete
+	require.Contains(script, `--checkpoint-action="echo=aws_node_i-123 %T" -czf /home/ubuntu/.avalanchego-snapshots/db-20240305T120709Z.tar.gz.partial db`)
+	require.Contains(script, "mv /home/ubuntu/.avalanchego-snapshots/db-20240305T120709Z.tar.gz.partial /home/ubuntu/.avalanchego-snapshots/db-20240305T120709Z.tar.gz")
+
+	script, err = renderScript("Restore DB Snapshot", "shell/restoreDBSnapshot.sh", inputs)
+	require.NoError(err)
+	require.Contains(script, "-xzf /home/ubuntu/.avalanchego-snapshots/db-20240305T120709Z.tar.gz")
+	// the current db is removed only after the snapshot is extracted
+	require.Less(
+		strings.Index(script, "-xzf"),
+		strings.Index(script, "sudo rm -rf /home/ubuntu/.avalanchego/db\n"),
+	)
+}
+
+func TestCheckDBSnapshotDiskSpace(t *testing.T) {
+	require := require.New(t)
+	snapshotPath := "/home/ubuntu/.avalanchego-snapshots/db-20240305T120709Z.tar.gz"
+	require.NoError(checkDBSnapshotDiskSpace(snapshotPath, []byte("1073741824\n2147483648\n")))
+	require.NoError(checkDBSnapshotDiskSpace(snapshotPath, []byte("1073741824\n1073741824\n")))
+	require.EqualError(
+		checkDBSnapshotDiskSpace(snapshotPath, []byte("3221225472\n1073741824\n")),
+		"not enough free disk space to restore db snapshot "+snapshotPath+": 3.0GiB needed, 1.0GiB available",
+	)
+	require.ErrorContains(checkDBSnapshotDiskSpace(snapshotPath, []byte("1073741824\n")), "unexpected disk space output")
+	require.ErrorContains(checkDBSnapshotDiskSpace(snapshotPath, []byte("1073741824\nAvail\n")), "invalid free disk space")
+}
+
+func TestRenderSetupDockerServiceScript(t *testing.T) {
+	require := require.New(t)
+	host := &models.Host{SSHUser: "admin"}
+	script, err := renderScript("Setup Docker Service", "shell/setupDockerService.sh", scriptInputs{
+		SSHUser:     host.GetSSHUser(),
+		ComposeFile: host.ExpandHome(utils.GetRemoteComposeFile()),
+	})
+	require.NoError(err)
+	require.Contains(script, "User=admin\nGroup=admin\n")
+	require.Contains(script, "ExecStart=/usr/bin/docker compose -f /home/admin/.avalanche-cli/services/docker-compose.yml up")
+	require.NotContains(script, "ubuntu")
+}
+
+func TestRenderRunLoadTestScript(t *testing.T) {
+	require := require.New(t)
+	host := &models.Host{SSHUser: "ubuntu"}
+	require.Equal("loadtest_lt1.txt", LoadTestResultFileName("lt1", ""))
+	require.Equal("loadtest_lt1.cchain.txt", LoadTestResultFileName("lt1", "cchain"))
+
+	// single run
+	inputs := loadTestScriptInputs(host, "lt1", []LoadTestRun{{Command: "./simulator --rate=10"}}, false)
+	script, err := renderScript("Run Load Test", "shell/runLoadTest.sh", inputs)
+	require.NoError(err)
+	require.Contains(script, "./simulator --rate=10 > /home/ubuntu/.avalanchego/logs/loadtest_lt1.txt 2>&1\n")
+
+	runs := []LoadTestRun{
+		{Name: "cchain", Command: "./simulator --chain=C"},
+		{Name: "mysubnet", Command: "./simulator --chain=mysubnet"},
+	}
+	inputs = loadTestScriptInputs(host, "lt1", runs, false)
+	script, err = renderScript("Run Load Test", "shell/runLoadTest.sh", inputs)
+	require.NoError(err)
+	// runs are executed one after the other by a single background shell
+	require.Contains(script, "rm /home/ubuntu/.avalanchego/logs/loadtest_lt1.cchain.txt\n")
+	require.Contains(script, "rm /home/ubuntu/.avalanchego/logs/loadtest_lt1.mysubnet.txt\n")
+	require.Contains(script,
+		"./simulator --chain=C > /home/ubuntu/.avalanchego/logs/loadtest_lt1.cchain.txt 2>&1\n"+
+			"./simulator --chain=mysubnet > /home/ubuntu/.avalanchego/logs/loadtest_lt1.mysubnet.txt 2>&1\n"+
+			"LOADTEST\n",
+	)
+	require.Equal(1, strings.Count(script, "nohup"))
+
+	inputs = loadTestScriptInputs(host, "lt1", runs, true)
+	script, err = renderScript("Run Load Test", "shell/runLoadTest.sh", inputs)
+	require.NoError(err)
+	require.Contains(script, "nohup ./simulator --chain=C > /home/ubuntu/.avalanchego/logs/loadtest_lt1.cchain.txt 2>&1 &\n")
+	require.Contains(script, "nohup ./simulator --chain=mysubnet > /home/ubuntu/.avalanchego/logs/loadtest_lt1.mysubnet.txt 2>&1 &\n")
+	require.NotContains(script, "LOADTEST")
+}
+
+func TestPostWithRetries(t *testing.T) {
+	require := require.New(t)
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	response := []byte(`{"jsonrpc":"2.0","result":{"isBootstrapped":true},"id":1}`)
+	// newForwarder returns a forwarder that fails with [err] [failures] times, then succeeds
+	newForwarder := func(failures int, err error) (forwarder, *int) {
+		calls := 0
+		return func(string) ([]byte, error) {
+			calls++
+			if calls <= failures {
+				return nil, err
+			}
+			return response, nil
+		}, &calls
+	}
+
+	// the API is not up yet during bootstrap
+	for _, notUpErr := range []error{
+		fmt.Errorf("unable to port forward: %w", &goSSH.OpenChannelError{Reason: goSSH.ConnectionFailed}),
+		fmt.Errorf("unable to port forward: %w", syscall.ECONNREFUSED),
+		io.EOF,
+		errEmptyResponse,
+	} {
+		forward, calls := newForwarder(3, notUpErr)
+		output, err := postWithRetries(forward, "", 3, time.Minute, time.Millisecond)
+		require.NoError(err)
+		require.Equal(response, output)
+		require.Equal(4, *calls)
+	}
+
+	// retries are bounded by the retry count
+	forward, calls := newForwarder(3, io.EOF)
+	_, err := postWithRetries(forward, "", 2, time.Minute, time.Millisecond)
+	require.ErrorIs(err, io.EOF)
+	require.Equal(3, *calls)
+
+	// and by the timeout
+	forward, calls = newForwarder(10, io.EOF)
+	_, err = postWithRetries(forward, "", 10, 50*time.Millisecond, 20*time.Millisecond)
+	require.ErrorIs(err, io.EOF)
+	require.Less(*calls, 10)
+
+	// other errors are not retried
+	errAuth := errors.New("ssh: handshake failed")
+	forward, calls = newForwarder(1, errAuth)
+	_, err = postWithRetries(forward, "", 3, time.Minute, time.Millisecond)
+	require.ErrorIs(err, errAuth)
+	require.Equal(1, *calls)
+
+	// empty responses are retried, and invalid JSON is an error
+	for _, body := range []string{"", " \n"} {
+		attempts := 0
+		_, err = postWithRetries(func(string) ([]byte, error) {
+			attempts++
+			return []byte(body), nil
+		}, "", 2, time.Minute, time.Millisecond)
+		require.ErrorIs(err, errEmptyResponse)
+		require.Equal(3, attempts)
+	}
+	_, err = postWithRetries(func(string) ([]byte, error) {
+		return []byte("404 page not found"), nil
+	}, "", 2, time.Minute, time.Millisecond)
+	require.ErrorContains(err, "invalid JSON response")
+}
+
+func TestMergeNodeConfigs(t *testing.T) {
+	require := require.New(t)
+	nodeConfig := []byte(`{"log-level": "info", "public-ip": "10.0.0.1", "track-subnets": "a"}`)
+	merged, err := mergeNodeConfigs(
+		nodeConfig,
+		[]byte(`{"log-level": "debug", "proposervm-use-current-height": true}`),
+		[]byte(`{"log-level": "trace"}`),
+	)
+	require.NoError(err)
+	confMap := map[string]interface{}{}
+	require.NoError(json.Unmarshal(merged, &confMap))
+	require.Equal(map[string]interface{}{
+		"log-level":                     "trace",
+		"public-ip":                     "10.0.0.1",
+		"track-subnets":                 "a",
+		"proposervm-use-current-height": true,
+	}, confMap)
+
+	_, err = mergeNodeConfigs(nodeConfig, []byte("not json"))
+	require.ErrorContains(err, "subnet node config")
+}
+
+func TestCustomVMContainerBuildCmd(t *testing.T) {
+	require := require.New(t)
+	sc := models.Sidecar{
+		CustomVMRepoURL:     "https://github.com/org/vm.git",
+		CustomVMBranch:      "0123456789abcdef0123456789abcdef01234567",
+		CustomVMBuildScript: "scripts/build.sh",
+	}
+	require.Equal(
+		"mkdir -p /src && cd /src && git init -q && git remote add origin 'https://github.com/org/vm.git' && "+
+			"git fetch -q --depth 1 origin '0123456789abcdef0123456789abcdef01234567' && git checkout -q FETCH_HEAD && "+
+			"chmod +x 'scripts/build.sh' && './scripts/build.sh' /out/vm",
+		customVMContainerBuildCmd(sc),
+	)
+	// values are passed as single shell words
+	sc.CustomVMTag = "v1.0.0; rm -rf /"
+	require.Contains(customVMContainerBuildCmd(sc), "origin 'v1.0.0; rm -rf /' &&")
+}
+
+func TestGetNodePublicIP(t *testing.T) {
+	require := require.New(t)
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, nil, nil)
+	meshHost := &models.Host{NodeID: "aws_node_i-mesh", IP: "1.1.1.1"}
+	otherHost := &models.Host{NodeID: "aws_node_i-other", IP: "2.2.2.2"}
+
+	// without clusters config, nodes advertise their public IP
+	publicIP, err := getNodePublicIP(app, meshHost)
+	require.NoError(err)
+	require.Equal("1.1.1.1", publicIP)
+
+	require.NoError(app.WriteClustersConfigFile(&models.ClustersConfig{
+		Clusters: map[string]models.ClusterConfig{
+			"plain": {Nodes: []string{"i-other"}},
+			"mesh":  {Nodes: []string{"i-mesh"}, MeshIPs: map[string]string{"i-mesh": "10.99.0.1"}},
+		},
+	}))
+	publicIP, err = getNodePublicIP(app, meshHost)
+	require.NoError(err)
+	require.Equal("10.99.0.1", publicIP)
+	publicIP, err = getNodePublicIP(app, otherHost)
+	require.NoError(err)
+	require.Equal("2.2.2.2", publicIP)
+}
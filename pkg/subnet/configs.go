@@ -0,0 +1,37 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/subnet-evm/params"
+)
+
+// ValidateSubnetConfig checks that [subnetConfig] is a valid AvalancheGo subnet config. As AvalancheGo
+// does, the config is read on top of the default consensus parameters, so it only needs to set the
+// values that are changed
+func ValidateSubnetConfig(subnetConfig []byte) error {
+	config := subnets.Config{
+		ConsensusParameters: snowball.DefaultParameters,
+	}
+	if err := json.Unmarshal(subnetConfig, &config); err != nil {
+		return fmt.Errorf("invalid subnet config: %w", err)
+	}
+	if err := config.Valid(); err != nil {
+		return fmt.Errorf("invalid subnet config: %w", err)
+	}
+	return nil
+}
+
+// ValidateSubnetEVMUpgradeConfig checks that [upgradeConfig] is a valid Subnet-EVM upgrade.json
+func ValidateSubnetEVMUpgradeConfig(upgradeConfig []byte) error {
+	var config params.UpgradeConfig
+	if err := json.Unmarshal(upgradeConfig, &config); err != nil {
+		return fmt.Errorf("invalid upgrade config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSubnetConfig(t *testing.T) {
+	require := require.New(t)
+	require.NoError(ValidateSubnetConfig([]byte(`{}`)))
+	require.NoError(ValidateSubnetConfig([]byte(`{"proposerMinBlockDelay": 0, "consensusParameters": {"k": 25}}`)))
+	require.NoError(ValidateSubnetConfig([]byte(
+		`{"validatorOnly": true, "allowedNodes": ["NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg"]}`,
+	)))
+	require.ErrorContains(ValidateSubnetConfig([]byte(`{"validatorOnly": true`)), "invalid subnet config")
+	require.ErrorContains(ValidateSubnetConfig([]byte(`{"validatorOnly": "yes"}`)), "invalid subnet config")
+	// k can't be smaller than the quorum sizes of the default parameters
+	require.ErrorContains(ValidateSubnetConfig([]byte(`{"consensusParameters": {"k": 1}}`)), "invalid subnet config")
+	require.ErrorContains(
+		ValidateSubnetConfig([]byte(`{"allowedNodes": ["NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg"]}`)),
+		"allowedNodes can only be set when ValidatorOnly is true",
+	)
+}
+
+func TestValidateSubnetEVMUpgradeConfig(t *testing.T) {
+	require := require.New(t)
+	require.NoError(ValidateSubnetEVMUpgradeConfig([]byte(`{}`)))
+	require.NoError(ValidateSubnetEVMUpgradeConfig([]byte(
+		`{"precompileUpgrades": [{"feeManagerConfig": {"blockTimestamp": 1700000000, "adminAddresses": ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]}}]}`,
+	)))
+	require.ErrorContains(ValidateSubnetEVMUpgradeConfig([]byte(`{"precompileUpgrades": [`)), "invalid upgrade config")
+	require.ErrorContains(
+		ValidateSubnetEVMUpgradeConfig([]byte(`{"precompileUpgrades": [{"notAPrecompile": {"blockTimestamp": 1700000000}}]}`)),
+		"invalid upgrade config",
+	)
+}
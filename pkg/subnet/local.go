@@ -92,6 +92,8 @@ type TeleporterEsp struct {
 	MessengerDeployerAddressPath string
 	MessengerDeployerTxPath      string
 	RegistryBydecodePath         string
+	// RelayerLogLevel is the log level of the local relayer. If empty, the level of its config is kept
+	RelayerLogLevel string
 }
 
 type DeployInfo struct {
@@ -613,13 +615,17 @@ func (d *LocalDeployer) doDeploy(chain string, genesisPath string, teleporterEsp
 				return nil, err
 			}
 		}
+		ewoqSigner, err := teleporter.GetSigner(d.app, network, "")
+		if err != nil {
+			return nil, err
+		}
 		alreadyDeployed, cchainTeleporterMessengerAddress, cchainTeleporterRegistryAddress, err := teleporter.DeployAndFundRelayer(
 			d.app,
 			&td,
 			network,
 			"c-chain",
 			"C",
-			"",
+			ewoqSigner,
 		)
 		if err != nil {
 			return nil, err
@@ -632,6 +638,7 @@ func (d *LocalDeployer) doDeploy(chain string, genesisPath string, teleporterEsp
 			if err = teleporter.UpdateRelayerConfig(
 				relayerConfigPath,
 				d.app.GetAWMRelayerStorageDir(),
+				teleporterEsp.RelayerLogLevel,
 				relayerAddress,
 				relayerPrivateKey,
 				network,
@@ -663,13 +670,17 @@ func (d *LocalDeployer) doDeploy(chain string, genesisPath string, teleporterEsp
 				return nil, err
 			}
 		}
+		teleporterSigner, err := teleporter.GetSigner(d.app, network, teleporterKeyName)
+		if err != nil {
+			return nil, err
+		}
 		_, teleporterMessengerAddress, teleporterRegistryAddress, err = teleporter.DeployAndFundRelayer(
 			d.app,
 			&td,
 			network,
 			chain,
 			blockchainID,
-			teleporterKeyName,
+			teleporterSigner,
 		)
 		if err != nil {
 			return nil, err
@@ -677,6 +688,7 @@ func (d *LocalDeployer) doDeploy(chain string, genesisPath string, teleporterEsp
 		if err = teleporter.UpdateRelayerConfig(
 			relayerConfigPath,
 			d.app.GetAWMRelayerStorageDir(),
+			teleporterEsp.RelayerLogLevel,
 			relayerAddress,
 			relayerPrivateKey,
 			network,
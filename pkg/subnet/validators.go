@@ -0,0 +1,27 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+var ErrAlreadySubnetValidator = errors.New("node is already a validator of the subnet")
+
+// CheckNotSubnetValidator returns ErrAlreadySubnetValidator if [nodeID] is in [validators], the current
+// validator set of subnet [subnetID], so that the add validator tx is not built just to be rejected on-chain
+func CheckNotSubnetValidator(validators []platformvm.ClientPermissionlessValidator, subnetID ids.ID, nodeID ids.NodeID) error {
+	for _, validator := range validators {
+		if validator.NodeID == nodeID {
+			endTime := time.Unix(int64(validator.EndTime), 0).UTC().Format(constants.TimeParseLayout)
+			return fmt.Errorf("%w: %s already validates subnet %s until %s", ErrAlreadySubnetValidator, nodeID, subnetID, endTime)
+		}
+	}
+	return nil
+}
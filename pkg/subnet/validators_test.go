@@ -0,0 +1,30 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckNotSubnetValidator(t *testing.T) {
+	require := require.New(t)
+	subnetID := ids.GenerateTestID()
+	validatorNodeID := ids.GenerateTestNodeID()
+	validators := []platformvm.ClientPermissionlessValidator{
+		{ClientStaker: platformvm.ClientStaker{NodeID: ids.GenerateTestNodeID(), EndTime: 1893456000}},
+		{ClientStaker: platformvm.ClientStaker{NodeID: validatorNodeID, EndTime: 1893456000}},
+	}
+
+	err := CheckNotSubnetValidator(validators, subnetID, validatorNodeID)
+	require.ErrorIs(err, ErrAlreadySubnetValidator)
+	require.ErrorContains(err, validatorNodeID.String())
+	require.ErrorContains(err, subnetID.String())
+	require.ErrorContains(err, "2030-01-01 00:00:00")
+
+	require.NoError(CheckNotSubnetValidator(validators, subnetID, ids.GenerateTestNodeID()))
+	require.NoError(CheckNotSubnetValidator(nil, subnetID, validatorNodeID))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/awm-relayer/config"
 	offchainregistry "github.com/ava-labs/awm-relayer/messages/off-chain-registry"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -34,6 +35,12 @@ const (
 	localRelayerCheckTimeout  = 3 * time.Second
 )
 
+// RelayerLogFormatJSON is the only log format the relayer writes, as it always uses the JSON encoder
+const RelayerLogFormatJSON = "json"
+
+// relayerLogFormats are the log formats of the avalanchego logging library the relayer logs with
+var relayerLogFormats = []string{"auto", "plain", "colors", RelayerLogFormatJSON}
+
 var teleporterRelayerRequiredBalance = big.NewInt(0).Mul(big.NewInt(1e18), big.NewInt(500)) // 500 AVAX
 
 func GetRelayerKeyInfo(keyPath string) (string, string, error) {
@@ -62,6 +69,18 @@ func FundRelayer(
 	rpcURL string,
 	prefundedPrivateKey string,
 	teleporterRelayerAddress string,
+) error {
+	signer, err := evm.NewSignerFromPrivateKey(prefundedPrivateKey)
+	if err != nil {
+		return err
+	}
+	return FundRelayerWithSigner(rpcURL, signer, teleporterRelayerAddress)
+}
+
+func FundRelayerWithSigner(
+	rpcURL string,
+	signer *evm.Signer,
+	teleporterRelayerAddress string,
 ) error {
 	// get teleporter relayer balance
 	client, err := evm.GetClient(rpcURL)
@@ -74,9 +93,9 @@ func FundRelayer(
 	}
 	if teleporterRelayerBalance.Cmp(teleporterRelayerRequiredBalance) < 0 {
 		toFund := big.NewInt(0).Sub(teleporterRelayerRequiredBalance, teleporterRelayerBalance)
-		err := evm.FundAddress(
+		err := evm.FundAddressWithSigner(
 			client,
-			prefundedPrivateKey,
+			signer,
 			teleporterRelayerAddress,
 			toFund,
 		)
@@ -202,7 +221,7 @@ func saveRelayerRunFile(runFilePath string, pid int) error {
 }
 
 func InstallRelayer(binDir string) (string, error) {
-	downloader := application.NewDownloader()
+	downloader := application.NewDownloaderWithSource(binutils.GetReleaseSource())
 	version, err := downloader.GetLatestReleaseVersion(binutils.GetGithubLatestReleaseURL(constants.AvaLabsOrg, constants.AWMRelayerRepoName))
 	if err != nil {
 		return "", err
@@ -256,20 +275,49 @@ func getRelayerURL(version string) (string, error) {
 		return "", fmt.Errorf("OS not supported: %s", goos)
 	}
 	trimmedVersion := strings.TrimPrefix(version, "v")
-	return fmt.Sprintf(
-		"https://github.com/%s/%s/releases/download/%s/awm-relayer_%s_%s_%s.tar.gz",
+	return binutils.GetGithubReleaseAssetURL(
 		constants.AvaLabsOrg,
 		constants.AWMRelayerRepoName,
 		version,
-		trimmedVersion,
-		goos,
-		goarch,
+		fmt.Sprintf("awm-relayer_%s_%s_%s.tar.gz", trimmedVersion, goos, goarch),
 	), nil
 }
 
+// ValidateRelayerLogLevel checks that [logLevel] is one of the log levels accepted by the relayer
+func ValidateRelayerLogLevel(logLevel string) error {
+	if _, err := logging.ToLevel(logLevel); err != nil {
+		return fmt.Errorf("invalid relayer log level: %w. Accepted levels are %s", err, strings.Join(relayerLogLevels(), ", "))
+	}
+	return nil
+}
+
+func relayerLogLevels() []string {
+	levels := []logging.Level{logging.Off, logging.Fatal, logging.Error, logging.Warn, logging.Info, logging.Trace, logging.Debug, logging.Verbo}
+	return utils.Map(levels, func(l logging.Level) string { return l.LowerString() })
+}
+
+// ValidateRelayerLogFormat checks that the relayer can write its logs in [logFormat], one of the
+// avalanchego log formats, case insensitive. The relayer always writes JSON logs, so the others
+// are rejected
+func ValidateRelayerLogFormat(logFormat string) error {
+	logFormat = strings.ToLower(logFormat)
+	switch {
+	case logFormat == RelayerLogFormatJSON:
+		return nil
+	case slices.Contains(relayerLogFormats, logFormat):
+		return fmt.Errorf("relayer log format %q is not supported: the AWM relayer only writes %s logs", logFormat, RelayerLogFormatJSON)
+	default:
+		return fmt.Errorf("invalid relayer log format %q. Log formats are %s", logFormat, strings.Join(relayerLogFormats, ", "))
+	}
+}
+
+// UpdateRelayerConfig adds the given chain to the relayer config at [relayerConfigPath], creating
+// the config if needed. If [logLevel] is empty, the level of an existing config is kept, and
+// info is used for a new one
 func UpdateRelayerConfig(
 	relayerConfigPath string,
 	relayerStorageDir string,
+	logLevel string,
 	relayerAddress string,
 	relayerPrivateKey string,
 	network models.Network,
@@ -278,6 +326,11 @@ func UpdateRelayerConfig(
 	teleporterContractAddress string,
 	teleporterRegistryAddress string,
 ) error {
+	if logLevel != "" {
+		if err := ValidateRelayerLogLevel(logLevel); err != nil {
+			return err
+		}
+	}
 	awmRelayerConfig := config.Config{}
 	if utils.FileExists(relayerConfigPath) {
 		bs, err := os.ReadFile(relayerConfigPath)
@@ -294,6 +347,9 @@ func UpdateRelayerConfig(
 			network.Endpoint,
 		)
 	}
+	if logLevel != "" {
+		awmRelayerConfig.LogLevel = logLevel
+	}
 	host, port, _, err := utils.GetURIHostPortAndPath(network.Endpoint)
 	if err != nil {
 		return err
@@ -319,6 +375,35 @@ func UpdateRelayerConfig(
 	return nil
 }
 
+// RemoveChainFromRelayerConfig removes the source and destination entries of [blockchainID] from
+// the relayer config at [relayerConfigPath]. Returns false, leaving the config untouched, if the
+// chain is not in it
+func RemoveChainFromRelayerConfig(relayerConfigPath string, blockchainID string) (bool, error) {
+	bs, err := os.ReadFile(relayerConfigPath)
+	if err != nil {
+		return false, err
+	}
+	awmRelayerConfig, err := LoadRelayerConfig(bs)
+	if err != nil {
+		return false, err
+	}
+	sources := utils.Filter(awmRelayerConfig.SourceBlockchains, func(s *config.SourceBlockchain) bool { return s.BlockchainID != blockchainID })
+	destinations := utils.Filter(awmRelayerConfig.DestinationBlockchains, func(d *config.DestinationBlockchain) bool { return d.BlockchainID != blockchainID })
+	if len(sources) == len(awmRelayerConfig.SourceBlockchains) && len(destinations) == len(awmRelayerConfig.DestinationBlockchains) {
+		return false, nil
+	}
+	awmRelayerConfig.SourceBlockchains = sources
+	awmRelayerConfig.DestinationBlockchains = destinations
+	bs, err = json.MarshalIndent(awmRelayerConfig, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(relayerConfigPath, bs, constants.WriteReadReadPerms); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func createRelayerConfig(
 	logLevel string,
 	storageLocation string,
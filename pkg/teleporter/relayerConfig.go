@@ -0,0 +1,221 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/awm-relayer/config"
+)
+
+// RelayerConfigProblem is a problem found on a relayer config
+type RelayerConfigProblem struct {
+	Field   string // config field path, as source-blockchains[0].rpc-endpoint
+	Message string
+}
+
+func (p RelayerConfigProblem) String() string {
+	if p.Field == "" {
+		return p.Message
+	}
+	return fmt.Sprintf("%s: %s", p.Field, p.Message)
+}
+
+// LoadRelayerConfig parses relayer config JSON. Syntax and type errors are
+// reported together with their line and column, and unknown fields are rejected
+func LoadRelayerConfig(configBytes []byte) (config.Config, error) {
+	awmRelayerConfig := config.Config{}
+	decoder := json.NewDecoder(bytes.NewReader(configBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&awmRelayerConfig); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			// the syntax error offset is just after the invalid character
+			line, column := offsetToLineColumn(configBytes, syntaxErr.Offset-1)
+			return config.Config{}, fmt.Errorf("invalid relayer config JSON at line %d, column %d: %w", line, column, err)
+		case errors.As(err, &typeErr):
+			line, column := offsetToLineColumn(configBytes, typeErr.Offset)
+			return config.Config{}, fmt.Errorf("invalid value for field %s at line %d, column %d: expected %s, got %s", typeErr.Field, line, column, typeErr.Type, typeErr.Value)
+		default:
+			return config.Config{}, fmt.Errorf("invalid relayer config: %w", err)
+		}
+	}
+	return awmRelayerConfig, nil
+}
+
+// offsetToLineColumn converts a byte [offset] into [content] to 1-based line and column numbers
+func offsetToLineColumn(content []byte, offset int64) (int, int) {
+	offset = max(0, min(offset, int64(len(content))))
+	before := content[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// ValidateRelayerConfig checks the relayer config as the relayer does on startup,
+// but reports every problem found, each one associated to its config field
+func ValidateRelayerConfig(awmRelayerConfig config.Config) []RelayerConfigProblem {
+	problems := []RelayerConfigProblem{}
+	addProblem := func(field string, err error) {
+		problems = append(problems, RelayerConfigProblem{Field: field, Message: err.Error()})
+	}
+	// the relayer defaults to info when no level is set
+	if awmRelayerConfig.LogLevel != "" {
+		if err := ValidateRelayerLogLevel(awmRelayerConfig.LogLevel); err != nil {
+			addProblem("log-level", err)
+		}
+	}
+	for _, api := range []struct {
+		field     string
+		apiConfig *config.APIConfig
+	}{
+		{"p-chain-api", awmRelayerConfig.PChainAPI},
+		{"info-api", awmRelayerConfig.InfoAPI},
+	} {
+		field, apiConfig := api.field, api.apiConfig
+		if apiConfig == nil {
+			addProblem(field, errors.New("missing"))
+		} else if err := apiConfig.Validate(); err != nil {
+			addProblem(field, err)
+		}
+	}
+	if len(awmRelayerConfig.SourceBlockchains) == 0 {
+		addProblem("source-blockchains", errors.New("no source blockchains configured"))
+	}
+	if len(awmRelayerConfig.DestinationBlockchains) == 0 {
+		addProblem("destination-blockchains", errors.New("no destination blockchains configured"))
+	}
+	destinationBlockchainIDs := set.NewSet[string](len(awmRelayerConfig.DestinationBlockchains))
+	for i, destination := range awmRelayerConfig.DestinationBlockchains {
+		field := fmt.Sprintf("destination-blockchains[%d]", i)
+		if destination == nil {
+			addProblem(field, errors.New("empty destination blockchain"))
+			continue
+		}
+		if err := destination.Validate(); err != nil {
+			addProblem(field, err)
+		}
+		if destinationBlockchainIDs.Contains(destination.BlockchainID) {
+			addProblem(field, fmt.Errorf("duplicated destination blockchain ID %s", destination.BlockchainID))
+		}
+		destinationBlockchainIDs.Add(destination.BlockchainID)
+	}
+	sourceBlockchainIDs := set.NewSet[string](len(awmRelayerConfig.SourceBlockchains))
+	for i, source := range awmRelayerConfig.SourceBlockchains {
+		field := fmt.Sprintf("source-blockchains[%d]", i)
+		if source == nil {
+			addProblem(field, errors.New("empty source blockchain"))
+			continue
+		}
+		if err := source.Validate(&destinationBlockchainIDs); err != nil {
+			addProblem(field, err)
+		}
+		if sourceBlockchainIDs.Contains(source.BlockchainID) {
+			addProblem(field, fmt.Errorf("duplicated source blockchain ID %s", source.BlockchainID))
+		}
+		sourceBlockchainIDs.Add(source.BlockchainID)
+	}
+	for i, msg := range awmRelayerConfig.ManualWarpMessages {
+		if msg == nil {
+			continue
+		}
+		if err := msg.Validate(); err != nil {
+			addProblem(fmt.Sprintf("manual-warp-messages[%d]", i), err)
+		}
+	}
+	return problems
+}
+
+// CheckRelayerConfigEndpoints checks that every API and RPC endpoint of the relayer
+// config accepts connections within [timeout]
+func CheckRelayerConfigEndpoints(awmRelayerConfig config.Config, timeout time.Duration) []RelayerConfigProblem {
+	type endpoint struct {
+		field   string
+		baseURL string
+	}
+	endpoints := []endpoint{}
+	if awmRelayerConfig.PChainAPI != nil {
+		endpoints = append(endpoints, endpoint{"p-chain-api", awmRelayerConfig.PChainAPI.BaseURL})
+	}
+	if awmRelayerConfig.InfoAPI != nil {
+		endpoints = append(endpoints, endpoint{"info-api", awmRelayerConfig.InfoAPI.BaseURL})
+	}
+	for i, source := range awmRelayerConfig.SourceBlockchains {
+		if source != nil {
+			endpoints = append(endpoints, endpoint{fmt.Sprintf("source-blockchains[%d].rpc-endpoint", i), source.RPCEndpoint.BaseURL})
+		}
+	}
+	for i, destination := range awmRelayerConfig.DestinationBlockchains {
+		if destination != nil {
+			endpoints = append(endpoints, endpoint{fmt.Sprintf("destination-blockchains[%d].rpc-endpoint", i), destination.RPCEndpoint.BaseURL})
+		}
+	}
+	problems := []RelayerConfigProblem{}
+	// several endpoints usually share the same node, so each address is checked once
+	unreachable := map[string]error{}
+	checked := set.Set[string]{}
+	for _, e := range endpoints {
+		address, err := endpointAddress(e.baseURL)
+		if err != nil {
+			// invalid URLs are already reported by ValidateRelayerConfig
+			continue
+		}
+		if !checked.Contains(address) {
+			checked.Add(address)
+			conn, err := net.DialTimeout("tcp", address, timeout)
+			if err != nil {
+				unreachable[address] = err
+			} else {
+				_ = conn.Close()
+			}
+		}
+		if err, ok := unreachable[address]; ok {
+			problems = append(problems, RelayerConfigProblem{
+				Field:   e.field,
+				Message: fmt.Sprintf("endpoint %s is not reachable: %s", e.baseURL, err),
+			})
+		}
+	}
+	return problems
+}
+
+// endpointAddress returns the host:port address of [baseURL], using the scheme
+// default port if none is given
+func endpointAddress(baseURL string) (string, error) {
+	u, err := url.ParseRequestURI(baseURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+	defaultPorts := map[string]string{"http": "80", "ws": "80", "https": "443", "wss": "443"}
+	port, ok := defaultPorts[u.Scheme]
+	if !ok {
+		return "", fmt.Errorf("unsupported scheme %q at %s", u.Scheme, baseURL)
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// GetRelayerConfigProblems loads and validates the relayer config at [configBytes],
+// optionally checking its endpoints are reachable
+func GetRelayerConfigProblems(configBytes []byte, checkEndpoints bool, timeout time.Duration) ([]RelayerConfigProblem, error) {
+	awmRelayerConfig, err := LoadRelayerConfig(configBytes)
+	if err != nil {
+		return nil, err
+	}
+	problems := ValidateRelayerConfig(awmRelayerConfig)
+	if checkEndpoints {
+		problems = append(problems, CheckRelayerConfigEndpoints(awmRelayerConfig, timeout)...)
+	}
+	return problems, nil
+}
@@ -0,0 +1,251 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRelayerAddress = "0xA100fF48a37cab9f87c8b5Da933DA46ea1a5fb80"
+	// ewoq private key
+	testRelayerPrivateKey = "56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027"
+)
+
+func newTestRelayerConfig(t *testing.T, endpoint string) config.Config {
+	relayerConfigPath := filepath.Join(t.TempDir(), constants.AWMRelayerConfigFilename)
+	network := models.NewLocalNetwork()
+	network.Endpoint = endpoint
+	require.NoError(t, UpdateRelayerConfig(
+		relayerConfigPath,
+		t.TempDir(),
+		"",
+		testRelayerAddress,
+		testRelayerPrivateKey,
+		network,
+		ids.GenerateTestID().String(),
+		ids.GenerateTestID().String(),
+		"0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf",
+		"0x17aB05351fC94a1a67Bf3f56DdbB941aE6c63E25",
+	))
+	configBytes, err := os.ReadFile(relayerConfigPath)
+	require.NoError(t, err)
+	awmRelayerConfig, err := LoadRelayerConfig(configBytes)
+	require.NoError(t, err)
+	return awmRelayerConfig
+}
+
+func TestValidateRelayerConfig(t *testing.T) {
+	require := require.New(t)
+
+	awmRelayerConfig := newTestRelayerConfig(t, "http://127.0.0.1:9650")
+	require.Empty(ValidateRelayerConfig(awmRelayerConfig))
+
+	awmRelayerConfig.DestinationBlockchains[0].AccountPrivateKey = "not a key"
+	awmRelayerConfig.SourceBlockchains[0].RPCEndpoint.BaseURL = "not an url"
+	awmRelayerConfig.InfoAPI = nil
+	problems := ValidateRelayerConfig(awmRelayerConfig)
+	require.Len(problems, 3)
+	require.Equal("info-api", problems[0].Field)
+	require.Equal("destination-blockchains[0]", problems[1].Field)
+	require.Contains(problems[1].Message, "private key")
+	require.Equal("source-blockchains[0]", problems[2].Field)
+	require.Contains(problems[2].Message, "rpc-endpoint")
+
+	problems = ValidateRelayerConfig(config.Config{})
+	fields := []string{}
+	for _, problem := range problems {
+		fields = append(fields, problem.Field)
+	}
+	require.Equal([]string{"p-chain-api", "info-api", "source-blockchains", "destination-blockchains"}, fields)
+}
+
+func TestLoadRelayerConfig(t *testing.T) {
+	require := require.New(t)
+
+	awmRelayerConfig := newTestRelayerConfig(t, "http://127.0.0.1:9650")
+	configBytes, err := json.MarshalIndent(awmRelayerConfig, "", "  ")
+	require.NoError(err)
+	loadedConfig, err := LoadRelayerConfig(configBytes)
+	require.NoError(err)
+	require.Equal(awmRelayerConfig.SourceBlockchains[0].BlockchainID, loadedConfig.SourceBlockchains[0].BlockchainID)
+
+	_, err = LoadRelayerConfig([]byte("{\n  \"log-level\": \"info\",\n  \"metrics-port\": 9090,,\n}"))
+	require.ErrorContains(err, "line 3, column 24")
+
+	_, err = LoadRelayerConfig([]byte("{\n  \"log-level\": \"info\",\n  \"metrics-port\": \"9090\"\n}"))
+	require.ErrorContains(err, "invalid value for field metrics-port at line 3")
+
+	_, err = LoadRelayerConfig([]byte(`{"source-blockchain": []}`))
+	require.ErrorContains(err, `unknown field "source-blockchain"`)
+}
+
+func TestCheckRelayerConfigEndpoints(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+	awmRelayerConfig := newTestRelayerConfig(t, "http://"+listener.Addr().String())
+	require.Empty(CheckRelayerConfigEndpoints(awmRelayerConfig, time.Second))
+
+	// a closed port refuses connections
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	closedAddress := closedListener.Addr().String()
+	require.NoError(closedListener.Close())
+	awmRelayerConfig = newTestRelayerConfig(t, "http://"+closedAddress)
+	problems := CheckRelayerConfigEndpoints(awmRelayerConfig, time.Second)
+	require.Len(problems, 4)
+	require.Equal("p-chain-api", problems[0].Field)
+	require.Contains(problems[0].Message, "not reachable")
+	require.Equal("destination-blockchains[0].rpc-endpoint", problems[3].Field)
+}
+
+func TestEndpointAddress(t *testing.T) {
+	require := require.New(t)
+
+	for baseURL, expected := range map[string]string{
+		"http://127.0.0.1:9650/ext/bc/C/rpc": "127.0.0.1:9650",
+		"https://api.avax.network":           "api.avax.network:443",
+		"ws://localhost/ext/bc/C/ws":         "localhost:80",
+	} {
+		address, err := endpointAddress(baseURL)
+		require.NoError(err)
+		require.Equal(expected, address)
+	}
+	_, err := endpointAddress("ftp://localhost")
+	require.Error(err)
+}
+
+func TestUpdateRelayerConfigLogLevel(t *testing.T) {
+	require := require.New(t)
+	relayerConfigPath := filepath.Join(t.TempDir(), constants.AWMRelayerConfigFilename)
+	network := models.NewLocalNetwork()
+	updateConfig := func(logLevel string) (config.Config, error) {
+		if err := UpdateRelayerConfig(
+			relayerConfigPath,
+			t.TempDir(),
+			logLevel,
+			testRelayerAddress,
+			testRelayerPrivateKey,
+			network,
+			ids.GenerateTestID().String(),
+			ids.GenerateTestID().String(),
+			"0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf",
+			"0x17aB05351fC94a1a67Bf3f56DdbB941aE6c63E25",
+		); err != nil {
+			return config.Config{}, err
+		}
+		configBytes, err := os.ReadFile(relayerConfigPath)
+		require.NoError(err)
+		return LoadRelayerConfig(configBytes)
+	}
+
+	awmRelayerConfig, err := updateConfig("debug")
+	require.NoError(err)
+	require.Equal("debug", awmRelayerConfig.LogLevel)
+	require.Empty(ValidateRelayerConfig(awmRelayerConfig))
+
+	// an empty level keeps the current one
+	awmRelayerConfig, err = updateConfig("")
+	require.NoError(err)
+	require.Equal("debug", awmRelayerConfig.LogLevel)
+	require.Len(awmRelayerConfig.SourceBlockchains, 2)
+
+	awmRelayerConfig, err = updateConfig("warn")
+	require.NoError(err)
+	require.Equal("warn", awmRelayerConfig.LogLevel)
+
+	_, err = updateConfig("loud")
+	require.ErrorContains(err, "invalid relayer log level")
+	awmRelayerConfig, err = updateConfig("")
+	require.NoError(err)
+	require.Equal("warn", awmRelayerConfig.LogLevel)
+
+	awmRelayerConfig.LogLevel = "loud"
+	problems := ValidateRelayerConfig(awmRelayerConfig)
+	require.Len(problems, 1)
+	require.Equal("log-level", problems[0].Field)
+}
+
+func TestNewRelayerConfigLogLevel(t *testing.T) {
+	awmRelayerConfig := newTestRelayerConfig(t, "http://127.0.0.1:9650")
+	require.Equal(t, "info", awmRelayerConfig.LogLevel)
+}
+
+func TestValidateRelayerLogFormat(t *testing.T) {
+	require := require.New(t)
+	require.NoError(ValidateRelayerLogFormat(RelayerLogFormatJSON))
+	require.NoError(ValidateRelayerLogFormat("JSON"))
+	for _, logFormat := range []string{"plain", "colors", "auto"} {
+		require.ErrorContains(ValidateRelayerLogFormat(logFormat), "only writes json logs")
+	}
+	require.ErrorContains(ValidateRelayerLogFormat("text"), "invalid relayer log format \"text\". Log formats are auto, plain, colors, json")
+}
+
+func TestRemoveChainFromRelayerConfig(t *testing.T) {
+	require := require.New(t)
+	relayerConfigPath := filepath.Join(t.TempDir(), constants.AWMRelayerConfigFilename)
+	network := models.NewLocalNetwork()
+	blockchainIDs := []string{}
+	for i := 0; i < 3; i++ {
+		blockchainID := ids.GenerateTestID().String()
+		require.NoError(UpdateRelayerConfig(
+			relayerConfigPath,
+			t.TempDir(),
+			"",
+			testRelayerAddress,
+			testRelayerPrivateKey,
+			network,
+			ids.GenerateTestID().String(),
+			blockchainID,
+			"0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf",
+			"0x17aB05351fC94a1a67Bf3f56DdbB941aE6c63E25",
+		))
+		blockchainIDs = append(blockchainIDs, blockchainID)
+	}
+	loadConfig := func() config.Config {
+		configBytes, err := os.ReadFile(relayerConfigPath)
+		require.NoError(err)
+		awmRelayerConfig, err := LoadRelayerConfig(configBytes)
+		require.NoError(err)
+		return awmRelayerConfig
+	}
+
+	removed, err := RemoveChainFromRelayerConfig(relayerConfigPath, blockchainIDs[1])
+	require.NoError(err)
+	require.True(removed)
+	awmRelayerConfig := loadConfig()
+	require.Len(awmRelayerConfig.SourceBlockchains, 2)
+	require.Len(awmRelayerConfig.DestinationBlockchains, 2)
+	for i, blockchainID := range []string{blockchainIDs[0], blockchainIDs[2]} {
+		require.Equal(blockchainID, awmRelayerConfig.SourceBlockchains[i].BlockchainID)
+		require.Equal(blockchainID, awmRelayerConfig.DestinationBlockchains[i].BlockchainID)
+	}
+	require.Empty(ValidateRelayerConfig(awmRelayerConfig))
+
+	// removing a chain that is not in the config leaves it untouched
+	configBytes, err := os.ReadFile(relayerConfigPath)
+	require.NoError(err)
+	removed, err = RemoveChainFromRelayerConfig(relayerConfigPath, blockchainIDs[1])
+	require.NoError(err)
+	require.False(removed)
+	newConfigBytes, err := os.ReadFile(relayerConfigPath)
+	require.NoError(err)
+	require.Equal(configBytes, newConfigBytes)
+
+	_, err = RemoveChainFromRelayerConfig(filepath.Join(t.TempDir(), "missing.json"), blockchainIDs[0])
+	require.ErrorIs(err, os.ErrNotExist)
+}
@@ -21,11 +21,10 @@ import (
 )
 
 const (
-	releaseURL                     = "https://github.com/ava-labs/teleporter/releases/download/%s/"
-	messengerContractAddressURLFmt = releaseURL + "/TeleporterMessenger_Contract_Address_%s.txt"
-	messengerDeployerAddressURLFmt = releaseURL + "/TeleporterMessenger_Deployer_Address_%s.txt"
-	messengerDeployerTxURLFmt      = releaseURL + "/TeleporterMessenger_Deployment_Transaction_%s.txt"
-	registryBytecodeURLFmt         = releaseURL + "/TeleporterRegistry_Bytecode_%s.txt"
+	messengerContractAddressAssetFmt = "TeleporterMessenger_Contract_Address_%s.txt"
+	messengerDeployerAddressAssetFmt = "TeleporterMessenger_Deployer_Address_%s.txt"
+	messengerDeployerTxAssetFmt      = "TeleporterMessenger_Deployment_Transaction_%s.txt"
+	registryBytecodeAssetFmt         = "TeleporterRegistry_Bytecode_%s.txt"
 )
 
 var (
@@ -37,28 +36,21 @@ var (
 	// 600 AVAX
 )
 
+// getTeleporterURLs returns the URLs of the teleporter release assets of [version],
+// resolved against the configured release source
 func getTeleporterURLs(version string) (string, string, string, string) {
-	messengerContractAddressURL := fmt.Sprintf(
-		messengerContractAddressURLFmt,
-		version,
-		version,
-	)
-	messengerDeployerAddressURL := fmt.Sprintf(
-		messengerDeployerAddressURLFmt,
-		version,
-		version,
-	)
-	messengerDeployerTxURL := fmt.Sprintf(
-		messengerDeployerTxURLFmt,
-		version,
-		version,
-	)
-	registryBydecodeURL := fmt.Sprintf(
-		registryBytecodeURLFmt,
-		version,
-		version,
-	)
-	return messengerContractAddressURL, messengerDeployerAddressURL, messengerDeployerTxURL, registryBydecodeURL
+	assetURL := func(assetFmt string) string {
+		return binutils.GetGithubReleaseAssetURL(
+			constants.AvaLabsOrg,
+			constants.TeleporterRepoName,
+			version,
+			fmt.Sprintf(assetFmt, version),
+		)
+	}
+	return assetURL(messengerContractAddressAssetFmt),
+		assetURL(messengerDeployerAddressAssetFmt),
+		assetURL(messengerDeployerTxAssetFmt),
+		assetURL(registryBytecodeAssetFmt)
 }
 
 type Deployer struct {
@@ -239,7 +231,7 @@ func (t *Deployer) DownloadAssets(
 func (t *Deployer) Deploy(
 	subnetName string,
 	rpcURL string,
-	privateKey string,
+	signer *evm.Signer,
 	deployMessenger bool,
 	deployRegistry bool,
 ) (bool, string, string, error) {
@@ -253,12 +245,12 @@ func (t *Deployer) Deploy(
 		alreadyDeployed, messengerAddress, err = t.DeployMessenger(
 			subnetName,
 			rpcURL,
-			privateKey,
+			signer,
 		)
 	}
 	if err == nil && deployRegistry {
 		if !deployMessenger || !alreadyDeployed {
-			registryAddress, err = t.DeployRegistry(subnetName, rpcURL, privateKey)
+			registryAddress, err = t.DeployRegistry(subnetName, rpcURL, signer)
 		}
 	}
 	return alreadyDeployed, messengerAddress, registryAddress, err
@@ -267,7 +259,7 @@ func (t *Deployer) Deploy(
 func (t *Deployer) DeployMessenger(
 	subnetName string,
 	rpcURL string,
-	privateKey string,
+	signer *evm.Signer,
 ) (bool, string, error) {
 	if err := t.CheckAssets(); err != nil {
 		return false, "", err
@@ -294,9 +286,9 @@ func (t *Deployer) DeployMessenger(
 	if messengerDeployerBalance.Cmp(messengerDeployerRequiredBalance) < 0 {
 		toFund := big.NewInt(0).
 			Sub(messengerDeployerRequiredBalance, messengerDeployerBalance)
-		if err := evm.FundAddress(
+		if err := evm.FundAddressWithSigner(
 			client,
-			privateKey,
+			signer,
 			t.messengerDeployerAddress,
 			toFund,
 		); err != nil {
@@ -317,7 +309,7 @@ func (t *Deployer) DeployMessenger(
 func (t *Deployer) DeployRegistry(
 	subnetName string,
 	rpcURL string,
-	privateKey string,
+	signer *evm.Signer,
 ) (string, error) {
 	if err := t.CheckAssets(); err != nil {
 		return "", err
@@ -333,9 +325,9 @@ func (t *Deployer) DeployRegistry(
 			ProtocolAddress: messengerContractAddress,
 		},
 	}
-	registryAddress, err := contract.DeployContract(
+	registryAddress, err := contract.DeployContractWithSigner(
 		rpcURL,
-		privateKey,
+		signer,
 		[]byte(t.registryBydecode),
 		"([(uint256, address)])",
 		constructorInput,
@@ -387,23 +379,33 @@ func SetProposerVM(
 	return evm.SetupProposerVM(wsEndpoint, privKeyStr)
 }
 
+// GetSigner returns a signer for the CLI stored key [keyName], or for
+// the ewoq key if [keyName] is empty
+func GetSigner(
+	app *application.Avalanche,
+	network models.Network,
+	keyName string,
+) (*evm.Signer, error) {
+	privKeyStr, err := getPrivateKey(app, network, keyName)
+	if err != nil {
+		return nil, err
+	}
+	return evm.NewSignerFromPrivateKey(privKeyStr)
+}
+
 func DeployAndFundRelayer(
 	app *application.Avalanche,
 	td *Deployer,
 	network models.Network,
 	subnetName string,
 	blockchainID string,
-	fundedKeyName string,
+	signer *evm.Signer,
 ) (bool, string, string, error) {
-	privKeyStr, err := getPrivateKey(app, network, fundedKeyName)
-	if err != nil {
-		return false, "", "", err
-	}
 	endpoint := network.BlockchainEndpoint(blockchainID)
 	alreadyDeployed, messengerAddress, registryAddress, err := td.Deploy(
 		subnetName,
 		endpoint,
-		privKeyStr,
+		signer,
 		true,
 		true,
 	)
@@ -417,9 +419,9 @@ func DeployAndFundRelayer(
 			return false, "", "", err
 		}
 		// fund relayer
-		if err := FundRelayer(
+		if err := FundRelayerWithSigner(
 			endpoint,
-			privKeyStr,
+			signer,
 			relayerAddress,
 		); err != nil {
 			return false, "", "", err
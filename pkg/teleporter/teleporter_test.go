@@ -0,0 +1,37 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseURLsUseReleaseSource(t *testing.T) {
+	require := require.New(t)
+	mirrorSource, err := application.NewMirrorReleaseSource("https://mirror.example.com/releases")
+	require.NoError(err)
+	binutils.SetReleaseSource(mirrorSource)
+	defer binutils.SetReleaseSource(application.NewGithubReleaseSource())
+
+	messengerContractAddressURL, messengerDeployerAddressURL, messengerDeployerTxURL, registryBytecodeURL := getTeleporterURLs("v1.0.0")
+	require.Equal("https://mirror.example.com/releases/ava-labs/teleporter/v1.0.0/TeleporterMessenger_Contract_Address_v1.0.0.txt", messengerContractAddressURL)
+	require.Equal("https://mirror.example.com/releases/ava-labs/teleporter/v1.0.0/TeleporterMessenger_Deployer_Address_v1.0.0.txt", messengerDeployerAddressURL)
+	require.Equal("https://mirror.example.com/releases/ava-labs/teleporter/v1.0.0/TeleporterMessenger_Deployment_Transaction_v1.0.0.txt", messengerDeployerTxURL)
+	require.Equal("https://mirror.example.com/releases/ava-labs/teleporter/v1.0.0/TeleporterRegistry_Bytecode_v1.0.0.txt", registryBytecodeURL)
+
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		return
+	}
+	relayerURL, err := getRelayerURL("v1.3.0")
+	require.NoError(err)
+	require.Equal(
+		fmt.Sprintf("https://mirror.example.com/releases/ava-labs/awm-relayer/v1.3.0/awm-relayer_1.3.0_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH),
+		relayerURL,
+	)
+}
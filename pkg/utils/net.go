@@ -5,15 +5,69 @@ package utils
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
+const (
+	// IPVersionAuto detects the IPv4 address of the user, falling back to IPv6
+	IPVersionAuto = ""
+	IPVersion4    = "4"
+	IPVersion6    = "6"
+
+	ipv4LookupURL = "https://api.ipify.org?format=json"
+	ipv6LookupURL = "https://api6.ipify.org?format=json"
+)
+
+// ValidateIPVersion checks that [ipVersion] is one of the supported IP versions
+func ValidateIPVersion(ipVersion string) error {
+	switch ipVersion {
+	case IPVersionAuto, IPVersion4, IPVersion6:
+		return nil
+	}
+	return fmt.Errorf("invalid IP version %q: must be %s or %s", ipVersion, IPVersion4, IPVersion6)
+}
+
 // GetUserIPAddress retrieves the IP address of the user.
+// IPv4 is preferred, IPv6 is used if the user has no IPv4 connectivity
 func GetUserIPAddress() (string, error) {
-	resp, err := http.Get("https://api.ipify.org?format=json")
+	return GetUserIPAddressForVersion(IPVersionAuto)
+}
+
+// GetUserIPAddressForVersion retrieves the IP address of the user for the given [ipVersion]
+func GetUserIPAddressForVersion(ipVersion string) (string, error) {
+	switch ipVersion {
+	case IPVersion4:
+		return lookupUserIPAddress(ipv4LookupURL)
+	case IPVersion6:
+		ipAddress, err := lookupUserIPAddress(ipv6LookupURL)
+		if err != nil {
+			return "", err
+		}
+		if !IsIPv6(ipAddress) {
+			return "", fmt.Errorf("no IPv6 address found, got %s", ipAddress)
+		}
+		return ipAddress, nil
+	case IPVersionAuto:
+		ipAddress, err := lookupUserIPAddress(ipv4LookupURL)
+		if err == nil {
+			return ipAddress, nil
+		}
+		ipAddress, errV6 := lookupUserIPAddress(ipv6LookupURL)
+		if errV6 != nil {
+			return "", errors.Join(err, errV6)
+		}
+		return ipAddress, nil
+	}
+	return "", ValidateIPVersion(ipVersion)
+}
+
+func lookupUserIPAddress(lookupURL string) (string, error) {
+	resp, err := http.Get(lookupURL)
 	if err != nil {
 		return "", err
 	}
@@ -48,6 +102,23 @@ func IsValidIP(ipStr string) bool {
 	return net.ParseIP(ipStr) != nil
 }
 
+// IsIPv6 checks if [ipStr] is an IPv6 address or CIDR
+func IsIPv6(ipStr string) bool {
+	ip := net.ParseIP(strings.Split(ipStr, "/")[0])
+	return ip != nil && ip.To4() == nil
+}
+
+// IPToCIDR returns [ipStr] as a single host CIDR, adding the /32 or /128 netmask if missing
+func IPToCIDR(ipStr string) string {
+	if strings.Contains(ipStr, "/") {
+		return ipStr
+	}
+	if IsIPv6(ipStr) {
+		return ipStr + "/128"
+	}
+	return ipStr + "/32"
+}
+
 // IsValidURL checks if a URL is valid.
 func IsValidURL(urlString string) bool {
 	u, err := url.Parse(urlString)
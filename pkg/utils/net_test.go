@@ -0,0 +1,30 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPToCIDR(t *testing.T) {
+	require := require.New(t)
+	require.Equal("1.2.3.4/32", IPToCIDR("1.2.3.4"))
+	require.Equal("10.0.0.0/16", IPToCIDR("10.0.0.0/16"))
+	require.Equal("2001:db8::1/128", IPToCIDR("2001:db8::1"))
+	require.Equal("2001:db8::/32", IPToCIDR("2001:db8::/32"))
+	require.True(IsIPv6("::1"))
+	require.True(IsIPv6("2001:db8::/32"))
+	require.False(IsIPv6("1.2.3.4"))
+	require.False(IsIPv6("::ffff:1.2.3.4"))
+	require.False(IsIPv6("invalid"))
+}
+
+func TestValidateIPVersion(t *testing.T) {
+	require := require.New(t)
+	require.NoError(ValidateIPVersion(IPVersionAuto))
+	require.NoError(ValidateIPVersion(IPVersion4))
+	require.NoError(ValidateIPVersion(IPVersion6))
+	require.Error(ValidateIPVersion("5"))
+}
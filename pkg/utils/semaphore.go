@@ -0,0 +1,30 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import "runtime"
+
+// Semaphore bounds the number of goroutines concurrently running a section
+type Semaphore chan struct{}
+
+// NewSemaphore returns a semaphore allowing up to [n] concurrent holders
+func NewSemaphore(n int) Semaphore {
+	return make(Semaphore, n)
+}
+
+// Acquire blocks until a slot is available
+func (s Semaphore) Acquire() {
+	s <- struct{}{}
+}
+
+// Release frees a slot previously taken with Acquire
+func (s Semaphore) Release() {
+	<-s
+}
+
+// DefaultParallelism returns the default number of concurrent tasks to run
+// for [numTasks] tasks, bounded by twice the number of CPUs
+func DefaultParallelism(numTasks int) int {
+	return max(1, min(numTasks, runtime.NumCPU()*2))
+}
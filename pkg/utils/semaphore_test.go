@@ -0,0 +1,63 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingHost is a fake host whose setup tracks the number of setups running concurrently
+type countingHost struct {
+	running    *atomic.Int32
+	maxRunning *atomic.Int32
+}
+
+func (h countingHost) setup() {
+	running := h.running.Add(1)
+	for {
+		maxRunning := h.maxRunning.Load()
+		if running <= maxRunning || h.maxRunning.CompareAndSwap(maxRunning, running) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	h.running.Add(-1)
+}
+
+func TestSemaphore(t *testing.T) {
+	require := require.New(t)
+	const (
+		numHosts    = 20
+		parallelism = 3
+	)
+	running := atomic.Int32{}
+	maxRunning := atomic.Int32{}
+	sem := NewSemaphore(parallelism)
+	wg := sync.WaitGroup{}
+	for i := 0; i < numHosts; i++ {
+		wg.Add(1)
+		go func(host countingHost) {
+			defer wg.Done()
+			sem.Acquire()
+			defer sem.Release()
+			host.setup()
+		}(countingHost{running: &running, maxRunning: &maxRunning})
+	}
+	wg.Wait()
+	require.Equal(int32(parallelism), maxRunning.Load())
+	require.Zero(running.Load())
+}
+
+func TestDefaultParallelism(t *testing.T) {
+	require := require.New(t)
+	require.Equal(1, DefaultParallelism(0))
+	require.Equal(1, DefaultParallelism(1))
+	require.LessOrEqual(DefaultParallelism(1000), 1000)
+	require.GreaterOrEqual(DefaultParallelism(1000), 2)
+}
@@ -23,6 +23,38 @@ func GetSSHConnectionString(publicIP, certFilePath string) string {
 	return fmt.Sprintf("ssh %s %s@%s %s", constants.AnsibleSSHShellParams, constants.AnsibleSSHUser, publicIP, certFilePath)
 }
 
+// GetSSHConfigBlock returns an ssh config Host block to connect to [publicIP] as [hostAlias],
+// with the same options as GetSSHConnectionString
+func GetSSHConfigBlock(hostAlias, publicIP, certFilePath string) string {
+	block := fmt.Sprintf("Host %s\n  HostName %s\n  User %s\n", hostAlias, publicIP, constants.AnsibleSSHUser)
+	if certFilePath != "" {
+		block += fmt.Sprintf("  IdentityFile %s\n", certFilePath)
+	}
+	return block + "  IdentitiesOnly yes\n  StrictHostKeyChecking no\n"
+}
+
+// UpdateSSHConfigSection returns ssh config [config] with the section [sectionName] set to [blocks].
+// The section is delimited by marker comments, so it is replaced in place if it was already
+// written, and appended at the end of [config] otherwise
+func UpdateSSHConfigSection(config, sectionName string, blocks []string) string {
+	beginMarker := fmt.Sprintf("# BEGIN %s", sectionName)
+	endMarker := fmt.Sprintf("# END %s", sectionName)
+	section := beginMarker + "\n" + strings.Join(blocks, "\n") + endMarker + "\n"
+	if begin := strings.Index(config, beginMarker+"\n"); begin != -1 {
+		if end := strings.Index(config[begin:], endMarker+"\n"); end != -1 {
+			end += begin + len(endMarker) + 1
+			return config[:begin] + section + config[end:]
+		}
+	}
+	if config != "" && !strings.HasSuffix(config, "\n") {
+		config += "\n"
+	}
+	if config != "" {
+		config += "\n"
+	}
+	return config + section
+}
+
 // GetSCPTargetPath returns the target path for the given source path and target directory.
 func GetSCPTargetPath(ip, path string) string {
 	if ip == "" {
@@ -72,6 +104,32 @@ func SplitSCPPath(path string) (string, string) {
 	return parts[0], parts[1]
 }
 
+var remoteGlobRegex = regexp.MustCompile(`^[A-Za-z0-9_.,/*?~+\-\[\]]+$`)
+
+// ValidateRemoteGlob checks that [pattern] is a path, possibly with shell globs, that
+// can be expanded on a remote shell without quoting
+func ValidateRemoteGlob(pattern string) error {
+	if !remoteGlobRegex.MatchString(pattern) {
+		return fmt.Errorf("invalid remote path %q: only letters, digits, path characters and the globs * ? [] are allowed", pattern)
+	}
+	if !strings.HasPrefix(pattern, "/") && !strings.HasPrefix(pattern, "~/") {
+		return fmt.Errorf("invalid remote path %q: must be absolute or relative to ~/", pattern)
+	}
+	return nil
+}
+
+// RemoteGlobBaseDir returns the directory of [pattern] previous to the first
+// path element that contains a glob
+func RemoteGlobBaseDir(pattern string) string {
+	elements := strings.Split(pattern, "/")
+	for i, element := range elements {
+		if strings.ContainsAny(element, "*?[") {
+			return strings.Join(elements[:i], "/") + "/"
+		}
+	}
+	return strings.Join(elements[:len(elements)-1], "/") + "/"
+}
+
 // CombineSCPPath combines the given host and path into a single item for scp.
 func CombineSCPPath(host, path string) string {
 	if host != "" {
@@ -80,6 +138,36 @@ func CombineSCPPath(host, path string) string {
 	return path
 }
 
+// SSHKeyAuth is how the key pair of created nodes is made available to ssh connections
+type SSHKeyAuth int
+
+const (
+	// SSHKeyAuthFile passes the key file path directly to the ssh dialer
+	SSHKeyAuthFile SSHKeyAuth = iota
+	// SSHKeyAuthAgent uses a key already loaded in the ssh-agent
+	SSHKeyAuthAgent
+	// SSHKeyAuthAddToAgent adds the key file to the ssh-agent with ssh-add
+	SSHKeyAuthAddToAgent
+)
+
+// SelectSSHKeyAuth selects how to authenticate ssh connections to created nodes.
+// The ssh-agent is only modified if [useSSHAgent] is set, [noSSHAgent] is not set, the
+// agent is available and there is a key file to add. Otherwise the key file is used
+// directly, unless there is no key file, in which case the key is expected to be an
+// ssh-agent identity
+func SelectSSHKeyAuth(useSSHAgent, noSSHAgent, agentAvailable, keyFileExists bool) SSHKeyAuth {
+	switch {
+	case !useSSHAgent:
+		return SSHKeyAuthFile
+	case !keyFileExists:
+		return SSHKeyAuthAgent
+	case noSSHAgent || !agentAvailable:
+		return SSHKeyAuthFile
+	default:
+		return SSHKeyAuthAddToAgent
+	}
+}
+
 // isSSHAgentAvailable checks if the SSH agent is available.
 func IsSSHAgentAvailable() bool {
 	return os.Getenv("SSH_AUTH_SOCK") != ""
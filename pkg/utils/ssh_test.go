@@ -2,7 +2,12 @@
 // See the file LICENSE for licensing terms.
 package utils
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
 
 func TestIsSSHKey(t *testing.T) {
 	testCases := []struct {
@@ -41,3 +46,108 @@ func TestIsSSHKey(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectSSHKeyAuth(t *testing.T) {
+	testCases := []struct {
+		name           string
+		useSSHAgent    bool
+		noSSHAgent     bool
+		agentAvailable bool
+		keyFileExists  bool
+		expected       SSHKeyAuth
+	}{
+		{"no agent requested", false, false, true, true, SSHKeyAuthFile},
+		{"agent identity without key file", true, false, true, false, SSHKeyAuthAgent},
+		{"agent identity with no-ssh-agent", true, true, true, false, SSHKeyAuthAgent},
+		{"key file added to agent", true, false, true, true, SSHKeyAuthAddToAgent},
+		{"key file with no-ssh-agent", true, true, true, true, SSHKeyAuthFile},
+		{"key file with unavailable agent", true, false, false, true, SSHKeyAuthFile},
+		{"no-ssh-agent without agent requested", false, true, false, true, SSHKeyAuthFile},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := SelectSSHKeyAuth(tc.useSSHAgent, tc.noSSHAgent, tc.agentAvailable, tc.keyFileExists)
+			if result != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestValidateRemoteGlob(t *testing.T) {
+	for _, pattern := range []string{
+		"/home/ubuntu/.avalanchego/logs/loadtest_*.txt",
+		"~/logs/main.log",
+		"/var/log/[ab]?/*.log",
+	} {
+		if err := ValidateRemoteGlob(pattern); err != nil {
+			t.Errorf("expected %s to be valid, got %s", pattern, err)
+		}
+	}
+	for _, pattern := range []string{
+		"",
+		"logs/*.txt",
+		"/tmp/*.txt; rm -rf ~",
+		"/tmp/$(whoami)",
+		"/tmp/a b",
+	} {
+		if err := ValidateRemoteGlob(pattern); err == nil {
+			t.Errorf("expected %q to be invalid", pattern)
+		}
+	}
+}
+
+func TestRemoteGlobBaseDir(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		expected string
+	}{
+		{"/home/ubuntu/.avalanchego/logs/loadtest_*.txt", "/home/ubuntu/.avalanchego/logs/"},
+		{"/var/log/*/main.log", "/var/log/"},
+		{"/var/log/node?/*.log", "/var/log/"},
+		{"/tmp/file.txt", "/tmp/"},
+		{"/*.txt", "/"},
+	}
+	for _, tc := range testCases {
+		if result := RemoteGlobBaseDir(tc.pattern); result != tc.expected {
+			t.Errorf("expected %s for %s, got %s", tc.expected, tc.pattern, result)
+		}
+	}
+}
+
+func TestGetSSHConfigBlock(t *testing.T) {
+	require := require.New(t)
+	require.Equal(`Host i-123
+  HostName 1.2.3.4
+  User ubuntu
+  IdentityFile /home/user/.ssh/key.pem
+  IdentitiesOnly yes
+  StrictHostKeyChecking no
+`, GetSSHConfigBlock("i-123", "1.2.3.4", "/home/user/.ssh/key.pem"))
+	require.Equal(`Host i-123
+  HostName 1.2.3.4
+  User ubuntu
+  IdentitiesOnly yes
+  StrictHostKeyChecking no
+`, GetSSHConfigBlock("i-123", "1.2.3.4", ""))
+}
+
+func TestUpdateSSHConfigSection(t *testing.T) {
+	require := require.New(t)
+	blocks := []string{"Host a\n  HostName 1.1.1.1\n", "Host b\n  HostName 2.2.2.2\n"}
+	section := "# BEGIN cluster c\nHost a\n  HostName 1.1.1.1\n\nHost b\n  HostName 2.2.2.2\n# END cluster c\n"
+
+	// appended to an empty config
+	config := UpdateSSHConfigSection("", "cluster c", blocks)
+	require.Equal(section, config)
+	// appended after the existing entries, separated by an empty line
+	config = UpdateSSHConfigSection("Host other\n  HostName 9.9.9.9", "cluster c", blocks)
+	require.Equal("Host other\n  HostName 9.9.9.9\n\n"+section, config)
+	// replaced in place, keeping the entries around it
+	config = UpdateSSHConfigSection(config+"\nHost last\n", "cluster c", blocks[1:])
+	require.Equal("Host other\n  HostName 9.9.9.9\n\n# BEGIN cluster c\nHost b\n  HostName 2.2.2.2\n# END cluster c\n\nHost last\n", config)
+	// other sections are left alone
+	config = UpdateSSHConfigSection(config, "cluster d", blocks[:1])
+	require.Contains(config, "# BEGIN cluster c\nHost b\n")
+	require.True(strings.HasSuffix(config, "\n\n# BEGIN cluster d\nHost a\n  HostName 1.1.1.1\n# END cluster d\n"))
+}
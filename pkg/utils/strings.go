@@ -51,6 +51,11 @@ func AddSingleQuotes(s []string) []string {
 	})
 }
 
+// ShellQuote quotes [s] as a single POSIX shell word, so that it is passed as is to the command
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 // Cleans up a string by trimming \r and \n characters.
 func CleanupString(s string) string {
 	return strings.Trim(strings.Trim(s, "\n"), "\r")
@@ -60,3 +65,73 @@ func CleanupString(s string) string {
 func CleanupStrings(s []string) []string {
 	return Map(s, CleanupString)
 }
+
+// EditDistance returns the Levenshtein distance between [a] and [b]
+func EditDistance(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// ClosestMatch returns the element of [candidates] with the smallest edit distance to [s],
+// if that distance is at most [maxDistance]
+func ClosestMatch(s string, candidates []string, maxDistance int) (string, bool) {
+	closest := ""
+	closestDistance := maxDistance + 1
+	for _, candidate := range candidates {
+		if distance := EditDistance(s, candidate); distance < closestDistance {
+			closest = candidate
+			closestDistance = distance
+		}
+	}
+	return closest, closestDistance <= maxDistance
+}
+
+// FormatBytes formats [n] bytes with binary units, eg. 1.5GiB
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// ParseKeyValues parses [entries] given as key=value into a map. Keys must be non empty and
+// unique, values can be empty and contain '='
+func ParseKeyValues(entries []string) (map[string]string, error) {
+	keyValues := map[string]string{}
+	for _, entry := range entries {
+		key, value, found := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		switch {
+		case !found:
+			return nil, fmt.Errorf("invalid entry %q: expected key=value", entry)
+		case key == "":
+			return nil, fmt.Errorf("invalid entry %q: key must not be empty", entry)
+		}
+		if _, ok := keyValues[key]; ok {
+			return nil, fmt.Errorf("key %s given more than once", key)
+		}
+		keyValues[key] = strings.TrimSpace(value)
+	}
+	return keyValues, nil
+}
@@ -26,3 +26,84 @@ func TestSpitStringWithQuotes(t *testing.T) {
 		t.Errorf("Expected %v, but got %v", expected1, result1)
 	}
 }
+
+func TestEditDistance(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"us-east-1", "us-east-1", 0},
+		{"us-est-1", "us-east-1", 1},
+		{"us-east-1", "us-east-2", 1},
+		{"eu-wset-1", "eu-west-1", 2},
+		{"kitten", "sitting", 3},
+	} {
+		if distance := EditDistance(tc.a, tc.b); distance != tc.expected {
+			t.Errorf("EditDistance(%q, %q) = %d, expected %d", tc.a, tc.b, distance, tc.expected)
+		}
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	candidates := []string{"us-east-1", "us-west-1", "eu-west-1"}
+	if closest, ok := ClosestMatch("us-est-1", candidates, 2); !ok || closest != "us-east-1" {
+		t.Errorf("ClosestMatch(us-est-1) = %q %v, expected us-east-1", closest, ok)
+	}
+	if closest, ok := ClosestMatch("mars-north-1", candidates, 2); ok {
+		t.Errorf("ClosestMatch(mars-north-1) = %q, expected no match", closest)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for _, tc := range []struct {
+		n        int64
+		expected string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{1024, "1.0KiB"},
+		{1536, "1.5KiB"},
+		{5 * 1024 * 1024, "5.0MiB"},
+		{3*1024*1024*1024 + 512*1024*1024, "3.5GiB"},
+		{2 * 1024 * 1024 * 1024 * 1024, "2.0TiB"},
+	} {
+		if formatted := FormatBytes(tc.n); formatted != tc.expected {
+			t.Errorf("FormatBytes(%d) = %q, expected %q", tc.n, formatted, tc.expected)
+		}
+	}
+}
+
+func TestParseKeyValues(t *testing.T) {
+	keyValues, err := ParseKeyValues([]string{"team=infra", " cost-center = 1234 ", "empty=", "url=a=b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string]string{"team": "infra", "cost-center": "1234", "empty": "", "url": "a=b"}
+	if !reflect.DeepEqual(keyValues, expected) {
+		t.Errorf("ParseKeyValues = %v, expected %v", keyValues, expected)
+	}
+	for _, entries := range [][]string{{"team"}, {"=infra"}, {"team=a", "team=b"}} {
+		if _, err := ParseKeyValues(entries); err == nil {
+			t.Errorf("ParseKeyValues(%v) expected to fail", entries)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	for _, tc := range []struct {
+		s        string
+		expected string
+	}{
+		{"", "''"},
+		{"v1.0.0", "'v1.0.0'"},
+		{"a b; rm -rf /", "'a b; rm -rf /'"},
+		{"it's", `'it'\''s'`},
+		{"$(id)", "'$(id)'"},
+	} {
+		if quoted := ShellQuote(tc.s); quoted != tc.expected {
+			t.Errorf("ShellQuote(%q) = %s, expected %s", tc.s, quoted, tc.expected)
+		}
+	}
+}
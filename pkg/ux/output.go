@@ -3,8 +3,10 @@
 package ux
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"time"
 
@@ -17,9 +19,26 @@ import (
 
 var Logger *UserLog
 
+const (
+	levelInfo  = "info"
+	levelError = "error"
+)
+
+// ansiEscapeRegex matches the terminal color codes of the messages printed to the user
+var ansiEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+
 type UserLog struct {
 	log    logging.Logger
 	Writer io.Writer
+	// jsonOutput prints the messages to the user as JSON lines instead of text
+	jsonOutput bool
+}
+
+// userEvent is a message printed to the user in JSON output mode
+type userEvent struct {
+	Level  string                 `json:"level"`
+	Msg    string                 `json:"msg"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
 }
 
 func NewUserLog(log logging.Logger, userwriter io.Writer) {
@@ -31,10 +50,45 @@ func NewUserLog(log logging.Logger, userwriter io.Writer) {
 	}
 }
 
+// SetJSONOutput sets if the messages to the user are printed as JSON lines, with
+// level, msg and fields keys, instead of text. Prompts and spinners are not
+// affected, and keep being shown on the terminal
+func (ul *UserLog) SetJSONOutput(jsonOutput bool) {
+	ul.jsonOutput = jsonOutput
+}
+
+// JSONOutput tells if the messages to the user are printed as JSON lines
+func (ul *UserLog) JSONOutput() bool {
+	return ul != nil && ul.jsonOutput
+}
+
 // PrintToUser prints msg directly on the screen, but also to log file
 func (ul *UserLog) PrintToUser(msg string, args ...interface{}) {
-	fmt.Print("\r\033[K") // Clear the line from the cursor position to the end
-	ul.print(fmt.Sprintf(msg, args...) + "\n")
+	ul.printEvent(levelInfo, fmt.Sprintf(msg, args...), nil)
+}
+
+// ErrorToUser prints an error msg directly on the screen, but also to log file
+func (ul *UserLog) ErrorToUser(msg string, args ...interface{}) {
+	ul.printEvent(levelError, fmt.Sprintf(msg, args...), nil)
+}
+
+// printEvent prints [msg] to the user, as text or as a JSON line with [level] and [fields]
+func (ul *UserLog) printEvent(level string, msg string, fields map[string]interface{}) {
+	if !ul.JSONOutput() {
+		fmt.Print("\r\033[K") // Clear the line from the cursor position to the end
+		ul.print(msg + "\n")
+		return
+	}
+	msg = strings.TrimSpace(ansiEscapeRegex.ReplaceAllString(msg, ""))
+	if msg == "" {
+		// blank lines only space out the text output
+		return
+	}
+	eventBytes, err := json.Marshal(userEvent{Level: level, Msg: msg, Fields: fields})
+	if err != nil {
+		eventBytes, _ = json.Marshal(userEvent{Level: level, Msg: msg})
+	}
+	ul.print(string(eventBytes) + "\n")
 }
 
 func (ul *UserLog) print(msg string) {
@@ -51,6 +105,14 @@ func (ul *UserLog) Info(msg string, args ...interface{}) {
 	ul.log.Info(fmt.Sprintf(msg, args...) + "\n")
 }
 
+// Debug prints to the log file, at debug level
+func (ul *UserLog) Debug(msg string, args ...interface{}) {
+	if ul == nil {
+		return
+	}
+	ul.log.Debug(fmt.Sprintf(msg, args...))
+}
+
 // Error prints to the log file
 func (ul *UserLog) Error(msg string, args ...interface{}) {
 	ul.log.Error(fmt.Sprintf(msg, args...))
@@ -58,18 +120,29 @@ func (ul *UserLog) Error(msg string, args ...interface{}) {
 
 // GreenCheckmarkToUser prints a green checkmark to the user before the message
 func (ul *UserLog) GreenCheckmarkToUser(msg string, args ...interface{}) {
+	if ul.JSONOutput() {
+		ul.printEvent(levelInfo, fmt.Sprintf(msg, args...), map[string]interface{}{"status": "success"})
+		return
+	}
 	checkmark := "\u2713" // Unicode for checkmark symbol
 	green := color.New(color.FgHiGreen).SprintFunc()
 	ul.PrintToUser(green(checkmark)+" "+msg, args...)
 }
 
 func (ul *UserLog) RedXToUser(msg string, args ...interface{}) {
+	if ul.JSONOutput() {
+		ul.printEvent(levelError, fmt.Sprintf(msg, args...), map[string]interface{}{"status": "failure"})
+		return
+	}
 	xmark := "\u2717" // Unicode for X symbol
 	red := color.New(color.FgHiRed).SprintFunc()
 	ul.PrintToUser(red(xmark)+" "+msg, args...)
 }
 
 func (ul *UserLog) PrintLineSeparator() {
+	if ul.JSONOutput() {
+		return
+	}
 	ul.PrintToUser("==============================================")
 }
 
@@ -0,0 +1,54 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ux
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserLogJSONOutput(t *testing.T) {
+	require := require.New(t)
+	buf := &bytes.Buffer{}
+	ul := &UserLog{log: logging.NoLog{}, Writer: buf}
+	ul.SetJSONOutput(true)
+	require.True(ul.JSONOutput())
+
+	ul.PrintToUser("Node %s is %s", "i-0abc", logging.Green.Wrap("healthy"))
+	ul.PrintToUser(" ")
+	ul.PrintLineSeparator()
+	ul.GreenCheckmarkToUser("Cluster %s created", "myCluster")
+	ul.RedXToUser("Failed to destroy node %s", "i-0abc")
+	ul.ErrorToUser("Error: %s", "quota exceeded")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	// blank lines and separators are not printed
+	require.Equal([]string{
+		`{"level":"info","msg":"Node i-0abc is healthy"}`,
+		`{"level":"info","msg":"Cluster myCluster created","fields":{"status":"success"}}`,
+		`{"level":"error","msg":"Failed to destroy node i-0abc","fields":{"status":"failure"}}`,
+		`{"level":"error","msg":"Error: quota exceeded"}`,
+	}, lines)
+	for _, line := range lines {
+		event := userEvent{}
+		require.NoError(json.Unmarshal([]byte(line), &event))
+	}
+}
+
+func TestUserLogTextOutput(t *testing.T) {
+	require := require.New(t)
+	buf := &bytes.Buffer{}
+	ul := &UserLog{log: logging.NoLog{}, Writer: buf}
+	require.False(ul.JSONOutput())
+	ul.PrintToUser("Node %s is up", "i-0abc")
+	ul.ErrorToUser("Error: %s", "quota exceeded")
+	require.Equal("Node i-0abc is up\nError: quota exceeded\n", buf.String())
+
+	var nilLog *UserLog
+	require.False(nilLog.JSONOutput())
+}
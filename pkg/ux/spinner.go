@@ -22,6 +22,10 @@ type UserSpinner struct {
 func newSpinner(writer io.Writer) ysmrr.SpinnerManager {
 	if writer == nil {
 		writer = os.Stdout
+		if Logger.JSONOutput() {
+			// keep the JSON lines on stdout parseable
+			writer = os.Stderr
+		}
 	}
 	return ysmrr.NewSpinnerManager(
 		ysmrr.WithAnimation(animations.Dots),
@@ -61,6 +65,9 @@ func SpinFailWithError(s *ysmrr.Spinner, txt string, err error) {
 		s.ErrorWithMessagef("%s txt:%s err:%v", s.GetMessage(), txt, err)
 	}
 	Logger.log.Info(s.GetMessage() + " [Spinner Err]")
+	if Logger.JSONOutput() {
+		Logger.printEvent(levelError, s.GetMessage(), map[string]interface{}{"status": "failure"})
+	}
 }
 
 func SpinComplete(s *ysmrr.Spinner) {
@@ -69,4 +76,7 @@ func SpinComplete(s *ysmrr.Spinner) {
 	}
 	s.Complete()
 	Logger.log.Info(s.GetMessage() + " [Spinner Complete]")
+	if Logger.JSONOutput() {
+		Logger.printEvent(levelInfo, s.GetMessage(), map[string]interface{}{"status": "success"})
+	}
 }
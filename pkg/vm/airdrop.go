@@ -4,8 +4,13 @@
 package vm
 
 import (
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
 	"math/big"
+	"os"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
@@ -23,6 +28,59 @@ const (
 	extendAirdrop = "Would you like to airdrop more tokens?"
 )
 
+// AirdropFlags are the airdrop settings given by flags, used to build the genesis allocation
+// without prompting
+type AirdropFlags struct {
+	// Address to airdrop to. If empty, a new stored key is funded
+	Address string
+	// Amount to airdrop, in token units. If empty, the default airdrop amount is used
+	Amount string
+	// CSVFile lists the addresses to airdrop to, one address,balance per line with the
+	// balance in token units. Replaces Address and Amount
+	CSVFile string
+	// MaxSupply caps the total balance of the genesis allocation built from CSVFile, including
+	// the teleporter key prefunding, in token units. Not checked if empty
+	MaxSupply string
+}
+
+// IsSet returns true if any airdrop setting was given
+func (a AirdropFlags) IsSet() bool {
+	return a.Address != "" || a.Amount != "" || a.CSVFile != "" || a.MaxSupply != ""
+}
+
+// Validate checks that the address, if given, is an hex address, and that the amount, if given,
+// is a positive integer
+func (a AirdropFlags) Validate() error {
+	if a.CSVFile != "" && (a.Address != "" || a.Amount != "") {
+		return errors.New("airdrop CSV file can't be used together with an airdrop address or amount")
+	}
+	if a.MaxSupply != "" {
+		if a.CSVFile == "" {
+			return errors.New("airdrop max supply requires an airdrop CSV file")
+		}
+		if _, err := parseAirdropAmount(a.MaxSupply); err != nil {
+			return fmt.Errorf("invalid airdrop max supply: %w", err)
+		}
+	}
+	if a.Address != "" && !common.IsHexAddress(a.Address) {
+		return fmt.Errorf("invalid airdrop address %q: expected an hex address", a.Address)
+	}
+	if a.Amount != "" {
+		if _, err := parseAirdropAmount(a.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseAirdropAmount(amount string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid airdrop amount %q: expected a positive integer", amount)
+	}
+	return value, nil
+}
+
 func addAllocation(alloc core.GenesisAlloc, address string, amount *big.Int) {
 	alloc[common.HexToAddress(address)] = core.GenesisAccount{
 		Balance: amount,
@@ -57,14 +115,149 @@ func getEwoqAllocation(defaultAirdropAmount string) (core.GenesisAlloc, error) {
 	return allocation, nil
 }
 
+// getFlagsAllocation builds the allocation given by [airdrop]. The amount is given in token units,
+// and converted with [multiplier]
+func getFlagsAllocation(
+	app *application.Avalanche,
+	subnetName string,
+	airdrop AirdropFlags,
+	defaultAirdropAmount string,
+	multiplier *big.Int,
+) (core.GenesisAlloc, error) {
+	if err := airdrop.Validate(); err != nil {
+		return core.GenesisAlloc{}, err
+	}
+	if airdrop.CSVFile != "" {
+		return getCSVAllocation(airdrop, multiplier)
+	}
+	amount, ok := new(big.Int).SetString(defaultAirdropAmount, 10)
+	if !ok {
+		return core.GenesisAlloc{}, errors.New("unable to decode default allocation")
+	}
+	if airdrop.Amount != "" {
+		tokens, err := parseAirdropAmount(airdrop.Amount)
+		if err != nil {
+			return core.GenesisAlloc{}, err
+		}
+		amount = tokens.Mul(tokens, multiplier)
+	}
+	address := airdrop.Address
+	if address == "" {
+		keyName := utils.GetDefaultSubnetAirdropKeyName(subnetName)
+		k, err := app.GetKey(keyName, models.NewLocalNetwork(), true)
+		if err != nil {
+			return core.GenesisAlloc{}, err
+		}
+		address = k.C()
+	}
+	ux.Logger.PrintToUser("prefunding address %s with balance %s", address, amount)
+	allocation := core.GenesisAlloc{}
+	addAllocation(allocation, address, amount)
+	return allocation, nil
+}
+
+// getCSVAllocation builds the allocation listed in the CSV file of [airdrop]. Its max supply is
+// checked by checkAirdropMaxSupply once the allocation is complete
+func getCSVAllocation(airdrop AirdropFlags, multiplier *big.Int) (core.GenesisAlloc, error) {
+	csvFile, err := os.Open(airdrop.CSVFile)
+	if err != nil {
+		return core.GenesisAlloc{}, fmt.Errorf("failed to read airdrop CSV file: %w", err)
+	}
+	defer csvFile.Close()
+	allocation, total, err := parseAirdropCSV(csvFile, multiplier)
+	if err != nil {
+		return core.GenesisAlloc{}, fmt.Errorf("invalid airdrop CSV file %s: %w", airdrop.CSVFile, err)
+	}
+	ux.Logger.PrintToUser("prefunding %d addresses with a total balance of %s", len(allocation), total)
+	return allocation, nil
+}
+
+// checkAirdropMaxSupply checks that the total balance of the final genesis [allocation], which
+// includes the teleporter key prefunding, is within the max supply of [airdrop], if any
+func checkAirdropMaxSupply(allocation core.GenesisAlloc, airdrop AirdropFlags, multiplier *big.Int) error {
+	if airdrop.MaxSupply == "" {
+		return nil
+	}
+	maxSupply, err := parseAirdropAmount(airdrop.MaxSupply)
+	if err != nil {
+		return err
+	}
+	maxSupply.Mul(maxSupply, multiplier)
+	total := big.NewInt(0)
+	for _, account := range allocation {
+		if account.Balance != nil {
+			total.Add(total, account.Balance)
+		}
+	}
+	if total.Cmp(maxSupply) > 0 {
+		return fmt.Errorf("genesis allocation total balance %s, including any teleporter key prefunding, exceeds airdrop max supply %s", total, maxSupply)
+	}
+	return nil
+}
+
+// parseAirdropCSV parses the address,balance lines read from [r], with balances in token
+// units converted with [multiplier]. A first address,balance header line is skipped.
+// Returns the allocation and its total balance
+func parseAirdropCSV(r io.Reader, multiplier *big.Int) (core.GenesisAlloc, *big.Int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	allocation := core.GenesisAlloc{}
+	addressLines := map[common.Address]int{}
+	total := big.NewInt(0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) != 2 {
+			return nil, nil, fmt.Errorf("line %d: expected address,balance but found %d field(s)", line, len(record))
+		}
+		addressStr, balanceStr := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if len(addressLines) == 0 && strings.EqualFold(addressStr, "address") {
+			continue
+		}
+		if !common.IsHexAddress(addressStr) {
+			return nil, nil, fmt.Errorf("line %d: invalid address %q: expected an hex address", line, addressStr)
+		}
+		balance, err := parseAirdropAmount(balanceStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		address := common.HexToAddress(addressStr)
+		if firstLine, ok := addressLines[address]; ok {
+			return nil, nil, fmt.Errorf("line %d: duplicate address %s, already given at line %d", line, address, firstLine)
+		}
+		addressLines[address] = line
+		balance.Mul(balance, multiplier)
+		allocation[address] = core.GenesisAccount{Balance: balance}
+		total.Add(total, balance)
+	}
+	if len(allocation) == 0 {
+		return nil, nil, errors.New("no address,balance lines found")
+	}
+	return allocation, total, nil
+}
+
 func addTeleporterAddressToAllocations(
 	alloc core.GenesisAlloc,
 	teleporterKeyAddress string,
 	teleporterKeyBalance *big.Int,
 ) core.GenesisAlloc {
-	if alloc != nil {
-		addAllocation(alloc, teleporterKeyAddress, teleporterKeyBalance)
+	if alloc == nil {
+		return alloc
 	}
+	// an airdrop to the teleporter key, e.g. listed in an airdrop CSV file, adds to its balance
+	if account, ok := alloc[common.HexToAddress(teleporterKeyAddress)]; ok && account.Balance != nil {
+		addAllocation(alloc, teleporterKeyAddress, new(big.Int).Add(account.Balance, teleporterKeyBalance))
+		return alloc
+	}
+	addAllocation(alloc, teleporterKeyAddress, teleporterKeyBalance)
 	return alloc
 }
 
@@ -74,8 +267,14 @@ func getAllocation(
 	defaultAirdropAmount string,
 	multiplier *big.Int,
 	captureAmountLabel string,
+	airdrop AirdropFlags,
 	useDefaults bool,
 ) (core.GenesisAlloc, statemachine.StateDirection, error) {
+	if airdrop.IsSet() {
+		alloc, err := getFlagsAllocation(app, subnetName, airdrop, defaultAirdropAmount, multiplier)
+		return alloc, statemachine.Forward, err
+	}
+
 	if useDefaults {
 		alloc, err := getNewAllocation(app, subnetName, defaultAirdropAmount)
 		return alloc, statemachine.Forward, err
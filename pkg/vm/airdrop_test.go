@@ -5,11 +5,19 @@ package vm
 
 import (
 	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ava-labs/avalanche-cli/internal/mocks"
 	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/statemachine"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
 )
@@ -33,7 +41,7 @@ func TestGetAllocationCustomUnits(t *testing.T) {
 	mockPrompt.On("CapturePositiveBigInt", mock.Anything).Return(airdropInputAmount, nil)
 	mockPrompt.On("CaptureNoYes", mock.Anything).Return(false, nil)
 
-	alloc, direction, err := getAllocation(app, "", defaultEvmAirdropAmount, oneAvax, "", false)
+	alloc, direction, err := getAllocation(app, "", defaultEvmAirdropAmount, oneAvax, "", AirdropFlags{}, false)
 	require.NoError(err)
 	require.Equal(direction, statemachine.Forward)
 
@@ -73,9 +81,146 @@ func TestMultipleAirdropsSameAddress(t *testing.T) {
 		NotBefore(captureInt)
 	mockPrompt.On("CaptureNoYes", mock.Anything).Return(false, nil).Once().NotBefore(captureNoYes)
 
-	alloc, direction, err := getAllocation(app, "", defaultEvmAirdropAmount, oneAvax, "", false)
+	alloc, direction, err := getAllocation(app, "", defaultEvmAirdropAmount, oneAvax, "", AirdropFlags{}, false)
 	require.NoError(err)
 	require.Equal(direction, statemachine.Forward)
 
 	require.Equal(alloc[testAirdropAddress].Balance, expectedAmount)
 }
+
+func TestGetAllocationFromFlags(t *testing.T) {
+	require := setupTest(t)
+	app := application.New()
+	// no prompt expectations: flags must not prompt
+	mockPrompt := &mocks.Prompter{}
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, mockPrompt, nil)
+	require.NoError(os.MkdirAll(app.GetKeyDir(), constants.DefaultPerms755))
+
+	defaultAmount, ok := new(big.Int).SetString(defaultEvmAirdropAmount, 10)
+	require.True(ok)
+	fiveTokens := new(big.Int).Mul(big.NewInt(5), oneAvax)
+
+	// address and amount
+	alloc, direction, err := getAllocation(app, "testSubnet", defaultEvmAirdropAmount, oneAvax, "", AirdropFlags{
+		Address: testAirdropAddress.Hex(),
+		Amount:  "5",
+	}, false)
+	require.NoError(err)
+	require.Equal(statemachine.Forward, direction)
+	require.Equal(core.GenesisAlloc{testAirdropAddress: {Balance: fiveTokens}}, alloc)
+
+	// address only, takes precedence over the defaults
+	alloc, _, err = getAllocation(app, "testSubnet", defaultEvmAirdropAmount, oneAvax, "", AirdropFlags{
+		Address: testAirdropAddress.Hex(),
+	}, true)
+	require.NoError(err)
+	require.Equal(core.GenesisAlloc{testAirdropAddress: {Balance: defaultAmount}}, alloc)
+
+	// amount only, funds the stored airdrop key of the subnet
+	alloc, _, err = getAllocation(app, "testSubnet", defaultEvmAirdropAmount, oneAvax, "", AirdropFlags{
+		Amount: "5",
+	}, false)
+	require.NoError(err)
+	k, err := app.GetKey(utils.GetDefaultSubnetAirdropKeyName("testSubnet"), models.NewLocalNetwork(), false)
+	require.NoError(err)
+	require.Equal(core.GenesisAlloc{common.HexToAddress(k.C()): {Balance: fiveTokens}}, alloc)
+
+	// invalid flags
+	for _, airdrop := range []AirdropFlags{
+		{Address: "0x1234"},
+		{Address: "not an address"},
+		{Amount: "0"},
+		{Amount: "-5"},
+		{Amount: "1.5"},
+		{Address: testAirdropAddress.Hex(), Amount: "a lot"},
+	} {
+		require.Error(airdrop.Validate())
+		_, direction, err = getAllocation(app, "testSubnet", defaultEvmAirdropAmount, oneAvax, "", airdrop, false)
+		require.Error(err)
+		require.Equal(statemachine.Forward, direction)
+	}
+	require.False(AirdropFlags{}.IsSet())
+	mockPrompt.AssertExpectations(t)
+}
+
+const testAirdropCSV = `address,balance
+# team
+0x098B69E43b1720Bd12378225519d74e5F3aD0eA5, 100
+0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC,250
+
+0x0000000000000000000000000000000000000001,5
+`
+
+func TestParseAirdropCSV(t *testing.T) {
+	require := setupTest(t)
+	alloc, total, err := parseAirdropCSV(strings.NewReader(testAirdropCSV), oneAvax)
+	require.NoError(err)
+	tokens := func(amount int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(amount), oneAvax)
+	}
+	require.Equal(core.GenesisAlloc{
+		testAirdropAddress: {Balance: tokens(100)},
+		common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"): {Balance: tokens(250)},
+		common.HexToAddress("0x0000000000000000000000000000000000000001"): {Balance: tokens(5)},
+	}, alloc)
+	require.Equal(tokens(355), total)
+
+	for csvContent, expectedErr := range map[string]string{
+		testAirdropCSV + "0x1234,10\n":                                      "line 7: invalid address \"0x1234\"",
+		testAirdropCSV + "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC\n":     "line 7: expected address,balance but found 1 field(s)",
+		testAirdropCSV + "0x0000000000000000000000000000000000000002,1.5\n": "line 7: invalid airdrop amount \"1.5\"",
+		testAirdropCSV + "0x0000000000000000000000000000000000000002,0\n":   "line 7: invalid airdrop amount \"0\"",
+		// addresses are compared regardless of their checksum case
+		testAirdropCSV + "0x8DB97C7CECE249C2B98BDC0226CC4C2A57BF52FC,1\n": "line 7: duplicate address 0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC, already given at line 4",
+		"address,balance\n": "no address,balance lines found",
+	} {
+		_, _, err := parseAirdropCSV(strings.NewReader(csvContent), oneAvax)
+		require.ErrorContains(err, expectedErr)
+	}
+}
+
+func TestGetAllocationCSV(t *testing.T) {
+	require := setupTest(t)
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, &mocks.Prompter{}, nil)
+	csvPath := filepath.Join(t.TempDir(), "airdrop.csv")
+	require.NoError(os.WriteFile(csvPath, []byte(testAirdropCSV), constants.WriteReadReadPerms))
+
+	alloc, direction, err := getAllocation(app, "testSubnet", defaultEvmAirdropAmount, oneAvax, "", AirdropFlags{
+		CSVFile:   csvPath,
+		MaxSupply: "355",
+	}, false)
+	require.NoError(err)
+	require.Equal(statemachine.Forward, direction)
+	require.Len(alloc, 3)
+
+	require.NoError(checkAirdropMaxSupply(alloc, AirdropFlags{CSVFile: csvPath, MaxSupply: "355"}, oneAvax))
+	require.ErrorContains(
+		checkAirdropMaxSupply(alloc, AirdropFlags{CSVFile: csvPath, MaxSupply: "354"}, oneAvax),
+		"exceeds airdrop max supply",
+	)
+
+	// the teleporter key balance adds to its airdrop, and counts against the max supply
+	teleporterBalance := new(big.Int).Mul(big.NewInt(600), oneAvax)
+	alloc = addTeleporterAddressToAllocations(alloc, testAirdropAddress.Hex(), teleporterBalance)
+	require.Equal(new(big.Int).Mul(big.NewInt(700), oneAvax), alloc[testAirdropAddress].Balance)
+	require.ErrorContains(
+		checkAirdropMaxSupply(alloc, AirdropFlags{CSVFile: csvPath, MaxSupply: "355"}, oneAvax),
+		"genesis allocation total balance 955000000000000000000, including any teleporter key prefunding, exceeds airdrop max supply 355000000000000000000",
+	)
+	require.NoError(checkAirdropMaxSupply(alloc, AirdropFlags{CSVFile: csvPath, MaxSupply: "955"}, oneAvax))
+	require.NoError(checkAirdropMaxSupply(alloc, AirdropFlags{CSVFile: csvPath}, oneAvax))
+
+	for _, airdrop := range []AirdropFlags{
+		{CSVFile: csvPath, Address: testAirdropAddress.Hex()},
+		{CSVFile: csvPath, Amount: "5"},
+		{CSVFile: csvPath, MaxSupply: "0"},
+		{MaxSupply: "100"},
+	} {
+		require.Error(airdrop.Validate())
+	}
+	_, _, err = getAllocation(app, "testSubnet", defaultEvmAirdropAmount, oneAvax, "", AirdropFlags{
+		CSVFile: filepath.Join(t.TempDir(), "missing.csv"),
+	}, false)
+	require.ErrorContains(err, "failed to read airdrop CSV file")
+}
@@ -4,12 +4,18 @@
 package vm
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ethereum/go-ethereum/common"
@@ -17,6 +23,25 @@ import (
 	"golang.org/x/mod/semver"
 )
 
+const (
+	adminRole   = "admin"
+	managerRole = "manager"
+	enabledRole = "enabled"
+)
+
+// AllowListFiles contains paths to files describing the allow lists
+// of the allow list based precompiles, to be used instead of prompting
+type AllowListFiles struct {
+	TxAllowListFile       string
+	DeployerAllowListFile string
+}
+
+type allowListFileContent struct {
+	Admin   []string `json:"admin"`
+	Manager []string `json:"manager"`
+	Enabled []string `json:"enabled"`
+}
+
 func preview(
 	adminAddresses []common.Address,
 	managerAddresses []common.Address,
@@ -232,3 +257,93 @@ func GenerateAllowList(
 		}
 	}
 }
+
+// LoadAllowListFile reads the allow list roles from [path]. If the file has .csv
+// extension, it is expected to contain lines of the form "role,address". Otherwise,
+// it is expected to be a JSON object with "admin", "manager" and "enabled" address lists.
+func LoadAllowListFile(path string, evmVersion string) (allowlist.AllowListConfig, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return allowlist.AllowListConfig{}, err
+	}
+	var content allowListFileContent
+	if strings.ToLower(filepath.Ext(path)) == ".csv" {
+		content, err = parseAllowListCSV(bs)
+	} else {
+		err = json.Unmarshal(bs, &content)
+	}
+	if err != nil {
+		return allowlist.AllowListConfig{}, fmt.Errorf("failure parsing allow list file %s: %w", path, err)
+	}
+	config, err := content.toAllowListConfig()
+	if err != nil {
+		return allowlist.AllowListConfig{}, fmt.Errorf("invalid allow list file %s: %w", path, err)
+	}
+	if len(config.ManagerAddresses) != 0 && semver.Compare(evmVersion, "v0.6.4") < 0 {
+		return allowlist.AllowListConfig{}, fmt.Errorf("manager role is not supported on subnet-evm %s", evmVersion)
+	}
+	return config, nil
+}
+
+func parseAllowListCSV(bs []byte) (allowListFileContent, error) {
+	content := allowListFileContent{}
+	reader := csv.NewReader(strings.NewReader(string(bs)))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return content, err
+	}
+	for i, record := range records {
+		if len(record) != 2 {
+			return content, fmt.Errorf("line %d: expected 2 fields role,address, found %d", i+1, len(record))
+		}
+		role := strings.ToLower(strings.TrimSpace(record[0]))
+		address := strings.TrimSpace(record[1])
+		switch role {
+		case adminRole:
+			content.Admin = append(content.Admin, address)
+		case managerRole:
+			content.Manager = append(content.Manager, address)
+		case enabledRole:
+			content.Enabled = append(content.Enabled, address)
+		case "role":
+			// header line
+			if i != 0 {
+				return content, fmt.Errorf("line %d: unexpected header", i+1)
+			}
+		default:
+			return content, fmt.Errorf("line %d: unknown role %q", i+1, record[0])
+		}
+	}
+	return content, nil
+}
+
+func (c allowListFileContent) toAllowListConfig() (allowlist.AllowListConfig, error) {
+	config := allowlist.AllowListConfig{}
+	seen := map[common.Address]string{}
+	for _, roleAddresses := range []struct {
+		role      string
+		addresses []string
+		dest      *[]common.Address
+	}{
+		{adminRole, c.Admin, &config.AdminAddresses},
+		{managerRole, c.Manager, &config.ManagerAddresses},
+		{enabledRole, c.Enabled, &config.EnabledAddresses},
+	} {
+		for _, addressStr := range roleAddresses.addresses {
+			if err := prompts.ValidateAddress(addressStr); err != nil {
+				return config, fmt.Errorf("%s address %q: %w", roleAddresses.role, addressStr, err)
+			}
+			address := common.HexToAddress(addressStr)
+			if role, ok := seen[address]; ok {
+				return config, fmt.Errorf("address %s is already allowed as %s role", address.Hex(), role)
+			}
+			seen[address] = roleAddresses.role
+			*roleAddresses.dest = append(*roleAddresses.dest, address)
+		}
+	}
+	if len(seen) == 0 {
+		return config, errors.New("no addresses found")
+	}
+	return config, nil
+}
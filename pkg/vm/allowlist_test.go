@@ -0,0 +1,109 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package vm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	allowListTestAddr1 = "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+	allowListTestAddr2 = "0x0Fa8EA536Be85F32724D57A37758761B86416123"
+	allowListTestAddr3 = "0x2A9c2aF9FA5E3D4F2B3FB6c6A1Ff3aE2B4F6B2B0"
+)
+
+func TestLoadAllowListFile(t *testing.T) {
+	type test struct {
+		name       string
+		fileName   string
+		content    string
+		evmVersion string
+		admins     []common.Address
+		managers   []common.Address
+		enabled    []common.Address
+		shouldFail bool
+	}
+	tests := []test{
+		{
+			name:       "json file",
+			fileName:   "allowlist.json",
+			content:    `{"admin": ["` + allowListTestAddr1 + `"], "manager": ["` + allowListTestAddr2 + `"], "enabled": ["` + allowListTestAddr3 + `"]}`,
+			evmVersion: "v0.6.6",
+			admins:     []common.Address{common.HexToAddress(allowListTestAddr1)},
+			managers:   []common.Address{common.HexToAddress(allowListTestAddr2)},
+			enabled:    []common.Address{common.HexToAddress(allowListTestAddr3)},
+		},
+		{
+			name:       "csv file with header",
+			fileName:   "allowlist.csv",
+			content:    "role,address\nadmin," + allowListTestAddr1 + "\nEnabled, " + allowListTestAddr2 + "\n",
+			evmVersion: "v0.6.6",
+			admins:     []common.Address{common.HexToAddress(allowListTestAddr1)},
+			enabled:    []common.Address{common.HexToAddress(allowListTestAddr2)},
+		},
+		{
+			name:       "malformed json address",
+			fileName:   "allowlist.json",
+			content:    `{"admin": ["0x1234"]}`,
+			evmVersion: "v0.6.6",
+			shouldFail: true,
+		},
+		{
+			name:       "malformed csv address",
+			fileName:   "allowlist.csv",
+			content:    "admin,not-an-address\n",
+			evmVersion: "v0.6.6",
+			shouldFail: true,
+		},
+		{
+			name:       "unknown csv role",
+			fileName:   "allowlist.csv",
+			content:    "owner," + allowListTestAddr1 + "\n",
+			evmVersion: "v0.6.6",
+			shouldFail: true,
+		},
+		{
+			name:       "duplicated address",
+			fileName:   "allowlist.json",
+			content:    `{"admin": ["` + allowListTestAddr1 + `"], "enabled": ["` + allowListTestAddr1 + `"]}`,
+			evmVersion: "v0.6.6",
+			shouldFail: true,
+		},
+		{
+			name:       "empty allow list",
+			fileName:   "allowlist.json",
+			content:    `{}`,
+			evmVersion: "v0.6.6",
+			shouldFail: true,
+		},
+		{
+			name:       "manager on old subnet-evm",
+			fileName:   "allowlist.json",
+			content:    `{"manager": ["` + allowListTestAddr1 + `"]}`,
+			evmVersion: "v0.6.3",
+			shouldFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			require.NoError(os.WriteFile(path, []byte(tt.content), constants.WriteReadReadPerms))
+			config, err := LoadAllowListFile(path, tt.evmVersion)
+			if tt.shouldFail {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.admins, config.AdminAddresses)
+			require.Equal(tt.managers, config.ManagerAddresses)
+			require.Equal(tt.enabled, config.EnabledAddresses)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
@@ -23,6 +24,7 @@ func CreateCustomSubnetConfig(
 	useRepo bool,
 	customVMRepoURL string,
 	customVMBranch string,
+	customVMTag string,
 	customVMBuildScript string,
 	vmPath string,
 ) ([]byte, *models.Sidecar, error) {
@@ -39,7 +41,7 @@ func CreateCustomSubnetConfig(
 		Subnet: subnetName,
 	}
 
-	if customVMRepoURL != "" || customVMBranch != "" || customVMBuildScript != "" {
+	if customVMRepoURL != "" || customVMBranch != "" || customVMTag != "" || customVMBuildScript != "" {
 		useRepo = true
 	}
 	if vmPath == "" && !useRepo {
@@ -60,7 +62,7 @@ func CreateCustomSubnetConfig(
 		}
 	}
 	if useRepo {
-		if err := SetCustomVMSourceCodeFields(app, sc, customVMRepoURL, customVMBranch, customVMBuildScript); err != nil {
+		if err := SetCustomVMSourceCodeFields(app, sc, customVMRepoURL, customVMBranch, customVMTag, customVMBuildScript); err != nil {
 			return nil, &models.Sidecar{}, err
 		}
 		if err := BuildCustomVM(app, sc); err != nil {
@@ -96,7 +98,16 @@ func loadCustomGenesis(app *application.Avalanche, genesisPath string) ([]byte,
 	return genesisBytes, err
 }
 
-func SetCustomVMSourceCodeFields(app *application.Avalanche, sc *models.Sidecar, customVMRepoURL string, customVMBranch string, customVMBuildScript string) error {
+// SetCustomVMSourceCodeFields sets the repository, branch or tag, and build script of the custom VM
+// of [sc], prompting for the ones not given or invalid. A given tag must exist in the repository
+func SetCustomVMSourceCodeFields(
+	app *application.Avalanche,
+	sc *models.Sidecar,
+	customVMRepoURL string,
+	customVMBranch string,
+	customVMTag string,
+	customVMBuildScript string,
+) error {
 	var err error
 	if customVMRepoURL != "" {
 		ux.Logger.PrintToUser("Checking source code repository URL %s", customVMRepoURL)
@@ -111,6 +122,12 @@ func SetCustomVMSourceCodeFields(app *application.Avalanche, sc *models.Sidecar,
 			return err
 		}
 	}
+	if customVMTag != "" {
+		ux.Logger.PrintToUser("Checking tag %s", customVMTag)
+		if err := CheckRepoTagExists(customVMRepoURL, customVMTag); err != nil {
+			return err
+		}
+	}
 	if customVMBranch != "" {
 		ux.Logger.PrintToUser("Checking branch %s", customVMBranch)
 		if err := prompts.ValidateRepoBranch(customVMRepoURL, customVMBranch); err != nil {
@@ -118,27 +135,32 @@ func SetCustomVMSourceCodeFields(app *application.Avalanche, sc *models.Sidecar,
 			customVMBranch = ""
 		}
 	}
-	if customVMBranch == "" {
+	if customVMBranch == "" && customVMTag == "" {
 		customVMBranch, err = app.Prompt.CaptureRepoBranch("Branch", customVMRepoURL)
 		if err != nil {
 			return err
 		}
 	}
+	ref := customVMBranch
+	if customVMTag != "" {
+		ref = customVMTag
+	}
 	if customVMBuildScript != "" {
 		ux.Logger.PrintToUser("Checking build script %s", customVMBuildScript)
-		if err := prompts.ValidateRepoFile(customVMRepoURL, customVMBranch, customVMBuildScript); err != nil {
+		if err := prompts.ValidateRepoFile(customVMRepoURL, ref, customVMBuildScript); err != nil {
 			ux.Logger.PrintToUser("Invalid repository build script %s: %s", customVMBuildScript, err)
 			customVMBuildScript = ""
 		}
 	}
 	if customVMBuildScript == "" {
-		customVMBuildScript, err = app.Prompt.CaptureRepoFile("Build script", customVMRepoURL, customVMBranch)
+		customVMBuildScript, err = app.Prompt.CaptureRepoFile("Build script", customVMRepoURL, ref)
 		if err != nil {
 			return err
 		}
 	}
 	sc.CustomVMRepoURL = customVMRepoURL
 	sc.CustomVMBranch = customVMBranch
+	sc.CustomVMTag = customVMTag
 	sc.CustomVMBuildScript = customVMBuildScript
 	return nil
 }
@@ -154,6 +176,21 @@ func CheckGitIsInstalled() error {
 	return err
 }
 
+// CheckRepoTagExists checks that [tag] is a tag of the git repository at [repoURL]
+func CheckRepoTagExists(repoURL string, tag string) error {
+	if err := CheckGitIsInstalled(); err != nil {
+		return err
+	}
+	output, err := exec.Command("git", "ls-remote", "--tags", repoURL, "refs/tags/"+tag).Output()
+	if err != nil {
+		return fmt.Errorf("could not list tags of repository %s: %w", repoURL, err)
+	}
+	if strings.TrimSpace(string(output)) == "" {
+		return fmt.Errorf("tag %s not found in repository %s", tag, repoURL)
+	}
+	return nil
+}
+
 func BuildCustomVM(
 	app *application.Avalanche,
 	sc *models.Sidecar,
@@ -182,17 +219,33 @@ func BuildCustomVM(
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("could not add origin %s on git: %w", sc.CustomVMRepoURL, err)
 	}
-	cmd = exec.Command("git", "fetch", "--depth", "1", "origin", sc.CustomVMBranch, "-q")
-	cmd.Dir = repoDir
-	utils.SetupRealtimeCLIOutput(cmd, true, true)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("could not fetch git branch/commit %s of repository %s: %w", sc.CustomVMBranch, sc.CustomVMRepoURL, err)
-	}
-	cmd = exec.Command("git", "checkout", sc.CustomVMBranch)
-	cmd.Dir = repoDir
-	utils.SetupRealtimeCLIOutput(cmd, true, true)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("could not checkout git branch %s of repository %s: %w", sc.CustomVMBranch, sc.CustomVMRepoURL, err)
+	if sc.CustomVMTag != "" {
+		tagRef := "refs/tags/" + sc.CustomVMTag
+		cmd = exec.Command("git", "fetch", "--depth", "1", "origin", tagRef+":"+tagRef, "-q")
+		cmd.Dir = repoDir
+		utils.SetupRealtimeCLIOutput(cmd, true, true)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("could not fetch git tag %s of repository %s: %w", sc.CustomVMTag, sc.CustomVMRepoURL, err)
+		}
+		cmd = exec.Command("git", "checkout", "-q", "tags/"+sc.CustomVMTag)
+		cmd.Dir = repoDir
+		utils.SetupRealtimeCLIOutput(cmd, true, true)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("could not checkout git tag %s of repository %s: %w", sc.CustomVMTag, sc.CustomVMRepoURL, err)
+		}
+	} else {
+		cmd = exec.Command("git", "fetch", "--depth", "1", "origin", sc.CustomVMBranch, "-q")
+		cmd.Dir = repoDir
+		utils.SetupRealtimeCLIOutput(cmd, true, true)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("could not fetch git branch/commit %s of repository %s: %w", sc.CustomVMBranch, sc.CustomVMRepoURL, err)
+		}
+		cmd = exec.Command("git", "checkout", sc.CustomVMBranch)
+		cmd.Dir = repoDir
+		utils.SetupRealtimeCLIOutput(cmd, true, true)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("could not checkout git branch %s of repository %s: %w", sc.CustomVMBranch, sc.CustomVMRepoURL, err)
+		}
 	}
 
 	vmPath := app.GetCustomVMPath(sc.Name)
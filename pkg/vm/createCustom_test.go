@@ -0,0 +1,80 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package vm
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// newCustomVMRepo creates a git repository whose build script, at tag v1.0.0, builds a
+// binary printing "tagged", and at branch main, one printing "main"
+func newCustomVMRepo(t *testing.T) string {
+	require := require.New(t)
+	repoDir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@test"}, args...)...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		require.NoError(err, string(output))
+	}
+	writeBuildScript := func(version string) {
+		script := "#!/usr/bin/env bash\nprintf '#!/usr/bin/env bash\\necho " + version + "\\n' > $1\nchmod +x $1\n"
+		require.NoError(os.WriteFile(filepath.Join(repoDir, "build.sh"), []byte(script), 0o755))
+	}
+	git("init", "-q", "-b", "main")
+	writeBuildScript("tagged")
+	git("add", "build.sh")
+	git("commit", "-q", "-m", "tagged")
+	git("tag", "v1.0.0")
+	writeBuildScript("main")
+	git("commit", "-q", "-a", "-m", "main")
+	return "file://" + repoDir
+}
+
+func TestCheckRepoTagExists(t *testing.T) {
+	require := require.New(t)
+	repoURL := newCustomVMRepo(t)
+	require.NoError(CheckRepoTagExists(repoURL, "v1.0.0"))
+	require.ErrorContains(CheckRepoTagExists(repoURL, "v2.0.0"), "tag v2.0.0 not found")
+	// branches are not tags
+	require.ErrorContains(CheckRepoTagExists(repoURL, "main"), "tag main not found")
+}
+
+func TestBuildCustomVM(t *testing.T) {
+	require := require.New(t)
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	require.NoError(os.MkdirAll(filepath.Dir(app.GetCustomVMPath("testSubnet")), 0o755))
+	repoURL := newCustomVMRepo(t)
+	sc := &models.Sidecar{
+		Name:                "testSubnet",
+		VM:                  models.CustomVM,
+		CustomVMRepoURL:     repoURL,
+		CustomVMBranch:      "main",
+		CustomVMBuildScript: "./build.sh",
+	}
+	built := func() string {
+		output, err := exec.Command(app.GetCustomVMPath(sc.Name)).Output()
+		require.NoError(err)
+		return string(output)
+	}
+	require.NoError(BuildCustomVM(app, sc))
+	require.Equal("main\n", built())
+
+	sc.CustomVMBranch = ""
+	sc.CustomVMTag = "v1.0.0"
+	require.NoError(BuildCustomVM(app, sc))
+	require.Equal("tagged\n", built())
+
+	sc.CustomVMTag = "v2.0.0"
+	require.ErrorContains(BuildCustomVM(app, sc), "could not fetch git tag v2.0.0")
+}
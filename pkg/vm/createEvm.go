@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
@@ -25,10 +26,40 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// maxGenesisTimestampFuture is how far in the future a genesis timestamp can be set,
+// as the chain can't produce blocks before its genesis time
+const maxGenesisTimestampFuture = 24 * time.Hour
+
 var versionComments = map[string]string{
 	"v0.6.0-fuji": " (recommended for fuji durango)",
 }
 
+// ParseGenesisTimestamp parses a genesis timestamp given either as unix seconds or as RFC3339
+func ParseGenesisTimestamp(timestamp string) (time.Time, error) {
+	if unixSeconds, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+		if unixSeconds < 0 {
+			return time.Time{}, fmt.Errorf("invalid genesis timestamp %q: must not be negative", timestamp)
+		}
+		return time.Unix(unixSeconds, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid genesis timestamp %q: expected unix seconds or RFC3339", timestamp)
+	}
+	if t.Unix() < 0 {
+		return time.Time{}, fmt.Errorf("invalid genesis timestamp %q: must not be before unix epoch", timestamp)
+	}
+	return t, nil
+}
+
+// ValidateGenesisTimestamp checks that [timestamp] is not too far in the future of [now]
+func ValidateGenesisTimestamp(timestamp time.Time, now time.Time) error {
+	if timestamp.After(now.Add(maxGenesisTimestampFuture)) {
+		return fmt.Errorf("genesis timestamp %s is more than %s in the future", timestamp.Format(time.RFC3339), maxGenesisTimestampFuture)
+	}
+	return nil
+}
+
 func CreateEvmSubnetConfig(
 	app *application.Avalanche,
 	subnetName string,
@@ -37,9 +68,13 @@ func CreateEvmSubnetConfig(
 	getRPCVersionFromBinary bool,
 	subnetEVMChainID uint64,
 	subnetEVMTokenSymbol string,
+	genesisTimestamp *time.Time,
 	useSubnetEVMDefaults bool,
 	useWarp bool,
 	teleporterInfo *teleporter.Info,
+	allowListFiles AllowListFiles,
+	airdrop AirdropFlags,
+	template *EvmTemplate,
 ) ([]byte, *models.Sidecar, error) {
 	var (
 		genesisBytes []byte
@@ -72,9 +107,13 @@ func CreateEvmSubnetConfig(
 			rpcVersion,
 			subnetEVMChainID,
 			subnetEVMTokenSymbol,
+			genesisTimestamp,
 			useSubnetEVMDefaults,
 			useWarp,
 			teleporterInfo,
+			allowListFiles,
+			airdrop,
+			template,
 		)
 		if err != nil {
 			return nil, &models.Sidecar{}, err
@@ -105,14 +144,22 @@ func createEvmGenesis(
 	rpcVersion int,
 	subnetEVMChainID uint64,
 	subnetEVMTokenSymbol string,
+	genesisTimestamp *time.Time,
 	useSubnetEVMDefaults bool,
 	useWarp bool,
 	teleporterInfo *teleporter.Info,
+	allowListFiles AllowListFiles,
+	airdrop AirdropFlags,
+	template *EvmTemplate,
 ) ([]byte, *models.Sidecar, error) {
 	ux.Logger.PrintToUser("creating genesis for subnet %s", subnetName)
 
 	genesis := core.Genesis{}
-	genesis.Timestamp = *utils.TimeToNewUint64(time.Now())
+	if genesisTimestamp != nil {
+		genesis.Timestamp = *utils.TimeToNewUint64(*genesisTimestamp)
+	} else {
+		genesis.Timestamp = *utils.TimeToNewUint64(time.Now())
+	}
 
 	conf := params.SubnetEVMDefaultChainConfig
 	conf.NetworkUpgrades = params.NetworkUpgrades{}
@@ -147,15 +194,25 @@ func createEvmGenesis(
 				subnetEVMTokenSymbol,
 			)
 		case feeState:
-			*conf, direction, err = GetFeeConfig(*conf, app, useSubnetEVMDefaults)
+			if template != nil && template.FeeConfig != nil {
+				conf.FeeConfig = template.GetFeeConfig()
+				direction = statemachine.Forward
+			} else {
+				*conf, direction, err = GetFeeConfig(*conf, app, useSubnetEVMDefaults)
+			}
 		case airdropState:
+			stateAirdrop, useAirdropDefaults := airdrop, useSubnetEVMDefaults
+			if template != nil && template.Airdrop != nil {
+				stateAirdrop, useAirdropDefaults = template.GetAirdropFlags(airdrop), true
+			}
 			allocation, direction, err = getAllocation(
 				app,
 				subnetName,
 				defaultEvmAirdropAmount,
 				oneAvax,
 				fmt.Sprintf("Amount to airdrop (in %s units)", tokenSymbol),
-				useSubnetEVMDefaults,
+				stateAirdrop,
+				useAirdropDefaults,
 			)
 			if teleporterInfo != nil {
 				allocation = addTeleporterAddressToAllocations(
@@ -164,8 +221,16 @@ func createEvmGenesis(
 					teleporterInfo.FundedBalance,
 				)
 			}
+			if err == nil {
+				err = checkAirdropMaxSupply(allocation, stateAirdrop, oneAvax)
+			}
 		case precompilesState:
-			*conf, direction, err = getPrecompiles(*conf, app, &genesis.Timestamp, useSubnetEVMDefaults, useWarp, subnetEVMVersion)
+			if template != nil && template.Precompiles != nil {
+				*conf, err = getTemplatePrecompiles(*conf, *template.Precompiles, &genesis.Timestamp, useWarp, subnetEVMVersion, allowListFiles)
+				direction = statemachine.Forward
+			} else {
+				*conf, direction, err = getPrecompiles(*conf, app, &genesis.Timestamp, useSubnetEVMDefaults, useWarp, subnetEVMVersion, allowListFiles)
+			}
 			if teleporterInfo != nil {
 				*conf = addTeleporterAddressesToAllowLists(
 					*conf,
@@ -248,12 +313,18 @@ func ensureAdminsHaveBalance(admins []common.Address, alloc core.GenesisAlloc) e
 	)
 }
 
+// GetVMVersion resolves [vmVersion], that can be a version, latest, pre-release, or empty to prompt
+// for it. If [vmVersionConstraint] is given, the newest release satisfying it is used instead
 func GetVMVersion(
 	app *application.Avalanche,
 	vmName string,
 	repoName string,
 	vmVersion string,
+	vmVersionConstraint string,
 ) (string, error) {
+	if vmVersionConstraint != "" {
+		return getNewestMatchingVMVersion(app, vmName, repoName, vmVersionConstraint)
+	}
 	var err error
 	switch vmVersion {
 	case "latest":
@@ -281,6 +352,29 @@ func GetVMVersion(
 	return vmVersion, nil
 }
 
+// getNewestMatchingVMVersion returns the newest release of [repoName] that satisfies [vmVersionConstraint]
+func getNewestMatchingVMVersion(
+	app *application.Avalanche,
+	vmName string,
+	repoName string,
+	vmVersionConstraint string,
+) (string, error) {
+	constraint, err := ParseVersionConstraint(vmVersionConstraint)
+	if err != nil {
+		return "", err
+	}
+	versions, err := app.Downloader.GetAllReleasesForRepo(constants.AvaLabsOrg, repoName)
+	if err != nil {
+		return "", err
+	}
+	vmVersion, err := NewestMatchingVersion(versions, constraint)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", vmName, err)
+	}
+	ux.Logger.PrintToUser("Using %s %s, the newest release matching %q", vmName, vmVersion, vmVersionConstraint)
+	return vmVersion, nil
+}
+
 func askForVMVersion(
 	app *application.Avalanche,
 	vmName string,
@@ -3,11 +3,18 @@
 package vm
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanche-cli/internal/testutils"
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -133,3 +140,64 @@ func Test_removePrecompile(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGenesisTimestamp(t *testing.T) {
+	require := require.New(t)
+
+	timestamp, err := ParseGenesisTimestamp("1700000000")
+	require.NoError(err)
+	require.Equal(int64(1700000000), timestamp.Unix())
+
+	timestamp, err = ParseGenesisTimestamp("2023-11-14T22:13:20Z")
+	require.NoError(err)
+	require.Equal(int64(1700000000), timestamp.Unix())
+
+	for _, invalid := range []string{"", "-1", "yesterday", "2023-11-14", "1969-12-31T23:59:59Z"} {
+		_, err := ParseGenesisTimestamp(invalid)
+		require.Error(err, invalid)
+	}
+}
+
+func TestValidateGenesisTimestamp(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1700000000, 0)
+	require.NoError(ValidateGenesisTimestamp(time.Unix(0, 0), now))
+	require.NoError(ValidateGenesisTimestamp(now, now))
+	require.NoError(ValidateGenesisTimestamp(now.Add(maxGenesisTimestampFuture), now))
+	require.ErrorContains(ValidateGenesisTimestamp(now.Add(maxGenesisTimestampFuture+time.Second), now), "in the future")
+}
+
+func TestCreateEvmGenesisDeterministicTimestamp(t *testing.T) {
+	require := setupTest(t)
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	require.NoError(os.MkdirAll(app.GetKeyDir(), constants.DefaultPerms755))
+
+	genesisTimestamp := time.Unix(1700000000, 0)
+	createGenesis := func() []byte {
+		genesisBytes, _, err := createEvmGenesis(
+			app,
+			"testSubnet",
+			"v0.6.8",
+			35,
+			1234,
+			testToken,
+			&genesisTimestamp,
+			true,
+			true,
+			nil,
+			AllowListFiles{},
+			AirdropFlags{},
+			nil,
+		)
+		require.NoError(err)
+		return genesisBytes
+	}
+	genesisBytes := createGenesis()
+	require.Equal(genesisBytes, createGenesis())
+
+	genesis := core.Genesis{}
+	require.NoError(json.Unmarshal(genesisBytes, &genesis))
+	require.Equal(uint64(1700000000), genesis.Timestamp)
+}
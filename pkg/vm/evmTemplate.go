@@ -0,0 +1,270 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	subnetevmutils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// TemplateAirdropNewKey funds a new stored key, as --evm-defaults does
+	TemplateAirdropNewKey = "new-key"
+	// TemplateAirdropEwoq funds the ewoq address. Not to be used in production
+	TemplateAirdropEwoq = "ewoq"
+	// TemplateAirdropAddress funds the address given in the template
+	TemplateAirdropAddress = "address"
+)
+
+// EvmTemplate holds Subnet-EVM genesis defaults read from a file. Each section
+// that is present replaces the corresponding step of the genesis wizard
+type EvmTemplate struct {
+	// FeeConfig fields that are not given are taken from the C-Chain fee config
+	FeeConfig   *commontype.FeeConfig   `json:"feeConfig,omitempty"`
+	Precompiles *EvmTemplatePrecompiles `json:"precompiles,omitempty"`
+	Airdrop     *EvmTemplateAirdrop     `json:"airdrop,omitempty"`
+}
+
+// EvmTemplatePrecompiles toggles the precompiles to enable at genesis. All the
+// enabled allow list based precompiles get [Admins] as admin addresses
+type EvmTemplatePrecompiles struct {
+	Admins                    []string `json:"admins,omitempty"`
+	NativeMinter              bool     `json:"nativeMinter,omitempty"`
+	ContractDeployerAllowList bool     `json:"contractDeployerAllowList,omitempty"`
+	TxAllowList               bool     `json:"txAllowList,omitempty"`
+	FeeManager                bool     `json:"feeManager,omitempty"`
+	RewardManager             bool     `json:"rewardManager,omitempty"`
+}
+
+// EvmTemplateAirdrop sets how the genesis allocation is built
+type EvmTemplateAirdrop struct {
+	// Policy is one of new-key (default), ewoq or address
+	Policy  string `json:"policy,omitempty"`
+	Address string `json:"address,omitempty"`
+	// Amount to airdrop, in token units. If empty, the default airdrop amount is used
+	Amount string `json:"amount,omitempty"`
+}
+
+// LoadEvmTemplate reads and validates the Subnet-EVM template at [templatePath]. Unknown
+// fields are rejected, so that typos don't get silently ignored
+func LoadEvmTemplate(templatePath string) (*EvmTemplate, error) {
+	templateBytes, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, err
+	}
+	template, err := parseEvmTemplate(templateBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Subnet-EVM template %s: %w", templatePath, err)
+	}
+	return template, nil
+}
+
+func parseEvmTemplate(templateBytes []byte) (*EvmTemplate, error) {
+	decoder := json.NewDecoder(bytes.NewReader(templateBytes))
+	decoder.DisallowUnknownFields()
+	template := &EvmTemplate{}
+	if err := decoder.Decode(template); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the template object")
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Validate checks that the fee config is valid once completed with the C-Chain defaults,
+// that the precompile admins are hex addresses, and that the airdrop policy is consistent
+func (t *EvmTemplate) Validate() error {
+	if t.FeeConfig != nil {
+		feeConfig := t.GetFeeConfig()
+		if err := feeConfig.Verify(); err != nil {
+			return fmt.Errorf("invalid feeConfig: %w", err)
+		}
+	}
+	if t.Precompiles != nil {
+		for _, admin := range t.Precompiles.Admins {
+			if !common.IsHexAddress(admin) {
+				return fmt.Errorf("invalid precompiles admin %q: expected an hex address", admin)
+			}
+		}
+		if t.Precompiles.usesAllowList() && len(t.Precompiles.Admins) == 0 {
+			return errors.New("precompiles admins must be given when enabling an allow list based precompile")
+		}
+	}
+	if t.Airdrop != nil {
+		switch t.Airdrop.Policy {
+		case "", TemplateAirdropNewKey, TemplateAirdropEwoq:
+			if t.Airdrop.Address != "" {
+				return fmt.Errorf("airdrop address is only used with the %q policy", TemplateAirdropAddress)
+			}
+		case TemplateAirdropAddress:
+			if t.Airdrop.Address == "" {
+				return fmt.Errorf("airdrop address is required with the %q policy", TemplateAirdropAddress)
+			}
+		default:
+			return fmt.Errorf(
+				"invalid airdrop policy %q: expected one of %s, %s, %s",
+				t.Airdrop.Policy,
+				TemplateAirdropNewKey,
+				TemplateAirdropEwoq,
+				TemplateAirdropAddress,
+			)
+		}
+		if err := t.Airdrop.toFlags().Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetFeeConfig returns the template fee config, with the missing fields taken from
+// the C-Chain fee config
+func (t *EvmTemplate) GetFeeConfig() commontype.FeeConfig {
+	feeConfig := StarterFeeConfig
+	if t.FeeConfig == nil {
+		return feeConfig
+	}
+	if t.FeeConfig.GasLimit != nil {
+		feeConfig.GasLimit = t.FeeConfig.GasLimit
+	}
+	if t.FeeConfig.TargetBlockRate != 0 {
+		feeConfig.TargetBlockRate = t.FeeConfig.TargetBlockRate
+	}
+	if t.FeeConfig.MinBaseFee != nil {
+		feeConfig.MinBaseFee = t.FeeConfig.MinBaseFee
+	}
+	if t.FeeConfig.TargetGas != nil {
+		feeConfig.TargetGas = t.FeeConfig.TargetGas
+	}
+	if t.FeeConfig.BaseFeeChangeDenominator != nil {
+		feeConfig.BaseFeeChangeDenominator = t.FeeConfig.BaseFeeChangeDenominator
+	}
+	if t.FeeConfig.MinBlockGasCost != nil {
+		feeConfig.MinBlockGasCost = t.FeeConfig.MinBlockGasCost
+	}
+	if t.FeeConfig.MaxBlockGasCost != nil {
+		feeConfig.MaxBlockGasCost = t.FeeConfig.MaxBlockGasCost
+	}
+	if t.FeeConfig.BlockGasCostStep != nil {
+		feeConfig.BlockGasCostStep = t.FeeConfig.BlockGasCostStep
+	}
+	return feeConfig
+}
+
+// GetAirdropFlags merges the template airdrop into [airdrop], so that settings given
+// by flags take precedence over the template ones
+func (t *EvmTemplate) GetAirdropFlags(airdrop AirdropFlags) AirdropFlags {
+	if t.Airdrop == nil || airdrop.CSVFile != "" {
+		return airdrop
+	}
+	templateAirdrop := t.Airdrop.toFlags()
+	if airdrop.Address == "" {
+		airdrop.Address = templateAirdrop.Address
+	}
+	if airdrop.Amount == "" {
+		airdrop.Amount = templateAirdrop.Amount
+	}
+	return airdrop
+}
+
+func (a *EvmTemplateAirdrop) toFlags() AirdropFlags {
+	airdrop := AirdropFlags{
+		Address: a.Address,
+		Amount:  a.Amount,
+	}
+	if a.Policy == TemplateAirdropEwoq {
+		airdrop.Address = PrefundedEwoqAddress.Hex()
+	}
+	return airdrop
+}
+
+func (p *EvmTemplatePrecompiles) usesAllowList() bool {
+	return p.NativeMinter || p.ContractDeployerAllowList || p.TxAllowList || p.FeeManager || p.RewardManager
+}
+
+// applyTemplatePrecompiles enables on [config] the precompiles toggled in [precompiles]
+func applyTemplatePrecompiles(config params.ChainConfig, precompiles EvmTemplatePrecompiles) params.ChainConfig {
+	// each precompile gets its own admin list, as they may be extended separately later on
+	allowListConfig := func() allowlist.AllowListConfig {
+		return allowlist.AllowListConfig{
+			AdminAddresses: utils.Map(precompiles.Admins, common.HexToAddress),
+		}
+	}
+	upgrade := precompileconfig.Upgrade{
+		BlockTimestamp: subnetevmutils.NewUint64(0),
+	}
+	if precompiles.NativeMinter {
+		config.GenesisPrecompiles[nativeminter.ConfigKey] = &nativeminter.Config{
+			AllowListConfig: allowListConfig(),
+			Upgrade:         upgrade,
+		}
+	}
+	if precompiles.ContractDeployerAllowList {
+		config.GenesisPrecompiles[deployerallowlist.ConfigKey] = &deployerallowlist.Config{
+			AllowListConfig: allowListConfig(),
+			Upgrade:         upgrade,
+		}
+	}
+	if precompiles.TxAllowList {
+		config.GenesisPrecompiles[txallowlist.ConfigKey] = &txallowlist.Config{
+			AllowListConfig: allowListConfig(),
+			Upgrade:         upgrade,
+		}
+	}
+	if precompiles.FeeManager {
+		config.GenesisPrecompiles[feemanager.ConfigKey] = &feemanager.Config{
+			AllowListConfig: allowListConfig(),
+			Upgrade:         upgrade,
+		}
+	}
+	if precompiles.RewardManager {
+		// fees are burnt until the admins set a reward address
+		config.GenesisPrecompiles[rewardmanager.ConfigKey] = &rewardmanager.Config{
+			AllowListConfig:     allowListConfig(),
+			Upgrade:             upgrade,
+			InitialRewardConfig: &rewardmanager.InitialRewardConfig{},
+		}
+	}
+	return config
+}
+
+// getTemplatePrecompiles sets the precompiles of [config] from [precompiles] instead of prompting.
+// Allow lists given by file take precedence over the template ones
+func getTemplatePrecompiles(
+	config params.ChainConfig,
+	precompiles EvmTemplatePrecompiles,
+	genesisTimestamp *uint64,
+	useWarp bool,
+	subnetEvmVersion string,
+	allowListFiles AllowListFiles,
+) (params.ChainConfig, error) {
+	if useWarp {
+		warpConfig := configureWarp(genesisTimestamp)
+		config.GenesisPrecompiles[warp.ConfigKey] = &warpConfig
+	}
+	config = applyTemplatePrecompiles(config, precompiles)
+	config, _, err := configureAllowListsFromFiles(config, allowListFiles, subnetEvmVersion)
+	return config, err
+}
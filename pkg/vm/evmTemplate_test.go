@@ -0,0 +1,180 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEvmTemplateExamples(t *testing.T) {
+	require := require.New(t)
+
+	templatePaths, err := filepath.Glob(filepath.Join("templates", "*.json"))
+	require.NoError(err)
+	require.NotEmpty(templatePaths)
+	for _, templatePath := range templatePaths {
+		_, err := LoadEvmTemplate(templatePath)
+		require.NoError(err, templatePath)
+	}
+}
+
+func TestParseEvmTemplate(t *testing.T) {
+	require := require.New(t)
+
+	template, err := parseEvmTemplate([]byte(`{"feeConfig": {"targetGas": 20000000}}`))
+	require.NoError(err)
+	feeConfig := template.GetFeeConfig()
+	require.Equal(big.NewInt(20_000_000), feeConfig.TargetGas)
+	require.Equal(StarterFeeConfig.GasLimit, feeConfig.GasLimit)
+	require.Equal(StarterFeeConfig.MinBaseFee, feeConfig.MinBaseFee)
+	require.Nil(template.Precompiles)
+	require.Nil(template.Airdrop)
+
+	invalidTemplates := map[string]string{
+		"unknown field":             `{"feeConfig": {"targetGass": 20000000}}`,
+		"trailing data":             `{} {}`,
+		"invalid fee config":        `{"feeConfig": {"minBlockGasCost": 2, "maxBlockGasCost": 1}}`,
+		"invalid admin":             `{"precompiles": {"admins": ["0x1234"], "txAllowList": true}}`,
+		"allow list without admins": `{"precompiles": {"nativeMinter": true}}`,
+		"invalid airdrop policy":    `{"airdrop": {"policy": "everyone"}}`,
+		"address without policy":    `{"airdrop": {"address": "0x098B69E43b1720Bd12378225519d74e5F3aD0eA5"}}`,
+		"policy without address":    `{"airdrop": {"policy": "address"}}`,
+		"invalid airdrop amount":    `{"airdrop": {"amount": "-1"}}`,
+	}
+	for name, templateJSON := range invalidTemplates {
+		_, err := parseEvmTemplate([]byte(templateJSON))
+		require.Error(err, name)
+	}
+}
+
+func TestEvmTemplateGetAirdropFlags(t *testing.T) {
+	require := require.New(t)
+
+	template := &EvmTemplate{}
+	flags := AirdropFlags{Amount: "5"}
+	require.Equal(flags, template.GetAirdropFlags(flags))
+
+	template.Airdrop = &EvmTemplateAirdrop{Policy: TemplateAirdropEwoq, Amount: "10"}
+	require.Equal(
+		AirdropFlags{Address: PrefundedEwoqAddress.Hex(), Amount: "10"},
+		template.GetAirdropFlags(AirdropFlags{}),
+	)
+	require.Equal(
+		AirdropFlags{Address: testAirdropAddress.Hex(), Amount: "10"},
+		template.GetAirdropFlags(AirdropFlags{Address: testAirdropAddress.Hex()}),
+	)
+	require.Equal(
+		AirdropFlags{Address: PrefundedEwoqAddress.Hex(), Amount: "5"},
+		template.GetAirdropFlags(flags),
+	)
+}
+
+func createTemplateTestGenesis(
+	t *testing.T,
+	template *EvmTemplate,
+	allowListFiles AllowListFiles,
+	airdrop AirdropFlags,
+) core.Genesis {
+	require := require.New(t)
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, prompts.NewMockPrompter(), nil)
+	require.NoError(os.MkdirAll(app.GetKeyDir(), constants.DefaultPerms755))
+
+	genesisTimestamp := time.Unix(1700000000, 0)
+	// no defaults are requested, so this fails if any step prompts
+	genesisBytes, _, err := createEvmGenesis(
+		app,
+		"testSubnet",
+		"v0.6.8",
+		35,
+		1234,
+		testToken,
+		&genesisTimestamp,
+		false,
+		true,
+		nil,
+		allowListFiles,
+		airdrop,
+		template,
+	)
+	require.NoError(err)
+	genesis := core.Genesis{}
+	require.NoError(json.Unmarshal(genesisBytes, &genesis))
+	return genesis
+}
+
+func TestCreateEvmGenesisWithTemplate(t *testing.T) {
+	require := setupTest(t)
+
+	template, err := LoadEvmTemplate(filepath.Join("templates", "local-permissioned.json"))
+	require.NoError(err)
+	genesis := createTemplateTestGenesis(t, template, AllowListFiles{}, AirdropFlags{})
+
+	require.Equal(StarterFeeConfig.GasLimit, genesis.Config.FeeConfig.GasLimit)
+	require.Equal(big.NewInt(15_000_000), genesis.Config.FeeConfig.TargetGas)
+	require.Equal(StarterFeeConfig.GasLimit.Uint64(), genesis.GasLimit)
+
+	require.Contains(genesis.Config.GenesisPrecompiles, warp.ConfigKey)
+	require.Contains(genesis.Config.GenesisPrecompiles, feemanager.ConfigKey)
+	require.NotContains(genesis.Config.GenesisPrecompiles, nativeminter.ConfigKey)
+	txAllowListConfig, ok := genesis.Config.GenesisPrecompiles[txallowlist.ConfigKey].(*txallowlist.Config)
+	require.True(ok)
+	require.Equal([]common.Address{PrefundedEwoqAddress}, txAllowListConfig.AdminAddresses)
+	deployerAllowListConfig, ok := genesis.Config.GenesisPrecompiles[deployerallowlist.ConfigKey].(*deployerallowlist.Config)
+	require.True(ok)
+	require.Equal([]common.Address{PrefundedEwoqAddress}, deployerAllowListConfig.AdminAddresses)
+
+	require.Len(genesis.Alloc, 1)
+	expectedAmount := new(big.Int).Mul(big.NewInt(1_000_000), oneAvax)
+	require.Equal(expectedAmount, genesis.Alloc[PrefundedEwoqAddress].Balance)
+}
+
+func TestCreateEvmGenesisTemplateOverriddenByFlags(t *testing.T) {
+	require := setupTest(t)
+
+	template, err := LoadEvmTemplate(filepath.Join("templates", "local-permissioned.json"))
+	require.NoError(err)
+	txAllowListFile := filepath.Join(t.TempDir(), "txAllowList.json")
+	require.NoError(os.WriteFile(
+		txAllowListFile,
+		[]byte(`{"admin": ["`+testAirdropAddress.Hex()+`"]}`),
+		constants.WriteReadReadPerms,
+	))
+	genesis := createTemplateTestGenesis(
+		t,
+		template,
+		AllowListFiles{TxAllowListFile: txAllowListFile},
+		AirdropFlags{Address: testAirdropAddress.Hex(), Amount: "5"},
+	)
+
+	txAllowListConfig, ok := genesis.Config.GenesisPrecompiles[txallowlist.ConfigKey].(*txallowlist.Config)
+	require.True(ok)
+	require.Equal([]common.Address{testAirdropAddress}, txAllowListConfig.AdminAddresses)
+	// precompiles not given by flags are kept from the template
+	deployerAllowListConfig, ok := genesis.Config.GenesisPrecompiles[deployerallowlist.ConfigKey].(*deployerallowlist.Config)
+	require.True(ok)
+	require.Equal([]common.Address{PrefundedEwoqAddress}, deployerAllowListConfig.AdminAddresses)
+
+	require.Len(genesis.Alloc, 1)
+	expectedAmount := new(big.Int).Mul(big.NewInt(5), oneAvax)
+	require.Equal(expectedAmount, genesis.Alloc[testAirdropAddress].Balance)
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package vm
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core"
+)
+
+// GenesisStats summarizes the chain ID, allocations, precompiles and fees of a Subnet-EVM genesis
+type GenesisStats struct {
+	ChainID        *big.Int
+	NumAllocations int
+	// TotalSupply is the sum of all allocation balances, in wei
+	TotalSupply *big.Int
+	// CustomAirdrop indicates that addresses other than ewoq are prefunded
+	CustomAirdrop bool
+	// Precompiles are the config keys of the precompiles enabled at genesis, sorted
+	Precompiles []string
+	FeeConfig   commontype.FeeConfig
+}
+
+// GetGenesisStats computes the stats of a Subnet-EVM [genesis]
+func GetGenesisStats(genesis core.Genesis) GenesisStats {
+	stats := GenesisStats{
+		NumAllocations: len(genesis.Alloc),
+		TotalSupply:    big.NewInt(0),
+		Precompiles:    []string{},
+	}
+	for address, account := range genesis.Alloc {
+		if account.Balance != nil {
+			stats.TotalSupply.Add(stats.TotalSupply, account.Balance)
+		}
+		if address != PrefundedEwoqAddress {
+			stats.CustomAirdrop = true
+		}
+	}
+	if genesis.Config != nil {
+		stats.ChainID = genesis.Config.ChainID
+		stats.FeeConfig = genesis.Config.FeeConfig
+		for precompileName := range genesis.Config.GenesisPrecompiles {
+			stats.Precompiles = append(stats.Precompiles, precompileName)
+		}
+	}
+	sort.Strings(stats.Precompiles)
+	return stats
+}
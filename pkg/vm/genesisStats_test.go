@@ -0,0 +1,81 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package vm
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/stretchr/testify/require"
+)
+
+const testStatsGenesis = `{
+  "config": {
+    "chainId": 99999,
+    "feeConfig": {
+      "gasLimit": 8000000,
+      "targetBlockRate": 2,
+      "minBaseFee": 25000000000,
+      "targetGas": 15000000,
+      "baseFeeChangeDenominator": 36,
+      "minBlockGasCost": 0,
+      "maxBlockGasCost": 1000000,
+      "blockGasCostStep": 200000
+    },
+    "txAllowListConfig": {
+      "blockTimestamp": 0,
+      "adminAddresses": ["0x8db97c7cece249c2b98bdc0226cc4c2a57bf52fc"]
+    },
+    "warpConfig": {
+      "blockTimestamp": 0,
+      "quorumNumerator": 67
+    }
+  },
+  "alloc": {
+    "8db97c7cece249c2b98bdc0226cc4c2a57bf52fc": {
+      "balance": "0xd3c21bcecceda1000000"
+    },
+    "0x0000000000000000000000000000000000000001": {
+      "balance": "0xde0b6b3a7640000"
+    }
+  },
+  "gasLimit": "0x7a1200",
+  "difficulty": "0x0"
+}`
+
+func TestGetGenesisStats(t *testing.T) {
+	require := require.New(t)
+	var genesis core.Genesis
+	require.NoError(json.Unmarshal([]byte(testStatsGenesis), &genesis))
+
+	stats := GetGenesisStats(genesis)
+	require.Equal(big.NewInt(99999), stats.ChainID)
+	require.Equal(2, stats.NumAllocations)
+	// 1M tokens for ewoq plus 1 token for the custom address
+	expectedSupply, ok := new(big.Int).SetString("1000001000000000000000000", 10)
+	require.True(ok)
+	require.Equal(expectedSupply, stats.TotalSupply)
+	require.True(stats.CustomAirdrop)
+	require.Equal([]string{txallowlist.ConfigKey, warp.ConfigKey}, stats.Precompiles)
+	require.Equal(big.NewInt(8000000), stats.FeeConfig.GasLimit)
+	require.Equal(big.NewInt(25000000000), stats.FeeConfig.MinBaseFee)
+}
+
+func TestGetGenesisStatsEwoqOnly(t *testing.T) {
+	require := require.New(t)
+	genesis := core.Genesis{
+		Alloc: core.GenesisAlloc{
+			PrefundedEwoqAddress: {Balance: big.NewInt(10)},
+		},
+	}
+	stats := GetGenesisStats(genesis)
+	require.Nil(stats.ChainID)
+	require.Equal(1, stats.NumAllocations)
+	require.Equal(big.NewInt(10), stats.TotalSupply)
+	require.False(stats.CustomAirdrop)
+	require.Empty(stats.Precompiles)
+}
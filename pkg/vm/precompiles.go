@@ -281,6 +281,43 @@ func addAddressToAllowed(
 	return allowListConfig
 }
 
+// configureAllowListsFromFiles sets the allow list precompiles that are given by file,
+// returning the names of the precompiles that were configured
+func configureAllowListsFromFiles(
+	config params.ChainConfig,
+	allowListFiles AllowListFiles,
+	subnetEvmVersion string,
+) (params.ChainConfig, []string, error) {
+	configured := []string{}
+	if allowListFiles.TxAllowListFile != "" {
+		allowListConfig, err := LoadAllowListFile(allowListFiles.TxAllowListFile, subnetEvmVersion)
+		if err != nil {
+			return config, nil, err
+		}
+		config.GenesisPrecompiles[txallowlist.ConfigKey] = &txallowlist.Config{
+			AllowListConfig: allowListConfig,
+			Upgrade: precompileconfig.Upgrade{
+				BlockTimestamp: subnetevmutils.NewUint64(0),
+			},
+		}
+		configured = append(configured, TxAllowList)
+	}
+	if allowListFiles.DeployerAllowListFile != "" {
+		allowListConfig, err := LoadAllowListFile(allowListFiles.DeployerAllowListFile, subnetEvmVersion)
+		if err != nil {
+			return config, nil, err
+		}
+		config.GenesisPrecompiles[deployerallowlist.ConfigKey] = &deployerallowlist.Config{
+			AllowListConfig: allowListConfig,
+			Upgrade: precompileconfig.Upgrade{
+				BlockTimestamp: subnetevmutils.NewUint64(0),
+			},
+		}
+		configured = append(configured, ContractAllowList)
+	}
+	return config, configured, nil
+}
+
 func getPrecompiles(
 	config params.ChainConfig,
 	app *application.Avalanche,
@@ -288,6 +325,7 @@ func getPrecompiles(
 	useDefaults bool,
 	useWarp bool,
 	subnetEvmVersion string,
+	allowListFiles AllowListFiles,
 ) (
 	params.ChainConfig,
 	statemachine.StateDirection,
@@ -298,6 +336,11 @@ func getPrecompiles(
 		config.GenesisPrecompiles[warp.ConfigKey] = &warpConfig
 	}
 
+	config, configuredFromFiles, err := configureAllowListsFromFiles(config, allowListFiles, subnetEvmVersion)
+	if err != nil {
+		return config, statemachine.Stop, err
+	}
+
 	if useDefaults {
 		return config, statemachine.Forward, nil
 	}
@@ -325,6 +368,10 @@ func getPrecompiles(
 			cancel,
 		}
 	}
+	// precompiles configured from files are not available for interactive configuration
+	remainingPrecompiles = utils.Filter(remainingPrecompiles, func(precompile string) bool {
+		return !utils.Belongs(configuredFromFiles, precompile)
+	})
 
 	for {
 		firstStr := "Advanced: Would you like to add a custom precompile to modify the EVM?"
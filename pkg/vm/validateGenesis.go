@@ -0,0 +1,43 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+)
+
+// RunGenesisValidationScript runs the executable [scriptPath] with [genesisBytes] on stdin.
+// If the script exits with non-zero status, an error with its stderr is returned
+func RunGenesisValidationScript(scriptPath string, genesisBytes []byte) error {
+	if !utils.IsExecutable(scriptPath) {
+		return fmt.Errorf("genesis validation script %s not found or not executable", scriptPath)
+	}
+	ux.Logger.PrintToUser("Validating genesis with %s", scriptPath)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(scriptPath)
+	cmd.Stdin = bytes.NewReader(genesisBytes)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("genesis validation script %s failed with exit code %d: %s",
+				scriptPath,
+				exitErr.ExitCode(),
+				strings.TrimSpace(stderr.String()),
+			)
+		}
+		return fmt.Errorf("failed to run genesis validation script %s: %w", scriptPath, err)
+	}
+	if output := strings.TrimSpace(stdout.String()); output != "" {
+		ux.Logger.PrintToUser(output)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package vm
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func writeValidationScript(t *testing.T, content string) string {
+	scriptPath := filepath.Join(t.TempDir(), "validate.sh")
+	require.NoError(t, os.WriteFile(scriptPath, []byte("#!/bin/sh\n"+content), 0o700)) //nolint:gosec
+	return scriptPath
+}
+
+func TestRunGenesisValidationScript(t *testing.T) {
+	ux.NewUserLog(logging.NoLog{}, io.Discard)
+	genesis := []byte(`{"config": {"chainId": 12345}}`)
+
+	t.Run("passing script", func(t *testing.T) {
+		// only passes if the genesis is received on stdin
+		scriptPath := writeValidationScript(t, `grep -q '"chainId": 12345' || exit 1`)
+		require.NoError(t, RunGenesisValidationScript(scriptPath, genesis))
+	})
+
+	t.Run("failing script", func(t *testing.T) {
+		scriptPath := writeValidationScript(t, "cat > /dev/null\necho 'total supply exceeds cap' >&2\nexit 3")
+		err := RunGenesisValidationScript(scriptPath, genesis)
+		require.ErrorContains(t, err, "exit code 3")
+		require.ErrorContains(t, err, "total supply exceeds cap")
+	})
+
+	t.Run("missing script", func(t *testing.T) {
+		err := RunGenesisValidationScript(filepath.Join(t.TempDir(), "missing.sh"), genesis)
+		require.ErrorContains(t, err, "not found or not executable")
+	})
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// VersionConstraint is a set of semver ranges that a version must all satisfy
+type VersionConstraint struct {
+	raw    string
+	ranges []versionRange
+}
+
+// versionRange is the range of versions between [min] and [max]. An empty bound is unbounded
+type versionRange struct {
+	min          string
+	minInclusive bool
+	max          string
+	maxInclusive bool
+}
+
+// ParseVersionConstraint parses a space or comma separated list of version constraints, all of
+// which must be satisfied. Supported forms are exact versions (v0.6.2), wildcards (v0.6.x, v0.6),
+// tilde ranges (~v0.6.2, newer patches), caret ranges (^v1.2.0, newer compatible versions) and
+// comparisons (>=v0.6.0, >v0.6.0, <=v0.7.0, <v0.7.0). Partial versions in comparisons stand
+// for all of their versions, so <=v0.6 includes v0.6.5 and >v0.6 excludes it
+func ParseVersionConstraint(constraint string) (VersionConstraint, error) {
+	c := VersionConstraint{raw: constraint}
+	terms := strings.FieldsFunc(constraint, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(terms) == 0 {
+		return c, fmt.Errorf("invalid version constraint %q: empty", constraint)
+	}
+	for _, term := range terms {
+		r, err := parseVersionRange(term)
+		if err != nil {
+			return c, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+		}
+		c.ranges = append(c.ranges, r)
+	}
+	return c, nil
+}
+
+func (c VersionConstraint) String() string {
+	return c.raw
+}
+
+// Matches returns true if [version] is a valid release version that satisfies the constraint.
+// Pre-release versions never match
+func (c VersionConstraint) Matches(version string) bool {
+	if !semver.IsValid(version) || semver.Prerelease(version) != "" {
+		return false
+	}
+	for _, r := range c.ranges {
+		if !r.contains(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewestMatchingVersion returns the newest version of [versions] that satisfies [c]
+func NewestMatchingVersion(versions []string, c VersionConstraint) (string, error) {
+	newest := ""
+	for _, version := range versions {
+		if c.Matches(version) && (newest == "" || semver.Compare(version, newest) > 0) {
+			newest = version
+		}
+	}
+	if newest == "" {
+		return "", fmt.Errorf("no released version matches constraint %q", c)
+	}
+	return newest, nil
+}
+
+func (r versionRange) contains(version string) bool {
+	if r.min != "" {
+		cmp := semver.Compare(version, r.min)
+		if cmp < 0 || (cmp == 0 && !r.minInclusive) {
+			return false
+		}
+	}
+	if r.max != "" {
+		cmp := semver.Compare(version, r.max)
+		if cmp > 0 || (cmp == 0 && !r.maxInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseVersionRange(term string) (versionRange, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(term, prefix) {
+			op = prefix
+			break
+		}
+	}
+	parts, err := parseConstraintVersion(strings.TrimPrefix(term, op))
+	if err != nil {
+		return versionRange{}, err
+	}
+	lower := versionString(parts)
+	switch op {
+	case "", "=":
+		switch len(parts) {
+		case 0:
+			return versionRange{}, nil
+		case 3:
+			return versionRange{min: lower, minInclusive: true, max: lower, maxInclusive: true}, nil
+		}
+		return versionRange{min: lower, minInclusive: true, max: nextVersion(parts, len(parts)-1)}, nil
+	case "~":
+		if len(parts) == 0 {
+			return versionRange{}, fmt.Errorf("%q needs a version", term)
+		}
+		return versionRange{min: lower, minInclusive: true, max: nextVersion(parts, min(len(parts)-1, 1))}, nil
+	case "^":
+		if len(parts) == 0 {
+			return versionRange{}, fmt.Errorf("%q needs a version", term)
+		}
+		// the first non zero component can't change, as in npm
+		bumped := len(parts) - 1
+		for i, part := range parts {
+			if part != 0 {
+				bumped = i
+				break
+			}
+		}
+		return versionRange{min: lower, minInclusive: true, max: nextVersion(parts, bumped)}, nil
+	default:
+		if len(parts) == 0 {
+			return versionRange{}, fmt.Errorf("%q needs a version", term)
+		}
+		// a partial version stands for all of its versions, so > and <= compare against the
+		// last of them, while >= and < compare against the first
+		partial := len(parts) < 3
+		switch op {
+		case ">=":
+			return versionRange{min: lower, minInclusive: true}, nil
+		case ">":
+			if partial {
+				return versionRange{min: nextVersion(parts, len(parts)-1), minInclusive: true}, nil
+			}
+			return versionRange{min: lower}, nil
+		case "<=":
+			if partial {
+				return versionRange{max: nextVersion(parts, len(parts)-1)}, nil
+			}
+			return versionRange{max: lower, maxInclusive: true}, nil
+		default:
+			return versionRange{max: lower}, nil
+		}
+	}
+}
+
+// parseConstraintVersion parses a version with optional v prefix, and up to 3 numeric components,
+// returning the components that are given. Missing components and x, X or * are wildcards
+func parseConstraintVersion(version string) ([]int, error) {
+	if version == "" {
+		return nil, fmt.Errorf("missing version")
+	}
+	parts := []int{}
+	wildcard := false
+	fields := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(fields) > 3 {
+		return nil, fmt.Errorf("version %q has more than 3 components", version)
+	}
+	for _, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return nil, fmt.Errorf("version %q has a component after a wildcard", version)
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("version %q has an invalid component %q", version, field)
+		}
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+// versionString returns the version with components [parts], missing ones being 0
+func versionString(parts []int) string {
+	full := [3]int{}
+	copy(full[:], parts)
+	return fmt.Sprintf("v%d.%d.%d", full[0], full[1], full[2])
+}
+
+// nextVersion returns the lowest version with a greater component [i] than [parts]
+func nextVersion(parts []int, i int) string {
+	next := make([]int, i+1)
+	copy(next, parts)
+	next[i]++
+	return versionString(next)
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+var testReleases = []string{
+	"v0.7.0-rc.1",
+	"v0.6.9-rc.0",
+	"v0.6.8",
+	"v0.6.2",
+	"v0.6.10",
+	"v0.5.11",
+	"v0.5.6",
+	"v1.0.1",
+	"v1.2.0",
+}
+
+func TestNewestMatchingVersion(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{constraint: "v0.6.x", expected: "v0.6.10"},
+		{constraint: "v0.6", expected: "v0.6.10"},
+		{constraint: "v0.6.*", expected: "v0.6.10"},
+		{constraint: "0.5.x", expected: "v0.5.11"},
+		{constraint: "v0.x", expected: "v0.6.10"},
+		{constraint: "x", expected: "v1.2.0"},
+		{constraint: "v0.6.8", expected: "v0.6.8"},
+		{constraint: "=v0.5.6", expected: "v0.5.6"},
+		{constraint: "~v0.6.2", expected: "v0.6.10"},
+		{constraint: "~v1", expected: "v1.2.0"},
+		{constraint: "^v1.0.0", expected: "v1.2.0"},
+		{constraint: "^v0.5.6", expected: "v0.5.11"},
+		{constraint: ">=v0.6.0,<v0.6.9", expected: "v0.6.8"},
+		{constraint: ">=v0.5.0 <v0.6", expected: "v0.5.11"},
+		{constraint: "<=v0.6.8", expected: "v0.6.8"},
+		{constraint: ">v0.6.10 <v1.1", expected: "v1.0.1"},
+		// partial versions include all of their versions
+		{constraint: "<=v0.6.x", expected: "v0.6.10"},
+		{constraint: "<=v0.5", expected: "v0.5.11"},
+		{constraint: "<=v0", expected: "v0.6.10"},
+		{constraint: ">v0.5", expected: "v1.2.0"},
+		{constraint: ">v0.6.x <v1.1", expected: "v1.0.1"},
+		{constraint: ">=v0.6 <v0.6.9", expected: "v0.6.8"},
+		{constraint: "<v0.6 >=v0.5.7", expected: "v0.5.11"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			require := require.New(t)
+			constraint, err := ParseVersionConstraint(tt.constraint)
+			require.NoError(err)
+			version, err := NewestMatchingVersion(testReleases, constraint)
+			require.NoError(err)
+			require.Equal(tt.expected, version)
+		})
+	}
+}
+
+func TestNewestMatchingVersionNoMatch(t *testing.T) {
+	require := require.New(t)
+
+	for _, c := range []string{"v0.4.x", "v0.6.9", "~v0.7.0", ">v1.2.0", ">=v0.6.9,<v0.6.10", ">v1", ">v0.6 <v1.0.0", "<v0.5"} {
+		constraint, err := ParseVersionConstraint(c)
+		require.NoError(err)
+		_, err = NewestMatchingVersion(testReleases, constraint)
+		require.ErrorContains(err, "no released version matches", c)
+	}
+}
+
+func TestVersionConstraintPartialBounds(t *testing.T) {
+	require := require.New(t)
+	matches := func(constraint string, version string) bool {
+		c, err := ParseVersionConstraint(constraint)
+		require.NoError(err)
+		return c.Matches(version)
+	}
+	// <= and > compare against the last version of a partial version
+	require.True(matches("<=v0.6.x", "v0.6.5"))
+	require.True(matches("<=v0.6", "v0.6.0"))
+	require.False(matches("<=v0.6", "v0.7.0"))
+	require.False(matches(">v0.6", "v0.6.1"))
+	require.False(matches(">v0.6.x", "v0.6.99"))
+	require.True(matches(">v0.6", "v0.7.0"))
+	// >= and < compare against its first version
+	require.True(matches(">=v0.6", "v0.6.0"))
+	require.False(matches(">=v0.6", "v0.5.11"))
+	require.False(matches("<v0.6", "v0.6.0"))
+	require.True(matches("<v0.6", "v0.5.11"))
+	// full versions are compared as is
+	require.True(matches("<=v0.6.5", "v0.6.5"))
+	require.False(matches("<=v0.6.5", "v0.6.6"))
+	require.True(matches(">v0.6.0", "v0.6.1"))
+}
+
+func TestParseVersionConstraintInvalid(t *testing.T) {
+	require := require.New(t)
+
+	for _, c := range []string{"", " , ", "latest", "v0.6.2.1", "vx.6", "v0.-1", "v0.6.2-rc.1", ">=", "~x", "!v0.6.0"} {
+		_, err := ParseVersionConstraint(c)
+		require.Error(err, c)
+	}
+}
+
+func TestGetVMVersionWithConstraint(t *testing.T) {
+	require := setupTest(t)
+	app := application.New()
+	mockDownloader := &mocks.Downloader{}
+	mockDownloader.On("GetAllReleasesForRepo", constants.AvaLabsOrg, constants.SubnetEVMRepoName).Return(testReleases, nil)
+	app.Downloader = mockDownloader
+
+	version, err := GetVMVersion(app, "Subnet-EVM", constants.SubnetEVMRepoName, "", "~v0.6.2")
+	require.NoError(err)
+	require.Equal("v0.6.10", version)
+
+	_, err = GetVMVersion(app, "Subnet-EVM", constants.SubnetEVMRepoName, "", "v0.8.x")
+	require.ErrorContains(err, "no released version matches")
+}
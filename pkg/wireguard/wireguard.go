@@ -0,0 +1,164 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wireguard
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/netip"
+	"strings"
+	"text/template"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+const (
+	// MeshMode is the value of node create --mesh that sets up a Wireguard mesh
+	MeshMode = "wireguard"
+	// InterfaceName is the name of the Wireguard interface on the nodes
+	InterfaceName = "wg0"
+	// ListenPort is the UDP port the nodes listen on for Wireguard traffic
+	ListenPort = 51820
+	// persistentKeepalive keeps NAT mappings of the peers alive, in seconds
+	persistentKeepalive = 25
+)
+
+// MeshPrefix is the private network the mesh addresses are taken from
+var MeshPrefix = netip.MustParsePrefix("10.99.0.0/24")
+
+// Member is a node of the mesh
+type Member struct {
+	// Name identifies the node, e.g. its cloud ID
+	Name string
+	// PublicIP is the IP the other members reach the node at
+	PublicIP string
+	// MeshIP is the address of the node inside the mesh
+	MeshIP string
+	// PrivateKey and PublicKey are the base64 encoded Wireguard keys of the node
+	PrivateKey string
+	PublicKey  string
+}
+
+// GenerateKeyPair generates a Wireguard private key, and its public key, base64 encoded
+func GenerateKeyPair() (string, string, error) {
+	privateKey := make([]byte, curve25519.ScalarSize)
+	if _, err := rand.Read(privateKey); err != nil {
+		return "", "", err
+	}
+	// clamp as wg genkey does
+	privateKey[0] &= 248
+	privateKey[31] = (privateKey[31] & 127) | 64
+	encodedPrivateKey := base64.StdEncoding.EncodeToString(privateKey)
+	publicKey, err := GetPublicKey(encodedPrivateKey)
+	if err != nil {
+		return "", "", err
+	}
+	return encodedPrivateKey, publicKey, nil
+}
+
+// GetPublicKey returns the base64 encoded Wireguard public key of base64 encoded [privateKey]
+func GetPublicKey(privateKey string) (string, error) {
+	privateKeyBytes, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid wireguard private key: %w", err)
+	}
+	publicKey, err := curve25519.X25519(privateKeyBytes, curve25519.Basepoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid wireguard private key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(publicKey), nil
+}
+
+// GetConfigPrivateKey returns the private key of the interface of wg-quick [config]
+func GetConfigPrivateKey(config []byte) (string, error) {
+	for _, line := range strings.Split(string(config), "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if found && strings.TrimSpace(key) == "PrivateKey" {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	return "", fmt.Errorf("wireguard config has no private key")
+}
+
+// NewMesh returns the members of a mesh among the nodes of [publicIPs], by name, given in [names] order.
+// Each member gets the next address of MeshPrefix, and a new key pair
+func NewMesh(names []string, publicIPs map[string]string) ([]Member, error) {
+	return ExtendMesh(nil, names, publicIPs)
+}
+
+// ExtendMesh returns [members] followed by new members for the nodes of [publicIPs] given in [names]
+// order. Each new member gets the lowest address of MeshPrefix not used by [members], and a new key pair
+func ExtendMesh(members []Member, names []string, publicIPs map[string]string) ([]Member, error) {
+	// the network and broadcast addresses are not assigned
+	if maxMembers := 1<<(MeshPrefix.Addr().BitLen()-MeshPrefix.Bits()) - 2; len(members)+len(names) > maxMembers {
+		return nil, fmt.Errorf("a wireguard mesh supports at most %d nodes, got %d", maxMembers, len(members)+len(names))
+	}
+	usedMeshIPs := map[string]bool{}
+	for _, member := range members {
+		usedMeshIPs[member.MeshIP] = true
+	}
+	members = append([]Member{}, members...)
+	meshIP := MeshPrefix.Addr()
+	for _, name := range names {
+		publicIP, ok := publicIPs[name]
+		if !ok || publicIP == "" {
+			return nil, fmt.Errorf("node %s has no public IP", name)
+		}
+		meshIP = meshIP.Next()
+		for usedMeshIPs[meshIP.String()] {
+			meshIP = meshIP.Next()
+		}
+		privateKey, publicKey, err := GenerateKeyPair()
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, Member{
+			Name:       name,
+			PublicIP:   publicIP,
+			MeshIP:     meshIP.String(),
+			PrivateKey: privateKey,
+			PublicKey:  publicKey,
+		})
+	}
+	return members, nil
+}
+
+var configTemplate = template.Must(template.New("wireguard").Parse(`[Interface]
+# {{ .Self.Name }}
+Address = {{ .Self.MeshIP }}/{{ .PrefixBits }}
+ListenPort = {{ .ListenPort }}
+PrivateKey = {{ .Self.PrivateKey }}
+{{- range .Peers }}
+
+[Peer]
+# {{ .Name }}
+PublicKey = {{ .PublicKey }}
+Endpoint = {{ .PublicIP }}:{{ $.ListenPort }}
+AllowedIPs = {{ .MeshIP }}/32
+PersistentKeepalive = {{ $.PersistentKeepalive }}
+{{- end }}
+`))
+
+// RenderConfig renders the wg-quick config of member [self] of [members]. Every other member is a
+// peer, only allowed to send traffic from its own mesh address
+func RenderConfig(self Member, members []Member) ([]byte, error) {
+	peers := []Member{}
+	for _, member := range members {
+		if member.Name != self.Name {
+			peers = append(peers, member)
+		}
+	}
+	var config bytes.Buffer
+	if err := configTemplate.Execute(&config, map[string]interface{}{
+		"Self":                self,
+		"Peers":               peers,
+		"PrefixBits":          MeshPrefix.Bits(),
+		"ListenPort":          ListenPort,
+		"PersistentKeepalive": persistentKeepalive,
+	}); err != nil {
+		return nil, err
+	}
+	return config.Bytes(), nil
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package wireguard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	require := require.New(t)
+	privateKey, publicKey, err := GenerateKeyPair()
+	require.NoError(err)
+	privateKeyBytes, err := base64.StdEncoding.DecodeString(privateKey)
+	require.NoError(err)
+	require.Len(privateKeyBytes, curve25519.ScalarSize)
+	expectedPublicKey, err := curve25519.X25519(privateKeyBytes, curve25519.Basepoint)
+	require.NoError(err)
+	require.Equal(base64.StdEncoding.EncodeToString(expectedPublicKey), publicKey)
+
+	otherPrivateKey, _, err := GenerateKeyPair()
+	require.NoError(err)
+	require.NotEqual(privateKey, otherPrivateKey)
+}
+
+func TestNewMesh(t *testing.T) {
+	require := require.New(t)
+	publicIPs := map[string]string{"node0": "1.1.1.1", "node1": "2.2.2.2", "node2": "3.3.3.3"}
+	members, err := NewMesh([]string{"node2", "node0", "node1"}, publicIPs)
+	require.NoError(err)
+	require.Len(members, 3)
+	require.Equal("node2", members[0].Name)
+	require.Equal("3.3.3.3", members[0].PublicIP)
+	require.Equal("10.99.0.1", members[0].MeshIP)
+	require.Equal("node0", members[1].Name)
+	require.Equal("10.99.0.2", members[1].MeshIP)
+	require.Equal("node1", members[2].Name)
+	require.Equal("10.99.0.3", members[2].MeshIP)
+	require.NotEqual(members[0].PublicKey, members[1].PublicKey)
+
+	_, err = NewMesh([]string{"node0", "node3"}, publicIPs)
+	require.ErrorContains(err, "node node3 has no public IP")
+
+	names := []string{}
+	manyPublicIPs := map[string]string{}
+	for i := 0; i < 255; i++ {
+		name := fmt.Sprintf("node%d", i)
+		names = append(names, name)
+		manyPublicIPs[name] = fmt.Sprintf("1.1.%d.%d", i/256, i%256)
+	}
+	_, err = NewMesh(names, manyPublicIPs)
+	require.ErrorContains(err, "at most 254 nodes")
+	members, err = NewMesh(names[:254], manyPublicIPs)
+	require.NoError(err)
+	require.Equal("10.99.0.254", members[253].MeshIP)
+}
+
+func TestExtendMesh(t *testing.T) {
+	require := require.New(t)
+	members := []Member{
+		{Name: "node0", PublicIP: "1.1.1.1", MeshIP: "10.99.0.1", PrivateKey: "priv0", PublicKey: "pub0"},
+		{Name: "node2", PublicIP: "3.3.3.3", MeshIP: "10.99.0.3", PrivateKey: "priv2", PublicKey: "pub2"},
+	}
+	publicIPs := map[string]string{"node1": "2.2.2.2", "node3": "4.4.4.4"}
+	extended, err := ExtendMesh(members, []string{"node1", "node3"}, publicIPs)
+	require.NoError(err)
+	require.Len(extended, 4)
+	// existing members are kept as they are
+	require.Equal(members, extended[:2])
+	require.Equal("node1", extended[2].Name)
+	require.Equal("2.2.2.2", extended[2].PublicIP)
+	require.Equal("10.99.0.2", extended[2].MeshIP)
+	require.Equal("node3", extended[3].Name)
+	require.Equal("10.99.0.4", extended[3].MeshIP)
+	require.NotEmpty(extended[3].PrivateKey)
+
+	names := []string{}
+	manyPublicIPs := map[string]string{}
+	for i := 0; i < 253; i++ {
+		name := fmt.Sprintf("new%d", i)
+		names = append(names, name)
+		manyPublicIPs[name] = fmt.Sprintf("1.1.%d.%d", i/256, i%256)
+	}
+	_, err = ExtendMesh(members, names, manyPublicIPs)
+	require.ErrorContains(err, "at most 254 nodes, got 255")
+	extended, err = ExtendMesh(members, names[:252], manyPublicIPs)
+	require.NoError(err)
+	require.Equal("10.99.0.254", extended[253].MeshIP)
+}
+
+func TestGetConfigPrivateKey(t *testing.T) {
+	require := require.New(t)
+	privateKey, publicKey, err := GenerateKeyPair()
+	require.NoError(err)
+	members := []Member{
+		{Name: "node0", PublicIP: "1.1.1.1", MeshIP: "10.99.0.1", PrivateKey: privateKey, PublicKey: publicKey},
+		{Name: "node1", PublicIP: "2.2.2.2", MeshIP: "10.99.0.2", PrivateKey: "priv1", PublicKey: "pub1"},
+	}
+	config, err := RenderConfig(members[0], members)
+	require.NoError(err)
+	configPrivateKey, err := GetConfigPrivateKey(config)
+	require.NoError(err)
+	require.Equal(privateKey, configPrivateKey)
+	configPublicKey, err := GetPublicKey(configPrivateKey)
+	require.NoError(err)
+	require.Equal(publicKey, configPublicKey)
+
+	_, err = GetConfigPrivateKey([]byte("[Interface]\nAddress = 10.99.0.1/24\n"))
+	require.ErrorContains(err, "no private key")
+	_, err = GetPublicKey("not base64!")
+	require.ErrorContains(err, "invalid wireguard private key")
+}
+
+func TestRenderConfig(t *testing.T) {
+	require := require.New(t)
+	members := []Member{
+		{Name: "node0", PublicIP: "1.1.1.1", MeshIP: "10.99.0.1", PrivateKey: "priv0", PublicKey: "pub0"},
+		{Name: "node1", PublicIP: "2.2.2.2", MeshIP: "10.99.0.2", PrivateKey: "priv1", PublicKey: "pub1"},
+		{Name: "node2", PublicIP: "3.3.3.3", MeshIP: "10.99.0.3", PrivateKey: "priv2", PublicKey: "pub2"},
+	}
+	config, err := RenderConfig(members[1], members)
+	require.NoError(err)
+	require.Equal(`[Interface]
+# node1
+Address = 10.99.0.2/24
+ListenPort = 51820
+PrivateKey = priv1
+
+[Peer]
+# node0
+PublicKey = pub0
+Endpoint = 1.1.1.1:51820
+AllowedIPs = 10.99.0.1/32
+PersistentKeepalive = 25
+
+[Peer]
+# node2
+PublicKey = pub2
+Endpoint = 3.3.3.3:51820
+AllowedIPs = 10.99.0.3/32
+PersistentKeepalive = 25
+`, string(config))
+
+	// a single node mesh has no peers
+	config, err = RenderConfig(members[0], members[:1])
+	require.NoError(err)
+	require.NotContains(string(config), "[Peer]")
+	// other members private keys are never rendered
+	for _, member := range members {
+		config, err := RenderConfig(member, members)
+		require.NoError(err)
+		for _, other := range members {
+			require.Equal(other.Name == member.Name, strings.Contains(string(config), other.PrivateKey))
+		}
+	}
+}